@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -26,13 +28,15 @@ import (
 	socialpayUsecase "github.com/socialpay/socialpay/src/pkg/socialpayapi/usecase"
 
 	// Payment Processors
-	awashProcessor "github.com/socialpay/socialpay/src/pkg/shared/payment/awash"
-	cbeProcessor "github.com/socialpay/socialpay/src/pkg/shared/payment/cbe"
-	cybersourceProcessor "github.com/socialpay/socialpay/src/pkg/shared/payment/cybersource"
-	etswitch "github.com/socialpay/socialpay/src/pkg/shared/payment/etswitch"
-	kachaProcessor "github.com/socialpay/socialpay/src/pkg/shared/payment/kacha"
-	mpesaProcessor "github.com/socialpay/socialpay/src/pkg/shared/payment/mpesa"
-	telebirrProcessor "github.com/socialpay/socialpay/src/pkg/shared/payment/telebirr"
+	_ "github.com/socialpay/socialpay/src/pkg/shared/payment/awash"
+	_ "github.com/socialpay/socialpay/src/pkg/shared/payment/cbe"
+	_ "github.com/socialpay/socialpay/src/pkg/shared/payment/cybersource"
+	_ "github.com/socialpay/socialpay/src/pkg/shared/payment/etswitch"
+	_ "github.com/socialpay/socialpay/src/pkg/shared/payment/kacha"
+	_ "github.com/socialpay/socialpay/src/pkg/shared/payment/mpesa"
+	paymentRegistry "github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	simulatorProcessor "github.com/socialpay/socialpay/src/pkg/shared/payment/simulator"
+	_ "github.com/socialpay/socialpay/src/pkg/shared/payment/telebirr"
 
 	// Transaction Types
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
@@ -48,8 +52,11 @@ import (
 	apikeyUsecase "github.com/socialpay/socialpay/src/pkg/apikey_mgmt/usecase"
 
 	// [V2 MERCHANT]
+	etradeTINChecker "github.com/socialpay/socialpay/src/pkg/org/adapter/gateway/tin_checker/etrade"
 	v2MerchantHandler "github.com/socialpay/socialpay/src/pkg/v2_merchant/adapter/controller/gin"
 	v2MerchantRepo "github.com/socialpay/socialpay/src/pkg/v2_merchant/adapter/gateway/repo/sqlc"
+	v2MerchantEtradeRegistry "github.com/socialpay/socialpay/src/pkg/v2_merchant/adapter/gateway/tin_registry/etrade"
+	v2MerchantStubRegistry "github.com/socialpay/socialpay/src/pkg/v2_merchant/adapter/gateway/tin_registry/stub"
 
 	// v2MerchantSeeder "github.com/socialpay/socialpay/src/pkg/v2_merchant/seeder"
 	v2MerchantUsecase "github.com/socialpay/socialpay/src/pkg/v2_merchant/usecase"
@@ -82,6 +89,81 @@ import (
 	qrRepo "github.com/socialpay/socialpay/src/pkg/qr/core/repository"
 	qrUsecase "github.com/socialpay/socialpay/src/pkg/qr/usecase"
 
+	// [CHECKOUT BRANDING]
+	brandingHandler "github.com/socialpay/socialpay/src/pkg/checkout_branding/adapter/controller/gin"
+	brandingRepo "github.com/socialpay/socialpay/src/pkg/checkout_branding/core/repository"
+	brandingUsecase "github.com/socialpay/socialpay/src/pkg/checkout_branding/usecase"
+
+	// [CUSTOMER VAULT]
+	customerVaultHandler "github.com/socialpay/socialpay/src/pkg/customer_vault/adapter/controller/gin"
+	customerVaultRepo "github.com/socialpay/socialpay/src/pkg/customer_vault/core/repository"
+	customerVaultUsecase "github.com/socialpay/socialpay/src/pkg/customer_vault/usecase"
+	processorAccountHandler "github.com/socialpay/socialpay/src/pkg/processor_account/adapter/controller/gin"
+	processorAccountRepo "github.com/socialpay/socialpay/src/pkg/processor_account/core/repository"
+	processorAccountUsecase "github.com/socialpay/socialpay/src/pkg/processor_account/usecase"
+	processorHealthHandler "github.com/socialpay/socialpay/src/pkg/processor_health/adapter/controller/gin"
+	processorHealthRepo "github.com/socialpay/socialpay/src/pkg/processor_health/core/repository"
+	processorHealthUsecase "github.com/socialpay/socialpay/src/pkg/processor_health/usecase"
+	reconciliationHandler "github.com/socialpay/socialpay/src/pkg/reconciliation/adapter/controller/gin"
+	reconciliationRepo "github.com/socialpay/socialpay/src/pkg/reconciliation/core/repository"
+	reconciliationUsecase "github.com/socialpay/socialpay/src/pkg/reconciliation/usecase"
+
+	// [FX]
+	fxHandler "github.com/socialpay/socialpay/src/pkg/fx/adapter/controller/gin"
+	fxRepo "github.com/socialpay/socialpay/src/pkg/fx/core/repository"
+	fxUsecase "github.com/socialpay/socialpay/src/pkg/fx/usecase"
+
+	// [WITHDRAWAL APPROVAL]
+	withdrawalApprovalHandler "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/adapter/controller/gin"
+	withdrawalApprovalRepo "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/repository"
+	withdrawalApprovalUsecase "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/usecase"
+
+	// [RISK]
+	riskHandler "github.com/socialpay/socialpay/src/pkg/risk/adapter/controller/gin"
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	riskRepo "github.com/socialpay/socialpay/src/pkg/risk/core/repository"
+	riskUsecase "github.com/socialpay/socialpay/src/pkg/risk/usecase"
+
+	// [ACCESS LIST]
+	accessListHandler "github.com/socialpay/socialpay/src/pkg/access_list/adapter/controller/gin"
+	accessListRepo "github.com/socialpay/socialpay/src/pkg/access_list/core/repository"
+	accessListUsecase "github.com/socialpay/socialpay/src/pkg/access_list/usecase"
+
+	// [LIMITS]
+	limitsHandler "github.com/socialpay/socialpay/src/pkg/limits/adapter/controller/gin"
+	limitsRepo "github.com/socialpay/socialpay/src/pkg/limits/core/repository"
+	limitsUsecase "github.com/socialpay/socialpay/src/pkg/limits/usecase"
+
+	// [KYC]
+	kycHandler "github.com/socialpay/socialpay/src/pkg/kyc/adapter/controller/gin"
+	kycRepo "github.com/socialpay/socialpay/src/pkg/kyc/core/repository"
+	kycUsecase "github.com/socialpay/socialpay/src/pkg/kyc/usecase"
+
+	// [RESERVE]
+	reserveHandler "github.com/socialpay/socialpay/src/pkg/reserve/adapter/controller/gin"
+	reserveRepo "github.com/socialpay/socialpay/src/pkg/reserve/core/repository"
+	reserveUsecase "github.com/socialpay/socialpay/src/pkg/reserve/usecase"
+
+	// [DISPUTE]
+	disputeHandler "github.com/socialpay/socialpay/src/pkg/dispute/adapter/controller/gin"
+	disputeRepo "github.com/socialpay/socialpay/src/pkg/dispute/core/repository"
+	disputeUsecase "github.com/socialpay/socialpay/src/pkg/dispute/usecase"
+
+	// [WALLET ADJUSTMENT]
+	walletAdjustmentHandler "github.com/socialpay/socialpay/src/pkg/wallet_adjustment/adapter/controller/gin"
+	walletAdjustmentRepo "github.com/socialpay/socialpay/src/pkg/wallet_adjustment/core/repository"
+	walletAdjustmentUsecase "github.com/socialpay/socialpay/src/pkg/wallet_adjustment/usecase"
+
+	// [BENEFICIARY]
+	beneficiaryHandler "github.com/socialpay/socialpay/src/pkg/beneficiary/adapter/controller/gin"
+	beneficiaryRepo "github.com/socialpay/socialpay/src/pkg/beneficiary/core/repository"
+	beneficiaryUsecase "github.com/socialpay/socialpay/src/pkg/beneficiary/usecase"
+
+	// [PAYMENT LINK]
+	paymentLinkHandler "github.com/socialpay/socialpay/src/pkg/payment_link/adapter/controller/gin"
+	paymentLinkRepo "github.com/socialpay/socialpay/src/pkg/payment_link/core/repository"
+	paymentLinkUsecase "github.com/socialpay/socialpay/src/pkg/payment_link/usecase"
+
 	// Add this import
 	//"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -404,6 +486,31 @@ func main() {
 	_erpUsecase := erpUsecase.NewERPUseCase(_erpRepo)
 	_erpHandler := erpHandler.NewERPHandler(authv2ServiceInstance, _erpUsecase, middlewareProvider.RBAC)
 	_erpHandler.RegisterRoutes(v2)
+
+	// [CUSTOMER VAULT]
+	_customerVaultRepo := customerVaultRepo.NewCustomerRepository(db)
+	_customerVaultUseCase := customerVaultUsecase.NewCustomerVaultUseCase(_customerVaultRepo, _erpRepo)
+	_customerVaultHandler := customerVaultHandler.NewHandler(_customerVaultUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_customerVaultHandler.RegisterRouter(v2)
+
+	// [PROCESSOR ACCOUNTS]
+	// Merchant credentials are sealed with a base64 encoded 32 byte key; without it merchants cannot bring their own
+	processorCredentialsKey, err := base64.StdEncoding.DecodeString(os.Getenv("PROCESSOR_CREDENTIALS_KEY"))
+	if err != nil {
+		log.Printf("Invalid PROCESSOR_CREDENTIALS_KEY, merchant processor credentials disabled: %v", err)
+		processorCredentialsKey = nil
+	}
+	_processorAccountRepo := processorAccountRepo.NewProcessorAccountRepository(db)
+	_processorAccountUseCase := processorAccountUsecase.NewProcessorAccountUseCase(_processorAccountRepo, processorCredentialsKey)
+	_processorAccountHandler := processorAccountHandler.NewHandler(_processorAccountUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_processorAccountHandler.RegisterRouter(v2)
+
+	// [PROCESSOR HEALTH]
+	_processorHealthRepo := processorHealthRepo.NewOverrideRepository(db)
+	_processorHealthUseCase := processorHealthUsecase.NewHealthUseCase(_processorHealthRepo, processorHealthUsecase.BreakerConfig{})
+	_processorHealthHandler := processorHealthHandler.NewHandler(_processorHealthUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_processorHealthHandler.RegisterRouter(v2)
+
 	// Initialize cloudinary
 	_cloudinaryInstance, err := utils.InitCloudinary()
 	if err != nil {
@@ -424,6 +531,11 @@ func main() {
 	_hostedPaymentRepo := transactionRepo.NewHostedPaymentRepository(db)
 	_transactionUseCase := transactionUsecase.NewTransactionUsecase(
 		_transactionRepo,
+		_hostedPaymentRepo,
+	)
+	_statusTransitionUseCase := transactionUsecase.NewStatusTransitionUseCase(
+		_transactionRepo,
+		transactionRepo.NewStatusEventRepository(db),
 	)
 	// Handler at the bottom
 
@@ -443,7 +555,13 @@ func main() {
 
 	// [V2 MERCHANT]
 	_v2MerchantRepo := v2MerchantRepo.NewMerchantRepository(db)
-	_v2MerchantUseCase := v2MerchantUsecase.NewMerchantUseCase(authv2ServiceInstance, _v2MerchantRepo)
+	// TIN_REGISTRY=stub runs onboarding without calling the e-trade registry
+	var _tinRegistry v2MerchantUsecase.TINRegistry = v2MerchantEtradeRegistry.New(etradeTINChecker.New(log))
+	if os.Getenv("TIN_REGISTRY") == "stub" {
+		log.Printf("TIN_REGISTRY=stub, merchant TINs are not looked up in the trade registry")
+		_tinRegistry = v2MerchantStubRegistry.New()
+	}
+	_v2MerchantUseCase := v2MerchantUsecase.NewMerchantUseCase(authv2ServiceInstance, _v2MerchantRepo, _tinRegistry)
 	_v2MerchantHandler := v2MerchantHandler.NewHandler(
 		authv2ServiceInstance,
 		_v2MerchantUseCase,
@@ -480,97 +598,52 @@ func main() {
 	_commissionController.RegisterRoutes(v2)
 
 	// [SocialPay API]
-	// Initialize payment processors
-	telebirrProc := telebirrProcessor.NewProcessor(telebirrProcessor.ProcessorConfig{
-		SecurityCredential: os.Getenv("TELEBIRR_SECURITY_CREDENTIAL"),
-		Password:           os.Getenv("TELEBIRR_PASSWORD"),
-		IsTestMode:         os.Getenv("APP_ENV") != "production",
-		ShortCode:          os.Getenv("TELEBIRR_SHORT_CODE"),
-		IdentityID:         os.Getenv("TELEBIRR_IDENTITY_ID"),
-		BaseURL:            os.Getenv("TELEBIRR_BASE_URL"),
-		CallbackURL:        "https://api.socialpay.co/api/v1/settle/telebirr",
-	})
-
-	// Initialize CBE processor
-	cbeProc := cbeProcessor.NewProcessor(cbeProcessor.ProcessorConfig{
-		MerchantID:    os.Getenv("CBE_MERCHANT_ID"),
-		MerchantKey:   os.Getenv("CBE_MERCHANT_KEY"),
-		TerminalID:    os.Getenv("CBE_TERMINAL_ID"),
-		CredentialKey: os.Getenv("CBE_CREDENTIAL_KEY"),
-		IsTestMode:    os.Getenv("APP_ENV") != "production",
-		BaseURL:       os.Getenv("CBE_BASE_URL"),
-		CallbackURL:   "https://api.socialpay.co/api/v1/settle/cbe",
-	})
-
-	// Initialize Awash processor
-	awashProc := awashProcessor.NewProcessor(awashProcessor.ProcessorConfig{
-		MerchantID:         os.Getenv("AWASH_TEST_MERCHANT_CODE"), // code
-		CredentialKey:      os.Getenv("AWASH_TEST_PASSWORD"),
-		IsTestMode:         true,
-		BaseURL:            os.Getenv("AWASH_TEST_BASE_URL"),
-		CallbackURL:        os.Getenv("AWASH_TEST_CALLBACK_URL"),
-		MerchantTillNumber: os.Getenv("AWASH_TEST_TIN_NUMBER"),
-		TxnRepository:      _transactionRepo,
-	})
-
-	// Initialize Cybersource processor
-	cybersourceProc := cybersourceProcessor.NewProcessor(cybersourceProcessor.ProcessorConfig{
-		AccessKey:  os.Getenv("CYBERSOURCE_ACCESS_KEY"),
-		ProfileID:  os.Getenv("CYBERSOURCE_PROFILE_ID"),
-		SecretKey:  os.Getenv("CYBERSOURCE_SECRET_KEY"),
-		IsTestMode: os.Getenv("APP_ENV") != "production",
-	})
-
-	// Initializing EthSwitch Processor
-	ethSwitchProc := etswitch.NewEtSwitchProcessor(etswitch.ProcessorConfig{
-		UserName:   os.Getenv("ETHSWITCH_USERNAME"),
-		Credential: os.Getenv("ETHSWITCH_PASSWORD"),
-		BaseURL:    os.Getenv("ETHSWITCH_BASE_URL"),
-		RetunUrl:   os.Getenv("APP_CHECKOUT_URL"),
-		IsTestMode: true,
-	})
-
-	// Initialize M-PESA processor
-	mpesaProc := mpesaProcessor.NewProcessor(mpesaProcessor.ProcessorConfig{
-		Username:    os.Getenv("SAFARICOM_USERNAME"),
-		Password:    os.Getenv("SAFARICOM_PASSWORD"),
-		IsTestMode:  os.Getenv("APP_ENV") != "production",
-		BaseURL:     os.Getenv("MPESA_BASE_URL"),
-		CallbackURL: "https://api.socialpay.co/api/v1/settle/mpesa",
+	// Initialize payment processors. Each processor package registers itself with the
+	// registry; the configuration is read from the environment and validated up front.
+	processorConfig, err := paymentRegistry.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load payment processor configuration: %v", err)
+	}
+	processorRegistry, err := paymentRegistry.New(processorConfig, paymentRegistry.Dependencies{
+		TxnRepository: _transactionRepo,
 	})
+	if err != nil {
+		log.Fatalf("Failed to initialize payment processors: %v", err)
+	}
+	for _, info := range processorRegistry.List() {
+		log.Printf("Payment processor %s: enabled=%t test_mode=%t", info.Medium, info.Enabled, info.TestMode)
+	}
 
-	// Initialize Kacha processor
-	kachaProc := kachaProcessor.NewProcessor(kachaProcessor.ProcessorConfig{
-		IsTestMode:  os.Getenv("APP_ENV") != "production",
-		BaseURL:     os.Getenv("KACHA_BASE_URL"),
-		CallbackURL: "https://api.socialpay.co/api/v1/settle/std",
+	// Initialize the payment simulator used by test API keys
+	simulatorProc := simulatorProcessor.NewProcessor(simulatorProcessor.ProcessorConfig{
+		TxnRepository: _transactionRepo,
 	})
 
-	// Create processors map for settlement handler
-	processors := map[txEntity.TransactionMedium]payment.Processor{
-		txEntity.TELEBIRR:    telebirrProc,
-		txEntity.CBE:         cbeProc,
-		txEntity.CYBERSOURCE: cybersourceProc,
-		txEntity.MPESA:       mpesaProc,
-		txEntity.ETHSWITCH:   ethSwitchProc,
-		txEntity.KACHA:       kachaProc,
+	// Merchants with their own credentials get a processor with the platform settings and their credentials
+	processorFactories := make(map[txEntity.TransactionMedium]socialpayUsecase.ProcessorFactory)
+	for _, medium := range processorRegistry.Mediums() {
+		medium := medium
+		processorFactories[medium] = func(c map[string]string) (payment.Processor, error) {
+			return processorRegistry.WithCredentials(medium, c)
+		}
 	}
 
 	// Initialize payment service with all processors as variadic arguments
 	_paymentService := socialpayUsecase.NewPaymentService(
-		telebirrProc,
-		cbeProc,
-		cybersourceProc,
-		mpesaProc,
-		awashProc,
-		ethSwitchProc,
-		kachaProc,
+		_processorAccountUseCase,
+		processorFactories,
+		_processorHealthUseCase,
+		append(processorRegistry.Processors(), simulatorProc)...,
 	)
 
+	_processorHandler := paymentController.NewProcessorHandler(processorRegistry, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_processorHandler.RegisterRoutes(v2)
+
 	_tipService := socialpayUsecase.NewTipProcessingService(
 		_transactionRepo,
 		_walletUseCase,
 		_paymentService,
+		_statusTransitionUseCase,
 	)
 
 	// Initialize notification service with merchant repository
@@ -580,6 +653,18 @@ func main() {
 		log,
 	)
 
+	// [FX]
+	_fxRepo := fxRepo.NewFXRepository(db)
+	_fxUseCase := fxUsecase.NewFXUseCase(_fxRepo)
+	_fxHandler := fxHandler.NewHandler(_fxUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_fxHandler.RegisterRouter(v2)
+
+	// [RESERVE]
+	_reserveRepo := reserveRepo.NewReserveRepository(db)
+	_reserveUseCase := reserveUsecase.NewReserveUseCase(_reserveRepo, _walletUseCase)
+	_reserveHandler := reserveHandler.NewHandler(_reserveUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_reserveHandler.RegisterRouter(v2)
+
 	// [WEBHOOK]
 	_callbackRepo := webhookRepo.NewCallbackRepository(db)
 	_cfg, err := config.Load()
@@ -595,7 +680,15 @@ func main() {
 		_commissionUseCase,
 		_tipService,
 		_transactionNotifier,
+		_customerVaultUseCase,
+		_statusTransitionUseCase,
+		_fxUseCase,
+		_reserveUseCase,
 	)
+	// Simulated provider callbacks go through the same settlement pipeline as real ones
+	simulatorProc.SetDispatcher(_webhookUseCase)
+	// Merchant status changes are sent to merchants as webhook events
+	_v2MerchantUseCase.SetEventPublisher(_webhookUseCase)
 	_webhookController := webhookController.NewWebhookController(
 		_webhookUseCase,
 		middlewareProvider.JWTAuth,
@@ -607,6 +700,7 @@ func main() {
 
 	_transactionHandler := transactionHandler.NewTransactionHistoryHandler(
 		_transactionUseCase,
+		_statusTransitionUseCase,
 		middlewareProvider,
 		_webhookUseCase,
 	)
@@ -616,33 +710,161 @@ func main() {
 	_transactionHandler.RegisterAdminRoutes(v2)
 
 	// Initialize settlement handler
-	settlementHandler := paymentController.NewSettlementHandler(processors, _webhookUseCase)
+	settlementHandler := paymentController.NewSettlementHandler(processorRegistry.Map(), _webhookUseCase)
 
 	// Register settlement routes (no middleware)
 	settlementHandler.RegisterRoutes(v2)
 
+	// [RECONCILIATION]
+	_reconciliationRepo := reconciliationRepo.NewReconciliationRepository(db)
+	_reconciliationUseCase := reconciliationUsecase.NewReconciliationUseCase(_reconciliationRepo, _paymentService, _webhookUseCase)
+	_reconciliationHandler := reconciliationHandler.NewHandler(_reconciliationUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_reconciliationHandler.RegisterRouter(v2)
+
+	// [RISK]
+	_riskRepo := riskRepo.NewRiskRepository(db)
+	// Payments that cannot be assessed are failed unless RISK_FAIL_OPEN lets them through unscreened
+	_riskUseCase := riskUsecase.NewRiskUseCase(_riskRepo, _v2MerchantRepo, _transactionRepo, os.Getenv("RISK_FAIL_OPEN") == "true")
+	_scoreRepo := riskRepo.NewScoreRepository(db)
+	var _riskAlertPhones []string
+	for _, phone := range strings.Split(os.Getenv("RISK_ALERT_PHONES"), ",") {
+		if phone = strings.TrimSpace(phone); phone != "" {
+			_riskAlertPhones = append(_riskAlertPhones, phone)
+		}
+	}
+	_merchantScoreUseCase := riskUsecase.NewMerchantScoreUseCase(_scoreRepo, riskEntity.DefaultScoreConfig(), notifications.NewNotificationService(log), _riskAlertPhones)
+	_riskHandler := riskHandler.NewHandler(_riskUseCase, _merchantScoreUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_riskHandler.RegisterRouter(v2)
+
+	// [ACCESS LIST]
+	_accessListRepo := accessListRepo.NewAccessListRepository(db)
+	_accessListUseCase := accessListUsecase.NewAccessListUseCase(_accessListRepo)
+	_accessListHandler := accessListHandler.NewHandler(_accessListUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_accessListHandler.RegisterRouter(v2)
+
+	// [LIMITS]
+	_limitsRepo := limitsRepo.NewLimitsRepository(db)
+	_limitsUseCase := limitsUsecase.NewLimitsUseCase(_limitsRepo)
+	_limitsHandler := limitsHandler.NewHandler(_limitsUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_limitsHandler.RegisterRouter(v2)
+
+	// [KYC]
+	_kycRepo := kycRepo.NewKYCRepository(db)
+	_kycUseCase := kycUsecase.NewKYCUseCase(
+		_kycRepo,
+		_v2MerchantRepo,
+		_limitsUseCase,
+		kycUsecase.NewRegistryTINChecker(_v2MerchantUseCase),
+		_transactionNotifier,
+		_v2MerchantUseCase,
+	)
+	_kycHandler := kycHandler.NewHandler(_kycUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_kycHandler.RegisterRouter(v2)
+
+	// [DISPUTE]
+	_disputeRepo := disputeRepo.NewDisputeRepository(db)
+	_disputeUseCase := disputeUsecase.NewDisputeUseCase(
+		_disputeRepo,
+		_transactionRepo,
+		_v2MerchantRepo,
+		_fileServiceInstance,
+		_transactionNotifier,
+		_webhookUseCase,
+	)
+	_disputeHandler := disputeHandler.NewHandler(_disputeUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_disputeHandler.RegisterRouter(v2)
+
+	// [WALLET ADJUSTMENT]
+	_walletAdjustmentRepo := walletAdjustmentRepo.NewWalletAdjustmentRepository(db)
+	_walletAdjustmentUseCase := walletAdjustmentUsecase.NewWalletAdjustmentUseCase(
+		_walletAdjustmentRepo,
+		_transactionRepo,
+		_fileServiceInstance,
+	)
+	_walletAdjustmentHandler := walletAdjustmentHandler.NewHandler(_walletAdjustmentUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_walletAdjustmentHandler.RegisterRouter(v2)
+
 	// [QR]
 	_qrRepo := qrRepo.NewQRRepository(db)
 	_qrUseCase := qrUsecase.NewQRUseCase(
 		_qrRepo,
 		_transactionRepo,
 		_transactionUseCase,
+		_statusTransitionUseCase,
 		_paymentService,
 		_walletUseCase,
 		_commissionUseCase,
+		_processorAccountUseCase,
+		_riskUseCase,
+		_limitsUseCase,
+		_accessListUseCase,
+		_v2MerchantUseCase,
 	)
 	_qrHandler := qrHandler.NewHandler(_qrUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
 	_qrHandler.RegisterRouter(v2)
 
+	// [CHECKOUT BRANDING]
+	_brandingRepo := brandingRepo.NewBrandingRepository(db)
+	_brandingUseCase := brandingUsecase.NewBrandingUseCase(_brandingRepo, _v2MerchantRepo)
+	_brandingHandler := brandingHandler.NewHandler(_brandingUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_brandingHandler.RegisterRouter(v2)
+
+	// [PAYMENT LINK]
+	_paymentLinkRepo := paymentLinkRepo.NewPaymentLinkRepository(db)
+	_paymentLinkUseCase := paymentLinkUsecase.NewPaymentLinkUseCase(_paymentLinkRepo, _hostedPaymentRepo, _v2MerchantRepo, _brandingUseCase, _v2MerchantUseCase)
+	_paymentLinkHandler := paymentLinkHandler.NewHandler(_paymentLinkUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_paymentLinkHandler.RegisterRouter(v2)
+
+	// [WITHDRAWAL APPROVAL]
+	_withdrawalApprovalRepo := withdrawalApprovalRepo.NewWithdrawalApprovalRepository(db)
+	_withdrawalApprovalUseCase := withdrawalApprovalUsecase.NewWithdrawalApprovalUseCase(
+		_withdrawalApprovalRepo,
+		_transactionRepo,
+		_statusTransitionUseCase,
+		_transactionNotifier,
+	)
+	_withdrawalApprovalHandler := withdrawalApprovalHandler.NewHandler(_withdrawalApprovalUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_withdrawalApprovalHandler.RegisterRouter(v2)
+
+	// [BENEFICIARY]
+	_beneficiaryRepo := beneficiaryRepo.NewBeneficiaryRepository(db)
+	_beneficiaryUseCase := beneficiaryUsecase.NewBeneficiaryUseCase(_beneficiaryRepo, _paymentService)
+	_beneficiaryHandler := beneficiaryHandler.NewHandler(_beneficiaryUseCase, middlewareProvider.JWTAuth, middlewareProvider.RBAC)
+	_beneficiaryHandler.RegisterRouter(v2)
+
+	// [CARD AUTHORIZATION]
+	_cardAuthorizationRepo := transactionRepo.NewCardAuthorizationRepository(db)
+	_cardAuthorizationService := socialpayUsecase.NewCardAuthorizationService(
+		_transactionRepo,
+		_cardAuthorizationRepo,
+		_paymentService,
+		_webhookUseCase,
+	)
+
 	_socialpayAPIUseCase := socialpayUsecase.NewPaymentUseCase(socialpayUsecase.UseCaseConfig{
-		TransactionRepo:    _transactionRepo,
-		HostedPaymentRepo:  _hostedPaymentRepo,
-		TransactionUseCase: _transactionUseCase,
-		PaymentService:     _paymentService,
-		WalletUseCase:      _walletUseCase,
-		MerchantUseCase:    _v2MerchantUseCase,
-		CommissionUseCase:  _commissionUseCase,
+		TransactionRepo:     _transactionRepo,
+		HostedPaymentRepo:   _hostedPaymentRepo,
+		TransactionUseCase:  _transactionUseCase,
+		PaymentService:      _paymentService,
+		WalletUseCase:       _walletUseCase,
+		MerchantUseCase:     _v2MerchantUseCase,
+		CommissionUseCase:   _commissionUseCase,
+		BrandingUseCase:     _brandingUseCase,
+		CustomerVault:       _customerVaultUseCase,
+		ProcessorAccounts:   _processorAccountUseCase,
+		CardAuthorizations:  _cardAuthorizationService,
+		StatusTransitions:   _statusTransitionUseCase,
+		FX:                  _fxUseCase,
+		WithdrawalApprovals: _withdrawalApprovalUseCase,
+		Beneficiaries:       _beneficiaryUseCase,
+		Risk:                _riskUseCase,
+		Limits:              _limitsUseCase,
+		AccessLists:         _accessListUseCase,
 	})
+	// Approved withdrawals are sent, and rejected or expired ones released, by the payment usecase
+	_withdrawalApprovalUseCase.SetExecutor(_socialpayAPIUseCase)
+	// So are withdrawals and payments cleared or rejected in risk review
+	_riskUseCase.SetTransactionReviewer(_socialpayAPIUseCase)
 
 	_socialpayAPIHandler := socialpayController.NewHandler(
 		_socialpayAPIUseCase,
@@ -697,7 +919,13 @@ func main() {
 		_webhookUseCase,
 	)
 
-	_cronService := socialpayUsecase.NewCronService(_transactionStatusChecker, ctx)
+	_checkoutExpirySweeper := socialpayUsecase.NewCheckoutExpirySweeper(
+		_hostedPaymentRepo,
+		_transactionRepo,
+		_webhookUseCase,
+	)
+
+	_cronService := socialpayUsecase.NewCronService(_transactionStatusChecker, _checkoutExpirySweeper, _cardAuthorizationService, _withdrawalApprovalUseCase, _kycUseCase, _merchantScoreUseCase, _reserveUseCase, _disputeUseCase, ctx)
 
 	if err := _cronService.Start(); err != nil {
 		log.Fatalf("Failed to start cron service: %v", err)
@@ -0,0 +1,396 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/access_list/usecase"
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+// maxImportSize bounds an uploaded import file
+const maxImportSize = 5 << 20
+
+type Handler struct {
+	accessListUseCase usecase.AccessListUseCase
+	log               logging.Logger
+	jwtMiddleware     gin.HandlerFunc
+	rbac              *ginMiddleware.RBACV2
+}
+
+func NewHandler(accessListUseCase usecase.AccessListUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		accessListUseCase: accessListUseCase,
+		log:               logging.NewStdLogger("access_list_handler"),
+		jwtMiddleware:     jwtMiddleware,
+		rbac:              rbac,
+	}
+}
+
+// RegisterRouter sets up the admin access list routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	admin := router.Group("/admin/access-lists", h.jwtMiddleware)
+	admin.GET("/check",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_READ),
+		h.Check)
+	admin.GET("/entries",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_READ),
+		h.ListEntries)
+	admin.POST("/entries",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_CREATE),
+		h.CreateEntry)
+	admin.POST("/entries/import",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_CREATE),
+		h.ImportEntries)
+	admin.GET("/entries/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_READ),
+		h.GetEntry)
+	admin.PUT("/entries/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.UpdateEntry)
+	admin.DELETE("/entries/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_DELETE),
+		h.RemoveEntry)
+	admin.GET("/entries/:id/audit",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ACCESS_LIST, auth_entity.OPERATION_ADMIN_READ),
+		h.ListAudit)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// Check godoc
+// @Summary      Check a value against the access lists
+// @Description  Look a phone number, card fingerprint, IP address or device ID up as a transaction would be screened. Without merchant_id only global entries are considered.
+// @Tags         Admin Access Lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type         query  string  true   "PHONE, CARD, IP or DEVICE"
+// @Param        value        query  string  true   "Value to look up"
+// @Param        merchant_id  query  string  false  "Merchant the transaction would be for"
+// @Success      200  {object}  entity.CheckResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/access-lists/check [get]
+func (h *Handler) Check(c *gin.Context) {
+	req := &entity.CheckRequest{
+		Type:  entity.EntryType(c.Query("type")),
+		Value: c.Query("value"),
+	}
+	if merchantID := c.Query("merchant_id"); merchantID != "" {
+		id, err := uuid.Parse(merchantID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+			return
+		}
+		req.MerchantID = &id
+	}
+
+	response, err := h.accessListUseCase.Check(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListEntries godoc
+// @Summary      List access list entries
+// @Description  List blocklist and allowlist entries, newest first. Removed and expired entries are only listed with include_inactive.
+// @Tags         Admin Access Lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        list              query  string  false  "BLOCK or ALLOW"
+// @Param        type              query  string  false  "PHONE, CARD, IP or DEVICE"
+// @Param        merchant_id       query  string  false  "Merchant ID, or global for entries of every merchant"
+// @Param        value             query  string  false  "Value, normalized when type is given"
+// @Param        include_inactive  query  bool    false  "Include removed and expired entries"
+// @Param        limit             query  int     false  "Number of entries (max 500)"
+// @Param        offset            query  int     false  "Entries to skip"
+// @Success      200  {object}  entity.EntryListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/access-lists/entries [get]
+func (h *Handler) ListEntries(c *gin.Context) {
+	filter := entity.EntryFilter{
+		List:            entity.List(strings.ToUpper(c.Query("list"))),
+		Type:            entity.EntryType(strings.ToUpper(c.Query("type"))),
+		Value:           strings.TrimSpace(c.Query("value")),
+		IncludeInactive: c.Query("include_inactive") == "true",
+	}
+	switch merchantID := c.Query("merchant_id"); merchantID {
+	case "":
+	case "global":
+		filter.GlobalOnly = true
+	default:
+		id, err := uuid.Parse(merchantID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+			return
+		}
+		filter.MerchantID = &id
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filter.Offset, _ = strconv.Atoi(c.Query("offset"))
+
+	response, err := h.accessListUseCase.ListEntries(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateEntry godoc
+// @Summary      Add an access list entry
+// @Description  Block or allow a phone number, card fingerprint, IP address or CIDR range, or device ID, for every merchant or for the given merchant_id. An allow entry lifts block entries of the same type with the same or a wider scope.
+// @Tags         Admin Access Lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.EntryRequest  true  "Entry"
+// @Success      201  {object}  entity.Entry
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/access-lists/entries [post]
+func (h *Handler) CreateEntry(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.EntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	entry, err := h.accessListUseCase.CreateEntry(c.Request.Context(), &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// ImportEntries godoc
+// @Summary      Import access list entries
+// @Description  Upload a CSV file with the header list,type,value,reason_code and the optional columns merchant_id, expires_at and note. Nothing is imported when any row is invalid; values already on the same list and scope are skipped.
+// @Tags         Admin Access Lists
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file  formData  file  true  "Entries (.csv)"
+// @Success      201  {object}  entity.ImportResponse
+// @Failure      400  {object}  entity.ImportResponse
+// @Router       /admin/access-lists/entries/import [post]
+func (h *Handler) ImportEntries(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("import file is required")))
+		return
+	}
+	if fileHeader.Size > maxImportSize {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("import file must be at most %d MB", maxImportSize>>20)))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("failed to open import file: %w", err)))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxImportSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("failed to read import file: %w", err)))
+		return
+	}
+
+	response, err := h.accessListUseCase.ImportEntries(c.Request.Context(), content, fileHeader.Filename, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if len(response.Errors) > 0 {
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetEntry godoc
+// @Summary      Get an access list entry
+// @Tags         Admin Access Lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Entry ID"
+// @Success      200  {object}  entity.Entry
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/access-lists/entries/{id} [get]
+func (h *Handler) GetEntry(c *gin.Context) {
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid entry ID")))
+		return
+	}
+
+	entry, err := h.accessListUseCase.GetEntry(c.Request.Context(), entryID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// UpdateEntry godoc
+// @Summary      Update an access list entry
+// @Description  Change the reason code, note or expiry of an entry. An entry without expires_at stays until it is removed.
+// @Tags         Admin Access Lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                     true  "Entry ID"
+// @Param        request  body  entity.EntryUpdateRequest  true  "Update"
+// @Success      200  {object}  entity.Entry
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/access-lists/entries/{id} [put]
+func (h *Handler) UpdateEntry(c *gin.Context) {
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid entry ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.EntryUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	entry, err := h.accessListUseCase.UpdateEntry(c.Request.Context(), entryID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// RemoveEntry godoc
+// @Summary      Remove an access list entry
+// @Description  Take a value off its list. The entry and its audit trail are kept.
+// @Tags         Admin Access Lists
+// @Security     BearerAuth
+// @Param        id    path   string  true   "Entry ID"
+// @Param        note  query  string  false  "Why the entry is removed"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/access-lists/entries/{id} [delete]
+func (h *Handler) RemoveEntry(c *gin.Context) {
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid entry ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	if err := h.accessListUseCase.RemoveEntry(c.Request.Context(), entryID, adminID, strings.TrimSpace(c.Query("note"))); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAudit godoc
+// @Summary      Get the audit trail of an access list entry
+// @Description  List who added, changed and removed an entry, and the transactions it refused
+// @Tags         Admin Access Lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Entry ID"
+// @Success      200  {object}  entity.AuditListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/access-lists/entries/{id}/audit [get]
+func (h *Handler) ListAudit(c *gin.Context) {
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid entry ID")))
+		return
+	}
+
+	response, err := h.accessListUseCase.ListAudit(c.Request.Context(), entryID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrEntryNotFound),
+		errors.Is(err, entity.ErrMerchantNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrDuplicateEntry),
+		errors.Is(err, entity.ErrEntryRemoved):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("Access list request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
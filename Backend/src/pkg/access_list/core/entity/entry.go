@@ -0,0 +1,399 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrBlocked is returned to the client when a party to a transaction is on a blocklist. It does
+	// not say which party or why, so a blocked fraudster learns as little as possible.
+	ErrBlocked = errors.New("transaction refused: one of its parties is not allowed to transact")
+	// ErrEntryNotFound is returned when no entry has the given ID
+	ErrEntryNotFound = errors.New("access list entry not found")
+	// ErrEntryRemoved is returned when changing an entry that was already removed
+	ErrEntryRemoved = errors.New("access list entry was removed")
+	// ErrDuplicateEntry is returned when the same value is already on the same list and scope
+	ErrDuplicateEntry = errors.New("value is already on this list")
+	// ErrMerchantNotFound is returned when an entry is scoped to a merchant that does not exist
+	ErrMerchantNotFound = errors.New("merchant not found")
+)
+
+const (
+	// MaxImportRows bounds the rows of a CSV import
+	MaxImportRows = 10000
+	// MaxNoteLength bounds the free-text note of an entry
+	MaxNoteLength = 500
+)
+
+// List is the list an entry is on
+type List string
+
+const (
+	// ListBlock refuses transactions involving the value
+	ListBlock List = "BLOCK"
+	// ListAllow exempts the value from block entries of the same or a wider scope
+	ListAllow List = "ALLOW"
+)
+
+// EntryType is the kind of value an entry holds
+type EntryType string
+
+const (
+	// TypePhone is a payer or payout phone number, stored as +251XXXXXXXXX
+	TypePhone EntryType = "PHONE"
+	// TypeCard is the fingerprint of a saved Cybersource card, as shown on risk assessments
+	TypeCard EntryType = "CARD"
+	// TypeIP is an IP address or CIDR range of the client making the request
+	TypeIP EntryType = "IP"
+	// TypeDevice is the device ID sent by the checkout page or merchant app
+	TypeDevice EntryType = "DEVICE"
+)
+
+// ReasonCode explains why a value is on a list
+type ReasonCode string
+
+const (
+	ReasonFraud            ReasonCode = "FRAUD"
+	ReasonChargebacks      ReasonCode = "CHARGEBACKS"
+	ReasonStolenCard       ReasonCode = "STOLEN_CARD"
+	ReasonSanctions        ReasonCode = "SANCTIONS"
+	ReasonAbuse            ReasonCode = "ABUSE"
+	ReasonMerchantRequest  ReasonCode = "MERCHANT_REQUEST"
+	ReasonVerifiedCustomer ReasonCode = "VERIFIED_CUSTOMER"
+	ReasonOther            ReasonCode = "OTHER"
+)
+
+var reasonCodes = []interface{}{
+	ReasonFraud, ReasonChargebacks, ReasonStolenCard, ReasonSanctions,
+	ReasonAbuse, ReasonMerchantRequest, ReasonVerifiedCustomer, ReasonOther,
+}
+
+var cardFingerprintPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Entry is a value on the blocklist or allowlist, either for every merchant or for one
+// @Description Access list entry
+type Entry struct {
+	ID uuid.UUID `json:"id"`
+	// MerchantID is empty for entries that apply to every merchant
+	MerchantID *uuid.UUID `json:"merchant_id,omitempty"`
+	List       List       `json:"list" example:"BLOCK"`
+	Type       EntryType  `json:"type" example:"PHONE"`
+	Value      string     `json:"value" example:"+251911234567"`
+	ReasonCode ReasonCode `json:"reason_code" example:"FRAUD"`
+	Note       string     `json:"note,omitempty" example:"Reported by merchant for fake payment screenshots"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	UpdatedBy  *uuid.UUID `json:"updated_by,omitempty"`
+	RemovedBy  *uuid.UUID `json:"removed_by,omitempty"`
+	RemovedAt  *time.Time `json:"removed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ActiveAt reports whether the entry is in effect at t
+func (e *Entry) ActiveAt(t time.Time) bool {
+	return e.RemovedAt == nil && (e.ExpiresAt == nil || t.Before(*e.ExpiresAt))
+}
+
+// NormalizeValue returns the form a value of the given type is stored and matched in
+func NormalizeValue(entryType EntryType, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch entryType {
+	case TypePhone:
+		return NormalizePhone(value)
+	case TypeCard:
+		value = strings.ToLower(value)
+		if !cardFingerprintPattern.MatchString(value) {
+			return "", errors.New("card fingerprint must be 64 hexadecimal characters")
+		}
+		return value, nil
+	case TypeIP:
+		return NormalizeNetwork(value)
+	case TypeDevice:
+		if value == "" || len(value) > 255 {
+			return "", errors.New("device ID must be 1 to 255 characters")
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("unsupported entry type %q", entryType)
+	}
+}
+
+// NormalizePhone reduces an Ethiopian mobile number to +251XXXXXXXXX, the same form the
+// notification service sends SMS to. Safaricom numbers starting with 7 are accepted as well.
+func NormalizePhone(phone string) (string, error) {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	cleaned := digits.String()
+
+	switch {
+	case len(cleaned) == 9 && (cleaned[0] == '9' || cleaned[0] == '7'): // 9xxxxxxxx
+		return "+251" + cleaned, nil
+	case len(cleaned) == 10 && (cleaned[:2] == "09" || cleaned[:2] == "07"): // 09xxxxxxxx
+		return "+251" + cleaned[1:], nil
+	case len(cleaned) == 12 && strings.HasPrefix(cleaned, "251"): // 2519xxxxxxxx
+		return "+" + cleaned, nil
+	default:
+		return "", errors.New("invalid Ethiopian phone number format. Accepted formats: 9xxxxxxxx, 09xxxxxxxx, 2519xxxxxxxx, +2519xxxxxxxx")
+	}
+}
+
+// NormalizeNetwork turns an IP address or CIDR range into its canonical CIDR form; a single
+// address becomes a /32 or /128 range
+func NormalizeNetwork(value string) (string, error) {
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return "", errors.New("invalid CIDR range")
+		}
+		if addr := prefix.Addr(); addr.Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked().String(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return "", errors.New("invalid IP address")
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+}
+
+// EntryRequest adds a value to a list
+// @Description Access list entry
+type EntryRequest struct {
+	List       List       `json:"list" example:"BLOCK"`
+	Type       EntryType  `json:"type" example:"PHONE"`
+	Value      string     `json:"value" example:"0911234567"`
+	MerchantID *uuid.UUID `json:"merchant_id,omitempty"`
+	ReasonCode ReasonCode `json:"reason_code" example:"FRAUD"`
+	Note       string     `json:"note,omitempty" example:"Reported by merchant for fake payment screenshots"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// Normalize upper-cases the list, type and reason code
+func (r *EntryRequest) Normalize() {
+	r.List = List(strings.ToUpper(strings.TrimSpace(string(r.List))))
+	r.Type = EntryType(strings.ToUpper(strings.TrimSpace(string(r.Type))))
+	r.ReasonCode = ReasonCode(strings.ToUpper(strings.TrimSpace(string(r.ReasonCode))))
+	r.Note = strings.TrimSpace(r.Note)
+}
+
+// Validate validates the request at now
+func (r *EntryRequest) Validate(now time.Time) error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.List, validation.Required, validation.In(ListBlock, ListAllow)),
+		validation.Field(&r.Type, validation.Required, validation.In(TypePhone, TypeCard, TypeIP, TypeDevice)),
+		validation.Field(&r.Value, validation.Required),
+		validation.Field(&r.ReasonCode, validation.Required, validation.In(reasonCodes...)),
+		validation.Field(&r.Note, validation.Length(0, MaxNoteLength)),
+	); err != nil {
+		return err
+	}
+	if _, err := NormalizeValue(r.Type, r.Value); err != nil {
+		return validation.Errors{"value": err}
+	}
+	return validateExpiry(r.ExpiresAt, now)
+}
+
+// Entry builds the entry the request adds
+func (r *EntryRequest) Entry(adminID uuid.UUID, now time.Time) *Entry {
+	value, _ := NormalizeValue(r.Type, r.Value)
+	return &Entry{
+		ID:         uuid.New(),
+		MerchantID: r.MerchantID,
+		List:       r.List,
+		Type:       r.Type,
+		Value:      value,
+		ReasonCode: r.ReasonCode,
+		Note:       r.Note,
+		ExpiresAt:  r.ExpiresAt,
+		CreatedBy:  &adminID,
+		UpdatedBy:  &adminID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// EntryUpdateRequest changes why and until when a value is on a list. The value, list and
+// scope of an entry cannot change; remove it and add another instead.
+// @Description Access list entry update
+type EntryUpdateRequest struct {
+	ReasonCode ReasonCode `json:"reason_code" example:"FRAUD"`
+	Note       string     `json:"note,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// Normalize upper-cases the reason code
+func (r *EntryUpdateRequest) Normalize() {
+	r.ReasonCode = ReasonCode(strings.ToUpper(strings.TrimSpace(string(r.ReasonCode))))
+	r.Note = strings.TrimSpace(r.Note)
+}
+
+// Validate validates the update at now
+func (r *EntryUpdateRequest) Validate(now time.Time) error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.ReasonCode, validation.Required, validation.In(reasonCodes...)),
+		validation.Field(&r.Note, validation.Length(0, MaxNoteLength)),
+	); err != nil {
+		return err
+	}
+	return validateExpiry(r.ExpiresAt, now)
+}
+
+func validateExpiry(expiresAt *time.Time, now time.Time) error {
+	if expiresAt != nil && !expiresAt.After(now) {
+		return errors.New("expires_at must be in the future")
+	}
+	return nil
+}
+
+// EntryFilter selects entries to list
+type EntryFilter struct {
+	List List
+	Type EntryType
+	// MerchantID lists the entries of one merchant, GlobalOnly the entries of every merchant
+	MerchantID *uuid.UUID
+	GlobalOnly bool
+	// Value finds a value, normalized for its type when Type is set
+	Value string
+	// IncludeInactive also lists removed and expired entries
+	IncludeInactive bool
+	Limit           int
+	Offset          int
+}
+
+// EntryListResponse lists entries
+// @Description Access list entries
+type EntryListResponse struct {
+	Entries []Entry `json:"entries"`
+	Total   int     `json:"total"`
+}
+
+// CheckRequest looks a value up on the lists
+type CheckRequest struct {
+	Type       EntryType
+	Value      string
+	MerchantID *uuid.UUID
+}
+
+// Normalize upper-cases the type
+func (r *CheckRequest) Normalize() {
+	r.Type = EntryType(strings.ToUpper(strings.TrimSpace(string(r.Type))))
+}
+
+// Validate validates the request
+func (r *CheckRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Type, validation.Required, validation.In(TypePhone, TypeCard, TypeIP, TypeDevice)),
+		validation.Field(&r.Value, validation.Required),
+	); err != nil {
+		return err
+	}
+	if _, err := NormalizeValue(r.Type, r.Value); err != nil {
+		return validation.Errors{"value": err}
+	}
+	return nil
+}
+
+// CheckResponse tells whether a value would be refused
+// @Description Access list check
+type CheckResponse struct {
+	// Value is the looked up value in its normalized form
+	Value   string `json:"value" example:"+251911234567"`
+	Blocked bool   `json:"blocked"`
+	// Matching are the entries in effect that match the value, allow entries included
+	Matching []Entry `json:"matching"`
+}
+
+// Party is what is known about the parties to a transaction when it is screened. Empty
+// values are not screened.
+type Party struct {
+	MerchantID      uuid.UUID
+	PhoneNumber     string
+	CardFingerprint string
+	IPAddress       string
+	DeviceID        string
+}
+
+// Blocking returns the block entry that refuses a transaction, given the active entries its
+// party matched, or nil. A block entry is lifted by an allow entry of the same type whose scope
+// is the same or narrower: a merchant's allow entry lifts a global block for that merchant,
+// but a global allow entry does not lift a merchant's own block.
+func Blocking(matched []Entry) *Entry {
+	for i := range matched {
+		block := &matched[i]
+		if block.List != ListBlock {
+			continue
+		}
+		lifted := false
+		for _, allow := range matched {
+			if allow.List == ListAllow && allow.Type == block.Type && (allow.MerchantID != nil || block.MerchantID == nil) {
+				lifted = true
+				break
+			}
+		}
+		if !lifted {
+			return block
+		}
+	}
+	return nil
+}
+
+// AuditAction is a change to an entry, or a transaction it refused
+type AuditAction string
+
+const (
+	AuditAdded   AuditAction = "ADDED"
+	AuditUpdated AuditAction = "UPDATED"
+	AuditRemoved AuditAction = "REMOVED"
+	// AuditMatched records a transaction the entry refused
+	AuditMatched AuditAction = "MATCHED"
+)
+
+// AuditEvent is one line of an entry's audit trail
+// @Description Access list audit event
+type AuditEvent struct {
+	ID      uuid.UUID   `json:"id"`
+	EntryID uuid.UUID   `json:"entry_id"`
+	Action  AuditAction `json:"action" example:"ADDED"`
+	// ActorID is the admin who made the change, empty for matches
+	ActorID       *uuid.UUID `json:"actor_id,omitempty"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// AuditListResponse lists the audit trail of an entry
+// @Description Access list audit trail
+type AuditListResponse struct {
+	Events []AuditEvent `json:"events"`
+}
+
+// ImportError is a CSV row that could not be imported
+type ImportError struct {
+	Row   int    `json:"row" example:"3"`
+	Error string `json:"error" example:"value: invalid IP address"`
+}
+
+// ImportResponse summarizes a CSV import. Nothing is imported when any row is invalid.
+// @Description Access list import result
+type ImportResponse struct {
+	Imported int `json:"imported" example:"120"`
+	// Skipped counts rows already on the same list and scope
+	Skipped int           `json:"skipped" example:"3"`
+	Errors  []ImportError `json:"errors,omitempty"`
+}
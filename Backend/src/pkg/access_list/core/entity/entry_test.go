@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		entryType EntryType
+		value     string
+		want      string
+		wantErr   bool
+	}{
+		{TypePhone, "0911 234 567", "+251911234567", false},
+		{TypePhone, "+251-911-234567", "+251911234567", false},
+		{TypePhone, "911234567", "+251911234567", false},
+		{TypePhone, "0711234567", "+251711234567", false},
+		{TypePhone, "1000123456789", "", true},
+		{TypeIP, "196.188.1.7", "196.188.1.7/32", false},
+		{TypeIP, "196.188.1.7/24", "196.188.1.0/24", false},
+		{TypeIP, "::ffff:196.188.1.7", "196.188.1.7/32", false},
+		{TypeIP, "2001:db8::1/48", "2001:db8::/48", false},
+		{TypeIP, "196.188.1", "", true},
+		{TypeCard, "ABCDEF0123456789abcdef0123456789ABCDEF0123456789abcdef0123456789", "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789", false},
+		{TypeCard, "4111111111111111", "", true},
+		{TypeDevice, " device-1 ", "device-1", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeValue(tt.entryType, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("NormalizeValue(%s, %q) error = %v, wantErr %v", tt.entryType, tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("NormalizeValue(%s, %q) = %q, want %q", tt.entryType, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestBlocking(t *testing.T) {
+	merchantID := uuid.New()
+	globalBlock := Entry{ID: uuid.New(), List: ListBlock, Type: TypePhone}
+	merchantBlock := Entry{ID: uuid.New(), List: ListBlock, Type: TypePhone, MerchantID: &merchantID}
+	globalAllow := Entry{ID: uuid.New(), List: ListAllow, Type: TypePhone}
+	merchantAllow := Entry{ID: uuid.New(), List: ListAllow, Type: TypePhone, MerchantID: &merchantID}
+	deviceAllow := Entry{ID: uuid.New(), List: ListAllow, Type: TypeDevice, MerchantID: &merchantID}
+
+	tests := []struct {
+		name    string
+		matched []Entry
+		want    *Entry
+	}{
+		{"nothing matched", nil, nil},
+		{"global block", []Entry{globalBlock}, &globalBlock},
+		{"merchant allow lifts global block", []Entry{globalBlock, merchantAllow}, nil},
+		{"global allow lifts global block", []Entry{globalBlock, globalAllow}, nil},
+		{"global allow keeps merchant block", []Entry{merchantBlock, globalAllow}, &merchantBlock},
+		{"allow of another type lifts nothing", []Entry{globalBlock, deviceAllow}, &globalBlock},
+		{"allow only", []Entry{merchantAllow}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Blocking(tt.matched)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Fatalf("Blocking() = %s, want nil", got.ID)
+			case tt.want != nil && (got == nil || got.ID != tt.want.ID):
+				t.Fatalf("Blocking() = %v, want %s", got, tt.want.ID)
+			}
+		})
+	}
+}
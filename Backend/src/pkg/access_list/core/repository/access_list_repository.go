@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
+)
+
+// AccessListRepository defines the interface for access list entry and audit persistence. Every
+// change to an entry is written together with its audit event.
+type AccessListRepository interface {
+	// Create adds an entry. It returns entity.ErrDuplicateEntry when the value is already active on
+	// the same list and scope; an expired entry for the value is retired to make room.
+	Create(ctx context.Context, entry *entity.Entry, note string) error
+	// Import adds entries in one transaction, skipping those already active on the same list and scope
+	Import(ctx context.Context, entries []*entity.Entry, note string) (int, error)
+	// Update stores a changed reason, note or expiry
+	Update(ctx context.Context, entry *entity.Entry, note string) error
+	// Remove takes an active entry off its list
+	Remove(ctx context.Context, id, adminID uuid.UUID, note string, now time.Time) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Entry, error)
+	List(ctx context.Context, filter entity.EntryFilter, now time.Time) ([]entity.Entry, int, error)
+
+	// Match retrieves the entries in effect at now that apply to the merchant and match any value of the party
+	Match(ctx context.Context, party entity.Party, now time.Time) ([]entity.Entry, error)
+	// RecordMatch adds a transaction an entry refused to its audit trail
+	RecordMatch(ctx context.Context, entryID, transactionID uuid.UUID, now time.Time) error
+	ListAudit(ctx context.Context, entryID uuid.UUID) ([]entity.AuditEvent, error)
+}
@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
+)
+
+const entryColumns = `id, merchant_id, list, type, value, reason_code, note, expires_at, created_by, updated_by, removed_by, removed_at, created_at, updated_at`
+
+const auditColumns = `id, entry_id, action, actor_id, transaction_id, note, created_at`
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+type AccessListRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAccessListRepository(db *sql.DB) AccessListRepository {
+	return &AccessListRepositoryImpl{db: db}
+}
+
+func (r *AccessListRepositoryImpl) Create(ctx context.Context, entry *entity.Entry, note string) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	inserted, err := insertEntry(ctx, dbTx, entry)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		return entity.ErrDuplicateEntry
+	}
+	if err := insertAudit(ctx, dbTx, entry.ID, entity.AuditAdded, entry.CreatedBy, nil, note, entry.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit access list entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AccessListRepositoryImpl) Import(ctx context.Context, entries []*entity.Entry, note string) (int, error) {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	imported := 0
+	for _, entry := range entries {
+		inserted, err := insertEntry(ctx, dbTx, entry)
+		if err != nil {
+			return 0, err
+		}
+		if !inserted {
+			continue
+		}
+		if err := insertAudit(ctx, dbTx, entry.ID, entity.AuditAdded, entry.CreatedBy, nil, note, entry.CreatedAt); err != nil {
+			return 0, err
+		}
+		imported++
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit access list import: %w", err)
+	}
+	return imported, nil
+}
+
+// insertEntry adds an entry unless its value is already active on the same list and scope
+func insertEntry(ctx context.Context, dbTx *sql.Tx, entry *entity.Entry) (bool, error) {
+	// Expired entries still hold the unique index until they are retired
+	retire := `
+		UPDATE public.access_list_entries
+		SET removed_at = $6
+		WHERE merchant_id IS NOT DISTINCT FROM $1 AND list = $2 AND type = $3 AND value = $4
+			AND removed_at IS NULL AND expires_at <= $5`
+
+	if _, err := dbTx.ExecContext(ctx, retire,
+		entry.MerchantID, string(entry.List), string(entry.Type), entry.Value, entry.CreatedAt, entry.CreatedAt,
+	); err != nil {
+		return false, fmt.Errorf("failed to retire expired access list entry: %w", err)
+	}
+
+	query := `
+		INSERT INTO public.access_list_entries (` + entryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT DO NOTHING`
+
+	result, err := dbTx.ExecContext(ctx, query,
+		entry.ID, entry.MerchantID, string(entry.List), string(entry.Type), entry.Value,
+		string(entry.ReasonCode), nullString(entry.Note), entry.ExpiresAt, entry.CreatedBy,
+		entry.UpdatedBy, entry.RemovedBy, entry.RemovedAt, entry.CreatedAt, entry.UpdatedAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return false, entity.ErrMerchantNotFound
+		}
+		return false, fmt.Errorf("failed to create access list entry: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create access list entry: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func insertAudit(ctx context.Context, db execer, entryID uuid.UUID, action entity.AuditAction, actorID, transactionID *uuid.UUID, note string, at time.Time) error {
+	query := `
+		INSERT INTO public.access_list_audit (` + auditColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := db.ExecContext(ctx, query,
+		uuid.New(), entryID, string(action), actorID, transactionID, nullString(note), at,
+	); err != nil {
+		return fmt.Errorf("failed to record access list audit event: %w", err)
+	}
+	return nil
+}
+
+func (r *AccessListRepositoryImpl) Update(ctx context.Context, entry *entity.Entry, note string) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	query := `
+		UPDATE public.access_list_entries
+		SET reason_code = $2,
+			note = $3,
+			expires_at = $4,
+			updated_by = $5,
+			updated_at = $6
+		WHERE id = $1 AND removed_at IS NULL`
+
+	result, err := dbTx.ExecContext(ctx, query,
+		entry.ID, string(entry.ReasonCode), nullString(entry.Note), entry.ExpiresAt, entry.UpdatedBy, entry.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update access list entry: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update access list entry: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrEntryRemoved
+	}
+
+	if err := insertAudit(ctx, dbTx, entry.ID, entity.AuditUpdated, entry.UpdatedBy, nil, note, entry.UpdatedAt); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit access list entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AccessListRepositoryImpl) Remove(ctx context.Context, id, adminID uuid.UUID, note string, now time.Time) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	query := `
+		UPDATE public.access_list_entries
+		SET removed_by = $2,
+			removed_at = $3,
+			updated_by = $2,
+			updated_at = $3
+		WHERE id = $1 AND removed_at IS NULL`
+
+	result, err := dbTx.ExecContext(ctx, query, id, adminID, now)
+	if err != nil {
+		return fmt.Errorf("failed to remove access list entry: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove access list entry: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrEntryRemoved
+	}
+
+	if err := insertAudit(ctx, dbTx, id, entity.AuditRemoved, &adminID, nil, note, now); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit access list removal: %w", err)
+	}
+	return nil
+}
+
+func (r *AccessListRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.Entry, error) {
+	query := `SELECT ` + entryColumns + ` FROM public.access_list_entries WHERE id = $1`
+
+	entry, err := scanEntry(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get access list entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *AccessListRepositoryImpl) List(ctx context.Context, filter entity.EntryFilter, now time.Time) ([]entity.Entry, int, error) {
+	where := `
+		WHERE ($1 = '' OR list = $1)
+			AND ($2 = '' OR type = $2)
+			AND ($3::uuid IS NULL OR merchant_id = $3)
+			AND (NOT $4 OR merchant_id IS NULL)
+			AND ($5 = '' OR value = $5)
+			AND ($6 OR (removed_at IS NULL AND (expires_at IS NULL OR expires_at > $7)))`
+	args := []interface{}{
+		string(filter.List), string(filter.Type), filter.MerchantID, filter.GlobalOnly,
+		filter.Value, filter.IncludeInactive, now,
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM public.access_list_entries`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count access list entries: %w", err)
+	}
+
+	query := `SELECT ` + entryColumns + ` FROM public.access_list_entries` + where + `
+		ORDER BY created_at DESC
+		LIMIT $8 OFFSET $9`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list access list entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+func (r *AccessListRepositoryImpl) Match(ctx context.Context, party entity.Party, now time.Time) ([]entity.Entry, error) {
+	// The CASE keeps the CIDR cast away from the values of other types
+	query := `SELECT ` + entryColumns + ` FROM public.access_list_entries
+		WHERE removed_at IS NULL
+			AND (expires_at IS NULL OR expires_at > $2)
+			AND (merchant_id IS NULL OR merchant_id = $1)
+			AND CASE type
+				WHEN 'PHONE' THEN value = $3
+				WHEN 'CARD' THEN value = $4
+				WHEN 'DEVICE' THEN value = $5
+				WHEN 'IP' THEN $6 <> '' AND value::cidr >>= NULLIF($6, '')::inet
+				ELSE false
+			END
+		ORDER BY merchant_id NULLS LAST, created_at`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		party.MerchantID, now, party.PhoneNumber, party.CardFingerprint, party.DeviceID, party.IPAddress,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match access list entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEntries(rows)
+}
+
+func (r *AccessListRepositoryImpl) RecordMatch(ctx context.Context, entryID, transactionID uuid.UUID, now time.Time) error {
+	return insertAudit(ctx, r.db, entryID, entity.AuditMatched, nil, &transactionID, "", now)
+}
+
+func (r *AccessListRepositoryImpl) ListAudit(ctx context.Context, entryID uuid.UUID) ([]entity.AuditEvent, error) {
+	query := `SELECT ` + auditColumns + ` FROM public.access_list_audit WHERE entry_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []entity.AuditEvent
+	for rows.Next() {
+		var event entity.AuditEvent
+		var action string
+		var actorID, transactionID uuid.NullUUID
+		var note sql.NullString
+		if err := rows.Scan(&event.ID, &event.EntryID, &action, &actorID, &transactionID, &note, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan access list audit event: %w", err)
+		}
+		event.Action = entity.AuditAction(action)
+		event.ActorID = nullUUID(actorID)
+		event.TransactionID = nullUUID(transactionID)
+		event.Note = note.String
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list access list audit events: %w", err)
+	}
+
+	return events, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEntries(rows *sql.Rows) ([]entity.Entry, error) {
+	var entries []entity.Entry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan access list entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read access list entries: %w", err)
+	}
+	return entries, nil
+}
+
+func scanEntry(row rowScanner) (*entity.Entry, error) {
+	var entry entity.Entry
+	var list, entryType, reasonCode string
+	var note sql.NullString
+	var merchantID, createdBy, updatedBy, removedBy uuid.NullUUID
+	var expiresAt, removedAt sql.NullTime
+
+	if err := row.Scan(
+		&entry.ID,
+		&merchantID,
+		&list,
+		&entryType,
+		&entry.Value,
+		&reasonCode,
+		&note,
+		&expiresAt,
+		&createdBy,
+		&updatedBy,
+		&removedBy,
+		&removedAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	entry.MerchantID = nullUUID(merchantID)
+	entry.List = entity.List(list)
+	entry.Type = entity.EntryType(entryType)
+	entry.ReasonCode = entity.ReasonCode(reasonCode)
+	entry.Note = note.String
+	entry.ExpiresAt = nullTime(expiresAt)
+	entry.CreatedBy = nullUUID(createdBy)
+	entry.UpdatedBy = nullUUID(updatedBy)
+	entry.RemovedBy = nullUUID(removedBy)
+	entry.RemovedAt = nullTime(removedAt)
+
+	return &entry, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullUUID(value uuid.NullUUID) *uuid.UUID {
+	if !value.Valid {
+		return nil
+	}
+	return &value.UUID
+}
+
+func nullTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
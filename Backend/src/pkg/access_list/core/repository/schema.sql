@@ -0,0 +1,39 @@
+-- Access List Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.access_list_entries (
+    id UUID PRIMARY KEY,
+    -- NULL for entries that apply to every merchant
+    merchant_id UUID REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    list VARCHAR(10) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    -- Normalized: +251XXXXXXXXX phones, lowercase card fingerprints, canonical CIDR ranges
+    value VARCHAR(255) NOT NULL,
+    reason_code VARCHAR(30) NOT NULL,
+    note TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID,
+    updated_by UUID,
+    removed_by UUID,
+    removed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A value is on a list at most once per scope
+CREATE UNIQUE INDEX IF NOT EXISTS idx_access_list_entries_value
+    ON public.access_list_entries(COALESCE(merchant_id, '00000000-0000-0000-0000-000000000000'::uuid), list, type, value)
+    WHERE removed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_list_entries_match ON public.access_list_entries(type, value) WHERE removed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS public.access_list_audit (
+    id UUID PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES public.access_list_entries(id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL,
+    actor_id UUID,
+    transaction_id UUID REFERENCES public.transactions(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_list_audit_entry ON public.access_list_audit(entry_id, created_at);
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/access_list/core/repository"
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const (
+	defaultEntryListLimit = 50
+	maxEntryListLimit     = 500
+)
+
+// AccessListUseCase defines the interface for the payer blocklist and allowlist
+type AccessListUseCase interface {
+	// Screen checks the parties to a stored transaction against the lists: the payer or payout phone
+	// number and the card, IP address and device of the request. It returns the block entry that
+	// refuses the transaction, or nil, and records the refusal in the entry's audit trail.
+	Screen(ctx context.Context, tx *txEntity.Transaction) (*entity.Entry, error)
+
+	// Check looks a value up as a transaction of the merchant would be screened, for support.
+	// Without a merchant only global entries are considered.
+	Check(ctx context.Context, req *entity.CheckRequest) (*entity.CheckResponse, error)
+
+	// CreateEntry adds a value to a list
+	CreateEntry(ctx context.Context, req *entity.EntryRequest, adminID uuid.UUID) (*entity.Entry, error)
+
+	// ImportEntries adds the entries of a CSV file. Nothing is imported when any row is invalid.
+	ImportEntries(ctx context.Context, content []byte, fileName string, adminID uuid.UUID) (*entity.ImportResponse, error)
+
+	// UpdateEntry changes the reason, note or expiry of an entry
+	UpdateEntry(ctx context.Context, id uuid.UUID, req *entity.EntryUpdateRequest, adminID uuid.UUID) (*entity.Entry, error)
+
+	// RemoveEntry takes a value off its list
+	RemoveEntry(ctx context.Context, id, adminID uuid.UUID, note string) error
+
+	// GetEntry retrieves an entry
+	GetEntry(ctx context.Context, id uuid.UUID) (*entity.Entry, error)
+
+	// ListEntries retrieves entries
+	ListEntries(ctx context.Context, filter entity.EntryFilter) (*entity.EntryListResponse, error)
+
+	// ListAudit retrieves the audit trail of an entry
+	ListAudit(ctx context.Context, id uuid.UUID) (*entity.AuditListResponse, error)
+}
+
+type accessListUseCase struct {
+	repo repository.AccessListRepository
+	log  logging.Logger
+}
+
+// NewAccessListUseCase creates the access list usecase
+func NewAccessListUseCase(repo repository.AccessListRepository) AccessListUseCase {
+	return &accessListUseCase{
+		repo: repo,
+		log:  logging.NewStdLogger("access_list_usecase"),
+	}
+}
+
+func (uc *accessListUseCase) Screen(ctx context.Context, tx *txEntity.Transaction) (*entity.Entry, error) {
+	signals := riskEntity.SignalsFromContext(ctx)
+	party := entity.Party{
+		MerchantID:      tx.MerchantId,
+		CardFingerprint: signals.CardFingerprint(),
+		DeviceID:        signals.DeviceID,
+	}
+	// Bank payouts carry an account number rather than a phone; those are not screened by phone
+	if phone, err := entity.NormalizePhone(tx.PhoneNumber); err == nil {
+		party.PhoneNumber = phone
+	}
+	if network, err := entity.NormalizeNetwork(signals.IPAddress); err == nil {
+		party.IPAddress = network
+	}
+	if party.PhoneNumber == "" && party.CardFingerprint == "" && party.DeviceID == "" && party.IPAddress == "" {
+		return nil, nil
+	}
+
+	now := time.Now()
+	matched, err := uc.repo.Match(ctx, party, now)
+	if err != nil {
+		return nil, err
+	}
+	block := entity.Blocking(matched)
+	if block == nil {
+		return nil, nil
+	}
+
+	uc.log.Warn("Transaction refused by access list", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"entry_id":       block.ID,
+		"type":           block.Type,
+		"reason_code":    block.ReasonCode,
+	})
+	if err := uc.repo.RecordMatch(ctx, block.ID, tx.Id, now); err != nil {
+		// The transaction is refused either way; only its line in the audit trail is missing
+		uc.log.Error("Failed to record access list match", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"entry_id":       block.ID,
+			"error":          err.Error(),
+		})
+	}
+
+	return block, nil
+}
+
+func (uc *accessListUseCase) Check(ctx context.Context, req *entity.CheckRequest) (*entity.CheckResponse, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	value, _ := entity.NormalizeValue(req.Type, req.Value)
+	party := entity.Party{}
+	if req.MerchantID != nil {
+		party.MerchantID = *req.MerchantID
+	}
+	switch req.Type {
+	case entity.TypePhone:
+		party.PhoneNumber = value
+	case entity.TypeCard:
+		party.CardFingerprint = value
+	case entity.TypeDevice:
+		party.DeviceID = value
+	case entity.TypeIP:
+		party.IPAddress = value
+	}
+
+	matched, err := uc.repo.Match(ctx, party, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if matched == nil {
+		matched = []entity.Entry{}
+	}
+
+	return &entity.CheckResponse{
+		Value:    value,
+		Blocked:  entity.Blocking(matched) != nil,
+		Matching: matched,
+	}, nil
+}
+
+func (uc *accessListUseCase) CreateEntry(ctx context.Context, req *entity.EntryRequest, adminID uuid.UUID) (*entity.Entry, error) {
+	now := time.Now()
+	req.Normalize()
+	if err := req.Validate(now); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	entry := req.Entry(adminID, now)
+	if err := uc.repo.Create(ctx, entry, entry.Note); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Access list entry added", map[string]interface{}{
+		"entry_id":    entry.ID,
+		"merchant_id": entry.MerchantID,
+		"list":        entry.List,
+		"type":        entry.Type,
+		"reason_code": entry.ReasonCode,
+		"expires_at":  entry.ExpiresAt,
+		"created_by":  adminID,
+	})
+
+	return entry, nil
+}
+
+func (uc *accessListUseCase) ImportEntries(ctx context.Context, content []byte, fileName string, adminID uuid.UUID) (*entity.ImportResponse, error) {
+	now := time.Now()
+	entries, skipped, rowErrors, err := parseImport(content, adminID, now)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if len(rowErrors) > 0 {
+		return &entity.ImportResponse{Errors: rowErrors}, nil
+	}
+
+	imported, err := uc.repo.Import(ctx, entries, "imported from "+fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &entity.ImportResponse{
+		Imported: imported,
+		Skipped:  skipped + len(entries) - imported,
+	}
+
+	uc.log.Info("Access list entries imported", map[string]interface{}{
+		"file_name":   fileName,
+		"imported":    response.Imported,
+		"skipped":     response.Skipped,
+		"imported_by": adminID,
+	})
+
+	return response, nil
+}
+
+func (uc *accessListUseCase) UpdateEntry(ctx context.Context, id uuid.UUID, req *entity.EntryUpdateRequest, adminID uuid.UUID) (*entity.Entry, error) {
+	now := time.Now()
+	req.Normalize()
+	if err := req.Validate(now); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	entry, err := uc.GetEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.RemovedAt != nil {
+		return nil, entity.ErrEntryRemoved
+	}
+
+	entry.ReasonCode = req.ReasonCode
+	entry.Note = req.Note
+	entry.ExpiresAt = req.ExpiresAt
+	entry.UpdatedBy = &adminID
+	entry.UpdatedAt = now
+	if err := uc.repo.Update(ctx, entry, req.Note); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Access list entry updated", map[string]interface{}{
+		"entry_id":    entry.ID,
+		"reason_code": entry.ReasonCode,
+		"expires_at":  entry.ExpiresAt,
+		"updated_by":  adminID,
+	})
+
+	return entry, nil
+}
+
+func (uc *accessListUseCase) RemoveEntry(ctx context.Context, id, adminID uuid.UUID, note string) error {
+	if _, err := uc.GetEntry(ctx, id); err != nil {
+		return err
+	}
+	if len(note) > entity.MaxNoteLength {
+		return fmt.Errorf("validation failed: note must be at most %d characters", entity.MaxNoteLength)
+	}
+
+	if err := uc.repo.Remove(ctx, id, adminID, note, time.Now()); err != nil {
+		return err
+	}
+
+	uc.log.Info("Access list entry removed", map[string]interface{}{
+		"entry_id":   id,
+		"note":       note,
+		"removed_by": adminID,
+	})
+	return nil
+}
+
+func (uc *accessListUseCase) GetEntry(ctx context.Context, id uuid.UUID) (*entity.Entry, error) {
+	entry, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, entity.ErrEntryNotFound
+	}
+	return entry, nil
+}
+
+func (uc *accessListUseCase) ListEntries(ctx context.Context, filter entity.EntryFilter) (*entity.EntryListResponse, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEntryListLimit
+	}
+	if filter.Limit > maxEntryListLimit {
+		filter.Limit = maxEntryListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if filter.Type != "" && filter.Value != "" {
+		value, err := entity.NormalizeValue(filter.Type, filter.Value)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: value: %w", err)
+		}
+		filter.Value = value
+	}
+
+	entries, total, err := uc.repo.List(ctx, filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []entity.Entry{}
+	}
+
+	return &entity.EntryListResponse{Entries: entries, Total: total}, nil
+}
+
+func (uc *accessListUseCase) ListAudit(ctx context.Context, id uuid.UUID) (*entity.AuditListResponse, error) {
+	if _, err := uc.GetEntry(ctx, id); err != nil {
+		return nil, err
+	}
+
+	events, err := uc.repo.ListAudit(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []entity.AuditEvent{}
+	}
+
+	return &entity.AuditListResponse{Events: events}, nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
+)
+
+// importZone is applied to expiry dates without an offset
+var importZone = time.FixedZone("EAT", 3*60*60)
+
+// importColumns are the columns of an import file; the first four are required
+var importColumns = []string{"list", "type", "value", "reason_code", "merchant_id", "expires_at", "note"}
+
+const requiredImportColumns = 4
+
+// parseImport reads the entries of a CSV file with a header row. Rows repeating an earlier row
+// are skipped; invalid rows are reported by their line number.
+func parseImport(content []byte, adminID uuid.UUID, now time.Time) ([]*entity.Entry, int, []entity.ImportError, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, 0, nil, errors.New("file is empty")
+		}
+		return nil, 0, nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importColumns[:requiredImportColumns] {
+		if _, ok := index[name]; !ok {
+			return nil, 0, nil, fmt.Errorf("missing column %q; expected %s", name, strings.Join(importColumns, ","))
+		}
+	}
+
+	var entries []*entity.Entry
+	var rowErrors []entity.ImportError
+	seen := make(map[string]bool)
+	skipped := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(entries)+len(rowErrors)+skipped >= entity.MaxImportRows {
+			return nil, 0, nil, fmt.Errorf("file has more than %d rows", entity.MaxImportRows)
+		}
+
+		field := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		req, err := importRequest(field)
+		if err == nil {
+			req.Normalize()
+			err = req.Validate(now)
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, entity.ImportError{Row: line, Error: err.Error()})
+			continue
+		}
+
+		entry := req.Entry(adminID, now)
+		scope := "global"
+		if entry.MerchantID != nil {
+			scope = entry.MerchantID.String()
+		}
+		key := strings.Join([]string{scope, string(entry.List), string(entry.Type), entry.Value}, "|")
+		if seen[key] {
+			skipped++
+			continue
+		}
+		seen[key] = true
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 && len(rowErrors) == 0 {
+		return nil, 0, nil, errors.New("file has no entries")
+	}
+	return entries, skipped, rowErrors, nil
+}
+
+func importRequest(field func(string) string) (*entity.EntryRequest, error) {
+	req := &entity.EntryRequest{
+		List:       entity.List(field("list")),
+		Type:       entity.EntryType(field("type")),
+		Value:      field("value"),
+		ReasonCode: entity.ReasonCode(field("reason_code")),
+		Note:       field("note"),
+	}
+	if merchantID := field("merchant_id"); merchantID != "" {
+		id, err := uuid.Parse(merchantID)
+		if err != nil {
+			return nil, errors.New("merchant_id: invalid UUID")
+		}
+		req.MerchantID = &id
+	}
+	if expiresAt := field("expires_at"); expiresAt != "" {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			if t, err = time.ParseInLocation("2006-01-02", expiresAt, importZone); err != nil {
+				return nil, errors.New("expires_at: use YYYY-MM-DD or RFC 3339")
+			}
+		}
+		req.ExpiresAt = &t
+	}
+	return req, nil
+}
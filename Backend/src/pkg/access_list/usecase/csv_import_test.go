@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseImport(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, importZone)
+	adminID := uuid.New()
+
+	t.Run("valid rows are normalized and repeats skipped", func(t *testing.T) {
+		content := "\ufeffList,Type,Value,Reason_Code,Merchant_ID,Expires_At,Note\n" +
+			"block,phone,0911234567,fraud,,2026-06-01,fake receipts\n" +
+			"BLOCK,PHONE,+251 911 234567,FRAUD,,,\n" +
+			"allow,ip,196.188.1.0/24,verified_customer," + uuid.NewString() + ",,office range\n"
+
+		entries, skipped, rowErrors, err := parseImport([]byte(content), adminID, now)
+		if err != nil || len(rowErrors) > 0 {
+			t.Fatalf("parseImport() err = %v, row errors = %v", err, rowErrors)
+		}
+		if len(entries) != 2 || skipped != 1 {
+			t.Fatalf("entries = %d, skipped = %d; want 2 and 1", len(entries), skipped)
+		}
+		if entries[0].Value != "+251911234567" || entries[0].ExpiresAt == nil || entries[0].Note != "fake receipts" {
+			t.Fatalf("first entry = %+v", entries[0])
+		}
+		if entries[1].MerchantID == nil || entries[1].Value != "196.188.1.0/24" {
+			t.Fatalf("second entry = %+v", entries[1])
+		}
+	})
+
+	t.Run("invalid rows are reported by line", func(t *testing.T) {
+		content := "list,type,value,reason_code\n" +
+			"BLOCK,PHONE,0911234567,FRAUD\n" +
+			"BLOCK,IP,not-an-ip,FRAUD\n" +
+			"BLOCK,EMAIL,a@b.c,FRAUD\n"
+
+		_, _, rowErrors, err := parseImport([]byte(content), adminID, now)
+		if err != nil {
+			t.Fatalf("parseImport() err = %v", err)
+		}
+		if len(rowErrors) != 2 || rowErrors[0].Row != 3 || rowErrors[1].Row != 4 {
+			t.Fatalf("row errors = %+v, want lines 3 and 4", rowErrors)
+		}
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		if _, _, _, err := parseImport([]byte("list,type,value\nBLOCK,PHONE,0911234567\n"), adminID, now); err == nil {
+			t.Fatal("parseImport() err = nil, want missing reason_code column")
+		}
+	})
+}
@@ -27,4 +27,5 @@ type ApiKey struct {
 	LastUsedAt        sql.NullTime   `json:"last_used_at"`
 	IsActive          bool           `json:"is_active"`
 	MerchantID        uuid.NullUUID  `json:"merchant_id"`
+	IsTest            bool           `json:"is_test"`
 }
@@ -1,15 +1,15 @@
 -- Common columns for reference:
 -- id, user_id, created_by, name, description, public_key, secret_key,
 -- can_withdrawal, can_process_payment, created_at, updated_at, 
--- expires_at, last_used_at, is_active, merchant_id
+-- expires_at, last_used_at, is_active, merchant_id, is_test
 
 -- name: CreateAPIKey :one
 INSERT INTO api_keys (
     id, user_id, created_by, name, description, public_key, secret_key,
     can_withdrawal, can_process_payment, created_at, updated_at, expires_at, 
-    is_active, merchant_id
+    is_active, merchant_id, is_test
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
 RETURNING *;
 
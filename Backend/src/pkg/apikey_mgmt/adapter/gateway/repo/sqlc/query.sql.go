@@ -18,11 +18,11 @@ const createAPIKey = `-- name: CreateAPIKey :one
 INSERT INTO api_keys (
     id, user_id, created_by, name, description, public_key, secret_key,
     can_withdrawal, can_process_payment, created_at, updated_at, expires_at, 
-    is_active, merchant_id
+    is_active, merchant_id, is_test
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
-RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id
+RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test
 `
 
 type CreateAPIKeyParams struct {
@@ -40,12 +40,13 @@ type CreateAPIKeyParams struct {
 	ExpiresAt         sql.NullTime   `json:"expires_at"`
 	IsActive          bool           `json:"is_active"`
 	MerchantID        uuid.NullUUID  `json:"merchant_id"`
+	IsTest            bool           `json:"is_test"`
 }
 
 // Common columns for reference:
 // id, user_id, created_by, name, description, public_key, secret_key,
 // can_withdrawal, can_process_payment, created_at, updated_at,
-// expires_at, last_used_at, is_active, merchant_id
+// expires_at, last_used_at, is_active, merchant_id, is_test
 func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error) {
 	row := q.db.QueryRowContext(ctx, createAPIKey,
 		arg.ID,
@@ -62,6 +63,7 @@ func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (Api
 		arg.ExpiresAt,
 		arg.IsActive,
 		arg.MerchantID,
+		arg.IsTest,
 	)
 	var i ApiKey
 	err := row.Scan(
@@ -80,6 +82,7 @@ func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (Api
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
@@ -95,7 +98,7 @@ func (q *Queries) DeleteAPIKey(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAPIKey = `-- name: GetAPIKey :one
-SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id FROM api_keys
+SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test FROM api_keys
 WHERE id = $1
 `
 
@@ -118,12 +121,13 @@ func (q *Queries) GetAPIKey(ctx context.Context, id uuid.UUID) (ApiKey, error) {
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
 
 const getAPIKeyByPublicKey = `-- name: GetAPIKeyByPublicKey :one
-SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id FROM api_keys
+SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test FROM api_keys
 WHERE public_key = $1
 `
 
@@ -146,12 +150,13 @@ func (q *Queries) GetAPIKeyByPublicKey(ctx context.Context, publicKey string) (A
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
 
 const getAPIKeyBySecretKey = `-- name: GetAPIKeyBySecretKey :one
-SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id FROM api_keys
+SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test FROM api_keys
 WHERE secret_key = $1
 `
 
@@ -174,12 +179,13 @@ func (q *Queries) GetAPIKeyBySecretKey(ctx context.Context, secretKey string) (A
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
 
 const listAPIKeys = `-- name: ListAPIKeys :many
-SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id FROM api_keys
+SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test FROM api_keys
 WHERE user_id = $1
 ORDER BY created_at DESC
 `
@@ -209,6 +215,7 @@ func (q *Queries) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]ApiKey,
 			&i.LastUsedAt,
 			&i.IsActive,
 			&i.MerchantID,
+			&i.IsTest,
 		); err != nil {
 			return nil, err
 		}
@@ -224,7 +231,7 @@ func (q *Queries) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]ApiKey,
 }
 
 const listMerchantAPIKeys = `-- name: ListMerchantAPIKeys :many
-SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id FROM api_keys
+SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test FROM api_keys
 WHERE merchant_id = $1
 ORDER BY created_at DESC
 `
@@ -254,6 +261,7 @@ func (q *Queries) ListMerchantAPIKeys(ctx context.Context, merchantID uuid.NullU
 			&i.LastUsedAt,
 			&i.IsActive,
 			&i.MerchantID,
+			&i.IsTest,
 		); err != nil {
 			return nil, err
 		}
@@ -273,7 +281,7 @@ UPDATE api_keys
 SET secret_key = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id
+RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test
 `
 
 type RotateAPIKeySecretParams struct {
@@ -300,6 +308,7 @@ func (q *Queries) RotateAPIKeySecret(ctx context.Context, arg RotateAPIKeySecret
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
@@ -315,7 +324,7 @@ SET name = COALESCE($2, name),
     merchant_id = COALESCE($8, merchant_id),
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id
+RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test
 `
 
 type UpdateAPIKeyParams struct {
@@ -357,6 +366,7 @@ func (q *Queries) UpdateAPIKey(ctx context.Context, arg UpdateAPIKeyParams) (Api
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
@@ -365,7 +375,7 @@ const updateLastUsedAt = `-- name: UpdateLastUsedAt :one
 UPDATE api_keys
 SET last_used_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id
+RETURNING id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test
 `
 
 func (q *Queries) UpdateLastUsedAt(ctx context.Context, id uuid.UUID) (ApiKey, error) {
@@ -387,12 +397,13 @@ func (q *Queries) UpdateLastUsedAt(ctx context.Context, id uuid.UUID) (ApiKey, e
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
 
 const validateAPIKey = `-- name: ValidateAPIKey :one
-SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id FROM api_keys
+SELECT id, user_id, created_by, name, description, public_key, secret_key, can_withdrawal, can_process_payment, created_at, updated_at, expires_at, last_used_at, is_active, merchant_id, is_test FROM api_keys
 WHERE public_key = $1 AND secret_key = $2 AND is_active = true
 `
 
@@ -420,6 +431,7 @@ func (q *Queries) ValidateAPIKey(ctx context.Context, arg ValidateAPIKeyParams)
 		&i.LastUsedAt,
 		&i.IsActive,
 		&i.MerchantID,
+		&i.IsTest,
 	)
 	return i, err
 }
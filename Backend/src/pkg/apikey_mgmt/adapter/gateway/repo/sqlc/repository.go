@@ -50,6 +50,7 @@ func (r *Repository) CreateAPIKey(ctx context.Context, apiKey *entity.APIKey) (*
 		UpdatedAt:         apiKey.UpdatedAt,
 		ExpiresAt:         expiresAt,
 		IsActive:          apiKey.IsActive,
+		IsTest:            apiKey.IsTest,
 	}
 
 	result, err := r.q.CreateAPIKey(ctx, params)
@@ -242,5 +243,6 @@ func convertToEntity(model ApiKey) *entity.APIKey {
 		ExpiresAt:         expiresAt,
 		LastUsedAt:        lastUsedAt,
 		IsActive:          model.IsActive,
+		IsTest:            model.IsTest,
 	}
 }
@@ -16,9 +16,12 @@ CREATE TABLE api_keys (
     merchant_id UUID REFERENCES merchants.merchants(id) ON DELETE SET NULL
 );
 
+-- Test keys route payments to the simulator and never touch live wallets
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS is_test BOOLEAN NOT NULL DEFAULT false;
+
 -- Indexes for better query performance
 CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
 CREATE INDEX IF NOT EXISTS idx_api_keys_public_key ON api_keys(public_key);
 CREATE INDEX IF NOT EXISTS idx_api_keys_created_at ON api_keys(created_at);
 CREATE INDEX IF NOT EXISTS idx_api_keys_is_active ON api_keys(is_active);
-CREATE INDEX IF NOT EXISTS idx_api_keys_merchant_id ON api_keys(merchant_id); 
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_api_keys_merchant_id ON api_keys(merchant_id);
@@ -56,6 +56,8 @@ type APIKey struct {
 	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
 	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
 	IsActive          bool       `json:"is_active"`
+	// IsTest keys run against the payment simulator and are isolated from live data
+	IsTest bool `json:"is_test"`
 }
 
 // APIKeyResponse represents an API key response without sensitive data
@@ -74,6 +76,8 @@ type APIKeyResponse struct {
 	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
 	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
 	IsActive          bool       `json:"is_active"`
+	// IsTest keys run against the payment simulator and are isolated from live data
+	IsTest bool `json:"is_test"`
 }
 
 // CreateAPIKeyRequest represents the data needed to create a new API key
@@ -83,6 +87,8 @@ type CreateAPIKeyRequest struct {
 	CanWithdrawal     *bool       `json:"can_withdrawal"`
 	CanProcessPayment *bool       `json:"can_process_payment"`
 	ExpiresAt         *CustomTime `json:"expires_at,omitempty"`
+	// Test issues a sandbox key that routes payments to the simulator
+	Test bool `json:"test"`
 }
 
 // GetExpiresAt returns the actual time.Time pointer from CustomTime
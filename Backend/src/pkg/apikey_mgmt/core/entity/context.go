@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type keyIDContextKey struct{}
+
+// WithKeyID marks a request as authenticated with the API key
+func WithKeyID(ctx context.Context, keyID uuid.UUID) context.Context {
+	return context.WithValue(ctx, keyIDContextKey{}, keyID)
+}
+
+// KeyIDFromContext returns the API key a request was authenticated with, if any
+func KeyIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	keyID, ok := ctx.Value(keyIDContextKey{}).(uuid.UUID)
+	return keyID, ok
+}
@@ -27,6 +27,14 @@ type APIKeyUseCase interface {
 	RotateAPIKeySecret(ctx context.Context, id uuid.UUID) (*entity.APIKeyRotateResponse, error)
 }
 
+// Key prefixes make it obvious whether a key talks to live processors or the simulator
+const (
+	livePublicKeyPrefix = "socialPUB_"
+	liveSecretKeyPrefix = "socialSEC_"
+	testPublicKeyPrefix = "socialPUB_test_"
+	testSecretKeyPrefix = "socialSEC_test_"
+)
+
 type apiKeyUseCase struct {
 	log  logging.Logger
 	repo repository.Repository
@@ -50,7 +58,11 @@ func (u *apiKeyUseCase) CreateAPIKey(ctx context.Context, userID, createdBy uuid
 		})
 		return nil, "", fmt.Errorf("failed to generate public key: %w", err)
 	}
-	publicKey := "socialPUB_" + publicKeyBase
+	publicPrefix, secretPrefix := livePublicKeyPrefix, liveSecretKeyPrefix
+	if request.Test {
+		publicPrefix, secretPrefix = testPublicKeyPrefix, testSecretKeyPrefix
+	}
+	publicKey := publicPrefix + publicKeyBase
 
 	secretKeyBase, err := generateRandomString(24) // 24 chars
 	if err != nil {
@@ -59,7 +71,7 @@ func (u *apiKeyUseCase) CreateAPIKey(ctx context.Context, userID, createdBy uuid
 		})
 		return nil, "", fmt.Errorf("failed to generate secret key: %w", err)
 	}
-	secretKey := "socialSEC_" + secretKeyBase
+	secretKey := secretPrefix + secretKeyBase
 
 	// Set default values for permissions if not provided
 	canWithdrawal := false
@@ -88,6 +100,7 @@ func (u *apiKeyUseCase) CreateAPIKey(ctx context.Context, userID, createdBy uuid
 		UpdatedAt:         time.Now(),
 		ExpiresAt:         request.GetExpiresAt(),
 		IsActive:          true,
+		IsTest:            request.Test,
 	}
 
 	// Save to repository
@@ -115,6 +128,7 @@ func (u *apiKeyUseCase) CreateAPIKey(ctx context.Context, userID, createdBy uuid
 		ExpiresAt:         apiKey.ExpiresAt,
 		LastUsedAt:        apiKey.LastUsedAt,
 		IsActive:          apiKey.IsActive,
+		IsTest:            apiKey.IsTest,
 	}
 
 	return response, secretKey, nil
@@ -149,6 +163,7 @@ func (u *apiKeyUseCase) GetAPIKey(ctx context.Context, id uuid.UUID) (*entity.AP
 		ExpiresAt:         apiKey.ExpiresAt,
 		LastUsedAt:        apiKey.LastUsedAt,
 		IsActive:          apiKey.IsActive,
+		IsTest:            apiKey.IsTest,
 	}
 
 	return response, nil
@@ -180,6 +195,7 @@ func (u *apiKeyUseCase) GetAPIKeysByUserID(ctx context.Context, userID uuid.UUID
 			ExpiresAt:   apiKey.ExpiresAt,
 			LastUsedAt:  apiKey.LastUsedAt,
 			IsActive:    apiKey.IsActive,
+			IsTest:      apiKey.IsTest,
 		}
 	}
 
@@ -214,6 +230,7 @@ func (u *apiKeyUseCase) GetAPIKeysByMerchantID(ctx context.Context, merchantID u
 			ExpiresAt:         apiKey.ExpiresAt,
 			LastUsedAt:        apiKey.LastUsedAt,
 			IsActive:          apiKey.IsActive,
+			IsTest:            apiKey.IsTest,
 		}
 	}
 
@@ -249,6 +266,7 @@ func (u *apiKeyUseCase) UpdateAPIKey(ctx context.Context, id uuid.UUID, request
 		ExpiresAt:         apiKey.ExpiresAt,
 		LastUsedAt:        apiKey.LastUsedAt,
 		IsActive:          apiKey.IsActive,
+		IsTest:            apiKey.IsTest,
 	}
 
 	return response, nil
@@ -335,6 +353,7 @@ func (u *apiKeyUseCase) ValidateAPIKey(ctx context.Context, publicKey, secretKey
 		ExpiresAt:         apiKey.ExpiresAt,
 		LastUsedAt:        apiKey.LastUsedAt,
 		IsActive:          apiKey.IsActive,
+		IsTest:            apiKey.IsTest,
 	}
 
 	return response, nil
@@ -4,22 +4,35 @@ package entity
 type Resource string
 
 const (
-	RESOURCE_ALL          Resource = "ALL"       // Special permission for merchants to access all resources
-	RESOURCE_ADMIN_ALL    Resource = "ADMIN_ALL" // Special permission for super admins to access all admin resources
-	RESOURCE_TRANSACTION  Resource = "transaction"
-	RESOURCE_MERCHANT     Resource = "merchant"
-	RESOURCE_USER         Resource = "user"
-	RESOURCE_ADMIN_WALLET Resource = "admin_wallet"
-	RESOURCE_IP_WHITELIST Resource = "ip_whitelist"
-	RESOURCE_API_KEY      Resource = "api_key"
-	RESOURCE_WEBHOOK      Resource = "webhook"
-	RESOURCE_ANALYTICS    Resource = "analytics"
-	RESOURCE_COMMISSION   Resource = "commission"
-	RESOURCE_QR           Resource = "qr"
-	RESOURCE_CHECKOUT     Resource = "checkout"
-	RESOURCE_NOTIFICATION Resource = "notification"
-	RESOURCE_WALLET       Resource = "wallet"
-	RESOURCE_TEAM         Resource = "team"
+	RESOURCE_ALL                 Resource = "ALL"       // Special permission for merchants to access all resources
+	RESOURCE_ADMIN_ALL           Resource = "ADMIN_ALL" // Special permission for super admins to access all admin resources
+	RESOURCE_TRANSACTION         Resource = "transaction"
+	RESOURCE_MERCHANT            Resource = "merchant"
+	RESOURCE_USER                Resource = "user"
+	RESOURCE_ADMIN_WALLET        Resource = "admin_wallet"
+	RESOURCE_IP_WHITELIST        Resource = "ip_whitelist"
+	RESOURCE_API_KEY             Resource = "api_key"
+	RESOURCE_WEBHOOK             Resource = "webhook"
+	RESOURCE_ANALYTICS           Resource = "analytics"
+	RESOURCE_COMMISSION          Resource = "commission"
+	RESOURCE_QR                  Resource = "qr"
+	RESOURCE_CHECKOUT            Resource = "checkout"
+	RESOURCE_NOTIFICATION        Resource = "notification"
+	RESOURCE_WALLET              Resource = "wallet"
+	RESOURCE_TEAM                Resource = "team"
+	RESOURCE_CUSTOMER            Resource = "customer"
+	RESOURCE_PROCESSOR_ACCOUNT   Resource = "processor_account"
+	RESOURCE_PROCESSOR_HEALTH    Resource = "processor_health"
+	RESOURCE_RECONCILIATION      Resource = "reconciliation"
+	RESOURCE_FX                  Resource = "fx"
+	RESOURCE_WITHDRAWAL_APPROVAL Resource = "withdrawal_approval"
+	RESOURCE_BENEFICIARY         Resource = "beneficiary"
+	RESOURCE_RISK                Resource = "risk"
+	RESOURCE_LIMITS              Resource = "limits"
+	RESOURCE_ACCESS_LIST         Resource = "access_list"
+	RESOURCE_KYC                 Resource = "kyc"
+	RESOURCE_RESERVE             Resource = "reserve"
+	RESOURCE_DISPUTE             Resource = "dispute"
 )
 
 // Operation represents different operations that can be performed
@@ -33,6 +46,8 @@ const (
 	OPERATION_UPDATE         Operation = "UPDATE"
 	OPERATION_DELETE         Operation = "DELETE"
 	OPERATION_EXPORT         Operation = "EXPORT"
+	OPERATION_APPROVE        Operation = "APPROVE" // Decide on requests held for a second team member
+	OPERATION_ADJUST         Operation = "ADJUST"  // Request and approve manual wallet adjustments
 	OPERATION_ADMIN_CREATE   Operation = "ADMIN_CREATE"
 	OPERATION_ADMIN_READ     Operation = "ADMIN_READ"
 	OPERATION_ADMIN_UPDATE   Operation = "ADMIN_UPDATE"
@@ -127,6 +127,7 @@ func (s *AuthSeeder) getDefaultRBACConfig() *RBACConfig {
 			{Name: "DELETE", Description: "Delete operation", IsAdminOperation: false},
 			{Name: "ADMIN_READ", Description: "Admin-level read operation", IsAdminOperation: true},
 			{Name: "ADMIN_WRITE", Description: "Admin-level write operation", IsAdminOperation: true},
+			{Name: "ADJUST", Description: "Request and approve manual wallet adjustments", IsAdminOperation: true},
 		},
 		Resources: []ResourceConfig{
 			{Name: "transaction", Description: "Transaction management"},
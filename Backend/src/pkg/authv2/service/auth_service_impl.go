@@ -386,7 +386,7 @@ func (s *AuthServiceImpl) VerifyOTP(ctx context.Context, req *entity.VerifyOTPRe
 		for merchantID := range groups {
 			merchantUUID, err := uuid.Parse(merchantID)
 			if err != nil {
-				s.logger.Printf("Error parsing merchant ID: %w", err)
+				s.logger.Printf("Error parsing merchant ID: %v", err)
 				continue
 			}
 
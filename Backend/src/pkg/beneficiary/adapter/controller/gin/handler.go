@@ -0,0 +1,333 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/beneficiary/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/beneficiary/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	"github.com/socialpay/socialpay/src/pkg/shared/pagination"
+)
+
+type Handler struct {
+	beneficiaryUseCase usecase.BeneficiaryUseCase
+	log                logging.Logger
+	jwtMiddleware      gin.HandlerFunc
+	rbac               *ginMiddleware.RBACV2
+}
+
+func NewHandler(beneficiaryUseCase usecase.BeneficiaryUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		beneficiaryUseCase: beneficiaryUseCase,
+		log:                logging.NewStdLogger("beneficiary_handler"),
+		jwtMiddleware:      jwtMiddleware,
+		rbac:               rbac,
+	}
+}
+
+// RegisterRouter sets up the beneficiary routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	beneficiaries := router.Group("/beneficiaries", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	beneficiaries.POST("",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_BENEFICIARY, auth_entity.OPERATION_CREATE),
+		h.CreateBeneficiary)
+	beneficiaries.GET("",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_BENEFICIARY, auth_entity.OPERATION_READ),
+		h.ListBeneficiaries)
+	beneficiaries.GET("/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_BENEFICIARY, auth_entity.OPERATION_READ),
+		h.GetBeneficiary)
+	beneficiaries.PATCH("/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_BENEFICIARY, auth_entity.OPERATION_UPDATE),
+		h.UpdateBeneficiary)
+	beneficiaries.DELETE("/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_BENEFICIARY, auth_entity.OPERATION_DELETE),
+		h.DeleteBeneficiary)
+	beneficiaries.POST("/:id/verify",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_BENEFICIARY, auth_entity.OPERATION_UPDATE),
+		h.VerifyBeneficiary)
+	beneficiaries.GET("/:id/payouts",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_BENEFICIARY, auth_entity.OPERATION_READ),
+		h.PayoutHistory)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// CreateBeneficiary godoc
+// @Summary      Add beneficiary
+// @Description  Save a payout destination. Where the medium's provider supports it, the account holder is looked up and returned as verified_name; an account the provider does not know is refused. Live payouts to the beneficiary are allowed once its 24 hour cooling-off period ends (active_from).
+// @Tags         Beneficiaries
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.CreateBeneficiaryRequest  true  "Beneficiary"
+// @Success      201  {object}  entity.Beneficiary
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /beneficiaries [post]
+func (h *Handler) CreateBeneficiary(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+	userID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.CreateBeneficiaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	beneficiary, err := h.beneficiaryUseCase.CreateBeneficiary(c.Request.Context(), merchantID, userID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, beneficiary)
+}
+
+// ListBeneficiaries godoc
+// @Summary      List beneficiaries
+// @Description  List the saved payout destinations of the authenticated merchant, latest first
+// @Tags         Beneficiaries
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q          query  string  false  "Search by name or account number"
+// @Param        page       query  int     false  "Page number"
+// @Param        page_size  query  int     false  "Page size"
+// @Success      200  {object}  entity.BeneficiaryListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /beneficiaries [get]
+func (h *Handler) ListBeneficiaries(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	pag, err := pagination.NewPagination(c, h.log)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.beneficiaryUseCase.ListBeneficiaries(c.Request.Context(), merchantID, c.Query("q"), pag)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBeneficiary godoc
+// @Summary      Get beneficiary
+// @Description  Get a saved payout destination
+// @Tags         Beneficiaries
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Beneficiary ID"
+// @Success      200  {object}  entity.Beneficiary
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /beneficiaries/{id} [get]
+func (h *Handler) GetBeneficiary(c *gin.Context) {
+	id, merchantID, ok := h.beneficiaryAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	beneficiary, err := h.beneficiaryUseCase.GetBeneficiary(c.Request.Context(), merchantID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, beneficiary)
+}
+
+// UpdateBeneficiary godoc
+// @Summary      Rename beneficiary
+// @Description  Rename a saved payout destination. The destination itself cannot change; save a new beneficiary instead.
+// @Tags         Beneficiaries
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                           true  "Beneficiary ID"
+// @Param        request  body  entity.UpdateBeneficiaryRequest  true  "New name"
+// @Success      200  {object}  entity.Beneficiary
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /beneficiaries/{id} [patch]
+func (h *Handler) UpdateBeneficiary(c *gin.Context) {
+	id, merchantID, ok := h.beneficiaryAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdateBeneficiaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	beneficiary, err := h.beneficiaryUseCase.UpdateBeneficiary(c.Request.Context(), merchantID, id, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, beneficiary)
+}
+
+// DeleteBeneficiary godoc
+// @Summary      Delete beneficiary
+// @Description  Remove a saved payout destination. Its payout history is kept.
+// @Tags         Beneficiaries
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Beneficiary ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /beneficiaries/{id} [delete]
+func (h *Handler) DeleteBeneficiary(c *gin.Context) {
+	id, merchantID, ok := h.beneficiaryAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	if err := h.beneficiaryUseCase.DeleteBeneficiary(c.Request.Context(), merchantID, id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// VerifyBeneficiary godoc
+// @Summary      Verify beneficiary
+// @Description  Look up the holder of a beneficiary's account again, where the medium's provider supports it
+// @Tags         Beneficiaries
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Beneficiary ID"
+// @Success      200  {object}  entity.Beneficiary
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /beneficiaries/{id}/verify [post]
+func (h *Handler) VerifyBeneficiary(c *gin.Context) {
+	id, merchantID, ok := h.beneficiaryAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	beneficiary, err := h.beneficiaryUseCase.VerifyBeneficiary(c.Request.Context(), merchantID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, beneficiary)
+}
+
+// PayoutHistory godoc
+// @Summary      Beneficiary payout history
+// @Description  List the withdrawals to a beneficiary, latest first, with the total paid out successfully per currency
+// @Tags         Beneficiaries
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path   string  true   "Beneficiary ID"
+// @Param        page       query  int     false  "Page number"
+// @Param        page_size  query  int     false  "Page size"
+// @Success      200  {object}  entity.PayoutHistoryResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /beneficiaries/{id}/payouts [get]
+func (h *Handler) PayoutHistory(c *gin.Context) {
+	id, merchantID, ok := h.beneficiaryAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	pag, err := pagination.NewPagination(c, h.log)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.beneficiaryUseCase.PayoutHistory(c.Request.Context(), merchantID, id, pag)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) beneficiaryAndMerchant(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid beneficiary ID")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, merchantID, true
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrBeneficiaryNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrBeneficiaryExists):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case errors.Is(err, entity.ErrAccountNotFound):
+		c.JSON(http.StatusUnprocessableEntity, newErrorResponse(err))
+	default:
+		h.log.Error("Beneficiary request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
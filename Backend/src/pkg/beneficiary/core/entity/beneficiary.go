@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+var (
+	// ErrBeneficiaryNotFound is returned when a merchant has no beneficiary with the given ID
+	ErrBeneficiaryNotFound = errors.New("beneficiary not found")
+	// ErrBeneficiaryExists is returned when the merchant already saved the destination
+	ErrBeneficiaryExists = errors.New("a beneficiary with this medium and account number already exists")
+	// ErrBeneficiaryCoolingOff is returned when paying out to a beneficiary before its cooling-off period ends
+	ErrBeneficiaryCoolingOff = errors.New("beneficiary was added recently and cannot receive payouts yet")
+	// ErrAccountNotFound is returned when the provider reports that the destination does not exist
+	ErrAccountNotFound = errors.New("account not found at provider")
+)
+
+// CoolingOffPeriod is how long a newly added beneficiary waits before it can receive live payouts,
+// giving the merchant's team time to notice a beneficiary added from a compromised account
+const CoolingOffPeriod = 24 * time.Hour
+
+var accountNumberPattern = regexp.MustCompile(`^\+?[0-9]{5,20}$`)
+
+// VerificationStatus is what the provider confirmed about a beneficiary's account
+type VerificationStatus string
+
+const (
+	// VerificationVerified means the provider returned the account holder's name
+	VerificationVerified VerificationStatus = "VERIFIED"
+	// VerificationUnverified means the medium's provider has no lookup, or the lookup could not be completed
+	VerificationUnverified VerificationStatus = "UNVERIFIED"
+)
+
+// Beneficiary is a saved payout destination of a merchant
+type Beneficiary struct {
+	ID         uuid.UUID                  `json:"id"`
+	MerchantID uuid.UUID                  `json:"merchant_id"`
+	Name       string                     `json:"name"`
+	Medium     txEntity.TransactionMedium `json:"medium"`
+	// AccountNumber is the wallet phone number or bank account number paid out to
+	AccountNumber string `json:"account_number"`
+	BankCode      string `json:"bank_code,omitempty"`
+
+	VerificationStatus VerificationStatus `json:"verification_status"`
+	// VerifiedName is the account holder's name as returned by the provider
+	VerifiedName string     `json:"verified_name,omitempty"`
+	VerifiedAt   *time.Time `json:"verified_at,omitempty"`
+
+	// ActiveFrom is when the cooling-off period ends and live payouts are allowed
+	ActiveFrom time.Time  `json:"active_from"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"-"`
+}
+
+// CoolingOff reports whether the beneficiary is still in its cooling-off period
+func (b *Beneficiary) CoolingOff(now time.Time) bool {
+	return now.Before(b.ActiveFrom)
+}
+
+// CreateBeneficiaryRequest saves a payout destination
+// @Description Payout destination to save
+type CreateBeneficiaryRequest struct {
+	Name   string                     `json:"name" example:"Abebe Kebede"`
+	Medium txEntity.TransactionMedium `json:"medium" example:"TELEBIRR"`
+	// Wallet phone number or bank account number
+	AccountNumber string `json:"account_number" example:"251911234567"`
+	// Bank of the account, for bank account payouts
+	BankCode string `json:"bank_code,omitempty" example:"CBETETAA"`
+}
+
+// Normalize trims the request
+func (r *CreateBeneficiaryRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Medium = txEntity.TransactionMedium(strings.ToUpper(strings.TrimSpace(string(r.Medium))))
+	r.AccountNumber = strings.TrimPrefix(strings.ReplaceAll(strings.TrimSpace(r.AccountNumber), " ", ""), "+")
+	r.BankCode = strings.ToUpper(strings.TrimSpace(r.BankCode))
+}
+
+// Validate validates the beneficiary
+func (r *CreateBeneficiaryRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Medium, validation.Required, validation.In(payoutMediums()...)),
+		validation.Field(&r.AccountNumber, validation.Required, validation.Match(accountNumberPattern)),
+		validation.Field(&r.BankCode, validation.Length(0, 20)),
+	)
+}
+
+func payoutMediums() []interface{} {
+	mediums := make([]interface{}, len(txEntity.PayoutMediums))
+	for i, medium := range txEntity.PayoutMediums {
+		mediums[i] = medium
+	}
+	return mediums
+}
+
+// UpdateBeneficiaryRequest renames a beneficiary. The destination cannot change; a new
+// destination is a new beneficiary with its own cooling-off period.
+// @Description Beneficiary details that can change
+type UpdateBeneficiaryRequest struct {
+	Name string `json:"name" example:"Abebe Kebede"`
+}
+
+// Validate validates the update
+func (r *UpdateBeneficiaryRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+	)
+}
+
+// BeneficiaryListResponse lists the beneficiaries of a merchant
+type BeneficiaryListResponse struct {
+	Beneficiaries []Beneficiary `json:"beneficiaries"`
+	Total         int64         `json:"total"`
+	Page          int           `json:"page"`
+	Limit         int           `json:"limit"`
+}
+
+// Payout is a withdrawal made to a beneficiary
+type Payout struct {
+	TransactionID uuid.UUID                  `json:"transaction_id"`
+	Amount        float64                    `json:"amount"`
+	Currency      string                     `json:"currency"`
+	Status        txEntity.TransactionStatus `json:"status"`
+	Reference     string                     `json:"reference"`
+	Test          bool                       `json:"test"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+// PayoutHistoryResponse lists the payouts to a beneficiary, latest first
+// @Description Payouts to a beneficiary with the total paid out successfully
+type PayoutHistoryResponse struct {
+	BeneficiaryID uuid.UUID `json:"beneficiary_id"`
+	Payouts       []Payout  `json:"payouts"`
+	// TotalPaid sums the successful live payouts per currency
+	TotalPaid map[string]float64 `json:"total_paid"`
+	Total     int64              `json:"total"`
+	Page      int                `json:"page"`
+	Limit     int                `json:"limit"`
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestCreateBeneficiaryRequest(t *testing.T) {
+	t.Run("destination is normalized", func(t *testing.T) {
+		req := &CreateBeneficiaryRequest{Name: " Abebe ", Medium: "telebirr", AccountNumber: "+251 911 234 567", BankCode: " cbetetaa"}
+		req.Normalize()
+		if err := req.Validate(); err != nil {
+			t.Fatalf("Validate() = %v", err)
+		}
+		if req.AccountNumber != "251911234567" || req.Medium != txEntity.TELEBIRR || req.BankCode != "CBETETAA" || req.Name != "Abebe" {
+			t.Fatalf("normalized request = %+v", req)
+		}
+	})
+
+	t.Run("medium must support payouts", func(t *testing.T) {
+		req := &CreateBeneficiaryRequest{Name: "Abebe", Medium: txEntity.ETHSWITCH, AccountNumber: "251911234567"}
+		if err := req.Validate(); err == nil {
+			t.Fatal("Validate() = nil, want error for a medium without payouts")
+		}
+	})
+
+	t.Run("account number is digits", func(t *testing.T) {
+		req := &CreateBeneficiaryRequest{Name: "Abebe", Medium: txEntity.CBE, AccountNumber: "1000-abc"}
+		if err := req.Validate(); err == nil {
+			t.Fatal("Validate() = nil, want error for a malformed account number")
+		}
+	})
+}
+
+func TestBeneficiaryCoolingOff(t *testing.T) {
+	created := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	beneficiary := &Beneficiary{CreatedAt: created, ActiveFrom: created.Add(CoolingOffPeriod)}
+
+	if !beneficiary.CoolingOff(created.Add(time.Hour)) {
+		t.Fatal("CoolingOff() an hour after creation = false, want true")
+	}
+	if beneficiary.CoolingOff(created.Add(CoolingOffPeriod)) {
+		t.Fatal("CoolingOff() at active_from = true, want false")
+	}
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/beneficiary/core/entity"
+)
+
+// BeneficiaryRepository defines the interface for saved payout destinations
+type BeneficiaryRepository interface {
+	// Create stores a beneficiary. It returns entity.ErrBeneficiaryExists when the merchant already saved the destination.
+	Create(ctx context.Context, beneficiary *entity.Beneficiary) error
+
+	// GetByID retrieves a beneficiary of a merchant that was not deleted, nil when none exists
+	GetByID(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error)
+
+	// List retrieves the beneficiaries of a merchant, latest first, with their total count
+	List(ctx context.Context, merchantID uuid.UUID, search string, limit, offset int) ([]entity.Beneficiary, int64, error)
+
+	// UpdateName renames a beneficiary
+	UpdateName(ctx context.Context, merchantID, id uuid.UUID, name string) (*entity.Beneficiary, error)
+
+	// UpdateVerification records the result of an account lookup
+	UpdateVerification(ctx context.Context, beneficiary *entity.Beneficiary) error
+
+	// Delete removes a beneficiary from the merchant's list; its payout history is kept
+	Delete(ctx context.Context, merchantID, id uuid.UUID) error
+
+	// ListPayouts retrieves the withdrawals to a beneficiary, latest first, with their total count
+	ListPayouts(ctx context.Context, merchantID, beneficiaryID uuid.UUID, limit, offset int) ([]entity.Payout, int64, error)
+
+	// TotalPaid sums the successful live withdrawals to a beneficiary per currency
+	TotalPaid(ctx context.Context, merchantID, beneficiaryID uuid.UUID) (map[string]float64, error)
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/socialpay/socialpay/src/pkg/beneficiary/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const beneficiaryColumns = `id, merchant_id, name, medium, account_number, bank_code, verification_status, verified_name, verified_at, active_from, created_by, created_at, updated_at, deleted_at`
+
+// payoutWhere matches the withdrawals recorded against a beneficiary
+const payoutWhere = `WHERE merchant_id = $1 AND type = 'WITHDRAWAL' AND details->>'beneficiary_id' = $2`
+
+type BeneficiaryRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewBeneficiaryRepository(db *sql.DB) BeneficiaryRepository {
+	return &BeneficiaryRepositoryImpl{db: db}
+}
+
+func (r *BeneficiaryRepositoryImpl) Create(ctx context.Context, beneficiary *entity.Beneficiary) error {
+	query := `
+		INSERT INTO public.beneficiaries (` + beneficiaryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		beneficiary.ID, beneficiary.MerchantID, beneficiary.Name, string(beneficiary.Medium),
+		beneficiary.AccountNumber, nullString(beneficiary.BankCode), string(beneficiary.VerificationStatus),
+		nullString(beneficiary.VerifiedName), beneficiary.VerifiedAt, beneficiary.ActiveFrom,
+		beneficiary.CreatedBy, beneficiary.CreatedAt, beneficiary.UpdatedAt, beneficiary.DeletedAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return entity.ErrBeneficiaryExists
+		}
+		return fmt.Errorf("failed to create beneficiary: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BeneficiaryRepositoryImpl) GetByID(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error) {
+	query := `SELECT ` + beneficiaryColumns + ` FROM public.beneficiaries
+		WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL`
+
+	beneficiary, err := scanBeneficiary(r.db.QueryRowContext(ctx, query, merchantID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get beneficiary: %w", err)
+	}
+
+	return beneficiary, nil
+}
+
+func (r *BeneficiaryRepositoryImpl) List(ctx context.Context, merchantID uuid.UUID, search string, limit, offset int) ([]entity.Beneficiary, int64, error) {
+	where := `WHERE merchant_id = $1 AND deleted_at IS NULL`
+	args := []interface{}{merchantID}
+	if search = strings.TrimSpace(search); search != "" {
+		args = append(args, "%"+search+"%")
+		where += fmt.Sprintf(` AND (name ILIKE $%d OR account_number ILIKE $%d OR verified_name ILIKE $%d)`, len(args), len(args), len(args))
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM public.beneficiaries `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count beneficiaries: %w", err)
+	}
+
+	args = append(args, limit, offset)
+	query := fmt.Sprintf(`SELECT %s FROM public.beneficiaries %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
+		beneficiaryColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list beneficiaries: %w", err)
+	}
+	defer rows.Close()
+
+	beneficiaries := []entity.Beneficiary{}
+	for rows.Next() {
+		beneficiary, err := scanBeneficiary(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan beneficiary: %w", err)
+		}
+		beneficiaries = append(beneficiaries, *beneficiary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list beneficiaries: %w", err)
+	}
+
+	return beneficiaries, total, nil
+}
+
+func (r *BeneficiaryRepositoryImpl) UpdateName(ctx context.Context, merchantID, id uuid.UUID, name string) (*entity.Beneficiary, error) {
+	query := `
+		UPDATE public.beneficiaries
+		SET name = $3, updated_at = NOW()
+		WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL
+		RETURNING ` + beneficiaryColumns
+
+	beneficiary, err := scanBeneficiary(r.db.QueryRowContext(ctx, query, merchantID, id, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update beneficiary: %w", err)
+	}
+
+	return beneficiary, nil
+}
+
+func (r *BeneficiaryRepositoryImpl) UpdateVerification(ctx context.Context, beneficiary *entity.Beneficiary) error {
+	query := `
+		UPDATE public.beneficiaries
+		SET verification_status = $3, verified_name = $4, verified_at = $5, updated_at = NOW()
+		WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query,
+		beneficiary.MerchantID, beneficiary.ID, string(beneficiary.VerificationStatus),
+		nullString(beneficiary.VerifiedName), beneficiary.VerifiedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update beneficiary verification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BeneficiaryRepositoryImpl) Delete(ctx context.Context, merchantID, id uuid.UUID) error {
+	query := `
+		UPDATE public.beneficiaries
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, merchantID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete beneficiary: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete beneficiary: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrBeneficiaryNotFound
+	}
+
+	return nil
+}
+
+func (r *BeneficiaryRepositoryImpl) ListPayouts(ctx context.Context, merchantID, beneficiaryID uuid.UUID, limit, offset int) ([]entity.Payout, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM public.transactions `+payoutWhere,
+		merchantID, beneficiaryID.String()).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count beneficiary payouts: %w", err)
+	}
+
+	query := `
+		SELECT id, base_amount, COALESCE(currency, 'ETB'), status, COALESCE(reference, ''), COALESCE(test, false), created_at
+		FROM public.transactions
+		` + payoutWhere + `
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID, beneficiaryID.String(), limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list beneficiary payouts: %w", err)
+	}
+	defer rows.Close()
+
+	payouts := []entity.Payout{}
+	for rows.Next() {
+		var payout entity.Payout
+		var status string
+		if err := rows.Scan(&payout.TransactionID, &payout.Amount, &payout.Currency, &status,
+			&payout.Reference, &payout.Test, &payout.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan beneficiary payout: %w", err)
+		}
+		payout.Status = txEntity.TransactionStatus(status)
+		payouts = append(payouts, payout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list beneficiary payouts: %w", err)
+	}
+
+	return payouts, total, nil
+}
+
+func (r *BeneficiaryRepositoryImpl) TotalPaid(ctx context.Context, merchantID, beneficiaryID uuid.UUID) (map[string]float64, error) {
+	query := `
+		SELECT COALESCE(currency, 'ETB'), SUM(base_amount)
+		FROM public.transactions
+		` + payoutWhere + ` AND status = 'SUCCESS' AND COALESCE(test, false) = false
+		GROUP BY COALESCE(currency, 'ETB')`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID, beneficiaryID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to total beneficiary payouts: %w", err)
+	}
+	defer rows.Close()
+
+	totals := map[string]float64{}
+	for rows.Next() {
+		var currency string
+		var amount float64
+		if err := rows.Scan(&currency, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan beneficiary payout total: %w", err)
+		}
+		totals[currency] = amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to total beneficiary payouts: %w", err)
+	}
+
+	return totals, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBeneficiary(row rowScanner) (*entity.Beneficiary, error) {
+	var beneficiary entity.Beneficiary
+	var medium, status string
+	var bankCode, verifiedName sql.NullString
+	var verifiedAt, deletedAt sql.NullTime
+
+	err := row.Scan(
+		&beneficiary.ID,
+		&beneficiary.MerchantID,
+		&beneficiary.Name,
+		&medium,
+		&beneficiary.AccountNumber,
+		&bankCode,
+		&status,
+		&verifiedName,
+		&verifiedAt,
+		&beneficiary.ActiveFrom,
+		&beneficiary.CreatedBy,
+		&beneficiary.CreatedAt,
+		&beneficiary.UpdatedAt,
+		&deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	beneficiary.Medium = txEntity.TransactionMedium(medium)
+	beneficiary.VerificationStatus = entity.VerificationStatus(status)
+	beneficiary.BankCode = bankCode.String
+	beneficiary.VerifiedName = verifiedName.String
+	if verifiedAt.Valid {
+		beneficiary.VerifiedAt = &verifiedAt.Time
+	}
+	if deletedAt.Valid {
+		beneficiary.DeletedAt = &deletedAt.Time
+	}
+
+	return &beneficiary, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
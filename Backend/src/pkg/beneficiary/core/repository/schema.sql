@@ -0,0 +1,32 @@
+-- Beneficiary Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.beneficiaries (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    medium VARCHAR(50) NOT NULL,
+    -- Wallet phone number or bank account number
+    account_number VARCHAR(20) NOT NULL,
+    bank_code VARCHAR(20),
+    verification_status VARCHAR(20) NOT NULL DEFAULT 'UNVERIFIED',
+    verified_name VARCHAR(255),
+    verified_at TIMESTAMP WITH TIME ZONE,
+    -- End of the cooling-off period
+    active_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A destination is saved once per merchant; deleted beneficiaries keep their payout history
+CREATE UNIQUE INDEX IF NOT EXISTS idx_beneficiaries_destination
+    ON public.beneficiaries(merchant_id, medium, account_number, COALESCE(bank_code, ''))
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_beneficiaries_merchant ON public.beneficiaries(merchant_id, created_at DESC);
+
+-- Withdrawals record the beneficiary they pay out to in their details
+CREATE INDEX IF NOT EXISTS idx_transactions_beneficiary
+    ON public.transactions((details->>'beneficiary_id'))
+    WHERE type = 'WITHDRAWAL';
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/beneficiary/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/beneficiary/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/shared/pagination"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+)
+
+// AccountVerifier looks up the holder of a payout destination. The payment service implements it.
+type AccountVerifier interface {
+	VerifyAccount(ctx context.Context, req *payment.AccountVerificationRequest) (*payment.AccountVerificationResponse, error)
+}
+
+// BeneficiaryUseCase defines the interface for saved payout destinations
+type BeneficiaryUseCase interface {
+	// CreateBeneficiary saves a payout destination, looking up its holder where the provider supports it
+	CreateBeneficiary(ctx context.Context, merchantID, userID uuid.UUID, req *entity.CreateBeneficiaryRequest) (*entity.Beneficiary, error)
+
+	// GetBeneficiary retrieves a beneficiary of a merchant
+	GetBeneficiary(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error)
+
+	// ListBeneficiaries retrieves the beneficiaries of a merchant, optionally filtered by name or account number
+	ListBeneficiaries(ctx context.Context, merchantID uuid.UUID, search string, pag *pagination.Pagination) (*entity.BeneficiaryListResponse, error)
+
+	// UpdateBeneficiary renames a beneficiary
+	UpdateBeneficiary(ctx context.Context, merchantID, id uuid.UUID, req *entity.UpdateBeneficiaryRequest) (*entity.Beneficiary, error)
+
+	// DeleteBeneficiary removes a beneficiary; its payout history is kept
+	DeleteBeneficiary(ctx context.Context, merchantID, id uuid.UUID) error
+
+	// VerifyBeneficiary looks up the holder of a beneficiary's account again
+	VerifyBeneficiary(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error)
+
+	// ResolvePayout returns the beneficiary a withdrawal pays out to. Live withdrawals are refused
+	// with entity.ErrBeneficiaryCoolingOff until the beneficiary's cooling-off period ends.
+	ResolvePayout(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error)
+
+	// PayoutHistory retrieves the withdrawals to a beneficiary, latest first
+	PayoutHistory(ctx context.Context, merchantID, id uuid.UUID, pag *pagination.Pagination) (*entity.PayoutHistoryResponse, error)
+}
+
+type beneficiaryUseCase struct {
+	repo     repository.BeneficiaryRepository
+	verifier AccountVerifier
+	log      logging.Logger
+}
+
+// NewBeneficiaryUseCase creates the beneficiary usecase
+func NewBeneficiaryUseCase(repo repository.BeneficiaryRepository, verifier AccountVerifier) BeneficiaryUseCase {
+	return &beneficiaryUseCase{
+		repo:     repo,
+		verifier: verifier,
+		log:      logging.NewStdLogger("beneficiary_usecase"),
+	}
+}
+
+func (uc *beneficiaryUseCase) CreateBeneficiary(ctx context.Context, merchantID, userID uuid.UUID, req *entity.CreateBeneficiaryRequest) (*entity.Beneficiary, error) {
+	now := time.Now()
+	beneficiary := &entity.Beneficiary{
+		ID:                 uuid.New(),
+		MerchantID:         merchantID,
+		Name:               req.Name,
+		Medium:             req.Medium,
+		AccountNumber:      req.AccountNumber,
+		BankCode:           req.BankCode,
+		VerificationStatus: entity.VerificationUnverified,
+		ActiveFrom:         now.Add(entity.CoolingOffPeriod),
+		CreatedBy:          userID,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	if err := uc.verify(ctx, beneficiary); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(ctx, beneficiary); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Beneficiary added", map[string]interface{}{
+		"beneficiary_id":      beneficiary.ID,
+		"merchant_id":         merchantID,
+		"medium":              beneficiary.Medium,
+		"verification_status": beneficiary.VerificationStatus,
+		"active_from":         beneficiary.ActiveFrom,
+		"created_by":          userID,
+	})
+
+	return beneficiary, nil
+}
+
+func (uc *beneficiaryUseCase) GetBeneficiary(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error) {
+	beneficiary, err := uc.repo.GetByID(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if beneficiary == nil {
+		return nil, entity.ErrBeneficiaryNotFound
+	}
+	return beneficiary, nil
+}
+
+func (uc *beneficiaryUseCase) ListBeneficiaries(ctx context.Context, merchantID uuid.UUID, search string, pag *pagination.Pagination) (*entity.BeneficiaryListResponse, error) {
+	beneficiaries, total, err := uc.repo.List(ctx, merchantID, search, pag.PerPage, pag.GetOffset())
+	if err != nil {
+		uc.log.Error("Failed to list beneficiaries", map[string]interface{}{
+			"merchant_id": merchantID,
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	return &entity.BeneficiaryListResponse{
+		Beneficiaries: beneficiaries,
+		Total:         total,
+		Page:          pag.Page,
+		Limit:         pag.PerPage,
+	}, nil
+}
+
+func (uc *beneficiaryUseCase) UpdateBeneficiary(ctx context.Context, merchantID, id uuid.UUID, req *entity.UpdateBeneficiaryRequest) (*entity.Beneficiary, error) {
+	beneficiary, err := uc.repo.UpdateName(ctx, merchantID, id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if beneficiary == nil {
+		return nil, entity.ErrBeneficiaryNotFound
+	}
+	return beneficiary, nil
+}
+
+func (uc *beneficiaryUseCase) DeleteBeneficiary(ctx context.Context, merchantID, id uuid.UUID) error {
+	if err := uc.repo.Delete(ctx, merchantID, id); err != nil {
+		return err
+	}
+
+	uc.log.Info("Beneficiary deleted", map[string]interface{}{
+		"beneficiary_id": id,
+		"merchant_id":    merchantID,
+	})
+	return nil
+}
+
+func (uc *beneficiaryUseCase) VerifyBeneficiary(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error) {
+	beneficiary, err := uc.GetBeneficiary(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.verify(ctx, beneficiary); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdateVerification(ctx, beneficiary); err != nil {
+		return nil, err
+	}
+
+	return beneficiary, nil
+}
+
+func (uc *beneficiaryUseCase) ResolvePayout(ctx context.Context, merchantID, id uuid.UUID) (*entity.Beneficiary, error) {
+	beneficiary, err := uc.GetBeneficiary(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test withdrawals are simulated, so sandbox integrations can pay out straight away
+	if !payment.IsTestMode(ctx) && beneficiary.CoolingOff(time.Now()) {
+		uc.log.Warn("Payout to beneficiary in cooling-off refused", map[string]interface{}{
+			"beneficiary_id": id,
+			"merchant_id":    merchantID,
+			"active_from":    beneficiary.ActiveFrom,
+		})
+		return nil, fmt.Errorf("%w: payouts are allowed from %s", entity.ErrBeneficiaryCoolingOff, beneficiary.ActiveFrom.Format(time.RFC3339))
+	}
+
+	return beneficiary, nil
+}
+
+func (uc *beneficiaryUseCase) PayoutHistory(ctx context.Context, merchantID, id uuid.UUID, pag *pagination.Pagination) (*entity.PayoutHistoryResponse, error) {
+	if _, err := uc.GetBeneficiary(ctx, merchantID, id); err != nil {
+		return nil, err
+	}
+
+	payouts, total, err := uc.repo.ListPayouts(ctx, merchantID, id, pag.PerPage, pag.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+	totalPaid, err := uc.repo.TotalPaid(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PayoutHistoryResponse{
+		BeneficiaryID: id,
+		Payouts:       payouts,
+		TotalPaid:     totalPaid,
+		Total:         total,
+		Page:          pag.Page,
+		Limit:         pag.PerPage,
+	}, nil
+}
+
+// verify looks up the account holder. Only a provider saying the account does not exist is an
+// error; mediums without a lookup, or a lookup that fails, leave the beneficiary unverified.
+func (uc *beneficiaryUseCase) verify(ctx context.Context, beneficiary *entity.Beneficiary) error {
+	if uc.verifier == nil {
+		return nil
+	}
+
+	resp, err := uc.verifier.VerifyAccount(ctx, &payment.AccountVerificationRequest{
+		MerchantID:    beneficiary.MerchantID,
+		Medium:        beneficiary.Medium,
+		AccountNumber: beneficiary.AccountNumber,
+		BankCode:      beneficiary.BankCode,
+	})
+	switch {
+	case errors.Is(err, payment.ErrAccountNotFound):
+		return entity.ErrAccountNotFound
+	case errors.Is(err, payment.ErrNotSupported):
+		return nil
+	case err != nil:
+		uc.log.Warn("Beneficiary account lookup failed", map[string]interface{}{
+			"merchant_id": beneficiary.MerchantID,
+			"medium":      beneficiary.Medium,
+			"error":       err.Error(),
+		})
+		return nil
+	}
+
+	now := time.Now()
+	beneficiary.VerificationStatus = entity.VerificationVerified
+	beneficiary.VerifiedName = resp.AccountName
+	beneficiary.VerifiedAt = &now
+	return nil
+}
@@ -0,0 +1,217 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/checkout_branding/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/checkout_branding/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/checkout_branding/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/i18n"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+type Handler struct {
+	brandingUseCase usecase.BrandingUseCase
+	log             logging.Logger
+	jwtMiddleware   gin.HandlerFunc
+	rbac            *ginMiddleware.RBACV2
+}
+
+func NewHandler(brandingUseCase usecase.BrandingUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		brandingUseCase: brandingUseCase,
+		log:             logging.NewStdLogger("checkout_branding_handler"),
+		jwtMiddleware:   jwtMiddleware,
+		rbac:            rbac,
+	}
+}
+
+// RegisterRouter sets up the branding management routes (JWT + RBAC) and the public checkout lookups
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	mgmt := router.Group("/checkout_mgmt", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	mgmt.GET("/branding",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_READ),
+		h.GetBranding)
+	mgmt.PUT("/branding",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_UPDATE),
+		h.UpsertBranding)
+
+	// Public endpoints used by the hosted checkout page
+	public := router.Group("/checkout", ginMiddleware.CheckoutCors)
+	public.GET("/branding", h.GetBrandingByDomain)
+	public.GET("/branding/:slug", h.GetBrandingBySlug)
+	public.GET("/locales/:locale", h.GetLocaleCatalog)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// GetBranding godoc
+// @Summary      Get checkout branding
+// @Description  Get the hosted checkout branding of the authenticated merchant
+// @Tags         Checkout-Branding
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.BrandingResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /checkout_mgmt/branding [get]
+func (h *Handler) GetBranding(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	response, err := h.brandingUseCase.GetBranding(c.Request.Context(), merchantID)
+	if err != nil {
+		h.log.Error("Failed to get checkout branding", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpsertBranding godoc
+// @Summary      Update checkout branding
+// @Description  Configure logo, colors, slug, custom domain, support contact, medium order, theme and default language
+// @Tags         Checkout-Branding
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body entity.UpsertBrandingRequest true "Branding configuration"
+// @Success      200  {object}  entity.BrandingResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /checkout_mgmt/branding [put]
+func (h *Handler) UpsertBranding(c *gin.Context) {
+	var req entity.UpsertBrandingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Failed to bind JSON request", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	response, err := h.brandingUseCase.UpsertBranding(c.Request.Context(), merchantID, &req)
+	if err != nil {
+		h.log.Error("Failed to update checkout branding", map[string]interface{}{
+			"error": err.Error(),
+		})
+		if errors.Is(err, repository.ErrSlugTaken) || errors.Is(err, repository.ErrDomainTaken) {
+			c.JSON(http.StatusConflict, newErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBrandingBySlug godoc
+// @Summary      Resolve checkout branding by slug
+// @Description  Get the resolved theme, locale and checkout messages for a merchant slug
+// @Tags         Checkout
+// @Produce      json
+// @Param        slug  path   string  true   "Checkout slug"
+// @Param        lang  query  string  false  "Preferred language (en, am, om)"
+// @Success      200  {object}  entity.ResolvedCheckout
+// @Failure      404  {object}  ErrorResponse
+// @Router       /checkout/branding/{slug} [get]
+func (h *Handler) GetBrandingBySlug(c *gin.Context) {
+	response, err := h.brandingUseCase.ResolveBySlug(c.Request.Context(), c.Param("slug"), c.Query("lang"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBrandingByDomain godoc
+// @Summary      Resolve checkout branding by custom domain
+// @Description  Get the resolved theme, locale and checkout messages for a merchant custom domain
+// @Tags         Checkout
+// @Produce      json
+// @Param        domain  query  string  true   "Custom domain"
+// @Param        lang    query  string  false  "Preferred language (en, am, om)"
+// @Success      200  {object}  entity.ResolvedCheckout
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /checkout/branding [get]
+func (h *Handler) GetBrandingByDomain(c *gin.Context) {
+	domain := c.Query("domain")
+	if domain == "" {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("domain is required")))
+		return
+	}
+
+	response, err := h.brandingUseCase.ResolveByDomain(c.Request.Context(), domain, c.Query("lang"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetLocaleCatalog godoc
+// @Summary      Get checkout and receipt translations
+// @Description  Get the checkout and receipt strings for a locale (en, am, om)
+// @Tags         Checkout
+// @Produce      json
+// @Param        locale  path  string  true  "Locale"
+// @Success      200  {object}  entity.LocaleCatalogResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /checkout/locales/{locale} [get]
+func (h *Handler) GetLocaleCatalog(c *gin.Context) {
+	requested := c.Param("locale")
+	if !i18n.IsSupported(requested) {
+		c.JSON(http.StatusNotFound, newErrorResponse(fmt.Errorf("unsupported locale: %s", requested)))
+		return
+	}
+
+	locale := i18n.Resolve(requested)
+	messages := i18n.Messages(locale, "checkout.")
+	for key, message := range i18n.Messages(locale, "receipt.") {
+		messages[key] = message
+	}
+
+	c.JSON(http.StatusOK, entity.LocaleCatalogResponse{
+		Locale:   string(locale),
+		Messages: messages,
+	})
+}
@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/i18n"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// DefaultThemePreset is used when a merchant has not chosen a checkout theme
+const DefaultThemePreset = "default"
+
+// Default colors applied when a merchant has not customised their checkout
+const (
+	DefaultPrimaryColor    = "#1A73E8"
+	DefaultAccentColor     = "#34A853"
+	DefaultBackgroundColor = "#FFFFFF"
+	DefaultTextColor       = "#202124"
+)
+
+var (
+	hexColorRegex = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	slugRegex     = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{1,62}[a-z0-9])$`)
+	themeRegex    = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+)
+
+// CheckoutMediums lists the mediums a customer can pay with on the hosted checkout
+var CheckoutMediums = []interface{}{
+	txEntity.CYBERSOURCE,
+	txEntity.ETHSWITCH,
+	txEntity.MPESA,
+	txEntity.TELEBIRR,
+	txEntity.CBE,
+	txEntity.AWASH,
+	txEntity.KACHA,
+}
+
+// CheckoutBranding represents the look and feel of a merchant's hosted checkout
+type CheckoutBranding struct {
+	MerchantID      uuid.UUID                    `json:"merchant_id" db:"merchant_id"`
+	LogoURL         *string                      `json:"logo_url,omitempty" db:"logo_url"`
+	PrimaryColor    *string                      `json:"primary_color,omitempty" db:"primary_color"`
+	AccentColor     *string                      `json:"accent_color,omitempty" db:"accent_color"`
+	BackgroundColor *string                      `json:"background_color,omitempty" db:"background_color"`
+	TextColor       *string                      `json:"text_color,omitempty" db:"text_color"`
+	Slug            *string                      `json:"slug,omitempty" db:"slug"`
+	CustomDomain    *string                      `json:"custom_domain,omitempty" db:"custom_domain"`
+	SupportEmail    *string                      `json:"support_email,omitempty" db:"support_email"`
+	SupportPhone    *string                      `json:"support_phone,omitempty" db:"support_phone"`
+	SupportURL      *string                      `json:"support_url,omitempty" db:"support_url"`
+	MediumOrder     []txEntity.TransactionMedium `json:"medium_order" db:"medium_order"`
+	CreatedAt       time.Time                    `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time                    `json:"updated_at" db:"updated_at"`
+}
+
+// UpsertBrandingRequest represents request to create or update checkout branding
+// @Description Request to configure the merchant hosted checkout branding
+type UpsertBrandingRequest struct {
+	// Logo shown on checkout and receipts
+	LogoURL *string `json:"logo_url,omitempty" example:"https://cdn.example.com/logo.png"`
+
+	// Colors in hex notation
+	PrimaryColor    *string `json:"primary_color,omitempty" example:"#1A73E8"`
+	AccentColor     *string `json:"accent_color,omitempty" example:"#34A853"`
+	BackgroundColor *string `json:"background_color,omitempty" example:"#FFFFFF"`
+	TextColor       *string `json:"text_color,omitempty" example:"#202124"`
+
+	// Short slug used for branded checkout URLs
+	Slug *string `json:"slug,omitempty" example:"my-shop"`
+
+	// Custom domain serving the checkout
+	CustomDomain *string `json:"custom_domain,omitempty" example:"pay.myshop.com"`
+
+	// Support contact shown to customers
+	SupportEmail *string `json:"support_email,omitempty" example:"support@myshop.com"`
+	SupportPhone *string `json:"support_phone,omitempty" example:"251911234567"`
+	SupportURL   *string `json:"support_url,omitempty" example:"https://myshop.com/help"`
+
+	// Mediums shown on checkout, in display order. Mediums not listed are hidden.
+	MediumOrder []txEntity.TransactionMedium `json:"medium_order,omitempty" example:"[\"TELEBIRR\",\"MPESA\"]"`
+
+	// Theme preset stored in merchant settings
+	Theme *string `json:"theme,omitempty" example:"dark"`
+
+	// Default language for checkout, receipts and SMS (en, am, om)
+	DefaultLanguage *string `json:"default_language,omitempty" example:"am"`
+}
+
+func (r UpsertBrandingRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.LogoURL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&r.PrimaryColor, validation.NilOrNotEmpty, validation.Match(hexColorRegex).Error("must be a hex color")),
+		validation.Field(&r.AccentColor, validation.NilOrNotEmpty, validation.Match(hexColorRegex).Error("must be a hex color")),
+		validation.Field(&r.BackgroundColor, validation.NilOrNotEmpty, validation.Match(hexColorRegex).Error("must be a hex color")),
+		validation.Field(&r.TextColor, validation.NilOrNotEmpty, validation.Match(hexColorRegex).Error("must be a hex color")),
+		validation.Field(&r.Slug, validation.NilOrNotEmpty, validation.Match(slugRegex).Error("must be 3-64 lowercase letters, digits or dashes")),
+		validation.Field(&r.CustomDomain, validation.NilOrNotEmpty, is.Domain),
+		validation.Field(&r.SupportEmail, validation.NilOrNotEmpty, is.Email),
+		validation.Field(&r.SupportPhone, validation.NilOrNotEmpty, validation.Length(9, 15)),
+		validation.Field(&r.SupportURL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&r.MediumOrder, validation.Each(validation.In(CheckoutMediums...))),
+		validation.Field(&r.Theme, validation.NilOrNotEmpty, validation.Match(themeRegex).Error("must be a lowercase theme name")),
+		validation.Field(&r.DefaultLanguage, validation.NilOrNotEmpty, validation.By(func(value interface{}) error {
+			if language, ok := value.(*string); ok && language != nil && !i18n.IsSupported(*language) {
+				return validation.NewError("validation_in_invalid", "must be one of en, am, om")
+			}
+			return nil
+		})),
+	)
+}
+
+// CheckoutTheme is the resolved visual theme sent to checkout and receipt pages
+type CheckoutTheme struct {
+	Preset          string                       `json:"preset" example:"default"`
+	LogoURL         *string                      `json:"logo_url,omitempty"`
+	PrimaryColor    string                       `json:"primary_color" example:"#1A73E8"`
+	AccentColor     string                       `json:"accent_color" example:"#34A853"`
+	BackgroundColor string                       `json:"background_color" example:"#FFFFFF"`
+	TextColor       string                       `json:"text_color" example:"#202124"`
+	SupportEmail    *string                      `json:"support_email,omitempty"`
+	SupportPhone    *string                      `json:"support_phone,omitempty"`
+	SupportURL      *string                      `json:"support_url,omitempty"`
+	MediumOrder     []txEntity.TransactionMedium `json:"medium_order,omitempty"`
+}
+
+// BrandingResponse represents the merchant's branding configuration
+type BrandingResponse struct {
+	MerchantID      uuid.UUID                    `json:"merchant_id"`
+	LogoURL         *string                      `json:"logo_url,omitempty"`
+	PrimaryColor    *string                      `json:"primary_color,omitempty"`
+	AccentColor     *string                      `json:"accent_color,omitempty"`
+	BackgroundColor *string                      `json:"background_color,omitempty"`
+	TextColor       *string                      `json:"text_color,omitempty"`
+	Slug            *string                      `json:"slug,omitempty"`
+	CustomDomain    *string                      `json:"custom_domain,omitempty"`
+	SupportEmail    *string                      `json:"support_email,omitempty"`
+	SupportPhone    *string                      `json:"support_phone,omitempty"`
+	SupportURL      *string                      `json:"support_url,omitempty"`
+	MediumOrder     []txEntity.TransactionMedium `json:"medium_order"`
+	Theme           string                       `json:"theme"`
+	DefaultLanguage string                       `json:"default_language"`
+	UpdatedAt       *time.Time                   `json:"updated_at,omitempty"`
+}
+
+// ResolvedCheckout bundles the theme, locale and messages for a checkout page
+type ResolvedCheckout struct {
+	MerchantID uuid.UUID         `json:"merchant_id"`
+	Theme      CheckoutTheme     `json:"theme"`
+	Locale     string            `json:"locale" example:"en"`
+	Messages   map[string]string `json:"messages"`
+}
+
+// LocaleCatalogResponse represents the translated strings for a locale
+type LocaleCatalogResponse struct {
+	Locale   string            `json:"locale" example:"am"`
+	Messages map[string]string `json:"messages"`
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/checkout_branding/core/entity"
+)
+
+var (
+	// ErrSlugTaken is returned when another merchant already uses the slug
+	ErrSlugTaken = errors.New("checkout slug is already taken")
+	// ErrDomainTaken is returned when another merchant already uses the custom domain
+	ErrDomainTaken = errors.New("custom domain is already taken")
+)
+
+// BrandingRepository defines the interface for checkout branding operations
+type BrandingRepository interface {
+	// GetByMerchant retrieves branding for a merchant, returns nil when not configured
+	GetByMerchant(ctx context.Context, merchantID uuid.UUID) (*entity.CheckoutBranding, error)
+
+	// GetBySlug retrieves branding by its checkout slug
+	GetBySlug(ctx context.Context, slug string) (*entity.CheckoutBranding, error)
+
+	// GetByDomain retrieves branding by its custom domain
+	GetByDomain(ctx context.Context, domain string) (*entity.CheckoutBranding, error)
+
+	// Upsert creates or replaces the branding of a merchant
+	Upsert(ctx context.Context, branding *entity.CheckoutBranding) error
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/socialpay/socialpay/src/pkg/checkout_branding/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const brandingColumns = `
+	merchant_id, logo_url, primary_color, accent_color, background_color, text_color,
+	slug, custom_domain, support_email, support_phone, support_url, medium_order,
+	created_at, updated_at`
+
+type BrandingRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewBrandingRepository(db *sql.DB) BrandingRepository {
+	return &BrandingRepositoryImpl{db: db}
+}
+
+func (r *BrandingRepositoryImpl) GetByMerchant(ctx context.Context, merchantID uuid.UUID) (*entity.CheckoutBranding, error) {
+	query := `SELECT ` + brandingColumns + ` FROM merchants.checkout_branding WHERE merchant_id = $1`
+
+	branding, err := scanBranding(r.db.QueryRowContext(ctx, query, merchantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get checkout branding: %w", err)
+	}
+
+	return branding, nil
+}
+
+func (r *BrandingRepositoryImpl) GetBySlug(ctx context.Context, slug string) (*entity.CheckoutBranding, error) {
+	query := `SELECT ` + brandingColumns + ` FROM merchants.checkout_branding WHERE slug = $1`
+
+	branding, err := scanBranding(r.db.QueryRowContext(ctx, query, strings.ToLower(slug)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("checkout branding not found")
+		}
+		return nil, fmt.Errorf("failed to get checkout branding by slug: %w", err)
+	}
+
+	return branding, nil
+}
+
+func (r *BrandingRepositoryImpl) GetByDomain(ctx context.Context, domain string) (*entity.CheckoutBranding, error) {
+	query := `SELECT ` + brandingColumns + ` FROM merchants.checkout_branding WHERE custom_domain = $1`
+
+	branding, err := scanBranding(r.db.QueryRowContext(ctx, query, strings.ToLower(domain)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("checkout branding not found")
+		}
+		return nil, fmt.Errorf("failed to get checkout branding by domain: %w", err)
+	}
+
+	return branding, nil
+}
+
+func (r *BrandingRepositoryImpl) Upsert(ctx context.Context, branding *entity.CheckoutBranding) error {
+	mediumOrder := branding.MediumOrder
+	if mediumOrder == nil {
+		mediumOrder = []txEntity.TransactionMedium{}
+	}
+	mediumOrderJSON, err := json.Marshal(mediumOrder)
+	if err != nil {
+		return fmt.Errorf("failed to marshal medium order: %w", err)
+	}
+
+	query := `
+		INSERT INTO merchants.checkout_branding (
+			merchant_id, logo_url, primary_color, accent_color, background_color, text_color,
+			slug, custom_domain, support_email, support_phone, support_url, medium_order
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (merchant_id) DO UPDATE
+		SET logo_url = EXCLUDED.logo_url,
+			primary_color = EXCLUDED.primary_color,
+			accent_color = EXCLUDED.accent_color,
+			background_color = EXCLUDED.background_color,
+			text_color = EXCLUDED.text_color,
+			slug = EXCLUDED.slug,
+			custom_domain = EXCLUDED.custom_domain,
+			support_email = EXCLUDED.support_email,
+			support_phone = EXCLUDED.support_phone,
+			support_url = EXCLUDED.support_url,
+			medium_order = EXCLUDED.medium_order,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRowContext(ctx, query,
+		branding.MerchantID,
+		nullString(branding.LogoURL),
+		nullString(branding.PrimaryColor),
+		nullString(branding.AccentColor),
+		nullString(branding.BackgroundColor),
+		nullString(branding.TextColor),
+		nullLowerString(branding.Slug),
+		nullLowerString(branding.CustomDomain),
+		nullString(branding.SupportEmail),
+		nullString(branding.SupportPhone),
+		nullString(branding.SupportURL),
+		mediumOrderJSON,
+	).Scan(&branding.CreatedAt, &branding.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			if strings.Contains(pqErr.Constraint, "slug") {
+				return ErrSlugTaken
+			}
+			if strings.Contains(pqErr.Constraint, "custom_domain") {
+				return ErrDomainTaken
+			}
+		}
+		return fmt.Errorf("failed to upsert checkout branding: %w", err)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBranding(row rowScanner) (*entity.CheckoutBranding, error) {
+	var (
+		b                                                        entity.CheckoutBranding
+		logoURL, primary, accent, background, text, slug, domain sql.NullString
+		supportEmail, supportPhone, supportURL                   sql.NullString
+		mediumOrderJSON                                          []byte
+	)
+
+	if err := row.Scan(
+		&b.MerchantID,
+		&logoURL,
+		&primary,
+		&accent,
+		&background,
+		&text,
+		&slug,
+		&domain,
+		&supportEmail,
+		&supportPhone,
+		&supportURL,
+		&mediumOrderJSON,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(mediumOrderJSON) > 0 {
+		if err := json.Unmarshal(mediumOrderJSON, &b.MediumOrder); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal medium order: %w", err)
+		}
+	}
+
+	b.LogoURL = stringPtr(logoURL)
+	b.PrimaryColor = stringPtr(primary)
+	b.AccentColor = stringPtr(accent)
+	b.BackgroundColor = stringPtr(background)
+	b.TextColor = stringPtr(text)
+	b.Slug = stringPtr(slug)
+	b.CustomDomain = stringPtr(domain)
+	b.SupportEmail = stringPtr(supportEmail)
+	b.SupportPhone = stringPtr(supportPhone)
+	b.SupportURL = stringPtr(supportURL)
+
+	return &b, nil
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil || *s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullLowerString(s *string) sql.NullString {
+	if s == nil || *s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: strings.ToLower(*s), Valid: true}
+}
+
+func stringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
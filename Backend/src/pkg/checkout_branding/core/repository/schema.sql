@@ -0,0 +1,19 @@
+-- Checkout Branding Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS merchants.checkout_branding (
+    merchant_id UUID PRIMARY KEY REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    logo_url TEXT,
+    primary_color VARCHAR(7),
+    accent_color VARCHAR(7),
+    background_color VARCHAR(7),
+    text_color VARCHAR(7),
+    slug VARCHAR(64) UNIQUE,
+    custom_domain VARCHAR(255) UNIQUE,
+    support_email VARCHAR(255),
+    support_phone VARCHAR(20),
+    support_url TEXT,
+    medium_order JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
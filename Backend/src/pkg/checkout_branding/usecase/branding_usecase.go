@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/checkout_branding/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/checkout_branding/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/i18n"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+	merchantRepo "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/repository"
+)
+
+// BrandingUseCase defines the interface for checkout branding business operations
+type BrandingUseCase interface {
+	// GetBranding retrieves the branding configuration of a merchant
+	GetBranding(ctx context.Context, merchantID uuid.UUID) (*entity.BrandingResponse, error)
+
+	// UpsertBranding creates or updates the branding configuration of a merchant
+	UpsertBranding(ctx context.Context, merchantID uuid.UUID, req *entity.UpsertBrandingRequest) (*entity.BrandingResponse, error)
+
+	// ResolveCheckout resolves the theme, locale and checkout messages for a merchant
+	ResolveCheckout(ctx context.Context, merchantID uuid.UUID, requestedLocale string) (*entity.ResolvedCheckout, error)
+
+	// ResolveBySlug resolves the checkout branding by its public slug
+	ResolveBySlug(ctx context.Context, slug string, requestedLocale string) (*entity.ResolvedCheckout, error)
+
+	// ResolveByDomain resolves the checkout branding by its custom domain
+	ResolveByDomain(ctx context.Context, domain string, requestedLocale string) (*entity.ResolvedCheckout, error)
+
+	// ResolveLocale picks the requested locale when supported, then the merchant default, then English
+	ResolveLocale(ctx context.Context, merchantID uuid.UUID, requestedLocale string) i18n.Locale
+
+	// OrderMediums filters and orders mediums according to the merchant's branding
+	OrderMediums(ctx context.Context, merchantID uuid.UUID, mediums []txEntity.TransactionMedium) []txEntity.TransactionMedium
+}
+
+type brandingUseCase struct {
+	brandingRepo repository.BrandingRepository
+	merchantRepo merchantRepo.Repository
+	log          logging.Logger
+}
+
+func NewBrandingUseCase(brandingRepo repository.BrandingRepository, merchantRepo merchantRepo.Repository) BrandingUseCase {
+	return &brandingUseCase{
+		brandingRepo: brandingRepo,
+		merchantRepo: merchantRepo,
+		log:          logging.NewStdLogger("checkout_branding_usecase"),
+	}
+}
+
+func (uc *brandingUseCase) GetBranding(ctx context.Context, merchantID uuid.UUID) (*entity.BrandingResponse, error) {
+	branding, err := uc.brandingRepo.GetByMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := uc.getSettings(ctx, merchantID)
+	return toBrandingResponse(merchantID, branding, settings), nil
+}
+
+func (uc *brandingUseCase) UpsertBranding(ctx context.Context, merchantID uuid.UUID, req *entity.UpsertBrandingRequest) (*entity.BrandingResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	branding := &entity.CheckoutBranding{
+		MerchantID:      merchantID,
+		LogoURL:         req.LogoURL,
+		PrimaryColor:    req.PrimaryColor,
+		AccentColor:     req.AccentColor,
+		BackgroundColor: req.BackgroundColor,
+		TextColor:       req.TextColor,
+		Slug:            req.Slug,
+		CustomDomain:    req.CustomDomain,
+		SupportEmail:    req.SupportEmail,
+		SupportPhone:    req.SupportPhone,
+		SupportURL:      req.SupportURL,
+		MediumOrder:     dedupeMediums(req.MediumOrder),
+	}
+
+	if err := uc.brandingRepo.Upsert(ctx, branding); err != nil {
+		uc.log.Error("Failed to save checkout branding", map[string]interface{}{
+			"error":       err.Error(),
+			"merchant_id": merchantID,
+		})
+		return nil, err
+	}
+
+	// Theme and language live in merchant settings so other modules can read them
+	settings := uc.getSettings(ctx, merchantID)
+	if req.Theme != nil || req.DefaultLanguage != nil {
+		theme := (*string)(nil)
+		language := string(i18n.DefaultLocale)
+		if settings != nil {
+			theme = settings.CheckoutTheme
+			language = settings.DefaultLanguage
+		}
+		if req.Theme != nil {
+			theme = req.Theme
+		}
+		if req.DefaultLanguage != nil {
+			language = string(i18n.Resolve(*req.DefaultLanguage))
+		}
+
+		if err := uc.merchantRepo.UpdateMerchantCheckoutSettings(ctx, merchantID, theme, language); err != nil {
+			uc.log.Error("Failed to update merchant checkout settings", map[string]interface{}{
+				"error":       err.Error(),
+				"merchant_id": merchantID,
+			})
+			return nil, err
+		}
+		settings = uc.getSettings(ctx, merchantID)
+	}
+
+	uc.log.Info("Checkout branding saved", map[string]interface{}{
+		"merchant_id": merchantID,
+	})
+
+	return toBrandingResponse(merchantID, branding, settings), nil
+}
+
+func (uc *brandingUseCase) ResolveCheckout(ctx context.Context, merchantID uuid.UUID, requestedLocale string) (*entity.ResolvedCheckout, error) {
+	branding, err := uc.brandingRepo.GetByMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.resolve(ctx, merchantID, branding, requestedLocale), nil
+}
+
+func (uc *brandingUseCase) ResolveBySlug(ctx context.Context, slug string, requestedLocale string) (*entity.ResolvedCheckout, error) {
+	branding, err := uc.brandingRepo.GetBySlug(ctx, strings.TrimSpace(slug))
+	if err != nil {
+		return nil, err
+	}
+	return uc.resolve(ctx, branding.MerchantID, branding, requestedLocale), nil
+}
+
+func (uc *brandingUseCase) ResolveByDomain(ctx context.Context, domain string, requestedLocale string) (*entity.ResolvedCheckout, error) {
+	branding, err := uc.brandingRepo.GetByDomain(ctx, strings.TrimSpace(domain))
+	if err != nil {
+		return nil, err
+	}
+	return uc.resolve(ctx, branding.MerchantID, branding, requestedLocale), nil
+}
+
+func (uc *brandingUseCase) ResolveLocale(ctx context.Context, merchantID uuid.UUID, requestedLocale string) i18n.Locale {
+	var defaultLanguage string
+	if settings := uc.getSettings(ctx, merchantID); settings != nil {
+		defaultLanguage = settings.DefaultLanguage
+	}
+	return i18n.Resolve(requestedLocale, defaultLanguage)
+}
+
+func (uc *brandingUseCase) OrderMediums(ctx context.Context, merchantID uuid.UUID, mediums []txEntity.TransactionMedium) []txEntity.TransactionMedium {
+	branding, err := uc.brandingRepo.GetByMerchant(ctx, merchantID)
+	if err != nil {
+		uc.log.Warn("Failed to load branding, keeping default medium order", map[string]interface{}{
+			"error":       err.Error(),
+			"merchant_id": merchantID,
+		})
+		return mediums
+	}
+	if branding == nil {
+		return mediums
+	}
+	return orderMediums(branding.MediumOrder, mediums)
+}
+
+func (uc *brandingUseCase) resolve(ctx context.Context, merchantID uuid.UUID, branding *entity.CheckoutBranding, requestedLocale string) *entity.ResolvedCheckout {
+	settings := uc.getSettings(ctx, merchantID)
+
+	var defaultLanguage string
+	if settings != nil {
+		defaultLanguage = settings.DefaultLanguage
+	}
+	locale := i18n.Resolve(requestedLocale, defaultLanguage)
+
+	return &entity.ResolvedCheckout{
+		MerchantID: merchantID,
+		Theme:      buildTheme(branding, settings),
+		Locale:     string(locale),
+		Messages:   i18n.Messages(locale, "checkout."),
+	}
+}
+
+func (uc *brandingUseCase) getSettings(ctx context.Context, merchantID uuid.UUID) *merchantEntity.MerchantSettings {
+	settings, err := uc.merchantRepo.GetMerchantSettings(ctx, merchantID)
+	if err != nil {
+		uc.log.Warn("Failed to load merchant settings", map[string]interface{}{
+			"error":       err.Error(),
+			"merchant_id": merchantID,
+		})
+		return nil
+	}
+	return settings
+}
+
+func buildTheme(branding *entity.CheckoutBranding, settings *merchantEntity.MerchantSettings) entity.CheckoutTheme {
+	theme := entity.CheckoutTheme{
+		Preset:          entity.DefaultThemePreset,
+		PrimaryColor:    entity.DefaultPrimaryColor,
+		AccentColor:     entity.DefaultAccentColor,
+		BackgroundColor: entity.DefaultBackgroundColor,
+		TextColor:       entity.DefaultTextColor,
+	}
+
+	if settings != nil && settings.CheckoutTheme != nil && *settings.CheckoutTheme != "" {
+		theme.Preset = *settings.CheckoutTheme
+	}
+
+	if branding == nil {
+		return theme
+	}
+
+	theme.LogoURL = branding.LogoURL
+	theme.SupportEmail = branding.SupportEmail
+	theme.SupportPhone = branding.SupportPhone
+	theme.SupportURL = branding.SupportURL
+	theme.MediumOrder = branding.MediumOrder
+	if branding.PrimaryColor != nil {
+		theme.PrimaryColor = *branding.PrimaryColor
+	}
+	if branding.AccentColor != nil {
+		theme.AccentColor = *branding.AccentColor
+	}
+	if branding.BackgroundColor != nil {
+		theme.BackgroundColor = *branding.BackgroundColor
+	}
+	if branding.TextColor != nil {
+		theme.TextColor = *branding.TextColor
+	}
+
+	return theme
+}
+
+func toBrandingResponse(merchantID uuid.UUID, branding *entity.CheckoutBranding, settings *merchantEntity.MerchantSettings) *entity.BrandingResponse {
+	response := &entity.BrandingResponse{
+		MerchantID:      merchantID,
+		MediumOrder:     []txEntity.TransactionMedium{},
+		Theme:           entity.DefaultThemePreset,
+		DefaultLanguage: string(i18n.DefaultLocale),
+	}
+
+	if settings != nil {
+		if settings.CheckoutTheme != nil && *settings.CheckoutTheme != "" {
+			response.Theme = *settings.CheckoutTheme
+		}
+		response.DefaultLanguage = string(i18n.Resolve(settings.DefaultLanguage))
+	}
+
+	if branding != nil {
+		response.LogoURL = branding.LogoURL
+		response.PrimaryColor = branding.PrimaryColor
+		response.AccentColor = branding.AccentColor
+		response.BackgroundColor = branding.BackgroundColor
+		response.TextColor = branding.TextColor
+		response.Slug = branding.Slug
+		response.CustomDomain = branding.CustomDomain
+		response.SupportEmail = branding.SupportEmail
+		response.SupportPhone = branding.SupportPhone
+		response.SupportURL = branding.SupportURL
+		if branding.MediumOrder != nil {
+			response.MediumOrder = branding.MediumOrder
+		}
+		response.UpdatedAt = &branding.UpdatedAt
+	}
+
+	return response
+}
+
+// orderMediums keeps only the mediums present in order, in that order.
+// An empty order or an order that hides every medium leaves the list unchanged.
+func orderMediums(order, mediums []txEntity.TransactionMedium) []txEntity.TransactionMedium {
+	if len(order) == 0 {
+		return mediums
+	}
+
+	available := make(map[txEntity.TransactionMedium]bool, len(mediums))
+	for _, medium := range mediums {
+		available[medium] = true
+	}
+
+	ordered := make([]txEntity.TransactionMedium, 0, len(mediums))
+	for _, medium := range order {
+		if available[medium] {
+			ordered = append(ordered, medium)
+		}
+	}
+
+	if len(ordered) == 0 {
+		return mediums
+	}
+	return ordered
+}
+
+func dedupeMediums(mediums []txEntity.TransactionMedium) []txEntity.TransactionMedium {
+	seen := make(map[txEntity.TransactionMedium]bool, len(mediums))
+	result := make([]txEntity.TransactionMedium, 0, len(mediums))
+	for _, medium := range mediums {
+		if seen[medium] {
+			continue
+		}
+		seen[medium] = true
+		result = append(result, medium)
+	}
+	return result
+}
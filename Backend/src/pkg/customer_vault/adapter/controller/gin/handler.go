@@ -0,0 +1,172 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/customer_vault/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	"github.com/socialpay/socialpay/src/pkg/shared/pagination"
+)
+
+type Handler struct {
+	customerUseCase usecase.CustomerVaultUseCase
+	log             logging.Logger
+	jwtMiddleware   gin.HandlerFunc
+	rbac            *ginMiddleware.RBACV2
+}
+
+func NewHandler(customerUseCase usecase.CustomerVaultUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		customerUseCase: customerUseCase,
+		log:             logging.NewStdLogger("customer_vault_handler"),
+		jwtMiddleware:   jwtMiddleware,
+		rbac:            rbac,
+	}
+}
+
+// RegisterRouter sets up the saved customer routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	customers := router.Group("/customer_mgmt", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	customers.GET("/customers",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CUSTOMER, auth_entity.OPERATION_READ),
+		h.ListCustomers)
+	customers.GET("/customers/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CUSTOMER, auth_entity.OPERATION_READ),
+		h.GetCustomer)
+	customers.DELETE("/customers/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CUSTOMER, auth_entity.OPERATION_DELETE),
+		h.DeleteCustomer)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// ListCustomers godoc
+// @Summary      List saved customers
+// @Description  List the saved payers of the authenticated merchant with their lifetime value
+// @Tags         Customers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q          query  string  false  "Search by phone number, name or email"
+// @Param        page       query  int     false  "Page number"
+// @Param        page_size  query  int     false  "Page size"
+// @Success      200  {object}  entity.SavedCustomersListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /customer_mgmt/customers [get]
+func (h *Handler) ListCustomers(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	pag, err := pagination.NewPagination(c, h.log)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.customerUseCase.ListCustomers(c.Request.Context(), merchantID, c.Query("q"), pag)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCustomer godoc
+// @Summary      Get saved customer
+// @Description  Get a saved payer with lifetime value and the linked ERP customer
+// @Tags         Customers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Customer ID"
+// @Success      200  {object}  entity.SavedCustomerResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /customer_mgmt/customers/{id} [get]
+func (h *Handler) GetCustomer(c *gin.Context) {
+	id, merchantID, ok := h.customerAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.customerUseCase.GetCustomer(c.Request.Context(), id, merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteCustomer godoc
+// @Summary      Delete saved customer
+// @Description  Delete a saved payer and its stored payment details
+// @Tags         Customers
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Customer ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /customer_mgmt/customers/{id} [delete]
+func (h *Handler) DeleteCustomer(c *gin.Context) {
+	id, merchantID, ok := h.customerAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	if err := h.customerUseCase.DeleteCustomer(c.Request.Context(), id, merchantID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) customerAndMerchant(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid customer ID")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, merchantID, true
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+		return
+	}
+
+	h.log.Error("Customer request failed", map[string]interface{}{
+		"error": err.Error(),
+	})
+	c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+}
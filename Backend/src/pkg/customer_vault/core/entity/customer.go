@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	erpEntity "github.com/socialpay/socialpay/src/pkg/erp_v2/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// SavedCustomer represents a payer profile stored in a merchant's customer vault.
+// Profiles are keyed by merchant and phone number and are only created
+// after a successful payment the payer consented to save.
+type SavedCustomer struct {
+	ID         uuid.UUID `json:"id"`
+	MerchantID uuid.UUID `json:"merchant_id"`
+
+	// Linked ERP customer with the same phone number, when the merchant has one
+	ERPCustomerID *uuid.UUID `json:"erp_customer_id,omitempty"`
+
+	PhoneNumber     string                      `json:"phone_number"`
+	Name            string                      `json:"name,omitempty"`
+	Email           string                      `json:"email,omitempty"`
+	PreferredMedium *txEntity.TransactionMedium `json:"preferred_medium,omitempty"`
+
+	// Cybersource payment token for card payers, never exposed
+	CardToken    string `json:"-"`
+	HasCardToken bool   `json:"has_card_token"`
+
+	ConsentedAt   time.Time  `json:"consented_at"`
+	LastPaymentAt *time.Time `json:"last_payment_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CustomerConsent records a payer's consent to be saved, pending the
+// outcome of the transaction it was given for
+type CustomerConsent struct {
+	TransactionID uuid.UUID                  `json:"transaction_id"`
+	MerchantID    uuid.UUID                  `json:"merchant_id"`
+	PhoneNumber   string                     `json:"phone_number"`
+	Name          string                     `json:"name,omitempty"`
+	Medium        txEntity.TransactionMedium `json:"medium"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+// CustomerLifetimeValue aggregates the successful payments of a saved customer
+type CustomerLifetimeValue struct {
+	TotalPaid          float64    `json:"total_paid" example:"12500.00"`
+	SuccessfulPayments int64      `json:"successful_payments" example:"14"`
+	FirstPaymentAt     *time.Time `json:"first_payment_at,omitempty"`
+	LastPaymentAt      *time.Time `json:"last_payment_at,omitempty"`
+}
+
+// SavedCustomerResponse represents a saved customer with its lifetime value
+// @Description Saved customer profile with lifetime value
+type SavedCustomerResponse struct {
+	*SavedCustomer
+	LifetimeValue CustomerLifetimeValue `json:"lifetime_value"`
+
+	// ERP customer record when linked
+	ERPCustomer *erpEntity.Customer `json:"erp_customer,omitempty"`
+}
+
+// SavedCustomersListResponse represents a paginated list of saved customers
+// @Description Paginated list of saved customers
+type SavedCustomersListResponse struct {
+	Customers []SavedCustomerResponse `json:"customers"`
+	Total     int64                   `json:"total"`
+	Page      int                     `json:"page"`
+	Limit     int                     `json:"limit"`
+}
+
+// SavedCustomerWithValue is a list row carrying its lifetime value
+type SavedCustomerWithValue struct {
+	SavedCustomer
+	LifetimeValue CustomerLifetimeValue
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/customer_vault/core/entity"
+)
+
+// CustomerRepository defines the interface for customer vault operations
+type CustomerRepository interface {
+	// Upsert creates or refreshes the saved customer of a merchant keyed by phone number
+	Upsert(ctx context.Context, customer *entity.SavedCustomer) error
+
+	// GetByID retrieves a saved customer of a merchant
+	GetByID(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) (*entity.SavedCustomer, error)
+
+	// List retrieves saved customers of a merchant, optionally matching a phone or name search
+	List(ctx context.Context, merchantID uuid.UUID, search string, limit, offset int) ([]entity.SavedCustomerWithValue, int64, error)
+
+	// Delete removes a saved customer and its stored payment details
+	Delete(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) error
+
+	// GetLifetimeValue aggregates the successful deposits of a payer
+	GetLifetimeValue(ctx context.Context, merchantID uuid.UUID, phoneNumber string) (*entity.CustomerLifetimeValue, error)
+
+	// FindERPCustomerID looks up an ERP customer of the merchant with the same phone number
+	FindERPCustomerID(ctx context.Context, merchantID uuid.UUID, phoneNumber string) (*uuid.UUID, error)
+
+	// SaveConsent stores the payer's consent for a transaction
+	SaveConsent(ctx context.Context, consent *entity.CustomerConsent) error
+
+	// TakeConsent removes and returns the consent stored for a transaction, nil when none
+	TakeConsent(ctx context.Context, transactionID uuid.UUID) (*entity.CustomerConsent, error)
+}
@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/customer_vault/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const savedCustomerColumns = `
+	c.id, c.merchant_id, c.erp_customer_id, c.phone_number, c.name, c.email, c.preferred_medium,
+	c.card_token, c.consented_at, c.last_payment_at, c.created_at, c.updated_at`
+
+// lifetimeValueJoin aggregates successful deposits of each saved customer
+const lifetimeValueJoin = `
+	LEFT JOIN LATERAL (
+		SELECT
+			COALESCE(SUM(t.base_amount), 0) AS total_paid,
+			COUNT(t.id) AS successful_payments,
+			MIN(t.created_at) AS first_payment_at,
+			MAX(t.created_at) AS last_payment_at
+		FROM public.transactions t
+		WHERE t.merchant_id = c.merchant_id
+		  AND t.phone_number = c.phone_number
+		  AND t.type = '` + string(txEntity.DEPOSIT) + `'
+		  AND t.status = '` + string(txEntity.SUCCESS) + `'
+		  AND t.test IS NOT TRUE
+	) ltv ON true`
+
+type CustomerRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCustomerRepository(db *sql.DB) CustomerRepository {
+	return &CustomerRepositoryImpl{db: db}
+}
+
+func (r *CustomerRepositoryImpl) Upsert(ctx context.Context, customer *entity.SavedCustomer) error {
+	var preferredMedium sql.NullString
+	if customer.PreferredMedium != nil {
+		preferredMedium = sql.NullString{String: string(*customer.PreferredMedium), Valid: true}
+	}
+
+	query := `
+		INSERT INTO public.saved_customers (
+			id, merchant_id, erp_customer_id, phone_number, name, email, preferred_medium, card_token, last_payment_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (merchant_id, phone_number) DO UPDATE
+		SET erp_customer_id = COALESCE(EXCLUDED.erp_customer_id, saved_customers.erp_customer_id),
+			name = COALESCE(EXCLUDED.name, saved_customers.name),
+			email = COALESCE(EXCLUDED.email, saved_customers.email),
+			preferred_medium = COALESCE(EXCLUDED.preferred_medium, saved_customers.preferred_medium),
+			card_token = COALESCE(EXCLUDED.card_token, saved_customers.card_token),
+			last_payment_at = COALESCE(EXCLUDED.last_payment_at, saved_customers.last_payment_at),
+			updated_at = NOW()
+		RETURNING id, consented_at, created_at, updated_at, card_token IS NOT NULL
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		customer.ID,
+		customer.MerchantID,
+		customer.ERPCustomerID,
+		customer.PhoneNumber,
+		nullString(customer.Name),
+		nullString(customer.Email),
+		preferredMedium,
+		nullString(customer.CardToken),
+		customer.LastPaymentAt,
+	).Scan(&customer.ID, &customer.ConsentedAt, &customer.CreatedAt, &customer.UpdatedAt, &customer.HasCardToken)
+	if err != nil {
+		return fmt.Errorf("failed to save customer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CustomerRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) (*entity.SavedCustomer, error) {
+	query := `SELECT ` + savedCustomerColumns + ` FROM public.saved_customers c WHERE c.id = $1 AND c.merchant_id = $2`
+
+	customer, err := scanSavedCustomer(r.db.QueryRowContext(ctx, query, id, merchantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("customer not found")
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+func (r *CustomerRepositoryImpl) List(ctx context.Context, merchantID uuid.UUID, search string, limit, offset int) ([]entity.SavedCustomerWithValue, int64, error) {
+	where := `WHERE c.merchant_id = $1`
+	args := []interface{}{merchantID}
+	if search = strings.TrimSpace(search); search != "" {
+		args = append(args, "%"+search+"%")
+		where += fmt.Sprintf(` AND (c.phone_number ILIKE $%d OR c.name ILIKE $%d OR c.email ILIKE $%d)`, len(args), len(args), len(args))
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM public.saved_customers c `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count customers: %w", err)
+	}
+
+	args = append(args, limit, offset)
+	query := fmt.Sprintf(`
+		SELECT %s, ltv.total_paid, ltv.successful_payments, ltv.first_payment_at, ltv.last_payment_at
+		FROM public.saved_customers c
+		%s
+		%s
+		ORDER BY c.last_payment_at DESC NULLS LAST, c.created_at DESC
+		LIMIT $%d OFFSET $%d`, savedCustomerColumns, lifetimeValueJoin, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list customers: %w", err)
+	}
+	defer rows.Close()
+
+	customers := []entity.SavedCustomerWithValue{}
+	for rows.Next() {
+		var (
+			row                    entity.SavedCustomerWithValue
+			firstPayment, lastPaid sql.NullTime
+		)
+		customer, err := scanSavedCustomer(rows,
+			&row.LifetimeValue.TotalPaid,
+			&row.LifetimeValue.SuccessfulPayments,
+			&firstPayment,
+			&lastPaid,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan customer: %w", err)
+		}
+		row.SavedCustomer = *customer
+		row.LifetimeValue.FirstPaymentAt = timePtr(firstPayment)
+		row.LifetimeValue.LastPaymentAt = timePtr(lastPaid)
+		customers = append(customers, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate customers: %w", err)
+	}
+
+	return customers, total, nil
+}
+
+func (r *CustomerRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM public.saved_customers WHERE id = $1 AND merchant_id = $2`, id, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete customer: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete customer: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("customer not found")
+	}
+
+	return nil
+}
+
+func (r *CustomerRepositoryImpl) GetLifetimeValue(ctx context.Context, merchantID uuid.UUID, phoneNumber string) (*entity.CustomerLifetimeValue, error) {
+	query := `
+		SELECT ltv.total_paid, ltv.successful_payments, ltv.first_payment_at, ltv.last_payment_at
+		FROM (SELECT $1::uuid AS merchant_id, $2::varchar AS phone_number) c
+		` + lifetimeValueJoin
+
+	var (
+		ltv                    entity.CustomerLifetimeValue
+		firstPayment, lastPaid sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, query, merchantID, phoneNumber).Scan(
+		&ltv.TotalPaid,
+		&ltv.SuccessfulPayments,
+		&firstPayment,
+		&lastPaid,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer lifetime value: %w", err)
+	}
+
+	ltv.FirstPaymentAt = timePtr(firstPayment)
+	ltv.LastPaymentAt = timePtr(lastPaid)
+
+	return &ltv, nil
+}
+
+func (r *CustomerRepositoryImpl) FindERPCustomerID(ctx context.Context, merchantID uuid.UUID, phoneNumber string) (*uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id FROM erp.customers WHERE merchant_id = $1 AND phone = $2 ORDER BY created_at LIMIT 1`,
+		merchantID, phoneNumber,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find ERP customer: %w", err)
+	}
+
+	return &id, nil
+}
+
+func (r *CustomerRepositoryImpl) SaveConsent(ctx context.Context, consent *entity.CustomerConsent) error {
+	query := `
+		INSERT INTO public.saved_customer_consents (transaction_id, merchant_id, phone_number, name, medium)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (transaction_id) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		consent.TransactionID,
+		consent.MerchantID,
+		consent.PhoneNumber,
+		nullString(consent.Name),
+		string(consent.Medium),
+	).Scan(&consent.CreatedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to save customer consent: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CustomerRepositoryImpl) TakeConsent(ctx context.Context, transactionID uuid.UUID) (*entity.CustomerConsent, error) {
+	query := `
+		DELETE FROM public.saved_customer_consents
+		WHERE transaction_id = $1
+		RETURNING transaction_id, merchant_id, phone_number, name, medium, created_at
+	`
+
+	var (
+		consent entity.CustomerConsent
+		name    sql.NullString
+		medium  string
+	)
+	err := r.db.QueryRowContext(ctx, query, transactionID).Scan(
+		&consent.TransactionID,
+		&consent.MerchantID,
+		&consent.PhoneNumber,
+		&name,
+		&medium,
+		&consent.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to take customer consent: %w", err)
+	}
+
+	consent.Name = name.String
+	consent.Medium = txEntity.TransactionMedium(medium)
+
+	return &consent, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSavedCustomer(row rowScanner, extra ...interface{}) (*entity.SavedCustomer, error) {
+	var (
+		c                              entity.SavedCustomer
+		erpCustomerID                  uuid.NullUUID
+		name, email, medium, cardToken sql.NullString
+		lastPaymentAt                  sql.NullTime
+	)
+
+	dest := []interface{}{
+		&c.ID,
+		&c.MerchantID,
+		&erpCustomerID,
+		&c.PhoneNumber,
+		&name,
+		&email,
+		&medium,
+		&cardToken,
+		&c.ConsentedAt,
+		&lastPaymentAt,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if erpCustomerID.Valid {
+		c.ERPCustomerID = &erpCustomerID.UUID
+	}
+	if medium.Valid {
+		preferred := txEntity.TransactionMedium(medium.String)
+		c.PreferredMedium = &preferred
+	}
+	c.Name = name.String
+	c.Email = email.String
+	c.CardToken = cardToken.String
+	c.HasCardToken = cardToken.Valid && cardToken.String != ""
+	c.LastPaymentAt = timePtr(lastPaymentAt)
+
+	return &c, nil
+}
+
+func nullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,31 @@
+-- Customer Vault Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.saved_customers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    erp_customer_id UUID REFERENCES erp.customers(id) ON DELETE SET NULL,
+    phone_number VARCHAR(50) NOT NULL,
+    name VARCHAR(255),
+    email VARCHAR(255),
+    preferred_medium VARCHAR(50),
+    card_token VARCHAR(255),
+    consented_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_payment_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (merchant_id, phone_number)
+);
+
+-- Consent given at payment time, turned into a saved customer once the payment succeeds
+CREATE TABLE IF NOT EXISTS public.saved_customer_consents (
+    transaction_id UUID PRIMARY KEY REFERENCES public.transactions(id) ON DELETE CASCADE,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    phone_number VARCHAR(50) NOT NULL,
+    name VARCHAR(255),
+    medium VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_customers_merchant_id ON public.saved_customers(merchant_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant_phone ON public.transactions(merchant_id, phone_number);
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/customer_vault/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/customer_vault/core/repository"
+	erpRepository "github.com/socialpay/socialpay/src/pkg/erp_v2/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/shared/pagination"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// cardTokenFields are the provider data keys Cybersource returns the payment token under
+var cardTokenFields = []string{"payment_token", "req_payment_token"}
+
+// CustomerVaultUseCase defines the interface for saved customer operations
+type CustomerVaultUseCase interface {
+	// ListCustomers retrieves the saved customers of a merchant, optionally filtered by phone, name or email
+	ListCustomers(ctx context.Context, merchantID uuid.UUID, search string, pagination *pagination.Pagination) (*entity.SavedCustomersListResponse, error)
+
+	// GetCustomer retrieves a saved customer with its lifetime value
+	GetCustomer(ctx context.Context, id, merchantID uuid.UUID) (*entity.SavedCustomerResponse, error)
+
+	// DeleteCustomer removes a saved customer and its stored payment details
+	DeleteCustomer(ctx context.Context, id, merchantID uuid.UUID) error
+
+	// ResolveCustomer retrieves a saved customer referenced by customer_id in a payment request
+	ResolveCustomer(ctx context.Context, id, merchantID uuid.UUID) (*entity.SavedCustomer, error)
+
+	// RecordConsent stores the payer's consent to be saved once the payment succeeds
+	RecordConsent(ctx context.Context, consent *entity.CustomerConsent) error
+
+	// SaveFromTransaction creates or refreshes the saved customer of a successful transaction with consent
+	SaveFromTransaction(ctx context.Context, txn *txEntity.Transaction, providerData string) error
+}
+
+type customerVaultUseCase struct {
+	customerRepo repository.CustomerRepository
+	erpRepo      erpRepository.Repository
+	log          logging.Logger
+}
+
+func NewCustomerVaultUseCase(customerRepo repository.CustomerRepository, erpRepo erpRepository.Repository) CustomerVaultUseCase {
+	return &customerVaultUseCase{
+		customerRepo: customerRepo,
+		erpRepo:      erpRepo,
+		log:          logging.NewStdLogger("customer_vault_usecase"),
+	}
+}
+
+func (uc *customerVaultUseCase) ListCustomers(ctx context.Context, merchantID uuid.UUID, search string, pag *pagination.Pagination) (*entity.SavedCustomersListResponse, error) {
+	customers, total, err := uc.customerRepo.List(ctx, merchantID, search, pag.PerPage, (pag.Page-1)*pag.PerPage)
+	if err != nil {
+		uc.log.Error("Failed to list saved customers", map[string]interface{}{
+			"merchant_id": merchantID,
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	responses := make([]entity.SavedCustomerResponse, len(customers))
+	for i := range customers {
+		responses[i] = entity.SavedCustomerResponse{
+			SavedCustomer: &customers[i].SavedCustomer,
+			LifetimeValue: customers[i].LifetimeValue,
+		}
+	}
+
+	return &entity.SavedCustomersListResponse{
+		Customers: responses,
+		Total:     total,
+		Page:      pag.Page,
+		Limit:     pag.PerPage,
+	}, nil
+}
+
+func (uc *customerVaultUseCase) GetCustomer(ctx context.Context, id, merchantID uuid.UUID) (*entity.SavedCustomerResponse, error) {
+	customer, err := uc.customerRepo.GetByID(ctx, id, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ltv, err := uc.customerRepo.GetLifetimeValue(ctx, merchantID, customer.PhoneNumber)
+	if err != nil {
+		uc.log.Error("Failed to get customer lifetime value", map[string]interface{}{
+			"customer_id": id,
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	response := &entity.SavedCustomerResponse{
+		SavedCustomer: customer,
+		LifetimeValue: *ltv,
+	}
+
+	if customer.ERPCustomerID != nil && uc.erpRepo != nil {
+		erpCustomer, err := uc.erpRepo.GetCustomer(ctx, *customer.ERPCustomerID)
+		if err != nil {
+			uc.log.Warn("Failed to load linked ERP customer", map[string]interface{}{
+				"customer_id":     id,
+				"erp_customer_id": customer.ERPCustomerID,
+				"error":           err.Error(),
+			})
+		} else {
+			response.ERPCustomer = erpCustomer
+		}
+	}
+
+	return response, nil
+}
+
+func (uc *customerVaultUseCase) DeleteCustomer(ctx context.Context, id, merchantID uuid.UUID) error {
+	if err := uc.customerRepo.Delete(ctx, id, merchantID); err != nil {
+		return err
+	}
+
+	uc.log.Info("Saved customer deleted", map[string]interface{}{
+		"customer_id": id,
+		"merchant_id": merchantID,
+	})
+
+	return nil
+}
+
+func (uc *customerVaultUseCase) ResolveCustomer(ctx context.Context, id, merchantID uuid.UUID) (*entity.SavedCustomer, error) {
+	customer, err := uc.customerRepo.GetByID(ctx, id, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer_id: %w", err)
+	}
+
+	return customer, nil
+}
+
+func (uc *customerVaultUseCase) RecordConsent(ctx context.Context, consent *entity.CustomerConsent) error {
+	if consent.PhoneNumber == "" {
+		return nil
+	}
+
+	if err := uc.customerRepo.SaveConsent(ctx, consent); err != nil {
+		uc.log.Error("Failed to record customer consent", map[string]interface{}{
+			"transaction_id": consent.TransactionID,
+			"error":          err.Error(),
+		})
+		return err
+	}
+
+	return nil
+}
+
+func (uc *customerVaultUseCase) SaveFromTransaction(ctx context.Context, txn *txEntity.Transaction, providerData string) error {
+	consent, err := uc.customerRepo.TakeConsent(ctx, txn.Id)
+	if err != nil {
+		return err
+	}
+	if consent == nil {
+		// Payer did not consent to be saved
+		return nil
+	}
+
+	now := time.Now()
+	medium := consent.Medium
+	customer := &entity.SavedCustomer{
+		ID:              uuid.New(),
+		MerchantID:      consent.MerchantID,
+		PhoneNumber:     consent.PhoneNumber,
+		Name:            consent.Name,
+		PreferredMedium: &medium,
+		LastPaymentAt:   &now,
+	}
+
+	if medium == txEntity.CYBERSOURCE {
+		customer.CardToken = extractCardToken(providerData)
+	}
+
+	erpCustomerID, err := uc.customerRepo.FindERPCustomerID(ctx, consent.MerchantID, consent.PhoneNumber)
+	if err != nil {
+		uc.log.Warn("Failed to look up ERP customer", map[string]interface{}{
+			"transaction_id": txn.Id,
+			"error":          err.Error(),
+		})
+	}
+	customer.ERPCustomerID = erpCustomerID
+
+	if err := uc.customerRepo.Upsert(ctx, customer); err != nil {
+		uc.log.Error("Failed to save customer from transaction", map[string]interface{}{
+			"transaction_id": txn.Id,
+			"error":          err.Error(),
+		})
+		return err
+	}
+
+	uc.log.Info("Saved customer from transaction", map[string]interface{}{
+		"transaction_id": txn.Id,
+		"customer_id":    customer.ID,
+		"merchant_id":    customer.MerchantID,
+	})
+
+	return nil
+}
+
+// extractCardToken reads the Cybersource payment token from the callback provider data,
+// which is the form-encoded Secure Acceptance response or a JSON object
+func extractCardToken(providerData string) string {
+	if providerData == "" {
+		return ""
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(providerData), &data); err == nil {
+		for _, field := range cardTokenFields {
+			if token, ok := data[field].(string); ok && token != "" {
+				return token
+			}
+		}
+		return ""
+	}
+
+	form, err := url.ParseQuery(providerData)
+	if err != nil {
+		return ""
+	}
+	for _, field := range cardTokenFields {
+		if token := form.Get(field); token != "" {
+			return token
+		}
+	}
+	return ""
+}
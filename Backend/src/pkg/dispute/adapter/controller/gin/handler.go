@@ -0,0 +1,459 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/dispute/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/dispute/usecase"
+	fileEntity "github.com/socialpay/socialpay/src/pkg/file/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+type Handler struct {
+	disputeUseCase usecase.DisputeUseCase
+	log            logging.Logger
+	jwtMiddleware  gin.HandlerFunc
+	rbac           *ginMiddleware.RBACV2
+}
+
+func NewHandler(disputeUseCase usecase.DisputeUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		disputeUseCase: disputeUseCase,
+		log:            logging.NewStdLogger("dispute_handler"),
+		jwtMiddleware:  jwtMiddleware,
+		rbac:           rbac,
+	}
+}
+
+// RegisterRouter sets up the merchant and admin dispute routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	disputes := router.Group("/disputes", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	disputes.GET("",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_READ),
+		h.List)
+	disputes.GET("/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_READ),
+		h.Get)
+	disputes.POST("/:id/response",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_UPDATE),
+		h.Respond)
+	disputes.POST("/:id/evidence",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_UPDATE),
+		h.UploadEvidence)
+
+	admin := router.Group("/admin/disputes", h.jwtMiddleware)
+	admin.GET("",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_ADMIN_READ),
+		h.AdminList)
+	admin.POST("",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_ADMIN_CREATE),
+		h.Open)
+	admin.GET("/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_ADMIN_READ),
+		h.AdminGet)
+	admin.POST("/:id/status",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.UpdateStatus)
+	admin.POST("/:id/evidence",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_DISPUTE, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.AdminUploadEvidence)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// List godoc
+// @Summary      List disputes
+// @Description  List the disputes raised against the merchant's payments, latest first
+// @Tags         Disputes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query  string  false  "OPEN, NEEDS_RESPONSE, UNDER_REVIEW, WON or LOST"
+// @Param        limit   query  int     false  "Number of disputes (max 200)"
+// @Param        offset  query  int     false  "Disputes to skip"
+// @Success      200  {object}  entity.ListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /disputes [get]
+func (h *Handler) List(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	filter := listFilter(c)
+	filter.MerchantID = &merchantID
+
+	response, err := h.disputeUseCase.List(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Get godoc
+// @Summary      Get a dispute
+// @Description  Get a dispute raised against one of the merchant's payments, with its evidence
+// @Tags         Disputes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Dispute ID"
+// @Success      200  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /disputes/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid dispute ID")))
+		return
+	}
+
+	detail, err := h.disputeUseCase.Get(c.Request.Context(), disputeID, &merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// Respond godoc
+// @Summary      Respond to a dispute
+// @Description  Answer a dispute before its response deadline. Upload supporting evidence first: the dispute goes to review with the response and no more evidence can be added.
+// @Tags         Disputes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true  "Dispute ID"
+// @Param        request  body  entity.ResponseRequest  true  "Response"
+// @Success      200  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /disputes/{id}/response [post]
+func (h *Handler) Respond(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	userID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid dispute ID")))
+		return
+	}
+
+	var req entity.ResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	detail, err := h.disputeUseCase.Respond(c.Request.Context(), disputeID, merchantID, userID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// UploadEvidence godoc
+// @Summary      Upload dispute evidence
+// @Description  Upload a PDF, JPEG or PNG file of up to 10MB supporting the merchant's side of a dispute, until the merchant responds
+// @Tags         Disputes
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Dispute ID"
+// @Param        file         formData  file    true   "Evidence file"
+// @Param        description  formData  string  false  "What the file shows"
+// @Success      201  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /disputes/{id}/evidence [post]
+func (h *Handler) UploadEvidence(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+	h.uploadEvidence(c, &merchantID)
+}
+
+// AdminList godoc
+// @Summary      List disputes
+// @Description  List disputes across merchants, latest first
+// @Tags         Admin Disputes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id     query  string  false  "Merchant ID"
+// @Param        transaction_id  query  string  false  "Transaction ID"
+// @Param        status          query  string  false  "OPEN, NEEDS_RESPONSE, UNDER_REVIEW, WON or LOST"
+// @Param        limit           query  int     false  "Number of disputes (max 200)"
+// @Param        offset          query  int     false  "Disputes to skip"
+// @Success      200  {object}  entity.ListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/disputes [get]
+func (h *Handler) AdminList(c *gin.Context) {
+	filter := listFilter(c)
+	if raw := c.Query("merchant_id"); raw != "" {
+		merchantID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+			return
+		}
+		filter.MerchantID = &merchantID
+	}
+	if raw := c.Query("transaction_id"); raw != "" {
+		transactionID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid transaction ID")))
+			return
+		}
+		filter.TransactionID = &transactionID
+	}
+
+	response, err := h.disputeUseCase.List(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Open godoc
+// @Summary      Open a dispute
+// @Description  Record a chargeback or customer complaint against a successful payment. The disputed amount, by default what earlier lost and open disputes left of the merchant's net, is debited from the merchant's wallet and the merchant is notified by webhook and SMS.
+// @Tags         Admin Disputes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.OpenRequest  true  "Dispute"
+// @Success      201  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /admin/disputes [post]
+func (h *Handler) Open(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.OpenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	detail, err := h.disputeUseCase.Open(c.Request.Context(), &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, detail)
+}
+
+// AdminGet godoc
+// @Summary      Get a dispute
+// @Description  Get a dispute with its evidence
+// @Tags         Admin Disputes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Dispute ID"
+// @Success      200  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/disputes/{id} [get]
+func (h *Handler) AdminGet(c *gin.Context) {
+	disputeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid dispute ID")))
+		return
+	}
+
+	detail, err := h.disputeUseCase.Get(c.Request.Context(), disputeID, nil)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// UpdateStatus godoc
+// @Summary      Change the status of a dispute
+// @Description  Move a dispute along OPEN, NEEDS_RESPONSE, UNDER_REVIEW, then WON or LOST. NEEDS_RESPONSE resets the response deadline; WON credits the debited amount back to the merchant's wallet.
+// @Tags         Admin Disputes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                true  "Dispute ID"
+// @Param        request  body  entity.StatusRequest  true  "Status change"
+// @Success      200  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/disputes/{id}/status [post]
+func (h *Handler) UpdateStatus(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid dispute ID")))
+		return
+	}
+
+	var req entity.StatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	detail, err := h.disputeUseCase.UpdateStatus(c.Request.Context(), disputeID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// AdminUploadEvidence godoc
+// @Summary      Upload dispute evidence
+// @Description  Upload a PDF, JPEG or PNG file of up to 10MB to a dispute that is not closed, such as the issuer's chargeback documents
+// @Tags         Admin Disputes
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Dispute ID"
+// @Param        file         formData  file    true   "Evidence file"
+// @Param        description  formData  string  false  "What the file shows"
+// @Success      201  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/disputes/{id}/evidence [post]
+func (h *Handler) AdminUploadEvidence(c *gin.Context) {
+	h.uploadEvidence(c, nil)
+}
+
+// uploadEvidence stores the uploaded file as evidence, as the merchant's when merchantID is set
+func (h *Handler) uploadEvidence(c *gin.Context, merchantID *uuid.UUID) {
+	userID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid dispute ID")))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("no file is received")))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("failed to open file")))
+		return
+	}
+	defer file.Close()
+
+	upload := &entity.EvidenceUpload{
+		File:        file,
+		Header:      *fileHeader,
+		Description: c.PostForm("description"),
+	}
+	detail, err := h.disputeUseCase.UploadEvidence(c.Request.Context(), disputeID, merchantID, userID, upload)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, detail)
+}
+
+func listFilter(c *gin.Context) entity.Filter {
+	filter := entity.Filter{
+		Status: entity.Status(strings.ToUpper(c.Query("status"))),
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filter.Offset, _ = strconv.Atoi(c.Query("offset"))
+	return filter
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	var fileErr *fileEntity.FileError
+	switch {
+	case errors.Is(err, entity.ErrDisputeNotFound),
+		errors.Is(err, entity.ErrTransactionNotFound),
+		errors.Is(err, entity.ErrWalletNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrDisputeExists),
+		errors.Is(err, entity.ErrDisputeClosed),
+		errors.Is(err, entity.ErrInvalidTransition),
+		errors.Is(err, entity.ErrResponseNotAccepted):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case errors.Is(err, entity.ErrNotDisputable),
+		errors.Is(err, entity.ErrDisputedInFull):
+		c.JSON(http.StatusUnprocessableEntity, newErrorResponse(err))
+	case errors.As(err, &fileErr),
+		strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("Dispute request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
@@ -0,0 +1,343 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	fxEntity "github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+var (
+	// ErrDisputeNotFound is returned when no dispute has the given ID
+	ErrDisputeNotFound = errors.New("dispute not found")
+	// ErrTransactionNotFound is returned when the disputed transaction does not exist
+	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrNotDisputable is returned when the transaction is not a successful payment
+	ErrNotDisputable = errors.New("transaction cannot be disputed")
+	// ErrDisputedInFull is returned when earlier lost and open disputes already cover the merchant's net of the transaction
+	ErrDisputedInFull = errors.New("transaction has no amount left to dispute")
+	// ErrDisputeExists is returned when the transaction already has a dispute that is not closed
+	ErrDisputeExists = errors.New("transaction already has an open dispute")
+	// ErrInvalidTransition is returned when the dispute cannot move to the requested status
+	ErrInvalidTransition = errors.New("invalid dispute status transition")
+	// ErrDisputeClosed is returned when a dispute already won or lost is changed
+	ErrDisputeClosed = errors.New("dispute already closed")
+	// ErrResponseNotAccepted is returned when the merchant responds to a dispute under review or past its deadline
+	ErrResponseNotAccepted = errors.New("dispute does not accept a response")
+	// ErrWalletNotFound is returned when the merchant holds no wallet in the dispute currency
+	ErrWalletNotFound = errors.New("merchant wallet not found")
+)
+
+const (
+	// DefaultResponseDays is how long a merchant has to respond when the admin sets no deadline
+	DefaultResponseDays = 7
+	// MaxResponseDays bounds the response deadline of a dispute
+	MaxResponseDays = 45
+)
+
+// Status is where a dispute stands. Disputes open, may wait on the merchant's response, are
+// reviewed and are then won or lost.
+type Status string
+
+const (
+	StatusOpen          Status = "OPEN"
+	StatusNeedsResponse Status = "NEEDS_RESPONSE"
+	StatusUnderReview   Status = "UNDER_REVIEW"
+	StatusWon           Status = "WON"
+	StatusLost          Status = "LOST"
+)
+
+// transitions lists the statuses each status can move to
+var transitions = map[Status][]Status{
+	StatusOpen:          {StatusNeedsResponse, StatusUnderReview, StatusLost},
+	StatusNeedsResponse: {StatusUnderReview, StatusLost},
+	StatusUnderReview:   {StatusNeedsResponse, StatusWon, StatusLost},
+}
+
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusNeedsResponse, StatusUnderReview, StatusWon, StatusLost:
+		return true
+	}
+	return false
+}
+
+// Closed reports whether the dispute was decided
+func (s Status) Closed() bool {
+	return s == StatusWon || s == StatusLost
+}
+
+// AcceptsResponse reports whether the merchant can still respond and upload evidence
+func (s Status) AcceptsResponse() bool {
+	return s == StatusOpen || s == StatusNeedsResponse
+}
+
+// CanMoveTo reports whether a dispute in status s can move to status to
+func (s Status) CanMoveTo(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Reason is why the customer disputes the payment
+type Reason string
+
+const (
+	ReasonFraud              Reason = "FRAUD"
+	ReasonUnrecognized       Reason = "UNRECOGNIZED"
+	ReasonDuplicate          Reason = "DUPLICATE"
+	ReasonProductNotReceived Reason = "PRODUCT_NOT_RECEIVED"
+	ReasonNotAsDescribed     Reason = "NOT_AS_DESCRIBED"
+	ReasonCreditNotProcessed Reason = "CREDIT_NOT_PROCESSED"
+	ReasonIncorrectAmount    Reason = "INCORRECT_AMOUNT"
+	ReasonOther              Reason = "OTHER"
+)
+
+// IsValid reports whether r is a known reason
+func (r Reason) IsValid() bool {
+	switch r {
+	case ReasonFraud, ReasonUnrecognized, ReasonDuplicate, ReasonProductNotReceived,
+		ReasonNotAsDescribed, ReasonCreditNotProcessed, ReasonIncorrectAmount, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// Source is how the dispute reached SocialPay
+type Source string
+
+const (
+	// SourceChargeback is a card chargeback raised through the card network
+	SourceChargeback Source = "CHARGEBACK"
+	// SourceComplaint is a complaint a mobile-money or bank customer filed
+	SourceComplaint Source = "COMPLAINT"
+)
+
+// SourceOf is the source of disputes on payments made through a medium
+func SourceOf(medium txEntity.TransactionMedium) Source {
+	if medium == txEntity.CYBERSOURCE {
+		return SourceChargeback
+	}
+	return SourceComplaint
+}
+
+// Disputable reports whether a transaction is a payment that can be disputed
+func Disputable(txn *txEntity.Transaction) bool {
+	return txn.Type == txEntity.DEPOSIT && txn.Status == txEntity.SUCCESS
+}
+
+// DisputableAmount is what is left to dispute of a payment: the merchant's net less disputed,
+// the amount of its earlier disputes that were lost or are still open. Fees and VAT are not
+// clawed back from the merchant.
+func DisputableAmount(merchantNet, disputed float64) float64 {
+	return math.Round((merchantNet-disputed)*100) / 100
+}
+
+// DebitOf is the currency and amount a dispute of amount debits from the merchant's wallet. A
+// deposit converted at settlement is debited from the wallet it was credited to, at the rate it
+// was converted at. Test deposits and deposits collected into the merchant's own account were
+// never credited, so nothing is debited.
+func DebitOf(txn *txEntity.Transaction, amount float64) (string, float64) {
+	if txn.Test || txn.SettlesToMerchantAccount() {
+		return "", 0
+	}
+
+	var settlement fxEntity.Settlement
+	if details, ok := txn.Details.(map[string]interface{}); ok {
+		if raw, err := json.Marshal(details[txEntity.FXDetailKey]); err == nil {
+			_ = json.Unmarshal(raw, &settlement)
+		}
+	}
+	conversion := settlement.Merchant
+	if conversion == nil || conversion.ToCurrency == "" || conversion.Rate <= 0 {
+		return txn.WalletCurrency(), amount
+	}
+	return conversion.ToCurrency, math.Round(amount*conversion.Rate*100) / 100
+}
+
+// Dispute is a customer's challenge of a payment. The disputed amount is debited from the
+// merchant's wallet when the dispute opens and credited back when the merchant wins it.
+// @Description Payment dispute
+type Dispute struct {
+	ID                uuid.UUID  `json:"id"`
+	TransactionID     uuid.UUID  `json:"transaction_id"`
+	MerchantID        uuid.UUID  `json:"merchant_id"`
+	Source            Source     `json:"source" example:"CHARGEBACK"`
+	Reason            Reason     `json:"reason" example:"PRODUCT_NOT_RECEIVED"`
+	NetworkReasonCode string     `json:"network_reason_code,omitempty" example:"13.1"`
+	Description       string     `json:"description,omitempty"`
+	Status            Status     `json:"status" example:"OPEN"`
+	Currency          string     `json:"currency" example:"ETB"`
+	Amount            float64    `json:"amount" example:"1500"`
+	DebitedCurrency   string     `json:"debited_currency,omitempty" example:"ETB"`
+	DebitedAmount     float64    `json:"debited_amount" example:"1500"`
+	ResponseDueAt     time.Time  `json:"response_due_at"`
+	MerchantResponse  string     `json:"merchant_response,omitempty"`
+	RespondedBy       *uuid.UUID `json:"responded_by,omitempty"`
+	RespondedAt       *time.Time `json:"responded_at,omitempty"`
+	StatusNote        string     `json:"status_note,omitempty"`
+	OpenedBy          uuid.UUID  `json:"opened_by"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Overdue reports whether the merchant let the response deadline pass
+func (d *Dispute) Overdue(now time.Time) bool {
+	return d.Status.AcceptsResponse() && now.After(d.ResponseDueAt)
+}
+
+// Evidence is a document uploaded to support one side of a dispute
+// @Description Dispute evidence
+type Evidence struct {
+	ID          uuid.UUID `json:"id"`
+	DisputeID   uuid.UUID `json:"dispute_id"`
+	FileURL     string    `json:"file_url"`
+	FileName    string    `json:"file_name"`
+	Description string    `json:"description,omitempty" example:"Signed delivery note"`
+	UploadedBy  uuid.UUID `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// EvidenceUpload is a file uploaded as evidence of a dispute
+type EvidenceUpload struct {
+	File        multipart.File
+	Header      multipart.FileHeader
+	Description string
+}
+
+// Validate validates the upload
+func (u *EvidenceUpload) Validate() error {
+	if u.File == nil {
+		return errors.New("file: cannot be blank")
+	}
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Description, validation.Length(0, 500)),
+	)
+}
+
+// Detail is a dispute with its evidence, oldest upload first
+// @Description Payment dispute with evidence
+type Detail struct {
+	Dispute
+	Overdue  bool       `json:"overdue"`
+	Evidence []Evidence `json:"evidence"`
+}
+
+// OpenRequest opens a dispute on a transaction
+// @Description Dispute opening
+type OpenRequest struct {
+	TransactionID     uuid.UUID `json:"transaction_id"`
+	Reason            Reason    `json:"reason" example:"PRODUCT_NOT_RECEIVED"`
+	NetworkReasonCode string    `json:"network_reason_code,omitempty" example:"13.1"`
+	Description       string    `json:"description,omitempty" example:"Customer says the order never arrived"`
+	// Amount defaults to what is left to dispute of the merchant's net of the transaction
+	Amount float64 `json:"amount,omitempty" example:"1500"`
+	// ResponseDays is how many days the merchant has to respond, 7 by default
+	ResponseDays int `json:"response_days,omitempty" example:"7"`
+}
+
+// Normalize puts the reason in upper case and applies the default deadline
+func (r *OpenRequest) Normalize() {
+	r.Reason = Reason(strings.ToUpper(strings.TrimSpace(string(r.Reason))))
+	r.NetworkReasonCode = strings.TrimSpace(r.NetworkReasonCode)
+	if r.ResponseDays == 0 {
+		r.ResponseDays = DefaultResponseDays
+	}
+}
+
+// Validate validates the opening
+func (r *OpenRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Reason, validation.Required),
+		validation.Field(&r.NetworkReasonCode, validation.Length(0, 20)),
+		validation.Field(&r.Description, validation.Length(0, 1000)),
+		validation.Field(&r.Amount, validation.Min(0.0)),
+		validation.Field(&r.ResponseDays, validation.Min(1), validation.Max(MaxResponseDays)),
+	); err != nil {
+		return err
+	}
+	if r.TransactionID == uuid.Nil {
+		return errors.New("transaction_id: cannot be blank")
+	}
+	if !r.Reason.IsValid() {
+		return fmt.Errorf("unsupported reason: %s", r.Reason)
+	}
+	return nil
+}
+
+// StatusRequest moves a dispute along its status flow
+// @Description Dispute status change
+type StatusRequest struct {
+	Status Status `json:"status" example:"UNDER_REVIEW"`
+	Note   string `json:"note,omitempty" example:"Issuer accepted the delivery proof"`
+	// ResponseDays resets the response deadline when the dispute goes back to the merchant
+	ResponseDays int `json:"response_days,omitempty" example:"7"`
+}
+
+// Normalize puts the status in upper case and applies the default deadline when the dispute
+// waits on the merchant
+func (r *StatusRequest) Normalize() {
+	r.Status = Status(strings.ToUpper(strings.TrimSpace(string(r.Status))))
+	if r.Status == StatusNeedsResponse && r.ResponseDays == 0 {
+		r.ResponseDays = DefaultResponseDays
+	}
+}
+
+// Validate validates the status change
+func (r *StatusRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Status, validation.Required),
+		validation.Field(&r.Note, validation.Length(0, 1000)),
+		validation.Field(&r.ResponseDays, validation.Min(0), validation.Max(MaxResponseDays)),
+	); err != nil {
+		return err
+	}
+	if !r.Status.IsValid() {
+		return fmt.Errorf("unsupported status: %s", r.Status)
+	}
+	return nil
+}
+
+// ResponseRequest is the merchant's answer to a dispute
+// @Description Merchant dispute response
+type ResponseRequest struct {
+	Response string `json:"response" example:"The order was delivered on 3 May, see the signed delivery note"`
+}
+
+// Validate validates the response
+func (r *ResponseRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Response, validation.Required, validation.Length(1, 5000)),
+	)
+}
+
+// Filter selects disputes
+type Filter struct {
+	MerchantID    *uuid.UUID
+	TransactionID *uuid.UUID
+	Status        Status
+	Limit         int
+	Offset        int
+}
+
+// ListResponse is a page of disputes, latest first
+// @Description Payment disputes
+type ListResponse struct {
+	Disputes []Dispute `json:"disputes"`
+	Total    int       `json:"total"`
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"testing"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestStatusCanMoveTo(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusOpen, StatusNeedsResponse, true},
+		{StatusOpen, StatusUnderReview, true},
+		{StatusOpen, StatusWon, false},
+		{StatusNeedsResponse, StatusUnderReview, true},
+		{StatusNeedsResponse, StatusLost, true},
+		{StatusNeedsResponse, StatusWon, false},
+		{StatusUnderReview, StatusWon, true},
+		{StatusUnderReview, StatusLost, true},
+		{StatusUnderReview, StatusOpen, false},
+		{StatusWon, StatusLost, false},
+		{StatusLost, StatusUnderReview, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanMoveTo(tt.to); got != tt.want {
+			t.Errorf("%s.CanMoveTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestDebitOf(t *testing.T) {
+	converted := map[string]interface{}{
+		txEntity.FXDetailKey: map[string]interface{}{
+			"wallet_currency": "ETB",
+			"merchant": map[string]interface{}{
+				"from_currency": "USD",
+				"to_currency":   "ETB",
+				"rate":          130.5,
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		txn          txEntity.Transaction
+		wantCurrency string
+		wantAmount   float64
+	}{
+		{"credited in the transaction currency", txEntity.Transaction{Currency: "usd"}, "USD", 20},
+		{"converted at settlement", txEntity.Transaction{Currency: "USD", Details: converted}, "ETB", 2610},
+		{"test payment", txEntity.Transaction{Currency: "ETB", Test: true}, "", 0},
+		{"collected into the merchant's account", txEntity.Transaction{
+			Currency: "ETB",
+			Details:  map[string]interface{}{txEntity.SettlementDetailKey: txEntity.SettlementMerchantAccount},
+		}, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency, amount := DebitOf(&tt.txn, 20)
+			if currency != tt.wantCurrency || amount != tt.wantAmount {
+				t.Fatalf("DebitOf() = %s %v, want %s %v", currency, amount, tt.wantCurrency, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestDisputableAmount(t *testing.T) {
+	tests := []struct {
+		name                  string
+		merchantNet, disputed float64
+		want                  float64
+	}{
+		{"first dispute", 970.5, 0, 970.5},
+		{"after a partial dispute was lost", 970.5, 400.25, 570.25},
+		{"disputed in full", 970.5, 970.5, 0},
+	}
+
+	for _, tt := range tests {
+		if got := DisputableAmount(tt.merchantNet, tt.disputed); got != tt.want {
+			t.Errorf("%s: DisputableAmount(%v, %v) = %v, want %v", tt.name, tt.merchantNet, tt.disputed, got, tt.want)
+		}
+	}
+}
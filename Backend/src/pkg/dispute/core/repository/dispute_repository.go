@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/dispute/core/entity"
+)
+
+// DisputeRepository defines the interface for disputes and their evidence. The disputed amount
+// moves out of and back into the amount of the merchant's wallet as disputes are stored and won.
+type DisputeRepository interface {
+	// Create stores a dispute and, when it is marked debited, debits its amount from the merchant's
+	// wallet in its currency and posts the debit as a DISPUTE transaction. The balance may go
+	// negative: the merchant then owes the difference. It returns entity.ErrDisputeExists when the
+	// transaction already has a dispute that is not closed, and entity.ErrDisputedInFull when earlier
+	// lost and open disputes leave nothing of the transaction to dispute.
+	Create(ctx context.Context, dispute *entity.Dispute) error
+	// Get retrieves a dispute, nil when none exists
+	Get(ctx context.Context, id uuid.UUID) (*entity.Dispute, error)
+	// List retrieves a page of disputes, latest first, with the number of disputes matching the filter
+	List(ctx context.Context, filter entity.Filter) ([]entity.Dispute, int, error)
+	// Update saves a dispute that was in status from. A debited dispute that is won is credited
+	// back to the merchant's wallet, posted as a DISPUTE transaction. It returns entity.ErrInvalidTransition when the dispute
+	// moved on meanwhile.
+	Update(ctx context.Context, dispute *entity.Dispute, from entity.Status) error
+	// Disputed sums the amounts of the disputes of a transaction that were lost or are still open
+	Disputed(ctx context.Context, transactionID uuid.UUID) (float64, error)
+	// ListOverdue retrieves disputes waiting on the merchant past their response deadline, the first due first
+	ListOverdue(ctx context.Context, now time.Time, limit int) ([]entity.Dispute, error)
+
+	AddEvidence(ctx context.Context, evidence *entity.Evidence) error
+	// ListEvidence retrieves the evidence of a dispute, oldest first
+	ListEvidence(ctx context.Context, disputeID uuid.UUID) ([]entity.Evidence, error)
+}
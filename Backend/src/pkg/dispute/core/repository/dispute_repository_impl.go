@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/socialpay/socialpay/src/pkg/dispute/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const disputeColumns = `id, transaction_id, merchant_id, source, reason, network_reason_code, description,
+	status, currency, amount, debited_currency, debited_amount, response_due_at, merchant_response, responded_by,
+	responded_at, status_note, opened_by, updated_by, resolved_at, created_at, updated_at`
+
+const evidenceColumns = `id, dispute_id, file_url, file_name, description, uploaded_by, created_at`
+
+type DisputeRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewDisputeRepository(db *sql.DB) DisputeRepository {
+	return &DisputeRepositoryImpl{db: db}
+}
+
+func (r *DisputeRepositoryImpl) Create(ctx context.Context, dispute *entity.Dispute) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Disputes of the same transaction queue on its row, so each one sees the amount the
+	// others claimed
+	var merchantNet float64
+	if err := dbTx.QueryRowContext(ctx,
+		`SELECT COALESCE(merchant_net, 0) FROM public.transactions WHERE id = $1 FOR UPDATE`,
+		dispute.TransactionID,
+	).Scan(&merchantNet); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ErrTransactionNotFound
+		}
+		return fmt.Errorf("failed to lock disputed transaction: %w", err)
+	}
+	disputed, err := disputedAmount(ctx, dbTx, dispute.TransactionID)
+	if err != nil {
+		return err
+	}
+	left := entity.DisputableAmount(merchantNet, disputed)
+	if left <= 0 {
+		return entity.ErrDisputedInFull
+	}
+	if dispute.Amount > left {
+		return fmt.Errorf("validation failed: amount exceeds the %.2f left to dispute", left)
+	}
+
+	query := `
+		INSERT INTO public.disputes (` + disputeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
+
+	if _, err := dbTx.ExecContext(ctx, query,
+		dispute.ID, dispute.TransactionID, dispute.MerchantID, string(dispute.Source), string(dispute.Reason),
+		nullString(dispute.NetworkReasonCode), nullString(dispute.Description), string(dispute.Status),
+		dispute.Currency, dispute.Amount, nullString(dispute.DebitedCurrency), dispute.DebitedAmount, dispute.ResponseDueAt,
+		nullString(dispute.MerchantResponse), dispute.RespondedBy, dispute.RespondedAt,
+		nullString(dispute.StatusNote), dispute.OpenedBy, dispute.UpdatedBy, dispute.ResolvedAt,
+		dispute.CreatedAt, dispute.UpdatedAt,
+	); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return entity.ErrDisputeExists
+		}
+		return fmt.Errorf("failed to create dispute: %w", err)
+	}
+
+	if dispute.DebitedAmount > 0 {
+		if err := adjustWallet(ctx, dbTx, dispute, -dispute.DebitedAmount, dispute.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dispute: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DisputeRepositoryImpl) Get(ctx context.Context, id uuid.UUID) (*entity.Dispute, error) {
+	query := `SELECT ` + disputeColumns + ` FROM public.disputes WHERE id = $1`
+
+	dispute, err := scanDispute(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get dispute: %w", err)
+	}
+
+	return dispute, nil
+}
+
+func (r *DisputeRepositoryImpl) List(ctx context.Context, filter entity.Filter) ([]entity.Dispute, int, error) {
+	where := `WHERE ($1::uuid IS NULL OR merchant_id = $1)
+		AND ($2::uuid IS NULL OR transaction_id = $2)
+		AND ($3 = '' OR status = $3)`
+	args := []interface{}{filter.MerchantID, filter.TransactionID, string(filter.Status)}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM public.disputes ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count disputes: %w", err)
+	}
+
+	query := `
+		SELECT ` + disputeColumns + `
+		FROM public.disputes
+		` + where + `
+		ORDER BY created_at DESC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list disputes: %w", err)
+	}
+	defer rows.Close()
+
+	disputes, err := scanDisputes(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return disputes, total, nil
+}
+
+func (r *DisputeRepositoryImpl) Update(ctx context.Context, dispute *entity.Dispute, from entity.Status) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	query := `
+		UPDATE public.disputes
+		SET status = $3, response_due_at = $4, merchant_response = $5, responded_by = $6, responded_at = $7,
+			status_note = $8, updated_by = $9, resolved_at = $10, updated_at = $11
+		WHERE id = $1 AND status = $2`
+
+	result, err := dbTx.ExecContext(ctx, query,
+		dispute.ID, string(from), string(dispute.Status), dispute.ResponseDueAt,
+		nullString(dispute.MerchantResponse), dispute.RespondedBy, dispute.RespondedAt,
+		nullString(dispute.StatusNote), dispute.UpdatedBy, dispute.ResolvedAt, dispute.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update dispute: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update dispute: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrInvalidTransition
+	}
+
+	if dispute.Status == entity.StatusWon && dispute.DebitedAmount > 0 {
+		if err := adjustWallet(ctx, dbTx, dispute, dispute.DebitedAmount, dispute.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dispute: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DisputeRepositoryImpl) Disputed(ctx context.Context, transactionID uuid.UUID) (float64, error) {
+	return disputedAmount(ctx, r.db, transactionID)
+}
+
+func (r *DisputeRepositoryImpl) ListOverdue(ctx context.Context, now time.Time, limit int) ([]entity.Dispute, error) {
+	query := `
+		SELECT ` + disputeColumns + `
+		FROM public.disputes
+		WHERE status IN ($1, $2) AND response_due_at <= $3
+		ORDER BY response_due_at
+		LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		string(entity.StatusOpen), string(entity.StatusNeedsResponse), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue disputes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDisputes(rows)
+}
+
+func (r *DisputeRepositoryImpl) AddEvidence(ctx context.Context, evidence *entity.Evidence) error {
+	query := `
+		INSERT INTO public.dispute_evidence (` + evidenceColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		evidence.ID, evidence.DisputeID, evidence.FileURL, evidence.FileName,
+		nullString(evidence.Description), evidence.UploadedBy, evidence.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to add dispute evidence: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DisputeRepositoryImpl) ListEvidence(ctx context.Context, disputeID uuid.UUID) ([]entity.Evidence, error) {
+	query := `
+		SELECT ` + evidenceColumns + `
+		FROM public.dispute_evidence
+		WHERE dispute_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, disputeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispute evidence: %w", err)
+	}
+	defer rows.Close()
+
+	var evidence []entity.Evidence
+	for rows.Next() {
+		var item entity.Evidence
+		var description sql.NullString
+		if err := rows.Scan(
+			&item.ID, &item.DisputeID, &item.FileURL, &item.FileName, &description, &item.UploadedBy, &item.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dispute evidence: %w", err)
+		}
+		item.Description = description.String
+		evidence = append(evidence, item)
+	}
+
+	return evidence, rows.Err()
+}
+
+// queryRower is satisfied by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// disputedAmount sums the disputes of a transaction that were lost or are still open
+func disputedAmount(ctx context.Context, q queryRower, transactionID uuid.UUID) (float64, error) {
+	var disputed float64
+	if err := q.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(amount), 0) FROM public.disputes WHERE transaction_id = $1 AND status <> $2`,
+		transactionID, string(entity.StatusWon),
+	).Scan(&disputed); err != nil {
+		return 0, fmt.Errorf("failed to sum disputes: %w", err)
+	}
+
+	return disputed, nil
+}
+
+// adjustWallet moves an amount of a dispute into or out of the merchant's wallet in the debited
+// currency and posts it as a DISPUTE transaction
+func adjustWallet(ctx context.Context, dbTx *sql.Tx, dispute *entity.Dispute, amount float64, at time.Time) error {
+	query := `
+		UPDATE merchant.wallet
+		SET amount = amount + $3,
+			updated_at = NOW()
+		WHERE merchant_id = $1 AND wallet_type = 'merchant' AND currency = $2
+		RETURNING user_id`
+
+	var userID uuid.UUID
+	if err := dbTx.QueryRowContext(ctx, query, dispute.MerchantID, dispute.DebitedCurrency, amount).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ErrWalletNotFound
+		}
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	return postTransaction(ctx, dbTx, dispute, userID, amount, at)
+}
+
+// postTransaction records a wallet movement of a dispute as a successful DISPUTE transaction of
+// the wallet's owner. The signed amount goes to merchant_net, so the debit and its return show
+// in the merchant's statement and ledger.
+func postTransaction(ctx context.Context, dbTx *sql.Tx, dispute *entity.Dispute, userID uuid.UUID, amount float64, at time.Time) error {
+	detailsJSON, err := json.Marshal(map[string]interface{}{
+		"dispute_id":              dispute.ID,
+		"disputed_transaction_id": dispute.TransactionID,
+		"reason":                  dispute.Reason,
+		"status":                  dispute.Status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal dispute details: %w", err)
+	}
+
+	description := fmt.Sprintf("Dispute %s: debit of %s", dispute.Reason, dispute.TransactionID)
+	if amount > 0 {
+		description = fmt.Sprintf("Dispute %s won: credit back of %s", dispute.Reason, dispute.TransactionID)
+	}
+
+	query := `
+		INSERT INTO public.transactions (
+			id, user_id, merchant_id, type, medium, reference, description, verified, status, test,
+			base_amount, fee_amount, vat_amount, merchant_net, total_amount, currency, details,
+			confirm_timestamp, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, true, $8, false, $9, 0, 0, $10, $9, $11, $12, $13, $13, $13)`
+
+	if _, err := dbTx.ExecContext(ctx, query,
+		uuid.New(), userID, dispute.MerchantID, string(txEntity.DISPUTE), string(txEntity.SOCIALPAY),
+		dispute.ID.String(), description, string(txEntity.SUCCESS), math.Abs(amount), amount,
+		dispute.DebitedCurrency, detailsJSON, at,
+	); err != nil {
+		return fmt.Errorf("failed to post dispute transaction: %w", err)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDispute(row rowScanner) (*entity.Dispute, error) {
+	var dispute entity.Dispute
+	var networkReasonCode, description, debitedCurrency, response, note sql.NullString
+	if err := row.Scan(
+		&dispute.ID, &dispute.TransactionID, &dispute.MerchantID, &dispute.Source, &dispute.Reason,
+		&networkReasonCode, &description, &dispute.Status, &dispute.Currency, &dispute.Amount,
+		&debitedCurrency, &dispute.DebitedAmount, &dispute.ResponseDueAt, &response, &dispute.RespondedBy,
+		&dispute.RespondedAt, &note, &dispute.OpenedBy, &dispute.UpdatedBy, &dispute.ResolvedAt,
+		&dispute.CreatedAt, &dispute.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	dispute.NetworkReasonCode = networkReasonCode.String
+	dispute.Description = description.String
+	dispute.DebitedCurrency = debitedCurrency.String
+	dispute.MerchantResponse = response.String
+	dispute.StatusNote = note.String
+
+	return &dispute, nil
+}
+
+func scanDisputes(rows *sql.Rows) ([]entity.Dispute, error) {
+	var disputes []entity.Dispute
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dispute: %w", err)
+		}
+		disputes = append(disputes, *dispute)
+	}
+
+	return disputes, rows.Err()
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,48 @@
+-- Dispute Schema
+-- This should match the migration exactly
+
+-- Chargebacks and customer complaints raised against a payment
+CREATE TABLE IF NOT EXISTS public.disputes (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES public.transactions(id) ON DELETE CASCADE,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    network_reason_code VARCHAR(20),
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    currency VARCHAR(3) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    -- What was debited from merchant.wallet on opening, credited back when won. Zero for
+    -- payments that were never credited to the wallet.
+    debited_currency VARCHAR(3),
+    debited_amount DECIMAL(20,2) NOT NULL DEFAULT 0,
+    response_due_at TIMESTAMPTZ NOT NULL,
+    merchant_response TEXT,
+    responded_by UUID,
+    responded_at TIMESTAMPTZ,
+    status_note TEXT,
+    opened_by UUID NOT NULL,
+    updated_by UUID,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A transaction has at most one dispute that is not closed. Lost and open disputes together claim
+-- at most the merchant's net of the transaction, which Create checks under a lock on it.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disputes_active_transaction ON public.disputes(transaction_id) WHERE status NOT IN ('WON', 'LOST');
+CREATE INDEX IF NOT EXISTS idx_disputes_merchant ON public.disputes(merchant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_disputes_response_due ON public.disputes(response_due_at) WHERE status IN ('OPEN', 'NEEDS_RESPONSE');
+
+CREATE TABLE IF NOT EXISTS public.dispute_evidence (
+    id UUID PRIMARY KEY,
+    dispute_id UUID NOT NULL REFERENCES public.disputes(id) ON DELETE CASCADE,
+    file_url TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    description TEXT,
+    uploaded_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispute_evidence_dispute ON public.dispute_evidence(dispute_id, created_at);
@@ -0,0 +1,535 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/dispute/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/dispute/core/repository"
+	fileService "github.com/socialpay/socialpay/src/pkg/file/core/service"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepository "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	merchantRepository "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/repository"
+	webhookDto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+	// overdueBatch bounds how many overdue disputes one sweep closes
+	overdueBatch = 100
+)
+
+// overdueNote is the status note of disputes lost because the merchant did not respond in time
+const overdueNote = "No response was received by the deadline"
+
+// notificationZone is the time zone dates in merchant notifications are written in
+var notificationZone = time.FixedZone("EAT", 3*60*60)
+
+// Notifier tells merchants by SMS how a dispute on one of their payments progresses
+type Notifier interface {
+	NotifyDispute(ctx context.Context, merchantID uuid.UUID, status string, amount float64, currency, reference, detail string) error
+}
+
+// EventPublisher queues events for delivery to the merchant callback URL
+type EventPublisher interface {
+	PublishMerchantEvent(ctx context.Context, event webhookDto.WebhookEventMerchant) error
+}
+
+// DisputeUseCase defines the interface for payment disputes and chargebacks
+type DisputeUseCase interface {
+	// Open opens a dispute on a successful payment and debits the disputed amount, at most the
+	// merchant's net less earlier lost and open disputes, from the merchant's wallet. The merchant is notified and has until the response deadline to respond.
+	Open(ctx context.Context, req *entity.OpenRequest, adminID uuid.UUID) (*entity.Detail, error)
+
+	// Get retrieves a dispute with its evidence. When merchantID is set, disputes of other
+	// merchants are not found.
+	Get(ctx context.Context, id uuid.UUID, merchantID *uuid.UUID) (*entity.Detail, error)
+
+	// List retrieves a page of disputes, latest first
+	List(ctx context.Context, filter entity.Filter) (*entity.ListResponse, error)
+
+	// UpdateStatus moves a dispute along its status flow. A dispute that is won is credited back
+	// to the merchant's wallet; one that is lost keeps the debit.
+	UpdateStatus(ctx context.Context, id uuid.UUID, req *entity.StatusRequest, adminID uuid.UUID) (*entity.Detail, error)
+
+	// Respond records the merchant's response to an open dispute and sends it for review
+	Respond(ctx context.Context, id, merchantID, userID uuid.UUID, req *entity.ResponseRequest) (*entity.Detail, error)
+
+	// UploadEvidence stores a file supporting a dispute. Merchants can upload evidence until they
+	// respond; admins until the dispute is closed. When merchantID is set, the upload is the merchant's.
+	UploadEvidence(ctx context.Context, id uuid.UUID, merchantID *uuid.UUID, userID uuid.UUID, upload *entity.EvidenceUpload) (*entity.Detail, error)
+
+	// CloseOverdue marks the disputes whose merchant did not respond by the deadline as lost
+	CloseOverdue(ctx context.Context) error
+}
+
+type disputeUseCase struct {
+	repo            repository.DisputeRepository
+	transactionRepo txRepository.TransactionRepository
+	merchantRepo    merchantRepository.Repository
+	files           fileService.FileService
+	notifier        Notifier
+	events          EventPublisher
+	log             logging.Logger
+}
+
+// NewDisputeUseCase creates the dispute usecase
+func NewDisputeUseCase(
+	repo repository.DisputeRepository,
+	transactionRepo txRepository.TransactionRepository,
+	merchantRepo merchantRepository.Repository,
+	files fileService.FileService,
+	notifier Notifier,
+	events EventPublisher,
+) DisputeUseCase {
+	return &disputeUseCase{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		merchantRepo:    merchantRepo,
+		files:           files,
+		notifier:        notifier,
+		events:          events,
+		log:             logging.NewStdLogger("dispute_usecase"),
+	}
+}
+
+func (uc *disputeUseCase) Open(ctx context.Context, req *entity.OpenRequest, adminID uuid.UUID) (*entity.Detail, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	txn, err := uc.transaction(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.Disputable(txn) {
+		return nil, entity.ErrNotDisputable
+	}
+
+	// Earlier disputes that were lost or are still open keep their share of the merchant's net
+	disputed, err := uc.repo.Disputed(ctx, txn.Id)
+	if err != nil {
+		return nil, err
+	}
+	left := entity.DisputableAmount(txn.MerchantNet, disputed)
+	if left <= 0 {
+		return nil, entity.ErrDisputedInFull
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = left
+	}
+	if amount > left {
+		return nil, fmt.Errorf("validation failed: amount must be between 0 and the %.2f left to dispute", left)
+	}
+
+	now := time.Now()
+	debitedCurrency, debitedAmount := entity.DebitOf(txn, amount)
+	dispute := &entity.Dispute{
+		ID:                uuid.New(),
+		TransactionID:     txn.Id,
+		MerchantID:        txn.MerchantId,
+		Source:            entity.SourceOf(txn.Medium),
+		Reason:            req.Reason,
+		NetworkReasonCode: req.NetworkReasonCode,
+		Description:       req.Description,
+		Status:            entity.StatusOpen,
+		Currency:          txn.WalletCurrency(),
+		Amount:            amount,
+		DebitedCurrency:   debitedCurrency,
+		DebitedAmount:     debitedAmount,
+		ResponseDueAt:     now.AddDate(0, 0, req.ResponseDays),
+		OpenedBy:          adminID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := uc.repo.Create(ctx, dispute); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Dispute opened", map[string]interface{}{
+		"dispute_id":       dispute.ID,
+		"transaction_id":   txn.Id,
+		"merchant_id":      txn.MerchantId,
+		"reason":           dispute.Reason,
+		"amount":           dispute.Amount,
+		"debited_currency": dispute.DebitedCurrency,
+		"debited_amount":   dispute.DebitedAmount,
+		"admin_id":         adminID,
+	})
+
+	uc.announce(ctx, dispute, "", txn)
+
+	return uc.detail(ctx, dispute)
+}
+
+func (uc *disputeUseCase) Get(ctx context.Context, id uuid.UUID, merchantID *uuid.UUID) (*entity.Detail, error) {
+	dispute, err := uc.load(ctx, id, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.detail(ctx, dispute)
+}
+
+func (uc *disputeUseCase) List(ctx context.Context, filter entity.Filter) (*entity.ListResponse, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("validation failed: unsupported status: %s", filter.Status)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	disputes, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if disputes == nil {
+		disputes = []entity.Dispute{}
+	}
+
+	return &entity.ListResponse{Disputes: disputes, Total: total}, nil
+}
+
+func (uc *disputeUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, req *entity.StatusRequest, adminID uuid.UUID) (*entity.Detail, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	dispute, err := uc.load(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.Status.Closed() {
+		return nil, entity.ErrDisputeClosed
+	}
+	if !dispute.Status.CanMoveTo(req.Status) {
+		return nil, fmt.Errorf("%w: %s to %s", entity.ErrInvalidTransition, dispute.Status, req.Status)
+	}
+
+	now := time.Now()
+	from := dispute.Status
+	dispute.Status = req.Status
+	if req.Status == entity.StatusNeedsResponse {
+		dispute.ResponseDueAt = now.AddDate(0, 0, req.ResponseDays)
+	}
+	if req.Note != "" {
+		dispute.StatusNote = req.Note
+	}
+	if req.Status.Closed() {
+		dispute.ResolvedAt = &now
+	}
+	dispute.UpdatedBy = &adminID
+	dispute.UpdatedAt = now
+	if err := uc.repo.Update(ctx, dispute, from); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Dispute status changed", map[string]interface{}{
+		"dispute_id":  dispute.ID,
+		"merchant_id": dispute.MerchantID,
+		"from":        from,
+		"to":          dispute.Status,
+		"admin_id":    adminID,
+	})
+
+	uc.announce(ctx, dispute, from, nil)
+
+	return uc.detail(ctx, dispute)
+}
+
+func (uc *disputeUseCase) Respond(ctx context.Context, id, merchantID, userID uuid.UUID, req *entity.ResponseRequest) (*entity.Detail, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	dispute, err := uc.load(ctx, id, &merchantID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := acceptsResponse(dispute, now); err != nil {
+		return nil, err
+	}
+
+	from := dispute.Status
+	dispute.Status = entity.StatusUnderReview
+	dispute.MerchantResponse = req.Response
+	dispute.RespondedBy = &userID
+	dispute.RespondedAt = &now
+	dispute.UpdatedBy = &userID
+	dispute.UpdatedAt = now
+	if err := uc.repo.Update(ctx, dispute, from); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Dispute response submitted", map[string]interface{}{
+		"dispute_id":  dispute.ID,
+		"merchant_id": merchantID,
+		"user_id":     userID,
+	})
+
+	uc.announce(ctx, dispute, from, nil)
+
+	return uc.detail(ctx, dispute)
+}
+
+func (uc *disputeUseCase) UploadEvidence(ctx context.Context, id uuid.UUID, merchantID *uuid.UUID, userID uuid.UUID, upload *entity.EvidenceUpload) (*entity.Detail, error) {
+	if err := upload.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	dispute, err := uc.load(ctx, id, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if merchantID != nil {
+		if err := acceptsResponse(dispute, time.Now()); err != nil {
+			return nil, err
+		}
+	} else if dispute.Status.Closed() {
+		return nil, entity.ErrDisputeClosed
+	}
+
+	url, err := uc.files.UploadFile(ctx, upload.File, upload.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	evidence := &entity.Evidence{
+		ID:          uuid.New(),
+		DisputeID:   dispute.ID,
+		FileURL:     *url,
+		FileName:    upload.Header.Filename,
+		Description: upload.Description,
+		UploadedBy:  userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := uc.repo.AddEvidence(ctx, evidence); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Dispute evidence uploaded", map[string]interface{}{
+		"dispute_id":  dispute.ID,
+		"evidence_id": evidence.ID,
+		"user_id":     userID,
+	})
+
+	return uc.detail(ctx, dispute)
+}
+
+func (uc *disputeUseCase) CloseOverdue(ctx context.Context) error {
+	now := time.Now()
+	disputes, err := uc.repo.ListOverdue(ctx, now, overdueBatch)
+	if err != nil {
+		return err
+	}
+
+	for i := range disputes {
+		dispute := &disputes[i]
+		from := dispute.Status
+		dispute.Status = entity.StatusLost
+		dispute.StatusNote = overdueNote
+		dispute.ResolvedAt = &now
+		dispute.UpdatedAt = now
+		if err := uc.repo.Update(ctx, dispute, from); err != nil {
+			if errors.Is(err, entity.ErrInvalidTransition) {
+				// Answered between listing and closing
+				continue
+			}
+			uc.log.Error("Failed to close overdue dispute", map[string]interface{}{
+				"dispute_id": dispute.ID,
+				"error":      err.Error(),
+			})
+			continue
+		}
+
+		uc.log.Warn("Dispute lost for want of a response", map[string]interface{}{
+			"dispute_id":      dispute.ID,
+			"merchant_id":     dispute.MerchantID,
+			"response_due_at": dispute.ResponseDueAt,
+		})
+		uc.announce(ctx, dispute, from, nil)
+	}
+
+	return nil
+}
+
+// load retrieves a dispute, hiding the disputes of other merchants when merchantID is set
+func (uc *disputeUseCase) load(ctx context.Context, id uuid.UUID, merchantID *uuid.UUID) (*entity.Dispute, error) {
+	dispute, err := uc.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dispute == nil || (merchantID != nil && dispute.MerchantID != *merchantID) {
+		return nil, entity.ErrDisputeNotFound
+	}
+	return dispute, nil
+}
+
+func (uc *disputeUseCase) transaction(ctx context.Context, id uuid.UUID) (*txEntity.Transaction, error) {
+	txn, err := uc.transactionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrTransactionNotFound
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return txn, nil
+}
+
+func (uc *disputeUseCase) detail(ctx context.Context, dispute *entity.Dispute) (*entity.Detail, error) {
+	evidence, err := uc.repo.ListEvidence(ctx, dispute.ID)
+	if err != nil {
+		return nil, err
+	}
+	if evidence == nil {
+		evidence = []entity.Evidence{}
+	}
+
+	return &entity.Detail{
+		Dispute:  *dispute,
+		Overdue:  dispute.Overdue(time.Now()),
+		Evidence: evidence,
+	}, nil
+}
+
+// acceptsResponse checks the merchant can still respond to a dispute
+func acceptsResponse(dispute *entity.Dispute, now time.Time) error {
+	if dispute.Status.Closed() {
+		return entity.ErrDisputeClosed
+	}
+	if !dispute.Status.AcceptsResponse() || dispute.Overdue(now) {
+		return entity.ErrResponseNotAccepted
+	}
+	return nil
+}
+
+// announce tells the merchant a dispute opened or moved from a status, by webhook and SMS.
+// Neither undoes the change when it fails. txn is loaded when not given.
+func (uc *disputeUseCase) announce(ctx context.Context, dispute *entity.Dispute, from entity.Status, txn *txEntity.Transaction) {
+	if txn == nil {
+		var err error
+		if txn, err = uc.transaction(ctx, dispute.TransactionID); err != nil {
+			uc.log.Error("Failed to load disputed transaction for notification", map[string]interface{}{
+				"dispute_id":     dispute.ID,
+				"transaction_id": dispute.TransactionID,
+				"error":          err.Error(),
+			})
+			return
+		}
+	}
+
+	uc.publish(ctx, dispute, from, txn)
+
+	if txn.Test {
+		return
+	}
+	if err := uc.notifier.NotifyDispute(ctx, dispute.MerchantID, string(dispute.Status), dispute.Amount,
+		dispute.Currency, txn.Reference, notificationDetail(dispute)); err != nil {
+		uc.log.Error("Failed to notify merchant of dispute", map[string]interface{}{
+			"dispute_id":  dispute.ID,
+			"merchant_id": dispute.MerchantID,
+			"status":      dispute.Status,
+			"error":       err.Error(),
+		})
+	}
+}
+
+func (uc *disputeUseCase) publish(ctx context.Context, dispute *entity.Dispute, from entity.Status, txn *txEntity.Transaction) {
+	if uc.events == nil {
+		return
+	}
+
+	event := webhookDto.WebhookEventMerchant{
+		Event:          webhookDto.EventDisputeStatusChanged,
+		ReferenceId:    txn.Reference,
+		SocialPayTxnID: txn.Id.String(),
+		Status:         string(dispute.Status),
+		PreviousStatus: string(from),
+		Amount:         fmt.Sprintf("%f", dispute.Amount),
+		CallbackURL:    txn.CallbackURL,
+		Message:        eventMessage(dispute),
+		Timestamp:      time.Now(),
+		MerchantID:     dispute.MerchantID.String(),
+		UserID:         txn.UserId.String(),
+		Test:           txn.Test,
+		DisputeID:      dispute.ID.String(),
+	}
+	if from == "" {
+		event.Event = webhookDto.EventDisputeOpened
+	}
+
+	if event.CallbackURL == "" {
+		settings, err := uc.merchantRepo.GetMerchantSettings(ctx, dispute.MerchantID)
+		if err != nil {
+			uc.log.Error("Failed to get merchant webhook settings", map[string]interface{}{
+				"merchant_id": dispute.MerchantID,
+				"error":       err.Error(),
+			})
+		} else if settings != nil && settings.EnableWebhooks && settings.WebhookURL != nil {
+			event.CallbackURL = *settings.WebhookURL
+		}
+	}
+
+	if err := uc.events.PublishMerchantEvent(ctx, event); err != nil {
+		uc.log.Error("Failed to publish dispute event", map[string]interface{}{
+			"dispute_id":  dispute.ID,
+			"merchant_id": dispute.MerchantID,
+			"error":       err.Error(),
+		})
+	}
+}
+
+// notificationDetail completes the SMS of a dispute's status: the response deadline while the
+// merchant can respond, the date it was reviewed or won, or why it was lost
+func notificationDetail(dispute *entity.Dispute) string {
+	switch dispute.Status {
+	case entity.StatusOpen, entity.StatusNeedsResponse:
+		return dispute.ResponseDueAt.In(notificationZone).Format("02 Jan 2006 3:04 PM")
+	case entity.StatusLost:
+		if dispute.StatusNote != "" {
+			return dispute.StatusNote
+		}
+		return "the issuer upheld the customer's claim"
+	default:
+		return dispute.UpdatedAt.In(notificationZone).Format("02 Jan 2006")
+	}
+}
+
+// eventMessage describes a dispute's status in its webhook event
+func eventMessage(dispute *entity.Dispute) string {
+	switch dispute.Status {
+	case entity.StatusOpen:
+		return fmt.Sprintf("Payment disputed (%s); respond by %s", dispute.Reason, dispute.ResponseDueAt.UTC().Format(time.RFC3339))
+	case entity.StatusNeedsResponse:
+		return "Dispute needs a response by " + dispute.ResponseDueAt.UTC().Format(time.RFC3339)
+	case entity.StatusUnderReview:
+		return "Dispute under review"
+	case entity.StatusWon:
+		if dispute.DebitedAmount > 0 {
+			return "Dispute won; the debited amount was credited back"
+		}
+		return "Dispute won"
+	default:
+		if dispute.StatusNote != "" {
+			return "Dispute lost: " + dispute.StatusNote
+		}
+		return "Dispute lost"
+	}
+}
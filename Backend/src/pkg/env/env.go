@@ -18,30 +18,8 @@ var requiredEnvVars = []string{
 	"DB_PORT",
 	"SSL_MODE",
 
-	// M-PESA
-	"SAFARICOM_USERNAME",
-	"SAFARICOM_PASSWORD",
-	"MPESA_BASE_URL",
-
-	// Telebirr
-	"TELEBIRR_SECURITY_CREDENTIAL",
-	"TELEBIRR_PASSWORD",
-	"TELEBIRR_BASE_URL",
-	"TELEBIRR_SHORT_CODE",
-	"TELEBIRR_IDENTITY_ID",
-
-	// CBE
-	"CBE_MERCHANT_ID",
-	"CBE_MERCHANT_KEY",
-	"CBE_TERMINAL_ID",
-	"CBE_CREDENTIAL_KEY",
-	"CBE_BASE_URL",
-
-	// Cybersource
-	"CYBERSOURCE_ACCESS_KEY",
-	"CYBERSOURCE_PROFILE_ID",
-	"CYBERSOURCE_SECRET_KEY",
-	"CYBERSOURCE_BASE_URL",
+	// Payment processors are validated by the payment registry, which knows
+	// which ones are enabled (see payment/registry.LoadConfig)
 
 	// SMS/KMI Cloud
 	"KMI_ACCESS_KEY",
@@ -0,0 +1,134 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/fx/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+type Handler struct {
+	fxUseCase     usecase.FXUseCase
+	log           logging.Logger
+	jwtMiddleware gin.HandlerFunc
+	rbac          *ginMiddleware.RBACV2
+}
+
+func NewHandler(fxUseCase usecase.FXUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		fxUseCase:     fxUseCase,
+		log:           logging.NewStdLogger("fx_handler"),
+		jwtMiddleware: jwtMiddleware,
+		rbac:          rbac,
+	}
+}
+
+// RegisterRouter sets up the admin exchange rate routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	admin := router.Group("/admin/fx", h.jwtMiddleware)
+	admin.POST("/rates",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_FX, auth_entity.OPERATION_ADMIN_CREATE),
+		h.SetRate)
+	admin.GET("/rates",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_FX, auth_entity.OPERATION_ADMIN_READ),
+		h.ListRates)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// SetRate godoc
+// @Summary      Publish an exchange rate
+// @Description  Publish the mid-market rate and spread of a currency pair. The rate applies from effective_from (now by default) until a later rate of the pair; earlier rates stay for settlements and reports of their period.
+// @Tags         Admin FX
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.SetRateRequest  true  "Rate"
+// @Success      201  {object}  entity.Rate
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /admin/fx/rates [post]
+func (h *Handler) SetRate(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.SetRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	rate, err := h.fxUseCase.SetRate(c.Request.Context(), &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rate)
+}
+
+// ListRates godoc
+// @Summary      List exchange rates
+// @Description  List the rate history of all or one currency pair, latest first
+// @Tags         Admin FX
+// @Produce      json
+// @Security     BearerAuth
+// @Param        base_currency   query  string  false  "Base currency"
+// @Param        quote_currency  query  string  false  "Quote currency"
+// @Param        limit           query  int     false  "Number of rates (max 500)"
+// @Success      200  {object}  entity.RateListResponse
+// @Router       /admin/fx/rates [get]
+func (h *Handler) ListRates(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	response, err := h.fxUseCase.ListRates(c.Request.Context(), entity.RateFilter{
+		BaseCurrency:  c.Query("base_currency"),
+		QuoteCurrency: c.Query("quote_currency"),
+		Limit:         limit,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrUnsupportedCurrency):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	case errors.Is(err, entity.ErrRateNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	default:
+		h.log.Error("FX request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
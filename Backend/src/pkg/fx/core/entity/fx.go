@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	walletEntity "github.com/socialpay/socialpay/src/pkg/wallet/core/entity"
+)
+
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrRateNotFound        = errors.New("no exchange rate for the currency pair")
+	ErrQuoteNotFound       = errors.New("fx quote not found")
+	ErrQuoteExpired        = errors.New("fx quote has expired")
+	ErrQuoteUsed           = errors.New("fx quote has already been used")
+	ErrQuoteMismatch       = errors.New("fx quote does not belong to this checkout")
+)
+
+// QuoteTTL is how long a checkout quote holds its rate
+const QuoteTTL = 15 * time.Minute
+
+// MaxSpreadPercent bounds the spread an admin can set on a rate
+const MaxSpreadPercent = 10
+
+// IsSupportedCurrency reports whether rates can be set and wallets held in the currency
+func IsSupportedCurrency(currency string) bool {
+	return walletEntity.Currency(currency).IsValid()
+}
+
+// NormalizeCurrency upper-cases a currency code
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// Rate is the mid-market price of one unit of the base currency in the quote currency,
+// effective from a point in time until a later rate of the pair replaces it
+type Rate struct {
+	ID            uuid.UUID `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	MidRate       float64   `json:"mid_rate"`
+	SpreadPercent float64   `json:"spread_percent"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedBy     uuid.UUID `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Conversion is an amount converted between currencies. Rate is the applied rate,
+// the mid rate less the spread, so the spread stays with SocialPay.
+type Conversion struct {
+	FromCurrency    string     `json:"from_currency"`
+	ToCurrency      string     `json:"to_currency"`
+	Amount          float64    `json:"amount"`
+	ConvertedAmount float64    `json:"converted_amount"`
+	MidRate         float64    `json:"mid_rate"`
+	Rate            float64    `json:"rate"`
+	SpreadPercent   float64    `json:"spread_percent"`
+	SpreadAmount    float64    `json:"spread_amount"`
+	RateID          *uuid.UUID `json:"rate_id,omitempty"`
+	QuoteID         *uuid.UUID `json:"quote_id,omitempty"`
+	ConvertedAt     time.Time  `json:"converted_at"`
+}
+
+// NewConversion converts an amount at a mid rate less the spread
+func NewConversion(from, to string, amount, midRate, spreadPercent float64, at time.Time) Conversion {
+	rate := midRate * (1 - spreadPercent/100)
+	converted := roundAmount(amount * rate)
+	return Conversion{
+		FromCurrency:    from,
+		ToCurrency:      to,
+		Amount:          amount,
+		ConvertedAmount: converted,
+		MidRate:         midRate,
+		Rate:            rate,
+		SpreadPercent:   spreadPercent,
+		SpreadAmount:    roundAmount(amount*midRate - converted),
+		ConvertedAt:     at,
+	}
+}
+
+// Quote locks the price of a hosted checkout in another currency. The payer is charged
+// ChargeAmount in ChargeCurrency; at settlement the charge converts back to the checkout
+// currency at the locked Rate, so the merchant receives the price they asked for.
+type Quote struct {
+	ID               uuid.UUID  `json:"id"`
+	HostedCheckoutID uuid.UUID  `json:"hosted_checkout_id"`
+	MerchantID       uuid.UUID  `json:"merchant_id"`
+	Currency         string     `json:"currency"`
+	Amount           float64    `json:"amount"`
+	ChargeCurrency   string     `json:"charge_currency"`
+	ChargeAmount     float64    `json:"charge_amount"`
+	MidRate          float64    `json:"mid_rate"`
+	Rate             float64    `json:"rate"`
+	SpreadPercent    float64    `json:"spread_percent"`
+	RateID           uuid.UUID  `json:"rate_id"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	TransactionID    *uuid.UUID `json:"transaction_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// Usable reports why a quote cannot price a payment, if it cannot
+func (q *Quote) Usable(now time.Time) error {
+	if q.TransactionID != nil {
+		return ErrQuoteUsed
+	}
+	if !now.Before(q.ExpiresAt) {
+		return ErrQuoteExpired
+	}
+	return nil
+}
+
+// Conversion is the settlement conversion of an amount in the charge currency at the locked rate
+func (q *Quote) Conversion(amount float64, at time.Time) Conversion {
+	// The locked mid rate and spread give back the locked rate
+	c := NewConversion(q.ChargeCurrency, q.Currency, amount, q.MidRate, q.SpreadPercent, at)
+	rateID, quoteID := q.RateID, q.ID
+	c.RateID = &rateID
+	c.QuoteID = &quoteID
+	return c
+}
+
+// Settlement records on a transaction how its net amounts reached wallets held in other
+// currencies. MerchantAmount and AdminAmount are what the wallets were credited or debited.
+type Settlement struct {
+	WalletCurrency string      `json:"wallet_currency"`
+	MerchantAmount float64     `json:"merchant_amount"`
+	Merchant       *Conversion `json:"merchant,omitempty"`
+	AdminCurrency  string      `json:"admin_currency"`
+	AdminAmount    float64     `json:"admin_amount"`
+	Admin          *Conversion `json:"admin,omitempty"`
+}
+
+// SetRateRequest publishes a rate of a currency pair
+type SetRateRequest struct {
+	BaseCurrency  string     `json:"base_currency" example:"USD"`
+	QuoteCurrency string     `json:"quote_currency" example:"ETB"`
+	MidRate       float64    `json:"mid_rate" example:"131.25"`
+	SpreadPercent float64    `json:"spread_percent" example:"1.5"`
+	EffectiveFrom *time.Time `json:"effective_from,omitempty"`
+}
+
+func (r *SetRateRequest) Validate() error {
+	r.BaseCurrency = NormalizeCurrency(r.BaseCurrency)
+	r.QuoteCurrency = NormalizeCurrency(r.QuoteCurrency)
+
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.BaseCurrency, validation.Required, validation.By(supportedCurrency)),
+		validation.Field(&r.QuoteCurrency, validation.Required, validation.By(supportedCurrency)),
+		validation.Field(&r.MidRate, validation.Required, validation.Min(0.000001)),
+		validation.Field(&r.SpreadPercent, validation.Min(0.0), validation.Max(float64(MaxSpreadPercent))),
+	); err != nil {
+		return err
+	}
+	if r.BaseCurrency == r.QuoteCurrency {
+		return errors.New("base and quote currency must differ")
+	}
+	return nil
+}
+
+// QuoteRequest asks for the price of a hosted checkout in another currency
+type QuoteRequest struct {
+	Currency string `json:"currency" example:"ETB"`
+}
+
+func (r *QuoteRequest) Validate() error {
+	r.Currency = NormalizeCurrency(r.Currency)
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Currency, validation.Required, validation.By(supportedCurrency)),
+	)
+}
+
+// RateFilter selects rate history
+type RateFilter struct {
+	BaseCurrency  string
+	QuoteCurrency string
+	Limit         int
+}
+
+type RateListResponse struct {
+	Rates []Rate `json:"rates"`
+}
+
+func supportedCurrency(value interface{}) error {
+	currency, _ := value.(string)
+	if !IsSupportedCurrency(currency) {
+		return ErrUnsupportedCurrency
+	}
+	return nil
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
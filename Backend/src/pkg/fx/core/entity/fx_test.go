@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestConversion(t *testing.T) {
+	at := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+
+	t.Run("spread is taken off the mid rate", func(t *testing.T) {
+		c := NewConversion("USD", "ETB", 100, 131.25, 1.5, at)
+		if c.ConvertedAmount != 12928.13 {
+			t.Fatalf("converted amount = %.2f, want 12928.13", c.ConvertedAmount)
+		}
+		if c.SpreadAmount != 196.87 {
+			t.Fatalf("spread amount = %.2f, want 196.87", c.SpreadAmount)
+		}
+	})
+
+	t.Run("quote settles its charge at the price", func(t *testing.T) {
+		// A 5,000 ETB checkout charged in USD, priced the way quotes are created
+		rate := 131.25 * (1 - 1.5/100)
+		quote := &Quote{
+			ID:             uuid.New(),
+			Currency:       "ETB",
+			Amount:         5000,
+			ChargeCurrency: "USD",
+			ChargeAmount:   math.Ceil(5000/rate*100-1e-9) / 100,
+			MidRate:        131.25,
+			Rate:           rate,
+			SpreadPercent:  1.5,
+			RateID:         uuid.New(),
+			ExpiresAt:      at.Add(QuoteTTL),
+		}
+
+		c := quote.Conversion(quote.ChargeAmount, at)
+		if c.ConvertedAmount < quote.Amount {
+			t.Fatalf("charge %.2f USD settles to %.2f ETB, short of %.2f", quote.ChargeAmount, c.ConvertedAmount, quote.Amount)
+		}
+		if c.QuoteID == nil || *c.QuoteID != quote.ID {
+			t.Fatalf("conversion does not reference its quote")
+		}
+	})
+
+	t.Run("quote prices one payment before it expires", func(t *testing.T) {
+		quote := &Quote{ExpiresAt: at.Add(QuoteTTL)}
+		if err := quote.Usable(at); err != nil {
+			t.Fatalf("fresh quote: %v", err)
+		}
+		if err := quote.Usable(at.Add(QuoteTTL)); err != ErrQuoteExpired {
+			t.Fatalf("expired quote: got %v, want %v", err, ErrQuoteExpired)
+		}
+		txID := uuid.New()
+		quote.TransactionID = &txID
+		if err := quote.Usable(at); err != ErrQuoteUsed {
+			t.Fatalf("used quote: got %v, want %v", err, ErrQuoteUsed)
+		}
+	})
+}
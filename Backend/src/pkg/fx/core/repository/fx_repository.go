@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+)
+
+// FXRepository defines the interface for exchange rates and checkout quotes
+type FXRepository interface {
+	// SaveRate stores a rate of a currency pair
+	SaveRate(ctx context.Context, rate *entity.Rate) error
+
+	// ListRates retrieves rate history, latest first
+	ListRates(ctx context.Context, filter entity.RateFilter) ([]entity.Rate, error)
+
+	// RateAt retrieves the rate of a pair, in either direction, in force at a point in time.
+	// Without one it falls back to the earliest later rate, and returns nil when the pair has none.
+	RateAt(ctx context.Context, from, to string, at time.Time) (*entity.Rate, error)
+
+	// SaveQuote stores a checkout quote
+	SaveQuote(ctx context.Context, quote *entity.Quote) error
+
+	// GetQuote retrieves a quote, nil when it does not exist
+	GetQuote(ctx context.Context, id uuid.UUID) (*entity.Quote, error)
+
+	// ConsumeQuote binds an unexpired, unused quote to the transaction it priced
+	ConsumeQuote(ctx context.Context, id uuid.UUID, transactionID uuid.UUID) error
+}
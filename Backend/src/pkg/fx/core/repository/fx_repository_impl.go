@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+)
+
+const rateColumns = `id, base_currency, quote_currency, mid_rate, spread_percent, effective_from, created_by, created_at`
+
+const quoteColumns = `id, hosted_checkout_id, merchant_id, currency, amount, charge_currency, charge_amount,
+	mid_rate, rate, spread_percent, rate_id, expires_at, transaction_id, created_at`
+
+type FXRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewFXRepository(db *sql.DB) FXRepository {
+	return &FXRepositoryImpl{db: db}
+}
+
+func (r *FXRepositoryImpl) SaveRate(ctx context.Context, rate *entity.Rate) error {
+	query := `
+		INSERT INTO public.fx_rates (` + rateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		rate.ID, rate.BaseCurrency, rate.QuoteCurrency, rate.MidRate, rate.SpreadPercent,
+		rate.EffectiveFrom, rate.CreatedBy, rate.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save fx rate: %w", err)
+	}
+	return nil
+}
+
+func (r *FXRepositoryImpl) ListRates(ctx context.Context, filter entity.RateFilter) ([]entity.Rate, error) {
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+
+	if filter.BaseCurrency != "" {
+		args = append(args, filter.BaseCurrency)
+		conditions = append(conditions, fmt.Sprintf("base_currency = $%d", len(args)))
+	}
+	if filter.QuoteCurrency != "" {
+		args = append(args, filter.QuoteCurrency)
+		conditions = append(conditions, fmt.Sprintf("quote_currency = $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM public.fx_rates
+		WHERE %s
+		ORDER BY effective_from DESC, created_at DESC
+		LIMIT $%d
+	`, rateColumns, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fx rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []entity.Rate
+	for rows.Next() {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, *rate)
+	}
+	return rates, rows.Err()
+}
+
+func (r *FXRepositoryImpl) RateAt(ctx context.Context, from, to string, at time.Time) (*entity.Rate, error) {
+	// Same ordering as public.fx_factor, so settlement and reports agree on the rate
+	query := `
+		SELECT ` + rateColumns + `
+		FROM public.fx_rates
+		WHERE (base_currency = $1 AND quote_currency = $2)
+		   OR (base_currency = $2 AND quote_currency = $1)
+		ORDER BY (effective_from <= $3) DESC, ABS(EXTRACT(EPOCH FROM ($3 - effective_from))), created_at DESC
+		LIMIT 1
+	`
+
+	rate, err := scanRate(r.db.QueryRowContext(ctx, query, from, to, at))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (r *FXRepositoryImpl) SaveQuote(ctx context.Context, quote *entity.Quote) error {
+	query := `
+		INSERT INTO public.fx_quotes (` + quoteColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		quote.ID, quote.HostedCheckoutID, quote.MerchantID, quote.Currency, quote.Amount,
+		quote.ChargeCurrency, quote.ChargeAmount, quote.MidRate, quote.Rate, quote.SpreadPercent,
+		quote.RateID, quote.ExpiresAt, quote.TransactionID, quote.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save fx quote: %w", err)
+	}
+	return nil
+}
+
+func (r *FXRepositoryImpl) GetQuote(ctx context.Context, id uuid.UUID) (*entity.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM public.fx_quotes WHERE id = $1`
+
+	var q entity.Quote
+	var transactionID uuid.NullUUID
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&q.ID, &q.HostedCheckoutID, &q.MerchantID, &q.Currency, &q.Amount,
+		&q.ChargeCurrency, &q.ChargeAmount, &q.MidRate, &q.Rate, &q.SpreadPercent,
+		&q.RateID, &q.ExpiresAt, &transactionID, &q.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fx quote: %w", err)
+	}
+	if transactionID.Valid {
+		q.TransactionID = &transactionID.UUID
+	}
+	return &q, nil
+}
+
+func (r *FXRepositoryImpl) ConsumeQuote(ctx context.Context, id uuid.UUID, transactionID uuid.UUID) error {
+	query := `
+		UPDATE public.fx_quotes
+		SET transaction_id = $2
+		WHERE id = $1 AND transaction_id IS NULL AND expires_at > NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to consume fx quote: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	// Explain why the quote could not be used
+	quote, err := r.GetQuote(ctx, id)
+	if err != nil {
+		return err
+	}
+	if quote == nil {
+		return entity.ErrQuoteNotFound
+	}
+	if quote.TransactionID != nil {
+		return entity.ErrQuoteUsed
+	}
+	return entity.ErrQuoteExpired
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRate(row rowScanner) (*entity.Rate, error) {
+	var rate entity.Rate
+	err := row.Scan(
+		&rate.ID, &rate.BaseCurrency, &rate.QuoteCurrency, &rate.MidRate, &rate.SpreadPercent,
+		&rate.EffectiveFrom, &rate.CreatedBy, &rate.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan fx rate: %w", err)
+	}
+	return &rate, nil
+}
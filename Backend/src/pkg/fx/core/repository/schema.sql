@@ -0,0 +1,57 @@
+-- FX Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.fx_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    base_currency VARCHAR(3) NOT NULL,
+    quote_currency VARCHAR(3) NOT NULL,
+    mid_rate NUMERIC(20,8) NOT NULL CHECK (mid_rate > 0),
+    spread_percent NUMERIC(6,3) NOT NULL DEFAULT 0 CHECK (spread_percent >= 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (base_currency <> quote_currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_rates_pair ON public.fx_rates(base_currency, quote_currency, effective_from DESC);
+
+CREATE TABLE IF NOT EXISTS public.fx_quotes (
+    id UUID PRIMARY KEY,
+    hosted_checkout_id UUID NOT NULL,
+    merchant_id UUID NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    charge_currency VARCHAR(3) NOT NULL,
+    charge_amount DECIMAL(20,2) NOT NULL,
+    mid_rate NUMERIC(20,8) NOT NULL,
+    rate NUMERIC(20,8) NOT NULL,
+    spread_percent NUMERIC(6,3) NOT NULL,
+    rate_id UUID NOT NULL REFERENCES public.fx_rates(id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    transaction_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_quotes_hosted_checkout ON public.fx_quotes(hosted_checkout_id);
+
+-- fx_factor is the mid-market factor converting an amount from one currency to another at a
+-- point in time: the latest rate of the pair effective then, in either direction, or the
+-- earliest later one for activity that predates every rate. Reports use it to restate
+-- amounts in a base currency; it is NULL when the pair has no rate at all.
+CREATE OR REPLACE FUNCTION public.fx_factor(from_currency TEXT, to_currency TEXT, at TIMESTAMPTZ)
+RETURNS NUMERIC
+LANGUAGE sql
+STABLE
+AS $$
+    SELECT CASE
+        WHEN COALESCE(from_currency, 'ETB') = to_currency THEN 1::NUMERIC
+        ELSE (
+            SELECT CASE WHEN r.base_currency = COALESCE(from_currency, 'ETB') THEN r.mid_rate ELSE 1 / r.mid_rate END
+            FROM public.fx_rates r
+            WHERE (r.base_currency = COALESCE(from_currency, 'ETB') AND r.quote_currency = to_currency)
+               OR (r.base_currency = to_currency AND r.quote_currency = COALESCE(from_currency, 'ETB'))
+            ORDER BY (r.effective_from <= at) DESC, ABS(EXTRACT(EPOCH FROM (at - r.effective_from))), r.created_at DESC
+            LIMIT 1
+        )
+    END
+$$;
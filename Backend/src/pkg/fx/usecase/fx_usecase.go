@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/fx/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+)
+
+const (
+	defaultRateListLimit = 50
+	maxRateListLimit     = 500
+)
+
+// FXUseCase defines the interface for exchange rates, conversions and checkout quotes
+type FXUseCase interface {
+	// SetRate publishes a rate of a currency pair, effective now unless a time is given
+	SetRate(ctx context.Context, req *entity.SetRateRequest, adminID uuid.UUID) (*entity.Rate, error)
+
+	// ListRates retrieves rate history, latest first
+	ListRates(ctx context.Context, filter entity.RateFilter) (*entity.RateListResponse, error)
+
+	// Convert converts an amount at the rate in force at a point in time, less the rate's spread
+	Convert(ctx context.Context, amount float64, from, to string, at time.Time) (*entity.Conversion, error)
+
+	// CreateQuote locks the price of a checkout amount in a charge currency until the quote expires
+	CreateQuote(ctx context.Context, hostedCheckoutID, merchantID uuid.UUID, currency string, amount float64, chargeCurrency string, notAfter time.Time) (*entity.Quote, error)
+
+	// GetQuote retrieves a quote
+	GetQuote(ctx context.Context, id uuid.UUID) (*entity.Quote, error)
+
+	// ConsumeQuote binds a quote to the transaction it priced; a quote prices one transaction
+	ConsumeQuote(ctx context.Context, id uuid.UUID, transactionID uuid.UUID) error
+}
+
+type fxUseCase struct {
+	repo repository.FXRepository
+	log  logging.Logger
+}
+
+// NewFXUseCase creates the FX usecase
+func NewFXUseCase(repo repository.FXRepository) FXUseCase {
+	return &fxUseCase{
+		repo: repo,
+		log:  logging.NewStdLogger("fx_usecase"),
+	}
+}
+
+func (uc *fxUseCase) SetRate(ctx context.Context, req *entity.SetRateRequest, adminID uuid.UUID) (*entity.Rate, error) {
+	now := time.Now()
+	rate := &entity.Rate{
+		ID:            uuid.New(),
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+		MidRate:       req.MidRate,
+		SpreadPercent: req.SpreadPercent,
+		EffectiveFrom: now,
+		CreatedBy:     adminID,
+		CreatedAt:     now,
+	}
+	if req.EffectiveFrom != nil {
+		rate.EffectiveFrom = *req.EffectiveFrom
+	}
+
+	if err := uc.repo.SaveRate(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("FX rate published", map[string]interface{}{
+		"rate_id":        rate.ID,
+		"pair":           rate.BaseCurrency + "/" + rate.QuoteCurrency,
+		"mid_rate":       rate.MidRate,
+		"spread_percent": rate.SpreadPercent,
+		"effective_from": rate.EffectiveFrom,
+		"admin_id":       adminID,
+	})
+
+	return rate, nil
+}
+
+func (uc *fxUseCase) ListRates(ctx context.Context, filter entity.RateFilter) (*entity.RateListResponse, error) {
+	filter.BaseCurrency = entity.NormalizeCurrency(filter.BaseCurrency)
+	filter.QuoteCurrency = entity.NormalizeCurrency(filter.QuoteCurrency)
+	if filter.Limit <= 0 {
+		filter.Limit = defaultRateListLimit
+	}
+	if filter.Limit > maxRateListLimit {
+		filter.Limit = maxRateListLimit
+	}
+
+	rates, err := uc.repo.ListRates(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if rates == nil {
+		rates = []entity.Rate{}
+	}
+
+	return &entity.RateListResponse{Rates: rates}, nil
+}
+
+func (uc *fxUseCase) Convert(ctx context.Context, amount float64, from, to string, at time.Time) (*entity.Conversion, error) {
+	from, to = entity.NormalizeCurrency(from), entity.NormalizeCurrency(to)
+	if from == to {
+		conversion := entity.NewConversion(from, to, amount, 1, 0, at)
+		return &conversion, nil
+	}
+
+	rate, midRate, err := uc.rateAt(ctx, from, to, at)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := entity.NewConversion(from, to, amount, midRate, rate.SpreadPercent, at)
+	conversion.RateID = &rate.ID
+	return &conversion, nil
+}
+
+func (uc *fxUseCase) CreateQuote(ctx context.Context, hostedCheckoutID, merchantID uuid.UUID, currency string, amount float64, chargeCurrency string, notAfter time.Time) (*entity.Quote, error) {
+	currency, chargeCurrency = entity.NormalizeCurrency(currency), entity.NormalizeCurrency(chargeCurrency)
+	if !entity.IsSupportedCurrency(currency) || !entity.IsSupportedCurrency(chargeCurrency) {
+		return nil, entity.ErrUnsupportedCurrency
+	}
+	if currency == chargeCurrency {
+		return nil, fmt.Errorf("checkout is already priced in %s", currency)
+	}
+
+	now := time.Now()
+	// The quote converts the charge back into the checkout currency at settlement
+	rate, midRate, err := uc.rateAt(ctx, chargeCurrency, currency, now)
+	if err != nil {
+		return nil, err
+	}
+	applied := midRate * (1 - rate.SpreadPercent/100)
+
+	expiresAt := now.Add(entity.QuoteTTL)
+	if !notAfter.IsZero() && notAfter.Before(expiresAt) {
+		expiresAt = notAfter
+	}
+
+	quote := &entity.Quote{
+		ID:               uuid.New(),
+		HostedCheckoutID: hostedCheckoutID,
+		MerchantID:       merchantID,
+		Currency:         currency,
+		Amount:           amount,
+		ChargeCurrency:   chargeCurrency,
+		// Rounded up so the converted charge never falls short of the price
+		ChargeAmount:  math.Ceil(amount/applied*100-1e-9) / 100,
+		MidRate:       midRate,
+		Rate:          applied,
+		SpreadPercent: rate.SpreadPercent,
+		RateID:        rate.ID,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     now,
+	}
+	if err := uc.repo.SaveQuote(ctx, quote); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("FX quote locked", map[string]interface{}{
+		"quote_id":           quote.ID,
+		"hosted_checkout_id": hostedCheckoutID,
+		"amount":             amount,
+		"currency":           currency,
+		"charge_amount":      quote.ChargeAmount,
+		"charge_currency":    chargeCurrency,
+		"rate":               applied,
+		"expires_at":         expiresAt,
+	})
+
+	return quote, nil
+}
+
+func (uc *fxUseCase) GetQuote(ctx context.Context, id uuid.UUID) (*entity.Quote, error) {
+	quote, err := uc.repo.GetQuote(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, entity.ErrQuoteNotFound
+	}
+	return quote, nil
+}
+
+func (uc *fxUseCase) ConsumeQuote(ctx context.Context, id uuid.UUID, transactionID uuid.UUID) error {
+	return uc.repo.ConsumeQuote(ctx, id, transactionID)
+}
+
+// rateAt returns the rate of a pair in force at a time and its mid rate in the from -> to direction
+func (uc *fxUseCase) rateAt(ctx context.Context, from, to string, at time.Time) (*entity.Rate, float64, error) {
+	rate, err := uc.repo.RateAt(ctx, from, to, at)
+	if err != nil {
+		return nil, 0, err
+	}
+	if rate == nil {
+		return nil, 0, fmt.Errorf("%w: %s/%s", entity.ErrRateNotFound, from, to)
+	}
+
+	if rate.BaseCurrency == from {
+		return rate, rate.MidRate, nil
+	}
+	return rate, 1 / rate.MidRate, nil
+}
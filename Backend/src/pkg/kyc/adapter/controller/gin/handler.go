@@ -0,0 +1,418 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/kyc/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/kyc/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+type Handler struct {
+	kycUseCase    usecase.KYCUseCase
+	log           logging.Logger
+	jwtMiddleware gin.HandlerFunc
+	rbac          *ginMiddleware.RBACV2
+}
+
+func NewHandler(kycUseCase usecase.KYCUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		kycUseCase:    kycUseCase,
+		log:           logging.NewStdLogger("kyc_handler"),
+		jwtMiddleware: jwtMiddleware,
+		rbac:          rbac,
+	}
+}
+
+// RegisterRouter sets up the merchant and admin KYC routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	kyc := router.Group("/kyc", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	kyc.GET("",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_READ),
+		h.GetStatus)
+	kyc.POST("/documents",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_UPDATE),
+		h.UploadDocument)
+	kyc.POST("/submit",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_UPDATE),
+		h.Submit)
+
+	admin := router.Group("/admin/kyc", h.jwtMiddleware)
+	admin.GET("/queue",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_ADMIN_READ),
+		h.ListQueue)
+	admin.GET("/merchants/:merchant_id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_ADMIN_READ),
+		h.GetCase)
+	admin.POST("/merchants/:merchant_id/tin-check",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.CheckTIN)
+	admin.POST("/merchants/:merchant_id/approve",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.Approve)
+	admin.POST("/merchants/:merchant_id/reject",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.Reject)
+	admin.POST("/documents/:id/review",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_KYC, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.ReviewDocument)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// GetStatus godoc
+// @Summary      Get KYC status
+// @Description  Get the merchant's KYC case and its document checklist. The required documents depend on the business type; betting companies also provide their lottery certificate.
+// @Tags         KYC
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      401  {object}  ErrorResponse
+// @Router       /kyc [get]
+func (h *Handler) GetStatus(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	detail, err := h.kycUseCase.GetStatus(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// UploadDocument godoc
+// @Summary      Upload KYC document
+// @Description  Add a document to the checklist, or replace a pending or rejected one of the same type. Documents cannot be changed while the case is in review, and approved documents cannot be replaced.
+// @Tags         KYC
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.DocumentUploadRequest  true  "Document"
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /kyc/documents [post]
+func (h *Handler) UploadDocument(c *gin.Context) {
+	merchantID, userID, ok := merchantAndUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.DocumentUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	detail, err := h.kycUseCase.UploadDocument(c.Request.Context(), merchantID, userID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// Submit godoc
+// @Summary      Submit KYC for review
+// @Description  Send the case to compliance once every required document is uploaded and none is rejected. The TIN is checked automatically; a TIN that fails the check is refused with 422. Compliance reviews submitted cases within 48 hours.
+// @Tags         KYC
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /kyc/submit [post]
+func (h *Handler) Submit(c *gin.Context) {
+	merchantID, userID, ok := merchantAndUser(c)
+	if !ok {
+		return
+	}
+
+	detail, err := h.kycUseCase.Submit(c.Request.Context(), merchantID, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// ListQueue godoc
+// @Summary      List KYC review queue
+// @Description  List KYC cases for compliance, the oldest due first. Defaults to cases in review.
+// @Tags         Admin KYC
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status   query  string  false  "OPEN, IN_REVIEW, CHANGES_REQUESTED, APPROVED or REJECTED"
+// @Param        overdue  query  bool    false  "Only cases past their review SLA"
+// @Param        limit    query  int     false  "Number of cases (max 200)"
+// @Param        offset   query  int     false  "Cases to skip"
+// @Success      200  {object}  entity.QueueResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/kyc/queue [get]
+func (h *Handler) ListQueue(c *gin.Context) {
+	filter := entity.QueueFilter{
+		Status:      entity.CaseStatus(c.Query("status")),
+		OverdueOnly: c.Query("overdue") == "true",
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filter.Offset, _ = strconv.Atoi(c.Query("offset"))
+
+	response, err := h.kycUseCase.ListQueue(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCase godoc
+// @Summary      Get KYC case
+// @Description  Get a merchant's KYC case with its document checklist and history
+// @Tags         Admin KYC
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/kyc/merchants/{merchant_id} [get]
+func (h *Handler) GetCase(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	detail, err := h.kycUseCase.GetCase(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// ReviewDocument godoc
+// @Summary      Review KYC document
+// @Description  Approve or reject a pending document of a submitted case. Rejecting a document sends the case back to the merchant with the reason.
+// @Tags         Admin KYC
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                        true  "Document ID"
+// @Param        request  body  entity.DocumentReviewRequest  true  "Review"
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/kyc/documents/{id}/review [post]
+func (h *Handler) ReviewDocument(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+	documentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid document ID")))
+		return
+	}
+
+	var req entity.DocumentReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	detail, err := h.kycUseCase.ReviewDocument(c.Request.Context(), documentID, adminID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// CheckTIN godoc
+// @Summary      Check KYC TIN again
+// @Description  Run the automatic TIN check of a case again, e.g. after the merchant corrected its TIN or the registry was unavailable
+// @Tags         Admin KYC
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/kyc/merchants/{merchant_id}/tin-check [post]
+func (h *Handler) CheckTIN(c *gin.Context) {
+	adminID, merchantID, ok := adminAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	detail, err := h.kycUseCase.CheckTIN(c.Request.Context(), merchantID, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// Approve godoc
+// @Summary      Approve KYC case
+// @Description  Approve a case in review whose required documents are all approved and whose TIN passed its check. The merchant becomes active at the given KYC tier (default 1).
+// @Tags         Admin KYC
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string                 true   "Merchant ID"
+// @Param        request      body  entity.ApproveRequest  false  "Approval"
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /admin/kyc/merchants/{merchant_id}/approve [post]
+func (h *Handler) Approve(c *gin.Context) {
+	adminID, merchantID, ok := adminAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	var req entity.ApproveRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+
+	detail, err := h.kycUseCase.Approve(c.Request.Context(), merchantID, adminID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// Reject godoc
+// @Summary      Reject KYC case
+// @Description  Turn a case down for good. The reason is sent to the merchant.
+// @Tags         Admin KYC
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string                true  "Merchant ID"
+// @Param        request      body  entity.RejectRequest  true  "Rejection"
+// @Success      200  {object}  entity.CaseDetail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/kyc/merchants/{merchant_id}/reject [post]
+func (h *Handler) Reject(c *gin.Context) {
+	adminID, merchantID, ok := adminAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	var req entity.RejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	detail, err := h.kycUseCase.Reject(c.Request.Context(), merchantID, adminID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// merchantAndUser reads the authenticated merchant and user, responding when either is missing
+func merchantAndUser(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return merchantID, userID, true
+}
+
+// adminAndMerchant reads the authenticated admin and the merchant of the path, responding when either is invalid
+func adminAndMerchant(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return adminID, merchantID, true
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrMerchantNotFound),
+		errors.Is(err, entity.ErrDocumentNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrCaseDecided),
+		errors.Is(err, entity.ErrCaseInReview),
+		errors.Is(err, entity.ErrCaseNotInReview),
+		errors.Is(err, entity.ErrDocumentReviewed),
+		errors.Is(err, entity.ErrDocumentApproved):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case errors.Is(err, entity.ErrDocumentsIncomplete),
+		errors.Is(err, entity.ErrTINNotVerified),
+		errors.Is(err, entity.ErrNotApprovable):
+		c.JSON(http.StatusUnprocessableEntity, newErrorResponse(err))
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("KYC request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
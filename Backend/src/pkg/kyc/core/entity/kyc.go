@@ -0,0 +1,498 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+var (
+	// ErrMerchantNotFound is returned when the merchant of a case does not exist
+	ErrMerchantNotFound = errors.New("merchant not found")
+	// ErrDocumentNotFound is returned when no merchant document has the given ID
+	ErrDocumentNotFound = errors.New("document not found")
+	// ErrCaseDecided is returned when a case that was approved or rejected is changed
+	ErrCaseDecided = errors.New("KYC case has already been decided")
+	// ErrCaseInReview is returned when a merchant changes documents while compliance reviews them
+	ErrCaseInReview = errors.New("KYC case is under review")
+	// ErrCaseNotInReview is returned when compliance acts on a case the merchant has not submitted
+	ErrCaseNotInReview = errors.New("KYC case has not been submitted for review")
+	// ErrDocumentsIncomplete is returned when a case is submitted without every required document
+	ErrDocumentsIncomplete = errors.New("required KYC documents are missing or rejected")
+	// ErrDocumentReviewed is returned when a document that was already approved or rejected is reviewed
+	ErrDocumentReviewed = errors.New("document has already been reviewed")
+	// ErrDocumentApproved is returned when a merchant replaces a document compliance approved
+	ErrDocumentApproved = errors.New("approved documents cannot be replaced")
+	// ErrTINNotVerified is returned when a case is submitted or approved while its TIN check failed
+	ErrTINNotVerified = errors.New("tax identification number could not be verified")
+	// ErrNotApprovable is returned when a case is approved before every required document is
+	ErrNotApprovable = errors.New("KYC case cannot be approved until every required document is approved")
+)
+
+const (
+	// ReviewSLA is how long compliance has to decide a submitted case
+	ReviewSLA = 48 * time.Hour
+	// MaxReasonLength bounds rejection reasons and decision notes
+	MaxReasonLength = 500
+	// DefaultApprovedTier is the KYC tier a merchant reaches on approval unless another is chosen
+	DefaultApprovedTier = 1
+)
+
+// CaseStatus is where a merchant is in KYC onboarding
+type CaseStatus string
+
+const (
+	// CaseOpen cases wait for the merchant to upload and submit documents
+	CaseOpen CaseStatus = "OPEN"
+	// CaseInReview cases wait for compliance
+	CaseInReview CaseStatus = "IN_REVIEW"
+	// CaseChangesRequested cases wait for the merchant to replace rejected documents and resubmit
+	CaseChangesRequested CaseStatus = "CHANGES_REQUESTED"
+	// CaseApproved cases activated their merchant
+	CaseApproved CaseStatus = "APPROVED"
+	// CaseRejected cases were turned down for good
+	CaseRejected CaseStatus = "REJECTED"
+)
+
+// Decided reports whether the case was approved or rejected
+func (s CaseStatus) Decided() bool {
+	return s == CaseApproved || s == CaseRejected
+}
+
+// DocumentType is the kind of a merchant document
+type DocumentType string
+
+const (
+	DocBusinessLicense         DocumentType = "business_license"
+	DocTINCertificate          DocumentType = "tin_certificate"
+	DocBankStatement           DocumentType = "bank_statement"
+	DocOwnerID                 DocumentType = "owner_id"
+	DocCommercialRegistration  DocumentType = "commercial_registration"
+	DocMemorandumOfAssociation DocumentType = "memorandum_of_association"
+	DocRegistrationCertificate DocumentType = "registration_certificate"
+	DocLotteryCertificate      DocumentType = "lottery_certificate"
+)
+
+var documentTypes = []interface{}{
+	DocBusinessLicense, DocTINCertificate, DocBankStatement, DocOwnerID, DocCommercialRegistration,
+	DocMemorandumOfAssociation, DocRegistrationCertificate, DocLotteryCertificate,
+}
+
+// DocumentStatus is the review state of a merchant document, as stored on merchants.documents
+type DocumentStatus string
+
+const (
+	DocumentPending  DocumentStatus = "pending"
+	DocumentApproved DocumentStatus = "approved"
+	DocumentRejected DocumentStatus = "rejected"
+	// DocumentMissing marks checklist items the merchant has not uploaded
+	DocumentMissing DocumentStatus = "missing"
+)
+
+// TINStatus is the outcome of the automatic TIN check
+type TINStatus string
+
+const (
+	TINNotChecked  TINStatus = "NOT_CHECKED"
+	TINPassed      TINStatus = "PASSED"
+	TINFailed      TINStatus = "FAILED"
+	TINUnavailable TINStatus = "UNAVAILABLE"
+)
+
+// Decision is a compliance reviewer's verdict on a document
+type Decision string
+
+const (
+	DecisionApprove Decision = "APPROVE"
+	DecisionReject  Decision = "REJECT"
+)
+
+// EventAction is a step recorded in the history of a case
+type EventAction string
+
+const (
+	EventDocumentUploaded    EventAction = "DOCUMENT_UPLOADED"
+	EventDocumentResubmitted EventAction = "DOCUMENT_RESUBMITTED"
+	EventSubmitted           EventAction = "SUBMITTED"
+	EventTINChecked          EventAction = "TIN_CHECKED"
+	EventDocumentApproved    EventAction = "DOCUMENT_APPROVED"
+	EventDocumentRejected    EventAction = "DOCUMENT_REJECTED"
+	EventSLABreached         EventAction = "SLA_BREACHED"
+	EventApproved            EventAction = "APPROVED"
+	EventRejected            EventAction = "REJECTED"
+)
+
+// requirementSet lists the documents a family of business types must provide
+type requirementSet struct {
+	keywords  []string
+	documents []DocumentType
+}
+
+// companyDocuments are asked of companies and of business types nobody recognizes
+var companyDocuments = []DocumentType{
+	DocBusinessLicense, DocTINCertificate, DocCommercialRegistration, DocMemorandumOfAssociation, DocBankStatement,
+}
+
+// requirementSets are matched in order against the words of a business type
+var requirementSets = []requirementSet{
+	{
+		keywords:  []string{"sole", "individual", "proprietor"},
+		documents: []DocumentType{DocBusinessLicense, DocTINCertificate, DocOwnerID, DocBankStatement},
+	},
+	{
+		keywords:  []string{"ngo", "non profit", "nonprofit", "charity", "charitable", "association", "society"},
+		documents: []DocumentType{DocRegistrationCertificate, DocTINCertificate, DocBankStatement},
+	},
+	{
+		keywords:  []string{"partnership"},
+		documents: []DocumentType{DocBusinessLicense, DocTINCertificate, DocCommercialRegistration, DocMemorandumOfAssociation, DocOwnerID, DocBankStatement},
+	},
+}
+
+var nonLetters = regexp.MustCompile(`[^a-z]+`)
+
+// RequiredDocuments returns the documents a merchant of the business type must provide. Business
+// types are free text, so they are matched by keyword; anything unrecognized is held to the
+// company set. Betting companies also provide their lottery certificate.
+func RequiredDocuments(businessType string, isBettingCompany bool) []DocumentType {
+	words := " " + strings.TrimSpace(nonLetters.ReplaceAllString(strings.ToLower(businessType), " ")) + " "
+
+	documents := companyDocuments
+	for _, set := range requirementSets {
+		if matchesAny(words, set.keywords) {
+			documents = set.documents
+			break
+		}
+	}
+
+	required := append([]DocumentType(nil), documents...)
+	if isBettingCompany {
+		required = append(required, DocLotteryCertificate)
+	}
+	return required
+}
+
+func matchesAny(words string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(words, " "+keyword+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// Case is the KYC onboarding of a merchant
+// @Description Merchant KYC case
+type Case struct {
+	MerchantID       uuid.UUID  `json:"merchant_id"`
+	Status           CaseStatus `json:"status" example:"IN_REVIEW"`
+	BusinessType     string     `json:"business_type" example:"Private Limited Company"`
+	IsBettingCompany bool       `json:"is_betting_company"`
+	TINStatus        TINStatus  `json:"tin_status" example:"PASSED"`
+	TINDetail        string     `json:"tin_detail,omitempty"`
+	TINCheckedAt     *time.Time `json:"tin_checked_at,omitempty"`
+	// Submissions counts how often the merchant sent the case for review
+	Submissions   int        `json:"submissions"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty"`
+	DueAt         *time.Time `json:"due_at,omitempty"`
+	SLABreachedAt *time.Time `json:"sla_breached_at,omitempty"`
+	DecidedBy     *uuid.UUID `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	DecisionNote  string     `json:"decision_note,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NewCase opens the case of a merchant
+func NewCase(merchantID uuid.UUID, now time.Time) *Case {
+	return &Case{
+		MerchantID: merchantID,
+		Status:     CaseOpen,
+		TINStatus:  TINNotChecked,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Overdue reports whether compliance missed the review SLA of the case at now
+func (c *Case) Overdue(now time.Time) bool {
+	return c.Status == CaseInReview && c.DueAt != nil && !now.Before(*c.DueAt)
+}
+
+// ApplyTINCheck records the outcome of a TIN check on the case
+func (c *Case) ApplyTINCheck(check *TINCheck) {
+	c.TINStatus = check.Status
+	c.TINDetail = check.Detail
+	checkedAt := check.CheckedAt
+	c.TINCheckedAt = &checkedAt
+}
+
+// TINCheck is the outcome of checking a merchant's taxpayer identification number
+type TINCheck struct {
+	Status    TINStatus
+	Detail    string
+	CheckedAt time.Time
+}
+
+// ChecklistItem is a document of a merchant's checklist and where its review stands
+// @Description KYC checklist item
+type ChecklistItem struct {
+	DocumentType    DocumentType   `json:"document_type" example:"business_license"`
+	Required        bool           `json:"required"`
+	Status          DocumentStatus `json:"status" example:"pending"`
+	DocumentID      *uuid.UUID     `json:"document_id,omitempty"`
+	DocumentNumber  *string        `json:"document_number,omitempty"`
+	FileURL         string         `json:"file_url,omitempty"`
+	RejectionReason *string        `json:"rejection_reason,omitempty"`
+	VerifiedAt      *time.Time     `json:"verified_at,omitempty"`
+	UpdatedAt       *time.Time     `json:"updated_at,omitempty"`
+}
+
+// BuildChecklist lists the required documents first, in order, followed by any other documents the
+// merchant uploaded. Only the latest upload of each type counts.
+func BuildChecklist(required []DocumentType, documents []merchantEntity.MerchantDocument) []ChecklistItem {
+	latest := LatestDocuments(documents)
+
+	items := make([]ChecklistItem, 0, len(required)+len(latest))
+	listed := make(map[DocumentType]bool, len(required))
+	for _, documentType := range required {
+		listed[documentType] = true
+		item := ChecklistItem{DocumentType: documentType, Required: true, Status: DocumentMissing}
+		if document, ok := latest[documentType]; ok {
+			item = checklistItem(document, true)
+		}
+		items = append(items, item)
+	}
+
+	var extra []ChecklistItem
+	for documentType, document := range latest {
+		if !listed[documentType] {
+			extra = append(extra, checklistItem(document, false))
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i].DocumentType < extra[j].DocumentType })
+
+	return append(items, extra...)
+}
+
+// LatestDocuments returns the most recently updated document of each type
+func LatestDocuments(documents []merchantEntity.MerchantDocument) map[DocumentType]merchantEntity.MerchantDocument {
+	latest := make(map[DocumentType]merchantEntity.MerchantDocument, len(documents))
+	for _, document := range documents {
+		documentType := DocumentType(strings.ToLower(strings.TrimSpace(document.DocumentType)))
+		if current, ok := latest[documentType]; ok && !document.UpdatedAt.After(current.UpdatedAt) {
+			continue
+		}
+		latest[documentType] = document
+	}
+	return latest
+}
+
+func checklistItem(document merchantEntity.MerchantDocument, required bool) ChecklistItem {
+	id := document.ID
+	updatedAt := document.UpdatedAt
+	return ChecklistItem{
+		DocumentType:    DocumentType(strings.ToLower(strings.TrimSpace(document.DocumentType))),
+		Required:        required,
+		Status:          DocumentStatus(strings.ToLower(document.Status)),
+		DocumentID:      &id,
+		DocumentNumber:  document.DocumentNumber,
+		FileURL:         document.FileURL,
+		RejectionReason: document.RejectionReason,
+		VerifiedAt:      document.VerifiedAt,
+		UpdatedAt:       &updatedAt,
+	}
+}
+
+// Outstanding returns the required items that keep a case from being submitted: missing or rejected
+func Outstanding(items []ChecklistItem) []DocumentType {
+	var outstanding []DocumentType
+	for _, item := range items {
+		if item.Required && (item.Status == DocumentMissing || item.Status == DocumentRejected) {
+			outstanding = append(outstanding, item.DocumentType)
+		}
+	}
+	return outstanding
+}
+
+// Unapproved returns the required items compliance has not approved
+func Unapproved(items []ChecklistItem) []DocumentType {
+	var unapproved []DocumentType
+	for _, item := range items {
+		if item.Required && item.Status != DocumentApproved {
+			unapproved = append(unapproved, item.DocumentType)
+		}
+	}
+	return unapproved
+}
+
+// Event is a step in the history of a case
+// @Description KYC case event
+type Event struct {
+	ID         uuid.UUID   `json:"id"`
+	MerchantID uuid.UUID   `json:"merchant_id"`
+	DocumentID *uuid.UUID  `json:"document_id,omitempty"`
+	Action     EventAction `json:"action" example:"DOCUMENT_REJECTED"`
+	ActorID    *uuid.UUID  `json:"actor_id,omitempty"`
+	Note       string      `json:"note,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// NewEvent records an action on the case of a merchant
+func NewEvent(merchantID uuid.UUID, documentID *uuid.UUID, action EventAction, actorID *uuid.UUID, note string, now time.Time) *Event {
+	return &Event{
+		ID:         uuid.New(),
+		MerchantID: merchantID,
+		DocumentID: documentID,
+		Action:     action,
+		ActorID:    actorID,
+		Note:       note,
+		CreatedAt:  now,
+	}
+}
+
+// CaseDetail is a case with its document checklist; compliance also sees its history
+// @Description Merchant KYC case with its checklist
+type CaseDetail struct {
+	Case
+	Overdue   bool            `json:"overdue"`
+	Checklist []ChecklistItem `json:"checklist"`
+	Events    []Event         `json:"events,omitempty"`
+}
+
+// DocumentUploadRequest adds a document to a merchant's checklist, or replaces one compliance
+// has not approved
+// @Description KYC document upload
+type DocumentUploadRequest struct {
+	DocumentType   DocumentType `json:"document_type" example:"business_license"`
+	DocumentNumber *string      `json:"document_number,omitempty" example:"AA/14/667/1234/2016"`
+	FileURL        string       `json:"file_url" example:"https://files.socialpay.et/merchants/license.pdf"`
+}
+
+// Normalize lower-cases the document type and trims the request
+func (r *DocumentUploadRequest) Normalize() {
+	r.DocumentType = DocumentType(strings.ToLower(strings.TrimSpace(string(r.DocumentType))))
+	r.FileURL = strings.TrimSpace(r.FileURL)
+	if r.DocumentNumber != nil {
+		number := strings.TrimSpace(*r.DocumentNumber)
+		r.DocumentNumber = &number
+		if number == "" {
+			r.DocumentNumber = nil
+		}
+	}
+}
+
+// Validate validates the request
+func (r *DocumentUploadRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.DocumentType, validation.Required, validation.In(documentTypes...)),
+		validation.Field(&r.DocumentNumber, validation.NilOrNotEmpty, validation.Length(1, 100)),
+		validation.Field(&r.FileURL, validation.Required, is.URL, validation.Length(1, 255)),
+	)
+}
+
+// DocumentReviewRequest approves or rejects a document
+// @Description KYC document review
+type DocumentReviewRequest struct {
+	Decision Decision `json:"decision" example:"REJECT"`
+	// Reason is shown to the merchant and is required when rejecting
+	Reason string `json:"reason,omitempty" example:"License has expired"`
+}
+
+// Normalize upper-cases the decision
+func (r *DocumentReviewRequest) Normalize() {
+	r.Decision = Decision(strings.ToUpper(strings.TrimSpace(string(r.Decision))))
+	r.Reason = strings.TrimSpace(r.Reason)
+}
+
+// Validate validates the request
+func (r *DocumentReviewRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Decision, validation.Required, validation.In(DecisionApprove, DecisionReject)),
+		validation.Field(&r.Reason,
+			validation.When(r.Decision == DecisionReject, validation.Required),
+			validation.Length(0, MaxReasonLength)),
+	)
+}
+
+// ApproveRequest approves a case, activating its merchant
+// @Description KYC case approval
+type ApproveRequest struct {
+	// KYCTier selects the merchant's transaction limits; defaults to 1
+	KYCTier *int   `json:"kyc_tier,omitempty" example:"1"`
+	Note    string `json:"note,omitempty"`
+}
+
+// Normalize trims the note
+func (r *ApproveRequest) Normalize() {
+	r.Note = strings.TrimSpace(r.Note)
+}
+
+// Validate validates the request; maxTier is the highest KYC tier limits know
+func (r *ApproveRequest) Validate(maxTier int) error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.KYCTier, validation.NilOrNotEmpty, validation.Min(1), validation.Max(maxTier)),
+		validation.Field(&r.Note, validation.Length(0, MaxReasonLength)),
+	)
+}
+
+// Tier returns the KYC tier the approval grants
+func (r *ApproveRequest) Tier() int {
+	if r.KYCTier == nil {
+		return DefaultApprovedTier
+	}
+	return *r.KYCTier
+}
+
+// RejectRequest turns a case down for good
+// @Description KYC case rejection
+type RejectRequest struct {
+	// Reason is shown to the merchant
+	Reason string `json:"reason" example:"Business is not eligible"`
+}
+
+// Normalize trims the reason
+func (r *RejectRequest) Normalize() {
+	r.Reason = strings.TrimSpace(r.Reason)
+}
+
+// Validate validates the request
+func (r *RejectRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Reason, validation.Required, validation.Length(1, MaxReasonLength)),
+	)
+}
+
+// QueueFilter selects cases of the review queue
+type QueueFilter struct {
+	// Status defaults to IN_REVIEW
+	Status      CaseStatus
+	OverdueOnly bool
+	Limit       int
+	Offset      int
+}
+
+// QueueItem is a case in the review queue
+// @Description KYC review queue item
+type QueueItem struct {
+	Case
+	LegalName string `json:"legal_name"`
+	// PendingDocuments counts documents waiting for a reviewer
+	PendingDocuments int  `json:"pending_documents"`
+	Overdue          bool `json:"overdue"`
+}
+
+// QueueResponse is a page of the review queue, oldest due first
+// @Description KYC review queue
+type QueueResponse struct {
+	Cases []QueueItem `json:"cases"`
+	Total int         `json:"total"`
+}
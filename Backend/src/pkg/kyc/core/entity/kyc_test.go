@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+func TestRequiredDocuments(t *testing.T) {
+	tests := []struct {
+		businessType string
+		betting      bool
+		want         []DocumentType
+	}{
+		{"Sole Proprietorship", false, []DocumentType{DocBusinessLicense, DocTINCertificate, DocOwnerID, DocBankStatement}},
+		{"sole-proprietor", true, []DocumentType{DocBusinessLicense, DocTINCertificate, DocOwnerID, DocBankStatement, DocLotteryCertificate}},
+		{"Private Limited Company (PLC)", false, companyDocuments},
+		{"General Partnership", false, []DocumentType{DocBusinessLicense, DocTINCertificate, DocCommercialRegistration, DocMemorandumOfAssociation, DocOwnerID, DocBankStatement}},
+		{"NGO", false, []DocumentType{DocRegistrationCertificate, DocTINCertificate, DocBankStatement}},
+		{"Nonsolemn Trading", false, companyDocuments},
+		{"", true, append(append([]DocumentType(nil), companyDocuments...), DocLotteryCertificate)},
+	}
+
+	for _, tt := range tests {
+		if got := RequiredDocuments(tt.businessType, tt.betting); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RequiredDocuments(%q, %v) = %v, want %v", tt.businessType, tt.betting, got, tt.want)
+		}
+	}
+
+	// Appending the lottery certificate must not grow the shared set
+	RequiredDocuments("PLC", true)
+	if len(companyDocuments) != 5 {
+		t.Fatalf("companyDocuments was modified: %v", companyDocuments)
+	}
+}
+
+func TestBuildChecklist(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	merchantID := uuid.New()
+	document := func(documentType, status string, updatedAt time.Time) merchantEntity.MerchantDocument {
+		return merchantEntity.MerchantDocument{
+			ID:           uuid.New(),
+			MerchantID:   merchantID,
+			DocumentType: documentType,
+			FileURL:      "https://files.example.com/" + documentType,
+			Status:       status,
+			UpdatedAt:    updatedAt,
+		}
+	}
+
+	required := []DocumentType{DocBusinessLicense, DocTINCertificate, DocBankStatement}
+	documents := []merchantEntity.MerchantDocument{
+		document("business_license", "rejected", now.Add(-time.Hour)),
+		document("Business_License", "pending", now),
+		document("tin_certificate", "approved", now),
+		document("lottery_certificate", "pending", now),
+	}
+
+	checklist := BuildChecklist(required, documents)
+	if len(checklist) != 4 {
+		t.Fatalf("checklist has %d items, want 4: %+v", len(checklist), checklist)
+	}
+	if checklist[0].Status != DocumentPending || checklist[0].DocumentID == nil || *checklist[0].DocumentID != documents[1].ID {
+		t.Fatalf("business license = %+v, want the latest upload", checklist[0])
+	}
+	if checklist[2].Status != DocumentMissing || checklist[2].DocumentID != nil {
+		t.Fatalf("bank statement = %+v, want missing", checklist[2])
+	}
+	if checklist[3].Required || checklist[3].DocumentType != DocLotteryCertificate {
+		t.Fatalf("extra item = %+v, want optional lottery certificate", checklist[3])
+	}
+
+	if got := Outstanding(checklist); !reflect.DeepEqual(got, []DocumentType{DocBankStatement}) {
+		t.Fatalf("Outstanding() = %v, want [bank_statement]", got)
+	}
+	if got := Unapproved(checklist); !reflect.DeepEqual(got, []DocumentType{DocBusinessLicense, DocBankStatement}) {
+		t.Fatalf("Unapproved() = %v, want [business_license bank_statement]", got)
+	}
+}
+
+func TestDocumentReviewRequestValidate(t *testing.T) {
+	req := &DocumentReviewRequest{Decision: "reject"}
+	req.Normalize()
+	if err := req.Validate(); err == nil {
+		t.Fatal("Validate() = nil, want a reason to be required when rejecting")
+	}
+
+	req = &DocumentReviewRequest{Decision: " approve "}
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/kyc/core/entity"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+// KYCRepository defines the interface for KYC case persistence. Cases review the documents in
+// merchants.documents; every change to a case or document is written together with its events.
+type KYCRepository interface {
+	// CreateCase opens a case unless the merchant already has one. It returns
+	// entity.ErrMerchantNotFound when the merchant does not exist.
+	CreateCase(ctx context.Context, kycCase *entity.Case) error
+	GetCase(ctx context.Context, merchantID uuid.UUID) (*entity.Case, error)
+	// UpdateCase stores a changed case
+	UpdateCase(ctx context.Context, kycCase *entity.Case, events ...*entity.Event) error
+	// ActivateMerchant stores an approved case and makes its merchant active
+	ActivateMerchant(ctx context.Context, kycCase *entity.Case, events ...*entity.Event) error
+
+	ListDocuments(ctx context.Context, merchantID uuid.UUID) ([]merchantEntity.MerchantDocument, error)
+	GetDocument(ctx context.Context, id uuid.UUID) (*merchantEntity.MerchantDocument, error)
+	// CreateDocument adds a document to the merchant of the case and stores the case
+	CreateDocument(ctx context.Context, document *merchantEntity.MerchantDocument, kycCase *entity.Case, events ...*entity.Event) error
+	// UpdateDocument stores a replaced or reviewed document and the case
+	UpdateDocument(ctx context.Context, document *merchantEntity.MerchantDocument, kycCase *entity.Case, events ...*entity.Event) error
+
+	// ListQueue retrieves the cases of the review queue, the oldest due first
+	ListQueue(ctx context.Context, filter entity.QueueFilter, now time.Time) ([]entity.QueueItem, int, error)
+	// ListOverdue retrieves cases in review whose due time passed before now and were not yet flagged
+	ListOverdue(ctx context.Context, now time.Time, limit int) ([]entity.Case, error)
+	ListEvents(ctx context.Context, merchantID uuid.UUID) ([]entity.Event, error)
+}
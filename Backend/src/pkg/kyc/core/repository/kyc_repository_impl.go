@@ -0,0 +1,448 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/socialpay/socialpay/src/pkg/kyc/core/entity"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+const caseColumns = `merchant_id, status, business_type, is_betting_company, tin_status, tin_detail, tin_checked_at, submissions, submitted_at, due_at, sla_breached_at, decided_by, decided_at, decision_note, created_at, updated_at`
+
+// caseColumnsPrefixed is caseColumns for a query aliasing kyc_cases as c
+const caseColumnsPrefixed = `c.merchant_id, c.status, c.business_type, c.is_betting_company, c.tin_status, c.tin_detail, c.tin_checked_at, c.submissions, c.submitted_at, c.due_at, c.sla_breached_at, c.decided_by, c.decided_at, c.decision_note, c.created_at, c.updated_at`
+
+const eventColumns = `id, merchant_id, document_id, action, actor_id, note, created_at`
+
+const documentColumns = `id, merchant_id, document_type, document_number, file_url, file_hash, verified_by, verified_at, status, rejection_reason, created_at, updated_at`
+
+type KYCRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewKYCRepository(db *sql.DB) KYCRepository {
+	return &KYCRepositoryImpl{db: db}
+}
+
+func (r *KYCRepositoryImpl) CreateCase(ctx context.Context, kycCase *entity.Case) error {
+	query := `
+		INSERT INTO public.kyc_cases (` + caseColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (merchant_id) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		kycCase.MerchantID, string(kycCase.Status), kycCase.BusinessType, kycCase.IsBettingCompany,
+		string(kycCase.TINStatus), nullString(kycCase.TINDetail), kycCase.TINCheckedAt, kycCase.Submissions,
+		kycCase.SubmittedAt, kycCase.DueAt, kycCase.SLABreachedAt, kycCase.DecidedBy, kycCase.DecidedAt,
+		nullString(kycCase.DecisionNote), kycCase.CreatedAt, kycCase.UpdatedAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return entity.ErrMerchantNotFound
+		}
+		return fmt.Errorf("failed to create KYC case: %w", err)
+	}
+	return nil
+}
+
+func (r *KYCRepositoryImpl) GetCase(ctx context.Context, merchantID uuid.UUID) (*entity.Case, error) {
+	query := `SELECT ` + caseColumns + ` FROM public.kyc_cases WHERE merchant_id = $1`
+
+	kycCase, err := scanCase(r.db.QueryRowContext(ctx, query, merchantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get KYC case: %w", err)
+	}
+	return kycCase, nil
+}
+
+func (r *KYCRepositoryImpl) UpdateCase(ctx context.Context, kycCase *entity.Case, events ...*entity.Event) error {
+	return r.inTx(ctx, func(dbTx *sql.Tx) error {
+		if err := updateCase(ctx, dbTx, kycCase); err != nil {
+			return err
+		}
+		return insertEvents(ctx, dbTx, events)
+	})
+}
+
+func (r *KYCRepositoryImpl) ActivateMerchant(ctx context.Context, kycCase *entity.Case, events ...*entity.Event) error {
+	return r.inTx(ctx, func(dbTx *sql.Tx) error {
+		if err := updateCase(ctx, dbTx, kycCase); err != nil {
+			return err
+		}
+
+		query := `UPDATE merchants.merchants SET status = $2, updated_at = $3 WHERE id = $1`
+		result, err := dbTx.ExecContext(ctx, query, kycCase.MerchantID, string(merchantEntity.StatusActive), kycCase.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to activate merchant: %w", err)
+		}
+		if err := expectRow(result, entity.ErrMerchantNotFound); err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, dbTx, events)
+	})
+}
+
+func (r *KYCRepositoryImpl) ListDocuments(ctx context.Context, merchantID uuid.UUID) ([]merchantEntity.MerchantDocument, error) {
+	query := `SELECT ` + documentColumns + ` FROM merchants.documents WHERE merchant_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merchant documents: %w", err)
+	}
+	defer rows.Close()
+
+	var documents []merchantEntity.MerchantDocument
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan merchant document: %w", err)
+		}
+		documents = append(documents, *document)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list merchant documents: %w", err)
+	}
+	return documents, nil
+}
+
+func (r *KYCRepositoryImpl) GetDocument(ctx context.Context, id uuid.UUID) (*merchantEntity.MerchantDocument, error) {
+	query := `SELECT ` + documentColumns + ` FROM merchants.documents WHERE id = $1`
+
+	document, err := scanDocument(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get merchant document: %w", err)
+	}
+	return document, nil
+}
+
+func (r *KYCRepositoryImpl) CreateDocument(ctx context.Context, document *merchantEntity.MerchantDocument, kycCase *entity.Case, events ...*entity.Event) error {
+	return r.inTx(ctx, func(dbTx *sql.Tx) error {
+		query := `
+			INSERT INTO merchants.documents (` + documentColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+		if _, err := dbTx.ExecContext(ctx, query,
+			document.ID, document.MerchantID, document.DocumentType, document.DocumentNumber, document.FileURL,
+			document.FileHash, document.VerifiedBy, document.VerifiedAt, document.Status, document.RejectionReason,
+			document.CreatedAt, document.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to create merchant document: %w", err)
+		}
+
+		if err := updateCase(ctx, dbTx, kycCase); err != nil {
+			return err
+		}
+		return insertEvents(ctx, dbTx, events)
+	})
+}
+
+func (r *KYCRepositoryImpl) UpdateDocument(ctx context.Context, document *merchantEntity.MerchantDocument, kycCase *entity.Case, events ...*entity.Event) error {
+	return r.inTx(ctx, func(dbTx *sql.Tx) error {
+		query := `
+			UPDATE merchants.documents
+			SET document_number = $2, file_url = $3, file_hash = $4, verified_by = $5, verified_at = $6,
+				status = $7, rejection_reason = $8, updated_at = $9
+			WHERE id = $1`
+
+		result, err := dbTx.ExecContext(ctx, query,
+			document.ID, document.DocumentNumber, document.FileURL, document.FileHash, document.VerifiedBy,
+			document.VerifiedAt, document.Status, document.RejectionReason, document.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update merchant document: %w", err)
+		}
+		if err := expectRow(result, entity.ErrDocumentNotFound); err != nil {
+			return err
+		}
+
+		if err := updateCase(ctx, dbTx, kycCase); err != nil {
+			return err
+		}
+		return insertEvents(ctx, dbTx, events)
+	})
+}
+
+func (r *KYCRepositoryImpl) ListQueue(ctx context.Context, filter entity.QueueFilter, now time.Time) ([]entity.QueueItem, int, error) {
+	where := `WHERE c.status = $1 AND ($2 = FALSE OR c.due_at <= $3)`
+	args := []interface{}{string(filter.Status), filter.OverdueOnly, now}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM public.kyc_cases c ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count KYC cases: %w", err)
+	}
+
+	query := `
+		SELECT ` + caseColumnsPrefixed + `, m.legal_name,
+			(SELECT COUNT(*) FROM merchants.documents d WHERE d.merchant_id = c.merchant_id AND d.status = 'pending')
+		FROM public.kyc_cases c
+		JOIN merchants.merchants m ON m.id = c.merchant_id
+		` + where + `
+		ORDER BY c.due_at ASC NULLS LAST, c.updated_at ASC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list KYC cases: %w", err)
+	}
+	defer rows.Close()
+
+	var items []entity.QueueItem
+	for rows.Next() {
+		var item entity.QueueItem
+		kycCase, err := scanCase(rows, &item.LegalName, &item.PendingDocuments)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan KYC case: %w", err)
+		}
+		item.Case = *kycCase
+		item.Overdue = kycCase.Overdue(now)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list KYC cases: %w", err)
+	}
+	return items, total, nil
+}
+
+func (r *KYCRepositoryImpl) ListOverdue(ctx context.Context, now time.Time, limit int) ([]entity.Case, error) {
+	query := `
+		SELECT ` + caseColumns + `
+		FROM public.kyc_cases
+		WHERE status = $1 AND due_at <= $2 AND sla_breached_at IS NULL
+		ORDER BY due_at
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, string(entity.CaseInReview), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue KYC cases: %w", err)
+	}
+	defer rows.Close()
+
+	var cases []entity.Case
+	for rows.Next() {
+		kycCase, err := scanCase(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan KYC case: %w", err)
+		}
+		cases = append(cases, *kycCase)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list overdue KYC cases: %w", err)
+	}
+	return cases, nil
+}
+
+func (r *KYCRepositoryImpl) ListEvents(ctx context.Context, merchantID uuid.UUID) ([]entity.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM public.kyc_events WHERE merchant_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KYC events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []entity.Event
+	for rows.Next() {
+		var event entity.Event
+		var action string
+		var documentID, actorID uuid.NullUUID
+		var note sql.NullString
+		if err := rows.Scan(&event.ID, &event.MerchantID, &documentID, &action, &actorID, &note, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan KYC event: %w", err)
+		}
+		event.Action = entity.EventAction(action)
+		event.DocumentID = nullUUID(documentID)
+		event.ActorID = nullUUID(actorID)
+		event.Note = note.String
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list KYC events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *KYCRepositoryImpl) inTx(ctx context.Context, fn func(dbTx *sql.Tx) error) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	if err := fn(dbTx); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit KYC change: %w", err)
+	}
+	return nil
+}
+
+func updateCase(ctx context.Context, dbTx *sql.Tx, kycCase *entity.Case) error {
+	query := `
+		UPDATE public.kyc_cases
+		SET status = $2, business_type = $3, is_betting_company = $4, tin_status = $5, tin_detail = $6,
+			tin_checked_at = $7, submissions = $8, submitted_at = $9, due_at = $10, sla_breached_at = $11,
+			decided_by = $12, decided_at = $13, decision_note = $14, updated_at = $15
+		WHERE merchant_id = $1`
+
+	result, err := dbTx.ExecContext(ctx, query,
+		kycCase.MerchantID, string(kycCase.Status), kycCase.BusinessType, kycCase.IsBettingCompany,
+		string(kycCase.TINStatus), nullString(kycCase.TINDetail), kycCase.TINCheckedAt, kycCase.Submissions,
+		kycCase.SubmittedAt, kycCase.DueAt, kycCase.SLABreachedAt, kycCase.DecidedBy, kycCase.DecidedAt,
+		nullString(kycCase.DecisionNote), kycCase.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update KYC case: %w", err)
+	}
+	return expectRow(result, entity.ErrMerchantNotFound)
+}
+
+func insertEvents(ctx context.Context, dbTx *sql.Tx, events []*entity.Event) error {
+	query := `
+		INSERT INTO public.kyc_events (` + eventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	for _, event := range events {
+		if _, err := dbTx.ExecContext(ctx, query,
+			event.ID, event.MerchantID, event.DocumentID, string(event.Action), event.ActorID,
+			nullString(event.Note), event.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to record KYC event: %w", err)
+		}
+	}
+	return nil
+}
+
+// expectRow returns notFound when an update matched no row
+func expectRow(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCase scans the case columns followed by any extra columns of the row
+func scanCase(row rowScanner, extra ...interface{}) (*entity.Case, error) {
+	var kycCase entity.Case
+	var status, tinStatus string
+	var tinDetail, decisionNote sql.NullString
+	var tinCheckedAt, submittedAt, dueAt, slaBreachedAt, decidedAt sql.NullTime
+	var decidedBy uuid.NullUUID
+
+	dest := []interface{}{
+		&kycCase.MerchantID,
+		&status,
+		&kycCase.BusinessType,
+		&kycCase.IsBettingCompany,
+		&tinStatus,
+		&tinDetail,
+		&tinCheckedAt,
+		&kycCase.Submissions,
+		&submittedAt,
+		&dueAt,
+		&slaBreachedAt,
+		&decidedBy,
+		&decidedAt,
+		&decisionNote,
+		&kycCase.CreatedAt,
+		&kycCase.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	kycCase.Status = entity.CaseStatus(status)
+	kycCase.TINStatus = entity.TINStatus(tinStatus)
+	kycCase.TINDetail = tinDetail.String
+	kycCase.TINCheckedAt = nullTime(tinCheckedAt)
+	kycCase.SubmittedAt = nullTime(submittedAt)
+	kycCase.DueAt = nullTime(dueAt)
+	kycCase.SLABreachedAt = nullTime(slaBreachedAt)
+	kycCase.DecidedBy = nullUUID(decidedBy)
+	kycCase.DecidedAt = nullTime(decidedAt)
+	kycCase.DecisionNote = decisionNote.String
+
+	return &kycCase, nil
+}
+
+func scanDocument(row rowScanner) (*merchantEntity.MerchantDocument, error) {
+	var document merchantEntity.MerchantDocument
+	var documentNumber, fileHash, rejectionReason sql.NullString
+	var verifiedBy uuid.NullUUID
+	var verifiedAt sql.NullTime
+
+	if err := row.Scan(
+		&document.ID,
+		&document.MerchantID,
+		&document.DocumentType,
+		&documentNumber,
+		&document.FileURL,
+		&fileHash,
+		&verifiedBy,
+		&verifiedAt,
+		&document.Status,
+		&rejectionReason,
+		&document.CreatedAt,
+		&document.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	document.DocumentNumber = nullStringPtr(documentNumber)
+	document.FileHash = nullStringPtr(fileHash)
+	document.VerifiedBy = nullUUID(verifiedBy)
+	document.VerifiedAt = nullTime(verifiedAt)
+	document.RejectionReason = nullStringPtr(rejectionReason)
+
+	return &document, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullStringPtr(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+func nullUUID(value uuid.NullUUID) *uuid.UUID {
+	if !value.Valid {
+		return nil
+	}
+	return &value.UUID
+}
+
+func nullTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
@@ -0,0 +1,38 @@
+-- KYC Schema
+-- This should match the migration exactly
+
+-- Documents themselves live in merchants.documents; a case tracks the onboarding around them
+CREATE TABLE IF NOT EXISTS public.kyc_cases (
+    merchant_id UUID PRIMARY KEY REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    -- Business type and betting flag the checklist was last built from
+    business_type VARCHAR(100) NOT NULL DEFAULT '',
+    is_betting_company BOOLEAN NOT NULL DEFAULT FALSE,
+    tin_status VARCHAR(20) NOT NULL DEFAULT 'NOT_CHECKED',
+    tin_detail TEXT,
+    tin_checked_at TIMESTAMP WITH TIME ZONE,
+    submissions INTEGER NOT NULL DEFAULT 0,
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    -- Review SLA; cleared while the case waits on the merchant
+    due_at TIMESTAMP WITH TIME ZONE,
+    sla_breached_at TIMESTAMP WITH TIME ZONE,
+    decided_by UUID,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_cases_queue ON public.kyc_cases(status, due_at);
+
+CREATE TABLE IF NOT EXISTS public.kyc_events (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES public.kyc_cases(merchant_id) ON DELETE CASCADE,
+    document_id UUID,
+    action VARCHAR(30) NOT NULL,
+    actor_id UUID,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_events_merchant ON public.kyc_events(merchant_id, created_at);
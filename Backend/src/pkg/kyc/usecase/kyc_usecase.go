@@ -0,0 +1,600 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/kyc/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/kyc/core/repository"
+	limitsEntity "github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+	limitsUsecase "github.com/socialpay/socialpay/src/pkg/limits/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+	merchantRepository "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/repository"
+)
+
+const (
+	defaultQueueLimit = 50
+	maxQueueLimit     = 200
+	// slaSweepBatch bounds how many overdue cases one sweep flags
+	slaSweepBatch = 100
+)
+
+// Notification steps besides the case events of the same name
+const stepTINFailed = "TIN_FAILED"
+
+// notificationZone is the time zone dates in merchant notifications are written in
+var notificationZone = time.FixedZone("EAT", 3*60*60)
+
+// Notifier tells merchants how their KYC case progresses. Step is SUBMITTED, DOCUMENT_REJECTED,
+// TIN_FAILED, APPROVED or REJECTED; detail completes the message.
+type Notifier interface {
+	NotifyKYC(ctx context.Context, merchantID uuid.UUID, step, detail string) error
+}
+
+// StatusPublisher tells merchants their status changed. The merchant usecase implements it.
+type StatusPublisher interface {
+	PublishStatusChanged(ctx context.Context, merchant *merchantEntity.Merchant, to merchantEntity.MerchantStatus, reason string)
+}
+
+// KYCUseCase defines the interface for merchant KYC onboarding
+type KYCUseCase interface {
+	// GetStatus retrieves the case of a merchant with its document checklist, opening the case on first use
+	GetStatus(ctx context.Context, merchantID uuid.UUID) (*entity.CaseDetail, error)
+
+	// UploadDocument adds a document to the merchant's checklist. A pending or rejected document of
+	// the same type is replaced and goes back to pending; approved documents cannot be replaced.
+	UploadDocument(ctx context.Context, merchantID, userID uuid.UUID, req *entity.DocumentUploadRequest) (*entity.CaseDetail, error)
+
+	// Submit sends the case for review once every required document is uploaded and none is
+	// rejected. The TIN is checked first; a case whose TIN fails the check is not submitted.
+	Submit(ctx context.Context, merchantID, userID uuid.UUID) (*entity.CaseDetail, error)
+
+	// ListQueue retrieves the cases waiting for compliance, the oldest due first
+	ListQueue(ctx context.Context, filter entity.QueueFilter) (*entity.QueueResponse, error)
+
+	// GetCase retrieves the case of a merchant with its checklist and history, for compliance
+	GetCase(ctx context.Context, merchantID uuid.UUID) (*entity.CaseDetail, error)
+
+	// ReviewDocument approves or rejects a pending document of a submitted case. Rejecting one
+	// sends the case back to the merchant and pauses its review SLA.
+	ReviewDocument(ctx context.Context, documentID, adminID uuid.UUID, req *entity.DocumentReviewRequest) (*entity.CaseDetail, error)
+
+	// CheckTIN runs the TIN check of a case again
+	CheckTIN(ctx context.Context, merchantID, adminID uuid.UUID) (*entity.CaseDetail, error)
+
+	// Approve approves a case in review whose required documents are all approved and whose TIN
+	// passed its check. The merchant becomes active at the chosen KYC tier.
+	Approve(ctx context.Context, merchantID, adminID uuid.UUID, req *entity.ApproveRequest) (*entity.CaseDetail, error)
+
+	// Reject turns a case down for good
+	Reject(ctx context.Context, merchantID, adminID uuid.UUID, req *entity.RejectRequest) (*entity.CaseDetail, error)
+
+	// FlagOverdue records the cases compliance did not decide within the review SLA
+	FlagOverdue(ctx context.Context) error
+}
+
+type kycUseCase struct {
+	repo         repository.KYCRepository
+	merchantRepo merchantRepository.Repository
+	limits       limitsUsecase.LimitsUseCase
+	tinChecker   TINChecker
+	notifier     Notifier
+	statuses     StatusPublisher
+	log          logging.Logger
+}
+
+// NewKYCUseCase creates the KYC usecase
+func NewKYCUseCase(
+	repo repository.KYCRepository,
+	merchantRepo merchantRepository.Repository,
+	limits limitsUsecase.LimitsUseCase,
+	tinChecker TINChecker,
+	notifier Notifier,
+	statuses StatusPublisher,
+) KYCUseCase {
+	return &kycUseCase{
+		repo:         repo,
+		merchantRepo: merchantRepo,
+		limits:       limits,
+		tinChecker:   tinChecker,
+		notifier:     notifier,
+		statuses:     statuses,
+		log:          logging.NewStdLogger("kyc_usecase"),
+	}
+}
+
+func (uc *kycUseCase) GetStatus(ctx context.Context, merchantID uuid.UUID) (*entity.CaseDetail, error) {
+	kycCase, merchant, err := uc.loadCase(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.detail(ctx, kycCase, merchant, false)
+}
+
+func (uc *kycUseCase) UploadDocument(ctx context.Context, merchantID, userID uuid.UUID, req *entity.DocumentUploadRequest) (*entity.CaseDetail, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	kycCase, merchant, err := uc.loadCase(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if kycCase.Status.Decided() {
+		return nil, entity.ErrCaseDecided
+	}
+	if kycCase.Status == entity.CaseInReview {
+		return nil, entity.ErrCaseInReview
+	}
+
+	documents, err := uc.repo.ListDocuments(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	kycCase.UpdatedAt = now
+	if document, ok := entity.LatestDocuments(documents)[req.DocumentType]; ok {
+		if entity.DocumentStatus(document.Status) == entity.DocumentApproved {
+			return nil, entity.ErrDocumentApproved
+		}
+
+		action := entity.EventDocumentUploaded
+		if entity.DocumentStatus(document.Status) == entity.DocumentRejected {
+			action = entity.EventDocumentResubmitted
+		}
+		document.DocumentNumber = req.DocumentNumber
+		document.FileURL = req.FileURL
+		document.FileHash = nil
+		document.Status = string(entity.DocumentPending)
+		document.VerifiedBy = nil
+		document.VerifiedAt = nil
+		document.RejectionReason = nil
+		document.UpdatedAt = now
+
+		event := entity.NewEvent(merchantID, &document.ID, action, &userID, string(req.DocumentType), now)
+		if err := uc.repo.UpdateDocument(ctx, &document, kycCase, event); err != nil {
+			return nil, err
+		}
+	} else {
+		document := &merchantEntity.MerchantDocument{
+			ID:             uuid.New(),
+			MerchantID:     merchantID,
+			DocumentType:   string(req.DocumentType),
+			DocumentNumber: req.DocumentNumber,
+			FileURL:        req.FileURL,
+			Status:         string(entity.DocumentPending),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		event := entity.NewEvent(merchantID, &document.ID, entity.EventDocumentUploaded, &userID, string(req.DocumentType), now)
+		if err := uc.repo.CreateDocument(ctx, document, kycCase, event); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.log.Info("KYC document uploaded", map[string]interface{}{
+		"merchant_id":   merchantID,
+		"document_type": req.DocumentType,
+		"uploaded_by":   userID,
+	})
+
+	return uc.detail(ctx, kycCase, merchant, false)
+}
+
+func (uc *kycUseCase) Submit(ctx context.Context, merchantID, userID uuid.UUID) (*entity.CaseDetail, error) {
+	kycCase, merchant, err := uc.loadCase(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if kycCase.Status.Decided() {
+		return nil, entity.ErrCaseDecided
+	}
+	if kycCase.Status == entity.CaseInReview {
+		return nil, entity.ErrCaseInReview
+	}
+
+	checklist, err := uc.checklist(ctx, merchant)
+	if err != nil {
+		return nil, err
+	}
+	if outstanding := entity.Outstanding(checklist); len(outstanding) > 0 {
+		return nil, fmt.Errorf("%w: %s", entity.ErrDocumentsIncomplete, joinTypes(outstanding))
+	}
+
+	now := time.Now()
+	kycCase.BusinessType = merchant.BusinessType
+	kycCase.IsBettingCompany = merchant.IsBettingCompany
+	kycCase.UpdatedAt = now
+	kycCase.ApplyTINCheck(uc.checkTIN(ctx, merchant))
+	tinEvent := entity.NewEvent(merchantID, nil, entity.EventTINChecked, nil, tinNote(kycCase), now)
+
+	if kycCase.TINStatus == entity.TINFailed {
+		if err := uc.repo.UpdateCase(ctx, kycCase, tinEvent); err != nil {
+			return nil, err
+		}
+		uc.notify(ctx, merchantID, stepTINFailed, kycCase.TINDetail)
+		return nil, fmt.Errorf("%w: %s", entity.ErrTINNotVerified, kycCase.TINDetail)
+	}
+
+	dueAt := now.Add(entity.ReviewSLA)
+	kycCase.Status = entity.CaseInReview
+	kycCase.Submissions++
+	kycCase.SubmittedAt = &now
+	kycCase.DueAt = &dueAt
+	kycCase.SLABreachedAt = nil
+	submitted := entity.NewEvent(merchantID, nil, entity.EventSubmitted, &userID, fmt.Sprintf("submission %d", kycCase.Submissions), now)
+	if err := uc.repo.UpdateCase(ctx, kycCase, tinEvent, submitted); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("KYC case submitted for review", map[string]interface{}{
+		"merchant_id":  merchantID,
+		"submissions":  kycCase.Submissions,
+		"tin_status":   kycCase.TINStatus,
+		"due_at":       dueAt,
+		"submitted_by": userID,
+	})
+	uc.notify(ctx, merchantID, string(entity.EventSubmitted), dueAt.In(notificationZone).Format("02 Jan 2006 3:04 PM"))
+
+	return uc.detailWithChecklist(kycCase, checklist, nil), nil
+}
+
+func (uc *kycUseCase) ListQueue(ctx context.Context, filter entity.QueueFilter) (*entity.QueueResponse, error) {
+	filter.Status = entity.CaseStatus(strings.ToUpper(strings.TrimSpace(string(filter.Status))))
+	switch filter.Status {
+	case "":
+		filter.Status = entity.CaseInReview
+	case entity.CaseOpen, entity.CaseInReview, entity.CaseChangesRequested, entity.CaseApproved, entity.CaseRejected:
+	default:
+		return nil, fmt.Errorf("validation failed: unknown status %q", filter.Status)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultQueueLimit
+	}
+	if filter.Limit > maxQueueLimit {
+		filter.Limit = maxQueueLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	items, total, err := uc.repo.ListQueue(ctx, filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []entity.QueueItem{}
+	}
+
+	return &entity.QueueResponse{Cases: items, Total: total}, nil
+}
+
+func (uc *kycUseCase) GetCase(ctx context.Context, merchantID uuid.UUID) (*entity.CaseDetail, error) {
+	kycCase, merchant, err := uc.loadCase(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.detail(ctx, kycCase, merchant, true)
+}
+
+func (uc *kycUseCase) ReviewDocument(ctx context.Context, documentID, adminID uuid.UUID, req *entity.DocumentReviewRequest) (*entity.CaseDetail, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	document, err := uc.repo.GetDocument(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, entity.ErrDocumentNotFound
+	}
+
+	kycCase, merchant, err := uc.loadCase(ctx, document.MerchantID)
+	if err != nil {
+		return nil, err
+	}
+	if kycCase.Status.Decided() {
+		return nil, entity.ErrCaseDecided
+	}
+	if kycCase.Status == entity.CaseOpen {
+		return nil, entity.ErrCaseNotInReview
+	}
+	if entity.DocumentStatus(document.Status) != entity.DocumentPending {
+		return nil, entity.ErrDocumentReviewed
+	}
+
+	now := time.Now()
+	document.VerifiedBy = &adminID
+	document.VerifiedAt = &now
+	document.UpdatedAt = now
+	kycCase.UpdatedAt = now
+
+	var event *entity.Event
+	if req.Decision == entity.DecisionApprove {
+		document.Status = string(entity.DocumentApproved)
+		document.RejectionReason = nil
+		event = entity.NewEvent(document.MerchantID, &document.ID, entity.EventDocumentApproved, &adminID, document.DocumentType, now)
+	} else {
+		reason := req.Reason
+		document.Status = string(entity.DocumentRejected)
+		document.RejectionReason = &reason
+		// The case waits on the merchant now, so compliance is not on the clock
+		kycCase.Status = entity.CaseChangesRequested
+		kycCase.DueAt = nil
+		event = entity.NewEvent(document.MerchantID, &document.ID, entity.EventDocumentRejected, &adminID, document.DocumentType+": "+reason, now)
+	}
+	if err := uc.repo.UpdateDocument(ctx, document, kycCase, event); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("KYC document reviewed", map[string]interface{}{
+		"merchant_id":   document.MerchantID,
+		"document_id":   document.ID,
+		"document_type": document.DocumentType,
+		"decision":      req.Decision,
+		"reviewed_by":   adminID,
+	})
+	if req.Decision == entity.DecisionReject {
+		uc.notify(ctx, document.MerchantID, string(entity.EventDocumentRejected), document.DocumentType+" - "+req.Reason)
+	}
+
+	return uc.detail(ctx, kycCase, merchant, true)
+}
+
+func (uc *kycUseCase) CheckTIN(ctx context.Context, merchantID, adminID uuid.UUID) (*entity.CaseDetail, error) {
+	kycCase, merchant, err := uc.loadCase(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if kycCase.Status.Decided() {
+		return nil, entity.ErrCaseDecided
+	}
+
+	now := time.Now()
+	kycCase.ApplyTINCheck(uc.checkTIN(ctx, merchant))
+	kycCase.UpdatedAt = now
+	event := entity.NewEvent(merchantID, nil, entity.EventTINChecked, &adminID, tinNote(kycCase), now)
+	if err := uc.repo.UpdateCase(ctx, kycCase, event); err != nil {
+		return nil, err
+	}
+
+	if kycCase.TINStatus == entity.TINFailed {
+		uc.notify(ctx, merchantID, stepTINFailed, kycCase.TINDetail)
+	}
+
+	return uc.detail(ctx, kycCase, merchant, true)
+}
+
+func (uc *kycUseCase) Approve(ctx context.Context, merchantID, adminID uuid.UUID, req *entity.ApproveRequest) (*entity.CaseDetail, error) {
+	req.Normalize()
+	if err := req.Validate(limitsEntity.MaxKYCTier); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	kycCase, merchant, err := uc.loadCase(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if kycCase.Status.Decided() {
+		return nil, entity.ErrCaseDecided
+	}
+	if kycCase.Status != entity.CaseInReview {
+		return nil, entity.ErrCaseNotInReview
+	}
+
+	checklist, err := uc.checklist(ctx, merchant)
+	if err != nil {
+		return nil, err
+	}
+	if unapproved := entity.Unapproved(checklist); len(unapproved) > 0 {
+		return nil, fmt.Errorf("%w: %s", entity.ErrNotApprovable, joinTypes(unapproved))
+	}
+	if kycCase.TINStatus != entity.TINPassed {
+		return nil, fmt.Errorf("%w: TIN check is %s", entity.ErrTINNotVerified, kycCase.TINStatus)
+	}
+
+	now := time.Now()
+	kycCase.Status = entity.CaseApproved
+	kycCase.DecidedBy = &adminID
+	kycCase.DecidedAt = &now
+	kycCase.DecisionNote = req.Note
+	kycCase.DueAt = nil
+	kycCase.UpdatedAt = now
+	event := entity.NewEvent(merchantID, nil, entity.EventApproved, &adminID, req.Note, now)
+	if err := uc.repo.ActivateMerchant(ctx, kycCase, event); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("KYC case approved and merchant activated", map[string]interface{}{
+		"merchant_id": merchantID,
+		"kyc_tier":    req.Tier(),
+		"approved_by": adminID,
+	})
+	if err := uc.limits.SetKYCTier(ctx, merchantID, req.Tier(), adminID); err != nil {
+		// The merchant is active either way; its tier can be set from the limits admin
+		uc.log.Error("Failed to set KYC tier after approval", map[string]interface{}{
+			"merchant_id": merchantID,
+			"kyc_tier":    req.Tier(),
+			"error":       err.Error(),
+		})
+	}
+	uc.notify(ctx, merchantID, string(entity.EventApproved), now.In(notificationZone).Format("02 Jan 2006"))
+	if uc.statuses != nil && merchant.Status != merchantEntity.StatusActive {
+		uc.statuses.PublishStatusChanged(ctx, merchant, merchantEntity.StatusActive, "KYC approved")
+	}
+
+	return uc.detailWithChecklist(kycCase, checklist, nil), nil
+}
+
+func (uc *kycUseCase) Reject(ctx context.Context, merchantID, adminID uuid.UUID, req *entity.RejectRequest) (*entity.CaseDetail, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	kycCase, merchant, err := uc.loadCase(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if kycCase.Status.Decided() {
+		return nil, entity.ErrCaseDecided
+	}
+
+	now := time.Now()
+	kycCase.Status = entity.CaseRejected
+	kycCase.DecidedBy = &adminID
+	kycCase.DecidedAt = &now
+	kycCase.DecisionNote = req.Reason
+	kycCase.DueAt = nil
+	kycCase.UpdatedAt = now
+	event := entity.NewEvent(merchantID, nil, entity.EventRejected, &adminID, req.Reason, now)
+	if err := uc.repo.UpdateCase(ctx, kycCase, event); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("KYC case rejected", map[string]interface{}{
+		"merchant_id": merchantID,
+		"reason":      req.Reason,
+		"rejected_by": adminID,
+	})
+	uc.notify(ctx, merchantID, string(entity.EventRejected), req.Reason)
+
+	return uc.detail(ctx, kycCase, merchant, true)
+}
+
+func (uc *kycUseCase) FlagOverdue(ctx context.Context) error {
+	now := time.Now()
+	cases, err := uc.repo.ListOverdue(ctx, now, slaSweepBatch)
+	if err != nil {
+		return err
+	}
+
+	for i := range cases {
+		kycCase := &cases[i]
+		kycCase.SLABreachedAt = &now
+		kycCase.UpdatedAt = now
+		note := "review was due at " + kycCase.DueAt.In(notificationZone).Format(time.RFC3339)
+		event := entity.NewEvent(kycCase.MerchantID, nil, entity.EventSLABreached, nil, note, now)
+		if err := uc.repo.UpdateCase(ctx, kycCase, event); err != nil {
+			uc.log.Error("Failed to flag overdue KYC case", map[string]interface{}{
+				"merchant_id": kycCase.MerchantID,
+				"error":       err.Error(),
+			})
+			continue
+		}
+
+		uc.log.Warn("KYC review SLA breached", map[string]interface{}{
+			"merchant_id":  kycCase.MerchantID,
+			"submitted_at": kycCase.SubmittedAt,
+			"due_at":       kycCase.DueAt,
+		})
+	}
+	return nil
+}
+
+// loadCase retrieves the case of a merchant, opening it when the merchant has none
+func (uc *kycUseCase) loadCase(ctx context.Context, merchantID uuid.UUID) (*entity.Case, *merchantEntity.Merchant, error) {
+	kycCase, err := uc.repo.GetCase(ctx, merchantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kycCase == nil {
+		// Opening the case before reading the merchant tells a missing merchant apart from a failed lookup
+		if err := uc.repo.CreateCase(ctx, entity.NewCase(merchantID, time.Now())); err != nil {
+			return nil, nil, err
+		}
+		if kycCase, err = uc.repo.GetCase(ctx, merchantID); err != nil {
+			return nil, nil, err
+		}
+		if kycCase == nil {
+			return nil, nil, entity.ErrMerchantNotFound
+		}
+	}
+
+	merchant, err := uc.merchantRepo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kycCase, merchant, nil
+}
+
+func (uc *kycUseCase) checklist(ctx context.Context, merchant *merchantEntity.Merchant) ([]entity.ChecklistItem, error) {
+	documents, err := uc.repo.ListDocuments(ctx, merchant.ID)
+	if err != nil {
+		return nil, err
+	}
+	return entity.BuildChecklist(entity.RequiredDocuments(merchant.BusinessType, merchant.IsBettingCompany), documents), nil
+}
+
+func (uc *kycUseCase) detail(ctx context.Context, kycCase *entity.Case, merchant *merchantEntity.Merchant, withEvents bool) (*entity.CaseDetail, error) {
+	checklist, err := uc.checklist(ctx, merchant)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []entity.Event
+	if withEvents {
+		if events, err = uc.repo.ListEvents(ctx, kycCase.MerchantID); err != nil {
+			return nil, err
+		}
+	}
+	return uc.detailWithChecklist(kycCase, checklist, events), nil
+}
+
+func (uc *kycUseCase) detailWithChecklist(kycCase *entity.Case, checklist []entity.ChecklistItem, events []entity.Event) *entity.CaseDetail {
+	return &entity.CaseDetail{
+		Case:      *kycCase,
+		Overdue:   kycCase.Overdue(time.Now()),
+		Checklist: checklist,
+		Events:    events,
+	}
+}
+
+// checkTIN runs the TIN checker; a check that cannot be made leaves the TIN unavailable
+func (uc *kycUseCase) checkTIN(ctx context.Context, merchant *merchantEntity.Merchant) *entity.TINCheck {
+	check, err := uc.tinChecker.CheckTIN(ctx, merchant)
+	if err != nil {
+		uc.log.Warn("TIN check unavailable", map[string]interface{}{
+			"merchant_id": merchant.ID,
+			"error":       err.Error(),
+		})
+		return &entity.TINCheck{
+			Status:    entity.TINUnavailable,
+			Detail:    "TIN check could not be completed",
+			CheckedAt: time.Now(),
+		}
+	}
+	return check
+}
+
+// notify tells the merchant about a step; a failed notification does not undo the step
+func (uc *kycUseCase) notify(ctx context.Context, merchantID uuid.UUID, step, detail string) {
+	if err := uc.notifier.NotifyKYC(ctx, merchantID, step, detail); err != nil {
+		uc.log.Error("Failed to notify merchant of KYC step", map[string]interface{}{
+			"merchant_id": merchantID,
+			"step":        step,
+			"error":       err.Error(),
+		})
+	}
+}
+
+func tinNote(kycCase *entity.Case) string {
+	return string(kycCase.TINStatus) + ": " + kycCase.TINDetail
+}
+
+func joinTypes(types []entity.DocumentType) string {
+	names := make([]string, len(types))
+	for i, documentType := range types {
+		names[i] = string(documentType)
+	}
+	return strings.Join(names, ", ")
+}
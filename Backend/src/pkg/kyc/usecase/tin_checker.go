@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/kyc/core/entity"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+// TINChecker verifies the taxpayer identification number a merchant registered with. An error
+// means the check could not be made, not that the TIN is wrong.
+type TINChecker interface {
+	CheckTIN(ctx context.Context, merchant *merchantEntity.Merchant) (*entity.TINCheck, error)
+}
+
+// tinPattern is the shape of an Ethiopian TIN
+var tinPattern = regexp.MustCompile(`^[0-9]{10}$`)
+
+type formatTINChecker struct{}
+
+// NewFormatTINChecker creates a TIN checker that only checks the shape of the number, for
+// deployments without a registry to look it up in
+func NewFormatTINChecker() TINChecker {
+	return formatTINChecker{}
+}
+
+func (formatTINChecker) CheckTIN(ctx context.Context, merchant *merchantEntity.Merchant) (*entity.TINCheck, error) {
+	check := &entity.TINCheck{
+		Status:    entity.TINPassed,
+		Detail:    "TIN format is valid; not looked up in a registry",
+		CheckedAt: time.Now(),
+	}
+	if !tinPattern.MatchString(merchant.TaxIdentificationNumber) {
+		check.Status = entity.TINFailed
+		check.Detail = "TIN must be 10 digits"
+	}
+	return check, nil
+}
+
+// MerchantTINVerifier checks a merchant's TIN and business details against the trade registry
+type MerchantTINVerifier interface {
+	VerifyMerchantTIN(ctx context.Context, merchantID uuid.UUID) (*merchantEntity.TINVerification, error)
+}
+
+type registryTINChecker struct {
+	verifier MerchantTINVerifier
+}
+
+// NewRegistryTINChecker creates a TIN checker that looks the merchant up in the trade registry.
+// The check fails when the registry does not know the TIN or disagrees with the business
+// details the merchant submitted.
+func NewRegistryTINChecker(verifier MerchantTINVerifier) TINChecker {
+	return registryTINChecker{verifier: verifier}
+}
+
+func (c registryTINChecker) CheckTIN(ctx context.Context, merchant *merchantEntity.Merchant) (*entity.TINCheck, error) {
+	if !tinPattern.MatchString(merchant.TaxIdentificationNumber) {
+		return &entity.TINCheck{
+			Status:    entity.TINFailed,
+			Detail:    "TIN must be 10 digits",
+			CheckedAt: time.Now(),
+		}, nil
+	}
+
+	verification, err := c.verifier.VerifyMerchantTIN(ctx, merchant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &entity.TINCheck{CheckedAt: verification.CheckedAt}
+	switch verification.Status {
+	case merchantEntity.TINVerified:
+		check.Status = entity.TINPassed
+		check.Detail = fmt.Sprintf("TIN verified against the %s registry", verification.Source)
+	case merchantEntity.TINMismatch:
+		check.Status = entity.TINFailed
+		check.Detail = "Business details differ from the registry: " + describeDiscrepancies(verification.Discrepancies)
+	case merchantEntity.TINNotFound:
+		check.Status = entity.TINFailed
+		check.Detail = fmt.Sprintf("TIN is not registered in the %s registry", verification.Source)
+	default:
+		check.Status = entity.TINUnavailable
+		check.Detail = fmt.Sprintf("The %s registry could not be reached", verification.Source)
+	}
+	return check, nil
+}
+
+// describeDiscrepancies lists discrepancies as `legal_name "Abebe Trading" (registry "Abebe Trading PLC")`
+func describeDiscrepancies(discrepancies []merchantEntity.TINDiscrepancy) string {
+	parts := make([]string, 0, len(discrepancies))
+	for _, d := range discrepancies {
+		parts = append(parts, fmt.Sprintf("%s %q (registry %q)", d.Field, d.Submitted, d.Registry))
+	}
+	return strings.Join(parts, "; ")
+}
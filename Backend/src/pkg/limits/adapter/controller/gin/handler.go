@@ -0,0 +1,445 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/limits/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+type Handler struct {
+	limitsUseCase usecase.LimitsUseCase
+	log           logging.Logger
+	jwtMiddleware gin.HandlerFunc
+	rbac          *ginMiddleware.RBACV2
+}
+
+func NewHandler(limitsUseCase usecase.LimitsUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		limitsUseCase: limitsUseCase,
+		log:           logging.NewStdLogger("limits_handler"),
+		jwtMiddleware: jwtMiddleware,
+		rbac:          rbac,
+	}
+}
+
+// RegisterRouter sets up the merchant and admin limit routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	limits := router.Group("/limits", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	limits.GET("",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_READ),
+		h.GetUtilization)
+
+	admin := router.Group("/admin/limits", h.jwtMiddleware)
+	admin.GET("/profiles",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_READ),
+		h.ListProfiles)
+	admin.POST("/profiles",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_CREATE),
+		h.CreateProfile)
+	admin.GET("/profiles/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_READ),
+		h.GetProfile)
+	admin.PUT("/profiles/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.UpdateProfile)
+	admin.DELETE("/profiles/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_DELETE),
+		h.DeleteProfile)
+	admin.GET("/merchants/:merchant_id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_READ),
+		h.GetMerchantUtilization)
+	admin.PUT("/merchants/:merchant_id/kyc-tier",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.SetKYCTier)
+	admin.GET("/merchants/:merchant_id/increases",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_READ),
+		h.ListIncreases)
+	admin.POST("/merchants/:merchant_id/increases",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_CREATE),
+		h.GrantIncrease)
+	admin.POST("/increases/:id/revoke",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_LIMITS, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.RevokeIncrease)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// GetUtilization godoc
+// @Summary      Get transaction limits
+// @Description  Get the collection and withdrawal limits of the merchant, with how much of each was used today and this month
+// @Tags         Limits
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.UtilizationResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /limits [get]
+func (h *Handler) GetUtilization(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	response, err := h.limitsUseCase.GetUtilization(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListProfiles godoc
+// @Summary      List limit profiles
+// @Description  List every limit profile, inactive ones included
+// @Tags         Admin Limits
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.ProfileListResponse
+// @Router       /admin/limits/profiles [get]
+func (h *Handler) ListProfiles(c *gin.Context) {
+	response, err := h.limitsUseCase.ListProfiles(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateProfile godoc
+// @Summary      Create a limit profile
+// @Description  Create limits for the collections or withdrawals of the merchants matching its merchant_status, kyc_tier or merchant_id, optionally on one medium or API key. Within the same direction, currency, medium and API key only the most specific profiles apply to a merchant: its own, then those for its KYC tier and status, its KYC tier, its status and finally those for every merchant.
+// @Tags         Admin Limits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.ProfileRequest  true  "Limit profile"
+// @Success      201  {object}  entity.Profile
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /admin/limits/profiles [post]
+func (h *Handler) CreateProfile(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	profile, err := h.limitsUseCase.CreateProfile(c.Request.Context(), &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// GetProfile godoc
+// @Summary      Get a limit profile
+// @Tags         Admin Limits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Limit profile ID"
+// @Success      200  {object}  entity.Profile
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/limits/profiles/{id} [get]
+func (h *Handler) GetProfile(c *gin.Context) {
+	profileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid limit profile ID")))
+		return
+	}
+
+	profile, err := h.limitsUseCase.GetProfile(c.Request.Context(), profileID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// UpdateProfile godoc
+// @Summary      Update a limit profile
+// @Description  Replace a limit profile. Set active to false to suspend it without deleting it.
+// @Tags         Admin Limits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                 true  "Limit profile ID"
+// @Param        request  body  entity.ProfileRequest  true  "Limit profile"
+// @Success      200  {object}  entity.Profile
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/limits/profiles/{id} [put]
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	profileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid limit profile ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	profile, err := h.limitsUseCase.UpdateProfile(c.Request.Context(), profileID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteProfile godoc
+// @Summary      Delete a limit profile
+// @Tags         Admin Limits
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Limit profile ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/limits/profiles/{id} [delete]
+func (h *Handler) DeleteProfile(c *gin.Context) {
+	profileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid limit profile ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	if err := h.limitsUseCase.DeleteProfile(c.Request.Context(), profileID, adminID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMerchantUtilization godoc
+// @Summary      Get the transaction limits of a merchant
+// @Description  Get the limits that apply to a merchant, with its active increases applied and how much of each was used
+// @Tags         Admin Limits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      200  {object}  entity.UtilizationResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/limits/merchants/{merchant_id} [get]
+func (h *Handler) GetMerchantUtilization(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	response, err := h.limitsUseCase.GetUtilization(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetKYCTier godoc
+// @Summary      Set the KYC tier of a merchant
+// @Description  Set the KYC tier limit profiles select the merchant by
+// @Tags         Admin Limits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string                 true  "Merchant ID"
+// @Param        request      body  entity.KYCTierRequest  true  "KYC tier"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/limits/merchants/{merchant_id}/kyc-tier [put]
+func (h *Handler) SetKYCTier(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.KYCTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	if err := h.limitsUseCase.SetKYCTier(c.Request.Context(), merchantID, req.KYCTier, adminID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListIncreases godoc
+// @Summary      List the limit increases of a merchant
+// @Description  List the temporary limit increases of a merchant that are in effect
+// @Tags         Admin Limits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      200  {object}  entity.IncreaseListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/limits/merchants/{merchant_id}/increases [get]
+func (h *Handler) ListIncreases(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	response, err := h.limitsUseCase.ListIncreases(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GrantIncrease godoc
+// @Summary      Grant a temporary limit increase
+// @Description  Raise the maximum amount, daily and monthly volume or daily count of a merchant's collection or withdrawal limits until expires_at, at most 90 days ahead. An increase only raises limits its profiles already set.
+// @Tags         Admin Limits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string                  true  "Merchant ID"
+// @Param        request      body  entity.IncreaseRequest  true  "Limit increase"
+// @Success      201  {object}  entity.Increase
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/limits/merchants/{merchant_id}/increases [post]
+func (h *Handler) GrantIncrease(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.IncreaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	req.Direction = entity.Direction(strings.ToUpper(string(req.Direction)))
+
+	increase, err := h.limitsUseCase.GrantIncrease(c.Request.Context(), merchantID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, increase)
+}
+
+// RevokeIncrease godoc
+// @Summary      Revoke a limit increase
+// @Description  End a temporary limit increase before it expires
+// @Tags         Admin Limits
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Limit increase ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/limits/increases/{id}/revoke [post]
+func (h *Handler) RevokeIncrease(c *gin.Context) {
+	increaseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid limit increase ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	if err := h.limitsUseCase.RevokeIncrease(c.Request.Context(), increaseID, adminID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrProfileNotFound),
+		errors.Is(err, entity.ErrIncreaseNotFound),
+		errors.Is(err, entity.ErrMerchantNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("Limits request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
@@ -0,0 +1,587 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+var (
+	// ErrLimitExceeded matches every LimitExceededError
+	ErrLimitExceeded = errors.New("transaction limit exceeded")
+	// ErrProfileNotFound is returned when no limit profile has the given ID
+	ErrProfileNotFound = errors.New("limit profile not found")
+	// ErrIncreaseNotFound is returned when no active limit increase has the given ID
+	ErrIncreaseNotFound = errors.New("limit increase not found")
+	// ErrMerchantNotFound is returned when limits are asked for a merchant that does not exist
+	ErrMerchantNotFound = errors.New("merchant not found")
+)
+
+// LimitZone is the time zone daily and monthly limits reset in
+var LimitZone = time.FixedZone("EAT", 3*60*60)
+
+const (
+	// MaxKYCTier is the highest KYC tier a merchant can reach
+	MaxKYCTier = 3
+	// MaxIncreaseDays bounds how long a temporary limit increase can last
+	MaxIncreaseDays = 90
+	// DefaultCurrency is the currency of profiles created without one
+	DefaultCurrency = "ETB"
+)
+
+// Direction is the way money moves in a limited transaction
+type Direction string
+
+const (
+	// DirectionCollection covers payments collected from customers
+	DirectionCollection Direction = "COLLECTION"
+	// DirectionWithdrawal covers payouts from the merchant's wallet
+	DirectionWithdrawal Direction = "WITHDRAWAL"
+)
+
+// DirectionOf returns the direction limits apply to for a transaction type, if any
+func DirectionOf(txType txEntity.TransactionType) (Direction, bool) {
+	switch txType {
+	case txEntity.DEPOSIT:
+		return DirectionCollection, true
+	case txEntity.WITHDRAWAL:
+		return DirectionWithdrawal, true
+	default:
+		return "", false
+	}
+}
+
+// Limit identifies one of the limits of a profile
+type Limit string
+
+const (
+	LimitMinAmount     Limit = "MIN_AMOUNT"
+	LimitMaxAmount     Limit = "MAX_AMOUNT"
+	LimitDailyVolume   Limit = "DAILY_VOLUME"
+	LimitMonthlyVolume Limit = "MONTHLY_VOLUME"
+	LimitDailyCount    Limit = "DAILY_COUNT"
+)
+
+// Values are the limits of a profile, in the profile's currency. An unset limit does not apply.
+type Values struct {
+	MinAmount     *float64 `json:"min_amount,omitempty" example:"10"`
+	MaxAmount     *float64 `json:"max_amount,omitempty" example:"10000"`
+	DailyVolume   *float64 `json:"daily_volume,omitempty" example:"50000"`
+	MonthlyVolume *float64 `json:"monthly_volume,omitempty" example:"500000"`
+	DailyCount    *int     `json:"daily_count,omitempty" example:"3"`
+}
+
+// Validate validates the limits
+func (v Values) Validate() error {
+	if err := validation.ValidateStruct(&v,
+		validation.Field(&v.MinAmount, validation.Min(0.0)),
+		validation.Field(&v.MaxAmount, validation.Min(0.0)),
+		validation.Field(&v.DailyVolume, validation.Min(0.0)),
+		validation.Field(&v.MonthlyVolume, validation.Min(0.0)),
+		validation.Field(&v.DailyCount, validation.Min(0)),
+	); err != nil {
+		return err
+	}
+	if v.MinAmount != nil && v.MaxAmount != nil && *v.MinAmount > *v.MaxAmount {
+		return errors.New("min_amount must not exceed max_amount")
+	}
+	if v.DailyVolume != nil && v.MonthlyVolume != nil && *v.DailyVolume > *v.MonthlyVolume {
+		return errors.New("daily_volume must not exceed monthly_volume")
+	}
+	return nil
+}
+
+// IsEmpty reports whether no limit is set
+func (v Values) IsEmpty() bool {
+	return v.MinAmount == nil && v.MaxAmount == nil && v.DailyVolume == nil && v.MonthlyVolume == nil && v.DailyCount == nil
+}
+
+// Raise applies an increase to the limits. An increase only ever raises a maximum the limits
+// already set; it never lowers one, sets a missing one or changes the minimum amount.
+func (v Values) Raise(increase Values) Values {
+	raised := v
+	raised.MaxAmount = raiseFloat(v.MaxAmount, increase.MaxAmount)
+	raised.DailyVolume = raiseFloat(v.DailyVolume, increase.DailyVolume)
+	raised.MonthlyVolume = raiseFloat(v.MonthlyVolume, increase.MonthlyVolume)
+	if v.DailyCount != nil && increase.DailyCount != nil && *increase.DailyCount > *v.DailyCount {
+		count := *increase.DailyCount
+		raised.DailyCount = &count
+	}
+	return raised
+}
+
+func raiseFloat(limit, increase *float64) *float64 {
+	if limit == nil || increase == nil || *increase <= *limit {
+		return limit
+	}
+	raised := *increase
+	return &raised
+}
+
+// Scope selects the merchants, medium and API key a profile applies to. An empty field matches any.
+type Scope struct {
+	MerchantStatus string                     `json:"merchant_status,omitempty" example:"pending_verification"`
+	KYCTier        *int                       `json:"kyc_tier,omitempty" example:"0"`
+	MerchantID     *uuid.UUID                 `json:"merchant_id,omitempty"`
+	Medium         txEntity.TransactionMedium `json:"medium,omitempty" example:"TELEBIRR"`
+	APIKeyID       *uuid.UUID                 `json:"api_key_id,omitempty"`
+}
+
+// Profile is a set of limits on the collections or withdrawals of the merchants it applies to.
+// Usage is counted within the profile's scope: a profile for a medium or an API key only
+// counts transactions on that medium or made with that key.
+// @Description Limit profile
+type Profile struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name" example:"Unverified merchants"`
+	Description string    `json:"description,omitempty"`
+	Direction   Direction `json:"direction" example:"COLLECTION"`
+	Currency    string    `json:"currency" example:"ETB"`
+	Scope
+	Values
+	Active    bool       `json:"active"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// specificity ranks how closely the profile targets a merchant. Within the same direction,
+// currency, medium and API key, only the profiles with the highest rank apply.
+func (p *Profile) specificity() int {
+	rank := 0
+	if p.MerchantID != nil {
+		rank += 4
+	}
+	if p.KYCTier != nil {
+		rank += 2
+	}
+	if p.MerchantStatus != "" {
+		rank++
+	}
+	return rank
+}
+
+// matchesMerchant reports whether the profile applies to the merchant, whatever the transaction
+func (p *Profile) matchesMerchant(merchant Merchant) bool {
+	if !p.Active {
+		return false
+	}
+	if p.MerchantID != nil && *p.MerchantID != merchant.ID {
+		return false
+	}
+	if p.KYCTier != nil && *p.KYCTier != merchant.KYCTier {
+		return false
+	}
+	return p.MerchantStatus == "" || p.MerchantStatus == merchant.Status
+}
+
+// Matches reports whether the profile applies to the transaction
+func (p *Profile) Matches(subject Subject) bool {
+	if !p.matchesMerchant(subject.Merchant) || p.Direction != subject.Direction || p.Currency != subject.Currency {
+		return false
+	}
+	if p.Medium != "" && p.Medium != subject.Medium {
+		return false
+	}
+	return p.APIKeyID == nil || (subject.APIKeyID != nil && *p.APIKeyID == *subject.APIKeyID)
+}
+
+// ProfileRequest creates or replaces a limit profile
+// @Description Limit profile
+type ProfileRequest struct {
+	Name        string    `json:"name" example:"Unverified merchants"`
+	Description string    `json:"description,omitempty"`
+	Direction   Direction `json:"direction" example:"COLLECTION"`
+	Currency    string    `json:"currency,omitempty" example:"ETB"`
+	Scope
+	Values
+	Active *bool `json:"active,omitempty"`
+}
+
+// Normalize fills in the default currency and puts the scope in the case it is compared in
+func (r *ProfileRequest) Normalize() {
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	if r.Currency == "" {
+		r.Currency = DefaultCurrency
+	}
+	r.Direction = Direction(strings.ToUpper(string(r.Direction)))
+	r.Medium = txEntity.TransactionMedium(strings.ToUpper(string(r.Medium)))
+	r.MerchantStatus = strings.ToLower(strings.TrimSpace(r.MerchantStatus))
+}
+
+// Validate validates the profile
+func (r *ProfileRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Description, validation.Length(0, 500)),
+		validation.Field(&r.Direction, validation.Required, validation.In(DirectionCollection, DirectionWithdrawal)),
+		validation.Field(&r.Currency, validation.Required, validation.Length(3, 3)),
+		validation.Field(&r.MerchantStatus, validation.In(merchantStatuses()...)),
+	); err != nil {
+		return err
+	}
+	if r.KYCTier != nil && (*r.KYCTier < 0 || *r.KYCTier > MaxKYCTier) {
+		return fmt.Errorf("kyc_tier must be between 0 and %d", MaxKYCTier)
+	}
+	if r.Values.IsEmpty() {
+		return errors.New("at least one limit must be set")
+	}
+	return r.Values.Validate()
+}
+
+func merchantStatuses() []interface{} {
+	return []interface{}{
+		string(merchantEntity.StatusPendingVerification),
+		string(merchantEntity.StatusActive),
+		string(merchantEntity.StatusInactive),
+		string(merchantEntity.StatusSuspended),
+		string(merchantEntity.StatusTerminated),
+	}
+}
+
+// Merchant is what limits are selected by for a merchant
+type Merchant struct {
+	ID      uuid.UUID
+	Status  string
+	KYCTier int
+}
+
+// Subject is a transaction the limits of its merchant are applied to
+type Subject struct {
+	Merchant  Merchant
+	Direction Direction
+	Currency  string
+	Medium    txEntity.TransactionMedium
+	APIKeyID  *uuid.UUID
+	Amount    float64
+	Test      bool
+}
+
+type scopeKey struct {
+	direction Direction
+	currency  string
+	medium    txEntity.TransactionMedium
+	apiKeyID  uuid.UUID
+}
+
+// ForMerchant selects the profiles of a merchant. Within each direction, currency, medium and
+// API key, the most specific profiles apply: those for the merchant itself, then those for its
+// KYC tier and status, its KYC tier, its status and finally the profiles for every merchant.
+func ForMerchant(profiles []Profile, merchant Merchant) []Profile {
+	best := make(map[scopeKey]int)
+	for i := range profiles {
+		p := &profiles[i]
+		if !p.matchesMerchant(merchant) {
+			continue
+		}
+		key := p.scopeKey()
+		if rank, ok := best[key]; !ok || p.specificity() > rank {
+			best[key] = p.specificity()
+		}
+	}
+
+	var selected []Profile
+	for i := range profiles {
+		p := &profiles[i]
+		if p.matchesMerchant(merchant) && p.specificity() == best[p.scopeKey()] {
+			selected = append(selected, *p)
+		}
+	}
+	return selected
+}
+
+// Applicable selects the profiles a transaction is checked against
+func Applicable(profiles []Profile, subject Subject) []Profile {
+	var applicable []Profile
+	for _, p := range ForMerchant(profiles, subject.Merchant) {
+		if p.Matches(subject) {
+			applicable = append(applicable, p)
+		}
+	}
+	return applicable
+}
+
+func (p *Profile) scopeKey() scopeKey {
+	key := scopeKey{direction: p.Direction, currency: p.Currency, medium: p.Medium}
+	if p.APIKeyID != nil {
+		key.apiKeyID = *p.APIKeyID
+	}
+	return key
+}
+
+// Usage is what a merchant used of a profile's scope in the current day and month
+type Usage struct {
+	DailyVolume   float64
+	MonthlyVolume float64
+	DailyCount    int
+}
+
+// DayStart is when the daily limits in effect at now started to count
+func DayStart(now time.Time) time.Time {
+	local := now.In(LimitZone)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, LimitZone)
+}
+
+// MonthStart is when the monthly limits in effect at now started to count
+func MonthStart(now time.Time) time.Time {
+	local := now.In(LimitZone)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, LimitZone)
+}
+
+// AppliedProfile is a profile with the merchant's active increases applied to its limits
+type AppliedProfile struct {
+	Profile
+	// IncreasedUntil is when the earliest increase raising the limits expires
+	IncreasedUntil *time.Time
+}
+
+// Apply raises the limits of the profiles with the increases active at now
+func Apply(profiles []Profile, increases []Increase, now time.Time) []AppliedProfile {
+	applied := make([]AppliedProfile, len(profiles))
+	for i, p := range profiles {
+		applied[i] = AppliedProfile{Profile: p}
+		for _, increase := range increases {
+			if increase.Direction != p.Direction || !increase.ActiveAt(now) {
+				continue
+			}
+			raised := applied[i].Values.Raise(increase.Values)
+			if raised == applied[i].Values {
+				continue
+			}
+			applied[i].Values = raised
+			if applied[i].IncreasedUntil == nil || increase.ExpiresAt.Before(*applied[i].IncreasedUntil) {
+				expiresAt := increase.ExpiresAt
+				applied[i].IncreasedUntil = &expiresAt
+			}
+		}
+	}
+	return applied
+}
+
+// Check reports the first limit of the profile the amount would exceed given the usage so far
+func (p *AppliedProfile) Check(amount float64, usage Usage, now time.Time) *LimitExceededError {
+	exceeded := func(limit Limit, allowed, used float64, resetsAt *time.Time) *LimitExceededError {
+		return &LimitExceededError{
+			Limit:       limit,
+			ProfileID:   p.ID,
+			ProfileName: p.Name,
+			Direction:   p.Direction,
+			Currency:    p.Currency,
+			Allowed:     allowed,
+			Used:        used,
+			Requested:   amount,
+			ResetsAt:    resetsAt,
+		}
+	}
+	tomorrow := DayStart(now).AddDate(0, 0, 1)
+	nextMonth := MonthStart(now).AddDate(0, 1, 0)
+
+	switch {
+	case p.MinAmount != nil && amount < *p.MinAmount:
+		return exceeded(LimitMinAmount, *p.MinAmount, 0, nil)
+	case p.MaxAmount != nil && amount > *p.MaxAmount:
+		return exceeded(LimitMaxAmount, *p.MaxAmount, 0, nil)
+	case p.DailyCount != nil && usage.DailyCount+1 > *p.DailyCount:
+		return exceeded(LimitDailyCount, float64(*p.DailyCount), float64(usage.DailyCount), &tomorrow)
+	case p.DailyVolume != nil && usage.DailyVolume+amount > *p.DailyVolume:
+		return exceeded(LimitDailyVolume, *p.DailyVolume, usage.DailyVolume, &tomorrow)
+	case p.MonthlyVolume != nil && usage.MonthlyVolume+amount > *p.MonthlyVolume:
+		return exceeded(LimitMonthlyVolume, *p.MonthlyVolume, usage.MonthlyVolume, &nextMonth)
+	}
+	return nil
+}
+
+// LimitExceededError describes the limit a transaction would exceed
+type LimitExceededError struct {
+	Limit       Limit      `json:"limit" example:"DAILY_VOLUME"`
+	ProfileID   uuid.UUID  `json:"profile_id"`
+	ProfileName string     `json:"profile_name"`
+	Direction   Direction  `json:"direction" example:"COLLECTION"`
+	Currency    string     `json:"currency" example:"ETB"`
+	Allowed     float64    `json:"allowed" example:"50000"`
+	Used        float64    `json:"used" example:"48000"`
+	Requested   float64    `json:"requested" example:"5000"`
+	ResetsAt    *time.Time `json:"resets_at,omitempty"`
+}
+
+func (e *LimitExceededError) Error() string {
+	direction := "collection"
+	if e.Direction == DirectionWithdrawal {
+		direction = "withdrawal"
+	}
+	switch e.Limit {
+	case LimitMinAmount:
+		return fmt.Sprintf("%s amount %.2f %s is below the minimum of %.2f", direction, e.Requested, e.Currency, e.Allowed)
+	case LimitMaxAmount:
+		return fmt.Sprintf("%s amount %.2f %s is above the maximum of %.2f", direction, e.Requested, e.Currency, e.Allowed)
+	case LimitDailyCount:
+		return fmt.Sprintf("daily %s count limit of %d reached", direction, int(e.Allowed))
+	case LimitDailyVolume:
+		return fmt.Sprintf("daily %s limit of %.2f %s exceeded: %.2f used, %.2f requested", direction, e.Allowed, e.Currency, e.Used, e.Requested)
+	default:
+		return fmt.Sprintf("monthly %s limit of %.2f %s exceeded: %.2f used, %.2f requested", direction, e.Allowed, e.Currency, e.Used, e.Requested)
+	}
+}
+
+// Is makes errors.Is(err, ErrLimitExceeded) match
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// Increase temporarily raises the limits of a merchant
+// @Description Temporary limit increase
+type Increase struct {
+	ID         uuid.UUID  `json:"id"`
+	MerchantID uuid.UUID  `json:"merchant_id"`
+	Direction  Direction  `json:"direction" example:"COLLECTION"`
+	Values                // limits the increase raises the merchant's profiles to
+	Reason     string     `json:"reason" example:"Holiday season sales"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	GrantedBy  uuid.UUID  `json:"granted_by"`
+	RevokedBy  *uuid.UUID `json:"revoked_by,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ActiveAt reports whether the increase is in effect at t
+func (i *Increase) ActiveAt(t time.Time) bool {
+	return i.RevokedAt == nil && t.Before(i.ExpiresAt)
+}
+
+// IncreaseRequest grants a temporary limit increase
+// @Description Temporary limit increase
+type IncreaseRequest struct {
+	Direction Direction `json:"direction" example:"COLLECTION"`
+	Values
+	Reason    string    `json:"reason" example:"Holiday season sales"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Validate validates the increase at now
+func (r *IncreaseRequest) Validate(now time.Time) error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Direction, validation.Required, validation.In(DirectionCollection, DirectionWithdrawal)),
+		validation.Field(&r.Reason, validation.Required, validation.Length(1, 500)),
+		validation.Field(&r.ExpiresAt, validation.Required),
+	); err != nil {
+		return err
+	}
+	if r.MinAmount != nil {
+		return errors.New("an increase cannot change min_amount")
+	}
+	if r.Values.IsEmpty() {
+		return errors.New("at least one limit must be raised")
+	}
+	if !r.ExpiresAt.After(now) {
+		return errors.New("expires_at must be in the future")
+	}
+	if r.ExpiresAt.After(now.AddDate(0, 0, MaxIncreaseDays)) {
+		return fmt.Errorf("expires_at must be within %d days", MaxIncreaseDays)
+	}
+	return r.Values.Validate()
+}
+
+// KYCTierRequest sets the KYC tier of a merchant
+// @Description KYC tier
+type KYCTierRequest struct {
+	KYCTier int `json:"kyc_tier" example:"1"`
+}
+
+// Validate validates the tier
+func (r *KYCTierRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.KYCTier, validation.Min(0), validation.Max(MaxKYCTier)),
+	)
+}
+
+// ProfileListResponse lists limit profiles
+// @Description Limit profiles
+type ProfileListResponse struct {
+	Profiles []Profile `json:"profiles"`
+}
+
+// IncreaseListResponse lists the limit increases of a merchant
+// @Description Limit increases
+type IncreaseListResponse struct {
+	Increases []Increase `json:"increases"`
+}
+
+// LimitUtilization is how much of a limit was used
+type LimitUtilization struct {
+	Limit     Limit      `json:"limit" example:"DAILY_VOLUME"`
+	Allowed   float64    `json:"allowed" example:"50000"`
+	Used      float64    `json:"used" example:"12000"`
+	Remaining float64    `json:"remaining" example:"38000"`
+	ResetsAt  *time.Time `json:"resets_at,omitempty"`
+}
+
+// ProfileUtilization is how much of the limits of a profile a merchant used
+type ProfileUtilization struct {
+	ProfileID      uuid.UUID                  `json:"profile_id"`
+	Name           string                     `json:"name"`
+	Direction      Direction                  `json:"direction"`
+	Currency       string                     `json:"currency"`
+	Medium         txEntity.TransactionMedium `json:"medium,omitempty"`
+	APIKeyID       *uuid.UUID                 `json:"api_key_id,omitempty"`
+	MinAmount      *float64                   `json:"min_amount,omitempty"`
+	MaxAmount      *float64                   `json:"max_amount,omitempty"`
+	Limits         []LimitUtilization         `json:"limits"`
+	IncreasedUntil *time.Time                 `json:"increased_until,omitempty"`
+}
+
+// Utilization summarizes the usage limits of a profile
+func (p *AppliedProfile) Utilization(usage Usage, now time.Time) ProfileUtilization {
+	tomorrow := DayStart(now).AddDate(0, 0, 1)
+	nextMonth := MonthStart(now).AddDate(0, 1, 0)
+	utilization := ProfileUtilization{
+		ProfileID:      p.ID,
+		Name:           p.Name,
+		Direction:      p.Direction,
+		Currency:       p.Currency,
+		Medium:         p.Medium,
+		APIKeyID:       p.APIKeyID,
+		MinAmount:      p.MinAmount,
+		MaxAmount:      p.MaxAmount,
+		Limits:         []LimitUtilization{},
+		IncreasedUntil: p.IncreasedUntil,
+	}
+	add := func(limit Limit, allowed, used float64, resetsAt time.Time) {
+		remaining := allowed - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		utilization.Limits = append(utilization.Limits, LimitUtilization{
+			Limit:     limit,
+			Allowed:   allowed,
+			Used:      used,
+			Remaining: remaining,
+			ResetsAt:  &resetsAt,
+		})
+	}
+	if p.DailyCount != nil {
+		add(LimitDailyCount, float64(*p.DailyCount), float64(usage.DailyCount), tomorrow)
+	}
+	if p.DailyVolume != nil {
+		add(LimitDailyVolume, *p.DailyVolume, usage.DailyVolume, tomorrow)
+	}
+	if p.MonthlyVolume != nil {
+		add(LimitMonthlyVolume, *p.MonthlyVolume, usage.MonthlyVolume, nextMonth)
+	}
+	return utilization
+}
+
+// UtilizationResponse shows a merchant its limits and how much of them it used
+// @Description Transaction limits and their utilization
+type UtilizationResponse struct {
+	MerchantStatus string               `json:"merchant_status" example:"active"`
+	KYCTier        int                  `json:"kyc_tier" example:"1"`
+	Profiles       []ProfileUtilization `json:"profiles"`
+}
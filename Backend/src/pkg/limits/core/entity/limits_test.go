@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func intPtr(v int) *int { return &v }
+
+func TestForMerchantPicksMostSpecificProfiles(t *testing.T) {
+	merchant := Merchant{ID: uuid.New(), Status: "active", KYCTier: 2}
+	other := uuid.New()
+
+	profiles := []Profile{
+		{Name: "active", Direction: DirectionCollection, Currency: "ETB", Active: true,
+			Scope: Scope{MerchantStatus: "active"}},
+		{Name: "tier 2", Direction: DirectionCollection, Currency: "ETB", Active: true,
+			Scope: Scope{KYCTier: intPtr(2)}},
+		{Name: "tier 1", Direction: DirectionCollection, Currency: "ETB", Active: true,
+			Scope: Scope{KYCTier: intPtr(1)}},
+		{Name: "other merchant", Direction: DirectionCollection, Currency: "ETB", Active: true,
+			Scope: Scope{MerchantID: &other}},
+		{Name: "telebirr", Direction: DirectionCollection, Currency: "ETB", Active: true,
+			Scope: Scope{MerchantStatus: "active", Medium: "TELEBIRR"}},
+		{Name: "withdrawals", Direction: DirectionWithdrawal, Currency: "ETB", Active: true},
+		{Name: "inactive", Direction: DirectionWithdrawal, Currency: "ETB",
+			Scope: Scope{MerchantID: &merchant.ID}},
+	}
+
+	var names []string
+	for _, p := range ForMerchant(profiles, merchant) {
+		names = append(names, p.Name)
+	}
+	want := []string{"tier 2", "telebirr", "withdrawals"}
+	if len(names) != len(want) {
+		t.Fatalf("selected %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("selected %v, want %v", names, want)
+		}
+	}
+
+	applicable := Applicable(profiles, Subject{Merchant: merchant, Direction: DirectionCollection, Currency: "ETB", Medium: "CBE"})
+	if len(applicable) != 1 || applicable[0].Name != "tier 2" {
+		t.Fatalf("applicable = %v, want only tier 2", applicable)
+	}
+}
+
+func TestApplyRaisesOnlyWithActiveIncreases(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, LimitZone)
+	revokedAt := now.Add(-time.Hour)
+	profile := Profile{
+		Direction: DirectionCollection,
+		Values:    Values{MinAmount: floatPtr(10), MaxAmount: floatPtr(1000), DailyVolume: floatPtr(5000)},
+	}
+	increases := []Increase{
+		{Direction: DirectionCollection, Values: Values{DailyVolume: floatPtr(20000), MonthlyVolume: floatPtr(1e6)}, ExpiresAt: now.AddDate(0, 0, 7)},
+		{Direction: DirectionCollection, Values: Values{MaxAmount: floatPtr(500)}, ExpiresAt: now.AddDate(0, 0, 1)},
+		{Direction: DirectionCollection, Values: Values{MaxAmount: floatPtr(9000)}, ExpiresAt: now.AddDate(0, 0, 3), RevokedAt: &revokedAt},
+		{Direction: DirectionWithdrawal, Values: Values{MaxAmount: floatPtr(9000)}, ExpiresAt: now.AddDate(0, 0, 3)},
+	}
+
+	applied := Apply([]Profile{profile}, increases, now)[0]
+	if *applied.DailyVolume != 20000 || *applied.MaxAmount != 1000 || *applied.MinAmount != 10 {
+		t.Fatalf("values = max %v, daily %v, min %v; want 1000, 20000, 10", *applied.MaxAmount, *applied.DailyVolume, *applied.MinAmount)
+	}
+	if applied.MonthlyVolume != nil {
+		t.Fatalf("monthly volume = %v, want no limit added by an increase", *applied.MonthlyVolume)
+	}
+	if applied.IncreasedUntil == nil || !applied.IncreasedUntil.Equal(now.AddDate(0, 0, 7)) {
+		t.Fatalf("increased until = %v, want %v", applied.IncreasedUntil, now.AddDate(0, 0, 7))
+	}
+	if *profile.DailyVolume != 5000 {
+		t.Fatalf("profile daily volume = %v, want it left at 5000", *profile.DailyVolume)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2026, 3, 10, 23, 30, 0, 0, LimitZone)
+	profile := AppliedProfile{Profile: Profile{
+		Direction: DirectionWithdrawal,
+		Currency:  "ETB",
+		Values: Values{
+			MinAmount:     floatPtr(10),
+			MaxAmount:     floatPtr(1000),
+			DailyVolume:   floatPtr(2000),
+			MonthlyVolume: floatPtr(3000),
+			DailyCount:    intPtr(3),
+		},
+	}}
+
+	tests := []struct {
+		name   string
+		amount float64
+		usage  Usage
+		limit  Limit
+		resets time.Time
+	}{
+		{name: "within limits", amount: 500, usage: Usage{DailyVolume: 1500, MonthlyVolume: 2000, DailyCount: 2}},
+		{name: "below minimum", amount: 5, limit: LimitMinAmount},
+		{name: "above maximum", amount: 1500, limit: LimitMaxAmount},
+		{name: "count before volume", amount: 900, usage: Usage{DailyVolume: 1500, DailyCount: 3},
+			limit: LimitDailyCount, resets: time.Date(2026, 3, 11, 0, 0, 0, 0, LimitZone)},
+		{name: "daily volume", amount: 600, usage: Usage{DailyVolume: 1500, MonthlyVolume: 1500},
+			limit: LimitDailyVolume, resets: time.Date(2026, 3, 11, 0, 0, 0, 0, LimitZone)},
+		{name: "monthly volume", amount: 600, usage: Usage{MonthlyVolume: 2500},
+			limit: LimitMonthlyVolume, resets: time.Date(2026, 4, 1, 0, 0, 0, 0, LimitZone)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exceeded := profile.Check(tt.amount, tt.usage, now)
+			if tt.limit == "" {
+				if exceeded != nil {
+					t.Fatalf("Check() = %v, want nil", exceeded)
+				}
+				return
+			}
+			if exceeded == nil || exceeded.Limit != tt.limit {
+				t.Fatalf("Check() = %v, want %s exceeded", exceeded, tt.limit)
+			}
+			if !errors.Is(exceeded, ErrLimitExceeded) {
+				t.Fatalf("Check() = %v, want it to match ErrLimitExceeded", exceeded)
+			}
+			if !tt.resets.IsZero() && (exceeded.ResetsAt == nil || !exceeded.ResetsAt.Equal(tt.resets)) {
+				t.Fatalf("resets at = %v, want %v", exceeded.ResetsAt, tt.resets)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+)
+
+// LimitsRepository defines the interface for limit profile, increase and usage persistence
+type LimitsRepository interface {
+	// GetMerchant retrieves the status and KYC tier of a merchant, nil when it does not exist
+	GetMerchant(ctx context.Context, merchantID uuid.UUID) (*entity.Merchant, error)
+	// SetKYCTier sets the KYC tier of a merchant
+	SetKYCTier(ctx context.Context, merchantID uuid.UUID, tier int) error
+
+	CreateProfile(ctx context.Context, profile *entity.Profile) error
+	UpdateProfile(ctx context.Context, profile *entity.Profile) error
+	GetProfile(ctx context.Context, id uuid.UUID) (*entity.Profile, error)
+	ListProfiles(ctx context.Context, activeOnly bool) ([]entity.Profile, error)
+	DeleteProfile(ctx context.Context, id uuid.UUID) error
+
+	CreateIncrease(ctx context.Context, increase *entity.Increase) error
+	// ListIncreases retrieves the increases of a merchant that did not expire and were not revoked by now
+	ListIncreases(ctx context.Context, merchantID uuid.UUID, now time.Time) ([]entity.Increase, error)
+	RevokeIncrease(ctx context.Context, id, adminID uuid.UUID, now time.Time) error
+
+	// GetUsage sums what a merchant used of a profile's scope in the day and month of now
+	GetUsage(ctx context.Context, merchantID uuid.UUID, profile *entity.Profile, test bool, now time.Time) (entity.Usage, error)
+	// Reserve checks a transaction against the profiles and records it in their usage. Reservations
+	// of the same merchant are serialized, so concurrent transactions cannot overrun a limit together.
+	Reserve(ctx context.Context, transactionID uuid.UUID, subject entity.Subject, profiles []entity.AppliedProfile, now time.Time) error
+}
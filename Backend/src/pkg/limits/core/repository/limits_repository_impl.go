@@ -0,0 +1,437 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const profileColumns = `id, name, description, direction, currency, merchant_status, kyc_tier, merchant_id, medium, api_key_id, min_amount, max_amount, daily_volume, monthly_volume, daily_count, active, created_by, updated_by, created_at, updated_at`
+
+const increaseColumns = `id, merchant_id, direction, max_amount, daily_volume, monthly_volume, daily_count, reason, expires_at, granted_by, revoked_by, revoked_at, created_at`
+
+type LimitsRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewLimitsRepository(db *sql.DB) LimitsRepository {
+	return &LimitsRepositoryImpl{db: db}
+}
+
+func (r *LimitsRepositoryImpl) GetMerchant(ctx context.Context, merchantID uuid.UUID) (*entity.Merchant, error) {
+	query := `SELECT id, status, kyc_tier FROM merchants.merchants WHERE id = $1 AND deleted_at IS NULL`
+
+	var merchant entity.Merchant
+	err := r.db.QueryRowContext(ctx, query, merchantID).Scan(&merchant.ID, &merchant.Status, &merchant.KYCTier)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	return &merchant, nil
+}
+
+func (r *LimitsRepositoryImpl) SetKYCTier(ctx context.Context, merchantID uuid.UUID, tier int) error {
+	query := `UPDATE merchants.merchants SET kyc_tier = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, merchantID, tier)
+	if err != nil {
+		return fmt.Errorf("failed to set KYC tier: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to set KYC tier: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrMerchantNotFound
+	}
+
+	return nil
+}
+
+func (r *LimitsRepositoryImpl) CreateProfile(ctx context.Context, profile *entity.Profile) error {
+	query := `
+		INSERT INTO public.limit_profiles (` + profileColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		profile.ID, profile.Name, nullString(profile.Description), string(profile.Direction), profile.Currency,
+		nullString(profile.MerchantStatus), profile.KYCTier, profile.MerchantID, nullString(string(profile.Medium)),
+		profile.APIKeyID, profile.MinAmount, profile.MaxAmount, profile.DailyVolume, profile.MonthlyVolume,
+		profile.DailyCount, profile.Active, profile.CreatedBy, profile.UpdatedBy, profile.CreatedAt, profile.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create limit profile: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LimitsRepositoryImpl) UpdateProfile(ctx context.Context, profile *entity.Profile) error {
+	query := `
+		UPDATE public.limit_profiles
+		SET name = $2,
+			description = $3,
+			direction = $4,
+			currency = $5,
+			merchant_status = $6,
+			kyc_tier = $7,
+			merchant_id = $8,
+			medium = $9,
+			api_key_id = $10,
+			min_amount = $11,
+			max_amount = $12,
+			daily_volume = $13,
+			monthly_volume = $14,
+			daily_count = $15,
+			active = $16,
+			updated_by = $17,
+			updated_at = $18
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		profile.ID, profile.Name, nullString(profile.Description), string(profile.Direction), profile.Currency,
+		nullString(profile.MerchantStatus), profile.KYCTier, profile.MerchantID, nullString(string(profile.Medium)),
+		profile.APIKeyID, profile.MinAmount, profile.MaxAmount, profile.DailyVolume, profile.MonthlyVolume,
+		profile.DailyCount, profile.Active, profile.UpdatedBy, profile.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update limit profile: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update limit profile: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *LimitsRepositoryImpl) GetProfile(ctx context.Context, id uuid.UUID) (*entity.Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM public.limit_profiles WHERE id = $1`
+
+	profile, err := scanProfile(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get limit profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (r *LimitsRepositoryImpl) ListProfiles(ctx context.Context, activeOnly bool) ([]entity.Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM public.limit_profiles
+		WHERE ($1 = false OR active)
+		ORDER BY direction, name`
+
+	rows, err := r.db.QueryContext(ctx, query, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []entity.Profile
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan limit profile: %w", err)
+		}
+		profiles = append(profiles, *profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list limit profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+func (r *LimitsRepositoryImpl) DeleteProfile(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM public.limit_profiles WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete limit profile: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete limit profile: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *LimitsRepositoryImpl) CreateIncrease(ctx context.Context, increase *entity.Increase) error {
+	query := `
+		INSERT INTO public.limit_increases (` + increaseColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		increase.ID, increase.MerchantID, string(increase.Direction), increase.MaxAmount, increase.DailyVolume,
+		increase.MonthlyVolume, increase.DailyCount, increase.Reason, increase.ExpiresAt, increase.GrantedBy,
+		increase.RevokedBy, increase.RevokedAt, increase.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create limit increase: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LimitsRepositoryImpl) ListIncreases(ctx context.Context, merchantID uuid.UUID, now time.Time) ([]entity.Increase, error) {
+	query := `SELECT ` + increaseColumns + ` FROM public.limit_increases
+		WHERE merchant_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY expires_at`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit increases: %w", err)
+	}
+	defer rows.Close()
+
+	var increases []entity.Increase
+	for rows.Next() {
+		increase, err := scanIncrease(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan limit increase: %w", err)
+		}
+		increases = append(increases, *increase)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list limit increases: %w", err)
+	}
+
+	return increases, nil
+}
+
+func (r *LimitsRepositoryImpl) RevokeIncrease(ctx context.Context, id, adminID uuid.UUID, now time.Time) error {
+	query := `
+		UPDATE public.limit_increases
+		SET revoked_by = $2, revoked_at = $3
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > $3`
+
+	result, err := r.db.ExecContext(ctx, query, id, adminID, now)
+	if err != nil {
+		return fmt.Errorf("failed to revoke limit increase: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke limit increase: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrIncreaseNotFound
+	}
+
+	return nil
+}
+
+func (r *LimitsRepositoryImpl) GetUsage(ctx context.Context, merchantID uuid.UUID, profile *entity.Profile, test bool, now time.Time) (entity.Usage, error) {
+	return usage(ctx, r.db, merchantID, profile, test, now)
+}
+
+func (r *LimitsRepositoryImpl) Reserve(ctx context.Context, transactionID uuid.UUID, subject entity.Subject, profiles []entity.AppliedProfile, now time.Time) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Held until commit, so the usage read below cannot change under another reservation of the merchant
+	if _, err := dbTx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "limits:"+subject.Merchant.ID.String()); err != nil {
+		return fmt.Errorf("failed to lock merchant limits: %w", err)
+	}
+
+	for i := range profiles {
+		used, err := usage(ctx, dbTx, subject.Merchant.ID, &profiles[i].Profile, subject.Test, now)
+		if err != nil {
+			return err
+		}
+		if exceeded := profiles[i].Check(subject.Amount, used, now); exceeded != nil {
+			return exceeded
+		}
+	}
+
+	query := `
+		INSERT INTO public.limit_usage (transaction_id, merchant_id, api_key_id, direction, currency, medium, amount, test, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (transaction_id) DO NOTHING`
+
+	if _, err := dbTx.ExecContext(ctx, query,
+		transactionID, subject.Merchant.ID, subject.APIKeyID, string(subject.Direction), subject.Currency,
+		string(subject.Medium), subject.Amount, subject.Test, now,
+	); err != nil {
+		return fmt.Errorf("failed to record limit usage: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit limit usage: %w", err)
+	}
+
+	return nil
+}
+
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// usage sums the transactions in a profile's scope that have not failed
+func usage(ctx context.Context, q querier, merchantID uuid.UUID, profile *entity.Profile, test bool, now time.Time) (entity.Usage, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(u.amount) FILTER (WHERE u.created_at >= $5), 0),
+			COUNT(*) FILTER (WHERE u.created_at >= $5),
+			COALESCE(SUM(u.amount), 0)
+		FROM public.limit_usage u
+		JOIN public.transactions t ON t.id = u.transaction_id
+		WHERE u.merchant_id = $1 AND u.direction = $2 AND u.currency = $3 AND u.test = $4
+			AND u.created_at >= $6
+			AND ($7 = '' OR u.medium = $7)
+			AND ($8::uuid IS NULL OR u.api_key_id = $8)
+			AND t.status NOT IN ('FAILED', 'CANCELED', 'EXPIRED')`
+
+	var used entity.Usage
+	err := q.QueryRowContext(ctx, query,
+		merchantID, string(profile.Direction), profile.Currency, test,
+		entity.DayStart(now), entity.MonthStart(now), string(profile.Medium), profile.APIKeyID,
+	).Scan(&used.DailyVolume, &used.DailyCount, &used.MonthlyVolume)
+	if err != nil {
+		return entity.Usage{}, fmt.Errorf("failed to get limit usage: %w", err)
+	}
+
+	return used, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProfile(row rowScanner) (*entity.Profile, error) {
+	var profile entity.Profile
+	var direction string
+	var description, merchantStatus, medium sql.NullString
+	var kycTier, dailyCount sql.NullInt64
+	var merchantID, apiKeyID, createdBy, updatedBy uuid.NullUUID
+	var minAmount, maxAmount, dailyVolume, monthlyVolume sql.NullFloat64
+
+	err := row.Scan(
+		&profile.ID,
+		&profile.Name,
+		&description,
+		&direction,
+		&profile.Currency,
+		&merchantStatus,
+		&kycTier,
+		&merchantID,
+		&medium,
+		&apiKeyID,
+		&minAmount,
+		&maxAmount,
+		&dailyVolume,
+		&monthlyVolume,
+		&dailyCount,
+		&profile.Active,
+		&createdBy,
+		&updatedBy,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Description = description.String
+	profile.Direction = entity.Direction(direction)
+	profile.MerchantStatus = merchantStatus.String
+	profile.KYCTier = nullInt(kycTier)
+	profile.MerchantID = nullUUID(merchantID)
+	profile.Medium = txEntity.TransactionMedium(medium.String)
+	profile.APIKeyID = nullUUID(apiKeyID)
+	profile.MinAmount = nullFloat(minAmount)
+	profile.MaxAmount = nullFloat(maxAmount)
+	profile.DailyVolume = nullFloat(dailyVolume)
+	profile.MonthlyVolume = nullFloat(monthlyVolume)
+	profile.DailyCount = nullInt(dailyCount)
+	profile.CreatedBy = nullUUID(createdBy)
+	profile.UpdatedBy = nullUUID(updatedBy)
+
+	return &profile, nil
+}
+
+func scanIncrease(row rowScanner) (*entity.Increase, error) {
+	var increase entity.Increase
+	var direction string
+	var dailyCount sql.NullInt64
+	var maxAmount, dailyVolume, monthlyVolume sql.NullFloat64
+	var revokedBy uuid.NullUUID
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&increase.ID,
+		&increase.MerchantID,
+		&direction,
+		&maxAmount,
+		&dailyVolume,
+		&monthlyVolume,
+		&dailyCount,
+		&increase.Reason,
+		&increase.ExpiresAt,
+		&increase.GrantedBy,
+		&revokedBy,
+		&revokedAt,
+		&increase.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	increase.Direction = entity.Direction(direction)
+	increase.MaxAmount = nullFloat(maxAmount)
+	increase.DailyVolume = nullFloat(dailyVolume)
+	increase.MonthlyVolume = nullFloat(monthlyVolume)
+	increase.DailyCount = nullInt(dailyCount)
+	increase.RevokedBy = nullUUID(revokedBy)
+	if revokedAt.Valid {
+		increase.RevokedAt = &revokedAt.Time
+	}
+
+	return &increase, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullFloat(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
+
+func nullInt(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	v := int(value.Int64)
+	return &v
+}
+
+func nullUUID(value uuid.NullUUID) *uuid.UUID {
+	if !value.Valid {
+		return nil
+	}
+	return &value.UUID
+}
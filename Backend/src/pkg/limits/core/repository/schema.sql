@@ -0,0 +1,72 @@
+-- Limits Schema
+-- This should match the migration exactly
+
+-- KYC tier of the merchant, raised as its verification progresses
+ALTER TABLE merchants.merchants ADD COLUMN IF NOT EXISTS kyc_tier INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS public.limit_profiles (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    direction VARCHAR(20) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'ETB',
+    -- Scope; NULL matches any
+    merchant_status VARCHAR(50),
+    kyc_tier INT,
+    merchant_id UUID REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    medium VARCHAR(50),
+    api_key_id UUID REFERENCES public.api_keys(id) ON DELETE CASCADE,
+    -- Limits; NULL leaves a limit unset
+    min_amount DECIMAL(20,2),
+    max_amount DECIMAL(20,2),
+    daily_volume DECIMAL(20,2),
+    monthly_volume DECIMAL(20,2),
+    daily_count INT,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID,
+    updated_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_limit_profiles_active ON public.limit_profiles(active);
+
+CREATE TABLE IF NOT EXISTS public.limit_increases (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    direction VARCHAR(20) NOT NULL,
+    max_amount DECIMAL(20,2),
+    daily_volume DECIMAL(20,2),
+    monthly_volume DECIMAL(20,2),
+    daily_count INT,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    granted_by UUID NOT NULL,
+    revoked_by UUID,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_limit_increases_merchant ON public.limit_increases(merchant_id, expires_at);
+
+-- Every limited transaction, counted against the limits while it has not failed
+CREATE TABLE IF NOT EXISTS public.limit_usage (
+    transaction_id UUID PRIMARY KEY REFERENCES public.transactions(id) ON DELETE CASCADE,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    api_key_id UUID,
+    direction VARCHAR(20) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    medium VARCHAR(50) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    test BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_limit_usage_merchant ON public.limit_usage(merchant_id, direction, currency, test, created_at);
+
+-- Merchants awaiting verification can only move small amounts
+INSERT INTO public.limit_profiles (id, name, description, direction, currency, merchant_status, max_amount, daily_volume, monthly_volume, daily_count)
+VALUES
+    (gen_random_uuid(), 'Unverified merchant collections', 'Collections before KYC is complete', 'COLLECTION', 'ETB', 'pending_verification', 10000, 50000, 200000, NULL),
+    (gen_random_uuid(), 'Unverified merchant withdrawals', 'Withdrawals before KYC is complete', 'WITHDRAWAL', 'ETB', 'pending_verification', 10000, 20000, 100000, 3)
+ON CONFLICT (name) DO NOTHING;
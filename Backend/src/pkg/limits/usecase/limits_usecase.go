@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	apikeyEntity "github.com/socialpay/socialpay/src/pkg/apikey_mgmt/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/limits/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// LimitsUseCase defines the interface for transaction limits
+type LimitsUseCase interface {
+	// Enforce checks a stored transaction against the limits of its merchant and counts it in their
+	// usage. It returns a *entity.LimitExceededError when the transaction would exceed a limit.
+	Enforce(ctx context.Context, tx *txEntity.Transaction) error
+
+	// GetUtilization retrieves the limits of a merchant and how much of them it used
+	GetUtilization(ctx context.Context, merchantID uuid.UUID) (*entity.UtilizationResponse, error)
+
+	// CreateProfile creates a limit profile
+	CreateProfile(ctx context.Context, req *entity.ProfileRequest, adminID uuid.UUID) (*entity.Profile, error)
+
+	// UpdateProfile replaces a limit profile
+	UpdateProfile(ctx context.Context, id uuid.UUID, req *entity.ProfileRequest, adminID uuid.UUID) (*entity.Profile, error)
+
+	// GetProfile retrieves a limit profile
+	GetProfile(ctx context.Context, id uuid.UUID) (*entity.Profile, error)
+
+	// ListProfiles retrieves every limit profile
+	ListProfiles(ctx context.Context) (*entity.ProfileListResponse, error)
+
+	// DeleteProfile deletes a limit profile
+	DeleteProfile(ctx context.Context, id, adminID uuid.UUID) error
+
+	// SetKYCTier sets the KYC tier the limits of a merchant are selected by
+	SetKYCTier(ctx context.Context, merchantID uuid.UUID, tier int, adminID uuid.UUID) error
+
+	// GrantIncrease temporarily raises the limits of a merchant
+	GrantIncrease(ctx context.Context, merchantID uuid.UUID, req *entity.IncreaseRequest, adminID uuid.UUID) (*entity.Increase, error)
+
+	// ListIncreases retrieves the active limit increases of a merchant
+	ListIncreases(ctx context.Context, merchantID uuid.UUID) (*entity.IncreaseListResponse, error)
+
+	// RevokeIncrease ends a limit increase before it expires
+	RevokeIncrease(ctx context.Context, id, adminID uuid.UUID) error
+}
+
+type limitsUseCase struct {
+	repo repository.LimitsRepository
+	log  logging.Logger
+}
+
+// NewLimitsUseCase creates the limits usecase
+func NewLimitsUseCase(repo repository.LimitsRepository) LimitsUseCase {
+	return &limitsUseCase{
+		repo: repo,
+		log:  logging.NewStdLogger("limits_usecase"),
+	}
+}
+
+func (uc *limitsUseCase) Enforce(ctx context.Context, tx *txEntity.Transaction) error {
+	direction, ok := entity.DirectionOf(tx.Type)
+	if !ok {
+		return nil
+	}
+
+	merchant, err := uc.repo.GetMerchant(ctx, tx.MerchantId)
+	if err != nil {
+		return err
+	}
+	if merchant == nil {
+		return entity.ErrMerchantNotFound
+	}
+
+	subject := entity.Subject{
+		Merchant:  *merchant,
+		Direction: direction,
+		Currency:  tx.WalletCurrency(),
+		Medium:    tx.Medium,
+		Amount:    tx.BaseAmount,
+		Test:      tx.Test,
+	}
+	if keyID, ok := apikeyEntity.KeyIDFromContext(ctx); ok {
+		subject.APIKeyID = &keyID
+	}
+
+	profiles, err := uc.repo.ListProfiles(ctx, true)
+	if err != nil {
+		return err
+	}
+	applicable := entity.Applicable(profiles, subject)
+	if len(applicable) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	increases, err := uc.repo.ListIncreases(ctx, merchant.ID, now)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Reserve(ctx, tx.Id, subject, entity.Apply(applicable, increases, now), now); err != nil {
+		uc.log.Warn("Transaction refused by limits", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"merchant_id":    merchant.ID,
+			"direction":      direction,
+			"amount":         subject.Amount,
+			"error":          err.Error(),
+		})
+		return err
+	}
+
+	return nil
+}
+
+func (uc *limitsUseCase) GetUtilization(ctx context.Context, merchantID uuid.UUID) (*entity.UtilizationResponse, error) {
+	merchant, err := uc.repo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if merchant == nil {
+		return nil, entity.ErrMerchantNotFound
+	}
+
+	profiles, err := uc.repo.ListProfiles(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	increases, err := uc.repo.ListIncreases(ctx, merchantID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &entity.UtilizationResponse{
+		MerchantStatus: merchant.Status,
+		KYCTier:        merchant.KYCTier,
+		Profiles:       []entity.ProfileUtilization{},
+	}
+	for _, applied := range entity.Apply(entity.ForMerchant(profiles, *merchant), increases, now) {
+		// Live usage; test transactions have limits of their own but are not shown
+		usage, err := uc.repo.GetUsage(ctx, merchantID, &applied.Profile, false, now)
+		if err != nil {
+			return nil, err
+		}
+		response.Profiles = append(response.Profiles, applied.Utilization(usage, now))
+	}
+
+	return response, nil
+}
+
+func (uc *limitsUseCase) CreateProfile(ctx context.Context, req *entity.ProfileRequest, adminID uuid.UUID) (*entity.Profile, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	now := time.Now()
+	profile := &entity.Profile{
+		ID:        uuid.New(),
+		Active:    true,
+		CreatedBy: &adminID,
+		UpdatedBy: &adminID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	applyProfileRequest(profile, req)
+
+	if err := uc.repo.CreateProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Limit profile created", map[string]interface{}{
+		"profile_id": profile.ID,
+		"name":       profile.Name,
+		"direction":  profile.Direction,
+		"created_by": adminID,
+	})
+
+	return profile, nil
+}
+
+func (uc *limitsUseCase) UpdateProfile(ctx context.Context, id uuid.UUID, req *entity.ProfileRequest, adminID uuid.UUID) (*entity.Profile, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	profile, err := uc.GetProfile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	applyProfileRequest(profile, req)
+	profile.UpdatedBy = &adminID
+	profile.UpdatedAt = time.Now()
+
+	if err := uc.repo.UpdateProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Limit profile updated", map[string]interface{}{
+		"profile_id": profile.ID,
+		"name":       profile.Name,
+		"active":     profile.Active,
+		"updated_by": adminID,
+	})
+
+	return profile, nil
+}
+
+func applyProfileRequest(profile *entity.Profile, req *entity.ProfileRequest) {
+	profile.Name = req.Name
+	profile.Description = req.Description
+	profile.Direction = req.Direction
+	profile.Currency = req.Currency
+	profile.Scope = req.Scope
+	profile.Values = req.Values
+	if req.Active != nil {
+		profile.Active = *req.Active
+	}
+}
+
+func (uc *limitsUseCase) GetProfile(ctx context.Context, id uuid.UUID) (*entity.Profile, error) {
+	profile, err := uc.repo.GetProfile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, entity.ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+func (uc *limitsUseCase) ListProfiles(ctx context.Context) (*entity.ProfileListResponse, error) {
+	profiles, err := uc.repo.ListProfiles(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if profiles == nil {
+		profiles = []entity.Profile{}
+	}
+	return &entity.ProfileListResponse{Profiles: profiles}, nil
+}
+
+func (uc *limitsUseCase) DeleteProfile(ctx context.Context, id, adminID uuid.UUID) error {
+	if err := uc.repo.DeleteProfile(ctx, id); err != nil {
+		return err
+	}
+
+	uc.log.Info("Limit profile deleted", map[string]interface{}{
+		"profile_id": id,
+		"deleted_by": adminID,
+	})
+	return nil
+}
+
+func (uc *limitsUseCase) SetKYCTier(ctx context.Context, merchantID uuid.UUID, tier int, adminID uuid.UUID) error {
+	if tier < 0 || tier > entity.MaxKYCTier {
+		return fmt.Errorf("validation failed: kyc_tier must be between 0 and %d", entity.MaxKYCTier)
+	}
+	if err := uc.repo.SetKYCTier(ctx, merchantID, tier); err != nil {
+		return err
+	}
+
+	uc.log.Info("Merchant KYC tier set", map[string]interface{}{
+		"merchant_id": merchantID,
+		"kyc_tier":    tier,
+		"set_by":      adminID,
+	})
+	return nil
+}
+
+func (uc *limitsUseCase) GrantIncrease(ctx context.Context, merchantID uuid.UUID, req *entity.IncreaseRequest, adminID uuid.UUID) (*entity.Increase, error) {
+	now := time.Now()
+	if err := req.Validate(now); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	merchant, err := uc.repo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if merchant == nil {
+		return nil, entity.ErrMerchantNotFound
+	}
+
+	increase := &entity.Increase{
+		ID:         uuid.New(),
+		MerchantID: merchantID,
+		Direction:  req.Direction,
+		Values:     req.Values,
+		Reason:     req.Reason,
+		ExpiresAt:  req.ExpiresAt,
+		GrantedBy:  adminID,
+		CreatedAt:  now,
+	}
+	if err := uc.repo.CreateIncrease(ctx, increase); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Limit increase granted", map[string]interface{}{
+		"increase_id": increase.ID,
+		"merchant_id": merchantID,
+		"direction":   increase.Direction,
+		"expires_at":  increase.ExpiresAt,
+		"reason":      increase.Reason,
+		"granted_by":  adminID,
+	})
+
+	return increase, nil
+}
+
+func (uc *limitsUseCase) ListIncreases(ctx context.Context, merchantID uuid.UUID) (*entity.IncreaseListResponse, error) {
+	increases, err := uc.repo.ListIncreases(ctx, merchantID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if increases == nil {
+		increases = []entity.Increase{}
+	}
+	return &entity.IncreaseListResponse{Increases: increases}, nil
+}
+
+func (uc *limitsUseCase) RevokeIncrease(ctx context.Context, id, adminID uuid.UUID) error {
+	if err := uc.repo.RevokeIncrease(ctx, id, adminID, time.Now()); err != nil {
+		return err
+	}
+
+	uc.log.Info("Limit increase revoked", map[string]interface{}{
+		"increase_id": id,
+		"revoked_by":  adminID,
+	})
+	return nil
+}
@@ -79,4 +79,5 @@ type TransactionNotificationData struct {
 	TransactionType string  `json:"transaction_type"`
 	TipAmount       float64 `json:"tip_amount"`
 	PhoneNumber     string  `json:"phone_number"`
+	Locale          string  `json:"locale,omitempty"`
 }
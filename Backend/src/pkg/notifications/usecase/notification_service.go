@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/socialpay/socialpay/src/pkg/notifications/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/i18n"
 )
 
 // NotificationServiceImpl implements the NotificationService interface
@@ -133,7 +134,7 @@ func (ns *NotificationServiceImpl) GetNotificationStatus(ctx context.Context, no
 	}
 }
 
-// buildTransactionSMSMessage creates an SMS message for transaction notifications
+// buildTransactionSMSMessage creates an SMS message for transaction notifications in the locale of the transaction data
 func (ns *NotificationServiceImpl) buildTransactionSMSMessage(recipient NotificationRecipient, data entity.TransactionNotificationData) string {
 	// Generate current timestamp in East Africa Time (UTC+3) - equivalent to Addis Ababa
 	// Using fixed offset instead of timezone name for better compatibility
@@ -142,104 +143,50 @@ func (ns *NotificationServiceImpl) buildTransactionSMSMessage(recipient Notifica
 	dateStr := now.Format("02 Jan 2006")
 	timeStr := now.Format("3:04 PM")
 
+	locale := i18n.Resolve(data.Locale)
+
 	if data.MerchantName == "" {
-		data.MerchantName = "merchant"
+		data.MerchantName = i18n.T(locale, "sms.merchant_fallback")
 	}
 
 	var message string
 
 	switch strings.ToLower(recipient.Role) {
 	case "payer", "customer":
+		key := "sms.payer.failed"
 		if data.Status == "SUCCESS" {
-			message = fmt.Sprintf(
-				`Dear %s,
-Your payment of %.2f %s to %s has been successfully processed.
-Reference: %s
-txnid: %s
-Date: %s at %s
-
-📞6562
-አሸናፊዎች በላኪፔይ ይከፍላሉ!!`,
-				recipient.Name,
-				data.Amount,
-				data.Currency,
-				data.MerchantName,
-				data.Reference,
-				data.TransactionID,
-				dateStr,
-				timeStr,
-			)
-		} else {
-			message = fmt.Sprintf(
-				`Dear %s,
-Your payment of %.2f %s to %s has failed.
-Reference: %s
-txnid: %s
-Date: %s at %s
-
-Please try again or contact support.`,
-				recipient.Name,
-				data.Amount,
-				data.Currency,
-				data.MerchantName,
-				data.Reference,
-				data.TransactionID,
-				dateStr,
-				timeStr,
-			)
+			key = "sms.payer.success"
 		}
+		message = i18n.T(locale, key,
+			recipient.Name,
+			data.Amount,
+			data.Currency,
+			data.MerchantName,
+			data.Reference,
+			data.TransactionID,
+			dateStr,
+			timeStr,
+		)
 
 	case "merchant_sender":
+		key := "sms.merchant_sender.failed"
 		if data.Status == "SUCCESS" {
-			message = fmt.Sprintf(
-				`Dear %s,
-Withdrawal of %.2f %s to %s has been successfully processed.
-Reference: %s
-txnid: %s
-Date: %s at %s
-
-SocialPay - Your trusted payment partner!`,
-				recipient.Name,
-				data.Amount,
-				data.Currency,
-				data.PhoneNumber,
-				data.Reference,
-				data.TransactionID,
-				dateStr,
-				timeStr,
-			)
-		} else {
-			message = fmt.Sprintf(
-				`Dear %s,
-Withdrawal of %.2f %s to %s has failed.
-Reference: %s
-txnid: %s
-Date: %s at %s.
-
-SocialPay - Your trusted payment partner!
-`,
-				recipient.Name,
-				data.Amount,
-				data.Currency,
-				data.PhoneNumber,
-				data.Reference,
-				data.TransactionID,
-				dateStr,
-				timeStr,
-			)
+			key = "sms.merchant_sender.success"
 		}
+		message = i18n.T(locale, key,
+			recipient.Name,
+			data.Amount,
+			data.Currency,
+			data.PhoneNumber,
+			data.Reference,
+			data.TransactionID,
+			dateStr,
+			timeStr,
+		)
 
 	case "merchant_recipient":
 		if data.Status == "SUCCESS" {
-			message = fmt.Sprintf(
-				`Dear %s,
-You have recieved payment of %.2f %s from %s.
-Reference: %s
-txnid: %s
-Date: %s at %s.
-
-SocialPay - Your trusted payment partner!
-`,
+			message = i18n.T(locale, "sms.merchant_recipient.success",
 				recipient.Name,
 				data.Amount,
 				data.Currency,
@@ -254,13 +201,7 @@ SocialPay - Your trusted payment partner!
 		}
 	case "tipee":
 		if data.Status == "SUCCESS" {
-			message = fmt.Sprintf(
-				`Dear %s,
-You received a tip of %.2f %s.
-Reference: %s
-Date: %s at %s
-
-Thank you for your service!`,
+			message = i18n.T(locale, "sms.tipee.success",
 				recipient.Name,
 				data.TipAmount,
 				data.Currency,
@@ -271,11 +212,7 @@ Thank you for your service!`,
 		}
 
 	default:
-		message = fmt.Sprintf(
-			`Transaction %s: %.2f %s
-Status: %s
-Reference: %s
-Date: %s at %s`,
+		message = i18n.T(locale, "sms.default",
 			data.TransactionType,
 			data.Amount,
 			data.Currency,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/socialpay/socialpay/src/pkg/notifications/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/i18n"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 	v2MerchantRepo "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/repository"
@@ -45,6 +46,9 @@ func (tn *TransactionNotifier) NotifyTransactionStatus(ctx context.Context, tran
 		tipAmount = *transaction.TipAmount
 	}
 
+	// Notifications follow the merchant's checkout language
+	locale := tn.merchantLocale(ctx, transaction.MerchantId)
+
 	var recipients []NotificationRecipient
 	transactionData := entity.TransactionNotificationData{
 		TransactionID:   transaction.Id.String(),
@@ -56,12 +60,14 @@ func (tn *TransactionNotifier) NotifyTransactionStatus(ctx context.Context, tran
 		TransactionType: string(transaction.Type),
 		TipAmount:       tipAmount,
 		PhoneNumber:     transaction.PhoneNumber,
+		Locale:          string(locale),
 	}
 
 	// For customers/payers: only use phone number from transaction without fetching user data
 	if transaction.PhoneNumber != "" {
 		// We don't have customer name, so we'll use "Customer" as a generic name
-		transactionData.CustomerName = "Customer"
+		customerName := i18n.T(locale, "sms.customer_name")
+		transactionData.CustomerName = customerName
 		role := "payer"
 		if transaction.Type == txEntity.WITHDRAWAL {
 			role = "recipient"
@@ -69,7 +75,7 @@ func (tn *TransactionNotifier) NotifyTransactionStatus(ctx context.Context, tran
 		recipients = append(recipients, NotificationRecipient{
 			Type:       entity.TypeSMS,
 			Identifier: transaction.PhoneNumber,
-			Name:       "Customer", // Generic name since we don't fetch customer data
+			Name:       customerName, // Generic name since we don't fetch customer data
 			Role:       role,
 		})
 		tn.log.Info("[TransactionNotifier] Added payer", map[string]interface{}{
@@ -79,38 +85,15 @@ func (tn *TransactionNotifier) NotifyTransactionStatus(ctx context.Context, tran
 
 	// For merchants: fetch merchant information if transaction has merchant ID
 	if transaction.MerchantId != uuid.Nil {
-		merchant, err := tn.merchantRepo.GetMerchantDetails(ctx, transaction.MerchantId)
+		merchantName, merchantPhone, err := tn.merchantContact(ctx, transaction.MerchantId)
 		if err != nil {
 			tn.log.Error("[TransactionNotifier] Failed to get merchant details", map[string]interface{}{
 				"error": err,
 			})
 			// Continue without merchant notification rather than failing
-		} else if merchant != nil {
-			// Access fields from the nested Merchant struct
-			tn.log.Info("[TransactionNotifier] Merchant details", map[string]interface{}{
-				"merchant": merchant,
-			})
-			merchantName := ""
-			if merchant.Merchant.TradingName != nil && *merchant.Merchant.TradingName != "" {
-				merchantName = *merchant.Merchant.TradingName
-			} else {
-				merchantName = merchant.Merchant.LegalName
-			}
+		} else {
 			transactionData.MerchantName = merchantName
 
-			// Get merchant phone number from contacts
-			var merchantPhone string
-			for _, contact := range merchant.Contacts {
-				if contact.ContactType == "primary" || contact.ContactType == "business" {
-					merchantPhone = contact.PhoneNumber
-					break
-				}
-			}
-			// If no primary contact, use the first available contact
-			if merchantPhone == "" && len(merchant.Contacts) > 0 {
-				merchantPhone = merchant.Contacts[0].PhoneNumber
-			}
-
 			role := "merchant_recipient"
 			if transaction.Type == txEntity.WITHDRAWAL {
 				role = "merchant_sender"
@@ -139,7 +122,7 @@ func (tn *TransactionNotifier) NotifyTransactionStatus(ctx context.Context, tran
 		recipients = append(recipients, NotificationRecipient{
 			Type:       entity.TypeSMS,
 			Identifier: *transaction.TipeePhone,
-			Name:       "Tipee", // We don't have tipee name in transaction
+			Name:       i18n.T(locale, "sms.tipee_name"), // We don't have tipee name in transaction
 			Role:       "tipee",
 		})
 		tn.log.Info("[TransactionNotifier] Added tipee", map[string]interface{}{
@@ -176,6 +159,118 @@ func (tn *TransactionNotifier) NotifyTransactionStatus(ctx context.Context, tran
 	return nil
 }
 
+// merchantLocale resolves the notification locale from the merchant's default language
+func (tn *TransactionNotifier) merchantLocale(ctx context.Context, merchantID uuid.UUID) i18n.Locale {
+	if merchantID == uuid.Nil {
+		return i18n.DefaultLocale
+	}
+
+	settings, err := tn.merchantRepo.GetMerchantSettings(ctx, merchantID)
+	if err != nil || settings == nil {
+		return i18n.DefaultLocale
+	}
+
+	return i18n.Resolve(settings.DefaultLanguage)
+}
+
+// merchantContact returns the merchant's display name and the phone number of its primary contact,
+// empty when the merchant has no contacts
+func (tn *TransactionNotifier) merchantContact(ctx context.Context, merchantID uuid.UUID) (string, string, error) {
+	merchant, err := tn.merchantRepo.GetMerchantDetails(ctx, merchantID)
+	if err != nil {
+		return "", "", err
+	}
+	if merchant == nil {
+		return "", "", fmt.Errorf("merchant not found")
+	}
+
+	merchantName := merchant.Merchant.LegalName
+	if merchant.Merchant.TradingName != nil && *merchant.Merchant.TradingName != "" {
+		merchantName = *merchant.Merchant.TradingName
+	}
+
+	var merchantPhone string
+	for _, contact := range merchant.Contacts {
+		if contact.ContactType == "primary" || contact.ContactType == "business" {
+			merchantPhone = contact.PhoneNumber
+			break
+		}
+	}
+	// If no primary contact, use the first available contact
+	if merchantPhone == "" && len(merchant.Contacts) > 0 {
+		merchantPhone = merchant.Contacts[0].PhoneNumber
+	}
+
+	return merchantName, merchantPhone, nil
+}
+
+// NotifyWithdrawalApproval tells the merchant that a withdrawal was held for approval or how it was decided.
+// Status is the approval status: PENDING, APPROVED, REJECTED or EXPIRED.
+func (tn *TransactionNotifier) NotifyWithdrawalApproval(ctx context.Context, transaction *txEntity.Transaction, status string) error {
+	merchantName, merchantPhone, err := tn.merchantContact(ctx, transaction.MerchantId)
+	if err != nil {
+		return fmt.Errorf("failed to get merchant details: %w", err)
+	}
+	if merchantPhone == "" {
+		tn.log.Info("[TransactionNotifier] Merchant has no phone number", map[string]interface{}{
+			"merchantName": merchantName,
+		})
+		return nil
+	}
+
+	now := time.Now().In(time.FixedZone("EAT", 3*60*60))
+	message := i18n.T(tn.merchantLocale(ctx, transaction.MerchantId), "sms.withdrawal_approval."+status,
+		merchantName,
+		transaction.BaseAmount,
+		transaction.WalletCurrency(),
+		transaction.PhoneNumber,
+		transaction.Reference,
+		transaction.Id.String(),
+		now.Format("02 Jan 2006"),
+		now.Format("3:04 PM"),
+	)
+
+	return tn.notificationService.SendSMS(ctx, merchantPhone, message)
+}
+
+// NotifyKYC tells the merchant how its KYC case progressed. Step is SUBMITTED, DOCUMENT_REJECTED,
+// TIN_FAILED, APPROVED or REJECTED; detail is the due date, reason or decision date it reports.
+func (tn *TransactionNotifier) NotifyKYC(ctx context.Context, merchantID uuid.UUID, step, detail string) error {
+	merchantName, merchantPhone, err := tn.merchantContact(ctx, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to get merchant details: %w", err)
+	}
+	if merchantPhone == "" {
+		tn.log.Info("[TransactionNotifier] Merchant has no phone number", map[string]interface{}{
+			"merchantName": merchantName,
+		})
+		return nil
+	}
+
+	message := i18n.T(tn.merchantLocale(ctx, merchantID), "sms.kyc."+step, merchantName, detail)
+
+	return tn.notificationService.SendSMS(ctx, merchantPhone, message)
+}
+
+// NotifyDispute tells the merchant how a dispute on one of its payments progressed. Status is the
+// dispute status; detail is the response deadline, review or decision date, or the reason it was lost.
+func (tn *TransactionNotifier) NotifyDispute(ctx context.Context, merchantID uuid.UUID, status string, amount float64, currency, reference, detail string) error {
+	merchantName, merchantPhone, err := tn.merchantContact(ctx, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to get merchant details: %w", err)
+	}
+	if merchantPhone == "" {
+		tn.log.Info("[TransactionNotifier] Merchant has no phone number", map[string]interface{}{
+			"merchantName": merchantName,
+		})
+		return nil
+	}
+
+	message := i18n.T(tn.merchantLocale(ctx, merchantID), "sms.dispute."+status, merchantName, reference, amount, currency, detail)
+
+	return tn.notificationService.SendSMS(ctx, merchantPhone, message)
+}
+
 // NotifyTransactionStatusByPhone sends a simple notification to a specific phone number
 func (tn *TransactionNotifier) NotifyTransactionStatusByPhone(ctx context.Context, phoneNumber, customerName, merchantName string, amount float64, currency, status, reference string) error {
 	tn.log.Info("[TransactionNotifier] Sending notification to phone", map[string]interface{}{
@@ -0,0 +1,367 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/payment_link/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/payment_link/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/payment_link/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	"github.com/socialpay/socialpay/src/pkg/shared/pagination"
+)
+
+type Handler struct {
+	paymentLinkUseCase usecase.PaymentLinkUseCase
+	log                logging.Logger
+	jwtMiddleware      gin.HandlerFunc
+	rbac               *ginMiddleware.RBACV2
+}
+
+func NewHandler(paymentLinkUseCase usecase.PaymentLinkUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		paymentLinkUseCase: paymentLinkUseCase,
+		log:                logging.NewStdLogger("payment_link_handler"),
+		jwtMiddleware:      jwtMiddleware,
+		rbac:               rbac,
+	}
+}
+
+// RegisterRouter sets up the payment link management routes (JWT + RBAC) and the public short URL routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	mgmt := router.Group("/payment_link_mgmt", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	mgmt.POST("/links",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_CREATE),
+		h.CreatePaymentLink)
+	mgmt.GET("/links",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_READ),
+		h.GetPaymentLinks)
+	mgmt.GET("/links/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_READ),
+		h.GetPaymentLink)
+	mgmt.GET("/links/:id/stats",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_READ),
+		h.GetPaymentLinkStats)
+	mgmt.PUT("/links/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_UPDATE),
+		h.UpdatePaymentLink)
+	mgmt.DELETE("/links/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_CHECKOUT, auth_entity.OPERATION_DELETE),
+		h.DeletePaymentLink)
+
+	// Public endpoints behind the short URL
+	public := router.Group("/pay", ginMiddleware.CheckoutCors)
+	public.GET("/:slug", h.GetPublicPaymentLink)
+	public.POST("/:slug/open", h.OpenPaymentLink)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
+
+// CreatePaymentLink godoc
+// @Summary      Create payment link
+// @Description  Create a reusable payment link with a fixed or customer-entered amount
+// @Tags         Payment-Links
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body entity.CreatePaymentLinkRequest true "Payment link details"
+// @Success      201  {object}  entity.PaymentLinkResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /payment_link_mgmt/links [post]
+func (h *Handler) CreatePaymentLink(c *gin.Context) {
+	var req entity.CreatePaymentLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Failed to bind JSON request", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	userID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	response, err := h.paymentLinkUseCase.CreatePaymentLink(c.Request.Context(), userID, merchantID, &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrSlugTaken) {
+			c.JSON(http.StatusConflict, newErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetPaymentLinks godoc
+// @Summary      List payment links
+// @Description  Get the payment links of the authenticated merchant
+// @Tags         Payment-Links
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query  int  false  "Page number"
+// @Param        page_size  query  int  false  "Page size"
+// @Success      200  {object}  entity.PaymentLinksListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /payment_link_mgmt/links [get]
+func (h *Handler) GetPaymentLinks(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	pag, err := pagination.NewPagination(c, h.log)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.paymentLinkUseCase.GetPaymentLinksByMerchant(c.Request.Context(), merchantID, pag)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPaymentLink godoc
+// @Summary      Get payment link
+// @Description  Get a payment link of the authenticated merchant
+// @Tags         Payment-Links
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Payment link ID"
+// @Success      200  {object}  entity.PaymentLinkResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /payment_link_mgmt/links/{id} [get]
+func (h *Handler) GetPaymentLink(c *gin.Context) {
+	id, merchantID, ok := h.linkAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.paymentLinkUseCase.GetPaymentLink(c.Request.Context(), id, merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPaymentLinkStats godoc
+// @Summary      Get payment link statistics
+// @Description  Get opens, payments, amount collected, conversion and remaining quantity of a payment link
+// @Tags         Payment-Links
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Payment link ID"
+// @Success      200  {object}  entity.PaymentLinkStats
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /payment_link_mgmt/links/{id}/stats [get]
+func (h *Handler) GetPaymentLinkStats(c *gin.Context) {
+	id, merchantID, ok := h.linkAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.paymentLinkUseCase.GetPaymentLinkStats(c.Request.Context(), id, merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdatePaymentLink godoc
+// @Summary      Update payment link
+// @Description  Update a payment link of the authenticated merchant
+// @Tags         Payment-Links
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                           true  "Payment link ID"
+// @Param        request  body  entity.UpdatePaymentLinkRequest  true  "Payment link changes"
+// @Success      200  {object}  entity.PaymentLinkResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /payment_link_mgmt/links/{id} [put]
+func (h *Handler) UpdatePaymentLink(c *gin.Context) {
+	id, merchantID, ok := h.linkAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdatePaymentLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.paymentLinkUseCase.UpdatePaymentLink(c.Request.Context(), id, merchantID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeletePaymentLink godoc
+// @Summary      Delete payment link
+// @Description  Delete a payment link of the authenticated merchant
+// @Tags         Payment-Links
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Payment link ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /payment_link_mgmt/links/{id} [delete]
+func (h *Handler) DeletePaymentLink(c *gin.Context) {
+	id, merchantID, ok := h.linkAndMerchant(c)
+	if !ok {
+		return
+	}
+
+	if err := h.paymentLinkUseCase.DeletePaymentLink(c.Request.Context(), id, merchantID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPublicPaymentLink godoc
+// @Summary      Get payment link by slug
+// @Description  Get the public details of a payment link behind a short URL
+// @Tags         Payment-Links
+// @Produce      json
+// @Param        slug  path  string  true  "Payment link slug"
+// @Success      200  {object}  entity.PublicPaymentLinkResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /pay/{slug} [get]
+func (h *Handler) GetPublicPaymentLink(c *gin.Context) {
+	response, err := h.paymentLinkUseCase.GetPublicPaymentLink(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// OpenPaymentLink godoc
+// @Summary      Open payment link
+// @Description  Create a fresh hosted checkout from a payment link and return its payment URL
+// @Tags         Payment-Links
+// @Accept       json
+// @Produce      json
+// @Param        slug     path  string                         true   "Payment link slug"
+// @Param        request  body  entity.OpenPaymentLinkRequest  false  "Customer-entered amount"
+// @Success      201  {object}  entity.OpenPaymentLinkResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      410  {object}  ErrorResponse
+// @Router       /pay/{slug}/open [post]
+func (h *Handler) OpenPaymentLink(c *gin.Context) {
+	var req entity.OpenPaymentLinkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+
+	response, err := h.paymentLinkUseCase.OpenPaymentLink(c.Request.Context(), c.Param("slug"), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) linkAndMerchant(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid payment link ID")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, merchantID, true
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrLinkUnavailable):
+		c.JSON(http.StatusGone, newErrorResponse(err))
+	case errors.Is(err, usecase.ErrInvalidAmount):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	case isNotFound(err):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	default:
+		h.log.Error("Payment link request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
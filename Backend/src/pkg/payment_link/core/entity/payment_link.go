@@ -0,0 +1,269 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// AmountType represents how the amount of a payment link is determined
+type AmountType string
+
+const (
+	FIXED AmountType = "FIXED"
+	OPEN  AmountType = "OPEN"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{4,32}$`)
+
+// PaymentLink represents a reusable, shareable payment link.
+// Every time a customer opens the link a fresh hosted checkout is created.
+type PaymentLink struct {
+	ID               uuid.UUID                  `json:"id" db:"id"`
+	UserID           uuid.UUID                  `json:"user_id" db:"user_id"`
+	MerchantID       uuid.UUID                  `json:"merchant_id" db:"merchant_id"`
+	Slug             string                     `json:"slug" db:"slug"`
+	Title            string                     `json:"title" db:"title"`
+	Description      *string                    `json:"description,omitempty" db:"description"`
+	AmountType       AmountType                 `json:"amount_type" db:"amount_type"`
+	Amount           *float64                   `json:"amount,omitempty" db:"amount"`         // Only for FIXED type
+	MinAmount        *float64                   `json:"min_amount,omitempty" db:"min_amount"` // Only for OPEN type
+	MaxAmount        *float64                   `json:"max_amount,omitempty" db:"max_amount"` // Only for OPEN type
+	Currency         string                     `json:"currency" db:"currency"`
+	SupportedMediums []entity.TransactionMedium `json:"supported_mediums" db:"supported_mediums"`
+	MerchantPaysFee  bool                       `json:"merchant_pays_fee" db:"merchant_pays_fee"`
+	AcceptTip        bool                       `json:"accept_tip" db:"accept_tip"`
+	QuantityLimit    *int                       `json:"quantity_limit,omitempty" db:"quantity_limit"`
+	SuccessURL       *string                    `json:"success_url,omitempty" db:"success_url"`
+	FailedURL        *string                    `json:"failed_url,omitempty" db:"failed_url"`
+	CallbackURL      *string                    `json:"callback_url,omitempty" db:"callback_url"`
+	ExpiresAt        *time.Time                 `json:"expires_at,omitempty" db:"expires_at"`
+	IsActive         bool                       `json:"is_active" db:"is_active"`
+	CreatedAt        time.Time                  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at" db:"updated_at"`
+}
+
+// IsExpired reports whether the link has passed its expiry
+func (l *PaymentLink) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && now.After(*l.ExpiresAt)
+}
+
+// ResolveAmount returns the amount to charge for an open of the link
+func (l *PaymentLink) ResolveAmount(requested *float64) (float64, error) {
+	if l.AmountType == FIXED {
+		if l.Amount == nil {
+			return 0, fmt.Errorf("payment link has no amount")
+		}
+		return *l.Amount, nil
+	}
+
+	if requested == nil || *requested < 0.01 {
+		return 0, fmt.Errorf("amount is required and must be at least 0.01")
+	}
+	if l.MinAmount != nil && *requested < *l.MinAmount {
+		return 0, fmt.Errorf("amount must be at least %.2f", *l.MinAmount)
+	}
+	if l.MaxAmount != nil && *requested > *l.MaxAmount {
+		return 0, fmt.Errorf("amount must not exceed %.2f", *l.MaxAmount)
+	}
+	return *requested, nil
+}
+
+// CreatePaymentLinkRequest represents request to create a payment link
+// @Description Request to create a new reusable payment link
+type CreatePaymentLinkRequest struct {
+	// Title shown to the customer
+	Title string `json:"title" example:"Yoga class"`
+
+	// Optional description
+	Description *string `json:"description,omitempty" example:"Monthly membership"`
+
+	// Amount type (FIXED or OPEN for customer-entered amount)
+	AmountType AmountType `json:"amount_type" example:"FIXED"`
+
+	// Amount for FIXED links
+	Amount *float64 `json:"amount,omitempty" example:"500.00"`
+
+	// Optional bounds for OPEN links
+	MinAmount *float64 `json:"min_amount,omitempty" example:"10.00"`
+	MaxAmount *float64 `json:"max_amount,omitempty" example:"10000.00"`
+
+	// Three-letter currency code (defaults to ETB)
+	Currency string `json:"currency,omitempty" example:"ETB"`
+
+	// Allowed payment mediums
+	SupportedMediums []entity.TransactionMedium `json:"supported_mediums" example:"[\"TELEBIRR\",\"MPESA\"]"`
+
+	// Indicates who should pay the fee
+	MerchantPaysFee bool `json:"merchant_pays_fee" example:"false"`
+
+	// Whether tipping is enabled
+	AcceptTip bool `json:"accept_tip" example:"false"`
+
+	// Optional maximum number of successful payments
+	QuantityLimit *int `json:"quantity_limit,omitempty" example:"50"`
+
+	// Optional custom slug, generated when empty
+	Slug *string `json:"slug,omitempty" example:"yoga-class"`
+
+	// Optional redirects and callback
+	SuccessURL  *string `json:"success_url,omitempty" example:"https://example.com/success"`
+	FailedURL   *string `json:"failed_url,omitempty" example:"https://example.com/failed"`
+	CallbackURL *string `json:"callback_url,omitempty" example:"https://example.com/callback"`
+
+	// Optional expiry date time in UTC (ISO 8601 format)
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+}
+
+func (r CreatePaymentLinkRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Title, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.AmountType, validation.Required, validation.In(FIXED, OPEN)),
+		validation.Field(&r.Amount, validation.By(func(value interface{}) error {
+			if r.AmountType == FIXED {
+				amount, _ := value.(*float64)
+				if amount == nil {
+					return validation.NewError("validation_required", "amount is required for fixed payment links")
+				}
+				if *amount < 0.01 {
+					return validation.NewError("validation_min", "amount must be at least 0.01")
+				}
+			}
+			return nil
+		})),
+		validation.Field(&r.MaxAmount, validation.By(func(value interface{}) error {
+			if r.MinAmount != nil && r.MaxAmount != nil && *r.MaxAmount < *r.MinAmount {
+				return validation.NewError("validation_min", "max_amount must be greater than min_amount")
+			}
+			return nil
+		})),
+		validation.Field(&r.Currency, validation.When(r.Currency != "", validation.Length(3, 3))),
+		validation.Field(&r.SupportedMediums, validation.Required, validation.Length(1, 10)),
+		validation.Field(&r.QuantityLimit, validation.NilOrNotEmpty, validation.Min(1)),
+		validation.Field(&r.Slug, validation.NilOrNotEmpty, validation.Match(slugRegex).Error("must be 4-32 letters, digits, dashes or underscores")),
+		validation.Field(&r.SuccessURL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&r.FailedURL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&r.CallbackURL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&r.ExpiresAt, validation.By(func(value interface{}) error {
+			if expiresAt, ok := value.(*time.Time); ok && expiresAt != nil && expiresAt.Before(time.Now().UTC()) {
+				return fmt.Errorf("expires_at must be in the future")
+			}
+			return nil
+		})),
+	)
+}
+
+// UpdatePaymentLinkRequest represents request to update a payment link
+// @Description Request to update an existing payment link
+type UpdatePaymentLinkRequest struct {
+	Title            *string                    `json:"title,omitempty" example:"Yoga class"`
+	Description      *string                    `json:"description,omitempty" example:"Monthly membership"`
+	Amount           *float64                   `json:"amount,omitempty" example:"600.00"`
+	MinAmount        *float64                   `json:"min_amount,omitempty" example:"10.00"`
+	MaxAmount        *float64                   `json:"max_amount,omitempty" example:"10000.00"`
+	SupportedMediums []entity.TransactionMedium `json:"supported_mediums,omitempty"`
+	MerchantPaysFee  *bool                      `json:"merchant_pays_fee,omitempty" example:"false"`
+	AcceptTip        *bool                      `json:"accept_tip,omitempty" example:"false"`
+	QuantityLimit    *int                       `json:"quantity_limit,omitempty" example:"100"`
+	SuccessURL       *string                    `json:"success_url,omitempty"`
+	FailedURL        *string                    `json:"failed_url,omitempty"`
+	CallbackURL      *string                    `json:"callback_url,omitempty"`
+	ExpiresAt        *time.Time                 `json:"expires_at,omitempty"`
+	IsActive         *bool                      `json:"is_active,omitempty" example:"true"`
+}
+
+func (r UpdatePaymentLinkRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Title, validation.NilOrNotEmpty, validation.Length(1, 255)),
+		validation.Field(&r.Amount, validation.NilOrNotEmpty, validation.Min(0.01)),
+		validation.Field(&r.SupportedMediums, validation.When(r.SupportedMediums != nil, validation.Length(1, 10))),
+		validation.Field(&r.QuantityLimit, validation.NilOrNotEmpty, validation.Min(1)),
+		validation.Field(&r.SuccessURL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&r.FailedURL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&r.CallbackURL, validation.NilOrNotEmpty, is.URL),
+	)
+}
+
+// OpenPaymentLinkRequest represents a customer opening a payment link
+// @Description Request to start a checkout from a payment link
+type OpenPaymentLinkRequest struct {
+	// Amount for OPEN links (ignored for FIXED)
+	Amount *float64 `json:"amount,omitempty" example:"250.00"`
+
+	// Optional payer phone number to pre-fill the checkout
+	PhoneNumber string `json:"phone_number,omitempty" example:"251911234567"`
+}
+
+// OpenPaymentLinkResponse represents the hosted checkout minted for an open
+// @Description Hosted checkout created from a payment link
+type OpenPaymentLinkResponse struct {
+	HostedCheckoutID uuid.UUID `json:"hosted_checkout_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	PaymentURL       string    `json:"payment_url" example:"https://checkout.socialpay.co/checkout/123e4567-e89b-12d3-a456-426614174000"`
+	Amount           float64   `json:"amount" example:"250.00"`
+	Currency         string    `json:"currency" example:"ETB"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// PaymentLinkStats represents usage statistics for a payment link
+// @Description Usage statistics of a payment link
+type PaymentLinkStats struct {
+	PaymentLinkID uuid.UUID `json:"payment_link_id"`
+
+	// Number of checkouts minted from the link
+	Opens int64 `json:"opens" example:"120"`
+
+	// Checkouts where the customer started a payment
+	PaymentsStarted int64 `json:"payments_started" example:"80"`
+
+	// Successful payments and total collected
+	SuccessfulPayments int64   `json:"successful_payments" example:"72"`
+	FailedPayments     int64   `json:"failed_payments" example:"8"`
+	ExpiredCheckouts   int64   `json:"expired_checkouts" example:"30"`
+	TotalCollected     float64 `json:"total_collected" example:"36000.00"`
+
+	// Successful payments divided by opens (0-100)
+	ConversionRate float64 `json:"conversion_rate" example:"60.00"`
+
+	// Remaining quantity when the link has a limit
+	Remaining *int64 `json:"remaining,omitempty" example:"28"`
+
+	LastPaymentAt *time.Time `json:"last_payment_at,omitempty"`
+}
+
+// PaymentLinkResponse represents payment link details response
+// @Description Response containing payment link details
+type PaymentLinkResponse struct {
+	*PaymentLink
+	// Short URL to share with customers
+	ShortURL string `json:"short_url" example:"https://checkout.socialpay.co/pay/yoga-class"`
+}
+
+// PaymentLinksListResponse represents paginated payment links response
+// @Description Paginated list of payment links
+type PaymentLinksListResponse struct {
+	PaymentLinks []PaymentLinkResponse `json:"payment_links"`
+	Total        int64                 `json:"total"`
+	Page         int                   `json:"page"`
+	Limit        int                   `json:"limit"`
+}
+
+// PublicPaymentLinkResponse represents the customer facing view of a payment link
+// @Description Public payment link details shown before checkout
+type PublicPaymentLinkResponse struct {
+	Slug             string                     `json:"slug" example:"yoga-class"`
+	Title            string                     `json:"title" example:"Yoga class"`
+	Description      *string                    `json:"description,omitempty"`
+	AmountType       AmountType                 `json:"amount_type" example:"FIXED"`
+	Amount           *float64                   `json:"amount,omitempty" example:"500.00"`
+	MinAmount        *float64                   `json:"min_amount,omitempty"`
+	MaxAmount        *float64                   `json:"max_amount,omitempty"`
+	Currency         string                     `json:"currency" example:"ETB"`
+	SupportedMediums []entity.TransactionMedium `json:"supported_mediums"`
+	MerchantName     string                     `json:"merchant_name,omitempty" example:"Addis Yoga"`
+	Available        bool                       `json:"available" example:"true"`
+}
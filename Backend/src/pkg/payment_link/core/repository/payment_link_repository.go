@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/payment_link/core/entity"
+)
+
+var (
+	// ErrSlugTaken is returned when another payment link already uses the slug
+	ErrSlugTaken = errors.New("payment link slug is already taken")
+	// ErrSoldOut is returned when every unit of a quantity-limited link is reserved
+	ErrSoldOut = errors.New("payment link quantity limit reached")
+)
+
+// PaymentLinkRepository defines the interface for payment link operations
+type PaymentLinkRepository interface {
+	// Create creates a new payment link
+	Create(ctx context.Context, link *entity.PaymentLink) error
+
+	// GetByID retrieves a payment link of a merchant by its ID
+	GetByID(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) (*entity.PaymentLink, error)
+
+	// GetBySlug retrieves a payment link by its short URL slug
+	GetBySlug(ctx context.Context, slug string) (*entity.PaymentLink, error)
+
+	// List retrieves the payment links of a merchant with pagination
+	List(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]entity.PaymentLink, int64, error)
+
+	// Update updates an existing payment link
+	Update(ctx context.Context, link *entity.PaymentLink) error
+
+	// Delete deletes a payment link
+	Delete(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) error
+
+	// ReserveSession links a minted hosted payment to the payment link, reserving one unit of
+	// a quantity-limited link. It locks the link row while counting and returns ErrSoldOut
+	// when no unit is left.
+	ReserveSession(ctx context.Context, linkID uuid.UUID, hostedPaymentID uuid.UUID) error
+
+	// CountReserved counts sessions holding a unit: unpaid checkouts that have not expired
+	// and payments that did not fail, expire, get canceled or refunded
+	CountReserved(ctx context.Context, linkID uuid.UUID) (int64, error)
+
+	// GetStats aggregates the sessions of a payment link
+	GetStats(ctx context.Context, linkID uuid.UUID) (*entity.PaymentLinkStats, error)
+}
@@ -0,0 +1,444 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/socialpay/socialpay/src/pkg/payment_link/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const paymentLinkColumns = `
+	id, user_id, merchant_id, slug, title, description, amount_type, amount, min_amount, max_amount,
+	currency, supported_mediums, merchant_pays_fee, accept_tip, quantity_limit,
+	success_url, failed_url, callback_url, expires_at, is_active, created_at, updated_at`
+
+type PaymentLinkRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPaymentLinkRepository(db *sql.DB) PaymentLinkRepository {
+	return &PaymentLinkRepositoryImpl{db: db}
+}
+
+func (r *PaymentLinkRepositoryImpl) Create(ctx context.Context, link *entity.PaymentLink) error {
+	supportedMediums, err := json.Marshal(link.SupportedMediums)
+	if err != nil {
+		return fmt.Errorf("failed to marshal supported mediums: %w", err)
+	}
+
+	query := `
+		INSERT INTO public.payment_links (
+			id, user_id, merchant_id, slug, title, description, amount_type, amount, min_amount, max_amount,
+			currency, supported_mediums, merchant_pays_fee, accept_tip, quantity_limit,
+			success_url, failed_url, callback_url, expires_at, is_active
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRowContext(ctx, query,
+		link.ID,
+		link.UserID,
+		link.MerchantID,
+		strings.ToLower(link.Slug),
+		link.Title,
+		nullString(link.Description),
+		string(link.AmountType),
+		nullFloat(link.Amount),
+		nullFloat(link.MinAmount),
+		nullFloat(link.MaxAmount),
+		link.Currency,
+		supportedMediums,
+		link.MerchantPaysFee,
+		link.AcceptTip,
+		nullInt(link.QuantityLimit),
+		nullString(link.SuccessURL),
+		nullString(link.FailedURL),
+		nullString(link.CallbackURL),
+		link.ExpiresAt,
+		link.IsActive,
+	).Scan(&link.CreatedAt, &link.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create payment link: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PaymentLinkRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) (*entity.PaymentLink, error) {
+	query := `SELECT ` + paymentLinkColumns + ` FROM public.payment_links WHERE id = $1 AND merchant_id = $2`
+
+	link, err := scanPaymentLink(r.db.QueryRowContext(ctx, query, id, merchantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payment link not found")
+		}
+		return nil, fmt.Errorf("failed to get payment link: %w", err)
+	}
+
+	return link, nil
+}
+
+func (r *PaymentLinkRepositoryImpl) GetBySlug(ctx context.Context, slug string) (*entity.PaymentLink, error) {
+	query := `SELECT ` + paymentLinkColumns + ` FROM public.payment_links WHERE slug = $1`
+
+	link, err := scanPaymentLink(r.db.QueryRowContext(ctx, query, strings.ToLower(slug)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payment link not found")
+		}
+		return nil, fmt.Errorf("failed to get payment link by slug: %w", err)
+	}
+
+	return link, nil
+}
+
+func (r *PaymentLinkRepositoryImpl) List(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]entity.PaymentLink, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM public.payment_links WHERE merchant_id = $1`, merchantID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count payment links: %w", err)
+	}
+
+	query := `SELECT ` + paymentLinkColumns + `
+		FROM public.payment_links
+		WHERE merchant_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list payment links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []entity.PaymentLink{}
+	for rows.Next() {
+		link, err := scanPaymentLink(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan payment link: %w", err)
+		}
+		links = append(links, *link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate payment links: %w", err)
+	}
+
+	return links, total, nil
+}
+
+func (r *PaymentLinkRepositoryImpl) Update(ctx context.Context, link *entity.PaymentLink) error {
+	supportedMediums, err := json.Marshal(link.SupportedMediums)
+	if err != nil {
+		return fmt.Errorf("failed to marshal supported mediums: %w", err)
+	}
+
+	query := `
+		UPDATE public.payment_links
+		SET title = $3,
+			description = $4,
+			amount = $5,
+			min_amount = $6,
+			max_amount = $7,
+			supported_mediums = $8,
+			merchant_pays_fee = $9,
+			accept_tip = $10,
+			quantity_limit = $11,
+			success_url = $12,
+			failed_url = $13,
+			callback_url = $14,
+			expires_at = $15,
+			is_active = $16,
+			updated_at = NOW()
+		WHERE id = $1 AND merchant_id = $2
+		RETURNING updated_at
+	`
+
+	err = r.db.QueryRowContext(ctx, query,
+		link.ID,
+		link.MerchantID,
+		link.Title,
+		nullString(link.Description),
+		nullFloat(link.Amount),
+		nullFloat(link.MinAmount),
+		nullFloat(link.MaxAmount),
+		supportedMediums,
+		link.MerchantPaysFee,
+		link.AcceptTip,
+		nullInt(link.QuantityLimit),
+		nullString(link.SuccessURL),
+		nullString(link.FailedURL),
+		nullString(link.CallbackURL),
+		link.ExpiresAt,
+		link.IsActive,
+	).Scan(&link.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("payment link not found")
+		}
+		return fmt.Errorf("failed to update payment link: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PaymentLinkRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, merchantID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM public.payment_links WHERE id = $1 AND merchant_id = $2`, id, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete payment link: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete payment link: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("payment link not found")
+	}
+
+	return nil
+}
+
+func (r *PaymentLinkRepositoryImpl) ReserveSession(ctx context.Context, linkID uuid.UUID, hostedPaymentID uuid.UUID) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Concurrent opens of the same link queue on the row lock, so each one counts the
+	// sessions committed before it
+	var quantityLimit sql.NullInt64
+	err = dbTx.QueryRowContext(ctx,
+		`SELECT quantity_limit FROM public.payment_links WHERE id = $1 FOR UPDATE`,
+		linkID).Scan(&quantityLimit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("payment link not found")
+		}
+		return fmt.Errorf("failed to lock payment link: %w", err)
+	}
+
+	if quantityLimit.Valid {
+		reserved, err := countReserved(ctx, dbTx, linkID)
+		if err != nil {
+			return err
+		}
+		if reserved >= quantityLimit.Int64 {
+			return ErrSoldOut
+		}
+	}
+
+	_, err = dbTx.ExecContext(ctx,
+		`INSERT INTO public.payment_link_sessions (payment_link_id, hosted_payment_id) VALUES ($1, $2)`,
+		linkID, hostedPaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to record payment link session: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PaymentLinkRepositoryImpl) CountReserved(ctx context.Context, linkID uuid.UUID) (int64, error) {
+	return countReserved(ctx, r.db, linkID)
+}
+
+// queryRower is satisfied by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// countReserved counts the sessions holding a unit of the link: checkouts not yet paid that
+// can still be, and payments in any status but a failed, expired, canceled or refunded one
+func countReserved(ctx context.Context, q queryRower, linkID uuid.UUID) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM public.payment_link_sessions s
+		JOIN public.hosted_payments hp ON hp.id = s.hosted_payment_id
+		LEFT JOIN public.transactions t ON t.id = hp.transaction_id
+		WHERE s.payment_link_id = $1
+		  AND (
+			(t.id IS NULL AND hp.status = $2 AND hp.expires_at > NOW())
+			OR t.status NOT IN ($3, $4, $5, $6)
+		  )
+	`
+
+	var count int64
+	err := q.QueryRowContext(ctx, query,
+		linkID,
+		string(txEntity.HostedPaymentPending),
+		string(txEntity.FAILED),
+		string(txEntity.EXPIRED),
+		string(txEntity.CANCELED),
+		string(txEntity.REFUNDED),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count payment link sales: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *PaymentLinkRepositoryImpl) GetStats(ctx context.Context, linkID uuid.UUID) (*entity.PaymentLinkStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(hp.transaction_id),
+			COUNT(*) FILTER (WHERE t.status = $2),
+			COUNT(*) FILTER (WHERE t.status = $3),
+			COUNT(*) FILTER (WHERE hp.status = $4),
+			COALESCE(SUM(t.base_amount) FILTER (WHERE t.status = $2), 0),
+			MAX(t.updated_at) FILTER (WHERE t.status = $2)
+		FROM public.payment_link_sessions s
+		JOIN public.hosted_payments hp ON hp.id = s.hosted_payment_id
+		LEFT JOIN public.transactions t ON t.id = hp.transaction_id
+		WHERE s.payment_link_id = $1
+	`
+
+	stats := entity.PaymentLinkStats{PaymentLinkID: linkID}
+	var lastPaymentAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query,
+		linkID,
+		string(txEntity.SUCCESS),
+		string(txEntity.FAILED),
+		string(txEntity.HostedPaymentExpired),
+	).Scan(
+		&stats.Opens,
+		&stats.PaymentsStarted,
+		&stats.SuccessfulPayments,
+		&stats.FailedPayments,
+		&stats.ExpiredCheckouts,
+		&stats.TotalCollected,
+		&lastPaymentAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment link stats: %w", err)
+	}
+
+	if lastPaymentAt.Valid {
+		stats.LastPaymentAt = &lastPaymentAt.Time
+	}
+	if stats.Opens > 0 {
+		rate := float64(stats.SuccessfulPayments) / float64(stats.Opens) * 100
+		stats.ConversionRate = math.Round(rate*100) / 100
+	}
+
+	return &stats, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPaymentLink(row rowScanner) (*entity.PaymentLink, error) {
+	var (
+		link                                      entity.PaymentLink
+		amountType                                string
+		description, successURL, failedURL, cbURL sql.NullString
+		amount, minAmount, maxAmount              sql.NullFloat64
+		quantityLimit                             sql.NullInt32
+		expiresAt                                 sql.NullTime
+		supportedMediums                          []byte
+	)
+
+	if err := row.Scan(
+		&link.ID,
+		&link.UserID,
+		&link.MerchantID,
+		&link.Slug,
+		&link.Title,
+		&description,
+		&amountType,
+		&amount,
+		&minAmount,
+		&maxAmount,
+		&link.Currency,
+		&supportedMediums,
+		&link.MerchantPaysFee,
+		&link.AcceptTip,
+		&quantityLimit,
+		&successURL,
+		&failedURL,
+		&cbURL,
+		&expiresAt,
+		&link.IsActive,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(supportedMediums, &link.SupportedMediums); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal supported mediums: %w", err)
+	}
+
+	link.AmountType = entity.AmountType(amountType)
+	link.Description = stringPtr(description)
+	link.SuccessURL = stringPtr(successURL)
+	link.FailedURL = stringPtr(failedURL)
+	link.CallbackURL = stringPtr(cbURL)
+	if amount.Valid {
+		link.Amount = &amount.Float64
+	}
+	if minAmount.Valid {
+		link.MinAmount = &minAmount.Float64
+	}
+	if maxAmount.Valid {
+		link.MaxAmount = &maxAmount.Float64
+	}
+	if quantityLimit.Valid {
+		limit := int(quantityLimit.Int32)
+		link.QuantityLimit = &limit
+	}
+	if expiresAt.Valid {
+		link.ExpiresAt = &expiresAt.Time
+	}
+
+	return &link, nil
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil || *s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullFloat(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+func nullInt(i *int) sql.NullInt32 {
+	if i == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*i), Valid: true}
+}
+
+func stringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
@@ -0,0 +1,47 @@
+-- Payment Links Schema
+-- This should match the migration exactly
+
+CREATE TYPE payment_link_amount_type AS ENUM (
+    'FIXED',
+    'OPEN'
+);
+
+CREATE TABLE IF NOT EXISTS public.payment_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES auth.users(id),
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    slug VARCHAR(32) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    amount_type payment_link_amount_type NOT NULL,
+    amount DECIMAL(20,2),
+    min_amount DECIMAL(20,2),
+    max_amount DECIMAL(20,2),
+    currency VARCHAR(3) NOT NULL DEFAULT 'ETB',
+    supported_mediums JSONB NOT NULL,
+    merchant_pays_fee BOOLEAN NOT NULL DEFAULT false,
+    accept_tip BOOLEAN NOT NULL DEFAULT false,
+    quantity_limit INTEGER,
+    success_url TEXT,
+    failed_url TEXT,
+    callback_url TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_payment_links_amount CHECK (
+        (amount_type = 'FIXED' AND amount IS NOT NULL) OR amount_type = 'OPEN'
+    )
+);
+
+-- Every open of a payment link mints a hosted payment, tracked here for stats
+CREATE TABLE IF NOT EXISTS public.payment_link_sessions (
+    payment_link_id UUID NOT NULL REFERENCES public.payment_links(id) ON DELETE CASCADE,
+    hosted_payment_id UUID NOT NULL REFERENCES public.hosted_payments(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (payment_link_id, hosted_payment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_links_merchant_id ON public.payment_links(merchant_id);
+CREATE INDEX IF NOT EXISTS idx_payment_link_sessions_hosted_payment_id ON public.payment_link_sessions(hosted_payment_id);
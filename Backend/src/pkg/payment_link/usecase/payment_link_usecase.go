@@ -0,0 +1,501 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	brandingUsecase "github.com/socialpay/socialpay/src/pkg/checkout_branding/usecase"
+	"github.com/socialpay/socialpay/src/pkg/payment_link/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/payment_link/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/shared/pagination"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepo "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+	merchantRepo "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/repository"
+)
+
+var (
+	// ErrLinkUnavailable is returned when a link is inactive, expired or sold out
+	ErrLinkUnavailable = errors.New("payment link is not available")
+	// ErrInvalidAmount is returned when the customer-entered amount is rejected
+	ErrInvalidAmount = errors.New("invalid payment link amount")
+)
+
+// slugAlphabet avoids characters that are easily confused when typed from a printed link
+const slugAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// PaymentLinkUseCase defines the interface for payment link business operations
+type PaymentLinkUseCase interface {
+	// CreatePaymentLink creates a new reusable payment link
+	CreatePaymentLink(ctx context.Context, userID, merchantID uuid.UUID, req *entity.CreatePaymentLinkRequest) (*entity.PaymentLinkResponse, error)
+
+	// GetPaymentLink retrieves a payment link of a merchant
+	GetPaymentLink(ctx context.Context, id, merchantID uuid.UUID) (*entity.PaymentLinkResponse, error)
+
+	// GetPaymentLinksByMerchant retrieves payment links of a merchant with pagination
+	GetPaymentLinksByMerchant(ctx context.Context, merchantID uuid.UUID, pagination *pagination.Pagination) (*entity.PaymentLinksListResponse, error)
+
+	// UpdatePaymentLink updates an existing payment link
+	UpdatePaymentLink(ctx context.Context, id, merchantID uuid.UUID, req *entity.UpdatePaymentLinkRequest) (*entity.PaymentLinkResponse, error)
+
+	// DeletePaymentLink deletes a payment link
+	DeletePaymentLink(ctx context.Context, id, merchantID uuid.UUID) error
+
+	// GetPaymentLinkStats returns usage statistics of a payment link
+	GetPaymentLinkStats(ctx context.Context, id, merchantID uuid.UUID) (*entity.PaymentLinkStats, error)
+
+	// GetPublicPaymentLink returns the customer facing view of a payment link
+	GetPublicPaymentLink(ctx context.Context, slug string) (*entity.PublicPaymentLinkResponse, error)
+
+	// OpenPaymentLink mints a fresh hosted checkout for a customer
+	OpenPaymentLink(ctx context.Context, slug string, req *entity.OpenPaymentLinkRequest) (*entity.OpenPaymentLinkResponse, error)
+}
+
+// MerchantEligibility decides whether a merchant's status lets it accept payments. The merchant usecase implements it.
+type MerchantEligibility interface {
+	CheckEligibility(ctx context.Context, merchantID uuid.UUID, operation merchantEntity.MerchantOperation) (bool, error)
+}
+
+type paymentLinkUseCase struct {
+	linkRepo          repository.PaymentLinkRepository
+	hostedPaymentRepo txRepo.HostedPaymentRepository
+	merchantRepo      merchantRepo.Repository
+	brandingUseCase   brandingUsecase.BrandingUseCase
+	merchants         MerchantEligibility
+	checkoutURL       string
+	log               logging.Logger
+}
+
+func NewPaymentLinkUseCase(
+	linkRepo repository.PaymentLinkRepository,
+	hostedPaymentRepo txRepo.HostedPaymentRepository,
+	merchantRepo merchantRepo.Repository,
+	brandingUseCase brandingUsecase.BrandingUseCase,
+	merchants MerchantEligibility,
+) PaymentLinkUseCase {
+	checkoutURL := os.Getenv("APP_CHECKOUT_URL")
+	if checkoutURL == "" {
+		checkoutURL = "http://localhost:3000"
+	}
+
+	return &paymentLinkUseCase{
+		linkRepo:          linkRepo,
+		hostedPaymentRepo: hostedPaymentRepo,
+		merchantRepo:      merchantRepo,
+		brandingUseCase:   brandingUseCase,
+		merchants:         merchants,
+		checkoutURL:       strings.TrimRight(checkoutURL, "/"),
+		log:               logging.NewStdLogger("payment_link_usecase"),
+	}
+}
+
+func (uc *paymentLinkUseCase) CreatePaymentLink(ctx context.Context, userID, merchantID uuid.UUID, req *entity.CreatePaymentLinkRequest) (*entity.PaymentLinkResponse, error) {
+	uc.log.Info("Creating payment link", map[string]interface{}{
+		"user_id":     userID,
+		"merchant_id": merchantID,
+		"amount_type": req.AmountType,
+	})
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	currency := strings.ToUpper(req.Currency)
+	if currency == "" {
+		currency = "ETB"
+	}
+
+	link := &entity.PaymentLink{
+		ID:               uuid.New(),
+		UserID:           userID,
+		MerchantID:       merchantID,
+		Title:            req.Title,
+		Description:      req.Description,
+		AmountType:       req.AmountType,
+		Currency:         currency,
+		SupportedMediums: req.SupportedMediums,
+		MerchantPaysFee:  req.MerchantPaysFee,
+		AcceptTip:        req.AcceptTip,
+		QuantityLimit:    req.QuantityLimit,
+		SuccessURL:       req.SuccessURL,
+		FailedURL:        req.FailedURL,
+		CallbackURL:      req.CallbackURL,
+		ExpiresAt:        req.ExpiresAt,
+		IsActive:         true,
+	}
+
+	// Amount bounds only make sense for the type they belong to
+	if req.AmountType == entity.FIXED {
+		link.Amount = req.Amount
+	} else {
+		link.MinAmount = req.MinAmount
+		link.MaxAmount = req.MaxAmount
+	}
+
+	if req.Slug != nil && *req.Slug != "" {
+		link.Slug = strings.ToLower(*req.Slug)
+		if err := uc.linkRepo.Create(ctx, link); err != nil {
+			uc.log.Error("Failed to create payment link", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return nil, err
+		}
+	} else if err := uc.createWithGeneratedSlug(ctx, link); err != nil {
+		uc.log.Error("Failed to create payment link", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	uc.log.Info("Payment link created successfully", map[string]interface{}{
+		"payment_link_id": link.ID,
+		"slug":            link.Slug,
+	})
+
+	return uc.buildPaymentLinkResponse(link), nil
+}
+
+// createWithGeneratedSlug retries a few times in the unlikely case of a slug collision
+func (uc *paymentLinkUseCase) createWithGeneratedSlug(ctx context.Context, link *entity.PaymentLink) error {
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		link.Slug = generateSlug(8)
+		err = uc.linkRepo.Create(ctx, link)
+		if !errors.Is(err, repository.ErrSlugTaken) {
+			return err
+		}
+	}
+	return err
+}
+
+func (uc *paymentLinkUseCase) GetPaymentLink(ctx context.Context, id, merchantID uuid.UUID) (*entity.PaymentLinkResponse, error) {
+	link, err := uc.linkRepo.GetByID(ctx, id, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildPaymentLinkResponse(link), nil
+}
+
+func (uc *paymentLinkUseCase) GetPaymentLinksByMerchant(ctx context.Context, merchantID uuid.UUID, pag *pagination.Pagination) (*entity.PaymentLinksListResponse, error) {
+	links, total, err := uc.linkRepo.List(ctx, merchantID, pag.PerPage, (pag.Page-1)*pag.PerPage)
+	if err != nil {
+		uc.log.Error("Failed to get payment links by merchant", map[string]interface{}{
+			"merchant_id": merchantID,
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	responses := make([]entity.PaymentLinkResponse, len(links))
+	for i := range links {
+		responses[i] = *uc.buildPaymentLinkResponse(&links[i])
+	}
+
+	return &entity.PaymentLinksListResponse{
+		PaymentLinks: responses,
+		Total:        total,
+		Page:         pag.Page,
+		Limit:        pag.PerPage,
+	}, nil
+}
+
+func (uc *paymentLinkUseCase) UpdatePaymentLink(ctx context.Context, id, merchantID uuid.UUID, req *entity.UpdatePaymentLinkRequest) (*entity.PaymentLinkResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	link, err := uc.linkRepo.GetByID(ctx, id, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		link.Title = *req.Title
+	}
+	if req.Description != nil {
+		link.Description = req.Description
+	}
+	if link.AmountType == entity.FIXED {
+		if req.Amount != nil {
+			link.Amount = req.Amount
+		}
+	} else {
+		if req.MinAmount != nil {
+			link.MinAmount = req.MinAmount
+		}
+		if req.MaxAmount != nil {
+			link.MaxAmount = req.MaxAmount
+		}
+		if link.MinAmount != nil && link.MaxAmount != nil && *link.MaxAmount < *link.MinAmount {
+			return nil, fmt.Errorf("validation failed: max_amount must be greater than min_amount")
+		}
+	}
+	if req.SupportedMediums != nil {
+		link.SupportedMediums = req.SupportedMediums
+	}
+	if req.MerchantPaysFee != nil {
+		link.MerchantPaysFee = *req.MerchantPaysFee
+	}
+	if req.AcceptTip != nil {
+		link.AcceptTip = *req.AcceptTip
+	}
+	if req.QuantityLimit != nil {
+		link.QuantityLimit = req.QuantityLimit
+	}
+	if req.SuccessURL != nil {
+		link.SuccessURL = req.SuccessURL
+	}
+	if req.FailedURL != nil {
+		link.FailedURL = req.FailedURL
+	}
+	if req.CallbackURL != nil {
+		link.CallbackURL = req.CallbackURL
+	}
+	if req.ExpiresAt != nil {
+		link.ExpiresAt = req.ExpiresAt
+	}
+	if req.IsActive != nil {
+		link.IsActive = *req.IsActive
+	}
+
+	if err := uc.linkRepo.Update(ctx, link); err != nil {
+		uc.log.Error("Failed to update payment link", map[string]interface{}{
+			"payment_link_id": id,
+			"error":           err.Error(),
+		})
+		return nil, err
+	}
+
+	return uc.buildPaymentLinkResponse(link), nil
+}
+
+func (uc *paymentLinkUseCase) DeletePaymentLink(ctx context.Context, id, merchantID uuid.UUID) error {
+	if err := uc.linkRepo.Delete(ctx, id, merchantID); err != nil {
+		uc.log.Error("Failed to delete payment link", map[string]interface{}{
+			"payment_link_id": id,
+			"error":           err.Error(),
+		})
+		return err
+	}
+
+	return nil
+}
+
+func (uc *paymentLinkUseCase) GetPaymentLinkStats(ctx context.Context, id, merchantID uuid.UUID) (*entity.PaymentLinkStats, error) {
+	link, err := uc.linkRepo.GetByID(ctx, id, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.linkRepo.GetStats(ctx, link.ID)
+	if err != nil {
+		uc.log.Error("Failed to get payment link stats", map[string]interface{}{
+			"payment_link_id": id,
+			"error":           err.Error(),
+		})
+		return nil, err
+	}
+
+	if link.QuantityLimit != nil {
+		reserved, err := uc.linkRepo.CountReserved(ctx, link.ID)
+		if err != nil {
+			return nil, err
+		}
+		remaining := int64(*link.QuantityLimit) - reserved
+		if remaining < 0 {
+			remaining = 0
+		}
+		stats.Remaining = &remaining
+	}
+
+	return stats, nil
+}
+
+func (uc *paymentLinkUseCase) GetPublicPaymentLink(ctx context.Context, slug string) (*entity.PublicPaymentLinkResponse, error) {
+	link, err := uc.linkRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &entity.PublicPaymentLinkResponse{
+		Slug:             link.Slug,
+		Title:            link.Title,
+		Description:      link.Description,
+		AmountType:       link.AmountType,
+		Amount:           link.Amount,
+		MinAmount:        link.MinAmount,
+		MaxAmount:        link.MaxAmount,
+		Currency:         link.Currency,
+		SupportedMediums: link.SupportedMediums,
+		Available:        uc.checkAvailable(ctx, link) == nil,
+	}
+
+	if uc.brandingUseCase != nil {
+		response.SupportedMediums = uc.brandingUseCase.OrderMediums(ctx, link.MerchantID, link.SupportedMediums)
+	}
+
+	if merchant, err := uc.merchantRepo.GetMerchant(ctx, link.MerchantID); err == nil && merchant != nil {
+		response.MerchantName = merchant.LegalName
+		if merchant.TradingName != nil && *merchant.TradingName != "" {
+			response.MerchantName = *merchant.TradingName
+		}
+	}
+
+	return response, nil
+}
+
+func (uc *paymentLinkUseCase) OpenPaymentLink(ctx context.Context, slug string, req *entity.OpenPaymentLinkRequest) (*entity.OpenPaymentLinkResponse, error) {
+	link, err := uc.linkRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkAvailable(ctx, link); err != nil {
+		uc.log.Warn("Payment link open rejected", map[string]interface{}{
+			"payment_link_id": link.ID,
+			"reason":          err.Error(),
+		})
+		return nil, err
+	}
+
+	amount, err := link.ResolveAmount(req.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAmount, err.Error())
+	}
+
+	shortURL := uc.shortURL(link.Slug)
+	hostedPayment := &txEntity.HostedPayment{
+		ID:               uuid.New(),
+		UserID:           link.UserID,
+		MerchantID:       link.MerchantID,
+		Amount:           amount,
+		Currency:         link.Currency,
+		Description:      link.Title,
+		Reference:        fmt.Sprintf("PL-%s-%s", link.Slug, strings.ToUpper(generateSlug(10))),
+		AcceptTip:        link.AcceptTip,
+		MerchantPaysFee:  link.MerchantPaysFee,
+		SupportedMediums: link.SupportedMediums,
+		PhoneNumber:      req.PhoneNumber,
+		SuccessURL:       valueOr(link.SuccessURL, shortURL),
+		FailedURL:        valueOr(link.FailedURL, shortURL),
+		CallbackURL:      valueOr(link.CallbackURL, ""),
+		Status:           txEntity.HostedPaymentPending,
+	}
+	if link.Description != nil && *link.Description != "" {
+		hostedPayment.Description = *link.Description
+	}
+
+	if err := uc.hostedPaymentRepo.Create(ctx, hostedPayment); err != nil {
+		uc.log.Error("Failed to create hosted payment for payment link", map[string]interface{}{
+			"payment_link_id": link.ID,
+			"error":           err.Error(),
+		})
+		return nil, fmt.Errorf("failed to create checkout: %w", err)
+	}
+
+	// The quantity check above is only a fast path; the unit is taken here, under the link's
+	// row lock, and a checkout that did not get one is canceled so it cannot be paid
+	if err := uc.linkRepo.ReserveSession(ctx, link.ID, hostedPayment.ID); err != nil {
+		if cancelErr := uc.hostedPaymentRepo.UpdateStatus(ctx, hostedPayment.ID, txEntity.HostedPaymentCanceled); cancelErr != nil {
+			uc.log.Error("Failed to cancel unreserved payment link checkout", map[string]interface{}{
+				"payment_link_id":    link.ID,
+				"hosted_checkout_id": hostedPayment.ID,
+				"error":              cancelErr.Error(),
+			})
+		}
+		if errors.Is(err, repository.ErrSoldOut) {
+			return nil, fmt.Errorf("%w: quantity limit reached", ErrLinkUnavailable)
+		}
+		uc.log.Error("Failed to reserve payment link session", map[string]interface{}{
+			"payment_link_id":    link.ID,
+			"hosted_checkout_id": hostedPayment.ID,
+			"error":              err.Error(),
+		})
+		return nil, fmt.Errorf("failed to create checkout: %w", err)
+	}
+
+	// Read back to get the database defaults (expiry)
+	created, err := uc.hostedPaymentRepo.GetByID(ctx, hostedPayment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkout: %w", err)
+	}
+
+	uc.log.Info("Payment link opened", map[string]interface{}{
+		"payment_link_id":    link.ID,
+		"hosted_checkout_id": created.ID,
+		"amount":             amount,
+	})
+
+	return &entity.OpenPaymentLinkResponse{
+		HostedCheckoutID: created.ID,
+		PaymentURL:       fmt.Sprintf("%s/checkout/%s", uc.checkoutURL, created.ID),
+		Amount:           created.Amount,
+		Currency:         created.Currency,
+		ExpiresAt:        created.ExpiresAt,
+	}, nil
+}
+
+// checkAvailable rejects inactive, expired and sold out links, and links of merchants that
+// cannot collect payments
+func (uc *paymentLinkUseCase) checkAvailable(ctx context.Context, link *entity.PaymentLink) error {
+	if !link.IsActive {
+		return fmt.Errorf("%w: link is inactive", ErrLinkUnavailable)
+	}
+	if link.IsExpired(time.Now()) {
+		return fmt.Errorf("%w: link has expired", ErrLinkUnavailable)
+	}
+	// Links of suspended and terminated merchants stay in place but cannot be paid
+	if uc.merchants != nil {
+		if _, err := uc.merchants.CheckEligibility(ctx, link.MerchantID, merchantEntity.OperationCollect); err != nil {
+			if !errors.Is(err, merchantEntity.ErrMerchantIneligible) {
+				return fmt.Errorf("failed to check merchant eligibility: %w", err)
+			}
+			return fmt.Errorf("%w: %s", ErrLinkUnavailable, err.Error())
+		}
+	}
+	if link.QuantityLimit != nil {
+		reserved, err := uc.linkRepo.CountReserved(ctx, link.ID)
+		if err != nil {
+			return err
+		}
+		if reserved >= int64(*link.QuantityLimit) {
+			return fmt.Errorf("%w: quantity limit reached", ErrLinkUnavailable)
+		}
+	}
+	return nil
+}
+
+func (uc *paymentLinkUseCase) shortURL(slug string) string {
+	return fmt.Sprintf("%s/pay/%s", uc.checkoutURL, slug)
+}
+
+func (uc *paymentLinkUseCase) buildPaymentLinkResponse(link *entity.PaymentLink) *entity.PaymentLinkResponse {
+	return &entity.PaymentLinkResponse{
+		PaymentLink: link,
+		ShortURL:    uc.shortURL(link.Slug),
+	}
+}
+
+func generateSlug(length int) string {
+	id := uuid.New()
+	slug := make([]byte, length)
+	for i := range slug {
+		slug[i] = slugAlphabet[int(id[i%len(id)])%len(slugAlphabet)]
+	}
+	return string(slug)
+}
+
+func valueOr(s *string, fallback string) string {
+	if s == nil || *s == "" {
+		return fallback
+	}
+	return *s
+}
@@ -0,0 +1,261 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/processor_account/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/processor_account/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+type Handler struct {
+	accountUseCase usecase.ProcessorAccountUseCase
+	log            logging.Logger
+	jwtMiddleware  gin.HandlerFunc
+	rbac           *ginMiddleware.RBACV2
+}
+
+func NewHandler(accountUseCase usecase.ProcessorAccountUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		accountUseCase: accountUseCase,
+		log:            logging.NewStdLogger("processor_account_handler"),
+		jwtMiddleware:  jwtMiddleware,
+		rbac:           rbac,
+	}
+}
+
+// RegisterRouter sets up the merchant and admin processor account routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	accounts := router.Group("/processor_mgmt", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	accounts.GET("/accounts",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_PROCESSOR_ACCOUNT, auth_entity.OPERATION_READ),
+		h.ListAccounts)
+	accounts.PUT("/accounts/:medium/credentials",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_PROCESSOR_ACCOUNT, auth_entity.OPERATION_UPDATE),
+		h.SetCredentials)
+	accounts.DELETE("/accounts/:medium/credentials",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_PROCESSOR_ACCOUNT, auth_entity.OPERATION_UPDATE),
+		h.ClearCredentials)
+
+	admin := router.Group("/admin/processor_mgmt", h.jwtMiddleware)
+	admin.GET("/merchants/:merchant_id/accounts",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_PROCESSOR_ACCOUNT, auth_entity.OPERATION_ADMIN_READ),
+		h.AdminListAccounts)
+	admin.PUT("/merchants/:merchant_id/accounts/:medium",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_PROCESSOR_ACCOUNT, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.AdminSetEnabled)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// ListAccounts godoc
+// @Summary      List payment mediums
+// @Description  List the payment mediums of the authenticated merchant, whether they are enabled and whether own credentials are set
+// @Tags         Processor Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.ProcessorAccountsListResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /processor_mgmt/accounts [get]
+func (h *Handler) ListAccounts(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	response, err := h.accountUseCase.ListAccounts(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetCredentials godoc
+// @Summary      Set own processor credentials
+// @Description  Store the merchant's own credentials for an enabled medium. Deposits through the medium are then collected into the merchant's processor account.
+// @Tags         Processor Accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        medium   path  string                        true  "Payment medium"
+// @Param        request  body  entity.SetCredentialsRequest  true  "Credentials"
+// @Success      200  {object}  entity.ProcessorAccountResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /processor_mgmt/accounts/{medium}/credentials [put]
+func (h *Handler) SetCredentials(c *gin.Context) {
+	merchantID, userID, ok := h.merchantAndUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.SetCredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.accountUseCase.SetCredentials(c.Request.Context(), merchantID, mediumParam(c), &req, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ClearCredentials godoc
+// @Summary      Clear own processor credentials
+// @Description  Remove the merchant's own credentials so the medium runs on SocialPay's account again
+// @Tags         Processor Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        medium  path  string  true  "Payment medium"
+// @Success      200  {object}  entity.ProcessorAccountResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /processor_mgmt/accounts/{medium}/credentials [delete]
+func (h *Handler) ClearCredentials(c *gin.Context) {
+	merchantID, userID, ok := h.merchantAndUser(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.accountUseCase.ClearCredentials(c.Request.Context(), merchantID, mediumParam(c), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AdminListAccounts godoc
+// @Summary      List payment mediums of a merchant
+// @Description  List the payment mediums of a merchant for admins
+// @Tags         Admin Processor Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      200  {object}  entity.ProcessorAccountsListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/processor_mgmt/merchants/{merchant_id}/accounts [get]
+func (h *Handler) AdminListAccounts(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	response, err := h.accountUseCase.ListAccounts(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AdminSetEnabled godoc
+// @Summary      Enable or disable a payment medium
+// @Description  Enable or disable a payment medium for a merchant
+// @Tags         Admin Processor Accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string                    true  "Merchant ID"
+// @Param        medium       path  string                    true  "Payment medium"
+// @Param        request      body  entity.SetEnabledRequest  true  "Enablement"
+// @Success      200  {object}  entity.ProcessorAccountResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/processor_mgmt/merchants/{merchant_id}/accounts/{medium} [put]
+func (h *Handler) AdminSetEnabled(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.SetEnabledRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.accountUseCase.SetEnabled(c.Request.Context(), merchantID, mediumParam(c), req.Enabled, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) merchantAndUser(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return merchantID, userID, true
+}
+
+func mediumParam(c *gin.Context) txEntity.TransactionMedium {
+	return txEntity.TransactionMedium(strings.ToUpper(c.Param("medium")))
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrMediumNotEnabled):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case errors.Is(err, entity.ErrCredentialsUnavailable):
+		c.JSON(http.StatusServiceUnavailable, newErrorResponse(err))
+	case errors.Is(err, entity.ErrOwnCredentialsNotSupported),
+		strings.HasPrefix(err.Error(), "missing credentials"),
+		strings.HasPrefix(err.Error(), "unknown credentials"),
+		strings.HasPrefix(err.Error(), "unsupported payment medium"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	default:
+		h.log.Error("Processor account request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
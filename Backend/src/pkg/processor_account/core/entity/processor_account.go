@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+var (
+	// ErrMediumNotEnabled is returned when a merchant pays or withdraws through a medium an admin has not enabled
+	ErrMediumNotEnabled = errors.New("payment medium is not enabled for this merchant")
+	// ErrOwnCredentialsNotSupported is returned for mediums that can only run on SocialPay's account
+	ErrOwnCredentialsNotSupported = errors.New("payment medium does not support merchant credentials")
+	// ErrCredentialsUnavailable is returned when no encryption key is configured for merchant credentials
+	ErrCredentialsUnavailable = errors.New("merchant processor credentials are not available")
+)
+
+// CredentialFields lists the credentials a merchant must provide to run a medium on their own account.
+// Mediums missing from the map always run on SocialPay's platform account.
+var CredentialFields = map[txEntity.TransactionMedium][]string{
+	txEntity.TELEBIRR:    {"short_code", "identity_id", "security_credential", "password"},
+	txEntity.CBE:         {"merchant_id", "merchant_key", "terminal_id", "credential_key"},
+	txEntity.AWASH:       {"merchant_code", "password", "till_number"},
+	txEntity.CYBERSOURCE: {"access_key", "profile_id", "secret_key"},
+	txEntity.ETHSWITCH:   {"username", "password"},
+	txEntity.MPESA:       {"username", "password"},
+}
+
+// PlatformMediums are the mediums SocialPay runs processors for and admins can enable
+var PlatformMediums = []txEntity.TransactionMedium{
+	txEntity.TELEBIRR,
+	txEntity.CBE,
+	txEntity.AWASH,
+	txEntity.CYBERSOURCE,
+	txEntity.ETHSWITCH,
+	txEntity.MPESA,
+	txEntity.KACHA,
+}
+
+// ProcessorAccount is a merchant's access to one payment medium. A merchant can only
+// pay and withdraw through enabled mediums; with own credentials, deposits are
+// collected straight into the merchant's processor account instead of SocialPay's.
+type ProcessorAccount struct {
+	ID         uuid.UUID                  `json:"id"`
+	MerchantID uuid.UUID                  `json:"merchant_id"`
+	Medium     txEntity.TransactionMedium `json:"medium"`
+	Enabled    bool                       `json:"enabled"`
+
+	// EncryptedCredentials is the sealed credential set as stored, never exposed
+	EncryptedCredentials string `json:"-"`
+	// Credentials is only populated when the account is resolved for a payment
+	Credentials       map[string]string `json:"-"`
+	HasOwnCredentials bool              `json:"has_own_credentials"`
+
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ProcessorAccountResponse describes a medium's state for a merchant
+// @Description Payment medium enablement and credential state of a merchant
+type ProcessorAccountResponse struct {
+	*ProcessorAccount
+	// CredentialFields are the fields accepted by the credentials endpoint, empty when not supported
+	CredentialFields []string `json:"credential_fields"`
+}
+
+// ProcessorAccountsListResponse lists every platform medium for a merchant
+// @Description Payment mediums of a merchant
+type ProcessorAccountsListResponse struct {
+	Accounts []ProcessorAccountResponse `json:"accounts"`
+}
+
+// SetEnabledRequest enables or disables a medium for a merchant
+// @Description Enable or disable a payment medium for a merchant
+type SetEnabledRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SetCredentialsRequest stores a merchant's own credentials for a medium
+// @Description Merchant credentials for a payment medium
+type SetCredentialsRequest struct {
+	Credentials map[string]string `json:"credentials" binding:"required"`
+}
+
+// Validate checks the credential set matches the fields required by the medium
+func (r SetCredentialsRequest) Validate(medium txEntity.TransactionMedium) error {
+	fields, ok := CredentialFields[medium]
+	if !ok {
+		return ErrOwnCredentialsNotSupported
+	}
+
+	allowed := make(map[string]bool, len(fields))
+	var missing []string
+	for _, field := range fields {
+		allowed[field] = true
+		if strings.TrimSpace(r.Credentials[field]) == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing credentials: %s", strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	for field := range r.Credentials {
+		if !allowed[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown credentials: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// IsPlatformMedium reports whether SocialPay runs a processor for the medium
+func IsPlatformMedium(medium txEntity.TransactionMedium) bool {
+	for _, m := range PlatformMediums {
+		if m == medium {
+			return true
+		}
+	}
+	return false
+}
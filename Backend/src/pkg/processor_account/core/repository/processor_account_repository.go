@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/processor_account/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// ProcessorAccountRepository defines the interface for merchant processor account operations
+type ProcessorAccountRepository interface {
+	// ListByMerchant retrieves the processor accounts of a merchant
+	ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]entity.ProcessorAccount, error)
+
+	// Get retrieves the processor account of a merchant for a medium, nil when none exists
+	Get(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium) (*entity.ProcessorAccount, error)
+
+	// SetEnabled creates the account when missing and enables or disables it
+	SetEnabled(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, enabled bool, updatedBy uuid.UUID) (*entity.ProcessorAccount, error)
+
+	// SetCredentials stores or clears the sealed credentials of an existing account
+	SetCredentials(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, encrypted *string, updatedBy uuid.UUID) (*entity.ProcessorAccount, error)
+}
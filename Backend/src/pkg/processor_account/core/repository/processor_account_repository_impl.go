@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/processor_account/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const processorAccountColumns = `id, merchant_id, medium, enabled, encrypted_credentials, updated_by, created_at, updated_at`
+
+type ProcessorAccountRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProcessorAccountRepository(db *sql.DB) ProcessorAccountRepository {
+	return &ProcessorAccountRepositoryImpl{db: db}
+}
+
+func (r *ProcessorAccountRepositoryImpl) ListByMerchant(ctx context.Context, merchantID uuid.UUID) ([]entity.ProcessorAccount, error) {
+	query := `SELECT ` + processorAccountColumns + ` FROM public.merchant_processor_accounts WHERE merchant_id = $1 ORDER BY medium`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processor accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []entity.ProcessorAccount
+	for rows.Next() {
+		account, err := scanProcessorAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan processor account: %w", err)
+		}
+		accounts = append(accounts, *account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list processor accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (r *ProcessorAccountRepositoryImpl) Get(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium) (*entity.ProcessorAccount, error) {
+	query := `SELECT ` + processorAccountColumns + ` FROM public.merchant_processor_accounts WHERE merchant_id = $1 AND medium = $2`
+
+	account, err := scanProcessorAccount(r.db.QueryRowContext(ctx, query, merchantID, string(medium)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get processor account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (r *ProcessorAccountRepositoryImpl) SetEnabled(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, enabled bool, updatedBy uuid.UUID) (*entity.ProcessorAccount, error) {
+	query := `
+		INSERT INTO public.merchant_processor_accounts (merchant_id, medium, enabled, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (merchant_id, medium) DO UPDATE
+		SET enabled = EXCLUDED.enabled,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING ` + processorAccountColumns
+
+	account, err := scanProcessorAccount(r.db.QueryRowContext(ctx, query, merchantID, string(medium), enabled, updatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update processor account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (r *ProcessorAccountRepositoryImpl) SetCredentials(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, encrypted *string, updatedBy uuid.UUID) (*entity.ProcessorAccount, error) {
+	query := `
+		UPDATE public.merchant_processor_accounts
+		SET encrypted_credentials = $3,
+			updated_by = $4,
+			updated_at = NOW()
+		WHERE merchant_id = $1 AND medium = $2
+		RETURNING ` + processorAccountColumns
+
+	account, err := scanProcessorAccount(r.db.QueryRowContext(ctx, query, merchantID, string(medium), encrypted, updatedBy))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("processor account not found")
+		}
+		return nil, fmt.Errorf("failed to update processor credentials: %w", err)
+	}
+
+	return account, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProcessorAccount(row rowScanner) (*entity.ProcessorAccount, error) {
+	var account entity.ProcessorAccount
+	var medium string
+	var encrypted sql.NullString
+	var updatedBy uuid.NullUUID
+
+	err := row.Scan(
+		&account.ID,
+		&account.MerchantID,
+		&medium,
+		&account.Enabled,
+		&encrypted,
+		&updatedBy,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Medium = txEntity.TransactionMedium(medium)
+	account.EncryptedCredentials = encrypted.String
+	account.HasOwnCredentials = encrypted.Valid && encrypted.String != ""
+	if updatedBy.Valid {
+		account.UpdatedBy = &updatedBy.UUID
+	}
+
+	return &account, nil
+}
@@ -0,0 +1,24 @@
+-- Processor Account Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.merchant_processor_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    medium VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    -- AES-GCM sealed JSON credential set, NULL when the medium runs on SocialPay's account
+    encrypted_credentials TEXT,
+    updated_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (merchant_id, medium)
+);
+
+CREATE INDEX IF NOT EXISTS idx_merchant_processor_accounts_merchant_id ON public.merchant_processor_accounts(merchant_id);
+
+-- Existing merchants keep every medium they could use before per-merchant enablement
+INSERT INTO public.merchant_processor_accounts (merchant_id, medium, enabled)
+SELECT m.id, p.medium, true
+FROM merchants.merchants m
+CROSS JOIN (VALUES ('TELEBIRR'), ('CBE'), ('AWASH'), ('CYBERSOURCE'), ('ETHSWITCH'), ('MPESA'), ('KACHA')) AS p(medium)
+ON CONFLICT (merchant_id, medium) DO NOTHING;
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/processor_account/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/processor_account/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// ProcessorAccountUseCase defines the interface for merchant processor account operations
+type ProcessorAccountUseCase interface {
+	// ListAccounts retrieves the state of every platform medium for a merchant
+	ListAccounts(ctx context.Context, merchantID uuid.UUID) (*entity.ProcessorAccountsListResponse, error)
+
+	// SetEnabled enables or disables a medium for a merchant
+	SetEnabled(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, enabled bool, adminID uuid.UUID) (*entity.ProcessorAccountResponse, error)
+
+	// SetCredentials stores the merchant's own credentials for an enabled medium
+	SetCredentials(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, req *entity.SetCredentialsRequest, userID uuid.UUID) (*entity.ProcessorAccountResponse, error)
+
+	// ClearCredentials removes the merchant's own credentials so the medium runs on SocialPay's account again
+	ClearCredentials(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, userID uuid.UUID) (*entity.ProcessorAccountResponse, error)
+
+	// ResolveAccount retrieves an enabled account with its credentials decrypted
+	ResolveAccount(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium) (*entity.ProcessorAccount, error)
+
+	// FilterEnabled keeps the mediums of the list that are enabled for the merchant, preserving order
+	FilterEnabled(ctx context.Context, merchantID uuid.UUID, mediums []txEntity.TransactionMedium) ([]txEntity.TransactionMedium, error)
+
+	// RequireEnabled fails when any of the mediums is not enabled for the merchant
+	RequireEnabled(ctx context.Context, merchantID uuid.UUID, mediums []txEntity.TransactionMedium) error
+}
+
+type processorAccountUseCase struct {
+	repo   repository.ProcessorAccountRepository
+	cipher *credentialsCipher
+	log    logging.Logger
+}
+
+// NewProcessorAccountUseCase creates the processor account usecase. Without a 32 byte
+// credentials key, merchants cannot store their own credentials.
+func NewProcessorAccountUseCase(repo repository.ProcessorAccountRepository, credentialsKey []byte) ProcessorAccountUseCase {
+	log := logging.NewStdLogger("processor_account_usecase")
+
+	var c *credentialsCipher
+	if len(credentialsKey) > 0 {
+		var err error
+		c, err = newCredentialsCipher(credentialsKey)
+		if err != nil {
+			log.Error("Merchant processor credentials disabled", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return &processorAccountUseCase{
+		repo:   repo,
+		cipher: c,
+		log:    log,
+	}
+}
+
+func (uc *processorAccountUseCase) ListAccounts(ctx context.Context, merchantID uuid.UUID) (*entity.ProcessorAccountsListResponse, error) {
+	accounts, err := uc.repo.ListByMerchant(ctx, merchantID)
+	if err != nil {
+		uc.log.Error("Failed to list processor accounts", map[string]interface{}{
+			"merchant_id": merchantID,
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	byMedium := make(map[txEntity.TransactionMedium]*entity.ProcessorAccount, len(accounts))
+	for i := range accounts {
+		byMedium[accounts[i].Medium] = &accounts[i]
+	}
+
+	// Mediums never enabled for the merchant are listed as disabled
+	responses := make([]entity.ProcessorAccountResponse, 0, len(entity.PlatformMediums))
+	for _, medium := range entity.PlatformMediums {
+		account, ok := byMedium[medium]
+		if !ok {
+			account = &entity.ProcessorAccount{MerchantID: merchantID, Medium: medium}
+		}
+		responses = append(responses, buildResponse(account))
+	}
+
+	return &entity.ProcessorAccountsListResponse{Accounts: responses}, nil
+}
+
+func (uc *processorAccountUseCase) SetEnabled(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, enabled bool, adminID uuid.UUID) (*entity.ProcessorAccountResponse, error) {
+	if !entity.IsPlatformMedium(medium) {
+		return nil, fmt.Errorf("unsupported payment medium: %s", medium)
+	}
+
+	account, err := uc.repo.SetEnabled(ctx, merchantID, medium, enabled, adminID)
+	if err != nil {
+		uc.log.Error("Failed to update processor account", map[string]interface{}{
+			"merchant_id": merchantID,
+			"medium":      medium,
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	uc.log.Info("Processor account updated", map[string]interface{}{
+		"merchant_id": merchantID,
+		"medium":      medium,
+		"enabled":     enabled,
+		"admin_id":    adminID,
+	})
+
+	response := buildResponse(account)
+	return &response, nil
+}
+
+func (uc *processorAccountUseCase) SetCredentials(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, req *entity.SetCredentialsRequest, userID uuid.UUID) (*entity.ProcessorAccountResponse, error) {
+	if err := req.Validate(medium); err != nil {
+		return nil, err
+	}
+	if uc.cipher == nil {
+		return nil, entity.ErrCredentialsUnavailable
+	}
+
+	existing, err := uc.repo.Get(ctx, merchantID, medium)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || !existing.Enabled {
+		return nil, entity.ErrMediumNotEnabled
+	}
+
+	sealed, err := uc.cipher.seal(req.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.repo.SetCredentials(ctx, merchantID, medium, &sealed, userID)
+	if err != nil {
+		uc.log.Error("Failed to store processor credentials", map[string]interface{}{
+			"merchant_id": merchantID,
+			"medium":      medium,
+			"error":       err.Error(),
+		})
+		return nil, err
+	}
+
+	uc.log.Info("Merchant processor credentials stored", map[string]interface{}{
+		"merchant_id": merchantID,
+		"medium":      medium,
+		"user_id":     userID,
+	})
+
+	response := buildResponse(account)
+	return &response, nil
+}
+
+func (uc *processorAccountUseCase) ClearCredentials(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, userID uuid.UUID) (*entity.ProcessorAccountResponse, error) {
+	account, err := uc.repo.SetCredentials(ctx, merchantID, medium, nil, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Merchant processor credentials cleared", map[string]interface{}{
+		"merchant_id": merchantID,
+		"medium":      medium,
+		"user_id":     userID,
+	})
+
+	response := buildResponse(account)
+	return &response, nil
+}
+
+func (uc *processorAccountUseCase) ResolveAccount(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium) (*entity.ProcessorAccount, error) {
+	account, err := uc.repo.Get(ctx, merchantID, medium)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || !account.Enabled {
+		return nil, entity.ErrMediumNotEnabled
+	}
+
+	if account.HasOwnCredentials {
+		// Never fall back to SocialPay's account: the payment would settle somewhere the merchant does not expect
+		if uc.cipher == nil {
+			return nil, entity.ErrCredentialsUnavailable
+		}
+		credentials, err := uc.cipher.open(account.EncryptedCredentials)
+		if err != nil {
+			uc.log.Error("Failed to decrypt processor credentials", map[string]interface{}{
+				"merchant_id": merchantID,
+				"medium":      medium,
+				"error":       err.Error(),
+			})
+			return nil, entity.ErrCredentialsUnavailable
+		}
+		account.Credentials = credentials
+	}
+
+	return account, nil
+}
+
+func (uc *processorAccountUseCase) FilterEnabled(ctx context.Context, merchantID uuid.UUID, mediums []txEntity.TransactionMedium) ([]txEntity.TransactionMedium, error) {
+	accounts, err := uc.repo.ListByMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[txEntity.TransactionMedium]bool, len(accounts))
+	for _, account := range accounts {
+		enabled[account.Medium] = account.Enabled
+	}
+
+	filtered := make([]txEntity.TransactionMedium, 0, len(mediums))
+	for _, medium := range mediums {
+		if enabled[medium] {
+			filtered = append(filtered, medium)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (uc *processorAccountUseCase) RequireEnabled(ctx context.Context, merchantID uuid.UUID, mediums []txEntity.TransactionMedium) error {
+	enabled, err := uc.FilterEnabled(ctx, merchantID, mediums)
+	if err != nil {
+		return fmt.Errorf("failed to check enabled mediums: %w", err)
+	}
+	if len(enabled) == len(mediums) {
+		return nil
+	}
+
+	enabledSet := make(map[txEntity.TransactionMedium]bool, len(enabled))
+	for _, medium := range enabled {
+		enabledSet[medium] = true
+	}
+	for _, medium := range mediums {
+		if !enabledSet[medium] {
+			return fmt.Errorf("%w: %s", entity.ErrMediumNotEnabled, medium)
+		}
+	}
+	return nil
+}
+
+func buildResponse(account *entity.ProcessorAccount) entity.ProcessorAccountResponse {
+	fields := entity.CredentialFields[account.Medium]
+	if fields == nil {
+		fields = []string{}
+	}
+	return entity.ProcessorAccountResponse{
+		ProcessorAccount: account,
+		CredentialFields: fields,
+	}
+}
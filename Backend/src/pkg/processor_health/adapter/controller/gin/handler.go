@@ -0,0 +1,117 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/processor_health/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/processor_health/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+type Handler struct {
+	healthUseCase usecase.HealthUseCase
+	log           logging.Logger
+	jwtMiddleware gin.HandlerFunc
+	rbac          *ginMiddleware.RBACV2
+}
+
+func NewHandler(healthUseCase usecase.HealthUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		healthUseCase: healthUseCase,
+		log:           logging.NewStdLogger("processor_health_handler"),
+		jwtMiddleware: jwtMiddleware,
+		rbac:          rbac,
+	}
+}
+
+// RegisterRouter sets up the admin processor health routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	admin := router.Group("/admin/processor_health", h.jwtMiddleware)
+	admin.GET("",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_PROCESSOR_HEALTH, auth_entity.OPERATION_ADMIN_READ),
+		h.ListHealth)
+	admin.PUT("/:medium/override",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_PROCESSOR_HEALTH, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.SetOverride)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// ListHealth godoc
+// @Summary      Get processor health
+// @Description  Rolling success rate, latency, error classes, circuit breaker state and manual override of every payment medium on this instance
+// @Tags         Admin Processor Health
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.ProcessorHealthListResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/processor_health [get]
+func (h *Handler) ListHealth(c *gin.Context) {
+	response, err := h.healthUseCase.ListHealth(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to list processor health", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetOverride godoc
+// @Summary      Disable or re-enable a payment medium
+// @Description  Manually disable a payment medium for every merchant with a message shown to payers, or re-enable it
+// @Tags         Admin Processor Health
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        medium   path  string                     true  "Payment medium"
+// @Param        request  body  entity.SetOverrideRequest  true  "Override"
+// @Success      200  {object}  entity.MediumOverride
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/processor_health/{medium}/override [put]
+func (h *Handler) SetOverride(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.SetOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	medium := txEntity.TransactionMedium(strings.ToUpper(c.Param("medium")))
+	override, err := h.healthUseCase.SetOverride(c.Request.Context(), medium, &req, adminID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation failed") || strings.HasPrefix(err.Error(), "unsupported payment medium") {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, override)
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// ErrorClass groups processor errors by what they say about the provider's health
+type ErrorClass string
+
+const (
+	// ErrorClassTimeout is a provider call that did not answer in time
+	ErrorClassTimeout ErrorClass = "TIMEOUT"
+	// ErrorClassNetwork is a provider call that could not be delivered
+	ErrorClassNetwork ErrorClass = "NETWORK"
+	// ErrorClassProvider is an answer from the provider rejecting the call. It lowers the
+	// success rate but does not open the circuit, since payer errors end up here too.
+	ErrorClassProvider ErrorClass = "PROVIDER"
+)
+
+// BreakerState is the state of a medium's circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed lets every payment through
+	BreakerClosed BreakerState = "CLOSED"
+	// BreakerOpen fast-fails payments until the cooldown has passed
+	BreakerOpen BreakerState = "OPEN"
+	// BreakerHalfOpen lets a single probe payment through to test the provider
+	BreakerHalfOpen BreakerState = "HALF_OPEN"
+)
+
+// MediumOverride is an admin's manual switch for a medium, shared by every instance
+type MediumOverride struct {
+	Medium   txEntity.TransactionMedium `json:"medium"`
+	Disabled bool                       `json:"disabled"`
+	// Message is shown to payers while the medium is disabled
+	Message   string     `json:"message,omitempty"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ProcessorHealth is the rolling health of a medium's processor on this instance
+// @Description Processor health, circuit breaker state and manual override of a payment medium
+type ProcessorHealth struct {
+	Medium    txEntity.TransactionMedium `json:"medium"`
+	Available bool                       `json:"available"`
+	State     BreakerState               `json:"state"`
+
+	// Rolling window metrics over InitiatePayment, InitiateWithdrawal and QueryTransactionStatus
+	Requests     int                `json:"requests"`
+	Successes    int                `json:"successes"`
+	SuccessRate  float64            `json:"success_rate" example:"0.98"`
+	AvgLatencyMs int64              `json:"avg_latency_ms"`
+	P95LatencyMs int64              `json:"p95_latency_ms"`
+	Errors       map[ErrorClass]int `json:"errors"`
+
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	OpenedAt    *time.Time `json:"opened_at,omitempty"`
+	RetryAt     *time.Time `json:"retry_at,omitempty"`
+
+	Override *MediumOverride `json:"override,omitempty"`
+}
+
+// ProcessorHealthListResponse lists the health of every payment medium
+// @Description Health of every payment medium
+type ProcessorHealthListResponse struct {
+	WindowSeconds int               `json:"window_seconds"`
+	Processors    []ProcessorHealth `json:"processors"`
+}
+
+// SetOverrideRequest disables or re-enables a medium for every merchant
+// @Description Manually disable or re-enable a payment medium
+type SetOverrideRequest struct {
+	Disabled bool   `json:"disabled"`
+	Message  string `json:"message" example:"Telebirr is under maintenance until 14:00, please use another payment method"`
+}
+
+// Validate requires a customer-facing message when disabling
+func (r SetOverrideRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Message, validation.When(r.Disabled, validation.Required), validation.Length(0, 255)),
+	)
+}
+
+// MediumUnavailableError is returned when a medium cannot take payments, either because an
+// admin disabled it or because its circuit is open. Error returns the payer-facing message.
+type MediumUnavailableError struct {
+	Medium  txEntity.TransactionMedium
+	Message string
+	// Alternative is another medium the payer can use instead, when one is available
+	Alternative *txEntity.TransactionMedium
+}
+
+func (e *MediumUnavailableError) Error() string {
+	return e.Message
+}
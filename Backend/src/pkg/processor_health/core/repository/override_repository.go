@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/socialpay/socialpay/src/pkg/processor_health/core/entity"
+)
+
+// OverrideRepository defines the interface for manual medium override operations
+type OverrideRepository interface {
+	// List retrieves every medium override
+	List(ctx context.Context) ([]entity.MediumOverride, error)
+
+	// Upsert creates or replaces the override of a medium
+	Upsert(ctx context.Context, override *entity.MediumOverride) error
+}
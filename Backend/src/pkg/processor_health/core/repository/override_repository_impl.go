@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/processor_health/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+type OverrideRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewOverrideRepository(db *sql.DB) OverrideRepository {
+	return &OverrideRepositoryImpl{db: db}
+}
+
+func (r *OverrideRepositoryImpl) List(ctx context.Context) ([]entity.MediumOverride, error) {
+	query := `SELECT medium, disabled, message, updated_by, updated_at FROM public.processor_medium_overrides ORDER BY medium`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list medium overrides: %w", err)
+	}
+	defer rows.Close()
+
+	var overrides []entity.MediumOverride
+	for rows.Next() {
+		var override entity.MediumOverride
+		var medium string
+		var message sql.NullString
+		var updatedBy uuid.NullUUID
+		if err := rows.Scan(&medium, &override.Disabled, &message, &updatedBy, &override.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan medium override: %w", err)
+		}
+		override.Medium = txEntity.TransactionMedium(medium)
+		override.Message = message.String
+		if updatedBy.Valid {
+			override.UpdatedBy = &updatedBy.UUID
+		}
+		overrides = append(overrides, override)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list medium overrides: %w", err)
+	}
+
+	return overrides, nil
+}
+
+func (r *OverrideRepositoryImpl) Upsert(ctx context.Context, override *entity.MediumOverride) error {
+	query := `
+		INSERT INTO public.processor_medium_overrides (medium, disabled, message, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (medium) DO UPDATE
+		SET disabled = EXCLUDED.disabled,
+			message = EXCLUDED.message,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	var message sql.NullString
+	if override.Message != "" {
+		message = sql.NullString{String: override.Message, Valid: true}
+	}
+
+	err := r.db.QueryRowContext(ctx, query,
+		string(override.Medium),
+		override.Disabled,
+		message,
+		override.UpdatedBy,
+	).Scan(&override.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save medium override: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+-- Processor Health Schema
+-- This should match the migration exactly
+
+-- Manual admin switches for payment mediums; health metrics are kept in memory
+CREATE TABLE IF NOT EXISTS public.processor_medium_overrides (
+    medium VARCHAR(50) PRIMARY KEY,
+    disabled BOOLEAN NOT NULL DEFAULT false,
+    message VARCHAR(255),
+    updated_by UUID,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/socialpay/socialpay/src/pkg/processor_health/core/entity"
+)
+
+// outcome is one processor call in the rolling window. Class is empty for successful calls.
+type outcome struct {
+	at      time.Time
+	latency time.Duration
+	class   entity.ErrorClass
+}
+
+// mediumHealth is the rolling window and circuit breaker of one medium
+type mediumHealth struct {
+	outcomes            []outcome
+	state               entity.BreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+	probing             bool
+	lastError           string
+	lastErrorAt         *time.Time
+}
+
+func newMediumHealth() *mediumHealth {
+	return &mediumHealth{state: entity.BreakerClosed}
+}
+
+// classifyError tells provider outages apart from rejected calls
+func classifyError(err error) entity.ErrorClass {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return entity.ErrorClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return entity.ErrorClassTimeout
+		}
+		return entity.ErrorClassNetwork
+	}
+	return entity.ErrorClassProvider
+}
+
+// isOutage reports whether an error class counts towards opening the circuit
+func isOutage(class entity.ErrorClass) bool {
+	return class == entity.ErrorClassTimeout || class == entity.ErrorClassNetwork
+}
+
+// prune drops outcomes that fell out of the window
+func (h *mediumHealth) prune(now time.Time, window time.Duration) {
+	cutoff := now.Add(-window)
+	i := 0
+	for i < len(h.outcomes) && h.outcomes[i].at.Before(cutoff) {
+		i++
+	}
+	h.outcomes = h.outcomes[i:]
+}
+
+// record adds a call to the window and moves the breaker. It returns true when the state changed.
+func (h *mediumHealth) record(o outcome, cfg BreakerConfig) bool {
+	h.prune(o.at, cfg.Window)
+	h.outcomes = append(h.outcomes, o)
+	h.probing = false
+	previous := h.state
+
+	if !isOutage(o.class) {
+		h.consecutiveFailures = 0
+		if h.state == entity.BreakerHalfOpen {
+			h.state = entity.BreakerClosed
+		}
+		return h.state != previous
+	}
+
+	h.consecutiveFailures++
+	switch h.state {
+	case entity.BreakerHalfOpen:
+		h.open(o.at)
+	case entity.BreakerClosed:
+		if h.consecutiveFailures >= cfg.ConsecutiveFailures || h.outageRateExceeded(cfg) {
+			h.open(o.at)
+		}
+	}
+	return h.state != previous
+}
+
+func (h *mediumHealth) open(at time.Time) {
+	h.state = entity.BreakerOpen
+	h.openedAt = at
+}
+
+func (h *mediumHealth) outageRateExceeded(cfg BreakerConfig) bool {
+	if len(h.outcomes) < cfg.MinRequests {
+		return false
+	}
+	outages := 0
+	for _, o := range h.outcomes {
+		if isOutage(o.class) {
+			outages++
+		}
+	}
+	return float64(outages)/float64(len(h.outcomes)) >= cfg.OutageRate
+}
+
+// allow decides whether a payment may be sent, letting a single probe through after the cooldown
+func (h *mediumHealth) allow(now time.Time, cfg BreakerConfig) bool {
+	switch h.state {
+	case entity.BreakerOpen:
+		if now.Sub(h.openedAt) < cfg.Cooldown {
+			return false
+		}
+		h.state = entity.BreakerHalfOpen
+		h.probing = true
+		return true
+	case entity.BreakerHalfOpen:
+		if h.probing {
+			return false
+		}
+		h.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// available reports whether the medium should be offered to payers right now
+func (h *mediumHealth) available(now time.Time, cfg BreakerConfig) bool {
+	return h.state != entity.BreakerOpen || now.Sub(h.openedAt) >= cfg.Cooldown
+}
+
+func (h *mediumHealth) snapshot(now time.Time, cfg BreakerConfig) entity.ProcessorHealth {
+	h.prune(now, cfg.Window)
+
+	snapshot := entity.ProcessorHealth{
+		State:       h.state,
+		Available:   h.available(now, cfg),
+		Requests:    len(h.outcomes),
+		Errors:      map[entity.ErrorClass]int{},
+		LastError:   h.lastError,
+		LastErrorAt: h.lastErrorAt,
+	}
+
+	latencies := make([]time.Duration, 0, len(h.outcomes))
+	var total time.Duration
+	for _, o := range h.outcomes {
+		latencies = append(latencies, o.latency)
+		total += o.latency
+		if o.class == "" {
+			snapshot.Successes++
+		} else {
+			snapshot.Errors[o.class]++
+		}
+	}
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		snapshot.SuccessRate = float64(snapshot.Successes) / float64(len(latencies))
+		snapshot.AvgLatencyMs = (total / time.Duration(len(latencies))).Milliseconds()
+		snapshot.P95LatencyMs = latencies[(len(latencies)*95-1)/100].Milliseconds()
+	}
+
+	if h.state != entity.BreakerClosed {
+		openedAt := h.openedAt
+		retryAt := h.openedAt.Add(cfg.Cooldown)
+		snapshot.OpenedAt = &openedAt
+		snapshot.RetryAt = &retryAt
+	}
+
+	return snapshot
+}
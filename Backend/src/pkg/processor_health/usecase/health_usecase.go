@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	processorAccountEntity "github.com/socialpay/socialpay/src/pkg/processor_account/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/processor_health/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/processor_health/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// HealthUseCase tracks processor health and decides whether a medium can take payments
+type HealthUseCase interface {
+	// Record adds the result of a processor call to the medium's rolling window
+	Record(medium txEntity.TransactionMedium, operation string, latency time.Duration, err error)
+
+	// Allow returns a *entity.MediumUnavailableError when the medium is disabled or its circuit is open
+	Allow(ctx context.Context, medium txEntity.TransactionMedium) error
+
+	// Check is Allow without letting a probe through, for deciding which mediums to offer payers
+	Check(ctx context.Context, medium txEntity.TransactionMedium) error
+
+	// ListHealth retrieves the health of every payment medium
+	ListHealth(ctx context.Context) (*entity.ProcessorHealthListResponse, error)
+
+	// SetOverride disables a medium with a payer-facing message, or re-enables it
+	SetOverride(ctx context.Context, medium txEntity.TransactionMedium, req *entity.SetOverrideRequest, adminID uuid.UUID) (*entity.MediumOverride, error)
+}
+
+// BreakerConfig tunes the circuit breaker. Zero values fall back to the defaults.
+type BreakerConfig struct {
+	// Window is how far back metrics and the outage rate look. Defaults to 5 minutes.
+	Window time.Duration
+	// ConsecutiveFailures opens the circuit after this many outages in a row. Defaults to 5.
+	ConsecutiveFailures int
+	// OutageRate opens the circuit once this share of the window are outages. Defaults to 0.5.
+	OutageRate float64
+	// MinRequests is the window size needed before OutageRate applies. Defaults to 10.
+	MinRequests int
+	// Cooldown is how long an open circuit fast-fails before a probe is let through. Defaults to 30 seconds.
+	Cooldown time.Duration
+	// OverrideRefresh is how often admin overrides are reloaded from the database. Defaults to 30 seconds.
+	OverrideRefresh time.Duration
+}
+
+type healthUseCase struct {
+	repo repository.OverrideRepository
+	cfg  BreakerConfig
+
+	mu      sync.Mutex
+	mediums map[txEntity.TransactionMedium]*mediumHealth
+
+	overridesMu       sync.RWMutex
+	overrides         map[txEntity.TransactionMedium]entity.MediumOverride
+	overridesLoadedAt time.Time
+
+	log logging.Logger
+}
+
+func NewHealthUseCase(repo repository.OverrideRepository, cfg BreakerConfig) HealthUseCase {
+	if cfg.Window <= 0 {
+		cfg.Window = 5 * time.Minute
+	}
+	if cfg.ConsecutiveFailures <= 0 {
+		cfg.ConsecutiveFailures = 5
+	}
+	if cfg.OutageRate <= 0 {
+		cfg.OutageRate = 0.5
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = 10
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second
+	}
+	if cfg.OverrideRefresh <= 0 {
+		cfg.OverrideRefresh = 30 * time.Second
+	}
+
+	return &healthUseCase{
+		repo:      repo,
+		cfg:       cfg,
+		mediums:   make(map[txEntity.TransactionMedium]*mediumHealth),
+		overrides: make(map[txEntity.TransactionMedium]entity.MediumOverride),
+		log:       logging.NewStdLogger("[PROCESSOR-HEALTH]"),
+	}
+}
+
+func (uc *healthUseCase) Record(medium txEntity.TransactionMedium, operation string, latency time.Duration, err error) {
+	o := outcome{at: time.Now(), latency: latency}
+	if err != nil {
+		o.class = classifyError(err)
+	}
+
+	uc.mu.Lock()
+	h := uc.health(medium)
+	if err != nil {
+		at := o.at
+		h.lastError = err.Error()
+		h.lastErrorAt = &at
+	}
+	changed := h.record(o, uc.cfg)
+	state := h.state
+	uc.mu.Unlock()
+
+	if changed {
+		fields := map[string]interface{}{
+			"medium":    medium,
+			"state":     state,
+			"operation": operation,
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+			fields["error_class"] = o.class
+		}
+		if state == entity.BreakerOpen {
+			uc.log.Warn("Processor circuit opened", fields)
+		} else {
+			uc.log.Info("Processor circuit state changed", fields)
+		}
+	}
+}
+
+func (uc *healthUseCase) Allow(ctx context.Context, medium txEntity.TransactionMedium) error {
+	if override, ok := uc.override(ctx, medium); ok && override.Disabled {
+		return &entity.MediumUnavailableError{Medium: medium, Message: override.Message}
+	}
+
+	uc.mu.Lock()
+	allowed := uc.health(medium).allow(time.Now(), uc.cfg)
+	uc.mu.Unlock()
+	if !allowed {
+		return circuitOpenError(medium)
+	}
+
+	return nil
+}
+
+func (uc *healthUseCase) Check(ctx context.Context, medium txEntity.TransactionMedium) error {
+	if override, ok := uc.override(ctx, medium); ok && override.Disabled {
+		return &entity.MediumUnavailableError{Medium: medium, Message: override.Message}
+	}
+
+	uc.mu.Lock()
+	available := uc.health(medium).available(time.Now(), uc.cfg)
+	uc.mu.Unlock()
+	if !available {
+		return circuitOpenError(medium)
+	}
+
+	return nil
+}
+
+func circuitOpenError(medium txEntity.TransactionMedium) error {
+	return &entity.MediumUnavailableError{
+		Medium:  medium,
+		Message: fmt.Sprintf("%s is temporarily unavailable, please try another payment method", medium),
+	}
+}
+
+func (uc *healthUseCase) ListHealth(ctx context.Context) (*entity.ProcessorHealthListResponse, error) {
+	if err := uc.loadOverrides(ctx); err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	mediums := make(map[txEntity.TransactionMedium]bool, len(processorAccountEntity.PlatformMediums))
+	for _, medium := range processorAccountEntity.PlatformMediums {
+		mediums[medium] = true
+	}
+	for medium := range uc.mediums {
+		mediums[medium] = true
+	}
+
+	now := time.Now()
+	processors := make([]entity.ProcessorHealth, 0, len(mediums))
+	for medium := range mediums {
+		snapshot := uc.health(medium).snapshot(now, uc.cfg)
+		snapshot.Medium = medium
+		processors = append(processors, snapshot)
+	}
+	uc.mu.Unlock()
+
+	uc.overridesMu.RLock()
+	for i := range processors {
+		if override, ok := uc.overrides[processors[i].Medium]; ok {
+			override := override
+			processors[i].Override = &override
+			if override.Disabled {
+				processors[i].Available = false
+			}
+		}
+	}
+	uc.overridesMu.RUnlock()
+
+	sort.Slice(processors, func(i, j int) bool { return processors[i].Medium < processors[j].Medium })
+
+	return &entity.ProcessorHealthListResponse{
+		WindowSeconds: int(uc.cfg.Window.Seconds()),
+		Processors:    processors,
+	}, nil
+}
+
+func (uc *healthUseCase) SetOverride(ctx context.Context, medium txEntity.TransactionMedium, req *entity.SetOverrideRequest, adminID uuid.UUID) (*entity.MediumOverride, error) {
+	if !processorAccountEntity.IsPlatformMedium(medium) {
+		return nil, fmt.Errorf("unsupported payment medium: %s", medium)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	override := &entity.MediumOverride{
+		Medium:    medium,
+		Disabled:  req.Disabled,
+		Message:   req.Message,
+		UpdatedBy: &adminID,
+	}
+	if err := uc.repo.Upsert(ctx, override); err != nil {
+		uc.log.Error("Failed to save medium override", map[string]interface{}{
+			"medium": medium,
+			"error":  err.Error(),
+		})
+		return nil, err
+	}
+
+	uc.overridesMu.Lock()
+	uc.overrides[medium] = *override
+	uc.overridesMu.Unlock()
+
+	uc.log.Warn("Medium override updated", map[string]interface{}{
+		"medium":   medium,
+		"disabled": req.Disabled,
+		"admin_id": adminID,
+	})
+
+	return override, nil
+}
+
+// health returns the tracker of a medium; callers hold uc.mu
+func (uc *healthUseCase) health(medium txEntity.TransactionMedium) *mediumHealth {
+	h, ok := uc.mediums[medium]
+	if !ok {
+		h = newMediumHealth()
+		uc.mediums[medium] = h
+	}
+	return h
+}
+
+// override returns the admin override of a medium, reloading overrides when they are stale.
+// A failed reload keeps the last known overrides rather than blocking payments.
+func (uc *healthUseCase) override(ctx context.Context, medium txEntity.TransactionMedium) (entity.MediumOverride, bool) {
+	uc.overridesMu.RLock()
+	stale := time.Since(uc.overridesLoadedAt) >= uc.cfg.OverrideRefresh
+	uc.overridesMu.RUnlock()
+
+	if stale {
+		if err := uc.loadOverrides(ctx); err != nil {
+			uc.log.Error("Failed to reload medium overrides", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	uc.overridesMu.RLock()
+	defer uc.overridesMu.RUnlock()
+	override, ok := uc.overrides[medium]
+	return override, ok
+}
+
+func (uc *healthUseCase) loadOverrides(ctx context.Context) error {
+	overrides, err := uc.repo.List(ctx)
+	if err != nil {
+		// Retry on the next refresh instead of on every payment
+		uc.overridesMu.Lock()
+		uc.overridesLoadedAt = time.Now()
+		uc.overridesMu.Unlock()
+		return err
+	}
+
+	loaded := make(map[txEntity.TransactionMedium]entity.MediumOverride, len(overrides))
+	for _, override := range overrides {
+		loaded[override.Medium] = override
+	}
+
+	uc.overridesMu.Lock()
+	uc.overrides = loaded
+	uc.overridesLoadedAt = time.Now()
+	uc.overridesMu.Unlock()
+	return nil
+}
@@ -2,15 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
 
 	"github.com/google/uuid"
 
+	accessListEntity "github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
+	accessListUsecase "github.com/socialpay/socialpay/src/pkg/access_list/usecase"
 	commission_usecase "github.com/socialpay/socialpay/src/pkg/commission/usecase"
+	limitsEntity "github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+	limitsUsecase "github.com/socialpay/socialpay/src/pkg/limits/usecase"
+	processorAccountUsecase "github.com/socialpay/socialpay/src/pkg/processor_account/usecase"
 	"github.com/socialpay/socialpay/src/pkg/qr/core/entity"
 	"github.com/socialpay/socialpay/src/pkg/qr/core/repository"
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	riskUsecase "github.com/socialpay/socialpay/src/pkg/risk/usecase"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
 	"github.com/socialpay/socialpay/src/pkg/shared/pagination"
 	"github.com/socialpay/socialpay/src/pkg/shared/payment"
@@ -18,6 +26,7 @@ import (
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 	txRepo "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
 	transaction_usecase "github.com/socialpay/socialpay/src/pkg/transaction/usecase"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
 	walletUsecase "github.com/socialpay/socialpay/src/pkg/wallet/usecase"
 )
 
@@ -45,13 +54,24 @@ type QRUseCase interface {
 	ProcessQRPayment(ctx context.Context, qrLinkID uuid.UUID, req *entity.QRPaymentRequest) (*entity.QRPaymentResponse, error)
 }
 
+// MerchantEligibility decides whether a merchant's status lets it accept payments. The merchant usecase implements it.
+type MerchantEligibility interface {
+	CheckEligibility(ctx context.Context, merchantID uuid.UUID, operation merchantEntity.MerchantOperation) (bool, error)
+}
+
 type qrUseCase struct {
 	qrRepo                     repository.QRRepository
 	transactionRepo            txRepo.TransactionRepository
 	transactionUseCase         transaction_usecase.TransactionUseCase
+	statusTransitions          transaction_usecase.StatusTransitionUseCase
 	paymentService             socialpayUsecase.PaymentProcessor
 	walletUseCase              walletUsecase.MerchantWalletUsecase
+	processorAccounts          processorAccountUsecase.ProcessorAccountUseCase
 	transactionCreationService *socialpayUsecase.TransactionCreationService
+	risk                       riskUsecase.RiskUseCase
+	limits                     limitsUsecase.LimitsUseCase
+	accessLists                accessListUsecase.AccessListUseCase
+	merchants                  MerchantEligibility
 	log                        logging.Logger
 }
 
@@ -59,20 +79,32 @@ func NewQRUseCase(
 	qrRepo repository.QRRepository,
 	transactionRepo txRepo.TransactionRepository,
 	transactionUseCase transaction_usecase.TransactionUseCase,
+	statusTransitions transaction_usecase.StatusTransitionUseCase,
 	paymentService socialpayUsecase.PaymentProcessor,
 	walletUseCase walletUsecase.MerchantWalletUsecase,
 	commissionUseCase commission_usecase.CommissionUseCase,
+	processorAccounts processorAccountUsecase.ProcessorAccountUseCase,
+	risk riskUsecase.RiskUseCase,
+	limits limitsUsecase.LimitsUseCase,
+	accessLists accessListUsecase.AccessListUseCase,
+	merchants MerchantEligibility,
 ) QRUseCase {
 	logger := logging.NewStdLogger("qr_usecase")
-	transactionCreationService := socialpayUsecase.NewTransactionCreationService(commissionUseCase, logger)
+	transactionCreationService := socialpayUsecase.NewTransactionCreationService(commissionUseCase, processorAccounts, logger)
 
 	return &qrUseCase{
 		qrRepo:                     qrRepo,
 		transactionRepo:            transactionRepo,
 		transactionUseCase:         transactionUseCase,
+		statusTransitions:          statusTransitions,
 		paymentService:             paymentService,
 		walletUseCase:              walletUseCase,
+		processorAccounts:          processorAccounts,
 		transactionCreationService: transactionCreationService,
+		risk:                       risk,
+		limits:                     limits,
+		accessLists:                accessLists,
+		merchants:                  merchants,
 		log:                        logger,
 	}
 }
@@ -92,6 +124,10 @@ func (uc *qrUseCase) CreateQRLink(ctx context.Context, userID, merchantID uuid.U
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	if err := uc.requireMethodsEnabled(ctx, merchantID, req.SupportedMethods); err != nil {
+		return nil, err
+	}
+
 	qrLink := &entity.QRLink{
 		ID:               uuid.New(),
 		UserID:           userID,
@@ -134,6 +170,30 @@ func (uc *qrUseCase) GetQRLink(ctx context.Context, id uuid.UUID) (*entity.QRLin
 		return nil, fmt.Errorf("failed to get QR link: %w", err)
 	}
 
+	// Payers are only offered the methods the merchant can currently be paid through
+	if uc.processorAccounts != nil {
+		enabled, err := uc.processorAccounts.FilterEnabled(ctx, qrLink.MerchantID, qrLink.SupportedMethods)
+		if err != nil {
+			uc.log.Error("Failed to filter enabled QR payment methods", map[string]interface{}{
+				"qr_link_id": id,
+				"error":      err.Error(),
+			})
+			return nil, fmt.Errorf("failed to get QR link: %w", err)
+		}
+		qrLink.SupportedMethods = enabled
+	}
+	qrLink.SupportedMethods = uc.paymentService.AvailableMediums(ctx, qrLink.SupportedMethods)
+
+	// Links of suspended and terminated merchants stay in place but cannot be paid
+	if qrLink.IsActive && uc.merchants != nil {
+		if _, err := uc.merchants.CheckEligibility(ctx, qrLink.MerchantID, merchantEntity.OperationCollect); err != nil {
+			if !errors.Is(err, merchantEntity.ErrMerchantIneligible) {
+				return nil, fmt.Errorf("failed to get QR link: %w", err)
+			}
+			qrLink.IsActive = false
+		}
+	}
+
 	return uc.buildQRLinkResponse(qrLink), nil
 }
 
@@ -199,6 +259,16 @@ func (uc *qrUseCase) UpdateQRLink(ctx context.Context, id, userID uuid.UUID, req
 		"user_id":    userID,
 	})
 
+	if len(req.SupportedMethods) > 0 {
+		existing, err := uc.qrRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update QR link: %w", err)
+		}
+		if err := uc.requireMethodsEnabled(ctx, existing.MerchantID, req.SupportedMethods); err != nil {
+			return nil, err
+		}
+	}
+
 	updatedQRLink, err := uc.qrRepo.Update(ctx, id, userID, req)
 	if err != nil {
 		uc.log.Error("Failed to update QR link", map[string]interface{}{
@@ -260,6 +330,12 @@ func (uc *qrUseCase) ProcessQRPayment(ctx context.Context, qrLinkID uuid.UUID, r
 		return nil, fmt.Errorf("QR link is not active")
 	}
 
+	if uc.merchants != nil {
+		if _, err := uc.merchants.CheckEligibility(ctx, qrLink.MerchantID, merchantEntity.OperationCollect); err != nil {
+			return nil, err
+		}
+	}
+
 	// Determine payment amount
 	var paymentAmount float64
 	if qrLink.Type == entity.STATIC {
@@ -285,6 +361,9 @@ func (uc *qrUseCase) ProcessQRPayment(ctx context.Context, qrLinkID uuid.UUID, r
 	if !mediumSupported {
 		return nil, fmt.Errorf("payment medium %s is not supported by this QR link", req.Medium)
 	}
+	if err := uc.paymentService.CheckMediumAvailable(ctx, req.Medium, qrLink.SupportedMethods); err != nil {
+		return nil, err
+	}
 
 	// Determine transaction tag based on QR link tag
 	var transactionTag string
@@ -380,9 +459,27 @@ func (uc *qrUseCase) ProcessQRPayment(ctx context.Context, qrLinkID uuid.UUID, r
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	if err := uc.screenAccessLists(ctx, mainTx); err != nil {
+		return nil, err
+	}
+
+	if err := uc.enforceLimits(ctx, mainTx); err != nil {
+		return nil, err
+	}
+
+	held, err := uc.screenPayment(ctx, mainTx)
+	if err != nil {
+		return nil, err
+	}
+	if held != nil {
+		held.PaymentAmount = totalAmountIncludingTip
+		return held, nil
+	}
+
 	// Process main payment
 	paymentReq := &payment.PaymentRequest{
 		TransactionID: mainTx.Id,
+		MerchantID:    mainTx.MerchantId,
 		Medium:        req.Medium,
 		Amount:        mainTx.CustomerNet,
 		Currency:      mainTx.Currency,
@@ -402,15 +499,13 @@ func (uc *qrUseCase) ProcessQRPayment(ctx context.Context, qrLinkID uuid.UUID, r
 		uc.log.Error("QR payment processing failed", map[string]interface{}{
 			"error": err.Error(),
 		})
-		mainTx.Status = txEntity.FAILED
 		mainTx.Comment = err.Error()
-		_ = uc.transactionRepo.Update(ctx, mainTx)
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, mainTx, txEntity.FAILED, err.Error())
 		return nil, fmt.Errorf("failed to process payment: %w", err)
 	}
 
 	// Update main transaction status
-	mainTx.Status = txEntity.TransactionStatus(paymentResp.Status)
-	if err := uc.transactionRepo.Update(ctx, mainTx); err != nil {
+	if err := uc.statusTransitions.ApplyProcessorStatus(ctx, mainTx, paymentResp.Status, paymentResp.Message); err != nil {
 		uc.log.Error("Failed to update QR transaction status", map[string]interface{}{
 			"error": err.Error(),
 		})
@@ -459,6 +554,14 @@ func (uc *qrUseCase) ProcessQRPayment(ctx context.Context, qrLinkID uuid.UUID, r
 	return response, nil
 }
 
+// requireMethodsEnabled rejects QR links offering methods the merchant has not been enabled for
+func (uc *qrUseCase) requireMethodsEnabled(ctx context.Context, merchantID uuid.UUID, methods []txEntity.TransactionMedium) error {
+	if uc.processorAccounts == nil {
+		return nil
+	}
+	return uc.processorAccounts.RequireEnabled(ctx, merchantID, methods)
+}
+
 func (uc *qrUseCase) buildQRLinkResponse(qrLink *entity.QRLink) *entity.QRLinkResponse {
 	return &entity.QRLinkResponse{
 		QRLink:     qrLink,
@@ -493,3 +596,154 @@ func (uc *qrUseCase) buildPaymentDescription(qrLink *entity.QRLink) string {
 func RoundToTwoDecimals(value float64) float64 {
 	return math.Round(value*100) / 100
 }
+
+// screenPayment runs a stored QR payment through the risk engine. A blocked payment is failed and
+// one sent to review is held in PENDING_REVIEW, with the held response returned, until an admin
+// clears it. A payment that cannot be assessed is failed, unless the risk engine is configured to
+// fail open.
+func (uc *qrUseCase) screenPayment(ctx context.Context, tx *txEntity.Transaction) (*entity.QRPaymentResponse, error) {
+	if uc.risk == nil {
+		return nil, nil
+	}
+
+	assessment, err := uc.risk.Assess(ctx, tx, riskEntity.FlowQR)
+	if err != nil {
+		if uc.risk.FailOpen() {
+			uc.log.Warn("Risk assessment failed, continuing without it as configured", map[string]interface{}{
+				"transaction_id": tx.Id,
+				"error":          err.Error(),
+			})
+			return nil, nil
+		}
+		uc.log.Error("Risk assessment failed", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+		tx.Comment = "risk assessment failed"
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, tx.Comment)
+		return nil, fmt.Errorf("failed to assess payment risk: %w", err)
+	}
+	if assessment == nil {
+		return nil, nil
+	}
+	if assessment.Outcome == riskEntity.OutcomeReview {
+		return uc.holdForReview(ctx, tx, assessment)
+	}
+	if assessment.Outcome != riskEntity.OutcomeBlock {
+		return nil, nil
+	}
+
+	tx.Comment = riskEntity.ErrBlocked.Error()
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceRiskEngine,
+		Actor:         "risk_engine",
+		Reason:        "blocked by risk rules: " + assessment.Summary(),
+	}); err != nil {
+		uc.log.Error("Failed to fail blocked QR payment", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"assessment_id":  assessment.ID,
+			"error":          err.Error(),
+		})
+	}
+
+	return nil, riskEntity.ErrBlocked
+}
+
+// holdForReview parks a stored QR payment before it reaches the processor until an admin reviews it
+func (uc *qrUseCase) holdForReview(ctx context.Context, tx *txEntity.Transaction, assessment *riskEntity.Assessment) (*entity.QRPaymentResponse, error) {
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.PENDING_REVIEW,
+		Source:        txEntity.StatusSourceRiskEngine,
+		Actor:         "risk_engine",
+		Reason:        "held for risk review: " + assessment.Summary(),
+	}); err != nil {
+		uc.log.Error("Failed to hold QR payment for risk review", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"assessment_id":  assessment.ID,
+			"error":          err.Error(),
+		})
+		tx.Comment = err.Error()
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
+		return nil, fmt.Errorf("failed to hold payment for risk review: %w", err)
+	}
+	tx.Status = txEntity.PENDING_REVIEW
+
+	return &entity.QRPaymentResponse{
+		Success:                true,
+		Status:                 string(txEntity.PENDING_REVIEW),
+		Message:                "Payment is under review",
+		SocialPayTransactionID: tx.Id.String(),
+	}, nil
+}
+
+// enforceLimits checks a stored QR payment against the limits of its merchant. A payment over a
+// limit, or one the limits could not be checked for, is failed before it reaches the processor.
+func (uc *qrUseCase) enforceLimits(ctx context.Context, tx *txEntity.Transaction) error {
+	if uc.limits == nil {
+		return nil
+	}
+
+	err := uc.limits.Enforce(ctx, tx)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, limitsEntity.ErrLimitExceeded) {
+		err = fmt.Errorf("failed to check transaction limits: %w", err)
+	}
+
+	tx.Comment = err.Error()
+	if _, transitionErr := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceLimits,
+		Actor:         "transaction_limits",
+		Reason:        err.Error(),
+	}); transitionErr != nil {
+		uc.log.Error("Failed to fail QR payment over its limits", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          transitionErr.Error(),
+		})
+	}
+
+	return err
+}
+
+// screenAccessLists checks the parties to a stored QR payment against the blocklist. A payment
+// involving a blocked party, or one that could not be screened, is failed before it reaches the processor.
+func (uc *qrUseCase) screenAccessLists(ctx context.Context, tx *txEntity.Transaction) error {
+	if uc.accessLists == nil {
+		return nil
+	}
+
+	block, err := uc.accessLists.Screen(ctx, tx)
+	var reason string
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to screen access lists: %w", err)
+		reason = err.Error()
+	case block == nil:
+		return nil
+	default:
+		err = accessListEntity.ErrBlocked
+		reason = fmt.Sprintf("%s blocked by access list entry %s (%s)", block.Type, block.ID, block.ReasonCode)
+	}
+
+	tx.Comment = err.Error()
+	if _, transitionErr := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceAccessList,
+		Actor:         "access_list",
+		Reason:        reason,
+	}); transitionErr != nil {
+		uc.log.Error("Failed to fail QR payment refused by access list", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          transitionErr.Error(),
+		})
+	}
+
+	return err
+}
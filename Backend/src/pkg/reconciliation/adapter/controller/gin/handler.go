@@ -0,0 +1,399 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// maxStatementSize bounds uploaded statements; a month of provider activity fits comfortably
+const maxStatementSize = 20 << 20
+
+type Handler struct {
+	reconciliationUseCase usecase.ReconciliationUseCase
+	log                   logging.Logger
+	jwtMiddleware         gin.HandlerFunc
+	rbac                  *ginMiddleware.RBACV2
+}
+
+func NewHandler(reconciliationUseCase usecase.ReconciliationUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		reconciliationUseCase: reconciliationUseCase,
+		log:                   logging.NewStdLogger("reconciliation_handler"),
+		jwtMiddleware:         jwtMiddleware,
+		rbac:                  rbac,
+	}
+}
+
+// RegisterRouter sets up the admin reconciliation routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	admin := router.Group("/admin/reconciliation", h.jwtMiddleware)
+	admin.POST("/statements",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RECONCILIATION, auth_entity.OPERATION_ADMIN_CREATE),
+		h.ImportStatement)
+	admin.GET("/runs",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RECONCILIATION, auth_entity.OPERATION_ADMIN_READ),
+		h.ListRuns)
+	admin.GET("/exceptions",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RECONCILIATION, auth_entity.OPERATION_ADMIN_READ),
+		h.ListExceptions)
+	admin.POST("/exceptions/:id/resolve",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RECONCILIATION, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.ResolveException)
+	admin.GET("/summary",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RECONCILIATION, auth_entity.OPERATION_ADMIN_READ),
+		h.DailySummary)
+	admin.GET("/mappings/:medium",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RECONCILIATION, auth_entity.OPERATION_ADMIN_READ),
+		h.GetColumnMapping)
+	admin.PUT("/mappings/:medium",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RECONCILIATION, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.SetColumnMapping)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// ImportStatement godoc
+// @Summary      Import a provider statement
+// @Description  Upload a CSV or XLSX settlement statement of a payment medium and reconcile it with transactions and merchant wallets. The period defaults to the days on the statement.
+// @Tags         Admin Reconciliation
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file          formData  file    true   "Statement (.csv or .xlsx)"
+// @Param        medium        formData  string  true   "Payment medium"
+// @Param        period_start  formData  string  false  "Period start (RFC 3339)"
+// @Param        period_end    formData  string  false  "Period end, exclusive (RFC 3339)"
+// @Success      201  {object}  entity.ImportResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /admin/reconciliation/statements [post]
+func (h *Handler) ImportStatement(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("statement file is required")))
+		return
+	}
+	if fileHeader.Size > maxStatementSize {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("statement must be at most %d MB", maxStatementSize>>20)))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("failed to open statement: %w", err)))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxStatementSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("failed to read statement: %w", err)))
+		return
+	}
+
+	req := &entity.ImportRequest{
+		Medium:     txEntity.TransactionMedium(strings.ToUpper(strings.TrimSpace(c.PostForm("medium")))),
+		FileName:   fileHeader.Filename,
+		Content:    content,
+		UploadedBy: adminID,
+	}
+	if req.Medium == "" {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("medium is required")))
+		return
+	}
+	if req.PeriodStart, err = optionalTime(c.PostForm("period_start")); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid period_start: %w", err)))
+		return
+	}
+	if req.PeriodEnd, err = optionalTime(c.PostForm("period_end")); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid period_end: %w", err)))
+		return
+	}
+
+	response, err := h.reconciliationUseCase.ImportStatement(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListRuns godoc
+// @Summary      List statement imports
+// @Description  List the latest reconciliation runs with their counts
+// @Tags         Admin Reconciliation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        medium  query  string  false  "Payment medium"
+// @Param        limit   query  int     false  "Number of runs (max 100)"
+// @Success      200  {object}  entity.RunListResponse
+// @Router       /admin/reconciliation/runs [get]
+func (h *Handler) ListRuns(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	response, err := h.reconciliationUseCase.ListRuns(c.Request.Context(), mediumQuery(c), limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListExceptions godoc
+// @Summary      List reconciliation exceptions
+// @Description  List the exceptions queue, open exceptions by default, oldest activity first
+// @Tags         Admin Reconciliation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        medium            query  string  false  "Payment medium"
+// @Param        status            query  string  false  "Item status (MISSING_ON_OUR_SIDE, MISSING_AT_PROVIDER, AMOUNT_MISMATCH, STATUS_MISMATCH, WALLET_MISMATCH)"
+// @Param        exception_status  query  string  false  "OPEN (default), RESOLVED or ALL"
+// @Param        run_id            query  string  false  "Reconciliation run ID"
+// @Param        page              query  int     false  "Page"
+// @Param        page_size         query  int     false  "Page size (max 100)"
+// @Success      200  {object}  entity.ExceptionListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/reconciliation/exceptions [get]
+func (h *Handler) ListExceptions(c *gin.Context) {
+	filter := entity.ExceptionFilter{
+		Medium:          mediumQuery(c),
+		Status:          entity.ItemStatus(strings.ToUpper(c.Query("status"))),
+		ExceptionStatus: entity.ExceptionOpen,
+	}
+	switch strings.ToUpper(c.Query("exception_status")) {
+	case "", string(entity.ExceptionOpen):
+	case string(entity.ExceptionResolved):
+		filter.ExceptionStatus = entity.ExceptionResolved
+	case "ALL":
+		filter.ExceptionStatus = ""
+	default:
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid exception_status")))
+		return
+	}
+	if runID := c.Query("run_id"); runID != "" {
+		id, err := uuid.Parse(runID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid run ID")))
+			return
+		}
+		filter.RunID = &id
+	}
+	filter.Page, _ = strconv.Atoi(c.Query("page"))
+	filter.PageSize, _ = strconv.Atoi(c.Query("page_size"))
+
+	response, err := h.reconciliationUseCase.ListExceptions(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResolveException godoc
+// @Summary      Resolve a reconciliation exception
+// @Description  Close an exception. ACKNOWLEDGE records an explanation, REQUERY closes it when the provider's live status agrees with the transaction, APPLY_PROVIDER_STATUS moves the transaction to the status on the statement.
+// @Tags         Admin Reconciliation
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                 true  "Reconciliation item ID"
+// @Param        request  body  entity.ResolveRequest  true  "Resolution"
+// @Success      200  {object}  entity.Item
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /admin/reconciliation/exceptions/{id}/resolve [post]
+func (h *Handler) ResolveException(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid reconciliation item ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.ResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	req.Action = entity.ResolveAction(strings.ToUpper(string(req.Action)))
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	item, err := h.reconciliationUseCase.ResolveException(c.Request.Context(), itemID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// DailySummary godoc
+// @Summary      Daily reconciliation summary
+// @Description  Reconciliation counts and totals per payment medium for a day of activity
+// @Tags         Admin Reconciliation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        date  query  string  false  "Day (YYYY-MM-DD), yesterday by default"
+// @Success      200  {object}  entity.DailySummaryResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/reconciliation/summary [get]
+func (h *Handler) DailySummary(c *gin.Context) {
+	date := time.Now().AddDate(0, 0, -1)
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid date, expected YYYY-MM-DD")))
+			return
+		}
+		date = parsed
+	}
+
+	response, err := h.reconciliationUseCase.DailySummary(c.Request.Context(), date)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetColumnMapping godoc
+// @Summary      Get statement column mapping
+// @Description  Get the statement column mapping of a payment medium, the provider's default export layout unless replaced
+// @Tags         Admin Reconciliation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        medium  path  string  true  "Payment medium"
+// @Success      200  {object}  entity.ColumnMappingResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/reconciliation/mappings/{medium} [get]
+func (h *Handler) GetColumnMapping(c *gin.Context) {
+	response, err := h.reconciliationUseCase.GetColumnMapping(c.Request.Context(), mediumParam(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetColumnMapping godoc
+// @Summary      Set statement column mapping
+// @Description  Replace the statement column mapping of a payment medium, e.g. after a provider changes its export
+// @Tags         Admin Reconciliation
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        medium   path  string                true  "Payment medium"
+// @Param        request  body  entity.ColumnMapping  true  "Column mapping"
+// @Success      200  {object}  entity.ColumnMappingResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/reconciliation/mappings/{medium} [put]
+func (h *Handler) SetColumnMapping(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var mapping entity.ColumnMapping
+	if err := c.ShouldBindJSON(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	response, err := h.reconciliationUseCase.SetColumnMapping(c.Request.Context(), mediumParam(c), mapping, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func mediumParam(c *gin.Context) txEntity.TransactionMedium {
+	return txEntity.TransactionMedium(strings.ToUpper(c.Param("medium")))
+}
+
+func mediumQuery(c *gin.Context) txEntity.TransactionMedium {
+	return txEntity.TransactionMedium(strings.ToUpper(c.Query("medium")))
+}
+
+func optionalTime(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrItemNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrExceptionResolved),
+		errors.Is(err, entity.ErrProviderDisagrees):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case errors.Is(err, entity.ErrActionNotApplicable),
+		errors.Is(err, entity.ErrNotAnException),
+		errors.Is(err, entity.ErrRequeryNotSupported):
+		c.JSON(http.StatusUnprocessableEntity, newErrorResponse(err))
+	case errors.Is(err, entity.ErrUnsupportedFile),
+		errors.Is(err, entity.ErrInvalidStatement),
+		errors.Is(err, entity.ErrUnsupportedMedium),
+		errors.Is(err, entity.ErrInvalidMapping):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("Reconciliation request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
@@ -0,0 +1,342 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+var (
+	// ErrUnsupportedFile is returned for statements that are neither CSV nor XLSX
+	ErrUnsupportedFile = errors.New("statement must be a .csv or .xlsx file")
+	// ErrInvalidStatement is returned for statements that cannot be read with the column mapping
+	ErrInvalidStatement = errors.New("invalid statement")
+	// ErrUnsupportedMedium is returned for mediums without a statement layout
+	ErrUnsupportedMedium = errors.New("unsupported payment medium")
+	// ErrInvalidMapping is returned for column mappings that cannot read a statement
+	ErrInvalidMapping = errors.New("invalid column mapping")
+	// ErrItemNotFound is returned for unknown reconciliation items
+	ErrItemNotFound = errors.New("reconciliation item not found")
+	// ErrActionNotApplicable is returned when the resolve action does not fit the exception
+	ErrActionNotApplicable = errors.New("resolve action does not apply to this exception")
+	// ErrRequeryNotSupported is returned when the provider has no status API
+	ErrRequeryNotSupported = errors.New("provider does not support status queries")
+	// ErrProviderDisagrees is returned when a requery still disagrees with our transaction
+	ErrProviderDisagrees = errors.New("provider status still disagrees with the transaction")
+	// ErrExceptionResolved is returned when resolving an exception that is already resolved
+	ErrExceptionResolved = errors.New("reconciliation exception already resolved")
+	// ErrNotAnException is returned when resolving a matched item
+	ErrNotAnException = errors.New("reconciliation item is matched and has nothing to resolve")
+)
+
+// ItemStatus classifies a reconciliation item
+type ItemStatus string
+
+const (
+	// ItemMatched agrees on all three sides
+	ItemMatched ItemStatus = "MATCHED"
+	// ItemMissingOnOurSide is a statement line without a transaction
+	ItemMissingOnOurSide ItemStatus = "MISSING_ON_OUR_SIDE"
+	// ItemMissingAtProvider is a successful transaction of the statement period absent from the statement
+	ItemMissingAtProvider ItemStatus = "MISSING_AT_PROVIDER"
+	// ItemAmountMismatch is a matched pair whose amounts differ
+	ItemAmountMismatch ItemStatus = "AMOUNT_MISMATCH"
+	// ItemStatusMismatch is a matched pair whose statuses differ
+	ItemStatusMismatch ItemStatus = "STATUS_MISMATCH"
+	// ItemWalletMismatch is a merchant whose wallet balance differs from its transaction history
+	ItemWalletMismatch ItemStatus = "WALLET_MISMATCH"
+)
+
+// ExceptionStatus is the state of an unmatched item in the exceptions queue
+type ExceptionStatus string
+
+const (
+	ExceptionOpen     ExceptionStatus = "OPEN"
+	ExceptionResolved ExceptionStatus = "RESOLVED"
+)
+
+// ResolveAction is how an admin closes an exception
+type ResolveAction string
+
+const (
+	// ResolveAcknowledge closes the exception as explained, e.g. a fee the provider nets off
+	ResolveAcknowledge ResolveAction = "ACKNOWLEDGE"
+	// ResolveRequery asks the provider for the current status and closes the exception when it agrees with the statement
+	ResolveRequery ResolveAction = "REQUERY"
+	// ResolveApplyProviderStatus moves the transaction to the status on the statement
+	ResolveApplyProviderStatus ResolveAction = "APPLY_PROVIDER_STATUS"
+)
+
+// AmountTolerance is the largest difference treated as equal, matching the wallet health check
+const AmountTolerance = 0.01
+
+// ColumnMapping tells the importer which statement columns hold which fields.
+// Column names are matched case-insensitively after trimming.
+type ColumnMapping struct {
+	ProviderTxID string `json:"provider_tx_id"`
+	Reference    string `json:"reference,omitempty"`
+	Amount       string `json:"amount"`
+	Date         string `json:"date"`
+	Status       string `json:"status,omitempty"`
+
+	// DateLayouts are tried in order; RFC 3339 and 2006-01-02 15:04:05 are always tried last
+	DateLayouts []string `json:"date_layouts,omitempty"`
+	// StatusValues maps provider statuses, case-insensitively, to ours. Lines without a
+	// status column are completed payments; unmapped statuses are kept as FAILED.
+	StatusValues map[string]txEntity.TransactionStatus `json:"status_values,omitempty"`
+	// HeaderRow is the 1-based row of the column names, for statements with a preamble
+	HeaderRow int `json:"header_row,omitempty"`
+	// AmountInMinorUnits divides amounts by 100
+	AmountInMinorUnits bool `json:"amount_in_minor_units,omitempty"`
+}
+
+// Validate checks the mapping names every required column
+func (m ColumnMapping) Validate() error {
+	var missing []string
+	if strings.TrimSpace(m.ProviderTxID) == "" && strings.TrimSpace(m.Reference) == "" {
+		missing = append(missing, "provider_tx_id or reference")
+	}
+	if strings.TrimSpace(m.Amount) == "" {
+		missing = append(missing, "amount")
+	}
+	if strings.TrimSpace(m.Date) == "" {
+		missing = append(missing, "date")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrInvalidMapping, strings.Join(missing, ", "))
+	}
+	if m.HeaderRow < 0 {
+		return fmt.Errorf("%w: header_row must be positive", ErrInvalidMapping)
+	}
+	for value, status := range m.StatusValues {
+		if !knownStatuses[status] {
+			return fmt.Errorf("%w: %q maps to unknown status %q", ErrInvalidMapping, value, status)
+		}
+	}
+	return nil
+}
+
+var knownStatuses = map[txEntity.TransactionStatus]bool{
+	txEntity.INITIATED:  true,
+	txEntity.PENDING:    true,
+	txEntity.SUCCESS:    true,
+	txEntity.FAILED:     true,
+	txEntity.REFUNDED:   true,
+	txEntity.EXPIRED:    true,
+	txEntity.CANCELED:   true,
+	txEntity.AUTHORIZED: true,
+}
+
+// StatementLine is one parsed row of a provider statement
+type StatementLine struct {
+	Row          int                        `json:"row"`
+	ProviderTxID string                     `json:"provider_tx_id"`
+	Reference    string                     `json:"reference,omitempty"`
+	Amount       float64                    `json:"amount"`
+	Date         time.Time                  `json:"date"`
+	Status       txEntity.TransactionStatus `json:"status"`
+	RawStatus    string                     `json:"raw_status,omitempty"`
+}
+
+// LedgerTransaction is the part of a transaction reconciliation compares
+type LedgerTransaction struct {
+	ID              uuid.UUID
+	MerchantID      uuid.UUID
+	Type            txEntity.TransactionType
+	Medium          txEntity.TransactionMedium
+	Reference       string
+	ReferenceNumber string
+	ProviderTxID    string
+	Status          txEntity.TransactionStatus
+	Amount          float64
+	CreatedAt       time.Time
+}
+
+// WalletBalance compares a merchant wallet with the balance its transaction history implies
+type WalletBalance struct {
+	MerchantID    uuid.UUID
+	Currency      string
+	WalletBalance float64
+	LedgerBalance float64
+}
+
+// Run is one imported statement
+type Run struct {
+	ID          uuid.UUID                  `json:"id"`
+	Medium      txEntity.TransactionMedium `json:"medium"`
+	FileName    string                     `json:"file_name"`
+	PeriodStart time.Time                  `json:"period_start"`
+	PeriodEnd   time.Time                  `json:"period_end"`
+	UploadedBy  uuid.UUID                  `json:"uploaded_by"`
+
+	Lines             int     `json:"lines"`
+	Matched           int     `json:"matched"`
+	MissingOnOurSide  int     `json:"missing_on_our_side"`
+	MissingAtProvider int     `json:"missing_at_provider"`
+	AmountMismatch    int     `json:"amount_mismatch"`
+	StatusMismatch    int     `json:"status_mismatch"`
+	WalletMismatch    int     `json:"wallet_mismatch"`
+	StatementTotal    float64 `json:"statement_total"`
+	MatchedTotal      float64 `json:"matched_total"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Count adds an item to the run totals
+func (r *Run) Count(item *Item) {
+	switch item.Status {
+	case ItemMatched:
+		r.Matched++
+		if item.StatementAmount != nil {
+			r.MatchedTotal += *item.StatementAmount
+		}
+	case ItemMissingOnOurSide:
+		r.MissingOnOurSide++
+	case ItemMissingAtProvider:
+		r.MissingAtProvider++
+	case ItemAmountMismatch:
+		r.AmountMismatch++
+	case ItemStatusMismatch:
+		r.StatusMismatch++
+	case ItemWalletMismatch:
+		r.WalletMismatch++
+	}
+}
+
+// Item is one reconciled statement line, transaction or wallet. Every item that is not
+// matched is an exception and stays in the queue until resolved.
+type Item struct {
+	ID     uuid.UUID                  `json:"id"`
+	RunID  uuid.UUID                  `json:"run_id"`
+	Medium txEntity.TransactionMedium `json:"medium"`
+	Status ItemStatus                 `json:"status"`
+
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	MerchantID    *uuid.UUID `json:"merchant_id,omitempty"`
+	StatementRow  *int       `json:"statement_row,omitempty"`
+	ProviderTxID  string     `json:"provider_tx_id,omitempty"`
+	Reference     string     `json:"reference,omitempty"`
+
+	StatementAmount   *float64                   `json:"statement_amount,omitempty"`
+	TransactionAmount *float64                   `json:"transaction_amount,omitempty"`
+	StatementStatus   txEntity.TransactionStatus `json:"statement_status,omitempty"`
+	TransactionStatus txEntity.TransactionStatus `json:"transaction_status,omitempty"`
+	// WalletBalance and LedgerBalance are set on wallet mismatches
+	WalletBalance *float64  `json:"wallet_balance,omitempty"`
+	LedgerBalance *float64  `json:"ledger_balance,omitempty"`
+	ActivityDate  time.Time `json:"activity_date"`
+	Detail        string    `json:"detail,omitempty"`
+
+	ExceptionStatus *ExceptionStatus `json:"exception_status,omitempty"`
+	Resolution      *ResolveAction   `json:"resolution,omitempty"`
+	ResolutionNote  string           `json:"resolution_note,omitempty"`
+	ResolvedBy      *uuid.UUID       `json:"resolved_by,omitempty"`
+	ResolvedAt      *time.Time       `json:"resolved_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsException reports whether the item belongs in the exceptions queue
+func (i *Item) IsException() bool {
+	return i.Status != ItemMatched
+}
+
+// ExceptionFilter narrows the exceptions queue
+type ExceptionFilter struct {
+	Medium          txEntity.TransactionMedium
+	Status          ItemStatus
+	ExceptionStatus ExceptionStatus
+	RunID           *uuid.UUID
+	Page            int
+	PageSize        int
+}
+
+// DailySummary is the reconciliation result of one medium for one day of activity
+type DailySummary struct {
+	Date              string                     `json:"date"`
+	Medium            txEntity.TransactionMedium `json:"medium"`
+	Items             int                        `json:"items"`
+	Matched           int                        `json:"matched"`
+	MissingOnOurSide  int                        `json:"missing_on_our_side"`
+	MissingAtProvider int                        `json:"missing_at_provider"`
+	AmountMismatch    int                        `json:"amount_mismatch"`
+	StatusMismatch    int                        `json:"status_mismatch"`
+	WalletMismatch    int                        `json:"wallet_mismatch"`
+	OpenExceptions    int                        `json:"open_exceptions"`
+	StatementTotal    float64                    `json:"statement_total"`
+	MatchedTotal      float64                    `json:"matched_total"`
+}
+
+// ImportRequest describes an uploaded statement
+type ImportRequest struct {
+	Medium   txEntity.TransactionMedium
+	FileName string
+	Content  []byte
+	// PeriodStart and PeriodEnd bound the transactions expected on the statement. When
+	// unset they are the first and last day of activity on the statement.
+	PeriodStart *time.Time
+	PeriodEnd   *time.Time
+	UploadedBy  uuid.UUID
+}
+
+// ImportResponse is the outcome of a statement import
+// @Description Reconciliation run with its exceptions
+type ImportResponse struct {
+	Run        *Run   `json:"run"`
+	Exceptions []Item `json:"exceptions"`
+}
+
+// ResolveRequest closes an exception
+// @Description Resolve a reconciliation exception
+type ResolveRequest struct {
+	Action ResolveAction `json:"action" binding:"required"`
+	Note   string        `json:"note"`
+}
+
+// Validate checks the action is known and explained
+func (r ResolveRequest) Validate() error {
+	switch r.Action {
+	case ResolveAcknowledge, ResolveApplyProviderStatus:
+		if len(strings.TrimSpace(r.Note)) < 10 {
+			return fmt.Errorf("note must explain the resolution in at least 10 characters")
+		}
+	case ResolveRequery:
+	default:
+		return fmt.Errorf("unknown resolve action %q", r.Action)
+	}
+	return nil
+}
+
+// ExceptionListResponse is a page of the exceptions queue
+// @Description Reconciliation exceptions
+type ExceptionListResponse struct {
+	Exceptions []Item `json:"exceptions"`
+	Total      int    `json:"total"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+}
+
+// RunListResponse lists statement imports
+// @Description Reconciliation runs
+type RunListResponse struct {
+	Runs []Run `json:"runs"`
+}
+
+// DailySummaryResponse lists the per medium summaries of a day
+// @Description Daily reconciliation summary per medium
+type DailySummaryResponse struct {
+	Date      string         `json:"date"`
+	Summaries []DailySummary `json:"summaries"`
+}
+
+// ColumnMappingResponse is the mapping used for a medium
+// @Description Statement column mapping of a payment medium
+type ColumnMappingResponse struct {
+	Medium  txEntity.TransactionMedium `json:"medium"`
+	Custom  bool                       `json:"custom"`
+	Mapping ColumnMapping              `json:"mapping"`
+}
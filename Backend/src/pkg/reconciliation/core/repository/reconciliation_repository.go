@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// ReconciliationRepository defines the interface for reconciliation runs, items and column mappings
+type ReconciliationRepository interface {
+	// GetColumnMapping retrieves the custom column mapping of a medium, nil when none is stored
+	GetColumnMapping(ctx context.Context, medium txEntity.TransactionMedium) (*entity.ColumnMapping, error)
+
+	// SaveColumnMapping stores the custom column mapping of a medium
+	SaveColumnMapping(ctx context.Context, medium txEntity.TransactionMedium, mapping entity.ColumnMapping, updatedBy uuid.UUID) error
+
+	// ListLedgerTransactions retrieves the transactions of a medium created in [from, to)
+	ListLedgerTransactions(ctx context.Context, medium txEntity.TransactionMedium, from, to time.Time) ([]entity.LedgerTransaction, error)
+
+	// FindLedgerTransactions retrieves transactions of a medium by provider transaction ID or reference, whatever their date
+	FindLedgerTransactions(ctx context.Context, medium txEntity.TransactionMedium, keys []string) ([]entity.LedgerTransaction, error)
+
+	// GetWalletBalances compares the wallets of merchants with their transaction history
+	GetWalletBalances(ctx context.Context, merchantIDs []uuid.UUID) ([]entity.WalletBalance, error)
+
+	// SaveRun stores a run and its items in one transaction
+	SaveRun(ctx context.Context, run *entity.Run, items []entity.Item) error
+
+	// ListRuns retrieves the latest runs, optionally of one medium
+	ListRuns(ctx context.Context, medium txEntity.TransactionMedium, limit int) ([]entity.Run, error)
+
+	// GetItem retrieves a reconciliation item, nil when it does not exist
+	GetItem(ctx context.Context, id uuid.UUID) (*entity.Item, error)
+
+	// ListExceptions retrieves the exceptions queue, oldest first
+	ListExceptions(ctx context.Context, filter entity.ExceptionFilter) ([]entity.Item, int, error)
+
+	// ResolveItem closes an open exception, failing with ErrExceptionResolved when it is already closed
+	ResolveItem(ctx context.Context, id uuid.UUID, action entity.ResolveAction, note string, resolvedBy uuid.UUID) (*entity.Item, error)
+
+	// DailySummary groups the items of a day of activity by medium
+	DailySummary(ctx context.Context, date time.Time) ([]entity.DailySummary, error)
+}
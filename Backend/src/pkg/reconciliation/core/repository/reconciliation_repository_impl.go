@@ -0,0 +1,455 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const runColumns = `id, medium, file_name, period_start, period_end, uploaded_by, lines, matched,
+	missing_on_our_side, missing_at_provider, amount_mismatch, status_mismatch, wallet_mismatch,
+	statement_total, matched_total, created_at`
+
+const itemColumns = `id, run_id, medium, status, transaction_id, merchant_id, statement_row, provider_tx_id,
+	reference, statement_amount, transaction_amount, statement_status, transaction_status, wallet_balance,
+	ledger_balance, activity_date, detail, exception_status, resolution, resolution_note, resolved_by,
+	resolved_at, created_at`
+
+// ledgerColumns reads the amount the provider collected or paid out; older rows may lack total_amount
+const ledgerColumns = `id, COALESCE(merchant_id, '00000000-0000-0000-0000-000000000000'::uuid), type, medium,
+	COALESCE(reference, ''), COALESCE(reference_number, ''), COALESCE(provider_tx_id, ''), status,
+	COALESCE(NULLIF(total_amount, 0), base_amount), created_at`
+
+type ReconciliationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReconciliationRepository(db *sql.DB) ReconciliationRepository {
+	return &ReconciliationRepositoryImpl{db: db}
+}
+
+func (r *ReconciliationRepositoryImpl) GetColumnMapping(ctx context.Context, medium txEntity.TransactionMedium) (*entity.ColumnMapping, error) {
+	query := `SELECT mapping FROM public.reconciliation_column_mappings WHERE medium = $1`
+
+	var raw []byte
+	if err := r.db.QueryRowContext(ctx, query, string(medium)).Scan(&raw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get column mapping: %w", err)
+	}
+
+	var mapping entity.ColumnMapping
+	if err := json.Unmarshal(raw, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to decode column mapping: %w", err)
+	}
+
+	return &mapping, nil
+}
+
+func (r *ReconciliationRepositoryImpl) SaveColumnMapping(ctx context.Context, medium txEntity.TransactionMedium, mapping entity.ColumnMapping, updatedBy uuid.UUID) error {
+	raw, err := json.Marshal(mapping)
+	if err != nil {
+		return fmt.Errorf("failed to encode column mapping: %w", err)
+	}
+
+	query := `
+		INSERT INTO public.reconciliation_column_mappings (medium, mapping, updated_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (medium) DO UPDATE
+		SET mapping = EXCLUDED.mapping,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()`
+
+	if _, err := r.db.ExecContext(ctx, query, string(medium), raw, updatedBy); err != nil {
+		return fmt.Errorf("failed to save column mapping: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconciliationRepositoryImpl) ListLedgerTransactions(ctx context.Context, medium txEntity.TransactionMedium, from, to time.Time) ([]entity.LedgerTransaction, error) {
+	query := `SELECT ` + ledgerColumns + `
+		FROM public.transactions
+		WHERE medium = $1 AND created_at >= $2 AND created_at < $3 AND test IS NOT TRUE
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, string(medium), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLedgerTransactions(rows)
+}
+
+func (r *ReconciliationRepositoryImpl) FindLedgerTransactions(ctx context.Context, medium txEntity.TransactionMedium, keys []string) ([]entity.LedgerTransaction, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT ` + ledgerColumns + `
+		FROM public.transactions
+		WHERE medium = $1 AND test IS NOT TRUE
+			AND (provider_tx_id = ANY($2) OR reference = ANY($2) OR reference_number = ANY($2) OR id::text = ANY($2))`
+
+	rows, err := r.db.QueryContext(ctx, query, string(medium), pq.Array(keys))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLedgerTransactions(rows)
+}
+
+func (r *ReconciliationRepositoryImpl) GetWalletBalances(ctx context.Context, merchantIDs []uuid.UUID) ([]entity.WalletBalance, error) {
+	if len(merchantIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(merchantIDs))
+	for i, id := range merchantIDs {
+		ids[i] = id.String()
+	}
+
+	// The wallet holds available plus locked funds; pending withdrawals only move money
+	// between the two. Successful payments credit merchant_net unless the merchant collected
+	// them into its own account, successful withdrawals debit it. Each currency wallet is
+	// compared with the transactions that settled into it, at the converted amount when the
+	// settlement converted currency.
+	query := `
+		SELECT w.merchant_id, w.currency, w.amount + w.locked_amount,
+			COALESCE((
+				SELECT SUM(CASE WHEN t.type = 'WITHDRAWAL' THEN -ABS(t.merchant_net)
+					ELSE COALESCE((t.details->'` + txEntity.FXDetailKey + `'->>'merchant_amount')::numeric, t.merchant_net) END)
+				FROM public.transactions t
+				WHERE t.merchant_id = w.merchant_id
+					AND COALESCE(t.details->'` + txEntity.FXDetailKey + `'->>'wallet_currency', t.currency, '` + txEntity.DefaultCurrency + `') = w.currency
+					AND t.status = 'SUCCESS'
+					AND t.test IS NOT TRUE
+					AND t.merchant_net IS NOT NULL
+					AND (t.type = 'WITHDRAWAL' OR COALESCE(t.details->>'` + txEntity.SettlementDetailKey + `', '') <> '` + txEntity.SettlementMerchantAccount + `')
+			), 0)
+		FROM merchant.wallet w
+		WHERE w.wallet_type = 'merchant' AND w.merchant_id::text = ANY($1)`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet balances: %w", err)
+	}
+	defer rows.Close()
+
+	var balances []entity.WalletBalance
+	for rows.Next() {
+		var balance entity.WalletBalance
+		if err := rows.Scan(&balance.MerchantID, &balance.Currency, &balance.WalletBalance, &balance.LedgerBalance); err != nil {
+			return nil, fmt.Errorf("failed to scan wallet balance: %w", err)
+		}
+		balances = append(balances, balance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get wallet balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+func (r *ReconciliationRepositoryImpl) SaveRun(ctx context.Context, run *entity.Run, items []entity.Item) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	runQuery := `
+		INSERT INTO public.reconciliation_runs (` + runColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err = tx.ExecContext(ctx, runQuery,
+		run.ID, string(run.Medium), run.FileName, run.PeriodStart, run.PeriodEnd, run.UploadedBy,
+		run.Lines, run.Matched, run.MissingOnOurSide, run.MissingAtProvider, run.AmountMismatch,
+		run.StatusMismatch, run.WalletMismatch, run.StatementTotal, run.MatchedTotal, run.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save reconciliation run: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO public.reconciliation_items (` + itemColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`
+
+	stmt, err := tx.PrepareContext(ctx, itemQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare reconciliation item insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, item := range items {
+		_, err := stmt.ExecContext(ctx,
+			item.ID, item.RunID, string(item.Medium), string(item.Status), item.TransactionID, item.MerchantID,
+			item.StatementRow, nullString(item.ProviderTxID), nullString(item.Reference), item.StatementAmount,
+			item.TransactionAmount, nullString(string(item.StatementStatus)), nullString(string(item.TransactionStatus)),
+			item.WalletBalance, item.LedgerBalance, item.ActivityDate, nullString(item.Detail), item.ExceptionStatus,
+			item.Resolution, nullString(item.ResolutionNote), item.ResolvedBy, item.ResolvedAt, item.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save reconciliation item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reconciliation run: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconciliationRepositoryImpl) ListRuns(ctx context.Context, medium txEntity.TransactionMedium, limit int) ([]entity.Run, error) {
+	query := `SELECT ` + runColumns + ` FROM public.reconciliation_runs
+		WHERE ($1 = '' OR medium = $1)
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, string(medium), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reconciliation runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []entity.Run
+	for rows.Next() {
+		var run entity.Run
+		var medium string
+		err := rows.Scan(
+			&run.ID, &medium, &run.FileName, &run.PeriodStart, &run.PeriodEnd, &run.UploadedBy,
+			&run.Lines, &run.Matched, &run.MissingOnOurSide, &run.MissingAtProvider, &run.AmountMismatch,
+			&run.StatusMismatch, &run.WalletMismatch, &run.StatementTotal, &run.MatchedTotal, &run.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation run: %w", err)
+		}
+		run.Medium = txEntity.TransactionMedium(medium)
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list reconciliation runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func (r *ReconciliationRepositoryImpl) GetItem(ctx context.Context, id uuid.UUID) (*entity.Item, error) {
+	query := `SELECT ` + itemColumns + ` FROM public.reconciliation_items WHERE id = $1`
+
+	item, err := scanItem(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get reconciliation item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *ReconciliationRepositoryImpl) ListExceptions(ctx context.Context, filter entity.ExceptionFilter) ([]entity.Item, int, error) {
+	conditions := []string{"exception_status IS NOT NULL"}
+	var args []interface{}
+
+	if filter.ExceptionStatus != "" {
+		args = append(args, string(filter.ExceptionStatus))
+		conditions = append(conditions, fmt.Sprintf("exception_status = $%d", len(args)))
+	}
+	if filter.Medium != "" {
+		args = append(args, string(filter.Medium))
+		conditions = append(conditions, fmt.Sprintf("medium = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.RunID != nil {
+		args = append(args, *filter.RunID)
+		conditions = append(conditions, fmt.Sprintf("run_id = $%d", len(args)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM public.reconciliation_items WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count reconciliation exceptions: %w", err)
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	query := fmt.Sprintf(`SELECT %s FROM public.reconciliation_items WHERE %s
+		ORDER BY activity_date, created_at
+		LIMIT $%d OFFSET $%d`, itemColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reconciliation exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	var items []entity.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan reconciliation item: %w", err)
+		}
+		items = append(items, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list reconciliation exceptions: %w", err)
+	}
+
+	return items, total, nil
+}
+
+func (r *ReconciliationRepositoryImpl) ResolveItem(ctx context.Context, id uuid.UUID, action entity.ResolveAction, note string, resolvedBy uuid.UUID) (*entity.Item, error) {
+	query := `
+		UPDATE public.reconciliation_items
+		SET exception_status = $2,
+			resolution = $3,
+			resolution_note = $4,
+			resolved_by = $5,
+			resolved_at = NOW()
+		WHERE id = $1 AND exception_status = $6
+		RETURNING ` + itemColumns
+
+	item, err := scanItem(r.db.QueryRowContext(ctx, query,
+		id, string(entity.ExceptionResolved), string(action), nullString(note), resolvedBy, string(entity.ExceptionOpen),
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrExceptionResolved
+		}
+		return nil, fmt.Errorf("failed to resolve reconciliation item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *ReconciliationRepositoryImpl) DailySummary(ctx context.Context, date time.Time) ([]entity.DailySummary, error) {
+	query := `
+		SELECT medium,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'MATCHED'),
+			COUNT(*) FILTER (WHERE status = 'MISSING_ON_OUR_SIDE'),
+			COUNT(*) FILTER (WHERE status = 'MISSING_AT_PROVIDER'),
+			COUNT(*) FILTER (WHERE status = 'AMOUNT_MISMATCH'),
+			COUNT(*) FILTER (WHERE status = 'STATUS_MISMATCH'),
+			COUNT(*) FILTER (WHERE status = 'WALLET_MISMATCH'),
+			COUNT(*) FILTER (WHERE exception_status = 'OPEN'),
+			COALESCE(SUM(statement_amount), 0),
+			COALESCE(SUM(statement_amount) FILTER (WHERE status = 'MATCHED'), 0)
+		FROM public.reconciliation_items
+		WHERE activity_date = $1
+		GROUP BY medium
+		ORDER BY medium`
+
+	day := date.Format("2006-01-02")
+	rows, err := r.db.QueryContext(ctx, query, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily reconciliation summary: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []entity.DailySummary
+	for rows.Next() {
+		summary := entity.DailySummary{Date: day}
+		var medium string
+		err := rows.Scan(
+			&medium, &summary.Items, &summary.Matched, &summary.MissingOnOurSide, &summary.MissingAtProvider,
+			&summary.AmountMismatch, &summary.StatusMismatch, &summary.WalletMismatch, &summary.OpenExceptions,
+			&summary.StatementTotal, &summary.MatchedTotal,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan daily reconciliation summary: %w", err)
+		}
+		summary.Medium = txEntity.TransactionMedium(medium)
+		summaries = append(summaries, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get daily reconciliation summary: %w", err)
+	}
+
+	return summaries, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLedgerTransactions(rows *sql.Rows) ([]entity.LedgerTransaction, error) {
+	var transactions []entity.LedgerTransaction
+	for rows.Next() {
+		var tx entity.LedgerTransaction
+		var txType, medium, status string
+		err := rows.Scan(
+			&tx.ID, &tx.MerchantID, &txType, &medium, &tx.Reference, &tx.ReferenceNumber,
+			&tx.ProviderTxID, &status, &tx.Amount, &tx.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		tx.Type = txEntity.TransactionType(txType)
+		tx.Medium = txEntity.TransactionMedium(medium)
+		tx.Status = txEntity.TransactionStatus(status)
+		transactions = append(transactions, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func scanItem(row scanner) (*entity.Item, error) {
+	var item entity.Item
+	var medium, status string
+	var providerTxID, reference, statementStatus, transactionStatus, detail, exceptionStatus, resolution, note sql.NullString
+
+	err := row.Scan(
+		&item.ID, &item.RunID, &medium, &status, &item.TransactionID, &item.MerchantID, &item.StatementRow,
+		&providerTxID, &reference, &item.StatementAmount, &item.TransactionAmount, &statementStatus,
+		&transactionStatus, &item.WalletBalance, &item.LedgerBalance, &item.ActivityDate, &detail,
+		&exceptionStatus, &resolution, &note, &item.ResolvedBy, &item.ResolvedAt, &item.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Medium = txEntity.TransactionMedium(medium)
+	item.Status = entity.ItemStatus(status)
+	item.ProviderTxID = providerTxID.String
+	item.Reference = reference.String
+	item.StatementStatus = txEntity.TransactionStatus(statementStatus.String)
+	item.TransactionStatus = txEntity.TransactionStatus(transactionStatus.String)
+	item.Detail = detail.String
+	item.ResolutionNote = note.String
+	if exceptionStatus.Valid {
+		s := entity.ExceptionStatus(exceptionStatus.String)
+		item.ExceptionStatus = &s
+	}
+	if resolution.Valid {
+		action := entity.ResolveAction(resolution.String)
+		item.Resolution = &action
+	}
+
+	return &item, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
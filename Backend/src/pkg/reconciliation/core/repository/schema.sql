@@ -0,0 +1,62 @@
+-- Reconciliation Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.reconciliation_column_mappings (
+    medium VARCHAR(50) PRIMARY KEY,
+    mapping JSONB NOT NULL,
+    updated_by UUID,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS public.reconciliation_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    medium VARCHAR(50) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    uploaded_by UUID NOT NULL,
+    lines INTEGER NOT NULL DEFAULT 0,
+    matched INTEGER NOT NULL DEFAULT 0,
+    missing_on_our_side INTEGER NOT NULL DEFAULT 0,
+    missing_at_provider INTEGER NOT NULL DEFAULT 0,
+    amount_mismatch INTEGER NOT NULL DEFAULT 0,
+    status_mismatch INTEGER NOT NULL DEFAULT 0,
+    wallet_mismatch INTEGER NOT NULL DEFAULT 0,
+    statement_total DECIMAL(20,2) NOT NULL DEFAULT 0,
+    matched_total DECIMAL(20,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_medium ON public.reconciliation_runs(medium, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS public.reconciliation_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id UUID NOT NULL REFERENCES public.reconciliation_runs(id) ON DELETE CASCADE,
+    medium VARCHAR(50) NOT NULL,
+    status VARCHAR(30) NOT NULL,
+    transaction_id UUID,
+    merchant_id UUID,
+    statement_row INTEGER,
+    provider_tx_id VARCHAR(255),
+    reference VARCHAR(255),
+    statement_amount DECIMAL(20,2),
+    transaction_amount DECIMAL(20,2),
+    statement_status VARCHAR(20),
+    transaction_status VARCHAR(20),
+    wallet_balance DECIMAL(20,2),
+    ledger_balance DECIMAL(20,2),
+    -- Day the activity happened, used for the daily summary
+    activity_date DATE NOT NULL,
+    detail TEXT,
+    -- NULL for matched items, which never enter the exceptions queue
+    exception_status VARCHAR(20),
+    resolution VARCHAR(30),
+    resolution_note TEXT,
+    resolved_by UUID,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_run_id ON public.reconciliation_items(run_id);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_exceptions ON public.reconciliation_items(exception_status, medium) WHERE exception_status IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_activity_date ON public.reconciliation_items(activity_date, medium);
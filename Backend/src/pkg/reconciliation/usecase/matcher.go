@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// amountMatchWindow is how far a statement time may be from the transaction when only the amount matches
+const amountMatchWindow = 15 * time.Minute
+
+// matcher pairs statement lines with transactions. Lines match on the provider
+// transaction ID, then on our references, then on a unique amount close in time.
+type matcher struct {
+	runID       uuid.UUID
+	medium      txEntity.TransactionMedium
+	periodStart time.Time
+	periodEnd   time.Time
+	now         time.Time
+
+	transactions []*entity.LedgerTransaction
+	byProviderID map[string][]*entity.LedgerTransaction
+	byReference  map[string][]*entity.LedgerTransaction
+	// matchedRow records the statement row each transaction was matched on
+	matchedRow map[uuid.UUID]int
+}
+
+func newMatcher(runID uuid.UUID, medium txEntity.TransactionMedium, periodStart, periodEnd time.Time, transactions []entity.LedgerTransaction) *matcher {
+	m := &matcher{
+		runID:        runID,
+		medium:       medium,
+		periodStart:  periodStart,
+		periodEnd:    periodEnd,
+		now:          time.Now(),
+		byProviderID: make(map[string][]*entity.LedgerTransaction),
+		byReference:  make(map[string][]*entity.LedgerTransaction),
+		matchedRow:   make(map[uuid.UUID]int),
+	}
+
+	seen := make(map[uuid.UUID]bool, len(transactions))
+	for i := range transactions {
+		tx := &transactions[i]
+		if seen[tx.ID] {
+			continue
+		}
+		seen[tx.ID] = true
+		m.transactions = append(m.transactions, tx)
+
+		if tx.ProviderTxID != "" {
+			m.byProviderID[matchKey(tx.ProviderTxID)] = append(m.byProviderID[matchKey(tx.ProviderTxID)], tx)
+		}
+		// Processors send our transaction ID or the merchant reference as the provider's order reference
+		refs := make(map[string]bool)
+		for _, ref := range []string{tx.ID.String(), strings.ReplaceAll(tx.ID.String(), "-", ""), tx.Reference, tx.ReferenceNumber} {
+			if key := matchKey(ref); key != "" && !refs[key] {
+				refs[key] = true
+				m.byReference[key] = append(m.byReference[key], tx)
+			}
+		}
+	}
+
+	return m
+}
+
+// reconcile classifies every statement line, then every successful transaction of the period the statement lacks
+func (m *matcher) reconcile(lines []entity.StatementLine) []entity.Item {
+	items := make([]entity.Item, 0, len(lines))
+
+	for _, line := range lines {
+		items = append(items, m.matchLine(line))
+	}
+
+	for _, tx := range m.transactions {
+		if _, ok := m.matchedRow[tx.ID]; ok {
+			continue
+		}
+		if tx.Status != txEntity.SUCCESS || tx.CreatedAt.Before(m.periodStart) || !tx.CreatedAt.Before(m.periodEnd) {
+			continue
+		}
+
+		item := m.newItem(entity.ItemMissingAtProvider, tx.CreatedAt)
+		m.setTransaction(&item, tx)
+		item.ProviderTxID = tx.ProviderTxID
+		item.Reference = tx.Reference
+		item.Detail = "successful transaction is not on the provider statement"
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func (m *matcher) matchLine(line entity.StatementLine) entity.Item {
+	item := m.newItem(entity.ItemMissingOnOurSide, line.Date)
+	row := line.Row
+	amount := line.Amount
+	item.StatementRow = &row
+	item.StatementAmount = &amount
+	item.StatementStatus = line.Status
+	item.ProviderTxID = line.ProviderTxID
+	item.Reference = line.Reference
+
+	tx, how := m.find(line)
+	if tx == nil {
+		item.Detail = "no transaction matches the statement line"
+		return item
+	}
+	m.setTransaction(&item, tx)
+
+	if first, ok := m.matchedRow[tx.ID]; ok {
+		item.Detail = fmt.Sprintf("duplicate of statement row %d", first)
+		return item
+	}
+	m.matchedRow[tx.ID] = line.Row
+
+	switch {
+	case line.Status != tx.Status:
+		item.Status = entity.ItemStatusMismatch
+		item.Detail = fmt.Sprintf("provider reports %s, transaction is %s", line.Status, tx.Status)
+	case math.Abs(line.Amount-tx.Amount) >= entity.AmountTolerance:
+		item.Status = entity.ItemAmountMismatch
+		item.Detail = fmt.Sprintf("provider amount %.2f differs from transaction amount %.2f by %.2f", line.Amount, tx.Amount, line.Amount-tx.Amount)
+	default:
+		item.Status = entity.ItemMatched
+		item.Detail = how
+	}
+	if item.Status != entity.ItemMatched && how != "" {
+		item.Detail += "; " + how
+	}
+
+	return m.finish(item)
+}
+
+// find returns the transaction of a statement line and, for weak matches, how it was found
+func (m *matcher) find(line entity.StatementLine) (*entity.LedgerTransaction, string) {
+	if line.ProviderTxID != "" {
+		if tx := unique(m.byProviderID[matchKey(line.ProviderTxID)]); tx != nil {
+			return tx, ""
+		}
+	}
+	for _, ref := range []string{line.Reference, line.ProviderTxID} {
+		if ref == "" {
+			continue
+		}
+		if tx := unique(m.byReference[matchKey(ref)]); tx != nil {
+			return tx, ""
+		}
+	}
+
+	var candidate *entity.LedgerTransaction
+	for _, tx := range m.transactions {
+		if _, ok := m.matchedRow[tx.ID]; ok {
+			continue
+		}
+		if math.Abs(line.Amount-tx.Amount) >= entity.AmountTolerance {
+			continue
+		}
+		if d := line.Date.Sub(tx.CreatedAt); d > amountMatchWindow || d < -amountMatchWindow {
+			continue
+		}
+		if candidate != nil {
+			// Ambiguous, an admin has to decide
+			return nil, ""
+		}
+		candidate = tx
+	}
+	if candidate != nil {
+		return candidate, "matched on amount and time only"
+	}
+
+	return nil, ""
+}
+
+func (m *matcher) newItem(status entity.ItemStatus, activity time.Time) entity.Item {
+	item := entity.Item{
+		ID:           uuid.New(),
+		RunID:        m.runID,
+		Medium:       m.medium,
+		Status:       status,
+		ActivityDate: activityDate(activity),
+		CreatedAt:    m.now,
+	}
+	return m.finish(item)
+}
+
+// finish opens an exception for every item that is not matched
+func (m *matcher) finish(item entity.Item) entity.Item {
+	item.ExceptionStatus = nil
+	if item.IsException() {
+		open := entity.ExceptionOpen
+		item.ExceptionStatus = &open
+	}
+	return item
+}
+
+func (m *matcher) setTransaction(item *entity.Item, tx *entity.LedgerTransaction) {
+	id := tx.ID
+	amount := tx.Amount
+	item.TransactionID = &id
+	item.TransactionAmount = &amount
+	item.TransactionStatus = tx.Status
+	if tx.MerchantID != uuid.Nil {
+		merchantID := tx.MerchantID
+		item.MerchantID = &merchantID
+	}
+}
+
+// activityDate is the statement day of a time, in the providers' time zone
+func activityDate(t time.Time) time.Time {
+	t = t.In(statementZone)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func matchKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func unique(txs []*entity.LedgerTransaction) *entity.LedgerTransaction {
+	if len(txs) != 1 {
+		return nil
+	}
+	return txs[0]
+}
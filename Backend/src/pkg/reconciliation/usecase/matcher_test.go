@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestStatementReconciliation(t *testing.T) {
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, statementZone)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	merchantID := uuid.New()
+	tx := func(providerTxID, reference string, status txEntity.TransactionStatus, amount float64, createdAt time.Time) entity.LedgerTransaction {
+		return entity.LedgerTransaction{
+			ID:           uuid.New(),
+			MerchantID:   merchantID,
+			Type:         txEntity.DEPOSIT,
+			Medium:       txEntity.TELEBIRR,
+			Reference:    reference,
+			ProviderTxID: providerTxID,
+			Status:       status,
+			Amount:       amount,
+			CreatedAt:    createdAt,
+		}
+	}
+
+	byProviderID := tx("TB001", "INV-1", txEntity.SUCCESS, 100, at(9, 0))
+	byReference := tx("", "INV-2", txEntity.SUCCESS, 250, at(10, 0))
+	byAmount := tx("", "", txEntity.SUCCESS, 75.5, at(11, 0))
+	statusMismatch := tx("TB004", "INV-4", txEntity.PENDING, 40, at(12, 0))
+	amountMismatch := tx("TB005", "INV-5", txEntity.SUCCESS, 500, at(13, 0))
+	missingAtProvider := tx("TB006", "INV-6", txEntity.SUCCESS, 60, at(14, 0))
+	failedNotOnStatement := tx("TB007", "INV-7", txEntity.FAILED, 60, at(15, 0))
+
+	csv := "\xef\xbb\xbf Transaction ID ,Merchant Order ID,Amount,Transaction Time,Status\n" +
+		"TB001,INV-1,100.00,2026-03-14 09:00:12,Completed\n" +
+		"TB002,inv-2,250,2026-03-14 10:01:00,Completed\n" +
+		"TB003,,75.50,2026-03-14 11:05:00,Completed\n" +
+		"TB004,INV-4,40,2026-03-14 12:00:30,Completed\n" +
+		"TB005,INV-5,\"495.00\",2026-03-14 13:00:05,Completed\n" +
+		"TB001,INV-1,100.00,2026-03-14 09:00:12,Completed\n" +
+		"TB999,INV-999,10,2026-03-14 16:00:00,Completed\n" +
+		",,,,\n"
+
+	lines, err := parseStatement("telebirr-2026-03-14.csv", []byte(csv), defaultMappings[txEntity.TELEBIRR])
+	if err != nil {
+		t.Fatalf("parseStatement() error = %v", err)
+	}
+	if len(lines) != 7 {
+		t.Fatalf("parseStatement() returned %d lines, want 7", len(lines))
+	}
+
+	start, end := statementPeriod(lines)
+	if !start.Equal(day) || !end.Equal(day.AddDate(0, 0, 1)) {
+		t.Fatalf("statementPeriod() = %v - %v, want the statement day", start, end)
+	}
+
+	m := newMatcher(uuid.New(), txEntity.TELEBIRR, start, end, []entity.LedgerTransaction{
+		byProviderID, byReference, byAmount, statusMismatch, amountMismatch, missingAtProvider, failedNotOnStatement,
+		// Found again by reference lookup, must not be counted twice
+		byProviderID,
+	})
+	items := m.reconcile(lines)
+
+	want := []struct {
+		row    int
+		txID   *uuid.UUID
+		status entity.ItemStatus
+	}{
+		{2, &byProviderID.ID, entity.ItemMatched},
+		{3, &byReference.ID, entity.ItemMatched},
+		{4, &byAmount.ID, entity.ItemMatched},
+		{5, &statusMismatch.ID, entity.ItemStatusMismatch},
+		{6, &amountMismatch.ID, entity.ItemAmountMismatch},
+		{7, &byProviderID.ID, entity.ItemMissingOnOurSide},
+		{8, nil, entity.ItemMissingOnOurSide},
+		{0, &missingAtProvider.ID, entity.ItemMissingAtProvider},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("reconcile() returned %d items, want %d: %+v", len(items), len(want), items)
+	}
+
+	var run entity.Run
+	for i, w := range want {
+		item := items[i]
+		run.Count(&item)
+
+		if item.Status != w.status {
+			t.Errorf("item %d status = %s, want %s (%s)", i, item.Status, w.status, item.Detail)
+		}
+		if w.row != 0 && (item.StatementRow == nil || *item.StatementRow != w.row) {
+			t.Errorf("item %d statement row = %v, want %d", i, item.StatementRow, w.row)
+		}
+		if (w.txID == nil) != (item.TransactionID == nil) || (w.txID != nil && *w.txID != *item.TransactionID) {
+			t.Errorf("item %d transaction = %v, want %v", i, item.TransactionID, w.txID)
+		}
+		if item.IsException() != (item.ExceptionStatus != nil && *item.ExceptionStatus == entity.ExceptionOpen) {
+			t.Errorf("item %d exception status = %v for %s", i, item.ExceptionStatus, item.Status)
+		}
+		if !item.ActivityDate.Equal(time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("item %d activity date = %v, want 2026-03-14", i, item.ActivityDate)
+		}
+	}
+
+	if run.Matched != 3 || run.MatchedTotal != 425.5 {
+		t.Errorf("run matched = %d (%.2f), want 3 (425.50)", run.Matched, run.MatchedTotal)
+	}
+	if items[5].Detail != "duplicate of statement row 2" {
+		t.Errorf("duplicate detail = %q", items[5].Detail)
+	}
+}
+
+func TestAmountOnlyMatchMustBeUnambiguous(t *testing.T) {
+	at := time.Date(2026, 3, 14, 9, 0, 0, 0, statementZone)
+	first := entity.LedgerTransaction{ID: uuid.New(), Status: txEntity.SUCCESS, Amount: 50, CreatedAt: at}
+	second := entity.LedgerTransaction{ID: uuid.New(), Status: txEntity.SUCCESS, Amount: 50, CreatedAt: at.Add(5 * time.Minute)}
+
+	m := newMatcher(uuid.New(), txEntity.MPESA, at.Add(-time.Hour), at.Add(time.Hour), []entity.LedgerTransaction{first, second})
+	items := m.reconcile([]entity.StatementLine{{Row: 2, ProviderTxID: "QX1", Amount: 50, Date: at.Add(2 * time.Minute), Status: txEntity.SUCCESS}})
+
+	if items[0].Status != entity.ItemMissingOnOurSide {
+		t.Fatalf("ambiguous amount match status = %s, want %s", items[0].Status, entity.ItemMissingOnOurSide)
+	}
+	if len(items) != 3 {
+		t.Fatalf("reconcile() returned %d items, want both transactions missing at provider", len(items))
+	}
+}
+
+func TestParseStatementRejectsUnknownColumns(t *testing.T) {
+	_, err := parseStatement("statement.csv", []byte("Receipt,Total\nA1,10\n"), defaultMappings[txEntity.MPESA])
+	if err == nil {
+		t.Fatal("parseStatement() accepted a statement without the mapped columns")
+	}
+
+	_, err = parseStatement("statement.pdf", []byte("%PDF"), defaultMappings[txEntity.MPESA])
+	if err != entity.ErrUnsupportedFile {
+		t.Fatalf("parseStatement() error = %v, want %v", err, entity.ErrUnsupportedFile)
+	}
+}
@@ -0,0 +1,483 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	settlementdto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
+)
+
+// StatusQuerier asks a provider for the live status of a transaction with the merchant's processor account
+type StatusQuerier interface {
+	QueryTransactionStatus(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error)
+}
+
+// WebhookDispatcher applies a status change to a transaction, with its wallet effects
+type WebhookDispatcher interface {
+	HandleWebhookDispatch(ctx context.Context, req settlementdto.WebhookRequest) error
+}
+
+// ReconciliationUseCase defines the interface for provider statement reconciliation
+type ReconciliationUseCase interface {
+	// ImportStatement reconciles a provider statement with transactions and merchant wallets
+	ImportStatement(ctx context.Context, req *entity.ImportRequest) (*entity.ImportResponse, error)
+
+	// ListRuns retrieves the latest statement imports, optionally of one medium
+	ListRuns(ctx context.Context, medium txEntity.TransactionMedium, limit int) (*entity.RunListResponse, error)
+
+	// ListExceptions retrieves a page of the exceptions queue
+	ListExceptions(ctx context.Context, filter entity.ExceptionFilter) (*entity.ExceptionListResponse, error)
+
+	// ResolveException closes an open exception with the requested action
+	ResolveException(ctx context.Context, itemID uuid.UUID, req *entity.ResolveRequest, adminID uuid.UUID) (*entity.Item, error)
+
+	// DailySummary retrieves the reconciliation result of each medium for a day of activity
+	DailySummary(ctx context.Context, date time.Time) (*entity.DailySummaryResponse, error)
+
+	// GetColumnMapping retrieves the statement column mapping used for a medium
+	GetColumnMapping(ctx context.Context, medium txEntity.TransactionMedium) (*entity.ColumnMappingResponse, error)
+
+	// SetColumnMapping replaces the statement column mapping of a medium
+	SetColumnMapping(ctx context.Context, medium txEntity.TransactionMedium, mapping entity.ColumnMapping, adminID uuid.UUID) (*entity.ColumnMappingResponse, error)
+}
+
+type reconciliationUseCase struct {
+	repo     repository.ReconciliationRepository
+	querier  StatusQuerier
+	webhooks WebhookDispatcher
+	log      logging.Logger
+}
+
+// NewReconciliationUseCase creates the reconciliation usecase
+func NewReconciliationUseCase(repo repository.ReconciliationRepository, querier StatusQuerier, webhooks WebhookDispatcher) ReconciliationUseCase {
+	return &reconciliationUseCase{
+		repo:     repo,
+		querier:  querier,
+		webhooks: webhooks,
+		log:      logging.NewStdLogger("reconciliation_usecase"),
+	}
+}
+
+func (uc *reconciliationUseCase) ImportStatement(ctx context.Context, req *entity.ImportRequest) (*entity.ImportResponse, error) {
+	mapping, _, err := uc.columnMapping(ctx, req.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := parseStatement(req.FileName, req.Content, mapping)
+	if err != nil {
+		uc.log.Warn("Failed to parse provider statement", map[string]interface{}{
+			"medium":    req.Medium,
+			"file_name": req.FileName,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	periodStart, periodEnd := statementPeriod(lines)
+	if req.PeriodStart != nil {
+		periodStart = *req.PeriodStart
+	}
+	if req.PeriodEnd != nil {
+		periodEnd = *req.PeriodEnd
+	}
+	if !periodEnd.After(periodStart) {
+		return nil, fmt.Errorf("%w: period end must be after period start", entity.ErrInvalidStatement)
+	}
+
+	// Transactions of the period, plus older ones the provider settled inside it
+	transactions, err := uc.repo.ListLedgerTransactions(ctx, req.Medium, periodStart.Add(-amountMatchWindow), periodEnd.Add(amountMatchWindow))
+	if err != nil {
+		return nil, err
+	}
+	referenced, err := uc.repo.FindLedgerTransactions(ctx, req.Medium, statementKeys(lines))
+	if err != nil {
+		return nil, err
+	}
+	transactions = append(transactions, referenced...)
+
+	run := &entity.Run{
+		ID:          uuid.New(),
+		Medium:      req.Medium,
+		FileName:    req.FileName,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		UploadedBy:  req.UploadedBy,
+		Lines:       len(lines),
+		CreatedAt:   time.Now(),
+	}
+	for _, line := range lines {
+		run.StatementTotal += line.Amount
+	}
+
+	m := newMatcher(run.ID, req.Medium, periodStart, periodEnd, transactions)
+	items := m.reconcile(lines)
+
+	walletItems, err := uc.checkWallets(ctx, m, items, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, walletItems...)
+
+	exceptions := []entity.Item{}
+	for i := range items {
+		run.Count(&items[i])
+		if items[i].IsException() {
+			exceptions = append(exceptions, items[i])
+		}
+	}
+
+	if err := uc.repo.SaveRun(ctx, run, items); err != nil {
+		uc.log.Error("Failed to save reconciliation run", map[string]interface{}{
+			"medium": req.Medium,
+			"error":  err.Error(),
+		})
+		return nil, err
+	}
+
+	uc.log.Info("Provider statement reconciled", map[string]interface{}{
+		"run_id":              run.ID,
+		"medium":              run.Medium,
+		"lines":               run.Lines,
+		"matched":             run.Matched,
+		"missing_on_our_side": run.MissingOnOurSide,
+		"missing_at_provider": run.MissingAtProvider,
+		"amount_mismatch":     run.AmountMismatch,
+		"status_mismatch":     run.StatusMismatch,
+		"wallet_mismatch":     run.WalletMismatch,
+		"uploaded_by":         run.UploadedBy,
+	})
+
+	return &entity.ImportResponse{Run: run, Exceptions: exceptions}, nil
+}
+
+// checkWallets compares the wallet of every merchant on the statement with its transaction history
+func (uc *reconciliationUseCase) checkWallets(ctx context.Context, m *matcher, items []entity.Item, periodEnd time.Time) ([]entity.Item, error) {
+	seen := make(map[uuid.UUID]bool)
+	var merchantIDs []uuid.UUID
+	for _, item := range items {
+		if item.MerchantID != nil && !seen[*item.MerchantID] {
+			seen[*item.MerchantID] = true
+			merchantIDs = append(merchantIDs, *item.MerchantID)
+		}
+	}
+
+	balances, err := uc.repo.GetWalletBalances(ctx, merchantIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var walletItems []entity.Item
+	for _, balance := range balances {
+		difference := balance.WalletBalance - balance.LedgerBalance
+		if math.Abs(difference) < entity.AmountTolerance {
+			continue
+		}
+
+		merchantID := balance.MerchantID
+		walletBalance := balance.WalletBalance
+		ledgerBalance := balance.LedgerBalance
+
+		item := m.newItem(entity.ItemWalletMismatch, periodEnd.Add(-time.Second))
+		item.MerchantID = &merchantID
+		item.WalletBalance = &walletBalance
+		item.LedgerBalance = &ledgerBalance
+		item.Detail = fmt.Sprintf("%s wallet balance %.2f differs from transaction history %.2f by %.2f", balance.Currency, walletBalance, ledgerBalance, difference)
+		walletItems = append(walletItems, item)
+	}
+
+	return walletItems, nil
+}
+
+func (uc *reconciliationUseCase) ListRuns(ctx context.Context, medium txEntity.TransactionMedium, limit int) (*entity.RunListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	runs, err := uc.repo.ListRuns(ctx, medium, limit)
+	if err != nil {
+		return nil, err
+	}
+	if runs == nil {
+		runs = []entity.Run{}
+	}
+
+	return &entity.RunListResponse{Runs: runs}, nil
+}
+
+func (uc *reconciliationUseCase) ListExceptions(ctx context.Context, filter entity.ExceptionFilter) (*entity.ExceptionListResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	items, total, err := uc.repo.ListExceptions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []entity.Item{}
+	}
+
+	return &entity.ExceptionListResponse{
+		Exceptions: items,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+	}, nil
+}
+
+func (uc *reconciliationUseCase) ResolveException(ctx context.Context, itemID uuid.UUID, req *entity.ResolveRequest, adminID uuid.UUID) (*entity.Item, error) {
+	item, err := uc.repo.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, entity.ErrItemNotFound
+	}
+	if !item.IsException() {
+		return nil, entity.ErrNotAnException
+	}
+	if item.ExceptionStatus != nil && *item.ExceptionStatus == entity.ExceptionResolved {
+		return nil, entity.ErrExceptionResolved
+	}
+
+	note := strings.TrimSpace(req.Note)
+
+	switch req.Action {
+	case entity.ResolveRequery:
+		providerNote, err := uc.requery(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		note = strings.TrimSpace(providerNote + ". " + note)
+	case entity.ResolveApplyProviderStatus:
+		if err := uc.applyProviderStatus(ctx, item, note, adminID); err != nil {
+			return nil, err
+		}
+	}
+
+	resolved, err := uc.repo.ResolveItem(ctx, item.ID, req.Action, note, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Reconciliation exception resolved", map[string]interface{}{
+		"item_id":        resolved.ID,
+		"status":         resolved.Status,
+		"action":         req.Action,
+		"transaction_id": resolved.TransactionID,
+		"admin_id":       adminID,
+	})
+
+	return resolved, nil
+}
+
+// requery closes status exceptions the provider has caught up with since the statement was produced
+func (uc *reconciliationUseCase) requery(ctx context.Context, item *entity.Item) (string, error) {
+	if item.TransactionID == nil || (item.Status != entity.ItemStatusMismatch && item.Status != entity.ItemMissingAtProvider) {
+		return "", entity.ErrActionNotApplicable
+	}
+
+	tx, err := uc.transaction(ctx, item)
+	if err != nil {
+		return "", err
+	}
+
+	// Awash and CBE look transactions up by our ID, the others by their own
+	id := tx.ProviderTxID
+	if tx.Medium == txEntity.AWASH || tx.Medium == txEntity.CBE {
+		id = tx.ID.String()
+	}
+
+	resp, err := uc.querier.QueryTransactionStatus(ctx, tx.MerchantID, tx.Medium, id)
+	if err != nil {
+		if errors.Is(err, payment.ErrNotSupported) {
+			return "", entity.ErrRequeryNotSupported
+		}
+		uc.log.Error("Failed to requery transaction status", map[string]interface{}{
+			"item_id":        item.ID,
+			"transaction_id": tx.ID,
+			"medium":         tx.Medium,
+			"error":          err.Error(),
+		})
+		return "", fmt.Errorf("failed to query provider: %w", err)
+	}
+	if resp.Status != tx.Status {
+		return "", fmt.Errorf("%w: provider reports %s, transaction is %s", entity.ErrProviderDisagrees, resp.Status, tx.Status)
+	}
+
+	return fmt.Sprintf("Provider confirms %s", resp.Status), nil
+}
+
+// applyProviderStatus moves the transaction to the statement status through the admin override path
+func (uc *reconciliationUseCase) applyProviderStatus(ctx context.Context, item *entity.Item, note string, adminID uuid.UUID) error {
+	if item.TransactionID == nil || item.Status != entity.ItemStatusMismatch {
+		return entity.ErrActionNotApplicable
+	}
+
+	tx, err := uc.transaction(ctx, item)
+	if err != nil {
+		return err
+	}
+	if !txEntity.CanTransition(tx.Status, item.StatementStatus) {
+		return fmt.Errorf("%w: cannot move transaction from %s to %s", entity.ErrActionNotApplicable, tx.Status, item.StatementStatus)
+	}
+
+	providerData, err := json.Marshal(map[string]interface{}{
+		"override_type":          "reconciliation",
+		"reconciliation_item_id": item.ID,
+		"reconciliation_run_id":  item.RunID,
+		"admin_id":               adminID,
+		"reason":                 note,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider data: %w", err)
+	}
+
+	providerTxID := item.ProviderTxID
+	if providerTxID == "" {
+		providerTxID = tx.ProviderTxID
+	}
+
+	err = uc.webhooks.HandleWebhookDispatch(ctx, settlementdto.WebhookRequest{
+		TransactionID: tx.ID.String(),
+		Status:        string(item.StatementStatus),
+		Message:       "Reconciliation: " + note,
+		ProviderTxID:  providerTxID,
+		ProviderData:  string(providerData),
+		Timestamp:     time.Now(),
+		Source:        txEntity.StatusSourceAdminOverride,
+		Actor:         adminID.String(),
+	})
+	if err != nil {
+		uc.log.Error("Failed to apply provider status", map[string]interface{}{
+			"item_id":        item.ID,
+			"transaction_id": tx.ID,
+			"status":         item.StatementStatus,
+			"error":          err.Error(),
+		})
+		return fmt.Errorf("failed to apply provider status: %w", err)
+	}
+
+	return nil
+}
+
+// transaction reloads the current state of an item's transaction
+func (uc *reconciliationUseCase) transaction(ctx context.Context, item *entity.Item) (*entity.LedgerTransaction, error) {
+	txs, err := uc.repo.FindLedgerTransactions(ctx, item.Medium, []string{item.TransactionID.String()})
+	if err != nil {
+		return nil, err
+	}
+	for i := range txs {
+		if txs[i].ID == *item.TransactionID {
+			return &txs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: transaction %s no longer exists", entity.ErrActionNotApplicable, item.TransactionID)
+}
+
+func (uc *reconciliationUseCase) DailySummary(ctx context.Context, date time.Time) (*entity.DailySummaryResponse, error) {
+	summaries, err := uc.repo.DailySummary(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if summaries == nil {
+		summaries = []entity.DailySummary{}
+	}
+
+	return &entity.DailySummaryResponse{
+		Date:      date.Format("2006-01-02"),
+		Summaries: summaries,
+	}, nil
+}
+
+func (uc *reconciliationUseCase) GetColumnMapping(ctx context.Context, medium txEntity.TransactionMedium) (*entity.ColumnMappingResponse, error) {
+	mapping, custom, err := uc.columnMapping(ctx, medium)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ColumnMappingResponse{Medium: medium, Custom: custom, Mapping: mapping}, nil
+}
+
+func (uc *reconciliationUseCase) SetColumnMapping(ctx context.Context, medium txEntity.TransactionMedium, mapping entity.ColumnMapping, adminID uuid.UUID) (*entity.ColumnMappingResponse, error) {
+	if _, ok := defaultMappings[medium]; !ok {
+		return nil, entity.ErrUnsupportedMedium
+	}
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SaveColumnMapping(ctx, medium, mapping, adminID); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Reconciliation column mapping updated", map[string]interface{}{
+		"medium":   medium,
+		"admin_id": adminID,
+	})
+
+	return &entity.ColumnMappingResponse{Medium: medium, Custom: true, Mapping: mapping}, nil
+}
+
+// columnMapping returns the custom mapping of a medium, falling back to the provider's default export
+func (uc *reconciliationUseCase) columnMapping(ctx context.Context, medium txEntity.TransactionMedium) (entity.ColumnMapping, bool, error) {
+	mapping, ok := defaultMappings[medium]
+	if !ok {
+		return entity.ColumnMapping{}, false, entity.ErrUnsupportedMedium
+	}
+
+	custom, err := uc.repo.GetColumnMapping(ctx, medium)
+	if err != nil {
+		return entity.ColumnMapping{}, false, err
+	}
+	if custom != nil {
+		return *custom, true, nil
+	}
+
+	return mapping, false, nil
+}
+
+// statementPeriod covers the statement days from the first to the last line
+func statementPeriod(lines []entity.StatementLine) (time.Time, time.Time) {
+	dates := make([]time.Time, len(lines))
+	for i, line := range lines {
+		dates[i] = line.Date.In(statementZone)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	first, last := dates[0], dates[len(dates)-1]
+	start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, statementZone)
+	end := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, statementZone).AddDate(0, 0, 1)
+	return start, end
+}
+
+func statementKeys(lines []entity.StatementLine) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, line := range lines {
+		for _, key := range []string{line.ProviderTxID, line.Reference} {
+			if key != "" && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/socialpay/socialpay/src/pkg/reconciliation/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// statementZone is applied to statement dates without an offset; Ethiopian providers report East Africa Time
+var statementZone = time.FixedZone("EAT", 3*60*60)
+
+var fallbackDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// defaultMappings are the statement layouts of the providers' settlement exports. Admins
+// can replace them per medium when a provider changes its export.
+var defaultMappings = map[txEntity.TransactionMedium]entity.ColumnMapping{
+	txEntity.TELEBIRR: {
+		ProviderTxID: "Transaction ID",
+		Reference:    "Merchant Order ID",
+		Amount:       "Amount",
+		Date:         "Transaction Time",
+		Status:       "Status",
+		DateLayouts:  []string{"2006-01-02 15:04:05", "2006/01/02 15:04:05"},
+		StatusValues: map[string]txEntity.TransactionStatus{
+			"completed": txEntity.SUCCESS,
+			"success":   txEntity.SUCCESS,
+			"failed":    txEntity.FAILED,
+			"reversed":  txEntity.REFUNDED,
+		},
+	},
+	txEntity.MPESA: {
+		ProviderTxID: "Receipt No.",
+		Reference:    "Reason Type",
+		Amount:       "Paid In",
+		Date:         "Completion Time",
+		Status:       "Transaction Status",
+		DateLayouts:  []string{"02-01-2006 15:04:05", "2006-01-02 15:04:05"},
+		StatusValues: map[string]txEntity.TransactionStatus{
+			"completed": txEntity.SUCCESS,
+			"failed":    txEntity.FAILED,
+			"cancelled": txEntity.CANCELED,
+		},
+	},
+	txEntity.CBE: {
+		ProviderTxID: "Transaction Reference",
+		Reference:    "Bill Reference",
+		Amount:       "Amount",
+		Date:         "Transaction Date",
+		Status:       "Status",
+		DateLayouts:  []string{"02/01/2006 15:04:05", "02/01/2006"},
+		StatusValues: map[string]txEntity.TransactionStatus{
+			"success":    txEntity.SUCCESS,
+			"successful": txEntity.SUCCESS,
+			"failed":     txEntity.FAILED,
+		},
+	},
+	txEntity.AWASH: {
+		ProviderTxID: "Transaction ID",
+		Reference:    "Reference",
+		Amount:       "Amount",
+		Date:         "Date",
+		Status:       "Status",
+		DateLayouts:  []string{"02/01/2006 15:04", "02/01/2006"},
+		StatusValues: map[string]txEntity.TransactionStatus{
+			"success": txEntity.SUCCESS,
+			"pending": txEntity.PENDING,
+			"failed":  txEntity.FAILED,
+		},
+	},
+	txEntity.KACHA: {
+		ProviderTxID: "Transaction ID",
+		Reference:    "Reference",
+		Amount:       "Amount",
+		Date:         "Created At",
+		Status:       "Status",
+		StatusValues: map[string]txEntity.TransactionStatus{
+			"success": txEntity.SUCCESS,
+			"failed":  txEntity.FAILED,
+		},
+	},
+	txEntity.ETHSWITCH: {
+		ProviderTxID: "Order Number",
+		Reference:    "Merchant Order Number",
+		Amount:       "Amount",
+		Date:         "Date",
+		Status:       "Order Status",
+		DateLayouts:  []string{"02.01.2006 15:04:05", "02.01.2006"},
+		StatusValues: map[string]txEntity.TransactionStatus{
+			"deposited": txEntity.SUCCESS,
+			"approved":  txEntity.AUTHORIZED,
+			"declined":  txEntity.FAILED,
+			"reversed":  txEntity.CANCELED,
+			"refunded":  txEntity.REFUNDED,
+		},
+	},
+	txEntity.CYBERSOURCE: {
+		ProviderTxID: "Request ID",
+		Reference:    "Merchant Reference Number",
+		Amount:       "Amount",
+		Date:         "Request Date",
+		Status:       "Decision",
+		StatusValues: map[string]txEntity.TransactionStatus{
+			"accept": txEntity.SUCCESS,
+			"reject": txEntity.FAILED,
+			"review": txEntity.PENDING,
+		},
+	},
+}
+
+// parseStatement reads the lines of a CSV or XLSX statement with the column mapping
+func parseStatement(fileName string, content []byte, mapping entity.ColumnMapping) ([]entity.StatementLine, error) {
+	var rows [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		rows, err = readCSV(content)
+	case ".xlsx":
+		rows, err = readXLSX(content)
+	default:
+		return nil, entity.ErrUnsupportedFile
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidStatement, err)
+	}
+
+	headerRow := mapping.HeaderRow
+	if headerRow == 0 {
+		headerRow = 1
+	}
+	if len(rows) < headerRow {
+		return nil, fmt.Errorf("%w: header row %d not found", entity.ErrInvalidStatement, headerRow)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[headerRow-1] {
+		columns[normalizeHeader(name)] = i
+	}
+	column := func(name string) (int, error) {
+		if strings.TrimSpace(name) == "" {
+			return -1, nil
+		}
+		i, ok := columns[normalizeHeader(name)]
+		if !ok {
+			return -1, fmt.Errorf("%w: column %q not found", entity.ErrInvalidStatement, name)
+		}
+		return i, nil
+	}
+
+	idCol, err := column(mapping.ProviderTxID)
+	if err != nil {
+		return nil, err
+	}
+	refCol, err := column(mapping.Reference)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := column(mapping.Amount)
+	if err != nil {
+		return nil, err
+	}
+	dateCol, err := column(mapping.Date)
+	if err != nil {
+		return nil, err
+	}
+	statusCol, err := column(mapping.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	statusValues := make(map[string]txEntity.TransactionStatus, len(mapping.StatusValues))
+	for value, status := range mapping.StatusValues {
+		statusValues[strings.ToLower(strings.TrimSpace(value))] = status
+	}
+
+	var lines []entity.StatementLine
+	for i := headerRow; i < len(rows); i++ {
+		row := rows[i]
+		if isBlankRow(row) {
+			continue
+		}
+		rowNumber := i + 1
+
+		line := entity.StatementLine{
+			Row:          rowNumber,
+			ProviderTxID: cell(row, idCol),
+			Reference:    cell(row, refCol),
+			Status:       txEntity.SUCCESS,
+		}
+		if line.ProviderTxID == "" && line.Reference == "" {
+			// Totals and footers carry neither identifier
+			continue
+		}
+
+		line.Amount, err = parseAmount(cell(row, amountCol), mapping.AmountInMinorUnits)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", entity.ErrInvalidStatement, rowNumber, err)
+		}
+		line.Date, err = parseDate(cell(row, dateCol), mapping.DateLayouts)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", entity.ErrInvalidStatement, rowNumber, err)
+		}
+		if statusCol >= 0 {
+			line.RawStatus = cell(row, statusCol)
+			status, ok := statusValues[strings.ToLower(line.RawStatus)]
+			if !ok {
+				status = txEntity.FAILED
+			}
+			line.Status = status
+		}
+
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: no transactions found", entity.ErrInvalidStatement)
+	}
+
+	return lines, nil
+}
+
+func readCSV(content []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader.ReadAll()
+}
+
+func readXLSX(content []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no sheets")
+	}
+	return f.GetRows(sheets[0])
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\xef\xbb\xbf")))
+}
+
+func cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func isBlankRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAmount accepts thousands separators, currency codes and debit signs; amounts are compared unsigned
+func parseAmount(value string, minorUnits bool) (float64, error) {
+	cleaned := strings.NewReplacer(",", "", " ", "", "ETB", "", "Br", "", "(", "-", ")", "").Replace(value)
+	if cleaned == "" {
+		return 0, fmt.Errorf("amount is empty")
+	}
+
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if minorUnits {
+		amount /= 100
+	}
+	return math.Abs(amount), nil
+}
+
+func parseDate(value string, layouts []string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("date is empty")
+	}
+
+	for _, layout := range append(append([]string{}, layouts...), fallbackDateLayouts...) {
+		if t, err := time.ParseInLocation(layout, value, statementZone); err == nil {
+			return t, nil
+		}
+	}
+
+	// Spreadsheet cells without a date format hold the Excel serial number
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, statementZone), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,340 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/reserve/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/reserve/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+type Handler struct {
+	reserveUseCase usecase.ReserveUseCase
+	log            logging.Logger
+	jwtMiddleware  gin.HandlerFunc
+	rbac           *ginMiddleware.RBACV2
+}
+
+func NewHandler(reserveUseCase usecase.ReserveUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		reserveUseCase: reserveUseCase,
+		log:            logging.NewStdLogger("reserve_handler"),
+		jwtMiddleware:  jwtMiddleware,
+		rbac:           rbac,
+	}
+}
+
+// RegisterRouter sets up the merchant and admin reserve routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	reserves := router.Group("/reserves", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	reserves.GET("",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WALLET, auth_entity.OPERATION_READ),
+		h.GetSummary)
+
+	admin := router.Group("/admin/reserves", h.jwtMiddleware)
+	admin.GET("/merchants/:merchant_id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RESERVE, auth_entity.OPERATION_ADMIN_READ),
+		h.GetMerchantSummary)
+	admin.PUT("/merchants/:merchant_id/policy",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RESERVE, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.SetPolicy)
+	admin.DELETE("/merchants/:merchant_id/policy",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RESERVE, auth_entity.OPERATION_ADMIN_DELETE),
+		h.DeletePolicy)
+	admin.GET("/merchants/:merchant_id/holds",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RESERVE, auth_entity.OPERATION_ADMIN_READ),
+		h.ListHolds)
+	admin.POST("/merchants/:merchant_id/holds",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RESERVE, auth_entity.OPERATION_ADMIN_CREATE),
+		h.PlaceHold)
+	admin.POST("/holds/:id/release",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RESERVE, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.ReleaseHold)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// GetSummary godoc
+// @Summary      Get reserves and holds
+// @Description  Get the wallets of the merchant with their reserved, held and available amounts, the reserve policy, the reserve tranches awaiting release and the active holds
+// @Tags         Reserves
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.Summary
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /reserves [get]
+func (h *Handler) GetSummary(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	summary, err := h.reserveUseCase.GetSummary(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetMerchantSummary godoc
+// @Summary      Get the reserves and holds of a merchant
+// @Description  Get the wallets of a merchant with their reserved, held and available amounts, the reserve policy, the reserve tranches awaiting release and the active holds
+// @Tags         Admin Reserves
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      200  {object}  entity.Summary
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/reserves/merchants/{merchant_id} [get]
+func (h *Handler) GetMerchantSummary(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	summary, err := h.reserveUseCase.GetSummary(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// SetPolicy godoc
+// @Summary      Set the rolling reserve of a merchant
+// @Description  Hold back percent of each later deposit of a merchant for hold_days days, up to 50% and 180 days. Matured tranches are released to the available balance automatically.
+// @Tags         Admin Reserves
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string                true  "Merchant ID"
+// @Param        request      body  entity.PolicyRequest  true  "Reserve policy"
+// @Success      200  {object}  entity.Policy
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/reserves/merchants/{merchant_id}/policy [put]
+func (h *Handler) SetPolicy(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.PolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	policy, err := h.reserveUseCase.SetPolicy(c.Request.Context(), merchantID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicy godoc
+// @Summary      Remove the rolling reserve of a merchant
+// @Description  Stop reserving the deposits of a merchant. Tranches already reserved are still released when due.
+// @Tags         Admin Reserves
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/reserves/merchants/{merchant_id}/policy [delete]
+func (h *Handler) DeletePolicy(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	if err := h.reserveUseCase.DeletePolicy(c.Request.Context(), merchantID, adminID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListHolds godoc
+// @Summary      List the holds of a merchant
+// @Description  List the holds placed on the wallets of a merchant, latest first, active ones by default
+// @Tags         Admin Reserves
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path   string  true   "Merchant ID"
+// @Param        status       query  string  false  "ACTIVE (default) or ALL"
+// @Success      200  {object}  entity.HoldListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/reserves/merchants/{merchant_id}/holds [get]
+func (h *Handler) ListHolds(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	var activeOnly bool
+	switch strings.ToUpper(c.Query("status")) {
+	case "", "ACTIVE":
+		activeOnly = true
+	case "ALL":
+	default:
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid status")))
+		return
+	}
+
+	holds, err := h.reserveUseCase.ListHolds(c.Request.Context(), merchantID, activeOnly)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entity.HoldListResponse{Holds: holds})
+}
+
+// PlaceHold godoc
+// @Summary      Place a hold on a merchant's balance
+// @Description  Keep an amount of a merchant's wallet from being withdrawn until the hold is released. The amount must not exceed the available balance.
+// @Tags         Admin Reserves
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string              true  "Merchant ID"
+// @Param        request      body  entity.HoldRequest  true  "Hold"
+// @Success      201  {object}  entity.Hold
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/reserves/merchants/{merchant_id}/holds [post]
+func (h *Handler) PlaceHold(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.HoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	hold, err := h.reserveUseCase.PlaceHold(c.Request.Context(), merchantID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, hold)
+}
+
+// ReleaseHold godoc
+// @Summary      Release a hold
+// @Description  Make the funds of a hold available to the merchant again
+// @Tags         Admin Reserves
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                     true   "Hold ID"
+// @Param        request  body  entity.ReleaseHoldRequest  false  "Release"
+// @Success      200  {object}  entity.Hold
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/reserves/holds/{id}/release [post]
+func (h *Handler) ReleaseHold(c *gin.Context) {
+	holdID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid hold ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.ReleaseHoldRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+
+	hold, err := h.reserveUseCase.ReleaseHold(c.Request.Context(), holdID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hold)
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrPolicyNotFound),
+		errors.Is(err, entity.ErrHoldNotFound),
+		errors.Is(err, entity.ErrWalletNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrHoldReleased),
+		errors.Is(err, entity.ErrInsufficientAvailable):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("Reserve request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	walletEntity "github.com/socialpay/socialpay/src/pkg/wallet/core/entity"
+)
+
+var (
+	// ErrPolicyNotFound is returned when a merchant has no reserve policy
+	ErrPolicyNotFound = errors.New("reserve policy not found")
+	// ErrHoldNotFound is returned when no hold has the given ID
+	ErrHoldNotFound = errors.New("hold not found")
+	// ErrHoldReleased is returned when a hold was already released
+	ErrHoldReleased = errors.New("hold already released")
+	// ErrWalletNotFound is returned when the merchant holds no wallet in the currency
+	ErrWalletNotFound = errors.New("merchant wallet not found")
+	// ErrInsufficientAvailable is returned when a hold exceeds the available balance
+	ErrInsufficientAvailable = errors.New("insufficient available balance")
+)
+
+const (
+	// MaxReservePercent bounds the share of each deposit a policy can reserve
+	MaxReservePercent = 50
+	// MaxReserveDays bounds how long a reserve tranche can be held
+	MaxReserveDays = 180
+)
+
+// Policy is the rolling reserve of a merchant: a share of each deposit is held back for a
+// number of days before it becomes available
+// @Description Rolling reserve policy
+type Policy struct {
+	MerchantID uuid.UUID  `json:"merchant_id"`
+	Percent    float64    `json:"percent" example:"10"`
+	HoldDays   int        `json:"hold_days" example:"30"`
+	Reason     string     `json:"reason,omitempty" example:"Betting company"`
+	Active     bool       `json:"active"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	UpdatedBy  *uuid.UUID `json:"updated_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ReserveOf is the part of a deposit the policy holds back, rounded to the cent
+func (p *Policy) ReserveOf(amount float64) float64 {
+	if !p.Active || amount <= 0 {
+		return 0
+	}
+	return math.Round(amount*p.Percent) / 100
+}
+
+// ReleaseAt is when a tranche reserved at t becomes available
+func (p *Policy) ReleaseAt(t time.Time) time.Time {
+	return t.AddDate(0, 0, p.HoldDays)
+}
+
+// PolicyRequest sets the rolling reserve of a merchant
+// @Description Rolling reserve policy
+type PolicyRequest struct {
+	Percent  float64 `json:"percent" example:"10"`
+	HoldDays int     `json:"hold_days" example:"30"`
+	Reason   string  `json:"reason,omitempty" example:"Betting company"`
+	Active   *bool   `json:"active,omitempty"`
+}
+
+// Validate validates the policy
+func (r *PolicyRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Percent, validation.Required, validation.Min(0.01), validation.Max(float64(MaxReservePercent))),
+		validation.Field(&r.HoldDays, validation.Required, validation.Min(1), validation.Max(MaxReserveDays)),
+		validation.Field(&r.Reason, validation.Length(0, 500)),
+	)
+}
+
+// Tranche is the part of one deposit held back by a merchant's rolling reserve
+// @Description Reserve tranche
+type Tranche struct {
+	ID            uuid.UUID  `json:"id"`
+	MerchantID    uuid.UUID  `json:"merchant_id"`
+	TransactionID uuid.UUID  `json:"transaction_id"`
+	Currency      string     `json:"currency" example:"ETB"`
+	Amount        float64    `json:"amount" example:"150"`
+	ReleaseAt     time.Time  `json:"release_at"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Hold is an amount compliance keeps in a merchant's wallet until it is released
+// @Description Balance hold
+type Hold struct {
+	ID          uuid.UUID  `json:"id"`
+	MerchantID  uuid.UUID  `json:"merchant_id"`
+	Currency    string     `json:"currency" example:"ETB"`
+	Amount      float64    `json:"amount" example:"5000"`
+	Reason      string     `json:"reason" example:"Chargeback investigation"`
+	PlacedBy    uuid.UUID  `json:"placed_by"`
+	ReleasedBy  *uuid.UUID `json:"released_by,omitempty"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty"`
+	ReleaseNote string     `json:"release_note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// HoldRequest places a hold on a merchant's wallet
+// @Description Balance hold
+type HoldRequest struct {
+	Currency string  `json:"currency,omitempty" example:"ETB"`
+	Amount   float64 `json:"amount" example:"5000"`
+	Reason   string  `json:"reason" example:"Chargeback investigation"`
+}
+
+// Normalize puts the currency in the case wallets are kept in, the ETB wallet by default
+func (r *HoldRequest) Normalize() {
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	if r.Currency == "" {
+		r.Currency = string(walletEntity.CurrencyETB)
+	}
+}
+
+// Validate validates the hold
+func (r *HoldRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Amount, validation.Required, validation.Min(0.01)),
+		validation.Field(&r.Reason, validation.Required, validation.Length(1, 500)),
+	); err != nil {
+		return err
+	}
+	if !walletEntity.Currency(r.Currency).IsValid() {
+		return fmt.Errorf("unsupported currency: %s", r.Currency)
+	}
+	return nil
+}
+
+// ReleaseHoldRequest releases a hold
+// @Description Hold release
+type ReleaseHoldRequest struct {
+	Note string `json:"note,omitempty" example:"Investigation closed"`
+}
+
+// Validate validates the release
+func (r *ReleaseHoldRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Note, validation.Length(0, 500)),
+	)
+}
+
+// Summary shows the reserved and held funds of a merchant
+// @Description Reserves and holds of a merchant
+type Summary struct {
+	MerchantID uuid.UUID                     `json:"merchant_id"`
+	Policy     *Policy                       `json:"policy,omitempty"`
+	Wallets    []walletEntity.MerchantWallet `json:"wallets"`
+	Tranches   []Tranche                     `json:"tranches"`
+	Holds      []Hold                        `json:"holds"`
+}
+
+// HoldListResponse lists the holds of a merchant
+// @Description Balance holds
+type HoldListResponse struct {
+	Holds []Hold `json:"holds"`
+}
+
+// TrancheListLimit is how many of the latest tranches a summary shows
+const TrancheListLimit = 100
@@ -0,0 +1,42 @@
+package entity
+
+import "testing"
+
+func TestPolicyReserveOf(t *testing.T) {
+	policy := Policy{Percent: 12.5, HoldDays: 30, Active: true}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		amount float64
+		want   float64
+	}{
+		{"share of the deposit", policy, 1000, 125},
+		{"rounded to the cent", policy, 10.01, 1.25},
+		{"nothing from an empty deposit", policy, 0, 0},
+		{"nothing while inactive", Policy{Percent: 12.5, HoldDays: 30}, 1000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ReserveOf(tt.amount); got != tt.want {
+				t.Fatalf("ReserveOf(%v) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoldRequestValidate(t *testing.T) {
+	req := HoldRequest{Amount: 500, Reason: "Chargeback investigation"}
+	req.Normalize()
+	if req.Currency != "ETB" {
+		t.Fatalf("currency = %q, want the ETB wallet by default", req.Currency)
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	req.Currency = "EUR"
+	if err := req.Validate(); err == nil {
+		t.Fatal("Validate() = nil, want an unsupported currency error")
+	}
+}
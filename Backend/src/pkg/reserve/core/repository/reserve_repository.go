@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/reserve/core/entity"
+)
+
+// ReserveRepository defines the interface for reserve policies, reserve tranches and holds. Tranches
+// and holds are counted in the reserved and held amounts of the merchant's wallet as they are
+// stored and released.
+type ReserveRepository interface {
+	// GetPolicy retrieves the reserve policy of a merchant, nil when it has none
+	GetPolicy(ctx context.Context, merchantID uuid.UUID) (*entity.Policy, error)
+	// SavePolicy creates or replaces the reserve policy of a merchant
+	SavePolicy(ctx context.Context, policy *entity.Policy) error
+	DeletePolicy(ctx context.Context, merchantID uuid.UUID) error
+
+	// CreateTranche reserves a tranche in the merchant's wallet of its currency, capped at the
+	// available balance. It returns false when the transaction was already reserved or nothing was available.
+	CreateTranche(ctx context.Context, tranche *entity.Tranche) (bool, error)
+	// ReleaseTranches releases every tranche due by now and returns how many were released
+	ReleaseTranches(ctx context.Context, now time.Time) (int, error)
+	// ListTranches retrieves the unreleased tranches of a merchant, the first due first
+	ListTranches(ctx context.Context, merchantID uuid.UUID, limit int) ([]entity.Tranche, error)
+
+	// CreateHold holds an amount in the merchant's wallet of its currency. It returns
+	// entity.ErrInsufficientAvailable when the amount exceeds the available balance.
+	CreateHold(ctx context.Context, hold *entity.Hold) error
+	// GetHold retrieves a hold, nil when none exists
+	GetHold(ctx context.Context, id uuid.UUID) (*entity.Hold, error)
+	// ReleaseHold releases a hold. It returns entity.ErrHoldReleased when it was already released.
+	ReleaseHold(ctx context.Context, hold *entity.Hold) error
+	// ListHolds retrieves the holds of a merchant, latest first
+	ListHolds(ctx context.Context, merchantID uuid.UUID, activeOnly bool) ([]entity.Hold, error)
+}
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/reserve/core/entity"
+)
+
+const policyColumns = `merchant_id, percent, hold_days, reason, active, created_by, updated_by, created_at, updated_at`
+
+const trancheColumns = `id, merchant_id, transaction_id, currency, amount, release_at, released_at, created_at`
+
+const holdColumns = `id, merchant_id, currency, amount, reason, placed_by, released_by, released_at, release_note, created_at`
+
+type ReserveRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReserveRepository(db *sql.DB) ReserveRepository {
+	return &ReserveRepositoryImpl{db: db}
+}
+
+func (r *ReserveRepositoryImpl) GetPolicy(ctx context.Context, merchantID uuid.UUID) (*entity.Policy, error) {
+	query := `SELECT ` + policyColumns + ` FROM public.reserve_policies WHERE merchant_id = $1`
+
+	var policy entity.Policy
+	var reason sql.NullString
+	err := r.db.QueryRowContext(ctx, query, merchantID).Scan(
+		&policy.MerchantID, &policy.Percent, &policy.HoldDays, &reason, &policy.Active,
+		&policy.CreatedBy, &policy.UpdatedBy, &policy.CreatedAt, &policy.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get reserve policy: %w", err)
+	}
+	policy.Reason = reason.String
+
+	return &policy, nil
+}
+
+func (r *ReserveRepositoryImpl) SavePolicy(ctx context.Context, policy *entity.Policy) error {
+	query := `
+		INSERT INTO public.reserve_policies (` + policyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (merchant_id) DO UPDATE SET
+			percent = EXCLUDED.percent,
+			hold_days = EXCLUDED.hold_days,
+			reason = EXCLUDED.reason,
+			active = EXCLUDED.active,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		policy.MerchantID, policy.Percent, policy.HoldDays, nullString(policy.Reason), policy.Active,
+		policy.CreatedBy, policy.UpdatedBy, policy.CreatedAt, policy.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save reserve policy: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReserveRepositoryImpl) DeletePolicy(ctx context.Context, merchantID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM public.reserve_policies WHERE merchant_id = $1`, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete reserve policy: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete reserve policy: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrPolicyNotFound
+	}
+
+	return nil
+}
+
+// lockAvailable locks the merchant's wallet in a currency and reads its available balance
+func lockAvailable(ctx context.Context, dbTx *sql.Tx, merchantID uuid.UUID, currency string) (float64, error) {
+	query := `
+		SELECT amount - reserved_amount - held_amount
+		FROM merchant.wallet
+		WHERE merchant_id = $1 AND wallet_type = 'merchant' AND currency = $2
+		FOR UPDATE`
+
+	var available float64
+	if err := dbTx.QueryRowContext(ctx, query, merchantID, currency).Scan(&available); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, entity.ErrWalletNotFound
+		}
+		return 0, fmt.Errorf("failed to lock wallet: %w", err)
+	}
+
+	return math.Floor(available*100) / 100, nil
+}
+
+func (r *ReserveRepositoryImpl) CreateTranche(ctx context.Context, tranche *entity.Tranche) (bool, error) {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	available, err := lockAvailable(ctx, dbTx, tranche.MerchantID, tranche.Currency)
+	if err != nil {
+		return false, err
+	}
+	tranche.Amount = math.Min(tranche.Amount, available)
+	if tranche.Amount <= 0 {
+		return false, nil
+	}
+
+	query := `
+		INSERT INTO public.reserve_tranches (` + trancheColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (transaction_id) DO NOTHING`
+
+	result, err := dbTx.ExecContext(ctx, query,
+		tranche.ID, tranche.MerchantID, tranche.TransactionID, tranche.Currency, tranche.Amount,
+		tranche.ReleaseAt, tranche.ReleasedAt, tranche.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create reserve tranche: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create reserve tranche: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if err := adjustWallet(ctx, dbTx, "reserved_amount", tranche.MerchantID, tranche.Currency, tranche.Amount); err != nil {
+		return false, err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit reserve tranche: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *ReserveRepositoryImpl) ReleaseTranches(ctx context.Context, now time.Time) (int, error) {
+	// One statement, so a tranche is never marked released without leaving the reserved amount
+	query := `
+		WITH released AS (
+			UPDATE public.reserve_tranches
+			SET released_at = $1
+			WHERE released_at IS NULL AND release_at <= $1
+			RETURNING merchant_id, currency, amount
+		),
+		totals AS (
+			SELECT merchant_id, currency, SUM(amount) AS amount
+			FROM released
+			GROUP BY merchant_id, currency
+		),
+		wallets AS (
+			UPDATE merchant.wallet w
+			SET reserved_amount = GREATEST(w.reserved_amount - t.amount, 0),
+				updated_at = NOW()
+			FROM totals t
+			WHERE w.merchant_id = t.merchant_id AND w.currency = t.currency AND w.wallet_type = 'merchant'
+			RETURNING w.id
+		)
+		SELECT COUNT(*) FROM released`
+
+	var released int
+	if err := r.db.QueryRowContext(ctx, query, now).Scan(&released); err != nil {
+		return 0, fmt.Errorf("failed to release reserve tranches: %w", err)
+	}
+
+	return released, nil
+}
+
+func (r *ReserveRepositoryImpl) ListTranches(ctx context.Context, merchantID uuid.UUID, limit int) ([]entity.Tranche, error) {
+	query := `
+		SELECT ` + trancheColumns + `
+		FROM public.reserve_tranches
+		WHERE merchant_id = $1 AND released_at IS NULL
+		ORDER BY release_at
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reserve tranches: %w", err)
+	}
+	defer rows.Close()
+
+	var tranches []entity.Tranche
+	for rows.Next() {
+		var tranche entity.Tranche
+		if err := rows.Scan(
+			&tranche.ID, &tranche.MerchantID, &tranche.TransactionID, &tranche.Currency, &tranche.Amount,
+			&tranche.ReleaseAt, &tranche.ReleasedAt, &tranche.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan reserve tranche: %w", err)
+		}
+		tranches = append(tranches, tranche)
+	}
+
+	return tranches, rows.Err()
+}
+
+func (r *ReserveRepositoryImpl) CreateHold(ctx context.Context, hold *entity.Hold) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	available, err := lockAvailable(ctx, dbTx, hold.MerchantID, hold.Currency)
+	if err != nil {
+		return err
+	}
+	if hold.Amount > available {
+		return fmt.Errorf("%w: %.2f %s available", entity.ErrInsufficientAvailable, available, hold.Currency)
+	}
+
+	query := `
+		INSERT INTO public.balance_holds (` + holdColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	if _, err := dbTx.ExecContext(ctx, query,
+		hold.ID, hold.MerchantID, hold.Currency, hold.Amount, hold.Reason, hold.PlacedBy,
+		hold.ReleasedBy, hold.ReleasedAt, nullString(hold.ReleaseNote), hold.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create hold: %w", err)
+	}
+
+	if err := adjustWallet(ctx, dbTx, "held_amount", hold.MerchantID, hold.Currency, hold.Amount); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit hold: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReserveRepositoryImpl) GetHold(ctx context.Context, id uuid.UUID) (*entity.Hold, error) {
+	query := `SELECT ` + holdColumns + ` FROM public.balance_holds WHERE id = $1`
+
+	hold, err := scanHold(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+
+	return hold, nil
+}
+
+func (r *ReserveRepositoryImpl) ReleaseHold(ctx context.Context, hold *entity.Hold) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	query := `
+		UPDATE public.balance_holds
+		SET released_by = $2, released_at = $3, release_note = $4
+		WHERE id = $1 AND released_at IS NULL`
+
+	result, err := dbTx.ExecContext(ctx, query, hold.ID, hold.ReleasedBy, hold.ReleasedAt, nullString(hold.ReleaseNote))
+	if err != nil {
+		return fmt.Errorf("failed to release hold: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to release hold: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrHoldReleased
+	}
+
+	if err := adjustWallet(ctx, dbTx, "held_amount", hold.MerchantID, hold.Currency, -hold.Amount); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit hold release: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReserveRepositoryImpl) ListHolds(ctx context.Context, merchantID uuid.UUID, activeOnly bool) ([]entity.Hold, error) {
+	query := `
+		SELECT ` + holdColumns + `
+		FROM public.balance_holds
+		WHERE merchant_id = $1 AND (NOT $2::boolean OR released_at IS NULL)
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holds: %w", err)
+	}
+	defer rows.Close()
+
+	var holds []entity.Hold
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan hold: %w", err)
+		}
+		holds = append(holds, *hold)
+	}
+
+	return holds, rows.Err()
+}
+
+// adjustWallet moves an amount into or out of the reserved or held amount of a wallet, never below zero
+func adjustWallet(ctx context.Context, dbTx *sql.Tx, column string, merchantID uuid.UUID, currency string, amount float64) error {
+	query := `
+		UPDATE merchant.wallet
+		SET ` + column + ` = GREATEST(` + column + ` + $3, 0),
+			updated_at = NOW()
+		WHERE merchant_id = $1 AND wallet_type = 'merchant' AND currency = $2`
+
+	result, err := dbTx.ExecContext(ctx, query, merchantID, currency, amount)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet %s: %w", column, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update wallet %s: %w", column, err)
+	}
+	if affected == 0 {
+		return entity.ErrWalletNotFound
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanHold(row rowScanner) (*entity.Hold, error) {
+	var hold entity.Hold
+	var note sql.NullString
+	if err := row.Scan(
+		&hold.ID, &hold.MerchantID, &hold.Currency, &hold.Amount, &hold.Reason, &hold.PlacedBy,
+		&hold.ReleasedBy, &hold.ReleasedAt, &note, &hold.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	hold.ReleaseNote = note.String
+
+	return &hold, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
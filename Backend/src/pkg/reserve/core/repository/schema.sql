@@ -0,0 +1,46 @@
+-- Reserve Schema
+-- This should match the migration exactly
+
+-- Rolling reserve of a merchant: a share of each deposit is held back for a number of days
+CREATE TABLE IF NOT EXISTS public.reserve_policies (
+    merchant_id UUID PRIMARY KEY REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    percent DECIMAL(5,2) NOT NULL,
+    hold_days INT NOT NULL,
+    reason TEXT,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID NOT NULL,
+    updated_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The part of each deposit held back, counted in merchant.wallet.reserved_amount until released
+CREATE TABLE IF NOT EXISTS public.reserve_tranches (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    transaction_id UUID NOT NULL UNIQUE REFERENCES public.transactions(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    release_at TIMESTAMPTZ NOT NULL,
+    released_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reserve_tranches_due ON public.reserve_tranches(release_at) WHERE released_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reserve_tranches_merchant ON public.reserve_tranches(merchant_id, release_at);
+
+-- Holds placed by compliance, counted in merchant.wallet.held_amount until released
+CREATE TABLE IF NOT EXISTS public.balance_holds (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    reason TEXT NOT NULL,
+    placed_by UUID NOT NULL,
+    released_by UUID,
+    released_at TIMESTAMPTZ,
+    release_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_holds_merchant ON public.balance_holds(merchant_id, created_at);
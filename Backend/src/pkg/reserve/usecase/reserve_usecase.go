@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/reserve/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/reserve/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	walletEntity "github.com/socialpay/socialpay/src/pkg/wallet/core/entity"
+	walletUsecase "github.com/socialpay/socialpay/src/pkg/wallet/usecase"
+)
+
+// ReserveUseCase defines the interface for rolling reserves and balance holds on merchant wallets
+type ReserveUseCase interface {
+	// ReserveDeposit holds back the share of a deposit the merchant's reserve policy asks for. It is
+	// called once the deposit was credited to the merchant's wallet in currency.
+	ReserveDeposit(ctx context.Context, merchantID, transactionID uuid.UUID, currency string, amount float64) error
+
+	// ReleaseMatured makes the reserve tranches whose reserve period ended available; it is run periodically
+	ReleaseMatured(ctx context.Context) error
+
+	// GetSummary retrieves the reserve policy, wallets, pending tranches and active holds of a merchant
+	GetSummary(ctx context.Context, merchantID uuid.UUID) (*entity.Summary, error)
+
+	// SetPolicy creates or replaces the reserve policy of a merchant. It applies to later deposits.
+	SetPolicy(ctx context.Context, merchantID uuid.UUID, req *entity.PolicyRequest, adminID uuid.UUID) (*entity.Policy, error)
+
+	// DeletePolicy removes the reserve policy of a merchant. Tranches already reserved are released when due.
+	DeletePolicy(ctx context.Context, merchantID, adminID uuid.UUID) error
+
+	// PlaceHold holds part of the available balance of a merchant until it is released
+	PlaceHold(ctx context.Context, merchantID uuid.UUID, req *entity.HoldRequest, adminID uuid.UUID) (*entity.Hold, error)
+
+	// ReleaseHold makes held funds available again
+	ReleaseHold(ctx context.Context, id uuid.UUID, req *entity.ReleaseHoldRequest, adminID uuid.UUID) (*entity.Hold, error)
+
+	// ListHolds retrieves the holds of a merchant, latest first
+	ListHolds(ctx context.Context, merchantID uuid.UUID, activeOnly bool) ([]entity.Hold, error)
+}
+
+type reserveUseCase struct {
+	repo    repository.ReserveRepository
+	wallets walletUsecase.MerchantWalletUsecase
+	log     logging.Logger
+}
+
+// NewReserveUseCase creates the reserve usecase
+func NewReserveUseCase(repo repository.ReserveRepository, wallets walletUsecase.MerchantWalletUsecase) ReserveUseCase {
+	return &reserveUseCase{
+		repo:    repo,
+		wallets: wallets,
+		log:     logging.NewStdLogger("reserve_usecase"),
+	}
+}
+
+func (uc *reserveUseCase) ReserveDeposit(ctx context.Context, merchantID, transactionID uuid.UUID, currency string, amount float64) error {
+	policy, err := uc.repo.GetPolicy(ctx, merchantID)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return nil
+	}
+	reserve := policy.ReserveOf(amount)
+	if reserve <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	tranche := &entity.Tranche{
+		ID:            uuid.New(),
+		MerchantID:    merchantID,
+		TransactionID: transactionID,
+		Currency:      currency,
+		Amount:        reserve,
+		ReleaseAt:     policy.ReleaseAt(now),
+		CreatedAt:     now,
+	}
+	reserved, err := uc.repo.CreateTranche(ctx, tranche)
+	if err != nil {
+		return fmt.Errorf("failed to reserve deposit: %w", err)
+	}
+	if !reserved {
+		return nil
+	}
+
+	if tranche.Amount < reserve {
+		uc.log.Warn("Reserve tranche capped at the available balance", map[string]interface{}{
+			"merchant_id":    merchantID,
+			"transaction_id": transactionID,
+			"requested":      reserve,
+			"reserved":       tranche.Amount,
+		})
+	}
+	uc.log.Info("Deposit reserved", map[string]interface{}{
+		"merchant_id":    merchantID,
+		"transaction_id": transactionID,
+		"currency":       currency,
+		"amount":         tranche.Amount,
+		"release_at":     tranche.ReleaseAt,
+	})
+
+	return nil
+}
+
+func (uc *reserveUseCase) ReleaseMatured(ctx context.Context) error {
+	released, err := uc.repo.ReleaseTranches(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if released > 0 {
+		uc.log.Info("Matured reserve tranches released", map[string]interface{}{
+			"tranches": released,
+		})
+	}
+
+	return nil
+}
+
+func (uc *reserveUseCase) GetSummary(ctx context.Context, merchantID uuid.UUID) (*entity.Summary, error) {
+	wallets, err := uc.merchantWallets(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := uc.repo.GetPolicy(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tranches, err := uc.repo.ListTranches(ctx, merchantID, entity.TrancheListLimit)
+	if err != nil {
+		return nil, err
+	}
+	if tranches == nil {
+		tranches = []entity.Tranche{}
+	}
+
+	holds, err := uc.ListHolds(ctx, merchantID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Summary{
+		MerchantID: merchantID,
+		Policy:     policy,
+		Wallets:    wallets,
+		Tranches:   tranches,
+		Holds:      holds,
+	}, nil
+}
+
+func (uc *reserveUseCase) SetPolicy(ctx context.Context, merchantID uuid.UUID, req *entity.PolicyRequest, adminID uuid.UUID) (*entity.Policy, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if _, err := uc.merchantWallets(ctx, merchantID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	policy := &entity.Policy{
+		MerchantID: merchantID,
+		Percent:    req.Percent,
+		HoldDays:   req.HoldDays,
+		Reason:     req.Reason,
+		Active:     req.Active == nil || *req.Active,
+		CreatedBy:  adminID,
+		UpdatedBy:  &adminID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := uc.repo.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Reserve policy set", map[string]interface{}{
+		"merchant_id": merchantID,
+		"percent":     policy.Percent,
+		"hold_days":   policy.HoldDays,
+		"active":      policy.Active,
+		"admin_id":    adminID,
+	})
+
+	return uc.repo.GetPolicy(ctx, merchantID)
+}
+
+func (uc *reserveUseCase) DeletePolicy(ctx context.Context, merchantID, adminID uuid.UUID) error {
+	if err := uc.repo.DeletePolicy(ctx, merchantID); err != nil {
+		return err
+	}
+
+	uc.log.Info("Reserve policy deleted", map[string]interface{}{
+		"merchant_id": merchantID,
+		"admin_id":    adminID,
+	})
+
+	return nil
+}
+
+func (uc *reserveUseCase) PlaceHold(ctx context.Context, merchantID uuid.UUID, req *entity.HoldRequest, adminID uuid.UUID) (*entity.Hold, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	hold := &entity.Hold{
+		ID:         uuid.New(),
+		MerchantID: merchantID,
+		Currency:   req.Currency,
+		Amount:     req.Amount,
+		Reason:     req.Reason,
+		PlacedBy:   adminID,
+		CreatedAt:  time.Now(),
+	}
+	if err := uc.repo.CreateHold(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Balance hold placed", map[string]interface{}{
+		"hold_id":     hold.ID,
+		"merchant_id": merchantID,
+		"currency":    hold.Currency,
+		"amount":      hold.Amount,
+		"reason":      hold.Reason,
+		"admin_id":    adminID,
+	})
+
+	return hold, nil
+}
+
+func (uc *reserveUseCase) ReleaseHold(ctx context.Context, id uuid.UUID, req *entity.ReleaseHoldRequest, adminID uuid.UUID) (*entity.Hold, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	hold, err := uc.repo.GetHold(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hold == nil {
+		return nil, entity.ErrHoldNotFound
+	}
+	if hold.ReleasedAt != nil {
+		return nil, entity.ErrHoldReleased
+	}
+
+	now := time.Now()
+	hold.ReleasedBy = &adminID
+	hold.ReleasedAt = &now
+	hold.ReleaseNote = req.Note
+	if err := uc.repo.ReleaseHold(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Balance hold released", map[string]interface{}{
+		"hold_id":     hold.ID,
+		"merchant_id": hold.MerchantID,
+		"amount":      hold.Amount,
+		"admin_id":    adminID,
+	})
+
+	return hold, nil
+}
+
+func (uc *reserveUseCase) ListHolds(ctx context.Context, merchantID uuid.UUID, activeOnly bool) ([]entity.Hold, error) {
+	holds, err := uc.repo.ListHolds(ctx, merchantID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	if holds == nil {
+		holds = []entity.Hold{}
+	}
+
+	return holds, nil
+}
+
+// merchantWallets lists the wallets of a merchant, failing when it has none
+func (uc *reserveUseCase) merchantWallets(ctx context.Context, merchantID uuid.UUID) ([]walletEntity.MerchantWallet, error) {
+	wallets, err := uc.wallets.ListMerchantWallets(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(wallets) == 0 {
+		return nil, entity.ErrWalletNotFound
+	}
+
+	return wallets, nil
+}
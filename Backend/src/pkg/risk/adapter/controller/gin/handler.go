@@ -0,0 +1,354 @@
+package gin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/risk/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+type Handler struct {
+	riskUseCase   usecase.RiskUseCase
+	scoreUseCase  usecase.MerchantScoreUseCase
+	log           logging.Logger
+	jwtMiddleware gin.HandlerFunc
+	rbac          *ginMiddleware.RBACV2
+}
+
+func NewHandler(riskUseCase usecase.RiskUseCase, scoreUseCase usecase.MerchantScoreUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		riskUseCase:   riskUseCase,
+		scoreUseCase:  scoreUseCase,
+		log:           logging.NewStdLogger("risk_handler"),
+		jwtMiddleware: jwtMiddleware,
+		rbac:          rbac,
+	}
+}
+
+// RegisterRouter sets up the admin risk routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	admin := router.Group("/admin/risk", h.jwtMiddleware)
+	admin.GET("/rules/:merchant_id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_READ),
+		h.GetRules)
+	admin.PUT("/rules/:merchant_id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.UpdateRules)
+	admin.GET("/reviews",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_READ),
+		h.ListReviews)
+	admin.POST("/reviews/:id/approve",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.ApproveReview)
+	admin.POST("/reviews/:id/reject",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.RejectReview)
+	admin.GET("/assessments/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_READ),
+		h.GetAssessment)
+	admin.GET("/transactions/:transaction_id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_READ),
+		h.GetTransactionAssessment)
+	admin.GET("/merchants",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_READ),
+		h.ListMerchantScores)
+	admin.GET("/merchants/:merchant_id/scores",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_READ),
+		h.GetMerchantScores)
+	admin.POST("/scoring-runs",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.RunScoring)
+	admin.GET("/alerts",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_READ),
+		h.ListAlerts)
+	admin.POST("/alerts/:id/acknowledge",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_RISK, auth_entity.OPERATION_ADMIN_UPDATE),
+		h.AcknowledgeAlert)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// GetRules godoc
+// @Summary      Get merchant risk rules
+// @Description  Get the effective risk rules of a merchant, the platform defaults merged with the merchant's overrides
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string  true  "Merchant ID"
+// @Success      200  {object}  entity.Rules
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/risk/rules/{merchant_id} [get]
+func (h *Handler) GetRules(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	rules, err := h.riskUseCase.GetRules(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// UpdateRules godoc
+// @Summary      Update merchant risk rules
+// @Description  Replace the risk rules of a merchant. Omitted rules keep their platform defaults; a rule with an empty outcome is switched off.
+// @Tags         Admin Risk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path  string        true  "Merchant ID"
+// @Param        request      body  entity.Rules  true  "Risk rules"
+// @Success      200  {object}  entity.Rules
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /admin/risk/rules/{merchant_id} [put]
+func (h *Handler) UpdateRules(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	// Start from the defaults so a partial body only overrides what it names
+	rules := entity.DefaultRules()
+	if err := c.ShouldBindJSON(rules); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	updated, err := h.riskUseCase.UpdateRules(c.Request.Context(), merchantID, rules, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// ListReviews godoc
+// @Summary      List the risk review queue
+// @Description  List transactions sent to manual review, pending ones by default, oldest first
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status       query  string  false  "PENDING (default), APPROVED, REJECTED or ALL"
+// @Param        merchant_id  query  string  false  "Merchant ID"
+// @Param        flow         query  string  false  "DIRECT, CHECKOUT, QR or WITHDRAWAL"
+// @Param        limit        query  int     false  "Number of reviews (max 200)"
+// @Success      200  {object}  entity.ReviewListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/risk/reviews [get]
+func (h *Handler) ListReviews(c *gin.Context) {
+	filter := entity.ReviewFilter{
+		Status: entity.ReviewPending,
+		Flow:   entity.Flow(strings.ToUpper(c.Query("flow"))),
+	}
+	switch status := entity.ReviewStatus(strings.ToUpper(c.Query("status"))); status {
+	case "", entity.ReviewPending:
+	case entity.ReviewApproved, entity.ReviewRejected:
+		filter.Status = status
+	case "ALL":
+		filter.Status = ""
+	default:
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid status")))
+		return
+	}
+	if merchantID := c.Query("merchant_id"); merchantID != "" {
+		id, err := uuid.Parse(merchantID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+			return
+		}
+		filter.MerchantID = &id
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	response, err := h.riskUseCase.ListReviews(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveReview godoc
+// @Summary      Approve a risk review
+// @Description  Clear a transaction in the review queue. A held withdrawal continues through the merchant's payout policy to its processor, and a held payment is sent to its processor.
+// @Tags         Admin Risk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true   "Assessment ID"
+// @Param        request  body  entity.DecisionRequest  false  "Decision"
+// @Success      200  {object}  entity.Assessment
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/risk/reviews/{id}/approve [post]
+func (h *Handler) ApproveReview(c *gin.Context) {
+	h.decide(c, h.riskUseCase.Approve)
+}
+
+// RejectReview godoc
+// @Summary      Reject a risk review
+// @Description  Mark a transaction in the review queue as fraudulent. A held payment fails, and so does a held withdrawal, whose funds are unlocked.
+// @Tags         Admin Risk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true   "Assessment ID"
+// @Param        request  body  entity.DecisionRequest  false  "Decision"
+// @Success      200  {object}  entity.Assessment
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/risk/reviews/{id}/reject [post]
+func (h *Handler) RejectReview(c *gin.Context) {
+	h.decide(c, h.riskUseCase.Reject)
+}
+
+type decisionFunc func(ctx context.Context, id, reviewerID uuid.UUID, req *entity.DecisionRequest) (*entity.Assessment, error)
+
+func (h *Handler) decide(c *gin.Context, decision decisionFunc) {
+	assessmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid assessment ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.DecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	assessment, err := decision(c.Request.Context(), assessmentID, adminID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assessment)
+}
+
+// GetAssessment godoc
+// @Summary      Get a risk assessment
+// @Description  Get a risk assessment with the rules it triggered
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Assessment ID"
+// @Success      200  {object}  entity.Assessment
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/risk/assessments/{id} [get]
+func (h *Handler) GetAssessment(c *gin.Context) {
+	assessmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid assessment ID")))
+		return
+	}
+
+	assessment, err := h.riskUseCase.GetAssessment(c.Request.Context(), assessmentID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assessment)
+}
+
+// GetTransactionAssessment godoc
+// @Summary      Get the risk assessment of a transaction
+// @Description  Get the risk assessment made before a transaction was sent to its processor
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        transaction_id  path  string  true  "Transaction ID"
+// @Success      200  {object}  entity.Assessment
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/risk/transactions/{transaction_id} [get]
+func (h *Handler) GetTransactionAssessment(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("transaction_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid transaction ID")))
+		return
+	}
+
+	assessment, err := h.riskUseCase.GetAssessmentByTransactionID(c.Request.Context(), transactionID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if assessment == nil {
+		c.JSON(http.StatusNotFound, newErrorResponse(entity.ErrAssessmentNotFound))
+		return
+	}
+
+	c.JSON(http.StatusOK, assessment)
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrAssessmentNotFound),
+		errors.Is(err, entity.ErrAlertNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrReviewDecided),
+		errors.Is(err, entity.ErrNotInReview),
+		errors.Is(err, entity.ErrAlertAcknowledged):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("Risk request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
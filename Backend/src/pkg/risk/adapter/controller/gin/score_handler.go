@@ -0,0 +1,214 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+)
+
+// ListMerchantScores godoc
+// @Summary      Rank merchants by risk score
+// @Description  Rank merchants by their daily risk score, riskiest first. Scores are computed every night from the previous day's transactions: volume deviation, failure, refund and night-time ratios, payouts against collections, account age and industry.
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        date   query  string  false  "Scoring day (YYYY-MM-DD), the latest scored day by default"
+// @Param        level  query  string  false  "Lowest level to include: LOW (default), MEDIUM or HIGH"
+// @Param        limit  query  int     false  "Number of merchants (max 200)"
+// @Success      200  {object}  entity.ScoreListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/risk/merchants [get]
+func (h *Handler) ListMerchantScores(c *gin.Context) {
+	var filter entity.ScoreFilter
+	if date := c.Query("date"); date != "" {
+		day, err := parseScoreDate(date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+		filter.Date = &day
+	}
+	switch level := entity.ScoreLevel(strings.ToUpper(c.Query("level"))); level {
+	case "", entity.ScoreLow, entity.ScoreMedium, entity.ScoreHigh:
+		filter.MinLevel = level
+	default:
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid level")))
+		return
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	response, err := h.scoreUseCase.ListScores(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMerchantScores godoc
+// @Summary      Get the risk score history of a merchant
+// @Description  Get the daily risk scores of a merchant with the factors behind each, latest first
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        merchant_id  path   string  true   "Merchant ID"
+// @Param        limit        query  int     false  "Number of days (default 90, max 200)"
+// @Success      200  {object}  entity.ScoreListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/risk/merchants/{merchant_id}/scores [get]
+func (h *Handler) GetMerchantScores(c *gin.Context) {
+	merchantID, err := uuid.Parse(c.Param("merchant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	response, err := h.scoreUseCase.GetMerchantScores(c.Request.Context(), merchantID, limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RunScoring godoc
+// @Summary      Score a day of merchant activity
+// @Description  Score every merchant active on a day again, replacing its earlier scores. Merchants already alerted on for the day are not alerted on again.
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        date  query  string  false  "Scoring day (YYYY-MM-DD), yesterday by default"
+// @Success      200  {object}  entity.ScoringRun
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/risk/scoring-runs [post]
+func (h *Handler) RunScoring(c *gin.Context) {
+	day := entity.ScoreDay(time.Now()).AddDate(0, 0, -1)
+	if date := c.Query("date"); date != "" {
+		parsed, err := parseScoreDate(date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+		day = parsed
+	}
+	if !day.Before(entity.ScoreDay(time.Now()).AddDate(0, 0, 1)) {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("cannot score a future day")))
+		return
+	}
+
+	run, err := h.scoreUseCase.ScoreDay(c.Request.Context(), day)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// ListAlerts godoc
+// @Summary      List merchant risk alerts
+// @Description  List the alerts raised when a merchant scored HIGH or breached the band of an activity factor, open ones by default
+// @Tags         Admin Risk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status       query  string  false  "OPEN (default), ACKNOWLEDGED or ALL"
+// @Param        merchant_id  query  string  false  "Merchant ID"
+// @Param        limit        query  int     false  "Number of alerts (max 200)"
+// @Success      200  {object}  entity.AlertListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/risk/alerts [get]
+func (h *Handler) ListAlerts(c *gin.Context) {
+	var filter entity.AlertFilter
+	switch strings.ToUpper(c.Query("status")) {
+	case "", "OPEN":
+		acknowledged := false
+		filter.Acknowledged = &acknowledged
+	case "ACKNOWLEDGED":
+		acknowledged := true
+		filter.Acknowledged = &acknowledged
+	case "ALL":
+	default:
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid status")))
+		return
+	}
+	if merchantID := c.Query("merchant_id"); merchantID != "" {
+		id, err := uuid.Parse(merchantID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+			return
+		}
+		filter.MerchantID = &id
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	response, err := h.scoreUseCase.ListAlerts(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AcknowledgeAlert godoc
+// @Summary      Acknowledge a merchant risk alert
+// @Description  Record that an admin looked into a merchant risk alert
+// @Tags         Admin Risk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                      true   "Alert ID"
+// @Param        request  body  entity.AcknowledgeRequest  false  "Acknowledgement"
+// @Success      200  {object}  entity.MerchantAlert
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/risk/alerts/{id}/acknowledge [post]
+func (h *Handler) AcknowledgeAlert(c *gin.Context) {
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid alert ID")))
+		return
+	}
+
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.AcknowledgeRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+
+	alert, err := h.scoreUseCase.AcknowledgeAlert(c.Request.Context(), alertID, adminID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+func parseScoreDate(date string) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, entity.ScoreZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date, expected YYYY-MM-DD")
+	}
+	return day, nil
+}
@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+var (
+	// ErrBlocked is returned when the risk rules block a payment or withdrawal
+	ErrBlocked = errors.New("transaction blocked by risk rules")
+	// ErrAssessmentNotFound is returned when no risk assessment has the given ID
+	ErrAssessmentNotFound = errors.New("risk assessment not found")
+	// ErrNotInReview is returned when deciding on an assessment that was never sent to review
+	ErrNotInReview = errors.New("risk assessment is not in the review queue")
+	// ErrReviewDecided is returned when a review was already approved or rejected
+	ErrReviewDecided = errors.New("risk review has already been decided")
+)
+
+// DetailKey is the transaction details key the outcome of the risk assessment is stored under
+const DetailKey = "risk"
+
+// Flow is the kind of request a risk assessment was made for
+type Flow string
+
+const (
+	FlowDirect     Flow = "DIRECT"
+	FlowCheckout   Flow = "CHECKOUT"
+	FlowQR         Flow = "QR"
+	FlowWithdrawal Flow = "WITHDRAWAL"
+)
+
+// ReviewStatus is the state of an assessment in the manual review queue
+type ReviewStatus string
+
+const (
+	ReviewPending ReviewStatus = "PENDING"
+	// ReviewApproved clears the transaction; a held withdrawal continues to its processor
+	ReviewApproved ReviewStatus = "APPROVED"
+	// ReviewRejected marks the transaction as fraudulent; a held withdrawal fails and its funds are unlocked
+	ReviewRejected ReviewStatus = "REJECTED"
+)
+
+// Assessment is the evaluation of a payment or withdrawal against the risk rules of its merchant
+type Assessment struct {
+	ID            uuid.UUID                  `json:"id"`
+	MerchantID    uuid.UUID                  `json:"merchant_id"`
+	TransactionID uuid.UUID                  `json:"transaction_id"`
+	Flow          Flow                       `json:"flow"`
+	Medium        txEntity.TransactionMedium `json:"medium"`
+	Amount        float64                    `json:"amount"`
+	Currency      string                     `json:"currency"`
+	PhoneNumber   string                     `json:"phone_number,omitempty"`
+	IPAddress     string                     `json:"ip_address,omitempty"`
+	DeviceID      string                     `json:"device_id,omitempty"`
+	Country       string                     `json:"country,omitempty"`
+	// CardFingerprint is a hash of the card token, used for card velocity and to blocklist the card
+	CardFingerprint string `json:"card_fingerprint,omitempty"`
+
+	Outcome Outcome  `json:"outcome"`
+	Reasons []Reason `json:"reasons"`
+	Test    bool     `json:"test"`
+
+	// ReviewStatus is empty unless the outcome sent the transaction to the review queue
+	ReviewStatus ReviewStatus `json:"review_status,omitempty"`
+	ReviewedBy   *uuid.UUID   `json:"reviewed_by,omitempty"`
+	ReviewNote   string       `json:"review_note,omitempty"`
+	ReviewedAt   *time.Time   `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// Decidable reports why an admin cannot decide on the assessment, if they cannot
+func (a *Assessment) Decidable() error {
+	switch a.ReviewStatus {
+	case ReviewPending:
+		return nil
+	case "":
+		return ErrNotInReview
+	default:
+		return ErrReviewDecided
+	}
+}
+
+// Detail is the summary of the assessment stored on its transaction
+func (a *Assessment) Detail() map[string]interface{} {
+	rules := make([]string, len(a.Reasons))
+	for i, reason := range a.Reasons {
+		rules[i] = string(reason.Rule)
+	}
+	return map[string]interface{}{
+		"assessment_id": a.ID.String(),
+		"outcome":       string(a.Outcome),
+		"rules":         rules,
+	}
+}
+
+// Summary describes the triggered rules for status history and error messages
+func (a *Assessment) Summary() string {
+	details := make([]string, len(a.Reasons))
+	for i, reason := range a.Reasons {
+		details[i] = string(reason.Rule) + " (" + reason.Detail + ")"
+	}
+	return strings.Join(details, ", ")
+}
+
+// DecisionRequest approves or rejects a transaction in the review queue
+// @Description Review decision
+type DecisionRequest struct {
+	Note string `json:"note,omitempty" example:"Customer confirmed the payments by phone"`
+}
+
+// Validate validates the decision
+func (r *DecisionRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Note, validation.Length(0, 500)),
+	)
+}
+
+// ReviewFilter narrows the review queue
+type ReviewFilter struct {
+	MerchantID *uuid.UUID
+	Status     ReviewStatus
+	Flow       Flow
+	Limit      int
+}
+
+// ReviewListResponse lists assessments in the review queue
+// @Description Transactions sent to manual review, oldest first
+type ReviewListResponse struct {
+	Reviews []Assessment `json:"reviews"`
+}
@@ -0,0 +1,461 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAlertNotFound is returned when no merchant risk alert has the given ID
+	ErrAlertNotFound = errors.New("merchant risk alert not found")
+	// ErrAlertAcknowledged is returned when an alert was already acknowledged
+	ErrAlertAcknowledged = errors.New("merchant risk alert has already been acknowledged")
+)
+
+// ScoreZone is the time zone merchant activity is grouped into days in
+var ScoreZone = time.FixedZone("EAT", 3*60*60)
+
+// ScoreDay is the start of the scoring day t falls in
+func ScoreDay(t time.Time) time.Time {
+	t = t.In(ScoreZone)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, ScoreZone)
+}
+
+// Factor is one signal a merchant's daily risk score is made of
+type Factor string
+
+const (
+	// FactorVolumeDeviation compares the day's collected volume with the merchant's daily average
+	FactorVolumeDeviation Factor = "VOLUME_DEVIATION"
+	// FactorFailureRatio is the share of the day's payment attempts that failed
+	FactorFailureRatio Factor = "FAILURE_RATIO"
+	// FactorRefundRatio is the share of the day's completed payments that were refunded
+	FactorRefundRatio Factor = "REFUND_RATIO"
+	// FactorNightActivity is the share of the day's payment attempts made at night
+	FactorNightActivity Factor = "NIGHT_ACTIVITY"
+	// FactorPayoutRatio compares what the merchant withdrew with what it collected over the payout window
+	FactorPayoutRatio Factor = "PAYOUT_RATIO"
+	// FactorAccountAge scores how recently the merchant registered
+	FactorAccountAge Factor = "ACCOUNT_AGE"
+	// FactorIndustry scores merchants in high-risk industries
+	FactorIndustry Factor = "INDUSTRY"
+)
+
+// ScoreLevel buckets a merchant risk score
+type ScoreLevel string
+
+const (
+	ScoreLow    ScoreLevel = "LOW"
+	ScoreMedium ScoreLevel = "MEDIUM"
+	ScoreHigh   ScoreLevel = "HIGH"
+)
+
+// Band maps a factor value to a score: 0 at Low, 100 at High and linear in between. High may be
+// below Low for factors where a smaller value is riskier.
+type Band struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+func (b Band) score(value float64) float64 {
+	if b.High == b.Low {
+		return 0
+	}
+	return 100 * math.Max(0, math.Min(1, (value-b.Low)/(b.High-b.Low)))
+}
+
+// ScoreConfig holds the weights and bands merchant risk scores are computed with
+type ScoreConfig struct {
+	Weights         map[Factor]float64 `json:"weights"`
+	VolumeDeviation Band               `json:"volume_deviation"`
+	FailureRatio    Band               `json:"failure_ratio"`
+	RefundRatio     Band               `json:"refund_ratio"`
+	NightActivity   Band               `json:"night_activity"`
+	PayoutRatio     Band               `json:"payout_ratio"`
+	// AccountAge is in days since registration
+	AccountAge Band `json:"account_age"`
+
+	// MinAttempts is how many payment attempts a day needs before its ratios are scored
+	MinAttempts int `json:"min_attempts"`
+	// MinDeviationVolume is the collected volume below which a day's deviation is not scored
+	MinDeviationVolume float64 `json:"min_deviation_volume"`
+	// BaselineDays is how many days before the scored day the daily average volume is taken over
+	BaselineDays int `json:"baseline_days"`
+	// PayoutWindowDays is how many days, the scored one included, payouts are compared with collections over
+	PayoutWindowDays int `json:"payout_window_days"`
+	// NightStartHour and NightEndHour bound the night in ScoreZone
+	NightStartHour int `json:"night_start_hour"`
+	NightEndHour   int `json:"night_end_hour"`
+
+	// MediumScore and AlertScore are where the MEDIUM and HIGH levels start. Merchants scoring
+	// HIGH, or breaching the band of an activity factor, raise an alert.
+	MediumScore float64 `json:"medium_score"`
+	AlertScore  float64 `json:"alert_score"`
+
+	// HighRiskIndustries are matched against the merchant's industry category, ignoring case
+	HighRiskIndustries []string `json:"high_risk_industries"`
+}
+
+// DefaultScoreConfig returns the platform scoring configuration
+func DefaultScoreConfig() ScoreConfig {
+	return ScoreConfig{
+		Weights: map[Factor]float64{
+			FactorVolumeDeviation: 25,
+			FactorFailureRatio:    15,
+			FactorRefundRatio:     10,
+			FactorNightActivity:   10,
+			FactorPayoutRatio:     20,
+			FactorAccountAge:      10,
+			FactorIndustry:        10,
+		},
+		VolumeDeviation:    Band{Low: 2, High: 10},
+		FailureRatio:       Band{Low: 0.1, High: 0.5},
+		RefundRatio:        Band{Low: 0.02, High: 0.1},
+		NightActivity:      Band{Low: 0.1, High: 0.6},
+		PayoutRatio:        Band{Low: 1, High: 2},
+		AccountAge:         Band{Low: 90, High: 7},
+		MinAttempts:        10,
+		MinDeviationVolume: 10000,
+		BaselineDays:       30,
+		PayoutWindowDays:   7,
+		NightStartHour:     0,
+		NightEndHour:       5,
+		MediumScore:        40,
+		AlertScore:         70,
+		HighRiskIndustries: []string{"betting", "gambling", "gaming", "lottery", "crypto", "forex", "money transfer", "remittance"},
+	}
+}
+
+// ActivityWindow is the span of transactions a scoring day reads
+type ActivityWindow struct {
+	// Day and DayEnd bound the scored day
+	Day    time.Time
+	DayEnd time.Time
+	// BaselineStart is where the volume baseline starts; it ends at Day
+	BaselineStart time.Time
+	// PayoutStart is where the payout window starts; it ends at DayEnd
+	PayoutStart    time.Time
+	NightStartHour int
+	NightEndHour   int
+}
+
+// Window returns the span of transactions scoring day reads
+func (c ScoreConfig) Window(day time.Time) ActivityWindow {
+	day = ScoreDay(day)
+	return ActivityWindow{
+		Day:            day,
+		DayEnd:         day.AddDate(0, 0, 1),
+		BaselineStart:  day.AddDate(0, 0, -c.BaselineDays),
+		PayoutStart:    day.AddDate(0, 0, 1-c.PayoutWindowDays),
+		NightStartHour: c.NightStartHour,
+		NightEndHour:   c.NightEndHour,
+	}
+}
+
+// MerchantActivity is what a merchant did on a scoring day, read from its live transactions
+type MerchantActivity struct {
+	MerchantID        uuid.UUID
+	MerchantName      string
+	MerchantStatus    string
+	RegisteredAt      time.Time
+	IndustryCategory  string
+	BettingCompany    bool
+	Attempts          int
+	Failed            int
+	Succeeded         int
+	Refunded          int
+	NightAttempts     int
+	Volume            float64
+	BaselineVolume    float64
+	CollectedInWindow float64
+	PaidOutInWindow   float64
+}
+
+// FactorScore is how one factor contributed to a merchant's score
+type FactorScore struct {
+	Factor Factor  `json:"factor"`
+	Value  float64 `json:"value"`
+	Score  float64 `json:"score"`
+	Weight float64 `json:"weight"`
+	// Breached is set when an activity factor reached the top of its band
+	Breached bool   `json:"breached"`
+	Detail   string `json:"detail"`
+}
+
+// MerchantScore is the risk score of a merchant for one day
+type MerchantScore struct {
+	ID             uuid.UUID     `json:"id"`
+	MerchantID     uuid.UUID     `json:"merchant_id"`
+	MerchantName   string        `json:"merchant_name,omitempty"`
+	MerchantStatus string        `json:"merchant_status,omitempty"`
+	ScoreDate      time.Time     `json:"score_date"`
+	Score          float64       `json:"score"`
+	Level          ScoreLevel    `json:"level"`
+	Factors        []FactorScore `json:"factors"`
+	Attempts       int           `json:"attempts"`
+	Volume         float64       `json:"volume"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// Breaches lists the activity factors that reached the top of their band
+func (s *MerchantScore) Breaches() []Factor {
+	var breaches []Factor
+	for _, factor := range s.Factors {
+		if factor.Breached {
+			breaches = append(breaches, factor.Factor)
+		}
+	}
+	return breaches
+}
+
+// Score computes the risk score of a merchant's activity on day. The score is the weighted
+// average of the factor scores, from 0 to 100.
+func (c ScoreConfig) Score(activity MerchantActivity, day time.Time) *MerchantScore {
+	day = ScoreDay(day)
+	var factors []FactorScore
+	add := func(factor Factor, band Band, value float64, behavioural bool, detail string) {
+		score := band.score(value)
+		factors = append(factors, FactorScore{
+			Factor:   factor,
+			Value:    round(value, 4),
+			Score:    round(score, 2),
+			Weight:   c.Weights[factor],
+			Breached: behavioural && score >= 100,
+			Detail:   detail,
+		})
+	}
+
+	// A merchant quiet over the whole baseline that suddenly collects scores as the top of the band
+	deviation, deviationDetail := 0.0, "below the minimum volume"
+	baselineDays := math.Min(float64(c.BaselineDays), math.Floor(day.Sub(activity.RegisteredAt).Hours()/24))
+	if activity.Volume >= c.MinDeviationVolume {
+		switch {
+		case baselineDays >= 1 && activity.BaselineVolume > 0:
+			average := activity.BaselineVolume / baselineDays
+			deviation = activity.Volume / average
+			deviationDetail = fmt.Sprintf("%.2f collected against a daily average of %.2f", activity.Volume, average)
+		case baselineDays >= float64(c.BaselineDays):
+			deviation = c.VolumeDeviation.High
+			deviationDetail = fmt.Sprintf("%.2f collected after %d days without volume", activity.Volume, c.BaselineDays)
+		default:
+			deviationDetail = "no volume baseline yet"
+		}
+	}
+	add(FactorVolumeDeviation, c.VolumeDeviation, deviation, true, deviationDetail)
+
+	if activity.Attempts >= c.MinAttempts {
+		add(FactorFailureRatio, c.FailureRatio, ratio(activity.Failed, activity.Attempts), true,
+			fmt.Sprintf("%d of %d payment attempts failed", activity.Failed, activity.Attempts))
+		add(FactorNightActivity, c.NightActivity, ratio(activity.NightAttempts, activity.Attempts), true,
+			fmt.Sprintf("%d of %d payment attempts between %02d:00 and %02d:00", activity.NightAttempts, activity.Attempts, c.NightStartHour, c.NightEndHour))
+	} else {
+		detail := fmt.Sprintf("fewer than %d payment attempts", c.MinAttempts)
+		add(FactorFailureRatio, c.FailureRatio, 0, true, detail)
+		add(FactorNightActivity, c.NightActivity, 0, true, detail)
+	}
+
+	completed := activity.Succeeded + activity.Refunded
+	if completed >= c.MinAttempts {
+		add(FactorRefundRatio, c.RefundRatio, ratio(activity.Refunded, completed), true,
+			fmt.Sprintf("%d of %d completed payments refunded", activity.Refunded, completed))
+	} else {
+		add(FactorRefundRatio, c.RefundRatio, 0, true, fmt.Sprintf("fewer than %d completed payments", c.MinAttempts))
+	}
+
+	payoutRatio := 0.0
+	switch {
+	case activity.CollectedInWindow > 0:
+		payoutRatio = activity.PaidOutInWindow / activity.CollectedInWindow
+	case activity.PaidOutInWindow > 0:
+		payoutRatio = c.PayoutRatio.High
+	}
+	add(FactorPayoutRatio, c.PayoutRatio, payoutRatio, true,
+		fmt.Sprintf("%.2f paid out against %.2f collected in %d days", activity.PaidOutInWindow, activity.CollectedInWindow, c.PayoutWindowDays))
+
+	age := math.Max(0, math.Floor(day.Sub(activity.RegisteredAt).Hours()/24))
+	add(FactorAccountAge, c.AccountAge, age, false, fmt.Sprintf("registered %.0f days ago", age))
+
+	industry, industryDetail := 0.0, "industry not high risk"
+	if match := c.highRiskIndustry(activity); match != "" {
+		industry, industryDetail = 1, "high-risk industry: "+match
+	}
+	add(FactorIndustry, Band{Low: 0, High: 1}, industry, false, industryDetail)
+
+	var total, weights float64
+	for _, factor := range factors {
+		total += factor.Score * factor.Weight
+		weights += factor.Weight
+	}
+	score := 0.0
+	if weights > 0 {
+		score = round(total/weights, 2)
+	}
+
+	return &MerchantScore{
+		ID:             uuid.New(),
+		MerchantID:     activity.MerchantID,
+		MerchantName:   activity.MerchantName,
+		MerchantStatus: activity.MerchantStatus,
+		ScoreDate:      day,
+		Score:          score,
+		Level:          c.Level(score),
+		Factors:        factors,
+		Attempts:       activity.Attempts,
+		Volume:         activity.Volume,
+	}
+}
+
+// Level buckets a score
+func (c ScoreConfig) Level(score float64) ScoreLevel {
+	switch {
+	case score >= c.AlertScore:
+		return ScoreHigh
+	case score >= c.MediumScore:
+		return ScoreMedium
+	default:
+		return ScoreLow
+	}
+}
+
+// Alerts reports whether a score should be brought to an admin's attention
+func (c ScoreConfig) Alerts(score *MerchantScore) bool {
+	return score.Level == ScoreHigh || len(score.Breaches()) > 0
+}
+
+func (c ScoreConfig) highRiskIndustry(activity MerchantActivity) string {
+	if activity.BettingCompany {
+		return "betting"
+	}
+	category := strings.ToLower(activity.IndustryCategory)
+	for _, industry := range c.HighRiskIndustries {
+		if industry != "" && strings.Contains(category, strings.ToLower(industry)) {
+			return industry
+		}
+	}
+	return ""
+}
+
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
+
+// MerchantAlert tells admins a merchant's daily risk score crossed a threshold
+type MerchantAlert struct {
+	ID             uuid.UUID  `json:"id"`
+	MerchantID     uuid.UUID  `json:"merchant_id"`
+	ScoreID        uuid.UUID  `json:"score_id"`
+	ScoreDate      time.Time  `json:"score_date"`
+	Score          float64    `json:"score"`
+	Level          ScoreLevel `json:"level"`
+	Breaches       []Factor   `json:"breaches"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	Note           string     `json:"note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// NewMerchantAlert raises an alert for a score
+func NewMerchantAlert(score *MerchantScore, now time.Time) *MerchantAlert {
+	breaches := score.Breaches()
+	if breaches == nil {
+		breaches = []Factor{}
+	}
+	return &MerchantAlert{
+		ID:         uuid.New(),
+		MerchantID: score.MerchantID,
+		ScoreID:    score.ID,
+		ScoreDate:  score.ScoreDate,
+		Score:      score.Score,
+		Level:      score.Level,
+		Breaches:   breaches,
+		CreatedAt:  now,
+	}
+}
+
+// Message is the text admins are alerted with
+func (a *MerchantAlert) Message(merchantName string) string {
+	message := fmt.Sprintf("SocialPay risk alert: merchant %s scored %.0f (%s) on %s", merchantName, a.Score, a.Level, a.ScoreDate.Format("02 Jan 2006"))
+	if len(a.Breaches) > 0 {
+		names := make([]string, len(a.Breaches))
+		for i, breach := range a.Breaches {
+			names[i] = string(breach)
+		}
+		message += ", breached " + strings.Join(names, ", ")
+	}
+	return message
+}
+
+// AcknowledgeRequest acknowledges a merchant risk alert
+// @Description Alert acknowledgement
+type AcknowledgeRequest struct {
+	Note string `json:"note,omitempty" example:"Volume spike is a planned promotion"`
+}
+
+// Validate validates the acknowledgement
+func (r *AcknowledgeRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Note, validation.Length(0, 500)),
+	)
+}
+
+// ScoreFilter narrows the merchant risk ranking
+type ScoreFilter struct {
+	// Date is the scoring day; the latest scored day when nil
+	Date     *time.Time
+	MinLevel ScoreLevel
+	Limit    int
+}
+
+// MinScore is the lowest score the filter's level includes
+func (f ScoreFilter) MinScore(config ScoreConfig) float64 {
+	switch f.MinLevel {
+	case ScoreHigh:
+		return config.AlertScore
+	case ScoreMedium:
+		return config.MediumScore
+	default:
+		return 0
+	}
+}
+
+// ScoreListResponse lists merchant risk scores
+// @Description Merchant risk scores, highest first
+type ScoreListResponse struct {
+	Scores []MerchantScore `json:"scores"`
+}
+
+// AlertFilter narrows the merchant risk alerts
+type AlertFilter struct {
+	MerchantID *uuid.UUID
+	// Acknowledged lists acknowledged alerts instead of open ones; all alerts when nil
+	Acknowledged *bool
+	Limit        int
+}
+
+// AlertListResponse lists merchant risk alerts
+// @Description Merchant risk alerts, latest first
+type AlertListResponse struct {
+	Alerts []MerchantAlert `json:"alerts"`
+}
+
+// ScoringRun summarizes one day of merchant scoring
+// @Description Result of scoring a day of merchant activity
+type ScoringRun struct {
+	ScoreDate time.Time `json:"score_date"`
+	Scored    int       `json:"scored"`
+	Alerts    int       `json:"alerts"`
+}
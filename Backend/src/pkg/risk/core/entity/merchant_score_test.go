@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestScoreConfigScore(t *testing.T) {
+	config := DefaultScoreConfig()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, ScoreZone)
+
+	t.Run("steady established merchant scores low", func(t *testing.T) {
+		score := config.Score(MerchantActivity{
+			MerchantID:        uuid.New(),
+			RegisteredAt:      day.AddDate(-1, 0, 0),
+			Attempts:          100,
+			Failed:            5,
+			Succeeded:         95,
+			NightAttempts:     2,
+			Volume:            50000,
+			BaselineVolume:    1500000,
+			CollectedInWindow: 350000,
+			PaidOutInWindow:   200000,
+		}, day)
+		if score.Level != ScoreLow || config.Alerts(score) {
+			t.Fatalf("score = %.2f (%s), breaches = %v, want LOW without alert", score.Score, score.Level, score.Breaches())
+		}
+	})
+
+	t.Run("volume spike is a breach", func(t *testing.T) {
+		score := config.Score(MerchantActivity{
+			MerchantID:        uuid.New(),
+			RegisteredAt:      day.AddDate(-1, 0, 0),
+			Attempts:          100,
+			Succeeded:         100,
+			Volume:            600000,
+			BaselineVolume:    1500000,
+			CollectedInWindow: 900000,
+		}, day)
+		breaches := score.Breaches()
+		if !config.Alerts(score) || len(breaches) != 1 || breaches[0] != FactorVolumeDeviation {
+			t.Fatalf("breaches = %v, want VOLUME_DEVIATION", breaches)
+		}
+	})
+
+	t.Run("new betting merchant refunding and paying out more than it collects scores high", func(t *testing.T) {
+		score := config.Score(MerchantActivity{
+			MerchantID:        uuid.New(),
+			RegisteredAt:      day.AddDate(0, 0, -2),
+			BettingCompany:    true,
+			Attempts:          40,
+			Failed:            20,
+			Succeeded:         15,
+			Refunded:          5,
+			NightAttempts:     30,
+			Volume:            20000,
+			CollectedInWindow: 20000,
+			PaidOutInWindow:   60000,
+		}, day)
+		if score.Level != ScoreHigh || !config.Alerts(score) {
+			t.Fatalf("score = %.2f (%s), want HIGH with alert", score.Score, score.Level)
+		}
+	})
+
+	t.Run("few attempts leave ratios unscored", func(t *testing.T) {
+		score := config.Score(MerchantActivity{
+			MerchantID:   uuid.New(),
+			RegisteredAt: day.AddDate(-1, 0, 0),
+			Attempts:     3,
+			Failed:       3,
+		}, day)
+		if len(score.Breaches()) != 0 {
+			t.Fatalf("breaches = %v, want none", score.Breaches())
+		}
+	})
+}
@@ -0,0 +1,341 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Outcome is what happens to a payment or withdrawal that triggered a rule
+type Outcome string
+
+const (
+	// OutcomeAllow lets the request through; a rule with this outcome only records that it triggered
+	OutcomeAllow Outcome = "ALLOW"
+	// OutcomeReview holds payments and withdrawals before they reach the processor until an admin reviews them
+	OutcomeReview Outcome = "REVIEW"
+	// OutcomeBlock fails the transaction before it reaches the processor
+	OutcomeBlock Outcome = "BLOCK"
+)
+
+func (o Outcome) severity() int {
+	switch o {
+	case OutcomeBlock:
+		return 2
+	case OutcomeReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Rule identifies a risk rule
+type Rule string
+
+const (
+	RulePhoneVelocity        Rule = "PHONE_VELOCITY"
+	RuleCardVelocity         Rule = "CARD_VELOCITY"
+	RuleIPVelocity           Rule = "IP_VELOCITY"
+	RuleAmountThreshold      Rule = "AMOUNT_THRESHOLD"
+	RuleNewDevicePayout      Rule = "NEW_DEVICE_PAYOUT"
+	RuleNewBeneficiaryPayout Rule = "NEW_BENEFICIARY_PAYOUT"
+	RuleCountryMismatch      Rule = "COUNTRY_MISMATCH"
+	RuleBlocklist            Rule = "BLOCKLIST"
+	// RuleMerchantSuspended is not configurable; it is raised by callers for withdrawals of suspended merchants
+	RuleMerchantSuspended Rule = "MERCHANT_SUSPENDED"
+)
+
+// MaxVelocityWindowMinutes bounds how far back velocity is counted
+const MaxVelocityWindowMinutes = 24 * 60
+
+var countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// VelocityRule limits the attempts sharing a phone number, card or IP address within a window.
+// A limit of zero disables the rule.
+type VelocityRule struct {
+	Limit         int     `json:"limit" example:"10"`
+	WindowMinutes int     `json:"window_minutes" example:"60"`
+	Outcome       Outcome `json:"outcome" example:"REVIEW"`
+}
+
+// AmountRule reviews or blocks payments and withdrawals above an amount, in the transaction currency
+type AmountRule struct {
+	ReviewAbove *float64 `json:"review_above,omitempty" example:"100000"`
+	BlockAbove  *float64 `json:"block_above,omitempty" example:"500000"`
+}
+
+// Blocklist blocks requests from or to the listed values
+type Blocklist struct {
+	PhoneNumbers []string `json:"phone_numbers,omitempty"`
+	IPAddresses  []string `json:"ip_addresses,omitempty"`
+	DeviceIDs    []string `json:"device_ids,omitempty"`
+	Countries    []string `json:"countries,omitempty"`
+}
+
+// Rules configure the risk engine for a merchant. They are stored in the merchant's risk
+// settings as overrides of DefaultRules: a field missing from the settings keeps its default.
+// @Description Risk rules of a merchant
+type Rules struct {
+	Enabled bool `json:"enabled" example:"true"`
+
+	PhoneVelocity VelocityRule `json:"phone_velocity"`
+	CardVelocity  VelocityRule `json:"card_velocity"`
+	IPVelocity    VelocityRule `json:"ip_velocity"`
+	Amount        AmountRule   `json:"amount"`
+
+	// NewDevicePayout applies to withdrawals from a device the merchant never withdrew from; empty disables it
+	NewDevicePayout Outcome `json:"new_device_payout,omitempty" example:"REVIEW"`
+	// NewBeneficiaryPayout applies to withdrawals to a destination the merchant never paid out to; empty disables it
+	NewBeneficiaryPayout Outcome `json:"new_beneficiary_payout,omitempty" example:"ALLOW"`
+
+	// AllowedCountries are where requests are expected from; CountryMismatch applies to requests
+	// from any other country. Requests whose country is unknown are not checked.
+	AllowedCountries []string `json:"allowed_countries,omitempty" example:"ET"`
+	CountryMismatch  Outcome  `json:"country_mismatch,omitempty" example:"REVIEW"`
+
+	Blocklist Blocklist `json:"blocklist"`
+}
+
+// DefaultRules are the platform rules of a merchant without overrides
+func DefaultRules() *Rules {
+	return &Rules{
+		Enabled:          true,
+		PhoneVelocity:    VelocityRule{Limit: 10, WindowMinutes: 60, Outcome: OutcomeReview},
+		CardVelocity:     VelocityRule{Limit: 5, WindowMinutes: 60, Outcome: OutcomeReview},
+		IPVelocity:       VelocityRule{Limit: 30, WindowMinutes: 60, Outcome: OutcomeReview},
+		NewDevicePayout:  OutcomeReview,
+		AllowedCountries: []string{"ET"},
+		CountryMismatch:  OutcomeReview,
+	}
+}
+
+// ParseRules applies the risk settings of a merchant over the default rules
+func ParseRules(settings *string) (*Rules, error) {
+	rules := DefaultRules()
+	if settings == nil || strings.TrimSpace(*settings) == "" || strings.TrimSpace(*settings) == "null" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(*settings), rules); err != nil {
+		return nil, fmt.Errorf("invalid risk settings: %w", err)
+	}
+	rules.Normalize()
+	return rules, nil
+}
+
+// Normalize trims and upper-cases the list entries so they compare with request signals
+func (r *Rules) Normalize() {
+	r.AllowedCountries = normalizeList(r.AllowedCountries, strings.ToUpper)
+	r.Blocklist.Countries = normalizeList(r.Blocklist.Countries, strings.ToUpper)
+	r.Blocklist.PhoneNumbers = normalizeList(r.Blocklist.PhoneNumbers, NormalizePhone)
+	r.Blocklist.IPAddresses = normalizeList(r.Blocklist.IPAddresses, nil)
+	r.Blocklist.DeviceIDs = normalizeList(r.Blocklist.DeviceIDs, nil)
+}
+
+// Validate validates the rules
+func (r *Rules) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.PhoneVelocity),
+		validation.Field(&r.CardVelocity),
+		validation.Field(&r.IPVelocity),
+		validation.Field(&r.Amount),
+		validation.Field(&r.NewDevicePayout, validation.In(outcomes()...)),
+		validation.Field(&r.NewBeneficiaryPayout, validation.In(outcomes()...)),
+		validation.Field(&r.AllowedCountries, validation.Each(validation.Match(countryPattern))),
+		validation.Field(&r.CountryMismatch, validation.In(outcomes()...)),
+		validation.Field(&r.Blocklist),
+	); err != nil {
+		return err
+	}
+
+	if r.CountryMismatch != "" && len(r.AllowedCountries) == 0 {
+		return errors.New("allowed_countries must be set when country_mismatch is enabled")
+	}
+	return nil
+}
+
+// Validate validates the velocity rule
+func (v VelocityRule) Validate() error {
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.Limit, validation.Min(0)),
+		validation.Field(&v.WindowMinutes,
+			validation.When(v.Limit > 0, validation.Required, validation.Min(1), validation.Max(MaxVelocityWindowMinutes))),
+		validation.Field(&v.Outcome,
+			validation.When(v.Limit > 0, validation.Required), validation.In(outcomes()...)),
+	)
+}
+
+// Validate validates the amount thresholds
+func (a AmountRule) Validate() error {
+	if err := validation.ValidateStruct(&a,
+		validation.Field(&a.ReviewAbove, validation.Min(0.0)),
+		validation.Field(&a.BlockAbove, validation.Min(0.0)),
+	); err != nil {
+		return err
+	}
+	if a.ReviewAbove != nil && a.BlockAbove != nil && *a.ReviewAbove > *a.BlockAbove {
+		return errors.New("review_above must not exceed block_above")
+	}
+	return nil
+}
+
+// Validate validates the blocklist
+func (b Blocklist) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Countries, validation.Each(validation.Match(countryPattern))),
+	)
+}
+
+func outcomes() []interface{} {
+	return []interface{}{OutcomeAllow, OutcomeReview, OutcomeBlock}
+}
+
+// Observation is what the engine knows about a request when evaluating the rules
+type Observation struct {
+	Withdrawal  bool
+	Amount      float64
+	PhoneNumber string
+	Signals     Signals
+
+	// Attempts sharing the phone number, card and IP address within each rule's window, this one excluded
+	PhoneAttempts int
+	CardAttempts  int
+	IPAttempts    int
+
+	// NewDevice and NewBeneficiary are only set for withdrawals
+	NewDevice      bool
+	NewBeneficiary bool
+}
+
+// Reason is a rule a request triggered
+type Reason struct {
+	Rule    Rule    `json:"rule"`
+	Outcome Outcome `json:"outcome"`
+	Detail  string  `json:"detail"`
+}
+
+// Evaluate applies the rules to a request. The outcome is the strictest of the triggered rules.
+func (r *Rules) Evaluate(o Observation) (Outcome, []Reason) {
+	outcome := OutcomeAllow
+	var reasons []Reason
+	trigger := func(rule Rule, ruleOutcome Outcome, detail string) {
+		if ruleOutcome == "" {
+			return
+		}
+		reasons = append(reasons, Reason{Rule: rule, Outcome: ruleOutcome, Detail: detail})
+		if ruleOutcome.severity() > outcome.severity() {
+			outcome = ruleOutcome
+		}
+	}
+
+	if !r.Enabled {
+		return outcome, nil
+	}
+
+	if detail, ok := r.blocklisted(o); ok {
+		trigger(RuleBlocklist, OutcomeBlock, detail)
+	}
+
+	velocity := []struct {
+		rule     Rule
+		config   VelocityRule
+		attempts int
+		present  bool
+		subject  string
+	}{
+		{RulePhoneVelocity, r.PhoneVelocity, o.PhoneAttempts, o.PhoneNumber != "", "phone number"},
+		{RuleCardVelocity, r.CardVelocity, o.CardAttempts, o.Signals.CardToken != "", "card"},
+		{RuleIPVelocity, r.IPVelocity, o.IPAttempts, o.Signals.IPAddress != "", "IP address"},
+	}
+	for _, v := range velocity {
+		if v.present && v.config.Limit > 0 && v.attempts >= v.config.Limit {
+			trigger(v.rule, v.config.Outcome, fmt.Sprintf("%d attempts with this %s in %d minutes", v.attempts+1, v.subject, v.config.WindowMinutes))
+		}
+	}
+
+	if r.Amount.BlockAbove != nil && o.Amount > *r.Amount.BlockAbove {
+		trigger(RuleAmountThreshold, OutcomeBlock, fmt.Sprintf("amount %.2f above %.2f", o.Amount, *r.Amount.BlockAbove))
+	} else if r.Amount.ReviewAbove != nil && o.Amount > *r.Amount.ReviewAbove {
+		trigger(RuleAmountThreshold, OutcomeReview, fmt.Sprintf("amount %.2f above %.2f", o.Amount, *r.Amount.ReviewAbove))
+	}
+
+	if o.Withdrawal && o.NewDevice {
+		trigger(RuleNewDevicePayout, r.NewDevicePayout, "first withdrawal from this device")
+	}
+	if o.Withdrawal && o.NewBeneficiary {
+		trigger(RuleNewBeneficiaryPayout, r.NewBeneficiaryPayout, "first payout to this destination")
+	}
+
+	if o.Signals.Country != "" && len(r.AllowedCountries) > 0 && !contains(r.AllowedCountries, o.Signals.Country) {
+		trigger(RuleCountryMismatch, r.CountryMismatch, "request from "+o.Signals.Country)
+	}
+
+	return outcome, reasons
+}
+
+// Escalate adds reasons raised outside the rules to an evaluation, keeping the strictest outcome
+func Escalate(outcome Outcome, reasons []Reason, extra ...Reason) (Outcome, []Reason) {
+	for _, reason := range extra {
+		reasons = append(reasons, reason)
+		if reason.Outcome.severity() > outcome.severity() {
+			outcome = reason.Outcome
+		}
+	}
+	return outcome, reasons
+}
+
+func (r *Rules) blocklisted(o Observation) (string, bool) {
+	switch {
+	case o.PhoneNumber != "" && contains(r.Blocklist.PhoneNumbers, NormalizePhone(o.PhoneNumber)):
+		return "phone number is blocklisted", true
+	case o.Signals.IPAddress != "" && contains(r.Blocklist.IPAddresses, o.Signals.IPAddress):
+		return "IP address is blocklisted", true
+	case o.Signals.DeviceID != "" && contains(r.Blocklist.DeviceIDs, o.Signals.DeviceID):
+		return "device is blocklisted", true
+	case o.Signals.Country != "" && contains(r.Blocklist.Countries, o.Signals.Country):
+		return "country " + o.Signals.Country + " is blocklisted", true
+	}
+	return "", false
+}
+
+// NormalizePhone reduces a phone number to its digits in international form, so 0911234567,
+// +251 911 234567 and 251911234567 compare equal
+func NormalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	normalized := digits.String()
+	if len(normalized) == 10 && strings.HasPrefix(normalized, "0") {
+		return "251" + normalized[1:]
+	}
+	return normalized
+}
+
+func normalizeList(values []string, normalize func(string) string) []string {
+	var normalized []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if normalize != nil {
+			value = normalize(value)
+		}
+		if value != "" && !contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package entity
+
+import "testing"
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestRulesEvaluate(t *testing.T) {
+	rules := DefaultRules()
+	rules.Amount = AmountRule{ReviewAbove: floatPtr(100000), BlockAbove: floatPtr(500000)}
+	rules.NewBeneficiaryPayout = OutcomeAllow
+	rules.Blocklist = Blocklist{PhoneNumbers: []string{"+251 911 000000"}}
+	rules.Normalize()
+
+	t.Run("ordinary payment goes through", func(t *testing.T) {
+		outcome, reasons := rules.Evaluate(Observation{
+			Amount:      500,
+			PhoneNumber: "0911234567",
+			Signals:     Signals{IPAddress: "196.188.1.1", Country: "ET"},
+		})
+		if outcome != OutcomeAllow || len(reasons) != 0 {
+			t.Fatalf("outcome = %s, reasons = %v, want ALLOW without reasons", outcome, reasons)
+		}
+	})
+
+	t.Run("blocklisted phone in another format is blocked", func(t *testing.T) {
+		outcome, reasons := rules.Evaluate(Observation{Amount: 500, PhoneNumber: "0911000000"})
+		if outcome != OutcomeBlock || len(reasons) != 1 || reasons[0].Rule != RuleBlocklist {
+			t.Fatalf("outcome = %s, reasons = %v, want BLOCK by BLOCKLIST", outcome, reasons)
+		}
+	})
+
+	t.Run("strictest outcome wins", func(t *testing.T) {
+		outcome, reasons := rules.Evaluate(Observation{
+			Amount:        600000,
+			PhoneNumber:   "0911234567",
+			PhoneAttempts: 10,
+			Signals:       Signals{Country: "KE"},
+		})
+		if outcome != OutcomeBlock {
+			t.Fatalf("outcome = %s, want BLOCK", outcome)
+		}
+		want := []Rule{RulePhoneVelocity, RuleAmountThreshold, RuleCountryMismatch}
+		if len(reasons) != len(want) {
+			t.Fatalf("reasons = %v, want %v", reasons, want)
+		}
+		for i, rule := range want {
+			if reasons[i].Rule != rule {
+				t.Errorf("reasons[%d] = %s, want %s", i, reasons[i].Rule, rule)
+			}
+		}
+	})
+
+	t.Run("velocity below the limit is ignored", func(t *testing.T) {
+		outcome, _ := rules.Evaluate(Observation{Amount: 500, PhoneNumber: "0911234567", PhoneAttempts: 9})
+		if outcome != OutcomeAllow {
+			t.Fatalf("outcome = %s, want ALLOW", outcome)
+		}
+	})
+
+	t.Run("payout rules only apply to withdrawals", func(t *testing.T) {
+		observation := Observation{Amount: 500, NewDevice: true, NewBeneficiary: true}
+		if outcome, reasons := rules.Evaluate(observation); outcome != OutcomeAllow || len(reasons) != 0 {
+			t.Fatalf("payment: outcome = %s, reasons = %v, want ALLOW without reasons", outcome, reasons)
+		}
+
+		observation.Withdrawal = true
+		outcome, reasons := rules.Evaluate(observation)
+		if outcome != OutcomeReview {
+			t.Fatalf("withdrawal: outcome = %s, want REVIEW", outcome)
+		}
+		// An ALLOW rule is still recorded
+		if len(reasons) != 2 || reasons[1].Rule != RuleNewBeneficiaryPayout || reasons[1].Outcome != OutcomeAllow {
+			t.Fatalf("withdrawal: reasons = %v, want NEW_DEVICE_PAYOUT and an ALLOW NEW_BENEFICIARY_PAYOUT", reasons)
+		}
+	})
+
+	t.Run("disabled rules never trigger", func(t *testing.T) {
+		disabled := *rules
+		disabled.Enabled = false
+		if outcome, reasons := disabled.Evaluate(Observation{PhoneNumber: "0911000000"}); outcome != OutcomeAllow || reasons != nil {
+			t.Fatalf("outcome = %s, reasons = %v, want ALLOW without reasons", outcome, reasons)
+		}
+	})
+}
+
+func TestParseRules(t *testing.T) {
+	settings := `{"card_velocity": {"limit": 3, "window_minutes": 30, "outcome": "BLOCK"}, "country_mismatch": "", "allowed_countries": [" et ", "ke"]}`
+	rules, err := ParseRules(&settings)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	if !rules.Enabled {
+		t.Error("Enabled = false, want the default")
+	}
+	if rules.PhoneVelocity != DefaultRules().PhoneVelocity {
+		t.Errorf("PhoneVelocity = %+v, want the default", rules.PhoneVelocity)
+	}
+	if want := (VelocityRule{Limit: 3, WindowMinutes: 30, Outcome: OutcomeBlock}); rules.CardVelocity != want {
+		t.Errorf("CardVelocity = %+v, want %+v", rules.CardVelocity, want)
+	}
+	if rules.CountryMismatch != "" {
+		t.Errorf("CountryMismatch = %q, want it switched off", rules.CountryMismatch)
+	}
+	if len(rules.AllowedCountries) != 2 || rules.AllowedCountries[0] != "ET" || rules.AllowedCountries[1] != "KE" {
+		t.Errorf("AllowedCountries = %v, want [ET KE]", rules.AllowedCountries)
+	}
+
+	if rules, err := ParseRules(nil); err != nil || rules.CardVelocity != DefaultRules().CardVelocity {
+		t.Errorf("ParseRules(nil) = %+v, %v, want the default rules", rules, err)
+	}
+}
+
+func TestRulesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(r *Rules)
+		wantErr bool
+	}{
+		{"default rules", func(r *Rules) {}, false},
+		{"velocity without window", func(r *Rules) { r.IPVelocity.WindowMinutes = 0 }, true},
+		{"disabled velocity", func(r *Rules) { r.IPVelocity = VelocityRule{} }, false},
+		{"unknown outcome", func(r *Rules) { r.NewDevicePayout = "HOLD" }, true},
+		{"review above block", func(r *Rules) { r.Amount = AmountRule{ReviewAbove: floatPtr(10), BlockAbove: floatPtr(5)} }, true},
+		{"country mismatch without allowed countries", func(r *Rules) { r.AllowedCountries = nil }, true},
+		{"invalid country", func(r *Rules) { r.Blocklist.Countries = []string{"ETH"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			tt.modify(rules)
+			if err := rules.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
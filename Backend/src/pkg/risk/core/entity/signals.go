@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// DeviceIDHeader carries the device fingerprint of the checkout page or merchant app
+	DeviceIDHeader = "X-Device-ID"
+	// CountryHeader carries the ISO country of the client IP, set by the edge proxy
+	CountryHeader = "CF-IPCountry"
+)
+
+// Signals describe where a payment or withdrawal request came from
+type Signals struct {
+	IPAddress string
+	DeviceID  string
+	// Country is the ISO 3166-1 alpha-2 code of the client IP, empty when unknown
+	Country string
+	// CardToken is the saved card the payment is charged to, never stored as is
+	CardToken string
+}
+
+// NewSignals normalizes the signals of a request
+func NewSignals(ipAddress, deviceID, country string) Signals {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	// XX and T1 are what the edge proxy sends for unknown and Tor addresses
+	if country == "XX" || country == "T1" {
+		country = ""
+	}
+	return Signals{
+		IPAddress: strings.TrimSpace(ipAddress),
+		DeviceID:  strings.TrimSpace(deviceID),
+		Country:   country,
+	}
+}
+
+// CardFingerprint hashes the card token so velocity can be counted without storing it
+func (s Signals) CardFingerprint() string {
+	if s.CardToken == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s.CardToken))
+	return hex.EncodeToString(sum[:])
+}
+
+type signalsKey struct{}
+
+// WithSignals attaches the request signals to the context the payment is processed with
+func WithSignals(ctx context.Context, signals Signals) context.Context {
+	return context.WithValue(ctx, signalsKey{}, signals)
+}
+
+// SignalsFromContext returns the signals attached by WithSignals, empty for system-initiated requests
+func SignalsFromContext(ctx context.Context) Signals {
+	signals, _ := ctx.Value(signalsKey{}).(Signals)
+	return signals
+}
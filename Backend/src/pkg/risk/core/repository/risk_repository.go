@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// AttemptKey is the request signal velocity is counted by
+type AttemptKey string
+
+const (
+	AttemptsByPhone AttemptKey = "phone_number"
+	AttemptsByCard  AttemptKey = "card_fingerprint"
+	AttemptsByIP    AttemptKey = "ip_address"
+)
+
+// RiskRepository defines the interface for risk assessments and the review queue
+type RiskRepository interface {
+	// Create stores an assessment
+	Create(ctx context.Context, assessment *entity.Assessment) error
+
+	// CountAttempts counts the assessments of a merchant sharing the signal value since the given time
+	CountAttempts(ctx context.Context, merchantID uuid.UUID, key AttemptKey, value string, since time.Time, test bool) (int, error)
+
+	// HasWithdrawnFromDevice reports whether the merchant requested a withdrawal from the device before
+	HasWithdrawnFromDevice(ctx context.Context, merchantID uuid.UUID, deviceID string, test bool) (bool, error)
+
+	// HasPaidOut reports whether the merchant has made a successful withdrawal to the phone number through the medium
+	HasPaidOut(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, phoneNumber string, test bool) (bool, error)
+
+	// GetByID retrieves an assessment, nil when none exists
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Assessment, error)
+
+	// GetByTransactionID retrieves the assessment of a transaction, nil when it was never assessed
+	GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Assessment, error)
+
+	// ListReviews retrieves the assessments in the review queue, pending ones oldest first and decided ones latest first
+	ListReviews(ctx context.Context, filter entity.ReviewFilter) ([]entity.Assessment, error)
+
+	// Decide records the review decision. It returns entity.ErrReviewDecided when the review
+	// is no longer pending, so only one decision is ever taken.
+	Decide(ctx context.Context, assessment *entity.Assessment) error
+}
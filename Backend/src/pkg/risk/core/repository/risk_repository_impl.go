@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const assessmentColumns = `id, merchant_id, transaction_id, flow, medium, amount, currency, phone_number, ip_address, device_id, country, card_fingerprint, outcome, reasons, test, review_status, reviewed_by, review_note, reviewed_at, created_at`
+
+// attemptColumns are the columns CountAttempts may filter on
+var attemptColumns = map[AttemptKey]bool{
+	AttemptsByPhone: true,
+	AttemptsByCard:  true,
+	AttemptsByIP:    true,
+}
+
+type RiskRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRiskRepository(db *sql.DB) RiskRepository {
+	return &RiskRepositoryImpl{db: db}
+}
+
+func (r *RiskRepositoryImpl) Create(ctx context.Context, assessment *entity.Assessment) error {
+	reasons, err := json.Marshal(assessment.Reasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal risk reasons: %w", err)
+	}
+
+	query := `
+		INSERT INTO public.risk_assessments (` + assessmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14::jsonb, $15, $16, $17, $18, $19, $20)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		assessment.ID, assessment.MerchantID, assessment.TransactionID, string(assessment.Flow),
+		string(assessment.Medium), assessment.Amount, assessment.Currency, nullString(assessment.PhoneNumber),
+		nullString(assessment.IPAddress), nullString(assessment.DeviceID), nullString(assessment.Country),
+		nullString(assessment.CardFingerprint), string(assessment.Outcome), string(reasons), assessment.Test,
+		nullString(string(assessment.ReviewStatus)), assessment.ReviewedBy, nullString(assessment.ReviewNote),
+		assessment.ReviewedAt, assessment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create risk assessment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RiskRepositoryImpl) CountAttempts(ctx context.Context, merchantID uuid.UUID, key AttemptKey, value string, since time.Time, test bool) (int, error) {
+	if !attemptColumns[key] {
+		return 0, fmt.Errorf("unsupported attempt key %q", key)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*) FROM public.risk_assessments
+		WHERE merchant_id = $1 AND %s = $2 AND created_at >= $3 AND test = $4`, key)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, merchantID, value, since, test).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count risk attempts: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *RiskRepositoryImpl) HasWithdrawnFromDevice(ctx context.Context, merchantID uuid.UUID, deviceID string, test bool) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM public.risk_assessments
+			WHERE merchant_id = $1 AND device_id = $2 AND flow = 'WITHDRAWAL' AND test = $3
+		)`
+
+	var seen bool
+	if err := r.db.QueryRowContext(ctx, query, merchantID, deviceID, test).Scan(&seen); err != nil {
+		return false, fmt.Errorf("failed to check withdrawal device: %w", err)
+	}
+
+	return seen, nil
+}
+
+func (r *RiskRepositoryImpl) HasPaidOut(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, phoneNumber string, test bool) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM public.transactions
+			WHERE merchant_id = $1 AND type = 'WITHDRAWAL' AND status = 'SUCCESS'
+				AND medium = $2 AND phone_number = $3 AND COALESCE(test, false) = $4
+		)`
+
+	var paid bool
+	if err := r.db.QueryRowContext(ctx, query, merchantID, string(medium), phoneNumber, test).Scan(&paid); err != nil {
+		return false, fmt.Errorf("failed to check payout destination: %w", err)
+	}
+
+	return paid, nil
+}
+
+func (r *RiskRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.Assessment, error) {
+	query := `SELECT ` + assessmentColumns + ` FROM public.risk_assessments WHERE id = $1`
+	return r.get(ctx, query, id)
+}
+
+func (r *RiskRepositoryImpl) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Assessment, error) {
+	query := `SELECT ` + assessmentColumns + ` FROM public.risk_assessments WHERE transaction_id = $1`
+	return r.get(ctx, query, transactionID)
+}
+
+func (r *RiskRepositoryImpl) get(ctx context.Context, query string, arg interface{}) (*entity.Assessment, error) {
+	assessment, err := scanAssessment(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get risk assessment: %w", err)
+	}
+
+	return assessment, nil
+}
+
+func (r *RiskRepositoryImpl) ListReviews(ctx context.Context, filter entity.ReviewFilter) ([]entity.Assessment, error) {
+	order := "DESC"
+	if filter.Status == entity.ReviewPending {
+		order = "ASC"
+	}
+
+	query := `SELECT ` + assessmentColumns + ` FROM public.risk_assessments
+		WHERE review_status IS NOT NULL
+			AND ($1 = '' OR review_status = $1)
+			AND ($2::uuid IS NULL OR merchant_id = $2)
+			AND ($3 = '' OR flow = $3)
+		ORDER BY created_at ` + order + `
+		LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, string(filter.Status), filter.MerchantID, string(filter.Flow), filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list risk reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var assessments []entity.Assessment
+	for rows.Next() {
+		assessment, err := scanAssessment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan risk assessment: %w", err)
+		}
+		assessments = append(assessments, *assessment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list risk reviews: %w", err)
+	}
+
+	return assessments, nil
+}
+
+func (r *RiskRepositoryImpl) Decide(ctx context.Context, assessment *entity.Assessment) error {
+	query := `
+		UPDATE public.risk_assessments
+		SET review_status = $2,
+			reviewed_by = $3,
+			review_note = $4,
+			reviewed_at = $5
+		WHERE id = $1 AND review_status = 'PENDING'`
+
+	result, err := r.db.ExecContext(ctx, query,
+		assessment.ID, string(assessment.ReviewStatus), assessment.ReviewedBy,
+		nullString(assessment.ReviewNote), assessment.ReviewedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to decide risk review: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to decide risk review: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrReviewDecided
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAssessment(row rowScanner) (*entity.Assessment, error) {
+	var assessment entity.Assessment
+	var flow, medium, outcome string
+	var reasons []byte
+	var phoneNumber, ipAddress, deviceID, country, cardFingerprint, reviewStatus, reviewNote sql.NullString
+	var reviewedBy uuid.NullUUID
+	var reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&assessment.ID,
+		&assessment.MerchantID,
+		&assessment.TransactionID,
+		&flow,
+		&medium,
+		&assessment.Amount,
+		&assessment.Currency,
+		&phoneNumber,
+		&ipAddress,
+		&deviceID,
+		&country,
+		&cardFingerprint,
+		&outcome,
+		&reasons,
+		&assessment.Test,
+		&reviewStatus,
+		&reviewedBy,
+		&reviewNote,
+		&reviewedAt,
+		&assessment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(reasons, &assessment.Reasons); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal risk reasons: %w", err)
+	}
+
+	assessment.Flow = entity.Flow(flow)
+	assessment.Medium = txEntity.TransactionMedium(medium)
+	assessment.Outcome = entity.Outcome(outcome)
+	assessment.PhoneNumber = phoneNumber.String
+	assessment.IPAddress = ipAddress.String
+	assessment.DeviceID = deviceID.String
+	assessment.Country = country.String
+	assessment.CardFingerprint = cardFingerprint.String
+	assessment.ReviewStatus = entity.ReviewStatus(reviewStatus.String)
+	assessment.ReviewNote = reviewNote.String
+	if reviewedBy.Valid {
+		assessment.ReviewedBy = &reviewedBy.UUID
+	}
+	if reviewedAt.Valid {
+		assessment.ReviewedAt = &reviewedAt.Time
+	}
+
+	return &assessment, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
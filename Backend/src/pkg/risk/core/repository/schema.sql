@@ -0,0 +1,67 @@
+-- Risk Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.risk_assessments (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    transaction_id UUID NOT NULL UNIQUE REFERENCES public.transactions(id) ON DELETE CASCADE,
+    flow VARCHAR(20) NOT NULL,
+    medium VARCHAR(50) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'ETB',
+    phone_number VARCHAR(20),
+    ip_address VARCHAR(45),
+    device_id VARCHAR(255),
+    country VARCHAR(2),
+    -- SHA-256 of the saved card token, never the token itself
+    card_fingerprint VARCHAR(64),
+    outcome VARCHAR(10) NOT NULL,
+    reasons JSONB NOT NULL DEFAULT '[]',
+    test BOOLEAN NOT NULL DEFAULT false,
+    -- NULL unless the outcome sent the transaction to the review queue
+    review_status VARCHAR(20),
+    reviewed_by UUID,
+    review_note TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_phone ON public.risk_assessments(merchant_id, phone_number, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_card ON public.risk_assessments(merchant_id, card_fingerprint, created_at DESC) WHERE card_fingerprint IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_ip ON public.risk_assessments(merchant_id, ip_address, created_at DESC) WHERE ip_address IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_device ON public.risk_assessments(merchant_id, device_id) WHERE device_id IS NOT NULL AND flow = 'WITHDRAWAL';
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_review ON public.risk_assessments(review_status, created_at) WHERE review_status IS NOT NULL;
+
+-- Daily merchant risk scores, one per merchant and day
+CREATE TABLE IF NOT EXISTS public.merchant_risk_scores (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    score_date DATE NOT NULL,
+    score DECIMAL(5,2) NOT NULL,
+    level VARCHAR(10) NOT NULL,
+    factors JSONB NOT NULL DEFAULT '[]',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    volume DECIMAL(20,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (merchant_id, score_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_merchant_risk_scores_ranking ON public.merchant_risk_scores(score_date, score DESC);
+
+-- Alerts raised for scores crossing a threshold, at most one per merchant and day
+CREATE TABLE IF NOT EXISTS public.merchant_risk_alerts (
+    id UUID PRIMARY KEY,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    score_id UUID NOT NULL REFERENCES public.merchant_risk_scores(id) ON DELETE CASCADE,
+    score_date DATE NOT NULL,
+    score DECIMAL(5,2) NOT NULL,
+    level VARCHAR(10) NOT NULL,
+    breaches JSONB NOT NULL DEFAULT '[]',
+    acknowledged_by UUID,
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (merchant_id, score_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_merchant_risk_alerts_open ON public.merchant_risk_alerts(created_at DESC) WHERE acknowledged_at IS NULL;
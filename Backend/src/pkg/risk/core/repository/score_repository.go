@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+)
+
+// ScoreRepository defines the interface for daily merchant risk scores and their alerts
+type ScoreRepository interface {
+	// MerchantActivity reads the activity of every merchant with live payment attempts on the window's day
+	MerchantActivity(ctx context.Context, window entity.ActivityWindow) ([]entity.MerchantActivity, error)
+
+	// SaveScore stores a score, replacing the merchant's score for the same day
+	SaveScore(ctx context.Context, score *entity.MerchantScore) error
+
+	// ListScores retrieves the scores of a day at or above minScore, highest first. The latest
+	// scored day is used when date is nil.
+	ListScores(ctx context.Context, date *time.Time, minScore float64, limit int) ([]entity.MerchantScore, error)
+
+	// ListMerchantScores retrieves the score history of a merchant, latest first
+	ListMerchantScores(ctx context.Context, merchantID uuid.UUID, limit int) ([]entity.MerchantScore, error)
+
+	// CreateAlert stores an alert. It returns false when the merchant already has an alert for the day.
+	CreateAlert(ctx context.Context, alert *entity.MerchantAlert) (bool, error)
+
+	// GetAlert retrieves an alert, nil when none exists
+	GetAlert(ctx context.Context, id uuid.UUID) (*entity.MerchantAlert, error)
+
+	// ListAlerts retrieves alerts, latest first
+	ListAlerts(ctx context.Context, filter entity.AlertFilter) ([]entity.MerchantAlert, error)
+
+	// AcknowledgeAlert records who acknowledged an alert. It returns entity.ErrAlertAcknowledged
+	// when the alert was already acknowledged.
+	AcknowledgeAlert(ctx context.Context, alert *entity.MerchantAlert) error
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+)
+
+const scoreColumns = `s.id, s.merchant_id, m.legal_name, m.status, s.score_date, s.score, s.level, s.factors, s.attempts, s.volume, s.created_at`
+
+const alertColumns = `id, merchant_id, score_id, score_date, score, level, breaches, acknowledged_by, acknowledged_at, note, created_at`
+
+// dateLayout is how scoring days are passed to DATE columns, so the session time zone cannot shift them
+const dateLayout = "2006-01-02"
+
+type ScoreRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewScoreRepository(db *sql.DB) ScoreRepository {
+	return &ScoreRepositoryImpl{db: db}
+}
+
+func (r *ScoreRepositoryImpl) MerchantActivity(ctx context.Context, window entity.ActivityWindow) ([]entity.MerchantActivity, error) {
+	_, offset := window.Day.Zone()
+
+	// Payments are deposits; refunded ones count towards the volume they were collected for
+	query := `
+		SELECT m.id, m.legal_name, m.status, m.created_at, COALESCE(m.industry_category, ''), COALESCE(m.is_betting_company, false),
+			COUNT(*) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at >= $1),
+			COUNT(*) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at >= $1 AND t.status = 'FAILED'),
+			COUNT(*) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at >= $1 AND t.status = 'SUCCESS'),
+			COUNT(*) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at >= $1 AND t.status = 'REFUNDED'),
+			COUNT(*) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at >= $1
+				AND EXTRACT(HOUR FROM (t.created_at AT TIME ZONE 'UTC') + make_interval(secs => $5)) >= $6
+				AND EXTRACT(HOUR FROM (t.created_at AT TIME ZONE 'UTC') + make_interval(secs => $5)) < $7),
+			COALESCE(SUM(t.base_amount) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at >= $1 AND t.status IN ('SUCCESS', 'REFUNDED')), 0),
+			COALESCE(SUM(t.base_amount) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at < $1 AND t.status IN ('SUCCESS', 'REFUNDED')), 0),
+			COALESCE(SUM(t.base_amount) FILTER (WHERE t.type = 'DEPOSIT' AND t.created_at >= $4 AND t.status = 'SUCCESS'), 0),
+			COALESCE(SUM(t.base_amount) FILTER (WHERE t.type = 'WITHDRAWAL' AND t.created_at >= $4 AND t.status = 'SUCCESS'), 0)
+		FROM public.transactions t
+		JOIN merchants.merchants m ON m.id = t.merchant_id
+		WHERE t.created_at >= LEAST($3::timestamptz, $4::timestamptz) AND t.created_at < $2
+			AND COALESCE(t.test, false) = false
+		GROUP BY m.id
+		HAVING COUNT(*) FILTER (WHERE t.created_at >= $1 AND t.type IN ('DEPOSIT', 'WITHDRAWAL')) > 0`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		window.Day, window.DayEnd, window.BaselineStart, window.PayoutStart,
+		offset, window.NightStartHour, window.NightEndHour,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read merchant activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []entity.MerchantActivity
+	for rows.Next() {
+		var a entity.MerchantActivity
+		if err := rows.Scan(
+			&a.MerchantID, &a.MerchantName, &a.MerchantStatus, &a.RegisteredAt, &a.IndustryCategory, &a.BettingCompany,
+			&a.Attempts, &a.Failed, &a.Succeeded, &a.Refunded, &a.NightAttempts,
+			&a.Volume, &a.BaselineVolume, &a.CollectedInWindow, &a.PaidOutInWindow,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan merchant activity: %w", err)
+		}
+		activities = append(activities, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read merchant activity: %w", err)
+	}
+
+	return activities, nil
+}
+
+func (r *ScoreRepositoryImpl) SaveScore(ctx context.Context, score *entity.MerchantScore) error {
+	factors, err := json.Marshal(score.Factors)
+	if err != nil {
+		return fmt.Errorf("failed to marshal score factors: %w", err)
+	}
+
+	// A re-run of the day keeps the row, so alerts raised from it stay linked
+	query := `
+		INSERT INTO public.merchant_risk_scores (id, merchant_id, score_date, score, level, factors, attempts, volume, created_at)
+		VALUES ($1, $2, $3::date, $4, $5, $6::jsonb, $7, $8, $9)
+		ON CONFLICT (merchant_id, score_date) DO UPDATE
+		SET score = EXCLUDED.score,
+			level = EXCLUDED.level,
+			factors = EXCLUDED.factors,
+			attempts = EXCLUDED.attempts,
+			volume = EXCLUDED.volume,
+			created_at = EXCLUDED.created_at
+		RETURNING id`
+
+	err = r.db.QueryRowContext(ctx, query,
+		score.ID, score.MerchantID, score.ScoreDate.Format(dateLayout), score.Score, string(score.Level),
+		string(factors), score.Attempts, score.Volume, score.CreatedAt,
+	).Scan(&score.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save merchant risk score: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ScoreRepositoryImpl) ListScores(ctx context.Context, date *time.Time, minScore float64, limit int) ([]entity.MerchantScore, error) {
+	var day sql.NullString
+	if date != nil {
+		day = sql.NullString{String: date.Format(dateLayout), Valid: true}
+	}
+
+	query := `SELECT ` + scoreColumns + `
+		FROM public.merchant_risk_scores s
+		JOIN merchants.merchants m ON m.id = s.merchant_id
+		WHERE s.score_date = COALESCE($1::date, (SELECT MAX(score_date) FROM public.merchant_risk_scores))
+			AND s.score >= $2
+		ORDER BY s.score DESC, s.volume DESC
+		LIMIT $3`
+
+	return r.listScores(ctx, query, day, minScore, limit)
+}
+
+func (r *ScoreRepositoryImpl) ListMerchantScores(ctx context.Context, merchantID uuid.UUID, limit int) ([]entity.MerchantScore, error) {
+	query := `SELECT ` + scoreColumns + `
+		FROM public.merchant_risk_scores s
+		JOIN merchants.merchants m ON m.id = s.merchant_id
+		WHERE s.merchant_id = $1
+		ORDER BY s.score_date DESC
+		LIMIT $2`
+
+	return r.listScores(ctx, query, merchantID, limit)
+}
+
+func (r *ScoreRepositoryImpl) listScores(ctx context.Context, query string, args ...interface{}) ([]entity.MerchantScore, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merchant risk scores: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []entity.MerchantScore
+	for rows.Next() {
+		var score entity.MerchantScore
+		var level string
+		var factors []byte
+		var scoreDate time.Time
+		if err := rows.Scan(
+			&score.ID, &score.MerchantID, &score.MerchantName, &score.MerchantStatus, &scoreDate,
+			&score.Score, &level, &factors, &score.Attempts, &score.Volume, &score.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan merchant risk score: %w", err)
+		}
+		if err := json.Unmarshal(factors, &score.Factors); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal score factors: %w", err)
+		}
+		score.Level = entity.ScoreLevel(level)
+		score.ScoreDate = scoringDay(scoreDate)
+		scores = append(scores, score)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list merchant risk scores: %w", err)
+	}
+
+	return scores, nil
+}
+
+func (r *ScoreRepositoryImpl) CreateAlert(ctx context.Context, alert *entity.MerchantAlert) (bool, error) {
+	breaches, err := json.Marshal(alert.Breaches)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal alert breaches: %w", err)
+	}
+
+	query := `
+		INSERT INTO public.merchant_risk_alerts (` + alertColumns + `)
+		VALUES ($1, $2, $3, $4::date, $5, $6, $7::jsonb, $8, $9, $10, $11)
+		ON CONFLICT (merchant_id, score_date) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query,
+		alert.ID, alert.MerchantID, alert.ScoreID, alert.ScoreDate.Format(dateLayout), alert.Score,
+		string(alert.Level), string(breaches), alert.AcknowledgedBy, alert.AcknowledgedAt,
+		nullString(alert.Note), alert.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create merchant risk alert: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create merchant risk alert: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (r *ScoreRepositoryImpl) GetAlert(ctx context.Context, id uuid.UUID) (*entity.MerchantAlert, error) {
+	query := `SELECT ` + alertColumns + ` FROM public.merchant_risk_alerts WHERE id = $1`
+
+	alert, err := scanAlert(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get merchant risk alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+func (r *ScoreRepositoryImpl) ListAlerts(ctx context.Context, filter entity.AlertFilter) ([]entity.MerchantAlert, error) {
+	var acknowledged sql.NullBool
+	if filter.Acknowledged != nil {
+		acknowledged = sql.NullBool{Bool: *filter.Acknowledged, Valid: true}
+	}
+
+	query := `SELECT ` + alertColumns + ` FROM public.merchant_risk_alerts
+		WHERE ($1::uuid IS NULL OR merchant_id = $1)
+			AND ($2::boolean IS NULL OR (acknowledged_at IS NOT NULL) = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.MerchantID, acknowledged, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merchant risk alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []entity.MerchantAlert
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan merchant risk alert: %w", err)
+		}
+		alerts = append(alerts, *alert)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list merchant risk alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *ScoreRepositoryImpl) AcknowledgeAlert(ctx context.Context, alert *entity.MerchantAlert) error {
+	query := `
+		UPDATE public.merchant_risk_alerts
+		SET acknowledged_by = $2,
+			acknowledged_at = $3,
+			note = $4
+		WHERE id = $1 AND acknowledged_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, alert.ID, alert.AcknowledgedBy, alert.AcknowledgedAt, nullString(alert.Note))
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge merchant risk alert: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge merchant risk alert: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrAlertAcknowledged
+	}
+
+	return nil
+}
+
+func scanAlert(row rowScanner) (*entity.MerchantAlert, error) {
+	var alert entity.MerchantAlert
+	var level string
+	var breaches []byte
+	var scoreDate time.Time
+	var acknowledgedBy uuid.NullUUID
+	var acknowledgedAt sql.NullTime
+	var note sql.NullString
+
+	err := row.Scan(
+		&alert.ID,
+		&alert.MerchantID,
+		&alert.ScoreID,
+		&scoreDate,
+		&alert.Score,
+		&level,
+		&breaches,
+		&acknowledgedBy,
+		&acknowledgedAt,
+		&note,
+		&alert.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(breaches, &alert.Breaches); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alert breaches: %w", err)
+	}
+
+	alert.ScoreDate = scoringDay(scoreDate)
+	alert.Level = entity.ScoreLevel(level)
+	alert.Note = note.String
+	if acknowledgedBy.Valid {
+		alert.AcknowledgedBy = &acknowledgedBy.UUID
+	}
+	if acknowledgedAt.Valid {
+		alert.AcknowledgedAt = &acknowledgedAt.Time
+	}
+
+	return &alert, nil
+}
+
+// scoringDay turns a DATE column back into the start of the scoring day
+func scoringDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, entity.ScoreZone)
+}
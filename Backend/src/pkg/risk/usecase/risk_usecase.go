@@ -0,0 +1,420 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/risk/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepository "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+const (
+	defaultReviewListLimit = 50
+	maxReviewListLimit     = 200
+)
+
+// SettingsStore reads and writes the risk settings of merchants. The merchant repository implements it.
+type SettingsStore interface {
+	GetMerchantSettings(ctx context.Context, merchantID uuid.UUID) (*merchantEntity.MerchantSettings, error)
+	UpdateMerchantRiskSettings(ctx context.Context, merchantID uuid.UUID, riskSettings string) error
+}
+
+// TransactionReviewer carries out review decisions on held transactions. The payment usecase implements it,
+// and is set after construction because it depends on this usecase.
+type TransactionReviewer interface {
+	// ResumeReviewedWithdrawal continues a withdrawal an admin cleared
+	ResumeReviewedWithdrawal(ctx context.Context, transactionID uuid.UUID, actor string) error
+
+	// RejectReviewedWithdrawal fails a withdrawal an admin rejected and unlocks its funds
+	RejectReviewedWithdrawal(ctx context.Context, transactionID uuid.UUID, actor, reason string) error
+
+	// ResumeReviewedPayment sends a payment an admin cleared to its processor. Payments that
+	// were not held are left as they are.
+	ResumeReviewedPayment(ctx context.Context, transactionID uuid.UUID, actor string) error
+
+	// RejectReviewedPayment fails a held payment an admin rejected. Payments that were not
+	// held are left as they are.
+	RejectReviewedPayment(ctx context.Context, transactionID uuid.UUID, actor, reason string) error
+}
+
+// RiskUseCase defines the interface for the risk engine and its manual review queue
+type RiskUseCase interface {
+	// Assess evaluates a stored transaction against the risk rules of its merchant before it is sent
+	// to the processor, records the assessment and stores its outcome in the transaction details.
+	// It returns nil when the merchant's rules are disabled. Acting on the outcome is up to the caller.
+	// Required reasons are raised by the caller and always apply, even when the rules are disabled.
+	Assess(ctx context.Context, tx *txEntity.Transaction, flow entity.Flow, required ...entity.Reason) (*entity.Assessment, error)
+
+	// FailOpen reports whether payments that could not be assessed go through unscreened rather
+	// than being failed. Withdrawals are never sent unscreened.
+	FailOpen() bool
+
+	// GetRules retrieves the effective risk rules of a merchant
+	GetRules(ctx context.Context, merchantID uuid.UUID) (*entity.Rules, error)
+
+	// UpdateRules replaces the risk rules of a merchant
+	UpdateRules(ctx context.Context, merchantID uuid.UUID, rules *entity.Rules, adminID uuid.UUID) (*entity.Rules, error)
+
+	// GetAssessment retrieves an assessment
+	GetAssessment(ctx context.Context, id uuid.UUID) (*entity.Assessment, error)
+
+	// GetAssessmentByTransactionID retrieves the assessment of a transaction, nil when it was never assessed
+	GetAssessmentByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Assessment, error)
+
+	// ListReviews retrieves the review queue
+	ListReviews(ctx context.Context, filter entity.ReviewFilter) (*entity.ReviewListResponse, error)
+
+	// Approve clears a transaction in the review queue; a held withdrawal or payment continues to its processor
+	Approve(ctx context.Context, id, reviewerID uuid.UUID, req *entity.DecisionRequest) (*entity.Assessment, error)
+
+	// Reject marks a transaction in the review queue as fraudulent; a held payment fails, and so does a held
+	// withdrawal, whose funds are unlocked
+	Reject(ctx context.Context, id, reviewerID uuid.UUID, req *entity.DecisionRequest) (*entity.Assessment, error)
+
+	// SetTransactionReviewer sets who carries out decisions on held transactions
+	SetTransactionReviewer(reviewer TransactionReviewer)
+}
+
+type riskUseCase struct {
+	repo            repository.RiskRepository
+	settings        SettingsStore
+	transactionRepo txRepository.TransactionRepository
+	reviewer        TransactionReviewer
+	failOpen        bool
+	log             logging.Logger
+}
+
+// NewRiskUseCase creates the risk usecase. failOpen lets payments through unscreened when they
+// cannot be assessed, trading fraud screening for availability while the risk engine is down.
+func NewRiskUseCase(repo repository.RiskRepository, settings SettingsStore, transactionRepo txRepository.TransactionRepository, failOpen bool) RiskUseCase {
+	return &riskUseCase{
+		repo:            repo,
+		settings:        settings,
+		transactionRepo: transactionRepo,
+		failOpen:        failOpen,
+		log:             logging.NewStdLogger("risk_usecase"),
+	}
+}
+
+func (uc *riskUseCase) SetTransactionReviewer(reviewer TransactionReviewer) {
+	uc.reviewer = reviewer
+}
+
+func (uc *riskUseCase) FailOpen() bool {
+	return uc.failOpen
+}
+
+func (uc *riskUseCase) GetRules(ctx context.Context, merchantID uuid.UUID) (*entity.Rules, error) {
+	settings, err := uc.settings.GetMerchantSettings(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return entity.DefaultRules(), nil
+	}
+
+	rules, err := entity.ParseRules(settings.RiskSettings)
+	if err != nil {
+		// Broken overrides must not switch the engine off; fall back to the platform rules
+		uc.log.Error("Invalid merchant risk settings, using default rules", map[string]interface{}{
+			"merchant_id": merchantID,
+			"error":       err.Error(),
+		})
+		return entity.DefaultRules(), nil
+	}
+	return rules, nil
+}
+
+func (uc *riskUseCase) UpdateRules(ctx context.Context, merchantID uuid.UUID, rules *entity.Rules, adminID uuid.UUID) (*entity.Rules, error) {
+	rules.Normalize()
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal risk rules: %w", err)
+	}
+	if err := uc.settings.UpdateMerchantRiskSettings(ctx, merchantID, string(raw)); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Merchant risk rules updated", map[string]interface{}{
+		"merchant_id": merchantID,
+		"enabled":     rules.Enabled,
+		"rules":       string(raw),
+		"updated_by":  adminID,
+	})
+
+	return rules, nil
+}
+
+func (uc *riskUseCase) Assess(ctx context.Context, tx *txEntity.Transaction, flow entity.Flow, required ...entity.Reason) (*entity.Assessment, error) {
+	rules, err := uc.GetRules(ctx, tx.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+	if !rules.Enabled && len(required) == 0 {
+		return nil, nil
+	}
+
+	signals := entity.SignalsFromContext(ctx)
+	outcome, reasons := entity.OutcomeAllow, []entity.Reason(nil)
+	if rules.Enabled {
+		observation, err := uc.observe(ctx, rules, tx, flow, signals)
+		if err != nil {
+			return nil, err
+		}
+		outcome, reasons = rules.Evaluate(*observation)
+	}
+	outcome, reasons = entity.Escalate(outcome, reasons, required...)
+	if reasons == nil {
+		reasons = []entity.Reason{}
+	}
+
+	now := time.Now()
+	assessment := &entity.Assessment{
+		ID:              uuid.New(),
+		MerchantID:      tx.MerchantId,
+		TransactionID:   tx.Id,
+		Flow:            flow,
+		Medium:          tx.Medium,
+		Amount:          tx.BaseAmount,
+		Currency:        tx.WalletCurrency(),
+		PhoneNumber:     tx.PhoneNumber,
+		IPAddress:       signals.IPAddress,
+		DeviceID:        signals.DeviceID,
+		Country:         signals.Country,
+		CardFingerprint: signals.CardFingerprint(),
+		Outcome:         outcome,
+		Reasons:         reasons,
+		Test:            tx.Test,
+		CreatedAt:       now,
+	}
+	if outcome == entity.OutcomeReview {
+		assessment.ReviewStatus = entity.ReviewPending
+	}
+
+	if err := uc.repo.Create(ctx, assessment); err != nil {
+		return nil, err
+	}
+
+	if err := uc.transactionRepo.MergeTransactionDetails(ctx, tx.Id, map[string]interface{}{
+		entity.DetailKey: assessment.Detail(),
+	}); err != nil {
+		// The assessment itself is recorded; the transaction only carries a summary of it
+		uc.log.Error("Failed to store risk outcome on transaction", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"assessment_id":  assessment.ID,
+			"error":          err.Error(),
+		})
+	}
+
+	if outcome != entity.OutcomeAllow || len(reasons) > 0 {
+		uc.log.Warn("Risk rules triggered", map[string]interface{}{
+			"assessment_id":  assessment.ID,
+			"transaction_id": tx.Id,
+			"merchant_id":    tx.MerchantId,
+			"flow":           flow,
+			"outcome":        outcome,
+			"reasons":        assessment.Summary(),
+		})
+	}
+
+	return assessment, nil
+}
+
+// observe gathers what the enabled rules need to know about the request
+func (uc *riskUseCase) observe(ctx context.Context, rules *entity.Rules, tx *txEntity.Transaction, flow entity.Flow, signals entity.Signals) (*entity.Observation, error) {
+	observation := &entity.Observation{
+		Withdrawal:  flow == entity.FlowWithdrawal,
+		Amount:      tx.BaseAmount,
+		PhoneNumber: tx.PhoneNumber,
+		Signals:     signals,
+	}
+
+	velocity := []struct {
+		key      repository.AttemptKey
+		value    string
+		rule     entity.VelocityRule
+		attempts *int
+	}{
+		{repository.AttemptsByPhone, tx.PhoneNumber, rules.PhoneVelocity, &observation.PhoneAttempts},
+		{repository.AttemptsByCard, signals.CardFingerprint(), rules.CardVelocity, &observation.CardAttempts},
+		{repository.AttemptsByIP, signals.IPAddress, rules.IPVelocity, &observation.IPAttempts},
+	}
+	for _, v := range velocity {
+		if v.value == "" || v.rule.Limit <= 0 {
+			continue
+		}
+		since := time.Now().Add(-time.Duration(v.rule.WindowMinutes) * time.Minute)
+		count, err := uc.repo.CountAttempts(ctx, tx.MerchantId, v.key, v.value, since, tx.Test)
+		if err != nil {
+			return nil, err
+		}
+		*v.attempts = count
+	}
+
+	if !observation.Withdrawal {
+		return observation, nil
+	}
+
+	// Requests without a device ID, such as server-to-server API calls, are not checked
+	if rules.NewDevicePayout != "" && signals.DeviceID != "" {
+		seen, err := uc.repo.HasWithdrawnFromDevice(ctx, tx.MerchantId, signals.DeviceID, tx.Test)
+		if err != nil {
+			return nil, err
+		}
+		observation.NewDevice = !seen
+	}
+	if rules.NewBeneficiaryPayout != "" {
+		paid, err := uc.repo.HasPaidOut(ctx, tx.MerchantId, tx.Medium, tx.PhoneNumber, tx.Test)
+		if err != nil {
+			return nil, err
+		}
+		observation.NewBeneficiary = !paid
+	}
+
+	return observation, nil
+}
+
+func (uc *riskUseCase) GetAssessment(ctx context.Context, id uuid.UUID) (*entity.Assessment, error) {
+	assessment, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, entity.ErrAssessmentNotFound
+	}
+	return assessment, nil
+}
+
+func (uc *riskUseCase) GetAssessmentByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Assessment, error) {
+	return uc.repo.GetByTransactionID(ctx, transactionID)
+}
+
+func (uc *riskUseCase) ListReviews(ctx context.Context, filter entity.ReviewFilter) (*entity.ReviewListResponse, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultReviewListLimit
+	}
+	if filter.Limit > maxReviewListLimit {
+		filter.Limit = maxReviewListLimit
+	}
+
+	reviews, err := uc.repo.ListReviews(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if reviews == nil {
+		reviews = []entity.Assessment{}
+	}
+
+	return &entity.ReviewListResponse{Reviews: reviews}, nil
+}
+
+func (uc *riskUseCase) Approve(ctx context.Context, id, reviewerID uuid.UUID, req *entity.DecisionRequest) (*entity.Assessment, error) {
+	assessment, err := uc.decide(ctx, id, reviewerID, entity.ReviewApproved, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	if assessment.Flow == entity.FlowWithdrawal {
+		if err := uc.reviewer.ResumeReviewedWithdrawal(ctx, assessment.TransactionID, reviewerID.String()); err != nil {
+			uc.log.Error("Cleared withdrawal could not be resumed", map[string]interface{}{
+				"assessment_id":  assessment.ID,
+				"transaction_id": assessment.TransactionID,
+				"error":          err.Error(),
+			})
+			return nil, fmt.Errorf("withdrawal cleared but could not be resumed: %w", err)
+		}
+	} else if err := uc.reviewer.ResumeReviewedPayment(ctx, assessment.TransactionID, reviewerID.String()); err != nil {
+		uc.log.Error("Cleared payment could not be resumed", map[string]interface{}{
+			"assessment_id":  assessment.ID,
+			"transaction_id": assessment.TransactionID,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("payment cleared but could not be resumed: %w", err)
+	}
+
+	return assessment, nil
+}
+
+func (uc *riskUseCase) Reject(ctx context.Context, id, reviewerID uuid.UUID, req *entity.DecisionRequest) (*entity.Assessment, error) {
+	assessment, err := uc.decide(ctx, id, reviewerID, entity.ReviewRejected, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	if assessment.Flow == entity.FlowWithdrawal {
+		reason := "withdrawal rejected in risk review"
+		if req.Note != "" {
+			reason += ": " + req.Note
+		}
+		if err := uc.reviewer.RejectReviewedWithdrawal(ctx, assessment.TransactionID, reviewerID.String(), reason); err != nil {
+			uc.log.Error("Failed to release rejected withdrawal", map[string]interface{}{
+				"assessment_id":  assessment.ID,
+				"transaction_id": assessment.TransactionID,
+				"error":          err.Error(),
+			})
+			return nil, fmt.Errorf("withdrawal rejected but its funds could not be released: %w", err)
+		}
+	} else {
+		reason := "payment rejected in risk review"
+		if req.Note != "" {
+			reason += ": " + req.Note
+		}
+		if err := uc.reviewer.RejectReviewedPayment(ctx, assessment.TransactionID, reviewerID.String(), reason); err != nil {
+			uc.log.Error("Failed to fail rejected payment", map[string]interface{}{
+				"assessment_id":  assessment.ID,
+				"transaction_id": assessment.TransactionID,
+				"error":          err.Error(),
+			})
+			return nil, fmt.Errorf("payment rejected but could not be failed: %w", err)
+		}
+	}
+
+	return assessment, nil
+}
+
+// decide records an admin's decision on an assessment in the review queue
+func (uc *riskUseCase) decide(ctx context.Context, id, reviewerID uuid.UUID, status entity.ReviewStatus, note string) (*entity.Assessment, error) {
+	assessment, err := uc.GetAssessment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := assessment.Decidable(); err != nil {
+		return nil, err
+	}
+	if uc.reviewer == nil {
+		return nil, fmt.Errorf("risk reviews are not available")
+	}
+
+	now := time.Now()
+	assessment.ReviewStatus = status
+	assessment.ReviewedBy = &reviewerID
+	assessment.ReviewNote = note
+	assessment.ReviewedAt = &now
+	if err := uc.repo.Decide(ctx, assessment); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Risk review decided", map[string]interface{}{
+		"assessment_id":  assessment.ID,
+		"transaction_id": assessment.TransactionID,
+		"merchant_id":    assessment.MerchantID,
+		"flow":           assessment.Flow,
+		"decision":       status,
+		"reviewer_id":    reviewerID,
+		"note":           note,
+	})
+
+	return assessment, nil
+}
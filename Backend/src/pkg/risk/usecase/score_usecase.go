@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/risk/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+)
+
+const defaultScoreHistoryLimit = 90
+
+// AlertNotifier sends merchant risk alerts to the admins on call. The notification service implements it.
+type AlertNotifier interface {
+	SendSMS(ctx context.Context, phoneNumber, message string) error
+}
+
+// MerchantScoreUseCase defines the interface for daily merchant risk scoring and its alerts
+type MerchantScoreUseCase interface {
+	// ScoreDay scores every merchant active on the day, replacing earlier scores of the day, and
+	// raises an alert for each merchant whose score crossed a threshold
+	ScoreDay(ctx context.Context, day time.Time) (*entity.ScoringRun, error)
+
+	// ScorePreviousDay scores the last complete day; it is run daily
+	ScorePreviousDay(ctx context.Context) error
+
+	// ListScores ranks merchants by their score for a day, riskiest first
+	ListScores(ctx context.Context, filter entity.ScoreFilter) (*entity.ScoreListResponse, error)
+
+	// GetMerchantScores retrieves the score history of a merchant, latest first
+	GetMerchantScores(ctx context.Context, merchantID uuid.UUID, limit int) (*entity.ScoreListResponse, error)
+
+	// ListAlerts retrieves merchant risk alerts, latest first
+	ListAlerts(ctx context.Context, filter entity.AlertFilter) (*entity.AlertListResponse, error)
+
+	// AcknowledgeAlert records that an admin looked into an alert
+	AcknowledgeAlert(ctx context.Context, id, adminID uuid.UUID, req *entity.AcknowledgeRequest) (*entity.MerchantAlert, error)
+
+	// Config returns the scoring configuration
+	Config() entity.ScoreConfig
+}
+
+type merchantScoreUseCase struct {
+	repo        repository.ScoreRepository
+	config      entity.ScoreConfig
+	notifier    AlertNotifier
+	alertPhones []string
+	log         logging.Logger
+}
+
+// NewMerchantScoreUseCase creates the merchant risk scoring usecase. Alerts are sent by SMS to
+// alertPhones when a notifier is given, and can always be listed by admins.
+func NewMerchantScoreUseCase(repo repository.ScoreRepository, config entity.ScoreConfig, notifier AlertNotifier, alertPhones []string) MerchantScoreUseCase {
+	return &merchantScoreUseCase{
+		repo:        repo,
+		config:      config,
+		notifier:    notifier,
+		alertPhones: alertPhones,
+		log:         logging.NewStdLogger("merchant_score_usecase"),
+	}
+}
+
+func (uc *merchantScoreUseCase) Config() entity.ScoreConfig {
+	return uc.config
+}
+
+func (uc *merchantScoreUseCase) ScorePreviousDay(ctx context.Context) error {
+	_, err := uc.ScoreDay(ctx, entity.ScoreDay(time.Now()).AddDate(0, 0, -1))
+	return err
+}
+
+func (uc *merchantScoreUseCase) ScoreDay(ctx context.Context, day time.Time) (*entity.ScoringRun, error) {
+	window := uc.config.Window(day)
+	activities, err := uc.repo.MerchantActivity(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &entity.ScoringRun{ScoreDate: window.Day}
+	now := time.Now()
+	for _, activity := range activities {
+		score := uc.config.Score(activity, window.Day)
+		score.CreatedAt = now
+		if err := uc.repo.SaveScore(ctx, score); err != nil {
+			// One merchant's score must not hold up the others
+			uc.log.Error("Failed to save merchant risk score", map[string]interface{}{
+				"merchant_id": activity.MerchantID,
+				"score_date":  window.Day.Format("2006-01-02"),
+				"error":       err.Error(),
+			})
+			continue
+		}
+		run.Scored++
+
+		if !uc.config.Alerts(score) {
+			continue
+		}
+		raised, err := uc.raiseAlert(ctx, score, now)
+		if err != nil {
+			uc.log.Error("Failed to raise merchant risk alert", map[string]interface{}{
+				"merchant_id": activity.MerchantID,
+				"score_id":    score.ID,
+				"error":       err.Error(),
+			})
+			continue
+		}
+		if raised {
+			run.Alerts++
+		}
+	}
+
+	uc.log.Info("Merchant risk scoring completed", map[string]interface{}{
+		"score_date": window.Day.Format("2006-01-02"),
+		"merchants":  len(activities),
+		"scored":     run.Scored,
+		"alerts":     run.Alerts,
+	})
+
+	return run, nil
+}
+
+// raiseAlert stores the alert of a score and sends it to the admins on call. A day that was
+// already alerted on is not alerted on again when it is scored a second time.
+func (uc *merchantScoreUseCase) raiseAlert(ctx context.Context, score *entity.MerchantScore, now time.Time) (bool, error) {
+	alert := entity.NewMerchantAlert(score, now)
+	created, err := uc.repo.CreateAlert(ctx, alert)
+	if err != nil || !created {
+		return false, err
+	}
+
+	uc.log.Warn("Merchant risk score crossed a threshold", map[string]interface{}{
+		"alert_id":    alert.ID,
+		"merchant_id": alert.MerchantID,
+		"score":       alert.Score,
+		"level":       alert.Level,
+		"breaches":    alert.Breaches,
+	})
+
+	if uc.notifier == nil {
+		return true, nil
+	}
+	message := alert.Message(score.MerchantName)
+	for _, phone := range uc.alertPhones {
+		if err := uc.notifier.SendSMS(ctx, phone, message); err != nil {
+			// The alert is stored either way and shows up in the admin list
+			uc.log.Error("Failed to send merchant risk alert", map[string]interface{}{
+				"alert_id": alert.ID,
+				"error":    err.Error(),
+			})
+		}
+	}
+
+	return true, nil
+}
+
+func (uc *merchantScoreUseCase) ListScores(ctx context.Context, filter entity.ScoreFilter) (*entity.ScoreListResponse, error) {
+	if filter.Date != nil {
+		day := entity.ScoreDay(*filter.Date)
+		filter.Date = &day
+	}
+
+	scores, err := uc.repo.ListScores(ctx, filter.Date, filter.MinScore(uc.config), listLimit(filter.Limit))
+	if err != nil {
+		return nil, err
+	}
+	if scores == nil {
+		scores = []entity.MerchantScore{}
+	}
+
+	return &entity.ScoreListResponse{Scores: scores}, nil
+}
+
+func (uc *merchantScoreUseCase) GetMerchantScores(ctx context.Context, merchantID uuid.UUID, limit int) (*entity.ScoreListResponse, error) {
+	if limit <= 0 {
+		limit = defaultScoreHistoryLimit
+	}
+
+	scores, err := uc.repo.ListMerchantScores(ctx, merchantID, listLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	if scores == nil {
+		scores = []entity.MerchantScore{}
+	}
+
+	return &entity.ScoreListResponse{Scores: scores}, nil
+}
+
+func (uc *merchantScoreUseCase) ListAlerts(ctx context.Context, filter entity.AlertFilter) (*entity.AlertListResponse, error) {
+	filter.Limit = listLimit(filter.Limit)
+
+	alerts, err := uc.repo.ListAlerts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if alerts == nil {
+		alerts = []entity.MerchantAlert{}
+	}
+
+	return &entity.AlertListResponse{Alerts: alerts}, nil
+}
+
+func (uc *merchantScoreUseCase) AcknowledgeAlert(ctx context.Context, id, adminID uuid.UUID, req *entity.AcknowledgeRequest) (*entity.MerchantAlert, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	alert, err := uc.repo.GetAlert(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil {
+		return nil, entity.ErrAlertNotFound
+	}
+	if alert.AcknowledgedAt != nil {
+		return nil, entity.ErrAlertAcknowledged
+	}
+
+	now := time.Now()
+	alert.AcknowledgedBy = &adminID
+	alert.AcknowledgedAt = &now
+	alert.Note = req.Note
+	if err := uc.repo.AcknowledgeAlert(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Merchant risk alert acknowledged", map[string]interface{}{
+		"alert_id":        alert.ID,
+		"merchant_id":     alert.MerchantID,
+		"acknowledged_by": adminID,
+	})
+
+	return alert, nil
+}
+
+// listLimit bounds a requested list size like the review queue does
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return defaultReviewListLimit
+	}
+	if limit > maxReviewListLimit {
+		return maxReviewListLimit
+	}
+	return limit
+}
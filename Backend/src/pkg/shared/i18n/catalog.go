@@ -0,0 +1,403 @@
+package i18n
+
+// catalog holds every translated string used by the hosted checkout,
+// receipts and SMS notifications. Keys are grouped by prefix:
+//   - checkout.* labels rendered by the hosted checkout page
+//   - receipt.*  labels rendered on the payment receipt
+//   - sms.*      fmt templates used by the notification service
+//
+// SMS templates in non-English locales use explicit argument indexes so the
+// word order can follow the language while callers pass arguments in the
+// same order as the English template.
+var catalog = map[Locale]map[string]string{
+	English: {
+		// Checkout
+		"checkout.title":             "Secure Checkout",
+		"checkout.amount":            "Amount",
+		"checkout.fee":               "Service fee",
+		"checkout.total":             "Total",
+		"checkout.description":       "Description",
+		"checkout.reference":         "Reference",
+		"checkout.select_method":     "Select payment method",
+		"checkout.phone_number":      "Phone number",
+		"checkout.invalid_phone":     "Enter a valid phone number",
+		"checkout.add_tip":           "Add a tip",
+		"checkout.tip_amount":        "Tip amount",
+		"checkout.tipee_phone":       "Tip recipient phone number",
+		"checkout.pay_now":           "Pay now",
+		"checkout.cancel":            "Cancel",
+		"checkout.processing":        "Processing your payment...",
+		"checkout.confirm_on_phone":  "Please confirm the payment on your phone",
+		"checkout.success":           "Payment successful",
+		"checkout.failed":            "Payment failed",
+		"checkout.try_again":         "Try again",
+		"checkout.expires_at":        "Expires at",
+		"checkout.expired":           "This checkout has expired",
+		"checkout.unavailable":       "This checkout is no longer available",
+		"checkout.merchant_pays_fee": "Fees are covered by the merchant",
+		"checkout.support":           "Need help? Contact support",
+		"checkout.powered_by":        "Powered by SocialPay",
+
+		// Receipt
+		"receipt.title":            "Payment Receipt",
+		"receipt.transaction_id":   "Transaction ID",
+		"receipt.date":             "Date",
+		"receipt.status":           "Status",
+		"receipt.merchant":         "Merchant",
+		"receipt.payer_phone":      "Payer phone number",
+		"receipt.medium":           "Payment method",
+		"receipt.amount":           "Amount",
+		"receipt.fee":              "Service fee",
+		"receipt.vat":              "VAT",
+		"receipt.total":            "Total",
+		"receipt.reference":        "Reference",
+		"receipt.description":      "Description",
+		"receipt.thank_you":        "Thank you for your payment",
+		"receipt.download":         "Download receipt",
+		"receipt.status.INITIATED": "Initiated",
+		"receipt.status.PENDING":   "Pending",
+		"receipt.status.SUCCESS":   "Successful",
+		"receipt.status.FAILED":    "Failed",
+		"receipt.status.REFUNDED":  "Refunded",
+		"receipt.status.EXPIRED":   "Expired",
+		"receipt.status.CANCELED":  "Canceled",
+
+		// SMS
+		"sms.customer_name":     "Customer",
+		"sms.tipee_name":        "Tipee",
+		"sms.merchant_fallback": "merchant",
+		"sms.payer.success": `Dear %s,
+Your payment of %.2f %s to %s has been successfully processed.
+Reference: %s
+txnid: %s
+Date: %s at %s
+
+📞6562
+አሸናፊዎች በላኪፔይ ይከፍላሉ!!`,
+		"sms.payer.failed": `Dear %s,
+Your payment of %.2f %s to %s has failed.
+Reference: %s
+txnid: %s
+Date: %s at %s
+
+Please try again or contact support.`,
+		"sms.merchant_sender.success": `Dear %s,
+Withdrawal of %.2f %s to %s has been successfully processed.
+Reference: %s
+txnid: %s
+Date: %s at %s
+
+SocialPay - Your trusted payment partner!`,
+		"sms.merchant_sender.failed": `Dear %s,
+Withdrawal of %.2f %s to %s has failed.
+Reference: %s
+txnid: %s
+Date: %s at %s.
+
+SocialPay - Your trusted payment partner!
+`,
+		"sms.merchant_recipient.success": `Dear %s,
+You have recieved payment of %.2f %s from %s.
+Reference: %s
+txnid: %s
+Date: %s at %s.
+
+SocialPay - Your trusted payment partner!
+`,
+		"sms.tipee.success": `Dear %s,
+You received a tip of %.2f %s.
+Reference: %s
+Date: %s at %s
+
+Thank you for your service!`,
+		"sms.withdrawal_approval.PENDING": `Dear %s,
+Withdrawal of %.2f %s to %s is awaiting approval by a second team member.
+Reference: %s
+txnid: %s
+Date: %s at %s
+
+SocialPay - Your trusted payment partner!`,
+		"sms.withdrawal_approval.APPROVED": `Dear %s,
+Withdrawal of %.2f %s to %s has been approved and sent for processing.
+Reference: %s
+txnid: %s
+Date: %s at %s
+
+SocialPay - Your trusted payment partner!`,
+		"sms.withdrawal_approval.REJECTED": `Dear %s,
+Withdrawal of %.2f %s to %s has been rejected and the funds released to your wallet.
+Reference: %s
+txnid: %s
+Date: %s at %s
+
+SocialPay - Your trusted payment partner!`,
+		"sms.withdrawal_approval.EXPIRED": `Dear %s,
+Withdrawal of %.2f %s to %s was not approved in time and the funds were released to your wallet.
+Reference: %s
+txnid: %s
+Date: %s at %s
+
+SocialPay - Your trusted payment partner!`,
+		"sms.kyc.SUBMITTED": `Dear %s,
+We received your business verification documents. Our compliance team will review them by %s.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.kyc.DOCUMENT_REJECTED": `Dear %s,
+A verification document was not accepted: %s
+Please upload a corrected document and resubmit.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.kyc.TIN_FAILED": `Dear %s,
+We could not verify your Tax Identification Number: %s
+Please correct your business details and resubmit.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.kyc.APPROVED": `Dear %s,
+Your business was verified on %s and your SocialPay account is now active.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.kyc.REJECTED": `Dear %s,
+Your business verification was not approved: %s
+Please contact support for more information.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.dispute.OPEN": `Dear %s,
+A customer disputed payment %s of %.2f %s. The disputed amount is debited from your wallet until the dispute is decided.
+Please respond with evidence by %s.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.dispute.NEEDS_RESPONSE": `Dear %s,
+The dispute on payment %s of %.2f %s needs your response.
+Please respond with evidence by %s.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.dispute.UNDER_REVIEW": `Dear %s,
+The dispute on payment %s of %.2f %s is under review since %s. We will let you know the outcome.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.dispute.WON": `Dear %s,
+The dispute on payment %s of %.2f %s was decided in your favour on %s. Any amount debited for it was credited back to your wallet.
+
+SocialPay - Your trusted payment partner!`,
+		"sms.dispute.LOST": `Dear %s,
+The dispute on payment %s of %.2f %s was decided in the customer's favour: %s
+
+SocialPay - Your trusted payment partner!`,
+		"sms.default": `Transaction %s: %.2f %s
+Status: %s
+Reference: %s
+Date: %s at %s`,
+	},
+
+	Amharic: {
+		// Checkout
+		"checkout.title":             "ደህንነቱ የተጠበቀ ክፍያ",
+		"checkout.amount":            "መጠን",
+		"checkout.fee":               "የአገልግሎት ክፍያ",
+		"checkout.total":             "ጠቅላላ",
+		"checkout.description":       "መግለጫ",
+		"checkout.reference":         "ማጣቀሻ",
+		"checkout.select_method":     "የክፍያ ዘዴ ይምረጡ",
+		"checkout.phone_number":      "ስልክ ቁጥር",
+		"checkout.invalid_phone":     "ትክክለኛ ስልክ ቁጥር ያስገቡ",
+		"checkout.add_tip":           "ጉርሻ ይጨምሩ",
+		"checkout.tip_amount":        "የጉርሻ መጠን",
+		"checkout.tipee_phone":       "የጉርሻ ተቀባይ ስልክ ቁጥር",
+		"checkout.pay_now":           "አሁን ይክፈሉ",
+		"checkout.cancel":            "ሰርዝ",
+		"checkout.processing":        "ክፍያዎ በሂደት ላይ ነው...",
+		"checkout.confirm_on_phone":  "እባክዎ ክፍያውን በስልክዎ ላይ ያረጋግጡ",
+		"checkout.success":           "ክፍያው ተሳክቷል",
+		"checkout.failed":            "ክፍያው አልተሳካም",
+		"checkout.try_again":         "እንደገና ይሞክሩ",
+		"checkout.expires_at":        "የሚያበቃበት ጊዜ",
+		"checkout.expired":           "የዚህ ክፍያ ጊዜ አልፎበታል",
+		"checkout.unavailable":       "ይህ ክፍያ ከአሁን በኋላ አይገኝም",
+		"checkout.merchant_pays_fee": "የአገልግሎት ክፍያው በነጋዴው ይሸፈናል",
+		"checkout.support":           "እርዳታ ይፈልጋሉ? ድጋፍ ሰጪን ያግኙ",
+		"checkout.powered_by":        "በSocialPay የቀረበ",
+
+		// Receipt
+		"receipt.title":            "የክፍያ ደረሰኝ",
+		"receipt.transaction_id":   "የግብይት መለያ",
+		"receipt.date":             "ቀን",
+		"receipt.status":           "ሁኔታ",
+		"receipt.merchant":         "ነጋዴ",
+		"receipt.payer_phone":      "የከፋይ ስልክ ቁጥር",
+		"receipt.medium":           "የክፍያ ዘዴ",
+		"receipt.amount":           "መጠን",
+		"receipt.fee":              "የአገልግሎት ክፍያ",
+		"receipt.vat":              "ተ.እ.ታ",
+		"receipt.total":            "ጠቅላላ",
+		"receipt.reference":        "ማጣቀሻ",
+		"receipt.description":      "መግለጫ",
+		"receipt.thank_you":        "ስለ ክፍያዎ እናመሰግናለን",
+		"receipt.download":         "ደረሰኝ ያውርዱ",
+		"receipt.status.INITIATED": "ተጀምሯል",
+		"receipt.status.PENDING":   "በመጠባበቅ ላይ",
+		"receipt.status.SUCCESS":   "ተሳክቷል",
+		"receipt.status.FAILED":    "አልተሳካም",
+		"receipt.status.REFUNDED":  "ተመላሽ ተደርጓል",
+		"receipt.status.EXPIRED":   "ጊዜው አልፏል",
+		"receipt.status.CANCELED":  "ተሰርዟል",
+
+		// SMS
+		"sms.customer_name":     "ደንበኛ",
+		"sms.tipee_name":        "የጉርሻ ተቀባይ",
+		"sms.merchant_fallback": "ነጋዴ",
+		"sms.payer.success": `ውድ %[1]s፣
+ለ%[4]s የከፈሉት %[2].2f %[3]s በተሳካ ሁኔታ ተፈጽሟል።
+ማጣቀሻ: %[5]s
+የግብይት መለያ: %[6]s
+ቀን: %[7]s %[8]s
+
+📞6562
+አሸናፊዎች በላኪፔይ ይከፍላሉ!!`,
+		"sms.payer.failed": `ውድ %[1]s፣
+ለ%[4]s የከፈሉት %[2].2f %[3]s አልተሳካም።
+ማጣቀሻ: %[5]s
+የግብይት መለያ: %[6]s
+ቀን: %[7]s %[8]s
+
+እባክዎ እንደገና ይሞክሩ ወይም ድጋፍ ሰጪን ያግኙ።`,
+		"sms.merchant_sender.success": `ውድ %[1]s፣
+ወደ %[4]s የተላከው %[2].2f %[3]s ወጪ በተሳካ ሁኔታ ተፈጽሟል።
+ማጣቀሻ: %[5]s
+የግብይት መለያ: %[6]s
+ቀን: %[7]s %[8]s
+
+SocialPay - ታማኝ የክፍያ አጋርዎ!`,
+		"sms.merchant_sender.failed": `ውድ %[1]s፣
+ወደ %[4]s የተላከው %[2].2f %[3]s ወጪ አልተሳካም።
+ማጣቀሻ: %[5]s
+የግብይት መለያ: %[6]s
+ቀን: %[7]s %[8]s
+
+SocialPay - ታማኝ የክፍያ አጋርዎ!
+`,
+		"sms.merchant_recipient.success": `ውድ %[1]s፣
+ከ%[4]s %[2].2f %[3]s ክፍያ ተቀብለዋል።
+ማጣቀሻ: %[5]s
+የግብይት መለያ: %[6]s
+ቀን: %[7]s %[8]s
+
+SocialPay - ታማኝ የክፍያ አጋርዎ!
+`,
+		"sms.tipee.success": `ውድ %[1]s፣
+%[2].2f %[3]s ጉርሻ ተቀብለዋል።
+ማጣቀሻ: %[4]s
+ቀን: %[5]s %[6]s
+
+ስለ አገልግሎትዎ እናመሰግናለን!`,
+		"sms.default": `ግብይት %[1]s: %[2].2f %[3]s
+ሁኔታ: %[4]s
+ማጣቀሻ: %[5]s
+ቀን: %[6]s %[7]s`,
+	},
+
+	AfaanOromo: {
+		// Checkout
+		"checkout.title":             "Kaffaltii Nageenya Qabu",
+		"checkout.amount":            "Hanga",
+		"checkout.fee":               "Kaffaltii tajaajilaa",
+		"checkout.total":             "Waliigala",
+		"checkout.description":       "Ibsa",
+		"checkout.reference":         "Wabii",
+		"checkout.select_method":     "Mala kaffaltii filadhaa",
+		"checkout.phone_number":      "Lakkoofsa bilbilaa",
+		"checkout.invalid_phone":     "Lakkoofsa bilbilaa sirrii galchaa",
+		"checkout.add_tip":           "Tiippii dabalaa",
+		"checkout.tip_amount":        "Hanga tiippii",
+		"checkout.tipee_phone":       "Lakkoofsa bilbilaa fudhataa tiippii",
+		"checkout.pay_now":           "Amma kaffalaa",
+		"checkout.cancel":            "Haqi",
+		"checkout.processing":        "Kaffaltiin keessan hojjetamaa jira...",
+		"checkout.confirm_on_phone":  "Maaloo kaffaltii bilbila keessan irratti mirkaneessaa",
+		"checkout.success":           "Kaffaltiin milkaa'eera",
+		"checkout.failed":            "Kaffaltiin hin milkoofne",
+		"checkout.try_again":         "Irra deebi'aa yaalaa",
+		"checkout.expires_at":        "Yeroo itti dhumatu",
+		"checkout.expired":           "Yeroon kaffaltii kanaa darbeera",
+		"checkout.unavailable":       "Kaffaltiin kun kana booda hin argamu",
+		"checkout.merchant_pays_fee": "Kaffaltiin tajaajilaa daldalaan kaffalama",
+		"checkout.support":           "Gargaarsa barbaadduu? Deeggarsa qunnamaa",
+		"checkout.powered_by":        "SocialPay'n kan dhiyaate",
+
+		// Receipt
+		"receipt.title":            "Ragaa Kaffaltii",
+		"receipt.transaction_id":   "Lakkoofsa daldalaa",
+		"receipt.date":             "Guyyaa",
+		"receipt.status":           "Haala",
+		"receipt.merchant":         "Daldalaa",
+		"receipt.payer_phone":      "Lakkoofsa bilbilaa kaffalaa",
+		"receipt.medium":           "Mala kaffaltii",
+		"receipt.amount":           "Hanga",
+		"receipt.fee":              "Kaffaltii tajaajilaa",
+		"receipt.vat":              "VAT",
+		"receipt.total":            "Waliigala",
+		"receipt.reference":        "Wabii",
+		"receipt.description":      "Ibsa",
+		"receipt.thank_you":        "Kaffaltii keessaniif galatoomaa",
+		"receipt.download":         "Ragaa buusaa",
+		"receipt.status.INITIATED": "Jalqabameera",
+		"receipt.status.PENDING":   "Eegaa jira",
+		"receipt.status.SUCCESS":   "Milkaa'eera",
+		"receipt.status.FAILED":    "Hin milkoofne",
+		"receipt.status.REFUNDED":  "Deebi'eera",
+		"receipt.status.EXPIRED":   "Yeroon darbeera",
+		"receipt.status.CANCELED":  "Haqameera",
+
+		// SMS
+		"sms.customer_name":     "Maamila",
+		"sms.tipee_name":        "Fudhataa tiippii",
+		"sms.merchant_fallback": "daldalaa",
+		"sms.payer.success": `Kabajamoo %[1]s,
+Kaffaltiin %[2].2f %[3]s %[4]s'f raawwattan milkaa'inaan xumurameera.
+Wabii: %[5]s
+txnid: %[6]s
+Guyyaa: %[7]s sa'aatii %[8]s
+
+📞6562
+አሸናፊዎች በላኪፔይ ይከፍላሉ!!`,
+		"sms.payer.failed": `Kabajamoo %[1]s,
+Kaffaltiin %[2].2f %[3]s %[4]s'f raawwattan hin milkoofne.
+Wabii: %[5]s
+txnid: %[6]s
+Guyyaa: %[7]s sa'aatii %[8]s
+
+Maaloo irra deebi'aa yaalaa ykn deeggarsa qunnamaa.`,
+		"sms.merchant_sender.success": `Kabajamoo %[1]s,
+Maallaqni %[2].2f %[3]s gara %[4]s'tti baase milkaa'inaan xumurameera.
+Wabii: %[5]s
+txnid: %[6]s
+Guyyaa: %[7]s sa'aatii %[8]s
+
+SocialPay - Michuu kaffaltii amanamaa keessan!`,
+		"sms.merchant_sender.failed": `Kabajamoo %[1]s,
+Maallaqni %[2].2f %[3]s gara %[4]s'tti baase hin milkoofne.
+Wabii: %[5]s
+txnid: %[6]s
+Guyyaa: %[7]s sa'aatii %[8]s
+
+SocialPay - Michuu kaffaltii amanamaa keessan!
+`,
+		"sms.merchant_recipient.success": `Kabajamoo %[1]s,
+Kaffaltii %[2].2f %[3]s %[4]s irraa fudhattaniittu.
+Wabii: %[5]s
+txnid: %[6]s
+Guyyaa: %[7]s sa'aatii %[8]s
+
+SocialPay - Michuu kaffaltii amanamaa keessan!
+`,
+		"sms.tipee.success": `Kabajamoo %[1]s,
+Tiippii %[2].2f %[3]s fudhattaniittu.
+Wabii: %[4]s
+Guyyaa: %[5]s sa'aatii %[6]s
+
+Tajaajila keessaniif galatoomaa!`,
+		"sms.default": `Daldala %[1]s: %[2].2f %[3]s
+Haala: %[4]s
+Wabii: %[5]s
+Guyyaa: %[6]s sa'aatii %[7]s`,
+	},
+}
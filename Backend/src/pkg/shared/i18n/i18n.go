@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Locale represents a supported user facing language
+type Locale string
+
+const (
+	English    Locale = "en"
+	Amharic    Locale = "am"
+	AfaanOromo Locale = "om"
+)
+
+// DefaultLocale is used whenever a requested locale is missing or unsupported
+const DefaultLocale = English
+
+// aliases maps common language tags and names to a supported locale
+var aliases = map[string]Locale{
+	"en":          English,
+	"eng":         English,
+	"english":     English,
+	"am":          Amharic,
+	"amh":         Amharic,
+	"amharic":     Amharic,
+	"om":          AfaanOromo,
+	"orm":         AfaanOromo,
+	"oromo":       AfaanOromo,
+	"afaan oromo": AfaanOromo,
+	"oromiffa":    AfaanOromo,
+}
+
+// SupportedLocales returns all locales that have a catalog
+func SupportedLocales() []Locale {
+	return []Locale{English, Amharic, AfaanOromo}
+}
+
+// IsSupported reports whether the given value resolves to a supported locale
+func IsSupported(value string) bool {
+	_, ok := lookup(value)
+	return ok
+}
+
+// Resolve returns the first candidate that maps to a supported locale.
+// Candidates are checked in order, so callers pass the most specific
+// preference first (e.g. query parameter, then merchant default).
+func Resolve(candidates ...string) Locale {
+	for _, candidate := range candidates {
+		if locale, ok := lookup(candidate); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// T returns the translated message for key, formatted with args.
+// Missing translations fall back to English and finally to the key itself.
+func T(locale Locale, key string, args ...interface{}) string {
+	message, ok := catalog[locale][key]
+	if !ok {
+		message, ok = catalog[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Messages returns every message under the given key prefix (e.g. "checkout.")
+// for the locale, with English used for any key the locale does not translate.
+func Messages(locale Locale, prefix string) map[string]string {
+	messages := make(map[string]string)
+	for key, message := range catalog[DefaultLocale] {
+		if strings.HasPrefix(key, prefix) {
+			messages[key] = message
+		}
+	}
+	for key, message := range catalog[locale] {
+		if strings.HasPrefix(key, prefix) {
+			messages[key] = message
+		}
+	}
+	return messages
+}
+
+func lookup(value string) (Locale, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "" {
+		return "", false
+	}
+	if locale, ok := aliases[normalized]; ok {
+		return locale, true
+	}
+	// Accept region tags such as am-ET or om_ET
+	if idx := strings.IndexAny(normalized, "-_"); idx > 0 {
+		if locale, ok := aliases[normalized[:idx]]; ok {
+			return locale, true
+		}
+	}
+	return "", false
+}
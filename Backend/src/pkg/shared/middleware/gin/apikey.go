@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/socialpay/socialpay/src/pkg/apikey_mgmt/core/entity"
 	"github.com/socialpay/socialpay/src/pkg/apikey_mgmt/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
 )
 
 const (
@@ -79,6 +80,12 @@ func APIKeyAuth(useCase usecase.APIKeyUseCase) gin.HandlerFunc {
 		// Store API key data in context
 		c.Set("apiKey", apiKeyData)
 		c.Set("userID", apiKeyData.UserID.String())
+		c.Request = c.Request.WithContext(entity.WithKeyID(c.Request.Context(), apiKeyData.ID))
+
+		// Test keys route payments to the simulator for the rest of the request
+		if apiKeyData.IsTest {
+			c.Request = c.Request.WithContext(payment.WithTestMode(c.Request.Context(), true))
+		}
 
 		fmt.Printf("[API-Key-Auth] Context keys set - Available keys: %v\n", c.Keys)
 
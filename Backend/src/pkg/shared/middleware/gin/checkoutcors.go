@@ -40,7 +40,7 @@ func CheckoutCors(c *gin.Context) {
 	}
 
 	c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+	c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-Device-ID")
 	c.Header("Access-Control-Allow-Credentials", "true")
 	c.Header("Access-Control-Max-Age", "86400")
 
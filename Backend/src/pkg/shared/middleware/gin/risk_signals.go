@@ -0,0 +1,16 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+)
+
+// RiskSignals records where a payment or withdrawal request came from, for the risk engine
+func RiskSignals() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		signals := riskEntity.NewSignals(c.ClientIP(), c.GetHeader(riskEntity.DeviceIDHeader), c.GetHeader(riskEntity.CountryHeader))
+		c.Request = c.Request.WithContext(riskEntity.WithSignals(c.Request.Context(), signals))
+		c.Next()
+	}
+}
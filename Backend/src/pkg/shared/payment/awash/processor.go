@@ -50,12 +50,11 @@ func NewProcessor(cfg ProcessorConfig) payment.Processor {
 
 	// BaseUlr is not defined
 	if cfg.BaseURL == "" {
-		cfg.BaseURL = os.Getenv("AWASH_TEST_BASE_URL")
+		cfg.BaseURL = os.Getenv("AWASH_BASE_URL")
 	}
 	// Callback Url
 	if cfg.CallbackURL == "" {
-		cfg.CallbackURL = os.Getenv("AWASH_TEST_CALLBACK_URL")
-
+		cfg.CallbackURL = os.Getenv("APP_URL_V2") + "/api/v2/settle/awash"
 	}
 
 	// Awash processor
@@ -208,15 +207,19 @@ func (p *processor) GetType() txEntity.TransactionMedium {
 	return txEntity.AWASH
 }
 
-// Todo remove
+// SettlePayment accepts approved callbacks; the Awash settlement handler normally settles inline
 func (p *processor) SettlePayment(ctx context.Context, req *payment.CallbackRequest) error {
+	if req.Status == txEntity.SUCCESS {
+		return nil
+	}
 
-	return nil
+	message, _ := req.Metadata["message"].(string)
+	return fmt.Errorf("payment failed: %s", message)
 }
 
 // To be implemented
 func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
-	return nil, nil
+	return nil, fmt.Errorf("Awash withdrawal: %w", payment.ErrNotSupported)
 }
 
 func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID string) (*payment.TransactionStatusQueryResponse, error) {
@@ -226,7 +229,7 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 		"transaction_id": transactionID,
 	})
 	// Building Url
-	url := fmt.Sprintf(`%v/%v`, p.baseURL, "/MerchantRS/DebitStatus")
+	url := fmt.Sprintf("%v/MerchantRS/DebitStatus", p.baseURL)
 	// Preparing body
 	body := map[string]interface{}{
 		"authorization": map[string]interface{}{
@@ -282,7 +285,10 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 
 	defer resp.Body.Close()
 
-	respBody, _ := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
 
 	p.log.Info("get this response for querytransactionstatus", map[string]interface{}{
 		"operations": "QueryTransactionStatus",
@@ -298,6 +304,8 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 			"body":      string(respBody),
 		})
 
+		// An unreadable answer says nothing about the payment; it must not fail it
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	var status txEntity.TransactionStatus
@@ -306,6 +314,8 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 		status = txEntity.SUCCESS
 	case "EXPIRED":
 		status = txEntity.EXPIRED
+	case "PENDING":
+		status = txEntity.PENDING
 	default:
 		status = txEntity.FAILED
 	}
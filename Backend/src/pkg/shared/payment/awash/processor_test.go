@@ -0,0 +1,61 @@
+package awash
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/paymenttest"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestProcessorConformance(t *testing.T) {
+	status := func(name, fixture string, want txEntity.TransactionStatus) paymenttest.StatusCase {
+		return paymenttest.StatusCase{
+			Name:     name,
+			Response: paymenttest.Exchange{Method: http.MethodPost, Path: "/MerchantRS/DebitStatus", Body: paymenttest.Fixture(t, fixture)},
+			Want:     want,
+		}
+	}
+
+	paymenttest.Run(t, paymenttest.Spec{
+		Medium: txEntity.AWASH,
+		New: func(t *testing.T, baseURL string) payment.Processor {
+			// The transaction repository is only read by the delayed fail fallback
+			return NewProcessor(ProcessorConfig{
+				MerchantID:         "test-merchant",
+				CredentialKey:      "test-password",
+				MerchantTillNumber: "123456",
+				IsTestMode:         true,
+				BaseURL:            baseURL,
+				CallbackURL:        "https://api.example.com/api/v2/settle/awash",
+			})
+		},
+		Initiate: paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/MerchantRS/DebitRequest",
+			Body:   paymenttest.Fixture(t, "debit_request_accepted.json"),
+		},
+		InitiateDeclined: paymenttest.Exchange{
+			Path: "/MerchantRS/DebitRequest",
+			Body: paymenttest.Fixture(t, "debit_request_declined.json"),
+		},
+		Status: []paymenttest.StatusCase{
+			status("approved", "debit_status_approved.json", txEntity.SUCCESS),
+			status("expired", "debit_status_expired.json", txEntity.EXPIRED),
+			status("pending", "debit_status_pending.json", txEntity.PENDING),
+			status("declined", "debit_status_declined.json", txEntity.FAILED),
+		},
+		Callbacks: []paymenttest.CallbackCase{
+			{
+				Name:    "approved",
+				Request: payment.CallbackRequest{Status: txEntity.SUCCESS, Metadata: map[string]interface{}{"message": "Success"}},
+				Settled: true,
+			},
+			{
+				Name:    "rejected by payer",
+				Request: payment.CallbackRequest{Status: txEntity.FAILED, Metadata: map[string]interface{}{"message": "Payer rejected the request"}},
+			},
+		},
+	})
+}
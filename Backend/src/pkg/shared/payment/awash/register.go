@@ -0,0 +1,42 @@
+package awash
+
+import (
+	"fmt"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func init() {
+	registry.Register(registry.Factory{
+		Medium:          txEntity.AWASH,
+		EnvPrefix:       "AWASH",
+		Credentials:     []string{"merchant_code", "password", "till_number"},
+		RequiresBaseURL: true,
+		CallbackPath:    "/settle/awash",
+		// Deployments configured before the registry used the sandbox variable names
+		EnvAliases: map[string]string{
+			"AWASH_MERCHANT_CODE": "AWASH_TEST_MERCHANT_CODE",
+			"AWASH_PASSWORD":      "AWASH_TEST_PASSWORD",
+			"AWASH_TILL_NUMBER":   "AWASH_TEST_TIN_NUMBER",
+			"AWASH_BASE_URL":      "AWASH_TEST_BASE_URL",
+			"AWASH_CALLBACK_URL":  "AWASH_TEST_CALLBACK_URL",
+		},
+		New: func(s registry.Settings, deps registry.Dependencies) (payment.Processor, error) {
+			// Failed payments are settled through the transaction repository
+			if deps.TxnRepository == nil {
+				return nil, fmt.Errorf("awash processor needs a transaction repository")
+			}
+			return NewProcessor(ProcessorConfig{
+				MerchantID:         s.Credentials["merchant_code"],
+				CredentialKey:      s.Credentials["password"],
+				MerchantTillNumber: s.Credentials["till_number"],
+				IsTestMode:         s.TestMode,
+				BaseURL:            s.BaseURL,
+				CallbackURL:        s.CallbackURL,
+				TxnRepository:      deps.TxnRepository,
+			}), nil
+		},
+	})
+}
@@ -53,6 +53,9 @@ func NewProcessor(config ProcessorConfig) payment.Processor {
 	if config.BaseURL == "" {
 		config.BaseURL = os.Getenv("CBE_BASE_URL")
 	}
+	if config.CallbackURL == "" {
+		config.CallbackURL = os.Getenv("APP_URL_V2") + "/api/v2/settle/std"
+	}
 	fmt.Println("config.MerchantID", config.MerchantID)
 	return &processor{
 		merchantID:    config.MerchantID,
@@ -78,7 +81,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 		"amount":        req.Amount,
 		"description":   "Deposit Request from " + req.PhoneNumber + " ID: " + req.TransactionID.String(),
 		"referenceId":   req.TransactionID.String(),
-		"callbackUrl":   os.Getenv("APP_URL_V2") + "/api/v2/settle/std",
+		"callbackUrl":   p.callbackURL,
 		"phoneNumber":   req.PhoneNumber,
 		"merchantId":    p.merchantID,
 		"merchantKey":   p.merchantKey,
@@ -241,7 +244,7 @@ func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *
 		"amount":        req.Amount,
 		"description":   "Withdrawal Request from " + req.PhoneNumber + " ID: " + req.TransactionID.String(),
 		"referenceId":   req.TransactionID.String(),
-		"callbackUrl":   os.Getenv("APP_URL_V2") + "/api/v2/settle/std",
+		"callbackUrl":   p.callbackURL,
 		"recipientId":   req.PhoneNumber,
 		"merchantId":    p.merchantID,
 		"merchantKey":   p.merchantKey,
@@ -451,8 +454,7 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 	switch httpReqParsed.Status {
 	case "Completed":
 		status = txEntity.SUCCESS
-	case "Failed":
-	case "FAILED":
+	case "Failed", "FAILED":
 		status = txEntity.FAILED
 	case "Pending":
 		status = txEntity.PENDING
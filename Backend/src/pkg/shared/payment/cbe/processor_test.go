@@ -0,0 +1,83 @@
+package cbe
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/paymenttest"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestProcessorConformance(t *testing.T) {
+	paymenttest.Run(t, paymenttest.Spec{
+		Medium: txEntity.CBE,
+		New: func(t *testing.T, baseURL string) payment.Processor {
+			return NewProcessor(ProcessorConfig{
+				MerchantID:    "test-merchant",
+				MerchantKey:   "test-key",
+				TerminalID:    "test-terminal",
+				CredentialKey: "test-credential",
+				IsTestMode:    true,
+				BaseURL:       baseURL,
+				CallbackURL:   "https://api.example.com/api/v2/settle/std",
+			})
+		},
+		Initiate: paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/api/v1/payments/create",
+			Body:   paymenttest.Fixture(t, "payment_create_accepted.json"),
+		},
+		InitiateDeclined: paymenttest.Exchange{
+			Path:   "/api/v1/payments/create",
+			Status: http.StatusBadRequest,
+			Body:   paymenttest.Fixture(t, "payment_create_declined.json"),
+		},
+		Withdrawal: &paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/api/v1/withdrawals/create",
+			Body:   paymenttest.Fixture(t, "withdrawal_create_accepted.json"),
+		},
+		WithdrawalDeclined: &paymenttest.Exchange{
+			Path: "/api/v1/withdrawals/create",
+			Body: paymenttest.Fixture(t, "withdrawal_create_declined.json"),
+		},
+		Status: []paymenttest.StatusCase{
+			{
+				Name:     "completed",
+				Response: paymenttest.Exchange{Method: http.MethodPost, Path: "/api/v1/transaction/status", Body: paymenttest.Fixture(t, "status_completed.json")},
+				Want:     txEntity.SUCCESS,
+			},
+			{
+				Name:     "failed",
+				Response: paymenttest.Exchange{Path: "/api/v1/transaction/status", Body: paymenttest.Fixture(t, "status_failed.json")},
+				Want:     txEntity.FAILED,
+			},
+			{
+				Name:     "pending",
+				Response: paymenttest.Exchange{Path: "/api/v1/transaction/status", Body: paymenttest.Fixture(t, "status_pending.json")},
+				Want:     txEntity.PENDING,
+			},
+		},
+		Callbacks: []paymenttest.CallbackCase{
+			{
+				Name: "success",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{
+					"referenceId":  "6f1c2d3e-4b5a-4c6d-8e7f-9a0b1c2d3e4f",
+					"status":       "SUCCESS",
+					"message":      "Transaction completed",
+					"providerTxId": "FT24292XK7QW",
+				}},
+				Settled: true,
+			},
+			{
+				Name: "failure",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{
+					"referenceId": "6f1c2d3e-4b5a-4c6d-8e7f-9a0b1c2d3e4f",
+					"status":      "FAILURE",
+					"message":     "Customer cancelled the request",
+				}},
+			},
+		},
+	})
+}
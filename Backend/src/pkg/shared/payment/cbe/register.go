@@ -0,0 +1,28 @@
+package cbe
+
+import (
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func init() {
+	registry.Register(registry.Factory{
+		Medium:          txEntity.CBE,
+		EnvPrefix:       "CBE",
+		Credentials:     []string{"merchant_id", "merchant_key", "terminal_id", "credential_key"},
+		RequiresBaseURL: true,
+		CallbackPath:    "/settle/std",
+		New: func(s registry.Settings, _ registry.Dependencies) (payment.Processor, error) {
+			return NewProcessor(ProcessorConfig{
+				MerchantID:    s.Credentials["merchant_id"],
+				MerchantKey:   s.Credentials["merchant_key"],
+				TerminalID:    s.Credentials["terminal_id"],
+				CredentialKey: s.Credentials["credential_key"],
+				IsTestMode:    s.TestMode,
+				BaseURL:       s.BaseURL,
+				CallbackURL:   s.CallbackURL,
+			}), nil
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+)
+
+// ProcessorHandler lists the payment processors configured on this instance
+type ProcessorHandler struct {
+	registry      *registry.Registry
+	jwtMiddleware gin.HandlerFunc
+	rbac          *ginMiddleware.RBACV2
+}
+
+func NewProcessorHandler(registry *registry.Registry, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *ProcessorHandler {
+	return &ProcessorHandler{
+		registry:      registry,
+		jwtMiddleware: jwtMiddleware,
+		rbac:          rbac,
+	}
+}
+
+// ProcessorListResponse is the admin listing of configured processors
+type ProcessorListResponse struct {
+	Processors []registry.ProcessorInfo `json:"processors"`
+}
+
+func (h *ProcessorHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin/processors", h.jwtMiddleware)
+	admin.GET("",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_PROCESSOR_HEALTH, auth_entity.OPERATION_ADMIN_READ),
+		h.ListProcessors)
+}
+
+// @Summary List payment processors
+// @Description Configuration of every registered payment processor: whether it is enabled, test mode, endpoints and which credentials are set. Credential values are never returned.
+// @Tags Admin Processor Health
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ProcessorListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/processors [get]
+func (h *ProcessorHandler) ListProcessors(c *gin.Context) {
+	c.JSON(http.StatusOK, ProcessorListResponse{Processors: h.registry.List()})
+}
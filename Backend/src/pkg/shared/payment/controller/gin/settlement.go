@@ -460,7 +460,14 @@ func (h *SettlementHandler) HandleCybersourceSettlement(c *gin.Context) {
 	transactionID := c.Request.Form.Get("req_transaction_uuid")
 	reasonCode := c.Request.Form.Get("reason_code")
 	decision := c.Request.Form.Get("decision")
+	transactionType := c.Request.Form.Get("req_transaction_type")
 	rawBody := c.Request.PostForm.Encode()
+
+	// Cybersource request ID, needed to capture or void an authorization
+	providerTxID := c.Request.Form.Get("transaction_id")
+	if providerTxID == "" {
+		providerTxID = transactionID
+	}
 	parsedTransactionID, err := uuid.Parse(transactionID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID format"})
@@ -488,10 +495,12 @@ func (h *SettlementHandler) HandleCybersourceSettlement(c *gin.Context) {
 	// Create generic callback request
 	callbackReq := &payment.CallbackRequest{
 		TransactionID: parsedTransactionID,
+		ProcessorRef:  providerTxID,
 		Status:        transactionStatus,
 		Metadata: map[string]interface{}{
-			"reason_code": reasonCode,
-			"decision":    decision,
+			"reason_code":      reasonCode,
+			"decision":         decision,
+			"transaction_type": transactionType,
 		},
 	}
 
@@ -501,12 +510,17 @@ func (h *SettlementHandler) HandleCybersourceSettlement(c *gin.Context) {
 		return
 	}
 
+	// An accepted authorization only holds the funds; the wallet is credited on capture
+	if callbackReq.Status == txEntity.SUCCESS && transactionType == "authorization" {
+		callbackReq.Status = txEntity.AUTHORIZED
+	}
+
 	// Dispatch webhook
 	h.usecase.HandleWebhookDispatch(c.Request.Context(), dto.WebhookRequest{
 		TransactionID: callbackReq.TransactionID.String(),
 		Status:        string(callbackReq.Status),
 		Message:       decision,
-		ProviderTxID:  transactionID,
+		ProviderTxID:  providerTxID,
 		ProviderData:  rawBody,
 		Timestamp:     time.Now(),
 	})
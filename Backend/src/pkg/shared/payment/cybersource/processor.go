@@ -7,7 +7,9 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,13 +19,27 @@ import (
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 )
 
+// defaultAuthValidity is how long an authorization is held before it is voided.
+// Card networks keep most card-not-present authorizations for seven days.
+const defaultAuthValidity = 7 * 24 * time.Hour
+
+// Payment forms are written to the directory served under /api/v2/static
+const (
+	defaultFormDir = "./public"
+	defaultFormURL = "https://api.socialpay.co/api/v2/static"
+)
+
 type processor struct {
-	accessKey  string
-	profileID  string
-	secretKey  string
-	isTestMode bool
-	baseURL    string
-	log        logging.Logger
+	accessKey    string
+	profileID    string
+	secretKey    string
+	isTestMode   bool
+	baseURL      string
+	rest         *restClient
+	authValidity time.Duration
+	formDir      string
+	formURL      string
+	log          logging.Logger
 }
 
 // ProcessorConfig holds the configuration for Cybersource processor
@@ -32,6 +48,20 @@ type ProcessorConfig struct {
 	ProfileID  string
 	SecretKey  string
 	IsTestMode bool
+	BaseURL    string
+
+	// REST API credentials used to capture and void authorizations
+	MerchantID    string
+	RestKeyID     string
+	RestSecretKey string
+	RestBaseURL   string
+
+	// AuthValidity overrides how long authorizations are held before being voided
+	AuthValidity time.Duration
+
+	// FormDir is where payment forms are written and FormURL is where that directory is served
+	FormDir string
+	FormURL string
 }
 
 // NewProcessor creates a new Cybersource payment processor
@@ -46,7 +76,10 @@ func NewProcessor(config ProcessorConfig) payment.Processor {
 		config.SecretKey = os.Getenv("CYBERSOURCE_SECRET_KEY")
 	}
 
-	baseURL := os.Getenv("CYBERSOURCE_BASE_URL")
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = os.Getenv("CYBERSOURCE_BASE_URL")
+	}
 	if baseURL == "" {
 		if config.IsTestMode {
 			baseURL = "https://testsecureacceptance.cybersource.com"
@@ -55,16 +88,55 @@ func NewProcessor(config ProcessorConfig) payment.Processor {
 		}
 	}
 
+	if config.MerchantID == "" {
+		config.MerchantID = os.Getenv("CYBERSOURCE_MERCHANT_ID")
+	}
+	if config.RestKeyID == "" {
+		config.RestKeyID = os.Getenv("CYBERSOURCE_REST_KEY_ID")
+	}
+	if config.RestSecretKey == "" {
+		config.RestSecretKey = os.Getenv("CYBERSOURCE_REST_SECRET_KEY")
+	}
+	if config.RestBaseURL == "" {
+		config.RestBaseURL = os.Getenv("CYBERSOURCE_REST_BASE_URL")
+	}
+	if config.RestBaseURL == "" {
+		if config.IsTestMode {
+			config.RestBaseURL = "https://apitest.cybersource.com"
+		} else {
+			config.RestBaseURL = "https://api.cybersource.com"
+		}
+	}
+
+	if config.AuthValidity <= 0 {
+		config.AuthValidity = defaultAuthValidity
+		if hours, err := strconv.Atoi(os.Getenv("CYBERSOURCE_AUTH_VALIDITY_HOURS")); err == nil && hours > 0 {
+			config.AuthValidity = time.Duration(hours) * time.Hour
+		}
+	}
+
+	if config.FormDir == "" {
+		config.FormDir = defaultFormDir
+	}
+	if config.FormURL == "" {
+		config.FormURL = defaultFormURL
+	}
+
 	return &processor{
-		accessKey:  config.AccessKey,
-		profileID:  config.ProfileID,
-		secretKey:  config.SecretKey,
-		isTestMode: config.IsTestMode,
-		baseURL:    baseURL,
-		log:        logging.NewStdLogger("[CYBERSOURCE] [PROCESSOR]"),
+		accessKey:    config.AccessKey,
+		profileID:    config.ProfileID,
+		secretKey:    config.SecretKey,
+		isTestMode:   config.IsTestMode,
+		baseURL:      baseURL,
+		rest:         newRestClient(config.RestBaseURL, config.MerchantID, config.RestKeyID, config.RestSecretKey),
+		authValidity: config.AuthValidity,
+		formDir:      config.FormDir,
+		formURL:      strings.TrimRight(config.FormURL, "/"),
+		log:          logging.NewStdLogger("[CYBERSOURCE] [PROCESSOR]"),
 	}
 }
 
+// InitiatePayment charges the card in a single step through Secure Acceptance
 func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
 	p.log.Info("Initiating Cybersource payment", map[string]interface{}{
 		"transaction_id": req.TransactionID,
@@ -72,6 +144,33 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 		"currency":       req.Currency,
 	})
 
+	return p.createPaymentForm(req, "sale")
+}
+
+// Authorize places a hold on the card through Secure Acceptance; the funds are
+// collected with Capture or released with Void
+func (p *processor) Authorize(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
+	p.log.Info("Initiating Cybersource authorization", map[string]interface{}{
+		"transaction_id": req.TransactionID,
+		"amount":         req.Amount,
+		"currency":       req.Currency,
+	})
+
+	resp, err := p.createPaymentForm(req, "authorization")
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(p.authValidity)
+	resp.AuthorizationExpiresAt = &expiresAt
+	resp.Message = "Redirect user to payment URL to authorize the card"
+
+	return resp, nil
+}
+
+// createPaymentForm writes the signed Secure Acceptance form the payer is redirected to
+func (p *processor) createPaymentForm(req *payment.PaymentRequest, transactionType string) (*payment.PaymentResponse, error) {
+
 	SIGNED_FIELD_NAMES := []string{
 		"access_key",
 		"amount",
@@ -118,7 +217,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 		"reference_number":            deviceFingerprint.String(),
 		"signed_date_time":            time.Now().UTC().Format("2006-01-02T15:04:05Z"),
 		"signed_field_names":          strings.Join(SIGNED_FIELD_NAMES, ","),
-		"transaction_type":            "authorization",
+		"transaction_type":            transactionType,
 		"transaction_uuid":            req.TransactionID.String(),
 		"unsigned_field_names":        "",
 	}
@@ -141,7 +240,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 	p.log.Info("Generated Signature", map[string]interface{}{
 		"signature":            signature,
 		"signed_fields":        strings.Join(encodedFields, ","),
-		"secret_key_truncated": p.secretKey[:4] + "..." + p.secretKey[len(p.secretKey)-4:],
+		"secret_key_truncated": maskSecret(p.secretKey),
 	})
 
 	// Create HTML form
@@ -212,7 +311,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 
 	// Save the HTML file
 	fileName := fmt.Sprintf("%s.html", req.TransactionID)
-	filePath := fmt.Sprintf("./public/%s", fileName)
+	filePath := filepath.Join(p.formDir, fileName)
 	if err := os.WriteFile(filePath, []byte(htmlContent), 0666); err != nil {
 		p.log.Error("Failed to create payment form", map[string]interface{}{
 			"error": err.Error(),
@@ -222,7 +321,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 	}
 
 	// Return the URL to the static file
-	paymentURL := fmt.Sprintf("%s/api/v2/static/%s", "https://api.socialpay.co", fileName)
+	paymentURL := fmt.Sprintf("%s/%s", p.formURL, fileName)
 
 	p.log.Info("Payment form created", map[string]interface{}{
 		"url":            paymentURL,
@@ -285,7 +384,15 @@ func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *
 	p.log.Error("Withdrawal not supported", map[string]interface{}{
 		"processor": "Cybersource",
 	})
-	return nil, fmt.Errorf("withdrawal not supported for Cybersource")
+	return nil, fmt.Errorf("Cybersource withdrawal: %w", payment.ErrNotSupported)
+}
+
+// maskSecret keeps the ends of a secret for log correlation; short secrets are hidden entirely
+func maskSecret(secret string) string {
+	if len(secret) < 12 {
+		return "****"
+	}
+	return secret[:4] + "..." + secret[len(secret)-4:]
 }
 
 func sign(fields map[string]string, secretKey string) string {
@@ -339,5 +446,5 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 	p.log.Info("Querying Cybersource transaction status", map[string]interface{}{
 		"transaction_id": transactionID,
 	})
-	return nil, nil
+	return nil, fmt.Errorf("Cybersource transaction status query: %w", payment.ErrNotSupported)
 }
@@ -0,0 +1,82 @@
+package cybersource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/paymenttest"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const testFormURL = "https://api.example.com/api/v2/static"
+
+func newTestProcessor(t *testing.T, baseURL, formDir string) payment.Processor {
+	return NewProcessor(ProcessorConfig{
+		AccessKey:   "test-access-key",
+		ProfileID:   "test-profile",
+		SecretKey:   "short",
+		IsTestMode:  true,
+		BaseURL:     baseURL,
+		RestBaseURL: baseURL,
+		FormDir:     formDir,
+		FormURL:     testFormURL,
+	})
+}
+
+// Secure Acceptance payments start from a signed form served by us, so the provider
+// is only reached when the payer submits it
+func TestProcessorConformance(t *testing.T) {
+	paymenttest.Run(t, paymenttest.Spec{
+		Medium: txEntity.CYBERSOURCE,
+		New: func(t *testing.T, baseURL string) payment.Processor {
+			return newTestProcessor(t, baseURL, t.TempDir())
+		},
+		Callbacks: []paymenttest.CallbackCase{
+			{
+				Name:    "accepted",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{"decision": "ACCEPT", "reason_code": "100"}},
+				Settled: true,
+			},
+			{
+				Name:    "accepted for review",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{"decision": "ACCEPT", "reason_code": "480"}},
+			},
+			{
+				Name:    "declined",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{"decision": "DECLINE", "reason_code": "481"}},
+			},
+			{
+				Name:    "cancelled by payer",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{"decision": "CANCEL"}},
+			},
+		},
+	})
+}
+
+func TestPaymentFormIsServedFromFormDir(t *testing.T) {
+	dir := t.TempDir()
+	processor := newTestProcessor(t, "https://testsecureacceptance.example.com", dir)
+
+	req := &payment.PaymentRequest{TransactionID: uuid.New(), Amount: 150.75, Currency: "ETB"}
+	resp, err := processor.InitiatePayment(context.Background(), "", req)
+	if err != nil {
+		t.Fatalf("InitiatePayment() error = %v", err)
+	}
+
+	fileName := req.TransactionID.String() + ".html"
+	if want := testFormURL + "/" + fileName; resp.PaymentURL != want {
+		t.Fatalf("payment url = %s, want %s", resp.PaymentURL, want)
+	}
+	form, err := os.ReadFile(filepath.Join(dir, fileName))
+	if err != nil {
+		t.Fatalf("payment form was not written: %v", err)
+	}
+	if !strings.Contains(string(form), `action="https://testsecureacceptance.example.com/embedded/pay"`) {
+		t.Fatalf("payment form does not post to the configured endpoint")
+	}
+}
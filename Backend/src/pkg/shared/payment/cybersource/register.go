@@ -0,0 +1,41 @@
+package cybersource
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func init() {
+	registry.Register(registry.Factory{
+		Medium:      txEntity.CYBERSOURCE,
+		EnvPrefix:   "CYBERSOURCE",
+		Credentials: []string{"access_key", "profile_id", "secret_key"},
+		Options:     []string{"merchant_id", "rest_key_id", "rest_secret_key", "rest_base_url", "auth_validity_hours"},
+		New: func(s registry.Settings, _ registry.Dependencies) (payment.Processor, error) {
+			config := ProcessorConfig{
+				AccessKey:     s.Credentials["access_key"],
+				ProfileID:     s.Credentials["profile_id"],
+				SecretKey:     s.Credentials["secret_key"],
+				IsTestMode:    s.TestMode,
+				BaseURL:       s.BaseURL,
+				MerchantID:    s.Options["merchant_id"],
+				RestKeyID:     s.Options["rest_key_id"],
+				RestSecretKey: s.Options["rest_secret_key"],
+				RestBaseURL:   s.Options["rest_base_url"],
+			}
+			if raw := s.Options["auth_validity_hours"]; raw != "" {
+				hours, err := strconv.Atoi(raw)
+				if err != nil || hours <= 0 {
+					return nil, fmt.Errorf("CYBERSOURCE_AUTH_VALIDITY_HOURS must be a positive number of hours")
+				}
+				config.AuthValidity = time.Duration(hours) * time.Hour
+			}
+			return NewProcessor(config), nil
+		},
+	})
+}
@@ -0,0 +1,246 @@
+package cybersource
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// restClient calls the Cybersource REST payments API using HTTP signature authentication
+type restClient struct {
+	baseURL    string
+	merchantID string
+	keyID      string
+	secretKey  string
+	client     *http.Client
+}
+
+func newRestClient(baseURL, merchantID, keyID, secretKey string) *restClient {
+	return &restClient{
+		baseURL:    baseURL,
+		merchantID: merchantID,
+		keyID:      keyID,
+		secretKey:  secretKey,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type clientReferenceInformation struct {
+	Code string `json:"code"`
+}
+
+type amountDetails struct {
+	TotalAmount string `json:"totalAmount"`
+	Currency    string `json:"currency,omitempty"`
+}
+
+type captureRequest struct {
+	ClientReferenceInformation clientReferenceInformation `json:"clientReferenceInformation"`
+	OrderInformation           struct {
+		AmountDetails amountDetails `json:"amountDetails"`
+	} `json:"orderInformation"`
+}
+
+type reversalRequest struct {
+	ClientReferenceInformation clientReferenceInformation `json:"clientReferenceInformation"`
+	ReversalInformation        struct {
+		AmountDetails amountDetails `json:"amountDetails"`
+		Reason        string        `json:"reason,omitempty"`
+	} `json:"reversalInformation"`
+}
+
+type restResponse struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// Capture collects all or part of an authorization through the REST API
+func (p *processor) Capture(ctx context.Context, req *payment.CaptureRequest) (*payment.PaymentResponse, error) {
+	p.log.Info("Capturing Cybersource authorization", map[string]interface{}{
+		"transaction_id": req.TransactionID,
+		"processor_ref":  req.ProcessorRef,
+		"amount":         req.Amount,
+	})
+
+	if req.ProcessorRef == "" {
+		return nil, fmt.Errorf("missing Cybersource authorization reference")
+	}
+
+	var body captureRequest
+	body.ClientReferenceInformation.Code = req.TransactionID.String()
+	body.OrderInformation.AmountDetails = amountDetails{
+		TotalAmount: fmt.Sprintf("%.2f", req.Amount),
+		Currency:    req.Currency,
+	}
+
+	resp, err := p.rest.post(ctx, "/pts/v2/payments/"+url.PathEscape(req.ProcessorRef)+"/captures", body)
+	if err != nil {
+		p.log.Error("Cybersource capture failed", map[string]interface{}{
+			"transaction_id": req.TransactionID,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("failed to capture authorization: %w", err)
+	}
+
+	// Captures are batched for settlement, so PENDING is the accepted outcome
+	if resp.Status != "PENDING" && resp.Status != "TRANSMITTED" {
+		return nil, fmt.Errorf("capture rejected: %s %s", resp.Status, resp.Message)
+	}
+
+	return &payment.PaymentResponse{
+		TransactionID: req.TransactionID,
+		Success:       true,
+		Status:        txEntity.SUCCESS,
+		Message:       "Authorization captured",
+		ProcessorRef:  resp.ID,
+		Metadata: map[string]interface{}{
+			"capture_status": resp.Status,
+		},
+	}, nil
+}
+
+// Void reverses an authorization that was not captured through the REST API
+func (p *processor) Void(ctx context.Context, req *payment.VoidRequest) (*payment.PaymentResponse, error) {
+	p.log.Info("Reversing Cybersource authorization", map[string]interface{}{
+		"transaction_id": req.TransactionID,
+		"processor_ref":  req.ProcessorRef,
+		"reason":         req.Reason,
+	})
+
+	if req.ProcessorRef == "" {
+		return nil, fmt.Errorf("missing Cybersource authorization reference")
+	}
+
+	var body reversalRequest
+	body.ClientReferenceInformation.Code = req.TransactionID.String()
+	body.ReversalInformation.AmountDetails = amountDetails{
+		TotalAmount: fmt.Sprintf("%.2f", req.Amount),
+		Currency:    req.Currency,
+	}
+	body.ReversalInformation.Reason = req.Reason
+
+	resp, err := p.rest.post(ctx, "/pts/v2/payments/"+url.PathEscape(req.ProcessorRef)+"/reversals", body)
+	if err != nil {
+		p.log.Error("Cybersource reversal failed", map[string]interface{}{
+			"transaction_id": req.TransactionID,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("failed to void authorization: %w", err)
+	}
+
+	if resp.Status != "REVERSED" {
+		return nil, fmt.Errorf("void rejected: %s %s", resp.Status, resp.Message)
+	}
+
+	return &payment.PaymentResponse{
+		TransactionID: req.TransactionID,
+		Success:       true,
+		Status:        txEntity.CANCELED,
+		Message:       "Authorization voided",
+		ProcessorRef:  resp.ID,
+	}, nil
+}
+
+// post sends a signed JSON request and decodes the Cybersource response
+func (c *restClient) post(ctx context.Context, path string, body interface{}) (*restResponse, error) {
+	if c.merchantID == "" || c.keyID == "" || c.secretKey == "" {
+		return nil, fmt.Errorf("Cybersource REST credentials are not configured")
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Cybersource URL: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	digest := sha256.Sum256(payload)
+	headers := map[string]string{
+		"host":             endpoint.Host,
+		"date":             time.Now().UTC().Format(http.TimeFormat),
+		"(request-target)": "post " + endpoint.RequestURI(),
+		"digest":           "SHA-256=" + base64.StdEncoding.EncodeToString(digest[:]),
+		"v-c-merchant-id":  c.merchantID,
+	}
+
+	signature, err := c.signature(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Date", headers["date"])
+	httpReq.Header.Set("Digest", headers["digest"])
+	httpReq.Header.Set("v-c-merchant-id", c.merchantID)
+	httpReq.Header.Set("Signature", signature)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result restResponse
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("Cybersource returned %d: %s %s", resp.StatusCode, result.Reason, result.Message)
+	}
+
+	return &result, nil
+}
+
+// signatureHeaders is the order the headers are signed in
+var signatureHeaders = []string{"host", "date", "(request-target)", "digest", "v-c-merchant-id"}
+
+// signature builds the HTTP signature header with the shared secret key
+func (c *restClient) signature(headers map[string]string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(c.secretKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid Cybersource REST secret key: %w", err)
+	}
+
+	var signingString bytes.Buffer
+	for i, name := range signatureHeaders {
+		if i > 0 {
+			signingString.WriteString("\n")
+		}
+		signingString.WriteString(name + ": " + headers[name])
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write(signingString.Bytes())
+
+	return fmt.Sprintf(`keyid="%s", algorithm="HmacSHA256", headers="host date (request-target) digest v-c-merchant-id", signature="%s"`,
+		c.keyID, base64.StdEncoding.EncodeToString(h.Sum(nil))), nil
+}
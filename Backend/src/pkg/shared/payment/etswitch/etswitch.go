@@ -59,6 +59,7 @@ func NewEtSwitchProcessor(cfg ProcessorConfig) payment.Processor {
 	return &processor{
 		userName:    cfg.UserName,
 		credentials: cfg.Credential,
+		isTestMode:  cfg.IsTestMode,
 		baseURL:     cfg.BaseURL,
 		retunUrl:    cfg.RetunUrl,
 		log:         logging.NewStdLogger("ETHSWITH_PROCESSOR_LOG"),
@@ -121,7 +122,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 	// Send the request
 	resp, err := client.Do(getReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to contact EthSwitch: %w", err.Error())
+		return nil, fmt.Errorf("failed to contact EthSwitch: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -226,7 +227,7 @@ func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *
 	p.log.Error("Withdrawal not supported", map[string]interface{}{
 		"processor": "EthSwitch",
 	})
-	return nil, fmt.Errorf("withdrawal not supported for EthSwitch")
+	return nil, fmt.Errorf("EthSwitch withdrawal: %w", payment.ErrNotSupported)
 }
 
 // MapTransactionIDToOrderNumber maps a UUID to an AN1.32-compatible string (for EthSwitch)
@@ -310,7 +311,14 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 	}
 
 	// Mapping the response status
-	status := MapCodeToOrderStatus[Transaction.Status]
+	status, ok := MapCodeToOrderStatus[Transaction.Status]
+	if !ok {
+		p.log.Warn("Unknown EthSwitch order status, keeping transaction pending", map[string]interface{}{
+			"order_status":   Transaction.Status,
+			"transaction_id": transactionID,
+		})
+		status = txEntity.PENDING
+	}
 
 	p.log.Info("Transaction status :-", map[string]interface{}{
 		"status": status,
@@ -0,0 +1,65 @@
+package etswitch
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/paymenttest"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestProcessorConformance(t *testing.T) {
+	status := func(name, fixture string, want txEntity.TransactionStatus) paymenttest.StatusCase {
+		return paymenttest.StatusCase{
+			Name:     name,
+			Response: paymenttest.Exchange{Method: http.MethodGet, Path: "/getOrderStatus.do", Body: paymenttest.Fixture(t, fixture)},
+			Want:     want,
+		}
+	}
+
+	paymenttest.Run(t, paymenttest.Spec{
+		Medium: txEntity.ETHSWITCH,
+		New: func(t *testing.T, baseURL string) payment.Processor {
+			return NewEtSwitchProcessor(ProcessorConfig{
+				UserName:   "test-user",
+				Credential: "test-password",
+				BaseURL:    baseURL,
+				RetunUrl:   "https://checkout.example.com",
+				IsTestMode: true,
+			})
+		},
+		Initiate: paymenttest.Exchange{
+			Method: http.MethodGet,
+			Path:   "/register.do",
+			Body:   paymenttest.Fixture(t, "register_accepted.json"),
+		},
+		InitiateDeclined: paymenttest.Exchange{
+			Path: "/register.do",
+			Body: paymenttest.Fixture(t, "register_declined.json"),
+		},
+		Status: []paymenttest.StatusCase{
+			status("deposited", "order_status_deposited.json", txEntity.SUCCESS),
+			status("registered", "order_status_registered.json", txEntity.PENDING),
+			status("3-D Secure in progress", "order_status_acs_auth.json", txEntity.PENDING),
+			status("reversed", "order_status_reversed.json", txEntity.CANCELED),
+			status("refunded", "order_status_refunded.json", txEntity.REFUNDED),
+			status("declined", "order_status_declined.json", txEntity.FAILED),
+		},
+		Callbacks: []paymenttest.CallbackCase{
+			{
+				Name:    "deposited",
+				Request: payment.CallbackRequest{Status: txEntity.SUCCESS, Metadata: map[string]interface{}{"reason_code": "1", "decision": txEntity.SUCCESS}},
+				Settled: true,
+			},
+			{
+				Name:    "declined",
+				Request: payment.CallbackRequest{Status: txEntity.FAILED, Metadata: map[string]interface{}{"reason_code": "0", "decision": txEntity.FAILED}},
+			},
+			{
+				Name:    "canceled",
+				Request: payment.CallbackRequest{Status: txEntity.CANCELED, Metadata: map[string]interface{}{"reason_code": "1", "decision": txEntity.CANCELED}},
+			},
+		},
+	})
+}
@@ -32,8 +32,11 @@ type EthSwitchResponse struct {
 }
 
 var MapCodeToOrderStatus = map[int]txEntity.TransactionStatus{
-	0: txEntity.PENDING,
-	2: txEntity.SUCCESS,
+	0: txEntity.PENDING,  // registered, not paid
+	1: txEntity.PENDING,  // pre-authorized
+	2: txEntity.SUCCESS,  // deposited
+	3: txEntity.CANCELED, // authorization reversed
 	4: txEntity.REFUNDED,
+	5: txEntity.PENDING, // 3-D Secure authentication started
 	6: txEntity.FAILED,
 }
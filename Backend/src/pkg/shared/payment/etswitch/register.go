@@ -0,0 +1,29 @@
+package etswitch
+
+import (
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func init() {
+	registry.Register(registry.Factory{
+		Medium:          txEntity.ETHSWITCH,
+		EnvPrefix:       "ETHSWITCH",
+		Credentials:     []string{"username", "password"},
+		Options:         []string{"return_url"},
+		RequiresBaseURL: true,
+		EnvAliases: map[string]string{
+			"ETHSWITCH_RETURN_URL": "APP_CHECKOUT_URL",
+		},
+		New: func(s registry.Settings, _ registry.Dependencies) (payment.Processor, error) {
+			return NewEtSwitchProcessor(ProcessorConfig{
+				UserName:   s.Credentials["username"],
+				Credential: s.Credentials["password"],
+				BaseURL:    s.BaseURL,
+				RetunUrl:   s.Options["return_url"],
+				IsTestMode: s.TestMode,
+			}), nil
+		},
+	})
+}
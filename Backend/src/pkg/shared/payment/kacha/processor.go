@@ -107,6 +107,9 @@ func NewProcessor(config ProcessorConfig) payment.Processor {
 			config.BaseURL = "https://kacha-sv.socialpay.co"
 		}
 	}
+	if config.CallbackURL == "" {
+		config.CallbackURL = os.Getenv("APP_URL_V2") + "/api/v2/settle/std"
+	}
 
 	return &processor{
 		isTestMode:  config.IsTestMode,
@@ -126,7 +129,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 
 	// Prepare Kacha specific request
 	kachaReq := map[string]interface{}{
-		"callback_url": req.CallbackURL,
+		"callback_url": p.callbackURL,
 		"phone":        req.PhoneNumber,
 		"amount":       req.Amount,
 		"trace_number": req.TransactionID.String(),
@@ -210,7 +213,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 	}
 
 	return &payment.PaymentResponse{
-		Success:       kachaResp.Success,
+		Success:       status != txEntity.FAILED,
 		TransactionID: req.TransactionID,
 		Status:        status,
 		ProcessorRef:  kachaResp.Data.Reference,
@@ -427,7 +430,7 @@ func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *
 	}
 
 	return &payment.PaymentResponse{
-		Success:       kachaResp.Success,
+		Success:       status != txEntity.FAILED,
 		TransactionID: req.TransactionID,
 		Status:        status,
 		ProcessorRef:  kachaResp.Data.Reference,
@@ -441,17 +444,6 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 		"transaction_id": transactionID,
 	})
 
-	// Note: Kacha doesn't provide a specific transaction status query endpoint in the examples
-	// This is a placeholder implementation - you may need to adjust this based on actual Kacha API
-	p.log.Warn("Kacha transaction status query not implemented - no API endpoint provided", map[string]interface{}{
-		"transaction_id": transactionID,
-	})
-
-	return &payment.TransactionStatusQueryResponse{
-		Status:       txEntity.PENDING,
-		ProviderTxId: "",
-		ProviderData: map[string]interface{}{
-			"message": "Transaction status query not supported by Kacha API",
-		},
-	}, nil
+	// Kacha only reports outcomes through callbacks
+	return nil, fmt.Errorf("Kacha transaction status query: %w", payment.ErrNotSupported)
 }
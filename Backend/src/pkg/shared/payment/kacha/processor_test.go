@@ -0,0 +1,65 @@
+package kacha
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/paymenttest"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestProcessorConformance(t *testing.T) {
+	paymenttest.Run(t, paymenttest.Spec{
+		Medium: txEntity.KACHA,
+		New: func(t *testing.T, baseURL string) payment.Processor {
+			return NewProcessor(ProcessorConfig{
+				IsTestMode:  true,
+				BaseURL:     baseURL,
+				CallbackURL: "https://api.example.com/api/v2/settle/std",
+			})
+		},
+		Initiate: paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/api/kacha/push-ussd-payment",
+			Body:   paymenttest.Fixture(t, "push_ussd_accepted.json"),
+		},
+		InitiateDeclined: paymenttest.Exchange{
+			Path:   "/api/kacha/push-ussd-payment",
+			Status: http.StatusNotFound,
+			Body:   paymenttest.Fixture(t, "push_ussd_declined.json"),
+		},
+		Withdrawal: &paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/api/b2c/kacha/initiate-transfer",
+			Body:   paymenttest.Fixture(t, "transfer_accepted.json"),
+		},
+		WithdrawalDeclined: &paymenttest.Exchange{
+			Path:   "/api/b2c/kacha/initiate-transfer",
+			Status: http.StatusBadRequest,
+			Body:   paymenttest.Fixture(t, "transfer_declined.json"),
+		},
+		Callbacks: []paymenttest.CallbackCase{
+			{
+				Name: "success",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{
+					"id":           "kp_01J5Z3X8Q4",
+					"reference":    "KCH2410181045",
+					"status":       "SUCCESS",
+					"message":      "Payment completed",
+					"trace_number": "6f1c2d3e-4b5a-4c6d-8e7f-9a0b1c2d3e4f",
+					"amount":       150.75,
+				}},
+				Settled: true,
+			},
+			{
+				Name: "failed",
+				Request: payment.CallbackRequest{Metadata: map[string]interface{}{
+					"reference": "KCH2410181045",
+					"status":    "failed",
+					"message":   "Customer rejected the payment",
+				}},
+			},
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package kacha
+
+import (
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func init() {
+	registry.Register(registry.Factory{
+		Medium:       txEntity.KACHA,
+		EnvPrefix:    "KACHA",
+		CallbackPath: "/settle/std",
+		New: func(s registry.Settings, _ registry.Dependencies) (payment.Processor, error) {
+			return NewProcessor(ProcessorConfig{
+				IsTestMode:  s.TestMode,
+				BaseURL:     s.BaseURL,
+				CallbackURL: s.CallbackURL,
+			}), nil
+		},
+	})
+}
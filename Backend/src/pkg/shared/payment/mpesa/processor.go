@@ -39,6 +39,9 @@ func NewProcessor(config ProcessorConfig) payment.Processor {
 	if config.BaseURL == "" {
 		config.BaseURL = os.Getenv("MPESA_BASE_URL")
 	}
+	if config.CallbackURL == "" {
+		config.CallbackURL = os.Getenv("MPESA_CALLBACK_BASE_URL")
+	}
 
 	return &processor{
 		username:    config.Username,
@@ -63,7 +66,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 		"AccountReference":  req.TransactionID.String(),
 		"Amount":            req.Amount,
 		"BusinessShortCode": "1883",
-		"CallBackURL":       os.Getenv("MPESA_CALLBACK_BASE_URL"),
+		"CallBackURL":       p.callbackURL,
 		"Password":          p.password,
 		"PartyA":            req.PhoneNumber,
 		"PartyB":            "1883",
@@ -233,7 +236,7 @@ func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *
 	}
 
 	// Create HTTP request
-	endpoint := "https://api.socialpay.co/account/mpesa/payment/b2c"
+	endpoint := fmt.Sprintf("%s/account/mpesa/payment/b2c", p.baseURL)
 	p.log.Info("Creating request to M-PESA B2C", map[string]interface{}{
 		"url":  endpoint,
 		"body": string(jsonData),
@@ -323,5 +326,5 @@ func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID st
 	p.log.Info("Querying Mpesa transaction status", map[string]interface{}{
 		"transaction_id": transactionID,
 	})
-	return nil, nil
+	return nil, fmt.Errorf("M-PESA transaction status query: %w", payment.ErrNotSupported)
 }
@@ -0,0 +1,55 @@
+package mpesa
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/paymenttest"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestProcessorConformance(t *testing.T) {
+	paymenttest.Run(t, paymenttest.Spec{
+		Medium: txEntity.MPESA,
+		New: func(t *testing.T, baseURL string) payment.Processor {
+			return NewProcessor(ProcessorConfig{
+				Username:    "test-user",
+				Password:    "test-password",
+				IsTestMode:  true,
+				BaseURL:     baseURL,
+				CallbackURL: "https://api.example.com/api/v2/settle/mpesa",
+			})
+		},
+		Initiate: paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/account/mpesa/ussd-push",
+			Body:   paymenttest.Fixture(t, "ussd_push_accepted.json"),
+		},
+		InitiateDeclined: paymenttest.Exchange{
+			Path:   "/account/mpesa/ussd-push",
+			Status: http.StatusBadRequest,
+			Body:   paymenttest.Fixture(t, "ussd_push_declined.json"),
+		},
+		Withdrawal: &paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/account/mpesa/payment/b2c",
+			Body:   paymenttest.Fixture(t, "b2c_accepted.json"),
+		},
+		WithdrawalDeclined: &paymenttest.Exchange{
+			Path: "/account/mpesa/payment/b2c",
+			Body: paymenttest.Fixture(t, "b2c_declined.json"),
+		},
+		Callbacks: []paymenttest.CallbackCase{
+			{
+				Name:    "completed",
+				Request: payment.CallbackRequest{Status: txEntity.SUCCESS, Metadata: map[string]interface{}{"message": "The service request is processed successfully."}},
+				Settled: true,
+			},
+			{
+				Name:    "cancelled by payer",
+				Request: payment.CallbackRequest{Status: txEntity.FAILED, Metadata: map[string]interface{}{"message": "Request cancelled by user"}},
+			},
+		},
+	})
+}
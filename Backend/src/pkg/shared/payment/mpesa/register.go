@@ -0,0 +1,31 @@
+package mpesa
+
+import (
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func init() {
+	registry.Register(registry.Factory{
+		Medium:          txEntity.MPESA,
+		EnvPrefix:       "MPESA",
+		Credentials:     []string{"username", "password"},
+		RequiresBaseURL: true,
+		CallbackPath:    "/settle/mpesa",
+		EnvAliases: map[string]string{
+			"MPESA_USERNAME":     "SAFARICOM_USERNAME",
+			"MPESA_PASSWORD":     "SAFARICOM_PASSWORD",
+			"MPESA_CALLBACK_URL": "MPESA_CALLBACK_BASE_URL",
+		},
+		New: func(s registry.Settings, _ registry.Dependencies) (payment.Processor, error) {
+			return NewProcessor(ProcessorConfig{
+				Username:    s.Credentials["username"],
+				Password:    s.Credentials["password"],
+				IsTestMode:  s.TestMode,
+				BaseURL:     s.BaseURL,
+				CallbackURL: s.CallbackURL,
+			}), nil
+		},
+	})
+}
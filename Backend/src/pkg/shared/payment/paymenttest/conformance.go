@@ -0,0 +1,376 @@
+// Package paymenttest is the conformance kit every payment.Processor must pass.
+//
+// A provider package describes its API in a Spec: the recorded provider responses
+// for initiation, withdrawal and status queries, and the callbacks it settles.
+// Run replays those responses from a local fake provider and checks the
+// processor against the contract the payment service relies on.
+package paymenttest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// malformedBody is what a provider's load balancer answers when the upstream is down
+const malformedBody = "<html><body><h1>502 Bad Gateway</h1></body></html>"
+
+// callTimeout bounds the calls of the timeout checks; providers never answer them
+const callTimeout = 100 * time.Millisecond
+
+// Exchange is a recorded provider response, replayed for every request to Path
+type Exchange struct {
+	// Method is checked when set
+	Method string
+	Path   string
+	// Status defaults to 200
+	Status int
+	Body   string
+}
+
+// StatusCase is a recorded status query answer and the status it must map to
+type StatusCase struct {
+	Name     string
+	Response Exchange
+	Want     txEntity.TransactionStatus
+}
+
+// CallbackCase is a callback as the settlement handler hands it to the processor
+type CallbackCase struct {
+	Name    string
+	Request payment.CallbackRequest
+	// Settled is whether SettlePayment accepts the callback as a completed payment
+	Settled bool
+}
+
+// Spec describes a provider's API to the conformance kit
+type Spec struct {
+	Medium txEntity.TransactionMedium
+
+	// New builds the processor against the fake provider listening on baseURL
+	New func(t *testing.T, baseURL string) payment.Processor
+
+	// Initiate is the provider accepting a payment. An empty Path means the processor
+	// starts payments without calling the provider, as hosted forms do.
+	Initiate Exchange
+	// InitiateDeclined is the provider turning a payment down
+	InitiateDeclined Exchange
+
+	// Withdrawal and WithdrawalDeclined are the provider accepting and refusing a payout.
+	// A nil Withdrawal means the processor must report payment.ErrNotSupported.
+	Withdrawal         *Exchange
+	WithdrawalDeclined *Exchange
+
+	// Status lists recorded status answers. An empty list means the processor
+	// must report payment.ErrNotSupported.
+	Status []StatusCase
+
+	// Callbacks must include at least one settled and one rejected callback
+	Callbacks []CallbackCase
+}
+
+// Run checks a processor against the payment processor contract
+func Run(t *testing.T, spec Spec) {
+	t.Helper()
+
+	if spec.New == nil {
+		t.Fatal("spec has no processor constructor")
+	}
+	server := NewServer(t)
+	processor := spec.New(t, server.URL)
+
+	t.Run("type", func(t *testing.T) {
+		if got := processor.GetType(); got != spec.Medium {
+			t.Fatalf("GetType() = %s, want %s", got, spec.Medium)
+		}
+	})
+
+	t.Run("initiate", func(t *testing.T) {
+		server.Reset()
+		req := newPaymentRequest()
+		if spec.Initiate.Path != "" {
+			server.Reply(spec.Initiate)
+		}
+
+		resp, err := processor.InitiatePayment(context.Background(), "test-api-key", req)
+		if err != nil {
+			t.Fatalf("InitiatePayment() error = %v", err)
+		}
+		checkAccepted(t, req, resp)
+
+		if spec.Initiate.Path == "" {
+			if requests := server.Requests(""); len(requests) != 0 {
+				t.Fatalf("expected no provider calls, got %d", len(requests))
+			}
+			return
+		}
+		requests := server.Requests(spec.Initiate.Path)
+		if len(requests) != 1 {
+			t.Fatalf("expected one call to %s, got %d", spec.Initiate.Path, len(requests))
+		}
+		if !referencesTransaction(requests[0], req.TransactionID) {
+			t.Errorf("provider request does not carry transaction %s: %s %s", req.TransactionID, requests[0].Query, requests[0].Body)
+		}
+	})
+
+	t.Run("initiate declined", func(t *testing.T) {
+		if spec.Initiate.Path == "" {
+			t.Skip("payments start without a provider call")
+		}
+		server.Reset()
+		server.Reply(spec.InitiateDeclined)
+
+		resp, err := processor.InitiatePayment(context.Background(), "test-api-key", newPaymentRequest())
+		checkDeclined(t, resp, err)
+	})
+
+	t.Run("initiate malformed response", func(t *testing.T) {
+		if spec.Initiate.Path == "" {
+			t.Skip("payments start without a provider call")
+		}
+		server.Reset()
+		server.Reply(Exchange{Path: spec.Initiate.Path, Body: malformedBody})
+
+		if _, err := processor.InitiatePayment(context.Background(), "test-api-key", newPaymentRequest()); err == nil {
+			t.Fatal("InitiatePayment() accepted an unreadable provider response")
+		}
+	})
+
+	t.Run("initiate timeout", func(t *testing.T) {
+		if spec.Initiate.Path == "" {
+			t.Skip("payments start without a provider call")
+		}
+		checkTimeout(t, server, spec.Initiate.Path, func(ctx context.Context) error {
+			_, err := processor.InitiatePayment(ctx, "test-api-key", newPaymentRequest())
+			return err
+		})
+	})
+
+	t.Run("withdrawal", func(t *testing.T) {
+		server.Reset()
+		req := newPaymentRequest()
+		if spec.Withdrawal == nil {
+			_, err := processor.InitiateWithdrawal(context.Background(), "test-api-key", req)
+			checkNotSupported(t, server, err)
+			return
+		}
+		server.Reply(*spec.Withdrawal)
+
+		resp, err := processor.InitiateWithdrawal(context.Background(), "test-api-key", req)
+		if err != nil {
+			t.Fatalf("InitiateWithdrawal() error = %v", err)
+		}
+		checkAccepted(t, req, resp)
+		if requests := server.Requests(spec.Withdrawal.Path); len(requests) != 1 {
+			t.Fatalf("expected one call to %s, got %d", spec.Withdrawal.Path, len(requests))
+		}
+	})
+
+	t.Run("withdrawal declined", func(t *testing.T) {
+		if spec.Withdrawal == nil {
+			t.Skip("withdrawals are not supported")
+		}
+		if spec.WithdrawalDeclined == nil {
+			t.Fatal("spec has a withdrawal but no declined withdrawal")
+		}
+		server.Reset()
+		server.Reply(*spec.WithdrawalDeclined)
+
+		resp, err := processor.InitiateWithdrawal(context.Background(), "test-api-key", newPaymentRequest())
+		checkDeclined(t, resp, err)
+	})
+
+	t.Run("withdrawal malformed response", func(t *testing.T) {
+		if spec.Withdrawal == nil {
+			t.Skip("withdrawals are not supported")
+		}
+		server.Reset()
+		server.Reply(Exchange{Path: spec.Withdrawal.Path, Body: malformedBody})
+
+		if _, err := processor.InitiateWithdrawal(context.Background(), "test-api-key", newPaymentRequest()); err == nil {
+			t.Fatal("InitiateWithdrawal() accepted an unreadable provider response")
+		}
+	})
+
+	t.Run("withdrawal timeout", func(t *testing.T) {
+		if spec.Withdrawal == nil {
+			t.Skip("withdrawals are not supported")
+		}
+		checkTimeout(t, server, spec.Withdrawal.Path, func(ctx context.Context) error {
+			_, err := processor.InitiateWithdrawal(ctx, "test-api-key", newPaymentRequest())
+			return err
+		})
+	})
+
+	t.Run("status", func(t *testing.T) {
+		if len(spec.Status) == 0 {
+			server.Reset()
+			_, err := processor.QueryTransactionStatus(context.Background(), uuid.NewString())
+			checkNotSupported(t, server, err)
+			return
+		}
+		for _, tc := range spec.Status {
+			t.Run(tc.Name, func(t *testing.T) {
+				server.Reset()
+				server.Reply(tc.Response)
+
+				resp, err := processor.QueryTransactionStatus(context.Background(), uuid.NewString())
+				if err != nil {
+					t.Fatalf("QueryTransactionStatus() error = %v", err)
+				}
+				if resp == nil {
+					t.Fatal("QueryTransactionStatus() returned no response and no error")
+				}
+				if resp.Status != tc.Want {
+					t.Fatalf("status = %s, want %s", resp.Status, tc.Want)
+				}
+			})
+		}
+	})
+
+	t.Run("status malformed response", func(t *testing.T) {
+		if len(spec.Status) == 0 {
+			t.Skip("status queries are not supported")
+		}
+		server.Reset()
+		server.Reply(Exchange{Path: spec.Status[0].Response.Path, Body: malformedBody})
+
+		// An unreadable answer must not be mistaken for a final status
+		if _, err := processor.QueryTransactionStatus(context.Background(), uuid.NewString()); err == nil {
+			t.Fatal("QueryTransactionStatus() accepted an unreadable provider response")
+		}
+	})
+
+	t.Run("status timeout", func(t *testing.T) {
+		if len(spec.Status) == 0 {
+			t.Skip("status queries are not supported")
+		}
+		checkTimeout(t, server, spec.Status[0].Response.Path, func(ctx context.Context) error {
+			_, err := processor.QueryTransactionStatus(ctx, uuid.NewString())
+			return err
+		})
+	})
+
+	t.Run("callbacks", func(t *testing.T) {
+		var settled, rejected bool
+		for _, tc := range spec.Callbacks {
+			settled = settled || tc.Settled
+			rejected = rejected || !tc.Settled
+		}
+		if !settled || !rejected {
+			t.Fatal("spec needs at least one settled and one rejected callback")
+		}
+
+		for _, tc := range spec.Callbacks {
+			t.Run(tc.Name, func(t *testing.T) {
+				// Providers retry callbacks, so a redelivery must settle the same way
+				for delivery := 1; delivery <= 2; delivery++ {
+					req := tc.Request
+					err := processor.SettlePayment(context.Background(), &req)
+					if tc.Settled && err != nil {
+						t.Fatalf("delivery %d: SettlePayment() error = %v, want settled", delivery, err)
+					}
+					if !tc.Settled && err == nil {
+						t.Fatalf("delivery %d: SettlePayment() settled a callback it must reject", delivery)
+					}
+				}
+			})
+		}
+	})
+}
+
+func newPaymentRequest() *payment.PaymentRequest {
+	return &payment.PaymentRequest{
+		TransactionID: uuid.New(),
+		MerchantID:    uuid.New(),
+		Amount:        150.75,
+		Currency:      "ETB",
+		PhoneNumber:   "251911223344",
+		Reference:     "conformance-" + uuid.NewString()[:8],
+		Description:   "Conformance test payment",
+		CallbackURL:   "https://merchant.example.com/callback",
+		SuccessURL:    "https://merchant.example.com/success",
+		FailedURL:     "https://merchant.example.com/failed",
+	}
+}
+
+func checkAccepted(t *testing.T, req *payment.PaymentRequest, resp *payment.PaymentResponse) {
+	t.Helper()
+
+	if resp == nil {
+		t.Fatal("returned no response and no error")
+	}
+	if resp.TransactionID != req.TransactionID {
+		t.Errorf("transaction id = %s, want %s", resp.TransactionID, req.TransactionID)
+	}
+	if !resp.Success {
+		t.Errorf("success = false for an accepted request")
+	}
+	if resp.Status != txEntity.PENDING && resp.Status != txEntity.SUCCESS {
+		t.Errorf("status = %s, want PENDING or SUCCESS", resp.Status)
+	}
+}
+
+// checkDeclined accepts an error or a response that is unsuccessful and failed, never a mix
+func checkDeclined(t *testing.T, resp *payment.PaymentResponse, err error) {
+	t.Helper()
+
+	if err != nil {
+		return
+	}
+	if resp == nil {
+		t.Fatal("returned no response and no error")
+	}
+	if resp.Success || resp.Status != txEntity.FAILED {
+		t.Fatalf("declined request reported success = %v, status = %s", resp.Success, resp.Status)
+	}
+}
+
+func checkNotSupported(t *testing.T, server *Server, err error) {
+	t.Helper()
+
+	if !errors.Is(err, payment.ErrNotSupported) {
+		t.Fatalf("error = %v, want payment.ErrNotSupported", err)
+	}
+	if requests := server.Requests(""); len(requests) != 0 {
+		t.Fatalf("expected no provider calls, got %d", len(requests))
+	}
+}
+
+// checkTimeout makes the provider hang and expects the call to give up with the caller's deadline
+func checkTimeout(t *testing.T, server *Server, path string, call func(ctx context.Context) error) {
+	t.Helper()
+
+	server.Reset()
+	server.Hang(path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := call(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 20*callTimeout {
+		t.Fatalf("call took %s after a %s deadline", elapsed, callTimeout)
+	}
+}
+
+// referencesTransaction reports whether a provider request carries the transaction id,
+// in full or in the hyphenless form some providers require
+func referencesTransaction(r Request, id uuid.UUID) bool {
+	compact := strings.ReplaceAll(id.String(), "-", "")
+	for _, part := range []string{r.Query, r.Body} {
+		if strings.Contains(part, id.String()) || strings.Contains(part, compact) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package paymenttest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// Request is a call a processor made to the fake provider
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// Server is a local stand-in for a provider API. It replays the recorded
+// exchange registered for a path and records every request it receives.
+type Server struct {
+	URL string
+
+	srv      *httptest.Server
+	mu       sync.Mutex
+	replies  map[string]Exchange
+	hanging  map[string]bool
+	requests []Request
+	closing  chan struct{}
+}
+
+// NewServer starts a fake provider that is shut down when the test ends.
+// Paths without a registered exchange answer 404.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := &Server{
+		replies: make(map[string]Exchange),
+		hanging: make(map[string]bool),
+		closing: make(chan struct{}),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.URL = s.srv.URL
+
+	t.Cleanup(func() {
+		close(s.closing)
+		s.srv.Close()
+	})
+	return s
+}
+
+// Reply replays the exchange for every request to its path, replacing earlier replies
+func (s *Server) Reply(exchange Exchange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.hanging, exchange.Path)
+	s.replies[exchange.Path] = exchange
+}
+
+// Hang never answers requests to path, so callers only return through their own deadline
+func (s *Server) Hang(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.replies, path)
+	s.hanging[path] = true
+}
+
+// Requests returns the requests received on path, or on every path when path is empty
+func (s *Server) Requests(path string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []Request
+	for _, r := range s.requests {
+		if path == "" || r.Path == path {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// Reset forgets the registered replies and recorded requests
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replies = make(map[string]Exchange)
+	s.hanging = make(map[string]bool)
+	s.requests = nil
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Body:   string(body),
+	})
+	exchange, ok := s.replies[r.URL.Path]
+	hang := s.hanging[r.URL.Path]
+	s.mu.Unlock()
+
+	if hang {
+		select {
+		case <-r.Context().Done():
+		case <-s.closing:
+		}
+		return
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if exchange.Method != "" && exchange.Method != r.Method {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := exchange.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, exchange.Body)
+}
+
+// Fixture reads a recorded provider payload from the calling package's testdata directory
+func Fixture(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return string(data)
+}
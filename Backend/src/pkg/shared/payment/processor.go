@@ -2,16 +2,26 @@ package payment
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 )
 
+// ErrNotSupported is wrapped by processors for operations their provider has no API for,
+// such as withdrawals on card gateways or status queries on push-only providers
+var ErrNotSupported = errors.New("operation not supported by this processor")
+
+// ErrAccountNotFound is returned by account verification when the provider has no such account
+var ErrAccountNotFound = errors.New("account not found at provider")
+
 // PaymentStatus represents the status of a payment
 
 // PaymentRequest represents a unified payment request structure
 type PaymentRequest struct {
 	TransactionID uuid.UUID                  `json:"transaction_id"`
+	MerchantID    uuid.UUID                  `json:"merchant_id"`
 	Amount        float64                    `json:"amount"`
 	Medium        txEntity.TransactionMedium `json:"medium"`
 	Currency      string                     `json:"currency"`
@@ -33,6 +43,28 @@ type PaymentResponse struct {
 	Message       string                     `json:"message,omitempty"`
 	ProcessorRef  string                     `json:"processor_ref,omitempty"`
 	Metadata      map[string]interface{}     `json:"metadata,omitempty"`
+
+	// AuthorizationExpiresAt is set by Authorize to when the hold on the card lapses
+	AuthorizationExpiresAt *time.Time `json:"authorization_expires_at,omitempty"`
+}
+
+// CaptureRequest captures all or part of a previously authorized payment
+type CaptureRequest struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	MerchantID    uuid.UUID `json:"merchant_id"`
+	ProcessorRef  string    `json:"processor_ref"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+}
+
+// VoidRequest releases a previously authorized payment that was not captured
+type VoidRequest struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	MerchantID    uuid.UUID `json:"merchant_id"`
+	ProcessorRef  string    `json:"processor_ref"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Reason        string    `json:"reason,omitempty"`
 }
 
 type TransactionStatusQueryResponse struct {
@@ -64,3 +96,36 @@ type Processor interface {
 
 	QueryTransactionStatus(ctx context.Context, transactionID string) (*TransactionStatusQueryResponse, error)
 }
+
+// CardAuthorizer is implemented by processors that can hold funds on a card and
+// capture or release them later. It is optional; callers check for it with a type assertion.
+type CardAuthorizer interface {
+	// Authorize places a hold for the payment amount without capturing it
+	Authorize(ctx context.Context, apikey string, req *PaymentRequest) (*PaymentResponse, error)
+
+	// Capture collects all or part of an authorized amount
+	Capture(ctx context.Context, req *CaptureRequest) (*PaymentResponse, error)
+
+	// Void releases an authorization that was not captured
+	Void(ctx context.Context, req *VoidRequest) (*PaymentResponse, error)
+}
+
+// AccountVerificationRequest asks a provider who holds a payout destination
+type AccountVerificationRequest struct {
+	MerchantID    uuid.UUID                  `json:"merchant_id"`
+	Medium        txEntity.TransactionMedium `json:"medium"`
+	AccountNumber string                     `json:"account_number"`
+	BankCode      string                     `json:"bank_code,omitempty"`
+}
+
+// AccountVerificationResponse is the holder of a payout destination as known to the provider
+type AccountVerificationResponse struct {
+	AccountName string `json:"account_name"`
+}
+
+// AccountVerifier is implemented by processors whose provider can look up the holder of a
+// wallet or bank account before paying out to it. It is optional; callers check for it with a type assertion.
+type AccountVerifier interface {
+	// VerifyAccount returns the account holder, or ErrAccountNotFound when the account does not exist
+	VerifyAccount(ctx context.Context, req *AccountVerificationRequest) (*AccountVerificationResponse, error)
+}
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// Config is the payment processor configuration read from the environment.
+//
+// Every registered processor reads:
+//
+//	<PREFIX>_ENABLED        defaults to true
+//	<PREFIX>_TEST_MODE      defaults to true unless APP_ENV is production
+//	<PREFIX>_BASE_URL       provider endpoint
+//	<PREFIX>_CALLBACK_URL   overrides PAYMENT_CALLBACK_BASE_URL + the processor's callback path
+//	<PREFIX>_<KEY>          one variable per credential and option key, upper cased
+//
+// PAYMENT_CALLBACK_BASE_URL defaults to APP_URL_V2 + /api/v2.
+type Config struct {
+	CallbackBaseURL string
+	Processors      map[txEntity.TransactionMedium]Settings
+}
+
+// LoadConfig reads and validates the configuration of every registered processor.
+// All problems are reported together so a misconfigured deployment fails on the first start.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		CallbackBaseURL: strings.TrimRight(os.Getenv("PAYMENT_CALLBACK_BASE_URL"), "/"),
+		Processors:      make(map[txEntity.TransactionMedium]Settings),
+	}
+	if cfg.CallbackBaseURL == "" && os.Getenv("APP_URL_V2") != "" {
+		cfg.CallbackBaseURL = strings.TrimRight(os.Getenv("APP_URL_V2"), "/") + "/api/v2"
+	}
+	production := os.Getenv("APP_ENV") == "production"
+
+	var errs []error
+	callbackBaseNeeded := false
+	for _, factory := range Factories() {
+		settings, err := loadSettings(factory, cfg.CallbackBaseURL, !production)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if settings.Enabled && factory.CallbackPath != "" && settings.CallbackURL == "" {
+			callbackBaseNeeded = true
+		}
+		cfg.Processors[factory.Medium] = settings
+	}
+
+	if callbackBaseNeeded {
+		if cfg.CallbackBaseURL == "" {
+			errs = append(errs, fmt.Errorf("PAYMENT_CALLBACK_BASE_URL or APP_URL_V2 is required"))
+		} else if err := validateURL(cfg.CallbackBaseURL, production); err != nil {
+			errs = append(errs, fmt.Errorf("PAYMENT_CALLBACK_BASE_URL: %w", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid payment processor configuration: %w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+func loadSettings(factory Factory, callbackBaseURL string, defaultTestMode bool) (Settings, error) {
+	var errs []error
+	env := func(name string) string {
+		key := factory.EnvPrefix + "_" + name
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		if legacy, ok := factory.EnvAliases[key]; ok {
+			return os.Getenv(legacy)
+		}
+		return ""
+	}
+	boolEnv := func(name string, fallback bool) bool {
+		raw := env(name)
+		if raw == "" {
+			return fallback
+		}
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s_%s: %q is not a boolean", factory.EnvPrefix, name, raw))
+			return fallback
+		}
+		return value
+	}
+
+	settings := Settings{
+		Medium:      factory.Medium,
+		Enabled:     boolEnv("ENABLED", true),
+		TestMode:    boolEnv("TEST_MODE", defaultTestMode),
+		BaseURL:     env("BASE_URL"),
+		CallbackURL: env("CALLBACK_URL"),
+		Credentials: make(map[string]string, len(factory.Credentials)),
+		Options:     make(map[string]string, len(factory.Options)),
+	}
+	for _, key := range factory.Credentials {
+		settings.Credentials[key] = env(strings.ToUpper(key))
+	}
+	for _, key := range factory.Options {
+		settings.Options[key] = env(strings.ToUpper(key))
+	}
+	if settings.CallbackURL == "" && factory.CallbackPath != "" && callbackBaseURL != "" {
+		settings.CallbackURL = callbackBaseURL + factory.CallbackPath
+	}
+
+	if !settings.Enabled {
+		return settings, errors.Join(errs...)
+	}
+
+	for _, key := range factory.Credentials {
+		if settings.Credentials[key] == "" {
+			errs = append(errs, fmt.Errorf("%s_%s is required", factory.EnvPrefix, strings.ToUpper(key)))
+		}
+	}
+	if settings.BaseURL == "" && factory.RequiresBaseURL {
+		errs = append(errs, fmt.Errorf("%s_BASE_URL is required", factory.EnvPrefix))
+	}
+	if settings.BaseURL != "" {
+		if err := validateURL(settings.BaseURL, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s_BASE_URL: %w", factory.EnvPrefix, err))
+		}
+	}
+	if env("CALLBACK_URL") != "" {
+		if err := validateURL(settings.CallbackURL, !settings.TestMode); err != nil {
+			errs = append(errs, fmt.Errorf("%s_CALLBACK_URL: %w", factory.EnvPrefix, err))
+		}
+	}
+
+	return settings, errors.Join(errs...)
+}
+
+// validateURL accepts absolute http(s) URLs, and only https when requireHTTPS is set
+func validateURL(raw string, requireHTTPS bool) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute URL", raw)
+	}
+	switch {
+	case parsed.Scheme == "https":
+		return nil
+	case parsed.Scheme == "http" && !requireHTTPS:
+		return nil
+	case requireHTTPS:
+		return fmt.Errorf("%q must use https", raw)
+	default:
+		return fmt.Errorf("%q must use http or https", raw)
+	}
+}
@@ -0,0 +1,220 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepo "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+)
+
+// Factory describes how a processor package is configured and built.
+// Processor packages register their factory from init and must pass paymenttest.Run.
+type Factory struct {
+	Medium txEntity.TransactionMedium
+
+	// EnvPrefix namespaces the processor's environment variables, e.g. TELEBIRR_BASE_URL
+	EnvPrefix string
+
+	// Credentials are required when the processor is enabled. The keys are the same ones
+	// merchants use for their own credentials, so merchant credentials replace them one to one.
+	Credentials []string
+
+	// Options are optional settings read from the environment next to the credentials
+	Options []string
+
+	// RequiresBaseURL fails startup when the processor has no built-in default endpoint
+	RequiresBaseURL bool
+
+	// CallbackPath is appended to the callback base URL to build the processor's callback URL
+	CallbackPath string
+
+	// EnvAliases maps a variable to its legacy name, which is read when the variable is unset
+	EnvAliases map[string]string
+
+	// New builds a processor from validated settings
+	New func(settings Settings, deps Dependencies) (payment.Processor, error)
+}
+
+// Settings is the resolved configuration of one processor
+type Settings struct {
+	Medium      txEntity.TransactionMedium
+	Enabled     bool
+	TestMode    bool
+	BaseURL     string
+	CallbackURL string
+	Credentials map[string]string
+	Options     map[string]string
+}
+
+// Dependencies are shared services some processors need
+type Dependencies struct {
+	TxnRepository txRepo.TransactionRepository
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[txEntity.TransactionMedium]Factory)
+)
+
+// Register makes a processor factory available. It panics when a medium is registered twice.
+func Register(factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory.New == nil {
+		panic(fmt.Sprintf("payment registry: factory for %s has no constructor", factory.Medium))
+	}
+	if _, exists := factories[factory.Medium]; exists {
+		panic(fmt.Sprintf("payment registry: %s registered twice", factory.Medium))
+	}
+	factories[factory.Medium] = factory
+}
+
+// Factories returns the registered factories ordered by medium
+func Factories() []Factory {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	list := make([]Factory, 0, len(factories))
+	for _, factory := range factories {
+		list = append(list, factory)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Medium < list[j].Medium })
+	return list
+}
+
+// ProcessorInfo is the admin view of a configured processor. Credential values are never exposed.
+type ProcessorInfo struct {
+	Medium                txEntity.TransactionMedium `json:"medium"`
+	Enabled               bool                       `json:"enabled"`
+	TestMode              bool                       `json:"test_mode"`
+	BaseURL               string                     `json:"base_url,omitempty"`
+	CallbackURL           string                     `json:"callback_url,omitempty"`
+	ConfiguredCredentials []string                   `json:"configured_credentials"`
+	ConfiguredOptions     []string                   `json:"configured_options"`
+}
+
+// Registry holds the processors built from one configuration. A single instance is
+// shared by payments, settlement, reconciliation and the admin listing.
+type Registry struct {
+	factories  map[txEntity.TransactionMedium]Factory
+	settings   map[txEntity.TransactionMedium]Settings
+	processors map[txEntity.TransactionMedium]payment.Processor
+	mediums    []txEntity.TransactionMedium
+	deps       Dependencies
+}
+
+// New builds every enabled processor of the configuration
+func New(cfg *Config, deps Dependencies) (*Registry, error) {
+	r := &Registry{
+		factories:  make(map[txEntity.TransactionMedium]Factory),
+		settings:   make(map[txEntity.TransactionMedium]Settings),
+		processors: make(map[txEntity.TransactionMedium]payment.Processor),
+		deps:       deps,
+	}
+
+	for _, factory := range Factories() {
+		settings, ok := cfg.Processors[factory.Medium]
+		if !ok {
+			return nil, fmt.Errorf("payment registry: %s is not configured", factory.Medium)
+		}
+		r.factories[factory.Medium] = factory
+		r.settings[factory.Medium] = settings
+		if !settings.Enabled {
+			continue
+		}
+
+		processor, err := factory.New(settings, deps)
+		if err != nil {
+			return nil, fmt.Errorf("payment registry: failed to build %s processor: %w", factory.Medium, err)
+		}
+		r.processors[factory.Medium] = processor
+		r.mediums = append(r.mediums, factory.Medium)
+	}
+
+	return r, nil
+}
+
+// Get returns the processor of an enabled medium
+func (r *Registry) Get(medium txEntity.TransactionMedium) (payment.Processor, bool) {
+	processor, ok := r.processors[medium]
+	return processor, ok
+}
+
+// Mediums returns the enabled mediums ordered by medium
+func (r *Registry) Mediums() []txEntity.TransactionMedium {
+	return append([]txEntity.TransactionMedium(nil), r.mediums...)
+}
+
+// Processors returns the enabled processors ordered by medium
+func (r *Registry) Processors() []payment.Processor {
+	processors := make([]payment.Processor, 0, len(r.mediums))
+	for _, medium := range r.mediums {
+		processors = append(processors, r.processors[medium])
+	}
+	return processors
+}
+
+// Map returns the enabled processors keyed by medium
+func (r *Registry) Map() map[txEntity.TransactionMedium]payment.Processor {
+	processors := make(map[txEntity.TransactionMedium]payment.Processor, len(r.processors))
+	for medium, processor := range r.processors {
+		processors[medium] = processor
+	}
+	return processors
+}
+
+// WithCredentials builds a processor of an enabled medium with the platform settings
+// and the given credentials in place of the platform credentials
+func (r *Registry) WithCredentials(medium txEntity.TransactionMedium, credentials map[string]string) (payment.Processor, error) {
+	if _, ok := r.processors[medium]; !ok {
+		return nil, fmt.Errorf("payment processor %s is not enabled", medium)
+	}
+
+	settings := r.settings[medium]
+	merged := make(map[string]string, len(settings.Credentials))
+	for key, value := range settings.Credentials {
+		merged[key] = value
+	}
+	for key, value := range credentials {
+		merged[key] = value
+	}
+	settings.Credentials = merged
+
+	return r.factories[medium].New(settings, r.deps)
+}
+
+// List returns the admin view of every registered processor, enabled or not
+func (r *Registry) List() []ProcessorInfo {
+	list := make([]ProcessorInfo, 0, len(r.settings))
+	for _, factory := range Factories() {
+		settings, ok := r.settings[factory.Medium]
+		if !ok {
+			continue
+		}
+		list = append(list, ProcessorInfo{
+			Medium:                factory.Medium,
+			Enabled:               settings.Enabled,
+			TestMode:              settings.TestMode,
+			BaseURL:               settings.BaseURL,
+			CallbackURL:           settings.CallbackURL,
+			ConfiguredCredentials: configuredKeys(settings.Credentials),
+			ConfiguredOptions:     configuredKeys(settings.Options),
+		})
+	}
+	return list
+}
+
+func configuredKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
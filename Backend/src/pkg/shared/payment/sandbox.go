@@ -0,0 +1,25 @@
+package payment
+
+import (
+	"context"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// SimulatorType is the processor type of the sandbox payment simulator.
+// It is never stored on a transaction; test transactions keep their real medium.
+const SimulatorType txEntity.TransactionMedium = "SIMULATOR"
+
+type testModeKey struct{}
+
+// WithTestMode marks a request as coming from a test API key. Payments made
+// with a test context are routed to the simulator instead of the real processor.
+func WithTestMode(ctx context.Context, test bool) context.Context {
+	return context.WithValue(ctx, testModeKey{}, test)
+}
+
+// IsTestMode reports whether the context was marked by WithTestMode
+func IsTestMode(ctx context.Context) bool {
+	test, _ := ctx.Value(testModeKey{}).(bool)
+	return test
+}
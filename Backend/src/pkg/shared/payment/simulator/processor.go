@@ -0,0 +1,353 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepo "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	webhookDto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
+)
+
+// Outcome is the scripted result of a simulated payment
+type Outcome string
+
+const (
+	// OutcomeSuccess sends a SUCCESS callback after the callback delay
+	OutcomeSuccess Outcome = "SUCCESS"
+	// OutcomeFailure sends a FAILED callback after the callback delay
+	OutcomeFailure Outcome = "FAILURE"
+	// OutcomeTimeout never sends a callback, leaving the payment pending
+	OutcomeTimeout Outcome = "TIMEOUT"
+	// OutcomeDelayed sends a SUCCESS callback after the long callback delay
+	OutcomeDelayed Outcome = "DELAYED"
+	// OutcomeDeclined rejects the payment synchronously at initiation
+	OutcomeDeclined Outcome = "DECLINED"
+)
+
+// magicCents maps the cents of the base amount to an outcome, e.g. 100.01 fails
+var magicCents = map[int]Outcome{
+	1: OutcomeFailure,
+	2: OutcomeTimeout,
+	3: OutcomeDelayed,
+	4: OutcomeDeclined,
+}
+
+// magicPhoneSuffixes maps the last digits of the payer phone number to an outcome
+var magicPhoneSuffixes = map[string]Outcome{
+	"0001": OutcomeFailure,
+	"0002": OutcomeTimeout,
+	"0003": OutcomeDelayed,
+	"0004": OutcomeDeclined,
+}
+
+// redirectMediums are paid on a hosted page rather than by a USSD push
+var redirectMediums = map[txEntity.TransactionMedium]bool{
+	txEntity.CYBERSOURCE: true,
+	txEntity.ETHSWITCH:   true,
+}
+
+// WebhookDispatcher delivers simulated provider callbacks through the settlement pipeline
+type WebhookDispatcher interface {
+	HandleWebhookDispatch(ctx context.Context, req webhookDto.WebhookRequest) error
+}
+
+// Processor is the sandbox payment processor used by test API keys
+type Processor interface {
+	payment.Processor
+	payment.CardAuthorizer
+	payment.AccountVerifier
+
+	// SetDispatcher wires the callback pipeline, which is built after the payment service
+	SetDispatcher(dispatcher WebhookDispatcher)
+}
+
+type processor struct {
+	txn               txRepo.TransactionRepository
+	callbackDelay     time.Duration
+	longCallbackDelay time.Duration
+	authorizationTTL  time.Duration
+
+	mu         sync.RWMutex
+	dispatcher WebhookDispatcher
+	// statuses remembers delivered outcomes so status queries agree with callbacks
+	statuses sync.Map
+	log      logging.Logger
+}
+
+// ProcessorConfig holds the configuration for the payment simulator
+type ProcessorConfig struct {
+	// CallbackDelay is how long a simulated provider takes to call back. Defaults to 5 seconds.
+	CallbackDelay time.Duration
+	// LongCallbackDelay is used for the delayed outcome. Defaults to 2 minutes.
+	LongCallbackDelay time.Duration
+	// AuthorizationTTL is how long a simulated card hold lasts. Defaults to 7 days.
+	AuthorizationTTL time.Duration
+	TxnRepository    txRepo.TransactionRepository
+}
+
+// NewProcessor creates a new payment simulator
+func NewProcessor(cfg ProcessorConfig) Processor {
+	if cfg.CallbackDelay <= 0 {
+		cfg.CallbackDelay = 5 * time.Second
+	}
+	if cfg.LongCallbackDelay <= 0 {
+		cfg.LongCallbackDelay = 2 * time.Minute
+	}
+	if cfg.AuthorizationTTL <= 0 {
+		cfg.AuthorizationTTL = 7 * 24 * time.Hour
+	}
+
+	return &processor{
+		txn:               cfg.TxnRepository,
+		callbackDelay:     cfg.CallbackDelay,
+		longCallbackDelay: cfg.LongCallbackDelay,
+		authorizationTTL:  cfg.AuthorizationTTL,
+		log:               logging.NewStdLogger("[SIMULATOR] [PROCESSOR]"),
+	}
+}
+
+func (p *processor) SetDispatcher(dispatcher WebhookDispatcher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dispatcher = dispatcher
+}
+
+func (p *processor) GetType() txEntity.TransactionMedium {
+	return payment.SimulatorType
+}
+
+func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
+	return p.initiate(ctx, req, "payment")
+}
+
+func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
+	return p.initiate(ctx, req, "withdrawal")
+}
+
+// initiate mimics a provider accepting the request and calling back later
+func (p *processor) initiate(ctx context.Context, req *payment.PaymentRequest, kind string) (*payment.PaymentResponse, error) {
+	outcome := p.outcomeFor(ctx, req)
+	processorRef := "sim_" + uuid.NewString()
+
+	p.log.Info("Simulating "+kind, map[string]interface{}{
+		"transaction_id": req.TransactionID,
+		"medium":         req.Medium,
+		"amount":         req.Amount,
+		"outcome":        outcome,
+	})
+
+	if outcome == OutcomeDeclined {
+		return nil, fmt.Errorf("simulated %s %s declined by provider", req.Medium, kind)
+	}
+
+	resp := &payment.PaymentResponse{
+		TransactionID: req.TransactionID,
+		Success:       true,
+		Status:        txEntity.PENDING,
+		ProcessorRef:  processorRef,
+		Metadata: map[string]interface{}{
+			"simulated": true,
+			"outcome":   outcome,
+		},
+	}
+	if kind == "payment" && redirectMediums[req.Medium] {
+		// The simulated card page approves instantly and sends the payer back
+		resp.PaymentURL = req.SuccessURL
+		if outcome == OutcomeFailure {
+			resp.PaymentURL = req.FailedURL
+		}
+		resp.Message = fmt.Sprintf("Simulated %s payment page", req.Medium)
+	} else if kind == "payment" {
+		resp.Message = fmt.Sprintf("Simulated %s USSD push sent to %s", req.Medium, req.PhoneNumber)
+	} else {
+		resp.Message = fmt.Sprintf("Simulated %s payout queued", req.Medium)
+	}
+
+	switch outcome {
+	case OutcomeTimeout:
+		// The provider never answers; the payment stays pending
+	case OutcomeDelayed:
+		go p.scheduleCallback(req.TransactionID, processorRef, txEntity.SUCCESS, outcome, p.longCallbackDelay)
+	case OutcomeFailure:
+		go p.scheduleCallback(req.TransactionID, processorRef, txEntity.FAILED, outcome, p.callbackDelay)
+	default:
+		go p.scheduleCallback(req.TransactionID, processorRef, txEntity.SUCCESS, outcome, p.callbackDelay)
+	}
+
+	return resp, nil
+}
+
+func (p *processor) Authorize(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
+	outcome := p.outcomeFor(ctx, req)
+	processorRef := "sim_" + uuid.NewString()
+
+	p.log.Info("Simulating card authorization", map[string]interface{}{
+		"transaction_id": req.TransactionID,
+		"amount":         req.Amount,
+		"outcome":        outcome,
+	})
+
+	switch outcome {
+	case OutcomeDeclined:
+		return nil, fmt.Errorf("simulated card authorization declined by issuer")
+	case OutcomeFailure:
+		return &payment.PaymentResponse{
+			TransactionID: req.TransactionID,
+			Success:       false,
+			Status:        txEntity.FAILED,
+			Message:       "Simulated card authorization failed",
+			ProcessorRef:  processorRef,
+		}, nil
+	}
+
+	expiresAt := time.Now().Add(p.authorizationTTL)
+	return &payment.PaymentResponse{
+		TransactionID:          req.TransactionID,
+		Success:                true,
+		Status:                 txEntity.AUTHORIZED,
+		Message:                "Simulated card authorization approved",
+		ProcessorRef:           processorRef,
+		AuthorizationExpiresAt: &expiresAt,
+	}, nil
+}
+
+func (p *processor) Capture(ctx context.Context, req *payment.CaptureRequest) (*payment.PaymentResponse, error) {
+	p.statuses.Store(req.TransactionID.String(), txEntity.SUCCESS)
+	return &payment.PaymentResponse{
+		TransactionID: req.TransactionID,
+		Success:       true,
+		Status:        txEntity.SUCCESS,
+		Message:       "Simulated capture approved",
+		ProcessorRef:  req.ProcessorRef,
+	}, nil
+}
+
+func (p *processor) Void(ctx context.Context, req *payment.VoidRequest) (*payment.PaymentResponse, error) {
+	p.statuses.Store(req.TransactionID.String(), txEntity.CANCELED)
+	return &payment.PaymentResponse{
+		TransactionID: req.TransactionID,
+		Success:       true,
+		Status:        txEntity.CANCELED,
+		Message:       "Simulated authorization reversed",
+		ProcessorRef:  req.ProcessorRef,
+	}, nil
+}
+
+func (p *processor) SettlePayment(ctx context.Context, req *payment.CallbackRequest) error {
+	return nil
+}
+
+func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID string) (*payment.TransactionStatusQueryResponse, error) {
+	status := txEntity.PENDING
+	if stored, ok := p.statuses.Load(transactionID); ok {
+		status = stored.(txEntity.TransactionStatus)
+	}
+
+	return &payment.TransactionStatusQueryResponse{
+		Status:       status,
+		ProviderTxId: transactionID,
+		ProviderData: map[string]interface{}{"simulated": true},
+	}, nil
+}
+
+// VerifyAccount finds every sandbox account except those ending in the failure suffix
+func (p *processor) VerifyAccount(ctx context.Context, req *payment.AccountVerificationRequest) (*payment.AccountVerificationResponse, error) {
+	if magicPhoneSuffixes[lastDigits(req.AccountNumber, 4)] == OutcomeFailure {
+		return nil, payment.ErrAccountNotFound
+	}
+
+	return &payment.AccountVerificationResponse{
+		AccountName: "Sandbox Account " + lastDigits(req.AccountNumber, 4),
+	}, nil
+}
+
+// outcomeFor picks the scripted outcome from the payer phone number, then the base amount
+func (p *processor) outcomeFor(ctx context.Context, req *payment.PaymentRequest) Outcome {
+	digits := onlyDigits(req.PhoneNumber)
+	for suffix, outcome := range magicPhoneSuffixes {
+		if strings.HasSuffix(digits, suffix) {
+			return outcome
+		}
+	}
+
+	// Fees are added to the charged amount, so merchants script outcomes on the amount they asked for
+	amount := req.Amount
+	if p.txn != nil {
+		if tx, err := p.txn.GetByID(ctx, req.TransactionID); err == nil {
+			amount = tx.BaseAmount
+		}
+	}
+	cents := int(math.Round(amount*100)) % 100
+	if outcome, ok := magicCents[cents]; ok {
+		return outcome
+	}
+
+	return OutcomeSuccess
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+func lastDigits(value string, n int) string {
+	digits := onlyDigits(value)
+	if len(digits) <= n {
+		return digits
+	}
+	return digits[len(digits)-n:]
+}
+
+// scheduleCallback delivers the simulated provider callback after a delay
+func (p *processor) scheduleCallback(transactionID uuid.UUID, processorRef string, status txEntity.TransactionStatus, outcome Outcome, delay time.Duration) {
+	time.Sleep(delay)
+
+	p.mu.RLock()
+	dispatcher := p.dispatcher
+	p.mu.RUnlock()
+	if dispatcher == nil {
+		p.log.Warn("No dispatcher configured; dropping simulated callback", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return
+	}
+
+	providerData, _ := json.Marshal(map[string]interface{}{
+		"simulated": true,
+		"outcome":   outcome,
+	})
+	message := "Simulated payment completed"
+	if status == txEntity.FAILED {
+		message = "Simulated payment failed"
+	}
+
+	p.statuses.Store(transactionID.String(), status)
+	p.statuses.Store(processorRef, status)
+	err := dispatcher.HandleWebhookDispatch(context.Background(), webhookDto.WebhookRequest{
+		TransactionID: transactionID.String(),
+		Status:        string(status),
+		Message:       message,
+		ProviderTxID:  processorRef,
+		ProviderData:  string(providerData),
+		Timestamp:     time.Now(),
+	})
+	if err != nil {
+		p.log.Error("Failed to dispatch simulated callback", map[string]interface{}{
+			"transaction_id": transactionID,
+			"error":          err.Error(),
+		})
+	}
+}
@@ -47,6 +47,9 @@ func NewProcessor(config ProcessorConfig) payment.Processor {
 			config.BaseURL = "https://api.telebirr.com"
 		}
 	}
+	if config.CallbackURL == "" {
+		config.CallbackURL = os.Getenv("APP_URL_V2") + "/api/v2/settle/std"
+	}
 
 	return &processor{
 		securityCredential: config.SecurityCredential,
@@ -73,7 +76,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 		"conversation_id":    req.TransactionID.String(),
 		"thirdPartyID":       "social-Pay",
 		"password":           p.password,
-		"resultURL":          os.Getenv("APP_URL_V2") + "/api/v2/settle/std",
+		"resultURL":          p.callbackURL,
 		"timestamp":          time.Now().Format("20060102150405"),
 		"identifier_type":    12,
 		"identifier":         p.identityID,
@@ -194,7 +197,7 @@ func (p *processor) InitiatePayment(ctx context.Context, apikey string, req *pay
 	}
 
 	return &payment.PaymentResponse{
-		Success:       telebirrResp.Success,
+		Success:       telebirrResp.Success && status != txEntity.FAILED,
 		TransactionID: req.TransactionID,
 		Status:        status,
 		ProcessorRef:  telebirrResp.Data.ConversationID,
@@ -362,12 +365,12 @@ func (p *processor) InitiateWithdrawal(ctx context.Context, apikey string, req *
 
 	// Map response status
 	status := txEntity.PENDING
-	if resp.StatusCode != 200 {
+	if resp.StatusCode != http.StatusOK || !telebirrResp.Success {
 		status = txEntity.FAILED
 	}
 
 	return &payment.PaymentResponse{
-		Success:       telebirrResp.Success,
+		Success:       status != txEntity.FAILED,
 		TransactionID: req.TransactionID,
 		Status:        status,
 		ProcessorRef:  req.TransactionID.String(),
@@ -386,8 +389,8 @@ func maskAPIKey(key string) string {
 }
 
 func (p *processor) QueryTransactionStatus(ctx context.Context, transactionID string) (*payment.TransactionStatusQueryResponse, error) {
-	p.log.Info("Querying Cybersource transaction status", map[string]interface{}{
+	p.log.Info("Querying Telebirr transaction status", map[string]interface{}{
 		"transaction_id": transactionID,
 	})
-	return nil, nil
+	return nil, fmt.Errorf("Telebirr transaction status query: %w", payment.ErrNotSupported)
 }
@@ -0,0 +1,60 @@
+package telebirr
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/paymenttest"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func TestProcessorConformance(t *testing.T) {
+	paymenttest.Run(t, paymenttest.Spec{
+		Medium: txEntity.TELEBIRR,
+		New: func(t *testing.T, baseURL string) payment.Processor {
+			return NewProcessor(ProcessorConfig{
+				SecurityCredential: "test-credential",
+				Password:           "test-password",
+				IsTestMode:         true,
+				ShortCode:          "514377",
+				IdentityID:         "51437702",
+				BaseURL:            baseURL,
+				CallbackURL:        "https://api.example.com/api/v2/settle/std",
+			})
+		},
+		Initiate: paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/api/account/telebirr/ussd-push",
+			Body:   paymenttest.Fixture(t, "ussd_push_accepted.json"),
+		},
+		InitiateDeclined: paymenttest.Exchange{
+			Path: "/api/account/telebirr/ussd-push",
+			Body: paymenttest.Fixture(t, "ussd_push_declined.json"),
+		},
+		Withdrawal: &paymenttest.Exchange{
+			Method: http.MethodPost,
+			Path:   "/api/accounts/telebirr/payment/b2c",
+			Body:   paymenttest.Fixture(t, "b2c_accepted.json"),
+		},
+		WithdrawalDeclined: &paymenttest.Exchange{
+			Path: "/api/accounts/telebirr/payment/b2c",
+			Body: paymenttest.Fixture(t, "b2c_declined.json"),
+		},
+		Callbacks: []paymenttest.CallbackCase{
+			{
+				Name:    "completed",
+				Request: payment.CallbackRequest{Status: txEntity.SUCCESS, Metadata: map[string]interface{}{"message": "Completed"}},
+				Settled: true,
+			},
+			{
+				Name:    "failed",
+				Request: payment.CallbackRequest{Status: txEntity.FAILED, Metadata: map[string]interface{}{"message": "Insufficient balance"}},
+			},
+			{
+				Name:    "expired",
+				Request: payment.CallbackRequest{Status: txEntity.EXPIRED},
+			},
+		},
+	})
+}
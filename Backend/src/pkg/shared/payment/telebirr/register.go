@@ -0,0 +1,27 @@
+package telebirr
+
+import (
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment/registry"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+func init() {
+	registry.Register(registry.Factory{
+		Medium:       txEntity.TELEBIRR,
+		EnvPrefix:    "TELEBIRR",
+		Credentials:  []string{"short_code", "identity_id", "security_credential", "password"},
+		CallbackPath: "/settle/std",
+		New: func(s registry.Settings, _ registry.Dependencies) (payment.Processor, error) {
+			return NewProcessor(ProcessorConfig{
+				SecurityCredential: s.Credentials["security_credential"],
+				Password:           s.Credentials["password"],
+				IsTestMode:         s.TestMode,
+				ShortCode:          s.Credentials["short_code"],
+				IdentityID:         s.Credentials["identity_id"],
+				BaseURL:            s.BaseURL,
+				CallbackURL:        s.CallbackURL,
+			}), nil
+		},
+	})
+}
@@ -3,6 +3,7 @@ package gin
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,9 +13,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	accessListEntity "github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
 	apikeyEntity "github.com/socialpay/socialpay/src/pkg/apikey_mgmt/core/entity"
+	fxEntity "github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	limitsEntity "github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+	processorHealthEntity "github.com/socialpay/socialpay/src/pkg/processor_health/core/entity"
 	qrEntity "github.com/socialpay/socialpay/src/pkg/qr/core/entity"
 	qrUsecase "github.com/socialpay/socialpay/src/pkg/qr/usecase"
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/i18n"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
 	ginn "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
 	middleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
@@ -22,6 +29,7 @@ import (
 	"github.com/socialpay/socialpay/src/pkg/socialpayapi/core/entity"
 	"github.com/socialpay/socialpay/src/pkg/socialpayapi/usecase"
 	txnEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	v2MerchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
 	v2MerchantRepo "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/repository"
 	settlementdto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
 	webhookusecase "github.com/socialpay/socialpay/src/pkg/webhook/usecase"
@@ -29,7 +37,7 @@ import (
 
 var supportedMediumsDeposit = []txnEntity.TransactionMedium{txnEntity.MPESA, txnEntity.CBE, txnEntity.AWASH,
 	txnEntity.TELEBIRR, txnEntity.CYBERSOURCE, txnEntity.ETHSWITCH, txnEntity.KACHA}
-var supportedMediumsWithdrawal = []txnEntity.TransactionMedium{txnEntity.CBE, txnEntity.TELEBIRR, txnEntity.CYBERSOURCE, txnEntity.KACHA, txnEntity.MPESA}
+var supportedMediumsWithdrawal = txnEntity.PayoutMediums
 
 // @title           SocialPay Payment API
 // @version         1.0
@@ -92,20 +100,24 @@ func (h *Handler) RegisterRoutes(r gin.IRouter) {
 	api := r.Group("/payment")
 	{
 		// Payment processing endpoints require payment processing permission
-		api.POST("/direct", *h.middleware, middleware.RequirePaymentProcessingPermission(), h.DirectPay)
+		api.POST("/direct", *h.middleware, middleware.RequirePaymentProcessingPermission(), middleware.RiskSignals(), h.DirectPay)
 		api.POST("/checkout", *h.middleware, middleware.RequirePaymentProcessingPermission(), h.Checkout)
 		api.PATCH("/checkout/:id", *h.middleware, middleware.RequirePaymentProcessingPermission(), h.UpdateCheckout)
 
 		api.GET("/transaction/:id", h.GetTransaction)
+		// Authorize-only card payments are captured or voided by the merchant
+		api.POST("/transaction/:id/capture", *h.middleware, middleware.RequirePaymentProcessingPermission(), h.CapturePayment)
+		api.POST("/transaction/:id/void", *h.middleware, middleware.RequirePaymentProcessingPermission(), h.VoidPayment)
 		// Withdrawal endpoints require withdrawal permission
-		api.POST("/withdrawal", *h.middleware, middleware.RequireWithdrawalPermission(), h.RequestWithdrawal)
+		api.POST("/withdrawal", *h.middleware, middleware.RequireWithdrawalPermission(), middleware.RiskSignals(), h.RequestWithdrawal)
 	}
 
 	// Checkout payment endpoint (no authentication required for hosted checkout)
 	checkout := r.Group("/checkout", middleware.CheckoutCors)
 	{
 		checkout.GET("/:id", h.GetHostedCheckout)
-		checkout.POST("/makepayment", h.CheckoutPayment)
+		checkout.POST("/makepayment", middleware.RiskSignals(), h.CheckoutPayment)
+		checkout.POST("/:id/quote", h.CheckoutQuote)
 	}
 }
 
@@ -120,9 +132,9 @@ func (h *Handler) RegisterQRRoutes(r gin.IRouter) {
 	{
 		// QR Payment endpoints (public for customer use)
 		qr.GET("/link/:id", h.GetQRLinkForPayment)
-		qr.POST("/link/:id", h.ProcessQRPayment)
+		qr.POST("/link/:id", middleware.RiskSignals(), h.ProcessQRPayment)
 
-		qr.POST("/merchant", h.QRMerchantPayment)
+		qr.POST("/merchant", middleware.RiskSignals(), h.QRMerchantPayment)
 	}
 
 	// QR callback endpoint (no CORS restrictions)
@@ -132,7 +144,7 @@ func (h *Handler) RegisterQRRoutes(r gin.IRouter) {
 
 // DirectPay godoc
 // @Summary      Process a direct payment
-// @Description  Process a direct payment transaction with the specified details
+// @Description  Process a direct payment transaction with the specified details. Payments flagged by the merchant's risk rules are held in PENDING_REVIEW and only sent to the processor once an admin clears them. Suspended and terminated merchants cannot accept payments and are refused with 403 and a MERCHANT_SUSPENDED or MERCHANT_TERMINATED code.
 // @Tags         Payments
 // @Accept       json
 // @Produce      json
@@ -141,7 +153,9 @@ func (h *Handler) RegisterQRRoutes(r gin.IRouter) {
 // @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  ErrorResponse
 // @Failure      403  {object}  ErrorResponse
+// @Failure      422  {object}  LimitExceededResponse
 // @Failure      500  {object}  ErrorResponse
+// @Failure      503  {object}  MediumUnavailableResponse
 // @Security     ApiKeyAuth
 // @Router       /payment/direct [post]
 func (h *Handler) DirectPay(c *gin.Context) {
@@ -208,7 +222,7 @@ func (h *Handler) DirectPay(c *gin.Context) {
 			"amount":   req.Amount,
 			"currency": req.Currency,
 		})
-		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		paymentErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -223,7 +237,7 @@ func (h *Handler) DirectPay(c *gin.Context) {
 
 // Checkout godoc
 // @Summary      Create hosted checkout
-// @Description  Create a hosted checkout session for payment processing
+// @Description  Create a hosted checkout session for payment processing. Suspended and terminated merchants are refused with 403 and a MERCHANT_SUSPENDED or MERCHANT_TERMINATED code.
 // @Tags         Payments
 // @Accept       json
 // @Produce      json
@@ -285,7 +299,7 @@ func (h *Handler) Checkout(c *gin.Context) {
 		h.log.Error("Hosted checkout creation failed", map[string]interface{}{
 			"error": err.Error(),
 		})
-		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		paymentErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -299,15 +313,18 @@ func (h *Handler) Checkout(c *gin.Context) {
 
 // CheckoutPayment godoc
 // @Summary      Process payment from hosted checkout
-// @Description  Process payment from hosted checkout page with selected medium and phone number
+// @Description  Process payment from hosted checkout page with selected medium and phone number. Payments flagged by the merchant's risk rules are held in PENDING_REVIEW until an admin clears them.
 // @Tags         Payments
 // @Accept       json
 // @Produce      json
 // @Param        request body entity.CheckoutPaymentRequest true "Checkout payment request details"
 // @Success      200  {object}  entity.PaymentResponse
 // @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
 // @Failure      404  {object}  ErrorResponse
+// @Failure      422  {object}  LimitExceededResponse
 // @Failure      500  {object}  ErrorResponse
+// @Failure      503  {object}  MediumUnavailableResponse
 // @Router       /checkout/makepayment [post]
 func (h *Handler) CheckoutPayment(c *gin.Context) {
 	h.log.Info("Starting checkout payment processing", map[string]interface{}{})
@@ -349,7 +366,7 @@ func (h *Handler) CheckoutPayment(c *gin.Context) {
 		h.log.Error("Checkout payment processing failed", map[string]interface{}{
 			"error": err.Error(),
 		})
-		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		paymentErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -368,6 +385,7 @@ func (h *Handler) CheckoutPayment(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Transaction ID"
+// @Param        lang query     string  false "Preferred language (en, am, om)"
 // @Success      200  {object}  entity.TransactionResponseDTO
 // @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  ErrorResponse
@@ -421,6 +439,10 @@ func (h *Handler) GetReceipt(c *gin.Context) {
 		Merchant:        tx.Merchant,
 	}
 
+	locale := h.paymentUseCase.ResolveLocale(c.Request.Context(), tx.MerchantId, c.Query("lang"))
+	response.Locale = string(locale)
+	response.Labels = i18n.Messages(locale, "receipt.")
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -468,8 +490,9 @@ func (h *Handler) GetTransaction(c *gin.Context) {
 		if tx.Medium == "CBE" {
 			id = tx.Id.String()
 		}
-		queryResp, err := h.paymentUseCase.QueryTransactionStatus(c.Request.Context(), tx.Medium, id)
-		if err != nil {
+		queryResp, err := h.paymentUseCase.QueryTransactionStatus(payment.WithTestMode(c.Request.Context(), tx.Test), tx.MerchantId, tx.Medium, id)
+		// Providers without a status API settle through callbacks only
+		if err != nil && !errors.Is(err, payment.ErrNotSupported) {
 			c.JSON(http.StatusBadRequest, newErrorResponse(err))
 			return
 		}
@@ -515,6 +538,17 @@ func (h *Handler) GetTransaction(c *gin.Context) {
 		FailedURL:       tx.FailedURL,
 	}
 
+	if tx.Type == txnEntity.WITHDRAWAL {
+		approval, err := h.paymentUseCase.WithdrawalApproval(c.Request.Context(), tx.Id)
+		if err != nil {
+			h.log.Error("Failed to get withdrawal approval", map[string]interface{}{
+				"transaction_id": tx.Id,
+				"error":          err.Error(),
+			})
+		}
+		response.Approval = approval
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -554,9 +588,123 @@ func (h *Handler) dispatchWebhookSettlement(c *gin.Context, transactionID uuid.U
 	})
 }
 
+// CapturePayment godoc
+// @Summary      Capture an authorized payment
+// @Description  Capture all or part of an authorize-only card payment. The merchant wallet is credited with the captured amount. When the capture is collected but crediting the wallet is still outstanding, the status is SETTLEMENT_PENDING and the credit is retried automatically.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                        true   "SocialPay transaction ID"
+// @Param        request body      entity.CapturePaymentRequest  false  "Capture amount, defaults to the authorized amount"
+// @Success      200  {object}  entity.PaymentResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /payment/transaction/{id}/capture [post]
+func (h *Handler) CapturePayment(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid transaction ID")))
+		return
+	}
+
+	var req entity.CapturePaymentRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	apiKeyData, _ := c.Get("apiKey")
+	apiKey, _ := apiKeyData.(*apikeyEntity.APIKeyResponse)
+
+	resp, err := h.paymentUseCase.CapturePayment(c.Request.Context(), apiKey.MerchantID, transactionID, &req)
+	if err != nil {
+		h.log.Error("Capture failed", map[string]interface{}{
+			"transaction_id": transactionID,
+			"error":          err.Error(),
+		})
+		c.JSON(authorizationErrorStatus(err), newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// VoidPayment godoc
+// @Summary      Void an authorized payment
+// @Description  Release the card hold of an authorize-only payment that was not captured
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                     true   "SocialPay transaction ID"
+// @Param        request body      entity.VoidPaymentRequest  false  "Void reason"
+// @Success      200  {object}  entity.PaymentResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Security     ApiKeyAuth
+// @Router       /payment/transaction/{id}/void [post]
+func (h *Handler) VoidPayment(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid transaction ID")))
+		return
+	}
+
+	var req entity.VoidPaymentRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	apiKeyData, _ := c.Get("apiKey")
+	apiKey, _ := apiKeyData.(*apikeyEntity.APIKeyResponse)
+
+	resp, err := h.paymentUseCase.VoidPayment(c.Request.Context(), apiKey.MerchantID, transactionID, &req)
+	if err != nil {
+		h.log.Error("Void failed", map[string]interface{}{
+			"transaction_id": transactionID,
+			"error":          err.Error(),
+		})
+		c.JSON(authorizationErrorStatus(err), newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// authorizationErrorStatus maps capture and void errors to HTTP status codes
+func authorizationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrAuthorizationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrAuthorizationClosed), errors.Is(err, usecase.ErrAuthorizationExpired):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 // RequestWithdrawal godoc
 // @Summary      Request a withdrawal
-// @Description  Process a withdrawal request for the specified amount
+// @Description  Process a withdrawal request for the specified amount, either to a medium and phone or account number, or to a saved beneficiary by beneficiary_id. Live payouts to a beneficiary added less than 24 hours ago are refused. Withdrawals flagged by the merchant's risk rules are held in PENDING_REVIEW until an admin reviews them, and blocked ones, like payouts to a blocklisted phone number, are refused with 403. Withdrawals over the merchant's transaction limits are refused with 422. Terminated merchants cannot withdraw, and withdrawals of suspended merchants are held for review.
 // @Tags         Payments
 // @Accept       json
 // @Produce      json
@@ -565,6 +713,7 @@ func (h *Handler) dispatchWebhookSettlement(c *gin.Context, transactionID uuid.U
 // @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  ErrorResponse
 // @Failure      403  {object}  ErrorResponse
+// @Failure      422  {object}  LimitExceededResponse
 // @Failure      500  {object}  ErrorResponse
 // @Security     ApiKeyAuth
 // @Router       /payment/withdrawal [post]
@@ -595,7 +744,8 @@ func (h *Handler) RequestWithdrawal(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, newErrorResponse(err))
 		return
 	}
-	if !slices.Contains(supportedMediumsWithdrawal, req.Medium) {
+	// Payouts to a saved beneficiary take the medium of the beneficiary
+	if req.BeneficiaryID == nil && !slices.Contains(supportedMediumsWithdrawal, req.Medium) {
 		h.log.Error("Unsupported medium", map[string]interface{}{
 			"error": "Unsupported medium",
 		})
@@ -613,7 +763,7 @@ func (h *Handler) RequestWithdrawal(c *gin.Context) {
 	// in a transaction-safe way using row-level locking
 	resp, err := h.paymentUseCase.RequestWithdrawal(c.Request.Context(), apiKeyHeader, userID, merchantID, &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		paymentErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -629,7 +779,7 @@ type ErrorResponse struct {
 
 // QRMerchantPayment godoc
 // @Summary      Process a QR payment for merchant
-// @Description  Process a QR payment transaction from checkout page
+// @Description  Process a QR payment transaction from checkout page. Payments flagged by the merchant's risk rules are held in PENDING_REVIEW until an admin clears them.
 // @Tags         QR-Payments
 // @Accept       json
 // @Produce      json
@@ -637,6 +787,7 @@ type ErrorResponse struct {
 // @Success      200  {object}  entity.QRPaymentResponse
 // @Failure      400  {object}  ErrorResponse
 // @Failure      403  {object}  ErrorResponse
+// @Failure      422  {object}  LimitExceededResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /qr/payment/merchant [post]
 func (h *Handler) QRMerchantPayment(c *gin.Context) {
@@ -763,7 +914,7 @@ func (h *Handler) QRMerchantPayment(c *gin.Context) {
 			"currency":    currency,
 			"merchant_id": req.MerchantID,
 		})
-		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		paymentErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -854,6 +1005,116 @@ func newErrorResponse(err error) ErrorResponse {
 	}
 }
 
+// MediumUnavailableResponse is returned when the selected medium cannot take payments right now
+// @Description Error response suggesting another payment medium
+type MediumUnavailableResponse struct {
+	Success           bool                         `json:"success"`
+	Message           string                       `json:"message" example:"TELEBIRR is temporarily unavailable, please try another payment method"`
+	Medium            txnEntity.TransactionMedium  `json:"medium" example:"TELEBIRR"`
+	AlternativeMedium *txnEntity.TransactionMedium `json:"alternative_medium,omitempty" example:"CBE"`
+}
+
+// LimitExceededResponse is returned when a transaction would exceed a limit of its merchant
+// @Description Error response naming the limit that was exceeded
+type LimitExceededResponse struct {
+	Success bool                             `json:"success"`
+	Message string                           `json:"message" example:"daily collection limit of 50000.00 ETB exceeded: 48000.00 used, 5000.00 requested"`
+	Limit   *limitsEntity.LimitExceededError `json:"limit"`
+}
+
+// MerchantUnavailableResponse is returned when the merchant's status refuses the transaction
+// @Description Error response with the merchant status error code
+type MerchantUnavailableResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message" example:"merchant is suspended and cannot accept payments"`
+	Code    string `json:"code" example:"MERCHANT_SUSPENDED"`
+}
+
+// paymentErrorResponse responds 503 with a suggested alternative when the medium is unavailable,
+// 403 when the risk rules or access lists blocked the transaction or the merchant is suspended or
+// terminated, and 422 when it exceeds a transaction limit
+func paymentErrorResponse(c *gin.Context, status int, err error) {
+	if errors.Is(err, riskEntity.ErrBlocked) || errors.Is(err, accessListEntity.ErrBlocked) {
+		c.JSON(http.StatusForbidden, newErrorResponse(err))
+		return
+	}
+	var ineligible *v2MerchantEntity.IneligibleError
+	if errors.As(err, &ineligible) {
+		c.JSON(http.StatusForbidden, MerchantUnavailableResponse{
+			Success: false,
+			Message: ineligible.Error(),
+			Code:    ineligible.Code,
+		})
+		return
+	}
+	var exceeded *limitsEntity.LimitExceededError
+	if errors.As(err, &exceeded) {
+		c.JSON(http.StatusUnprocessableEntity, LimitExceededResponse{
+			Success: false,
+			Message: exceeded.Error(),
+			Limit:   exceeded,
+		})
+		return
+	}
+	var unavailable *processorHealthEntity.MediumUnavailableError
+	if errors.As(err, &unavailable) {
+		c.JSON(http.StatusServiceUnavailable, MediumUnavailableResponse{
+			Success:           false,
+			Message:           unavailable.Message,
+			Medium:            unavailable.Medium,
+			AlternativeMedium: unavailable.Alternative,
+		})
+		return
+	}
+	c.JSON(status, newErrorResponse(err))
+}
+
+// CheckoutQuote godoc
+// @Summary      Quote a hosted checkout in another currency
+// @Description  Lock the price of a hosted checkout in the currency the payer pays in. Pass the quote's ID as fx_quote_id to /checkout/makepayment before it expires; the merchant receives the checkout amount at the locked rate.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string                 true  "Hosted Checkout ID"
+// @Param        request  body  fxEntity.QuoteRequest  true  "Currency to pay in"
+// @Success      201  {object}  fxEntity.Quote
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /checkout/{id}/quote [post]
+func (h *Handler) CheckoutQuote(c *gin.Context) {
+	hostedCheckoutID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid hosted checkout ID")))
+		return
+	}
+
+	var req fxEntity.QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	quote, err := h.paymentUseCase.QuoteCheckout(c.Request.Context(), hostedCheckoutID, &req)
+	if err != nil {
+		h.log.Error("Failed to quote hosted checkout", map[string]interface{}{
+			"hosted_checkout_id": hostedCheckoutID,
+			"error":              err.Error(),
+		})
+		status := http.StatusBadRequest
+		if errors.Is(err, fxEntity.ErrRateNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, newErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, quote)
+}
+
 // GetHostedCheckout godoc
 // @Summary      Get hosted checkout details
 // @Description  Retrieve hosted checkout details by ID for the checkout page
@@ -861,8 +1122,10 @@ func newErrorResponse(err error) ErrorResponse {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Hosted Checkout ID"
+// @Param        lang query     string  false "Preferred language (en, am, om)"
 // @Success      200  {object}  entity.HostedCheckoutWithMerchantResponseDTO
 // @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  MerchantUnavailableResponse
 // @Failure      404  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /checkout/{id} [get]
@@ -882,12 +1145,16 @@ func (h *Handler) GetHostedCheckout(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	hostedCheckout, err := h.paymentUseCase.GetHostedCheckoutWithMerchant(ctx, hostedCheckoutID)
+	hostedCheckout, err := h.paymentUseCase.GetHostedCheckoutWithMerchant(ctx, hostedCheckoutID, c.Query("lang"))
 	if err != nil {
 		h.log.Error("Failed to get hosted checkout", map[string]interface{}{
 			"id":    hostedCheckoutID,
 			"error": err.Error(),
 		})
+		if errors.Is(err, v2MerchantEntity.ErrMerchantIneligible) {
+			paymentErrorResponse(c, http.StatusForbidden, err)
+			return
+		}
 		c.JSON(http.StatusNotFound, newErrorResponse(fmt.Errorf("hosted checkout not found")))
 		return
 	}
@@ -942,8 +1209,11 @@ func (h *Handler) GetQRLinkForPayment(c *gin.Context) {
 // @Param        request body      qrEntity.QRPaymentRequest   true  "QR payment request"
 // @Success      200  {object}  qrEntity.QRPaymentResponse
 // @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
 // @Failure      404  {object}  ErrorResponse
+// @Failure      422  {object}  LimitExceededResponse
 // @Failure      500  {object}  ErrorResponse
+// @Failure      503  {object}  MediumUnavailableResponse
 // @Router       /qr/payment/link/{id} [post]
 func (h *Handler) ProcessQRPayment(c *gin.Context) {
 	idStr := c.Param("id")
@@ -967,7 +1237,7 @@ func (h *Handler) ProcessQRPayment(c *gin.Context) {
 		h.log.Error("Failed to process QR payment", map[string]interface{}{
 			"error": err.Error(),
 		})
-		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+		paymentErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
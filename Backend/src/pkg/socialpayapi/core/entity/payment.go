@@ -8,8 +8,10 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
+	brandingEntity "github.com/socialpay/socialpay/src/pkg/checkout_branding/core/entity"
 	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+	approvalEntity "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/entity"
 )
 
 // DirectPaymentRequest represents the request for direct payment
@@ -52,11 +54,28 @@ type DirectPaymentRequest struct {
 	// Indicates who should pay the fee (true for merchant, false for customer)
 	// @Example false
 	MerchantPaysFee bool `json:"merchant_pays_fee" example:"false"`
+
+	// Optional saved customer; fills phone number and medium when omitted
+	// @Example 123e4567-e89b-12d3-a456-426614174000
+	CustomerID *uuid.UUID `json:"customer_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+
+	// Payer consented to be saved in the customer vault once the payment succeeds
+	// @Example false
+	SaveCustomer bool `json:"save_customer,omitempty" example:"false"`
+
+	// Optional payer name stored with the saved customer
+	// @Example Abebe Kebede
+	CustomerName string `json:"customer_name,omitempty" example:"Abebe Kebede"`
+
+	// Only authorize the card; funds are collected with a later capture or released with a void.
+	// Supported for CYBERSOURCE card payments.
+	// @Example false
+	AuthorizeOnly bool `json:"authorize_only,omitempty" example:"false"`
 }
 
 func (r DirectPaymentRequest) Validate() error {
 	return validation.ValidateStruct(&r,
-		validation.Field(&r.Medium, validation.Required, validation.In(
+		validation.Field(&r.Medium, validation.When(r.CustomerID == nil, validation.Required), validation.In(
 			entity.CYBERSOURCE,
 			entity.MPESA,
 			entity.TELEBIRR,
@@ -67,9 +86,16 @@ func (r DirectPaymentRequest) Validate() error {
 		validation.Field(&r.Amount, validation.Required, validation.Min(0.01)),
 		validation.Field(&r.Currency, validation.Required, validation.Length(3, 3)),
 		validation.Field(&r.Details, validation.Required),
-		validation.Field(&r.PhoneNumber, validation.Required, validation.Length(12, 12), validation.Match(regexp.MustCompile(`^251\d{9}$`))),
+		validation.Field(&r.PhoneNumber, validation.When(r.CustomerID == nil, validation.Required), validation.Length(12, 12), validation.Match(regexp.MustCompile(`^251\d{9}$`))),
 		validation.Field(&r.Redirects, validation.Required),
 		validation.Field(&r.CallbackURL, validation.Required, is.URL),
+		validation.Field(&r.CustomerName, validation.Length(0, 255)),
+		validation.Field(&r.AuthorizeOnly, validation.When(r.AuthorizeOnly && r.Medium != "", validation.By(func(interface{}) error {
+			if r.Medium != entity.CYBERSOURCE {
+				return fmt.Errorf("authorize_only is only supported for %s", entity.CYBERSOURCE)
+			}
+			return nil
+		}))),
 	)
 }
 
@@ -100,6 +126,10 @@ type HostedCheckoutRequest struct {
 	// @Example 251911111111
 	PhoneNumber string `json:"phone_number,omitempty" example:"251911111111"`
 
+	// Optional saved customer; pre-fills the phone number and puts the preferred medium first
+	// @Example 123e4567-e89b-12d3-a456-426614174000
+	CustomerID *uuid.UUID `json:"customer_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+
 	// URLs for payment redirects
 	Redirects entity.TransactionRedirects `json:"redirects"`
 
@@ -165,6 +195,15 @@ type CheckoutPaymentRequest struct {
 
 	// Tip payment method (required if tip amount > 0)
 	TipMedium *entity.TransactionMedium `json:"tip_medium,omitempty" example:"TELEBIRR"`
+
+	// Payer consented to be saved for faster repeat payments
+	SaveCustomer bool `json:"save_customer,omitempty" example:"false"`
+
+	// Optional payer name stored with the saved customer
+	CustomerName string `json:"customer_name,omitempty" example:"Abebe Kebede"`
+
+	// Optional FX quote to pay the checkout in another currency at its locked rate
+	FXQuoteID *uuid.UUID `json:"fx_quote_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
 }
 
 func (r CheckoutPaymentRequest) Validate() error {
@@ -180,6 +219,7 @@ func (r CheckoutPaymentRequest) Validate() error {
 			entity.AWASH,
 		)),
 		validation.Field(&r.PhoneNumber, validation.Required, validation.Length(12, 12), validation.Match(regexp.MustCompile(`^251\d{9}$`))),
+		validation.Field(&r.CustomerName, validation.Length(0, 255)),
 	)
 }
 
@@ -253,6 +293,13 @@ type HostedCheckoutWithMerchantResponseDTO struct {
 	Merchant *merchantEntity.Merchant `json:"merchant,omitempty"`
 
 	AcceptTip bool `json:"accept_tip"`
+
+	// Resolved merchant checkout theme
+	Theme *brandingEntity.CheckoutTheme `json:"theme,omitempty"`
+
+	// Resolved locale and translated checkout strings
+	Locale   string            `json:"locale" example:"en"`
+	Messages map[string]string `json:"messages,omitempty"`
 }
 
 // PaymentResponse represents the response for payment operations
@@ -278,6 +325,45 @@ type PaymentResponse struct {
 
 	// MerchantPays fee flag
 	MerchantPaysFee bool `json:"merchant_pays_fee" example:"false"`
+
+	// When an authorize-only payment must be captured before it is voided
+	AuthorizationExpiresAt *time.Time `json:"authorization_expires_at,omitempty" example:"2024-05-10T10:00:00Z"`
+
+	// Approval a withdrawal is held for by the merchant's payout policy
+	Approval *approvalEntity.Approval `json:"approval,omitempty"`
+}
+
+// CaptureSettlementPending is the status of a capture the processor collected whose wallet
+// credit is still being applied; settlement is retried until the payment reaches SUCCESS
+const CaptureSettlementPending = "SETTLEMENT_PENDING"
+
+// CapturePaymentRequest represents the request to capture an authorized card payment
+// @Description Capture request for an authorized payment
+type CapturePaymentRequest struct {
+	// Amount to capture; defaults to the full authorized amount. Only one capture is allowed,
+	// any remainder of a partial capture is released.
+	// @Example 750.00
+	Amount *float64 `json:"amount,omitempty" example:"750.00"`
+}
+
+func (r CapturePaymentRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Amount, validation.When(r.Amount != nil, validation.Min(0.01))),
+	)
+}
+
+// VoidPaymentRequest represents the request to void an authorized card payment
+// @Description Void request for an authorized payment
+type VoidPaymentRequest struct {
+	// Reason for releasing the hold
+	// @Example Order canceled by customer
+	Reason string `json:"reason,omitempty" example:"Order canceled by customer"`
+}
+
+func (r VoidPaymentRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Reason, validation.Length(0, 255)),
+	)
 }
 
 // WithdrawalRequest represents the request for withdrawal
@@ -300,6 +386,9 @@ type WithdrawalRequest struct {
 	// Bank account number
 	PhoneNumber string `json:"phone_number" example:"1234567890"`
 
+	// Saved beneficiary to pay out to, instead of medium and phone_number
+	BeneficiaryID *uuid.UUID `json:"beneficiary_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+
 	// Client-provided reference
 	Reference string `json:"reference" example:"WD123456789"`
 
@@ -312,7 +401,11 @@ func (r WithdrawalRequest) Validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.Amount, validation.Required, validation.Min(0.01)),
 		validation.Field(&r.Currency, validation.Required, validation.Length(3, 3)),
-		validation.Field(&r.PhoneNumber, validation.Required, validation.Length(5, 20)),
+		validation.Field(&r.PhoneNumber,
+			validation.When(r.BeneficiaryID == nil, validation.Required, validation.Length(5, 20)).
+				Else(validation.Empty.Error("must be empty when paying out to a beneficiary"))),
+		validation.Field(&r.Medium,
+			validation.When(r.BeneficiaryID != nil, validation.Empty.Error("must be empty when paying out to a beneficiary"))),
 		validation.Field(&r.CallbackURL, validation.Required, is.URL),
 		validation.Field(&r.Reference, validation.Required, validation.Length(3, 50)),
 	)
@@ -393,6 +486,11 @@ type TransactionResponseDTO struct {
 	FailedURL string `json:"failed_url" example:"https://example.com/failed"`
 	// Merchant information
 	Merchant *merchantEntity.Merchant `json:"merchant,omitempty"`
+	// Resolved locale and translated receipt labels
+	Locale string            `json:"locale,omitempty" example:"en"`
+	Labels map[string]string `json:"labels,omitempty"`
+	// Approval of a withdrawal held by the merchant's payout policy
+	Approval *approvalEntity.Approval `json:"approval,omitempty"`
 }
 
 type DirectPaymentResponse struct {
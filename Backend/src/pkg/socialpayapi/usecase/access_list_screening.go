@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	accessListEntity "github.com/socialpay/socialpay/src/pkg/access_list/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// screenAccessLists checks the parties to a stored transaction against the blocklist. A transaction
+// involving a blocked party, or one that could not be screened, is failed before it reaches the processor.
+func (uc *paymentUseCase) screenAccessLists(ctx context.Context, tx *txEntity.Transaction) error {
+	if uc.accessLists == nil {
+		return nil
+	}
+
+	block, err := uc.accessLists.Screen(ctx, tx)
+	var reason string
+	switch {
+	case err != nil:
+		uc.log.Error("Failed to screen transaction against access lists", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+		err = fmt.Errorf("failed to screen access lists: %w", err)
+		reason = err.Error()
+	case block == nil:
+		return nil
+	default:
+		err = accessListEntity.ErrBlocked
+		reason = fmt.Sprintf("%s blocked by access list entry %s (%s)", block.Type, block.ID, block.ReasonCode)
+	}
+
+	tx.Comment = err.Error()
+	if _, transitionErr := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceAccessList,
+		Actor:         "access_list",
+		Reason:        reason,
+	}); transitionErr != nil {
+		uc.log.Error("Failed to fail transaction refused by access list", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          transitionErr.Error(),
+		})
+	} else {
+		tx.Status = txEntity.FAILED
+	}
+
+	return err
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	socialPayEntity "github.com/socialpay/socialpay/src/pkg/socialpayapi/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// resolveBeneficiary fills the destination of a withdrawal to a saved beneficiary and returns the
+// transaction details recording it. Withdrawals to a raw destination have no details.
+func (uc *paymentUseCase) resolveBeneficiary(ctx context.Context, merchantID uuid.UUID, req *socialPayEntity.WithdrawalRequest) (map[string]interface{}, error) {
+	if req.BeneficiaryID == nil {
+		return nil, nil
+	}
+	if uc.beneficiaries == nil {
+		return nil, fmt.Errorf("payouts to beneficiaries are not available")
+	}
+
+	beneficiary, err := uc.beneficiaries.ResolvePayout(ctx, merchantID, *req.BeneficiaryID)
+	if err != nil {
+		uc.log.Warn("[Withdrawal] Beneficiary cannot be paid out to", map[string]interface{}{
+			"merchant_id":    merchantID,
+			"beneficiary_id": *req.BeneficiaryID,
+			"error":          err.Error(),
+		})
+		return nil, err
+	}
+
+	req.Medium = beneficiary.Medium
+	req.PhoneNumber = beneficiary.AccountNumber
+
+	details := map[string]interface{}{
+		txEntity.BeneficiaryDetailKey: beneficiary.ID.String(),
+	}
+	if beneficiary.BankCode != "" {
+		details[txEntity.BankCodeDetailKey] = beneficiary.BankCode
+	}
+	return details, nil
+}
+
+// payoutMetadata passes the bank of a bank account payout on to the processor
+func payoutMetadata(tx *txEntity.Transaction) map[string]interface{} {
+	bankCode := tx.BankCode()
+	if bankCode == "" {
+		return nil
+	}
+	return map[string]interface{}{txEntity.BankCodeDetailKey: bankCode}
+}
@@ -0,0 +1,453 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	socialPayEntity "github.com/socialpay/socialpay/src/pkg/socialpayapi/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepo "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	settlementdto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
+)
+
+const (
+	// expiredAuthorizationBatchSize bounds how many lapsed holds are voided per run
+	expiredAuthorizationBatchSize = 100
+	// unsettledCaptureBatchSize bounds how many captures are settled per run
+	unsettledCaptureBatchSize = 100
+	// unsettledCaptureGrace leaves a just-recorded capture to the request that is settling it
+	unsettledCaptureGrace = 5 * time.Minute
+)
+
+var (
+	ErrAuthorizationNotFound = errors.New("authorized payment not found")
+	ErrAuthorizationExpired  = errors.New("authorization has expired and can no longer be captured")
+	ErrAuthorizationClosed   = errors.New("payment is not awaiting capture")
+	ErrInvalidCaptureAmount  = errors.New("capture amount exceeds the authorized amount")
+)
+
+// CardAuthorizationService captures and voids authorize-only card payments.
+// The merchant wallet is only credited when a hold is captured; holds that are
+// not captured within their validity window are voided automatically.
+type CardAuthorizationService struct {
+	transactionRepo   txRepo.TransactionRepository
+	authorizationRepo txRepo.CardAuthorizationRepository
+	paymentService    PaymentProcessor
+	webhookDispatcher WebhookDispatcher
+	log               logging.Logger
+}
+
+func NewCardAuthorizationService(
+	transactionRepo txRepo.TransactionRepository,
+	authorizationRepo txRepo.CardAuthorizationRepository,
+	paymentService PaymentProcessor,
+	webhookDispatcher WebhookDispatcher,
+) *CardAuthorizationService {
+	return &CardAuthorizationService{
+		transactionRepo:   transactionRepo,
+		authorizationRepo: authorizationRepo,
+		paymentService:    paymentService,
+		webhookDispatcher: webhookDispatcher,
+		log:               logging.NewStdLogger("[CARD-AUTHORIZATION]"),
+	}
+}
+
+// RecordAuthorization stores the hold placed for an authorize-only payment
+func (s *CardAuthorizationService) RecordAuthorization(ctx context.Context, tx *txEntity.Transaction, resp *payment.PaymentResponse) error {
+	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+	if resp.AuthorizationExpiresAt != nil {
+		expiresAt = *resp.AuthorizationExpiresAt
+	}
+
+	authorization := &txEntity.CardAuthorization{
+		TransactionID:    tx.Id,
+		MerchantID:       tx.MerchantId,
+		Medium:           tx.Medium,
+		Status:           txEntity.CardAuthorizationAuthorized,
+		AuthorizedAmount: tx.CustomerNet,
+		Currency:         tx.Currency,
+		ExpiresAt:        expiresAt,
+	}
+
+	if err := s.authorizationRepo.Create(ctx, authorization); err != nil {
+		s.log.Error("Failed to record card authorization", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+		return err
+	}
+
+	return nil
+}
+
+// Capture collects all or part of an authorized payment and settles it to the merchant wallet
+func (s *CardAuthorizationService) Capture(ctx context.Context, merchantID, transactionID uuid.UUID, amount *float64) (*socialPayEntity.PaymentResponse, error) {
+	tx, authorization, err := s.load(ctx, merchantID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization.IsExpired() {
+		return nil, ErrAuthorizationExpired
+	}
+
+	captureAmount := authorization.AuthorizedAmount
+	if amount != nil {
+		captureAmount = RoundToTwoDecimals(*amount)
+		if captureAmount > authorization.AuthorizedAmount {
+			return nil, ErrInvalidCaptureAmount
+		}
+	}
+	partial := captureAmount < authorization.AuthorizedAmount
+	if partial && tx.HasTip {
+		return nil, fmt.Errorf("partial capture is not supported for payments with a tip")
+	}
+
+	claimed, err := s.authorizationRepo.Transition(ctx, tx.Id, txEntity.CardAuthorizationAuthorized, txEntity.CardAuthorizationCapturing)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrAuthorizationClosed
+	}
+
+	// The amounts are scaled before the processor call and saved with the capture, so the
+	// wallet is never credited with the authorized amounts after a partial capture
+	captured := *tx
+	if partial {
+		scaleToCapture(&captured, captureAmount/authorization.AuthorizedAmount)
+	}
+
+	resp, err := s.paymentService.CapturePayment(payment.WithTestMode(ctx, tx.Test), tx.Medium, &payment.CaptureRequest{
+		TransactionID: tx.Id,
+		MerchantID:    tx.MerchantId,
+		ProcessorRef:  tx.ProviderTxId,
+		Amount:        captureAmount,
+		Currency:      tx.Currency,
+	})
+	if err != nil {
+		s.release(ctx, tx.Id, txEntity.CardAuthorizationCapturing)
+		return nil, err
+	}
+
+	if err := s.authorizationRepo.MarkCaptured(ctx, &captured, captureAmount, resp.ProcessorRef); err != nil {
+		// The processor collected the money, so the authorization stays CAPTURING for an
+		// operator to reconcile rather than being released for a second capture
+		s.log.Error("Failed to record capture", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"amount":         captureAmount,
+			"capture_ref":    resp.ProcessorRef,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("capture was collected but could not be recorded: %w", err)
+	}
+	tx = &captured
+
+	message := "Authorization captured"
+	if partial {
+		message = fmt.Sprintf("Authorization partially captured: %.2f of %.2f", captureAmount, authorization.AuthorizedAmount)
+	}
+	status := string(txEntity.SUCCESS)
+	if err := s.dispatch(ctx, tx, txEntity.SUCCESS, message); err != nil {
+		// The money is collected; only the wallet credit is outstanding
+		s.log.Error("Failed to settle card capture, it is retried by the settlement job", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+		status = socialPayEntity.CaptureSettlementPending
+		message += ", settlement pending"
+	}
+
+	s.log.Info("Card authorization captured", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"amount":         captureAmount,
+		"capture_ref":    resp.ProcessorRef,
+	})
+
+	return &socialPayEntity.PaymentResponse{
+		Success:                true,
+		Status:                 status,
+		Message:                message,
+		Reference:              tx.Reference,
+		SocialPayTransactionID: tx.Id.String(),
+		MerchantPaysFee:        tx.MerchantPaysFee,
+	}, nil
+}
+
+// Void releases an authorized payment without collecting it
+func (s *CardAuthorizationService) Void(ctx context.Context, merchantID, transactionID uuid.UUID, reason string) (*socialPayEntity.PaymentResponse, error) {
+	tx, authorization, err := s.load(ctx, merchantID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		reason = "Voided by merchant"
+	}
+
+	if err := s.void(ctx, tx, authorization, reason, txEntity.CANCELED); err != nil {
+		return nil, err
+	}
+
+	return &socialPayEntity.PaymentResponse{
+		Success:                true,
+		Status:                 string(txEntity.CANCELED),
+		Message:                "Authorization voided",
+		Reference:              tx.Reference,
+		SocialPayTransactionID: tx.Id.String(),
+		MerchantPaysFee:        tx.MerchantPaysFee,
+	}, nil
+}
+
+// VoidExpiredAuthorizations voids holds that were not captured within their validity window
+func (s *CardAuthorizationService) VoidExpiredAuthorizations(ctx context.Context) error {
+	authorizations, err := s.authorizationRepo.GetExpired(ctx, expiredAuthorizationBatchSize)
+	if err != nil {
+		s.log.Error("Failed to get expired card authorizations", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return fmt.Errorf("failed to get expired card authorizations: %w", err)
+	}
+
+	voidedCount := 0
+	for i := range authorizations {
+		authorization := &authorizations[i]
+
+		tx, err := s.transactionRepo.GetByID(ctx, authorization.TransactionID)
+		if err != nil {
+			s.log.Error("Failed to load authorized transaction", map[string]interface{}{
+				"transaction_id": authorization.TransactionID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+
+		if err := s.void(ctx, tx, authorization, "Authorization expired without capture", txEntity.EXPIRED); err != nil {
+			s.log.Error("Failed to void expired authorization", map[string]interface{}{
+				"transaction_id": authorization.TransactionID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+		voidedCount++
+	}
+
+	s.log.Info("Completed voiding expired card authorizations", map[string]interface{}{
+		"total_found":  len(authorizations),
+		"total_voided": voidedCount,
+	})
+
+	return nil
+}
+
+// SettleCapturedAuthorizations settles captures that were not credited to the wallets, because
+// dispatching SUCCESS or applying it failed after the capture was recorded
+func (s *CardAuthorizationService) SettleCapturedAuthorizations(ctx context.Context) error {
+	authorizations, err := s.authorizationRepo.GetUnsettledCaptures(ctx, unsettledCaptureGrace, unsettledCaptureBatchSize)
+	if err != nil {
+		s.log.Error("Failed to get unsettled card captures", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return fmt.Errorf("failed to get unsettled card captures: %w", err)
+	}
+
+	settledCount := 0
+	for i := range authorizations {
+		authorization := &authorizations[i]
+
+		tx, err := s.transactionRepo.GetByID(ctx, authorization.TransactionID)
+		if err != nil {
+			s.log.Error("Failed to load captured transaction", map[string]interface{}{
+				"transaction_id": authorization.TransactionID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+		if tx.Status != txEntity.AUTHORIZED {
+			// Settlement marks the capture in the same database transaction as SUCCESS, so this
+			// one left the hold another way and needs an operator
+			s.log.Warn("Captured transaction is no longer authorized", map[string]interface{}{
+				"transaction_id": authorization.TransactionID,
+				"status":         tx.Status,
+			})
+			continue
+		}
+
+		message := "Authorization captured"
+		if authorization.CapturedAmount != nil && *authorization.CapturedAmount < authorization.AuthorizedAmount {
+			message = fmt.Sprintf("Authorization partially captured: %.2f of %.2f", *authorization.CapturedAmount, authorization.AuthorizedAmount)
+		}
+		if err := s.dispatch(ctx, tx, txEntity.SUCCESS, message); err != nil {
+			s.log.Error("Failed to settle card capture", map[string]interface{}{
+				"transaction_id": authorization.TransactionID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+		settledCount++
+	}
+
+	s.log.Info("Completed settling card captures", map[string]interface{}{
+		"total_found":   len(authorizations),
+		"total_settled": settledCount,
+	})
+
+	return nil
+}
+
+// void reverses the hold with the processor and closes the transaction with the given status.
+// Expired holds lapse at the issuer regardless, so a failed reversal still closes them.
+func (s *CardAuthorizationService) void(ctx context.Context, tx *txEntity.Transaction, authorization *txEntity.CardAuthorization, reason string, status txEntity.TransactionStatus) error {
+	claimed, err := s.authorizationRepo.Transition(ctx, tx.Id, txEntity.CardAuthorizationAuthorized, txEntity.CardAuthorizationVoiding)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrAuthorizationClosed
+	}
+
+	var providerRef string
+	resp, err := s.paymentService.VoidPayment(payment.WithTestMode(ctx, tx.Test), tx.Medium, &payment.VoidRequest{
+		TransactionID: tx.Id,
+		MerchantID:    tx.MerchantId,
+		ProcessorRef:  tx.ProviderTxId,
+		Amount:        authorization.AuthorizedAmount,
+		Currency:      authorization.Currency,
+		Reason:        reason,
+	})
+	if err != nil {
+		if status != txEntity.EXPIRED {
+			s.release(ctx, tx.Id, txEntity.CardAuthorizationVoiding)
+			return err
+		}
+		s.log.Warn("Reversal of expired authorization failed, closing it locally", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+		reason = fmt.Sprintf("%s (reversal failed: %s)", reason, err.Error())
+	} else {
+		providerRef = resp.ProcessorRef
+	}
+
+	if err := s.authorizationRepo.MarkVoided(ctx, tx.Id, reason, providerRef); err != nil {
+		s.log.Error("Failed to record void", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+	}
+
+	if err := s.dispatch(ctx, tx, status, reason); err != nil {
+		s.log.Error("Failed to dispatch card authorization status", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"status":         status,
+			"error":          err.Error(),
+		})
+	}
+
+	s.log.Info("Card authorization voided", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"status":         status,
+		"reason":         reason,
+	})
+
+	return nil
+}
+
+// load retrieves an AUTHORIZED transaction of the merchant with its authorization
+func (s *CardAuthorizationService) load(ctx context.Context, merchantID, transactionID uuid.UUID) (*txEntity.Transaction, *txEntity.CardAuthorization, error) {
+	tx, err := s.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil || tx.MerchantId != merchantID {
+		return nil, nil, ErrAuthorizationNotFound
+	}
+
+	if tx.Status != txEntity.AUTHORIZED {
+		return nil, nil, ErrAuthorizationClosed
+	}
+
+	authorization, err := s.authorizationRepo.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		return nil, nil, ErrAuthorizationNotFound
+	}
+
+	return tx, authorization, nil
+}
+
+// release returns a claimed authorization to AUTHORIZED after the processor call failed
+func (s *CardAuthorizationService) release(ctx context.Context, transactionID uuid.UUID, from txEntity.CardAuthorizationStatus) {
+	if _, err := s.authorizationRepo.Transition(ctx, transactionID, from, txEntity.CardAuthorizationAuthorized); err != nil {
+		s.log.Error("Failed to release card authorization", map[string]interface{}{
+			"transaction_id": transactionID,
+			"error":          err.Error(),
+		})
+	}
+}
+
+// scaleToCapture scales the transaction amounts to the captured share so the
+// wallet, commission and receipts reflect what was actually collected
+func scaleToCapture(tx *txEntity.Transaction, ratio float64) {
+	tx.BaseAmount = RoundToTwoDecimals(tx.BaseAmount * ratio)
+	tx.FeeAmount = RoundToTwoDecimals(tx.FeeAmount * ratio)
+	tx.AdminNet = RoundToTwoDecimals(tx.AdminNet * ratio)
+	tx.VatAmount = RoundToTwoDecimals(tx.VatAmount * ratio)
+	tx.MerchantNet = RoundToTwoDecimals(tx.MerchantNet * ratio)
+	tx.CustomerNet = RoundToTwoDecimals(tx.CustomerNet * ratio)
+	tx.TotalAmount = RoundToTwoDecimals(tx.TotalAmount * ratio)
+}
+
+// dispatch settles the new status through the webhook pipeline, which credits the
+// wallet on SUCCESS and notifies the merchant and payer
+func (s *CardAuthorizationService) dispatch(ctx context.Context, tx *txEntity.Transaction, status txEntity.TransactionStatus, message string) error {
+	if s.webhookDispatcher == nil {
+		return nil
+	}
+
+	// Expired holds are voided by the sweeper rather than the merchant
+	actor := tx.MerchantId.String()
+	if status == txEntity.EXPIRED {
+		actor = ""
+	}
+
+	err := s.webhookDispatcher.HandleWebhookDispatch(ctx, settlementdto.WebhookRequest{
+		TransactionID: tx.Id.String(),
+		Status:        string(status),
+		Message:       message,
+		Source:        txEntity.StatusSourceCardAuthorization,
+		Actor:         actor,
+		// Keep the authorization reference and callback data for refunds and the customer vault
+		ProviderTxID: tx.ProviderTxId,
+		ProviderData: callbackData(tx.ProviderData),
+		Timestamp:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dispatch card authorization status: %w", err)
+	}
+
+	return nil
+}
+
+// callbackData returns the raw provider callback stored with the transaction
+func callbackData(providerData interface{}) string {
+	data, ok := providerData.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	switch raw := data["data"].(type) {
+	case string:
+		return raw
+	case nil:
+		return ""
+	default:
+		encoded, _ := json.Marshal(raw)
+		return string(encoded)
+	}
+}
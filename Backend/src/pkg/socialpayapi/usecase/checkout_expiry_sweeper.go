@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepo "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	settlementdto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
+)
+
+// MerchantEventPublisher is an interface to avoid import cycles
+type MerchantEventPublisher interface {
+	PublishMerchantEvent(ctx context.Context, event settlementdto.WebhookEventMerchant) error
+}
+
+// CheckoutExpirySweeper closes hosted checkouts whose expiry has passed.
+// Expired sessions and their unpaid (INITIATED or PENDING) transactions are
+// moved to EXPIRED and the merchant is notified.
+type CheckoutExpirySweeper struct {
+	hostedPaymentRepo txRepo.HostedPaymentRepository
+	transactionRepo   txRepo.TransactionRepository
+	eventPublisher    MerchantEventPublisher
+	log               logging.Logger
+}
+
+func NewCheckoutExpirySweeper(
+	hostedPaymentRepo txRepo.HostedPaymentRepository,
+	transactionRepo txRepo.TransactionRepository,
+	eventPublisher MerchantEventPublisher,
+) *CheckoutExpirySweeper {
+	return &CheckoutExpirySweeper{
+		hostedPaymentRepo: hostedPaymentRepo,
+		transactionRepo:   transactionRepo,
+		eventPublisher:    eventPublisher,
+		log:               logging.NewStdLogger("[CHECKOUT-EXPIRY-SWEEPER]"),
+	}
+}
+
+// SweepExpiredCheckouts expires all pending hosted checkouts past their expiry
+func (s *CheckoutExpirySweeper) SweepExpiredCheckouts(ctx context.Context) error {
+	hostedPayments, err := s.hostedPaymentRepo.GetExpiredPayments(ctx)
+	if err != nil {
+		s.log.Error("Failed to get expired hosted payments", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return fmt.Errorf("failed to get expired hosted payments: %w", err)
+	}
+
+	s.log.Info("Found expired hosted payments", map[string]interface{}{
+		"count": len(hostedPayments),
+	})
+
+	expiredCount := 0
+	for i := range hostedPayments {
+		expired, err := s.expire(ctx, &hostedPayments[i])
+		if err != nil {
+			s.log.Error("Failed to expire hosted payment", map[string]interface{}{
+				"hosted_checkout_id": hostedPayments[i].ID,
+				"error":              err.Error(),
+			})
+			// Continue with the next session
+			continue
+		}
+		if expired {
+			expiredCount++
+		}
+	}
+
+	s.log.Info("Completed expiring hosted payments", map[string]interface{}{
+		"total_found":   len(hostedPayments),
+		"total_expired": expiredCount,
+	})
+
+	return nil
+}
+
+func (s *CheckoutExpirySweeper) expire(ctx context.Context, hostedPayment *txEntity.HostedPayment) (bool, error) {
+	expired, transactionID, err := s.hostedPaymentRepo.Expire(ctx, hostedPayment.ID)
+	if err != nil {
+		return false, err
+	}
+	if !expired {
+		// Completed or canceled between listing and expiring
+		return false, nil
+	}
+
+	event := settlementdto.WebhookEventMerchant{
+		Event:            settlementdto.EventCheckoutExpired,
+		ReferenceId:      hostedPayment.Reference,
+		Status:           string(txEntity.HostedPaymentExpired),
+		Amount:           fmt.Sprintf("%f", hostedPayment.Amount),
+		CallbackURL:      hostedPayment.CallbackURL,
+		Message:          "Hosted checkout expired before payment was completed",
+		Timestamp:        time.Now(),
+		MerchantID:       hostedPayment.MerchantID.String(),
+		UserID:           hostedPayment.UserID.String(),
+		HostedCheckoutID: hostedPayment.ID.String(),
+	}
+
+	if transactionID != nil {
+		event.SocialPayTxnID = transactionID.String()
+
+		tx, err := s.transactionRepo.GetByID(ctx, *transactionID)
+		if err != nil {
+			s.log.Error("Failed to load expired transaction", map[string]interface{}{
+				"transaction_id": transactionID,
+				"error":          err.Error(),
+			})
+		} else if tx.CallbackURL != "" && event.CallbackURL == "" {
+			event.CallbackURL = tx.CallbackURL
+		}
+	}
+
+	if s.eventPublisher != nil {
+		if err := s.eventPublisher.PublishMerchantEvent(ctx, event); err != nil {
+			s.log.Error("Failed to publish checkout expired event", map[string]interface{}{
+				"hosted_checkout_id": hostedPayment.ID,
+				"error":              err.Error(),
+			})
+		}
+	}
+
+	s.log.Info("Hosted payment expired", map[string]interface{}{
+		"hosted_checkout_id": hostedPayment.ID,
+		"merchant_id":        hostedPayment.MerchantID,
+		"transaction_id":     transactionID,
+	})
+
+	return true, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	fxEntity "github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// mediumCurrencies are the currencies each medium can charge in; mediums not listed charge in ETB only
+var mediumCurrencies = map[txEntity.TransactionMedium][]string{
+	txEntity.CYBERSOURCE: {"ETB", "USD"},
+}
+
+// mediumChargesIn reports whether a medium can charge a payer in the currency
+func mediumChargesIn(medium txEntity.TransactionMedium, currency string) bool {
+	currencies, ok := mediumCurrencies[medium]
+	if !ok {
+		return currency == txEntity.DefaultCurrency
+	}
+	for _, c := range currencies {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// QuoteCheckout locks the price of a pending hosted checkout in another currency
+func (uc *paymentUseCase) QuoteCheckout(ctx context.Context, hostedCheckoutID uuid.UUID, req *fxEntity.QuoteRequest) (*fxEntity.Quote, error) {
+	if uc.fx == nil {
+		return nil, fmt.Errorf("currency conversion is not available")
+	}
+
+	hostedPayment, err := uc.hostedPaymentRepo.GetByID(ctx, hostedCheckoutID)
+	if err != nil {
+		return nil, fmt.Errorf("hosted payment not found: %w", err)
+	}
+	if hostedPayment.Status != txEntity.HostedPaymentPending {
+		return nil, fmt.Errorf("hosted payment is no longer pending")
+	}
+	if time.Now().UTC().After(hostedPayment.ExpiresAt) {
+		return nil, fmt.Errorf("hosted payment has expired")
+	}
+
+	currency := hostedPayment.Currency
+	if currency == "" {
+		currency = txEntity.DefaultCurrency
+	}
+
+	return uc.fx.CreateQuote(ctx, hostedPayment.ID, hostedPayment.MerchantID, currency, hostedPayment.Amount, req.Currency, hostedPayment.ExpiresAt)
+}
+
+// checkoutQuote returns the quote a checkout payment is priced with, checked against the checkout and medium
+func (uc *paymentUseCase) checkoutQuote(ctx context.Context, hostedPayment *txEntity.HostedPayment, quoteID uuid.UUID, medium txEntity.TransactionMedium) (*fxEntity.Quote, error) {
+	if uc.fx == nil {
+		return nil, fmt.Errorf("currency conversion is not available")
+	}
+
+	quote, err := uc.fx.GetQuote(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.HostedCheckoutID != hostedPayment.ID {
+		return nil, fxEntity.ErrQuoteMismatch
+	}
+	if err := quote.Usable(time.Now()); err != nil {
+		return nil, err
+	}
+	if !mediumChargesIn(medium, quote.ChargeCurrency) {
+		return nil, fmt.Errorf("%s cannot charge in %s", medium, quote.ChargeCurrency)
+	}
+
+	return quote, nil
+}
+
+// withDetail returns transaction details with a key added
+func withDetail(details interface{}, key string, value interface{}) map[string]interface{} {
+	existing, _ := details.(map[string]interface{})
+	merged := make(map[string]interface{}, len(existing)+1)
+	for k, v := range existing {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
@@ -4,19 +4,38 @@ import (
 	"context"
 	"fmt"
 
+	disputeUsecase "github.com/socialpay/socialpay/src/pkg/dispute/usecase"
+	kycUsecase "github.com/socialpay/socialpay/src/pkg/kyc/usecase"
+	reserveUsecase "github.com/socialpay/socialpay/src/pkg/reserve/usecase"
+	riskUsecase "github.com/socialpay/socialpay/src/pkg/risk/usecase"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	approvalUsecase "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/usecase"
 	"github.com/robfig/cron/v3"
 )
 
 type CronService struct {
 	cron                     *cron.Cron
 	transactionStatusChecker *TransactionStatusChecker
+	checkoutExpirySweeper    *CheckoutExpirySweeper
+	cardAuthorizations       *CardAuthorizationService
+	withdrawalApprovals      approvalUsecase.WithdrawalApprovalUseCase
+	kyc                      kycUsecase.KYCUseCase
+	merchantScores           riskUsecase.MerchantScoreUseCase
+	reserves                 reserveUsecase.ReserveUseCase
+	disputes                 disputeUsecase.DisputeUseCase
 	log                      logging.Logger
 	ctx                      context.Context
 }
 
 func NewCronService(
 	transactionStatusChecker *TransactionStatusChecker,
+	checkoutExpirySweeper *CheckoutExpirySweeper,
+	cardAuthorizations *CardAuthorizationService,
+	withdrawalApprovals approvalUsecase.WithdrawalApprovalUseCase,
+	kyc kycUsecase.KYCUseCase,
+	merchantScores riskUsecase.MerchantScoreUseCase,
+	reserves reserveUsecase.ReserveUseCase,
+	disputes disputeUsecase.DisputeUseCase,
 	ctx context.Context,
 ) *CronService {
 	// Create cron with seconds support
@@ -25,6 +44,13 @@ func NewCronService(
 	return &CronService{
 		cron:                     cronInstance,
 		transactionStatusChecker: transactionStatusChecker,
+		checkoutExpirySweeper:    checkoutExpirySweeper,
+		cardAuthorizations:       cardAuthorizations,
+		withdrawalApprovals:      withdrawalApprovals,
+		kyc:                      kyc,
+		merchantScores:           merchantScores,
+		reserves:                 reserves,
+		disputes:                 disputes,
 		log:                      logging.NewStdLogger("[CRON-SERVICE]"),
 		ctx:                      ctx,
 	}
@@ -54,6 +80,148 @@ func (cs *CronService) Start() error {
 		return fmt.Errorf("failed to add transaction status checker job: %w", err)
 	}
 
+	// Add hosted checkout expiry sweeper job - runs every minute
+	if cs.checkoutExpirySweeper != nil {
+		_, err = cs.cron.AddFunc("0 * * * * *", func() {
+			if err := cs.checkoutExpirySweeper.SweepExpiredCheckouts(cs.ctx); err != nil {
+				cs.log.Error("Checkout expiry sweep failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add checkout expiry sweeper job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add checkout expiry sweeper job: %w", err)
+		}
+	}
+
+	// Add expired card authorization voiding job - runs every 15 minutes
+	if cs.cardAuthorizations != nil {
+		_, err = cs.cron.AddFunc("0 */15 * * * *", func() {
+			if err := cs.cardAuthorizations.VoidExpiredAuthorizations(cs.ctx); err != nil {
+				cs.log.Error("Expired authorization void failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add expired authorization void job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add expired authorization void job: %w", err)
+		}
+
+		// Settle captures whose wallet credit failed - runs every 5 minutes
+		_, err = cs.cron.AddFunc("0 */5 * * * *", func() {
+			if err := cs.cardAuthorizations.SettleCapturedAuthorizations(cs.ctx); err != nil {
+				cs.log.Error("Card capture settlement failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add card capture settlement job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add card capture settlement job: %w", err)
+		}
+	}
+
+	// Add withdrawal approval expiry job - runs every minute
+	if cs.withdrawalApprovals != nil {
+		_, err = cs.cron.AddFunc("0 * * * * *", func() {
+			if err := cs.withdrawalApprovals.ExpireOverdue(cs.ctx); err != nil {
+				cs.log.Error("Withdrawal approval expiry failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add withdrawal approval expiry job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add withdrawal approval expiry job: %w", err)
+		}
+	}
+
+	// Add KYC review SLA job - runs every 15 minutes
+	if cs.kyc != nil {
+		_, err = cs.cron.AddFunc("0 */15 * * * *", func() {
+			if err := cs.kyc.FlagOverdue(cs.ctx); err != nil {
+				cs.log.Error("KYC review SLA check failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add KYC review SLA job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add KYC review SLA job: %w", err)
+		}
+	}
+
+	// Add merchant risk scoring job - runs daily at 01:00 for the previous day
+	if cs.merchantScores != nil {
+		_, err = cs.cron.AddFunc("0 0 1 * * *", func() {
+			if err := cs.merchantScores.ScorePreviousDay(cs.ctx); err != nil {
+				cs.log.Error("Merchant risk scoring failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add merchant risk scoring job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add merchant risk scoring job: %w", err)
+		}
+	}
+
+	// Add reserve release job - runs every 15 minutes
+	if cs.reserves != nil {
+		_, err = cs.cron.AddFunc("0 */15 * * * *", func() {
+			if err := cs.reserves.ReleaseMatured(cs.ctx); err != nil {
+				cs.log.Error("Reserve release failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add reserve release job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add reserve release job: %w", err)
+		}
+	}
+
+	// Add dispute deadline job - runs every hour
+	if cs.disputes != nil {
+		_, err = cs.cron.AddFunc("0 5 * * * *", func() {
+			if err := cs.disputes.CloseOverdue(cs.ctx); err != nil {
+				cs.log.Error("Dispute deadline sweep failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		})
+
+		if err != nil {
+			cs.log.Error("Failed to add dispute deadline job", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return fmt.Errorf("failed to add dispute deadline job: %w", err)
+		}
+	}
+
 	// Add more cron jobs here in the future
 	// Example:
 	// _, err = cs.cron.AddFunc("@daily", func() {
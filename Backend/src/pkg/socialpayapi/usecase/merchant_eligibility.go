@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	merchantEntity "github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+// suspendedWithdrawalReason sends every withdrawal of a suspended merchant to the review queue
+var suspendedWithdrawalReason = riskEntity.Reason{
+	Rule:    riskEntity.RuleMerchantSuspended,
+	Outcome: riskEntity.OutcomeReview,
+	Detail:  "merchant is suspended",
+}
+
+// checkWithdrawalEligibility is called before a withdrawal is created. It returns the reasons
+// the withdrawal must be reviewed for, or an error when the merchant may not withdraw at all.
+func (uc *paymentUseCase) checkWithdrawalEligibility(ctx context.Context, merchantID uuid.UUID) ([]riskEntity.Reason, error) {
+	reviewRequired, err := uc.merchantUseCase.CheckEligibility(ctx, merchantID, merchantEntity.OperationWithdraw)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewRequired {
+		return nil, nil
+	}
+	// Without the review queue nobody can clear the withdrawal
+	if uc.risk == nil {
+		return nil, &merchantEntity.IneligibleError{
+			Code:      merchantEntity.CodeMerchantSuspended,
+			Status:    merchantEntity.StatusSuspended,
+			Operation: merchantEntity.OperationWithdraw,
+		}
+	}
+	return []riskEntity.Reason{suspendedWithdrawalReason}, nil
+}
+
+// ensureWithdrawalEligible checks the merchant status again right before a stored withdrawal is
+// sent, since it may have changed while the withdrawal waited for approval. A suspended merchant's
+// withdrawal only goes out once it was approved in risk review. A refused withdrawal is failed and
+// its funds unlocked.
+func (uc *paymentUseCase) ensureWithdrawalEligible(ctx context.Context, tx *txEntity.Transaction) error {
+	reviewRequired, err := uc.merchantUseCase.CheckEligibility(ctx, tx.MerchantId, merchantEntity.OperationWithdraw)
+	if err == nil && reviewRequired {
+		err = uc.requireApprovedReview(ctx, tx)
+	}
+	if err == nil {
+		return nil
+	}
+
+	tx.Comment = err.Error()
+	if _, transitionErr := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceMerchantStatus,
+		Actor:         "merchant_status",
+		Reason:        err.Error(),
+	}); transitionErr != nil {
+		uc.log.Error("[Withdrawal] Failed to fail withdrawal refused by merchant status", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          transitionErr.Error(),
+		})
+	} else {
+		tx.Status = txEntity.FAILED
+	}
+	uc.unlockWithdrawal(ctx, tx)
+
+	return err
+}
+
+func (uc *paymentUseCase) requireApprovedReview(ctx context.Context, tx *txEntity.Transaction) error {
+	suspended := &merchantEntity.IneligibleError{
+		Code:      merchantEntity.CodeMerchantSuspended,
+		Status:    merchantEntity.StatusSuspended,
+		Operation: merchantEntity.OperationWithdraw,
+	}
+	if uc.risk == nil {
+		return suspended
+	}
+
+	assessment, err := uc.risk.GetAssessmentByTransactionID(ctx, tx.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get withdrawal risk review: %w", err)
+	}
+	if assessment == nil || assessment.ReviewStatus != riskEntity.ReviewApproved {
+		return suspended
+	}
+	return nil
+}
@@ -2,9 +2,17 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	processorAccountEntity "github.com/socialpay/socialpay/src/pkg/processor_account/core/entity"
+	processorAccountUsecase "github.com/socialpay/socialpay/src/pkg/processor_account/usecase"
+	processorHealthEntity "github.com/socialpay/socialpay/src/pkg/processor_health/core/entity"
+	processorHealthUsecase "github.com/socialpay/socialpay/src/pkg/processor_health/usecase"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
 	"github.com/socialpay/socialpay/src/pkg/shared/payment"
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
@@ -14,59 +22,335 @@ import (
 type PaymentProcessor interface {
 	ProcessPayment(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error)
 	ProcessWithdrawal(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error)
-	QueryTransactionStatus(ctx context.Context, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error)
+	QueryTransactionStatus(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error)
+
+	// AuthorizePayment places a card hold through a processor that supports separate capture
+	AuthorizePayment(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error)
+	CapturePayment(ctx context.Context, medium txEntity.TransactionMedium, req *payment.CaptureRequest) (*payment.PaymentResponse, error)
+	VoidPayment(ctx context.Context, medium txEntity.TransactionMedium, req *payment.VoidRequest) (*payment.PaymentResponse, error)
+
+	// VerifyAccount looks up the holder of a payout destination through a processor that supports it.
+	// It wraps payment.ErrNotSupported when the medium's provider has no lookup.
+	VerifyAccount(ctx context.Context, req *payment.AccountVerificationRequest) (*payment.AccountVerificationResponse, error)
+
+	// AvailableMediums drops mediums that are disabled by an admin or whose processor is down, preserving order
+	AvailableMediums(ctx context.Context, mediums []txEntity.TransactionMedium) []txEntity.TransactionMedium
+
+	// CheckMediumAvailable returns a *MediumUnavailableError suggesting one of the alternatives when the medium cannot take payments
+	CheckMediumAvailable(ctx context.Context, medium txEntity.TransactionMedium, alternatives []txEntity.TransactionMedium) error
+}
+
+// ProcessorFactory builds a processor running on a merchant's own credentials
+type ProcessorFactory func(credentials map[string]string) (payment.Processor, error)
+
+// merchantProcessor is a processor built from a merchant's credentials, valid until the account changes
+type merchantProcessor struct {
+	processor payment.Processor
+	updatedAt time.Time
 }
 
 type paymentService struct {
 	processors map[txEntity.TransactionMedium]payment.Processor
-	log        logging.Logger
+	accounts   processorAccountUsecase.ProcessorAccountUseCase
+	factories  map[txEntity.TransactionMedium]ProcessorFactory
+	health     processorHealthUsecase.HealthUseCase
+
+	mu                 sync.Mutex
+	merchantProcessors map[uuid.UUID]merchantProcessor
+	log                logging.Logger
 }
 
-// NewPaymentService creates a new payment service instance
-func NewPaymentService(processors ...payment.Processor) PaymentProcessor {
+// NewPaymentService creates a new payment service instance. Payments are checked against the
+// merchant's enabled mediums and run on the merchant's own credentials when they have set them.
+// Live calls feed the health tracker, which fast-fails mediums whose provider is down.
+func NewPaymentService(accounts processorAccountUsecase.ProcessorAccountUseCase, factories map[txEntity.TransactionMedium]ProcessorFactory, health processorHealthUsecase.HealthUseCase, processors ...payment.Processor) PaymentProcessor {
 	processorMap := make(map[txEntity.TransactionMedium]payment.Processor)
 	for _, p := range processors {
 		processorMap[p.GetType()] = p
 	}
 	return &paymentService{
-		processors: processorMap,
-		log:        logging.NewStdLogger("[SOCIALPAY-API] [PAYMENT-SERVICE]"),
+		processors:         processorMap,
+		accounts:           accounts,
+		factories:          factories,
+		health:             health,
+		merchantProcessors: make(map[uuid.UUID]merchantProcessor),
+		log:                logging.NewStdLogger("[SOCIALPAY-API] [PAYMENT-SERVICE]"),
 	}
 }
 
 func (s *paymentService) ProcessPayment(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
 
-	processor, ok := s.processors[req.Medium]
-	if !ok {
+	processor, err := s.processor(ctx, req.MerchantID, req.Medium, true)
+	if err != nil {
 		s.log.Error("No payment processor available", map[string]interface{}{
 			"processor_type": req.Medium,
+			"merchant_id":    req.MerchantID,
+			"error":          err.Error(),
 		})
-		return nil, fmt.Errorf("no payment processor available")
+		return nil, err
+	}
+
+	if err := s.allow(ctx, req.Medium); err != nil {
+		return nil, err
 	}
 
 	// Round amount to atmost 2 decimal places
 	req.Amount = math.Round(req.Amount*100) / 100
 
-	return processor.InitiatePayment(ctx, apikey, req)
+	start := time.Now()
+	resp, err := processor.InitiatePayment(ctx, apikey, req)
+	s.record(ctx, req.Medium, "initiate_payment", start, err)
+	return resp, err
 }
 
-func (s *paymentService) QueryTransactionStatus(ctx context.Context, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error) {
-	processor, ok := s.processors[medium]
-	if !ok {
-		return nil, fmt.Errorf("no payment processor available")
+func (s *paymentService) QueryTransactionStatus(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error) {
+	processor, err := s.existingPaymentProcessor(ctx, merchantID, medium)
+	if err != nil {
+		return nil, err
 	}
 
-	return processor.QueryTransactionStatus(ctx, transactionID)
+	start := time.Now()
+	resp, err := processor.QueryTransactionStatus(ctx, transactionID)
+	s.record(ctx, medium, "query_status", start, err)
+	return resp, err
 }
 
 func (s *paymentService) ProcessWithdrawal(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
-	processor, ok := s.processors[req.Medium]
-	if !ok {
-		return nil, fmt.Errorf("no payment processor available")
+	// Payouts come out of the merchant's SocialPay wallet, so they always run on SocialPay's account
+	processor, err := s.processor(ctx, req.MerchantID, req.Medium, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.allow(ctx, req.Medium); err != nil {
+		return nil, err
 	}
 
 	// Round amount to atmost 2 decimal places
 	req.Amount = math.Round(req.Amount*100) / 100
 
-	return processor.InitiateWithdrawal(ctx, apikey, req)
+	start := time.Now()
+	resp, err := processor.InitiateWithdrawal(ctx, apikey, req)
+	s.record(ctx, req.Medium, "initiate_withdrawal", start, err)
+	return resp, err
+}
+
+func (s *paymentService) AuthorizePayment(ctx context.Context, apikey string, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
+	processor, err := s.processor(ctx, req.MerchantID, req.Medium, true)
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := s.cardAuthorizer(processor, req.Medium)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.allow(ctx, req.Medium); err != nil {
+		return nil, err
+	}
+
+	// Round amount to atmost 2 decimal places
+	req.Amount = math.Round(req.Amount*100) / 100
+
+	start := time.Now()
+	resp, err := authorizer.Authorize(ctx, apikey, req)
+	s.record(ctx, req.Medium, "authorize", start, err)
+	return resp, err
+}
+
+func (s *paymentService) CapturePayment(ctx context.Context, medium txEntity.TransactionMedium, req *payment.CaptureRequest) (*payment.PaymentResponse, error) {
+	processor, err := s.existingPaymentProcessor(ctx, req.MerchantID, medium)
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := s.cardAuthorizer(processor, medium)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Amount = math.Round(req.Amount*100) / 100
+
+	return authorizer.Capture(ctx, req)
+}
+
+func (s *paymentService) VoidPayment(ctx context.Context, medium txEntity.TransactionMedium, req *payment.VoidRequest) (*payment.PaymentResponse, error) {
+	processor, err := s.existingPaymentProcessor(ctx, req.MerchantID, medium)
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := s.cardAuthorizer(processor, medium)
+	if err != nil {
+		return nil, err
+	}
+
+	return authorizer.Void(ctx, req)
+}
+
+func (s *paymentService) VerifyAccount(ctx context.Context, req *payment.AccountVerificationRequest) (*payment.AccountVerificationResponse, error) {
+	// Payouts run on SocialPay's account, so lookups do too
+	processor, err := s.processor(ctx, req.MerchantID, req.Medium, false)
+	if err != nil {
+		return nil, err
+	}
+	verifier, ok := processor.(payment.AccountVerifier)
+	if !ok {
+		return nil, fmt.Errorf("%s account lookup: %w", req.Medium, payment.ErrNotSupported)
+	}
+	if err := s.allow(ctx, req.Medium); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := verifier.VerifyAccount(ctx, req)
+	// A missing account is a valid answer from a healthy provider
+	if errors.Is(err, payment.ErrAccountNotFound) {
+		s.record(ctx, req.Medium, "verify_account", start, nil)
+		return nil, err
+	}
+	s.record(ctx, req.Medium, "verify_account", start, err)
+	return resp, err
+}
+
+func (s *paymentService) AvailableMediums(ctx context.Context, mediums []txEntity.TransactionMedium) []txEntity.TransactionMedium {
+	if s.health == nil || payment.IsTestMode(ctx) {
+		return mediums
+	}
+
+	available := make([]txEntity.TransactionMedium, 0, len(mediums))
+	for _, medium := range mediums {
+		if s.health.Check(ctx, medium) == nil {
+			available = append(available, medium)
+		}
+	}
+	return available
+}
+
+func (s *paymentService) CheckMediumAvailable(ctx context.Context, medium txEntity.TransactionMedium, alternatives []txEntity.TransactionMedium) error {
+	if s.health == nil || payment.IsTestMode(ctx) {
+		return nil
+	}
+
+	err := s.health.Check(ctx, medium)
+	var unavailable *processorHealthEntity.MediumUnavailableError
+	if !errors.As(err, &unavailable) {
+		return err
+	}
+
+	// Fail over by suggesting the first other medium the payer could use
+	for _, alternative := range alternatives {
+		if alternative != medium && s.health.Check(ctx, alternative) == nil {
+			alternative := alternative
+			unavailable.Alternative = &alternative
+			break
+		}
+	}
+	return unavailable
+}
+
+// allow fast-fails live calls through a medium that is disabled or whose circuit is open.
+// Every call let through must be followed by record, which releases a half-open probe.
+func (s *paymentService) allow(ctx context.Context, medium txEntity.TransactionMedium) error {
+	if s.health == nil || payment.IsTestMode(ctx) {
+		return nil
+	}
+	if err := s.health.Allow(ctx, medium); err != nil {
+		s.log.Warn("Processor unavailable, failing fast", map[string]interface{}{
+			"processor_type": medium,
+			"error":          err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+// record feeds the result of a live processor call to the health tracker
+func (s *paymentService) record(ctx context.Context, medium txEntity.TransactionMedium, operation string, start time.Time, err error) {
+	if s.health == nil || payment.IsTestMode(ctx) {
+		return
+	}
+	// An unsupported operation says nothing about the provider being up
+	if errors.Is(err, payment.ErrNotSupported) {
+		return
+	}
+	s.health.Record(medium, operation, time.Since(start), err)
+}
+
+// processor resolves the processor a merchant pays through. Test mode requests go to the
+// simulator; live requests need the medium enabled for the merchant and run on the
+// merchant's own credentials when they have set them and ownCredentials is allowed.
+func (s *paymentService) processor(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, ownCredentials bool) (payment.Processor, error) {
+	if payment.IsTestMode(ctx) {
+		return s.platformProcessor(payment.SimulatorType)
+	}
+	if s.accounts == nil || merchantID == uuid.Nil {
+		return s.platformProcessor(medium)
+	}
+
+	account, err := s.accounts.ResolveAccount(ctx, merchantID, medium)
+	if err != nil {
+		return nil, err
+	}
+	if ownCredentials && account.HasOwnCredentials {
+		return s.merchantProcessor(account)
+	}
+
+	return s.platformProcessor(medium)
+}
+
+// existingPaymentProcessor resolves the processor for follow-up calls on a payment that was
+// already made. The medium may have been disabled since, which must not block status checks,
+// captures or voids.
+func (s *paymentService) existingPaymentProcessor(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium) (payment.Processor, error) {
+	processor, err := s.processor(ctx, merchantID, medium, true)
+	if errors.Is(err, processorAccountEntity.ErrMediumNotEnabled) {
+		return s.platformProcessor(medium)
+	}
+	return processor, err
+}
+
+func (s *paymentService) platformProcessor(medium txEntity.TransactionMedium) (payment.Processor, error) {
+	processor, ok := s.processors[medium]
+	if !ok {
+		return nil, fmt.Errorf("no payment processor available")
+	}
+	return processor, nil
+}
+
+// merchantProcessor builds, or reuses, the processor running on a merchant's own credentials
+func (s *paymentService) merchantProcessor(account *processorAccountEntity.ProcessorAccount) (payment.Processor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cached, ok := s.merchantProcessors[account.ID]; ok && cached.updatedAt.Equal(account.UpdatedAt) {
+		return cached.processor, nil
+	}
+
+	factory, ok := s.factories[account.Medium]
+	if !ok {
+		return nil, processorAccountEntity.ErrOwnCredentialsNotSupported
+	}
+	processor, err := factory(account.Credentials)
+	if err != nil {
+		s.log.Error("Failed to build merchant processor", map[string]interface{}{
+			"merchant_id": account.MerchantID,
+			"medium":      account.Medium,
+			"error":       err.Error(),
+		})
+		return nil, fmt.Errorf("failed to build %s processor for merchant: %w", account.Medium, err)
+	}
+
+	s.merchantProcessors[account.ID] = merchantProcessor{processor: processor, updatedAt: account.UpdatedAt}
+	return processor, nil
+}
+
+// cardAuthorizer returns the processor when it supports authorize and capture
+func (s *paymentService) cardAuthorizer(processor payment.Processor, medium txEntity.TransactionMedium) (payment.CardAuthorizer, error) {
+	authorizer, ok := processor.(payment.CardAuthorizer)
+	if !ok {
+		s.log.Error("Processor does not support authorize and capture", map[string]interface{}{
+			"processor_type": medium,
+		})
+		return nil, fmt.Errorf("%s does not support authorize and capture", medium)
+	}
+
+	return authorizer, nil
 }
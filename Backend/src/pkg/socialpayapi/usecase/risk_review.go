@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	socialPayEntity "github.com/socialpay/socialpay/src/pkg/socialpayapi/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// authorizeOnlyDetailKey marks a direct payment that only places a card hold, so a payment held
+// for risk review is resumed the way it was requested
+const authorizeOnlyDetailKey = "authorize_only"
+
+// screenPayment runs a stored payment through the risk engine before it goes to the processor.
+// Blocked payments are failed. Payments sent to review are held in PENDING_REVIEW until an admin
+// clears them, and the held response is returned for the caller to pass on. A payment that
+// cannot be assessed is failed, unless the risk engine is configured to fail open.
+func (uc *paymentUseCase) screenPayment(ctx context.Context, tx *txEntity.Transaction, flow riskEntity.Flow) (*socialPayEntity.PaymentResponse, error) {
+	if uc.risk == nil {
+		return nil, nil
+	}
+
+	assessment, err := uc.risk.Assess(ctx, tx, flow)
+	if err != nil {
+		if uc.risk.FailOpen() {
+			uc.log.Warn("Risk assessment failed, continuing without it as configured", map[string]interface{}{
+				"transaction_id": tx.Id,
+				"flow":           flow,
+				"error":          err.Error(),
+			})
+			return nil, nil
+		}
+		uc.log.Error("Risk assessment failed", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"flow":           flow,
+			"error":          err.Error(),
+		})
+		tx.Comment = "risk assessment failed"
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, tx.Comment)
+		return nil, fmt.Errorf("failed to assess payment risk: %w", err)
+	}
+	if assessment == nil {
+		return nil, nil
+	}
+
+	switch assessment.Outcome {
+	case riskEntity.OutcomeBlock:
+		uc.failBlocked(ctx, tx, assessment)
+		return nil, riskEntity.ErrBlocked
+	case riskEntity.OutcomeReview:
+		return uc.holdPaymentForReview(ctx, tx, assessment)
+	}
+	return nil, nil
+}
+
+// holdPaymentForReview parks a stored payment before it reaches the processor until an admin reviews it
+func (uc *paymentUseCase) holdPaymentForReview(ctx context.Context, tx *txEntity.Transaction, assessment *riskEntity.Assessment) (*socialPayEntity.PaymentResponse, error) {
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.PENDING_REVIEW,
+		Source:        txEntity.StatusSourceRiskEngine,
+		Actor:         "risk_engine",
+		Reason:        "held for risk review: " + assessment.Summary(),
+	}); err != nil {
+		uc.log.Error("Failed to hold payment for risk review", map[string]interface{}{
+			"error":          err.Error(),
+			"transaction_id": tx.Id,
+		})
+		tx.Comment = err.Error()
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
+
+		return nil, fmt.Errorf("failed to hold payment for risk review: %w", err)
+	}
+	tx.Status = txEntity.PENDING_REVIEW
+
+	uc.log.Info("Held payment for risk review", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"assessment_id":  assessment.ID,
+		"merchant_id":    tx.MerchantId,
+	})
+
+	return &socialPayEntity.PaymentResponse{
+		Success:                true,
+		Status:                 string(txEntity.PENDING_REVIEW),
+		Message:                "Payment is under review",
+		Reference:              tx.Reference,
+		SocialPayTransactionID: tx.Id.String(),
+		MerchantPaysFee:        tx.MerchantPaysFee,
+	}, nil
+}
+
+// screenWithdrawal runs a stored withdrawal with locked funds through the risk engine. Blocked
+// withdrawals are failed and their funds unlocked. Unlike payments, a withdrawal is not sent
+// when the risk engine is unavailable. Required reasons apply whatever the merchant's rules.
+func (uc *paymentUseCase) screenWithdrawal(ctx context.Context, tx *txEntity.Transaction, required ...riskEntity.Reason) (*riskEntity.Assessment, error) {
+	if uc.risk == nil {
+		return nil, nil
+	}
+
+	assessment, err := uc.risk.Assess(ctx, tx, riskEntity.FlowWithdrawal, required...)
+	if err != nil {
+		uc.log.Error("[Withdrawal] Risk assessment failed", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+		tx.Comment = "risk assessment failed"
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, tx.Comment)
+		uc.unlockWithdrawal(ctx, tx)
+		return nil, fmt.Errorf("failed to assess withdrawal risk: %w", err)
+	}
+	if assessment == nil || assessment.Outcome != riskEntity.OutcomeBlock {
+		return assessment, nil
+	}
+
+	uc.failBlocked(ctx, tx, assessment)
+	uc.unlockWithdrawal(ctx, tx)
+	return nil, riskEntity.ErrBlocked
+}
+
+func (uc *paymentUseCase) failBlocked(ctx context.Context, tx *txEntity.Transaction, assessment *riskEntity.Assessment) {
+	tx.Comment = riskEntity.ErrBlocked.Error()
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceRiskEngine,
+		Actor:         "risk_engine",
+		Reason:        "blocked by risk rules: " + assessment.Summary(),
+	}); err != nil {
+		uc.log.Error("Failed to fail blocked transaction", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"assessment_id":  assessment.ID,
+			"error":          err.Error(),
+		})
+		return
+	}
+	tx.Status = txEntity.FAILED
+}
+
+// holdForReview parks a stored withdrawal until an admin reviews it. The funds stay locked.
+func (uc *paymentUseCase) holdForReview(ctx context.Context, tx *txEntity.Transaction, assessment *riskEntity.Assessment) (*socialPayEntity.PaymentResponse, error) {
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.PENDING_REVIEW,
+		Source:        txEntity.StatusSourceRiskEngine,
+		Actor:         "risk_engine",
+		Reason:        "held for risk review: " + assessment.Summary(),
+	}); err != nil {
+		uc.log.Error("[Withdrawal] Failed to hold withdrawal for risk review", map[string]interface{}{
+			"error":          err.Error(),
+			"transaction_id": tx.Id,
+		})
+		tx.Comment = err.Error()
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
+		uc.unlockWithdrawal(ctx, tx)
+
+		return nil, fmt.Errorf("failed to hold withdrawal for risk review: %w", err)
+	}
+	tx.Status = txEntity.PENDING_REVIEW
+
+	uc.log.Info("[Withdrawal] Held withdrawal for risk review", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"assessment_id":  assessment.ID,
+		"merchant_id":    tx.MerchantId,
+	})
+
+	return &socialPayEntity.PaymentResponse{
+		Success:                true,
+		Status:                 string(txEntity.PENDING_REVIEW),
+		Message:                "Withdrawal is under review",
+		Reference:              tx.Reference,
+		SocialPayTransactionID: tx.Id.String(),
+	}, nil
+}
+
+func (uc *paymentUseCase) ResumeReviewedWithdrawal(ctx context.Context, transactionID uuid.UUID, actor string) error {
+	tx, err := uc.reviewedWithdrawal(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	ctx = payment.WithTestMode(ctx, tx.Test)
+
+	uc.log.Info("[Withdrawal] Resuming withdrawal cleared in risk review", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"actor":          actor,
+	})
+
+	// A cleared withdrawal still needs the merchant's own approval when its payout policy asks for one
+	holdReasons, err := uc.withdrawalHoldReasons(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if len(holdReasons) > 0 {
+		_, err = uc.holdWithdrawal(ctx, tx, holdReasons, tx.UserId)
+		return err
+	}
+
+	// Like other system-initiated withdrawals, the merchant ID stands in for the API key
+	_, err = uc.sendWithdrawal(ctx, tx.MerchantId.String(), tx)
+	return err
+}
+
+func (uc *paymentUseCase) RejectReviewedWithdrawal(ctx context.Context, transactionID uuid.UUID, actor, reason string) error {
+	tx, err := uc.reviewedWithdrawal(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceRiskEngine,
+		Actor:         actor,
+		Reason:        reason,
+	}); err != nil {
+		return fmt.Errorf("failed to reject reviewed withdrawal: %w", err)
+	}
+
+	uc.unlockWithdrawal(ctx, tx)
+
+	uc.log.Info("[Withdrawal] Rejected withdrawal in risk review", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"actor":          actor,
+	})
+
+	return nil
+}
+
+func (uc *paymentUseCase) reviewedWithdrawal(ctx context.Context, transactionID uuid.UUID) (*txEntity.Transaction, error) {
+	tx, err := uc.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal: %w", err)
+	}
+	if tx.Type != txEntity.WITHDRAWAL || tx.Status != txEntity.PENDING_REVIEW {
+		return nil, fmt.Errorf("transaction %s is not a withdrawal in risk review", transactionID)
+	}
+	return tx, nil
+}
+
+func (uc *paymentUseCase) ResumeReviewedPayment(ctx context.Context, transactionID uuid.UUID, actor string) error {
+	tx, err := uc.reviewedPayment(ctx, transactionID)
+	if err != nil || tx == nil {
+		return err
+	}
+	ctx = payment.WithTestMode(ctx, tx.Test)
+
+	uc.log.Info("Resuming payment cleared in risk review", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"actor":          actor,
+	})
+
+	paymentReq := &payment.PaymentRequest{
+		TransactionID: tx.Id,
+		MerchantID:    tx.MerchantId,
+		Medium:        tx.Medium,
+		Amount:        tx.CustomerNet,
+		Currency:      tx.Currency,
+		PhoneNumber:   tx.PhoneNumber,
+		Reference:     tx.Reference,
+		Description:   tx.Description,
+		CallbackURL:   tx.CallbackURL,
+		SuccessURL:    tx.SuccessURL,
+		FailedURL:     tx.FailedURL,
+	}
+
+	// Like other system-initiated payments, the merchant ID stands in for the API key
+	var paymentResp *payment.PaymentResponse
+	if authorizeOnly(tx) {
+		paymentResp, err = uc.paymentService.AuthorizePayment(ctx, tx.MerchantId.String(), paymentReq)
+		if err == nil {
+			err = uc.cardAuthorizations.RecordAuthorization(ctx, tx, paymentResp)
+		}
+	} else {
+		paymentResp, err = uc.paymentService.ProcessPayment(ctx, tx.MerchantId.String(), paymentReq)
+	}
+	if err != nil {
+		tx.Comment = err.Error()
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
+		return fmt.Errorf("failed to process payment: %w", err)
+	}
+
+	if err := uc.statusTransitions.ApplyProcessorStatus(ctx, tx, paymentResp.Status, paymentResp.Message); err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	if err := uc.transactionRepo.UpdateTransactionWithProviderData(ctx, tx.Id, map[string]interface{}{
+		"provider_tx_id": paymentResp.ProcessorRef,
+	}); err != nil {
+		uc.log.Error("error while setting provider_tx_id", map[string]interface{}{
+			"err": err,
+		})
+	}
+
+	return nil
+}
+
+func (uc *paymentUseCase) RejectReviewedPayment(ctx context.Context, transactionID uuid.UUID, actor, reason string) error {
+	tx, err := uc.reviewedPayment(ctx, transactionID)
+	if err != nil || tx == nil {
+		return err
+	}
+
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceRiskEngine,
+		Actor:         actor,
+		Reason:        reason,
+	}); err != nil {
+		return fmt.Errorf("failed to reject reviewed payment: %w", err)
+	}
+
+	uc.log.Info("Rejected payment in risk review", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"actor":          actor,
+	})
+
+	return nil
+}
+
+// reviewedPayment loads a payment held for risk review, nil when it was not held
+func (uc *paymentUseCase) reviewedPayment(ctx context.Context, transactionID uuid.UUID) (*txEntity.Transaction, error) {
+	tx, err := uc.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if tx.Type == txEntity.WITHDRAWAL {
+		return nil, fmt.Errorf("transaction %s is a withdrawal", transactionID)
+	}
+	if tx.Status != txEntity.PENDING_REVIEW {
+		return nil, nil
+	}
+	return tx, nil
+}
+
+func authorizeOnly(tx *txEntity.Transaction) bool {
+	details, _ := tx.Details.(map[string]interface{})
+	value, _ := details[authorizeOnlyDetailKey].(bool)
+	return value
+}
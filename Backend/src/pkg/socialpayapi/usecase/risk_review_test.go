@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	riskUsecase "github.com/socialpay/socialpay/src/pkg/risk/usecase"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	transaction_usecase "github.com/socialpay/socialpay/src/pkg/transaction/usecase"
+)
+
+// stubRisk answers every assessment with the same result; every other method is left unimplemented
+type stubRisk struct {
+	riskUsecase.RiskUseCase
+	assessment *riskEntity.Assessment
+	err        error
+	failOpen   bool
+}
+
+func (r *stubRisk) Assess(ctx context.Context, tx *txEntity.Transaction, flow riskEntity.Flow, required ...riskEntity.Reason) (*riskEntity.Assessment, error) {
+	return r.assessment, r.err
+}
+
+func (r *stubRisk) FailOpen() bool {
+	return r.failOpen
+}
+
+// stubStatusTransitions applies every status change to the transaction it was given
+type stubStatusTransitions struct {
+	transaction_usecase.StatusTransitionUseCase
+	tx *txEntity.Transaction
+}
+
+func (s *stubStatusTransitions) Transition(ctx context.Context, req txEntity.StatusTransitionRequest) (*txEntity.TransactionStatusEvent, error) {
+	event := &txEntity.TransactionStatusEvent{TransactionID: req.TransactionID, FromStatus: s.tx.Status, ToStatus: req.To, Source: req.Source}
+	s.tx.Status = req.To
+	return event, nil
+}
+
+func (s *stubStatusTransitions) ApplyProcessorStatus(ctx context.Context, tx *txEntity.Transaction, status txEntity.TransactionStatus, reason string) error {
+	tx.Status = status
+	return nil
+}
+
+func TestScreenPayment(t *testing.T) {
+	errUnavailable := errors.New("risk engine unavailable")
+
+	tests := []struct {
+		name       string
+		risk       *stubRisk
+		wantErr    error
+		wantHeld   bool
+		wantStatus txEntity.TransactionStatus
+	}{
+		{
+			name:       "allowed payment goes to the processor",
+			risk:       &stubRisk{assessment: &riskEntity.Assessment{Outcome: riskEntity.OutcomeAllow}},
+			wantStatus: txEntity.INITIATED,
+		},
+		{
+			name:       "review holds the payment before the processor",
+			risk:       &stubRisk{assessment: &riskEntity.Assessment{Outcome: riskEntity.OutcomeReview}},
+			wantHeld:   true,
+			wantStatus: txEntity.PENDING_REVIEW,
+		},
+		{
+			name:       "block fails the payment",
+			risk:       &stubRisk{assessment: &riskEntity.Assessment{Outcome: riskEntity.OutcomeBlock}},
+			wantErr:    riskEntity.ErrBlocked,
+			wantStatus: txEntity.FAILED,
+		},
+		{
+			name:       "unassessed payment fails closed",
+			risk:       &stubRisk{err: errUnavailable},
+			wantErr:    errUnavailable,
+			wantStatus: txEntity.FAILED,
+		},
+		{
+			name:       "unassessed payment goes through when configured to fail open",
+			risk:       &stubRisk{err: errUnavailable, failOpen: true},
+			wantStatus: txEntity.INITIATED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &txEntity.Transaction{Id: uuid.New(), Status: txEntity.INITIATED}
+			uc := &paymentUseCase{
+				risk:              tt.risk,
+				statusTransitions: &stubStatusTransitions{tx: tx},
+				log:               logging.NewStdLogger("test"),
+			}
+
+			held, err := uc.screenPayment(context.Background(), tx, riskEntity.FlowDirect)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("screenPayment() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("screenPayment() error = %v, want nil", err)
+			}
+			if (held != nil) != tt.wantHeld {
+				t.Fatalf("screenPayment() held = %v, want held %v", held, tt.wantHeld)
+			}
+			if held != nil && held.Status != string(txEntity.PENDING_REVIEW) {
+				t.Fatalf("held response status = %s, want %s", held.Status, txEntity.PENDING_REVIEW)
+			}
+			if tx.Status != tt.wantStatus {
+				t.Fatalf("transaction status = %s, want %s", tx.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -9,8 +9,20 @@ import (
 
 	"github.com/google/uuid"
 
+	accessListUsecase "github.com/socialpay/socialpay/src/pkg/access_list/usecase"
+	beneficiaryUsecase "github.com/socialpay/socialpay/src/pkg/beneficiary/usecase"
+	brandingUsecase "github.com/socialpay/socialpay/src/pkg/checkout_branding/usecase"
 	commission_usecase "github.com/socialpay/socialpay/src/pkg/commission/usecase"
+	customerVaultEntity "github.com/socialpay/socialpay/src/pkg/customer_vault/core/entity"
+	customerVaultUsecase "github.com/socialpay/socialpay/src/pkg/customer_vault/usecase"
+	fxEntity "github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	fxUsecase "github.com/socialpay/socialpay/src/pkg/fx/usecase"
+	limitsUsecase "github.com/socialpay/socialpay/src/pkg/limits/usecase"
+	processorAccountUsecase "github.com/socialpay/socialpay/src/pkg/processor_account/usecase"
+	riskEntity "github.com/socialpay/socialpay/src/pkg/risk/core/entity"
+	riskUsecase "github.com/socialpay/socialpay/src/pkg/risk/usecase"
 	socialPayEntity "github.com/socialpay/socialpay/src/pkg/socialpayapi/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/i18n"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
 	"github.com/socialpay/socialpay/src/pkg/shared/payment"
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
@@ -20,6 +32,8 @@ import (
 	v2MerchantUsecase "github.com/socialpay/socialpay/src/pkg/v2_merchant/usecase"
 	walletEntity "github.com/socialpay/socialpay/src/pkg/wallet/core/entity"
 	walletUsecase "github.com/socialpay/socialpay/src/pkg/wallet/usecase"
+	approvalEntity "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/entity"
+	approvalUsecase "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/usecase"
 )
 
 // PaymentUseCase defines the interface for payment operations
@@ -32,11 +46,32 @@ type PaymentUseCase interface {
 	GetTransaction(ctx context.Context, id uuid.UUID) (*txEntity.Transaction, error)
 
 	// QueryTransactionStatus queries transaction status from provider
-	QueryTransactionStatus(ctx context.Context, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error)
+	QueryTransactionStatus(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error)
 
 	// RequestWithdrawal handles withdrawal requests
 	RequestWithdrawal(ctx context.Context, apiKey string, userID uuid.UUID, merchantID uuid.UUID, req *socialPayEntity.WithdrawalRequest) (*socialPayEntity.PaymentResponse, error)
 
+	// WithdrawalApproval retrieves the approval a withdrawal was held for, nil when it was not held
+	WithdrawalApproval(ctx context.Context, transactionID uuid.UUID) (*approvalEntity.Approval, error)
+
+	// ExecuteApprovedWithdrawal sends a withdrawal released by its approver to the processor
+	ExecuteApprovedWithdrawal(ctx context.Context, transactionID uuid.UUID) error
+
+	// ReleaseHeldWithdrawal closes a rejected or expired withdrawal and unlocks its funds
+	ReleaseHeldWithdrawal(ctx context.Context, transactionID uuid.UUID, status txEntity.TransactionStatus, actor, reason string) error
+
+	// ResumeReviewedWithdrawal continues a withdrawal cleared in risk review
+	ResumeReviewedWithdrawal(ctx context.Context, transactionID uuid.UUID, actor string) error
+
+	// RejectReviewedWithdrawal fails a withdrawal rejected in risk review and unlocks its funds
+	RejectReviewedWithdrawal(ctx context.Context, transactionID uuid.UUID, actor, reason string) error
+
+	// ResumeReviewedPayment sends a payment cleared in risk review to its processor
+	ResumeReviewedPayment(ctx context.Context, transactionID uuid.UUID, actor string) error
+
+	// RejectReviewedPayment fails a payment rejected in risk review
+	RejectReviewedPayment(ctx context.Context, transactionID uuid.UUID, actor, reason string) error
+
 	// GetWalletBalance retrieves the wallet balance for a merchant
 	GetWalletBalance(ctx context.Context, userID uuid.UUID, merchantID uuid.UUID) (*walletEntity.MerchantWallet, error)
 
@@ -44,8 +79,20 @@ type PaymentUseCase interface {
 	CreateHostedCheckout(ctx context.Context, apikey string, userID uuid.UUID, merchantID uuid.UUID, req *socialPayEntity.HostedCheckoutRequest) (*socialPayEntity.PaymentResponse, error)
 	UpdateHostedCheckout(ctx context.Context, apikey string, userID uuid.UUID, merchantID uuid.UUID, id uuid.UUID, req *socialPayEntity.UpdateHostedCheckoutRequest) (*socialPayEntity.PaymentResponse, error)
 	GetHostedCheckout(ctx context.Context, id uuid.UUID) (*socialPayEntity.HostedCheckoutResponseDTO, error)
-	GetHostedCheckoutWithMerchant(ctx context.Context, id uuid.UUID) (*socialPayEntity.HostedCheckoutWithMerchantResponseDTO, error)
+	GetHostedCheckoutWithMerchant(ctx context.Context, id uuid.UUID, locale string) (*socialPayEntity.HostedCheckoutWithMerchantResponseDTO, error)
 	ProcessCheckoutPayment(ctx context.Context, req *socialPayEntity.CheckoutPaymentRequest) (*socialPayEntity.PaymentResponse, error)
+
+	// QuoteCheckout locks the price of a hosted checkout in another currency for the payer
+	QuoteCheckout(ctx context.Context, hostedCheckoutID uuid.UUID, req *fxEntity.QuoteRequest) (*fxEntity.Quote, error)
+
+	// ResolveLocale resolves the locale used for a merchant's checkout and receipts
+	ResolveLocale(ctx context.Context, merchantID uuid.UUID, requested string) i18n.Locale
+
+	// CapturePayment collects all or part of an authorize-only card payment
+	CapturePayment(ctx context.Context, merchantID uuid.UUID, transactionID uuid.UUID, req *socialPayEntity.CapturePaymentRequest) (*socialPayEntity.PaymentResponse, error)
+
+	// VoidPayment releases an authorize-only card payment that was not captured
+	VoidPayment(ctx context.Context, merchantID uuid.UUID, transactionID uuid.UUID, req *socialPayEntity.VoidPaymentRequest) (*socialPayEntity.PaymentResponse, error)
 }
 
 type paymentUseCase struct {
@@ -54,6 +101,17 @@ type paymentUseCase struct {
 	transactionUseCase         transaction_usecase.TransactionUseCase
 	walletUseCase              walletUsecase.MerchantWalletUsecase
 	merchantUseCase            v2MerchantUsecase.MerchantUseCase
+	brandingUseCase            brandingUsecase.BrandingUseCase
+	customerVault              customerVaultUsecase.CustomerVaultUseCase
+	processorAccounts          processorAccountUsecase.ProcessorAccountUseCase
+	cardAuthorizations         *CardAuthorizationService
+	statusTransitions          transaction_usecase.StatusTransitionUseCase
+	fx                         fxUsecase.FXUseCase
+	withdrawalApprovals        approvalUsecase.WithdrawalApprovalUseCase
+	beneficiaries              beneficiaryUsecase.BeneficiaryUseCase
+	risk                       riskUsecase.RiskUseCase
+	limits                     limitsUsecase.LimitsUseCase
+	accessLists                accessListUsecase.AccessListUseCase
 	paymentService             PaymentProcessor
 	transactionCreationService *TransactionCreationService
 	log                        logging.Logger
@@ -68,11 +126,53 @@ func (uc *paymentUseCase) ProcessDirectPayment(ctx context.Context, apikey strin
 		"phone_number": req.PhoneNumber,
 	})
 
+	// Suspended and terminated merchants cannot accept payments
+	if _, err := uc.merchantUseCase.CheckEligibility(ctx, merchantID, merchantEntity.OperationCollect); err != nil {
+		return nil, err
+	}
+
 	// validate reference id
 	if err := uc.transactionUseCase.ValidateReferenceId(ctx, merchantID, req.Reference); err != nil {
 		return nil, err
 	}
 
+	// Fill payer details from the saved customer
+	if req.CustomerID != nil {
+		customer, err := uc.resolveSavedCustomer(ctx, merchantID, *req.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if req.PhoneNumber == "" {
+			req.PhoneNumber = customer.PhoneNumber
+		}
+		if req.Medium == "" && customer.PreferredMedium != nil {
+			req.Medium = *customer.PreferredMedium
+		}
+		if req.Medium == "" {
+			return nil, fmt.Errorf("medium is required: saved customer has no preferred medium")
+		}
+		// Card velocity follows the saved card across payments
+		if req.Medium == txEntity.CYBERSOURCE && customer.CardToken != "" {
+			signals := riskEntity.SignalsFromContext(ctx)
+			signals.CardToken = customer.CardToken
+			ctx = riskEntity.WithSignals(ctx, signals)
+		}
+	}
+
+	if req.AuthorizeOnly {
+		if req.Medium != txEntity.CYBERSOURCE {
+			return nil, fmt.Errorf("authorize_only is only supported for %s", txEntity.CYBERSOURCE)
+		}
+		if uc.cardAuthorizations == nil {
+			return nil, fmt.Errorf("authorize and capture is not available")
+		}
+	}
+
+	// Fail fast before creating a transaction the processor cannot take
+	if err := uc.paymentService.CheckMediumAvailable(ctx, req.Medium, nil); err != nil {
+		return nil, err
+	}
+
 	// Use unified transaction creation service
 	txCreationReq := TransactionCreationRequest{
 		UserID:          userID,
@@ -101,6 +201,9 @@ func (uc *paymentUseCase) ProcessDirectPayment(ctx context.Context, apikey strin
 	tx.PhoneNumber = req.PhoneNumber
 	tx.Currency = req.Currency
 	tx.Reference = req.Reference
+	if req.AuthorizeOnly {
+		tx.Details = withDetail(tx.Details, authorizeOnlyDetailKey, true)
+	}
 
 	uc.log.Info("Created transaction using unified service", map[string]interface{}{
 		"transaction_id":    tx.Id,
@@ -122,9 +225,26 @@ func (uc *paymentUseCase) ProcessDirectPayment(ctx context.Context, apikey strin
 		"transaction_id": tx.Id,
 	})
 
+	if req.SaveCustomer {
+		uc.recordCustomerConsent(ctx, tx, req.CustomerName)
+	}
+
+	if err := uc.screenAccessLists(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	if err := uc.enforceLimits(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	if held, err := uc.screenPayment(ctx, tx, riskEntity.FlowDirect); err != nil || held != nil {
+		return held, err
+	}
+
 	// Process payment using payment service
 	paymentReq := &payment.PaymentRequest{
 		TransactionID: tx.Id,
+		MerchantID:    tx.MerchantId,
 		Medium:        req.Medium,
 		Amount:        tx.CustomerNet,
 		Currency:      tx.Currency,
@@ -138,15 +258,23 @@ func (uc *paymentUseCase) ProcessDirectPayment(ctx context.Context, apikey strin
 
 	uc.log.Info("Initiating payment processing", map[string]interface{}{
 		"transaction_id": tx.Id,
+		"authorize_only": req.AuthorizeOnly,
 	})
-	paymentResp, err := uc.paymentService.ProcessPayment(ctx, apikey, paymentReq)
+	var paymentResp *payment.PaymentResponse
+	if req.AuthorizeOnly {
+		paymentResp, err = uc.paymentService.AuthorizePayment(ctx, apikey, paymentReq)
+		if err == nil {
+			err = uc.cardAuthorizations.RecordAuthorization(ctx, tx, paymentResp)
+		}
+	} else {
+		paymentResp, err = uc.paymentService.ProcessPayment(ctx, apikey, paymentReq)
+	}
 	if err != nil {
 		uc.log.Error("Payment processing failed", map[string]interface{}{
 			"error": err.Error(),
 		})
-		tx.Status = txEntity.TransactionStatus(txEntity.FAILED)
 		tx.Comment = err.Error()
-		_ = uc.transactionRepo.Update(ctx, tx)
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
 		return nil, fmt.Errorf("failed to process payment: %w", err)
 	}
 	uc.log.Info("Payment processing completed", map[string]interface{}{
@@ -154,8 +282,7 @@ func (uc *paymentUseCase) ProcessDirectPayment(ctx context.Context, apikey strin
 	})
 
 	// Update transaction status
-	tx.Status = txEntity.TransactionStatus(paymentResp.Status)
-	if err := uc.transactionRepo.Update(ctx, tx); err != nil {
+	if err := uc.statusTransitions.ApplyProcessorStatus(ctx, tx, paymentResp.Status, paymentResp.Message); err != nil {
 		uc.log.Error("Failed to update transaction status", map[string]interface{}{
 			"error": err.Error(),
 		})
@@ -188,6 +315,7 @@ func (uc *paymentUseCase) ProcessDirectPayment(ctx context.Context, apikey strin
 		Reference:            tx.Reference,
 		PaymentURL:           paymentResp.PaymentURL,
 		SocialPayTransactionID: tx.Id.String(),
+		AuthorizationExpiresAt: paymentResp.AuthorizationExpiresAt,
 	}, nil
 }
 
@@ -216,10 +344,21 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		"currency":    req.Currency,
 	})
 
+	// Terminated merchants cannot withdraw; suspended merchants only after a review
+	reviewReasons, err := uc.checkWithdrawalEligibility(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := uc.transactionUseCase.ValidateReferenceId(ctx, merchantID, req.Reference); err != nil {
 		return nil, err
 	}
 
+	details, err := uc.resolveBeneficiary(ctx, merchantID, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Use unified transaction creation service for withdrawal
 	txCreationReq := TransactionCreationRequest{
 		UserID:          userID,
@@ -231,6 +370,7 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		PaymentType:     "withdrawal",
 		MerchantPaysFee: req.MerchantPaysFee,
 		CallbackURL:     req.CallbackURL,
+		Details:         details,
 	}
 
 	txCreationResp, txErr := uc.transactionCreationService.CreateTransaction(ctx, txCreationReq)
@@ -262,20 +402,30 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		"customer_net":    tx.CustomerNet,
 	})
 
-	// Get the wallet and lock the withdrawal amount
-	// This uses row-level locking to prevent race conditions
-	err := uc.walletUseCase.LockWithdrawalAmount(ctx, merchantID, tx.MerchantNet)
-	uc.log.Info("[Withdrawal] Locked withdrawal amount", map[string]interface{}{
-		"merchant_id": merchantID,
-		"amount":      tx.MerchantNet,
-	})
+	// Check the payout policy before any funds are locked
+	holdReasons, err := uc.withdrawalHoldReasons(ctx, tx)
 	if err != nil {
-		uc.log.Error("[Withdrawal] Failed to lock withdrawal amount", map[string]interface{}{
-			"error":       err.Error(),
+		return nil, err
+	}
+
+	// Get the wallet and lock the withdrawal amount
+	// This uses row-level locking to prevent race conditions.
+	// Test withdrawals are simulated and never touch the live wallet.
+	if !tx.Test {
+		err := uc.walletUseCase.LockWithdrawalAmount(ctx, merchantID, tx.WalletCurrency(), tx.MerchantNet)
+		uc.log.Info("[Withdrawal] Locked withdrawal amount", map[string]interface{}{
 			"merchant_id": merchantID,
-			"amount":      tx.TotalAmount,
+			"currency":    tx.WalletCurrency(),
+			"amount":      tx.MerchantNet,
 		})
-		return nil, err
+		if err != nil {
+			uc.log.Error("[Withdrawal] Failed to lock withdrawal amount", map[string]interface{}{
+				"error":       err.Error(),
+				"merchant_id": merchantID,
+				"amount":      tx.TotalAmount,
+			})
+			return nil, err
+		}
 	}
 
 	// Save the transaction
@@ -285,14 +435,16 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		})
 
 		// Try to unlock the amount since the transaction creation failed
-		unlockErr := uc.walletUseCase.ProcessTransactionStatus(ctx, merchantID, tx.MerchantNet, 0, false, true)
-		if unlockErr != nil {
-			uc.log.Error("[Withdrawal] Failed to unlock withdrawal amount after transaction creation failure", map[string]interface{}{
-				"error":        unlockErr.Error(),
-				"merchant_id":  merchantID,
-				"total_amount": tx.TotalAmount,
-				"amount":       tx.BaseAmount,
-			})
+		if !tx.Test {
+			unlockErr := uc.walletUseCase.ProcessTransactionStatus(ctx, merchantID, tx.WalletCurrency(), tx.MerchantNet, 0, false, true)
+			if unlockErr != nil {
+				uc.log.Error("[Withdrawal] Failed to unlock withdrawal amount after transaction creation failure", map[string]interface{}{
+					"error":        unlockErr.Error(),
+					"merchant_id":  merchantID,
+					"total_amount": tx.TotalAmount,
+					"amount":       tx.BaseAmount,
+				})
+			}
 		}
 
 		return nil, fmt.Errorf("failed to create withdrawal transaction: %w", err)
@@ -301,6 +453,39 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		"transaction_id": tx.Id,
 	})
 
+	if err := uc.screenAccessLists(ctx, tx); err != nil {
+		uc.unlockWithdrawal(ctx, tx)
+		return nil, err
+	}
+
+	if err := uc.enforceLimits(ctx, tx); err != nil {
+		uc.unlockWithdrawal(ctx, tx)
+		return nil, err
+	}
+
+	// Risky withdrawals are blocked, or wait for an admin's review before the payout policy applies
+	assessment, err := uc.screenWithdrawal(ctx, tx, reviewReasons...)
+	if err != nil {
+		return nil, err
+	}
+	if assessment != nil && assessment.Outcome == riskEntity.OutcomeReview {
+		return uc.holdForReview(ctx, tx, assessment)
+	}
+
+	// Withdrawals matching the payout policy wait for a second team member with their funds locked
+	if len(holdReasons) > 0 {
+		return uc.holdWithdrawal(ctx, tx, holdReasons, userID)
+	}
+
+	return uc.sendWithdrawal(ctx, apikey, tx)
+}
+
+// sendWithdrawal sends a stored withdrawal with locked funds to its processor
+func (uc *paymentUseCase) sendWithdrawal(ctx context.Context, apikey string, tx *txEntity.Transaction) (*socialPayEntity.PaymentResponse, error) {
+	if err := uc.ensureWithdrawalEligible(ctx, tx); err != nil {
+		return nil, err
+	}
+
 	// Process withdrawal
 	uc.log.Info("[Withdrawal] Initiating withdrawal processing", map[string]interface{}{
 		"transaction_id": tx.Id,
@@ -309,7 +494,8 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 	// Process payment using payment service
 	paymentReq := &payment.PaymentRequest{
 		TransactionID: tx.Id,
-		Medium:        req.Medium,
+		MerchantID:    tx.MerchantId,
+		Medium:        tx.Medium,
 		Amount:        tx.CustomerNet,
 		Currency:      tx.Currency,
 		PhoneNumber:   tx.PhoneNumber,
@@ -318,6 +504,7 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		CallbackURL:   tx.CallbackURL,
 		SuccessURL:    tx.SuccessURL,
 		FailedURL:     tx.FailedURL,
+		Metadata:      payoutMetadata(tx),
 	}
 
 	withdrawalResponse, err := uc.paymentService.ProcessWithdrawal(ctx, apikey, paymentReq)
@@ -326,18 +513,19 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		uc.log.Error("[Withdrawal] Withdrawal processing failed", map[string]interface{}{
 			"error": err.Error(),
 		})
-		tx.Status = txEntity.FAILED
 		tx.Comment = err.Error()
-		_ = uc.transactionRepo.Update(ctx, tx)
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
 
 		// Unlock the amount since the withdrawal failed
-		unlockErr := uc.walletUseCase.ProcessTransactionStatus(ctx, merchantID, tx.MerchantNet, 0, false, true)
-		if unlockErr != nil {
-			uc.log.Error("[Withdrawal] Failed to unlock withdrawal amount after processing failure", map[string]interface{}{
-				"error":       unlockErr.Error(),
-				"merchant_id": merchantID,
-				"amount":      tx.TotalAmount,
-			})
+		if !tx.Test {
+			unlockErr := uc.walletUseCase.ProcessTransactionStatus(ctx, tx.MerchantId, tx.WalletCurrency(), tx.MerchantNet, 0, false, true)
+			if unlockErr != nil {
+				uc.log.Error("[Withdrawal] Failed to unlock withdrawal amount after processing failure", map[string]interface{}{
+					"error":       unlockErr.Error(),
+					"merchant_id": tx.MerchantId,
+					"amount":      tx.TotalAmount,
+				})
+			}
 		}
 
 		return nil, fmt.Errorf("failed to process withdrawal: %w", err)
@@ -346,8 +534,7 @@ func (uc *paymentUseCase) RequestWithdrawal(ctx context.Context, apikey string,
 		"transaction_id": tx.Id,
 	})
 
-	tx.Status = txEntity.TransactionStatus(withdrawalResponse.Status)
-	if err := uc.transactionRepo.Update(ctx, tx); err != nil {
+	if err := uc.statusTransitions.ApplyProcessorStatus(ctx, tx, withdrawalResponse.Status, withdrawalResponse.Message); err != nil {
 		uc.log.Error("Failed to update withdrawal transaction status", map[string]interface{}{
 			"error": err.Error(),
 		})
@@ -384,6 +571,9 @@ func (uc *paymentUseCase) GetWalletBalance(ctx context.Context, userID uuid.UUID
 		UserID:       wallet.UserID,
 		Amount:       wallet.Amount,
 		LockedAmount: wallet.LockedAmount,
+		ReservedAmount:  wallet.ReservedAmount,
+		HeldAmount:      wallet.HeldAmount,
+		AvailableAmount: wallet.AvailableAmount,
 		Currency:     walletEntity.Currency(wallet.Currency),
 		CreatedAt:    wallet.CreatedAt,
 		UpdatedAt:    wallet.UpdatedAt,
@@ -399,6 +589,11 @@ func (uc *paymentUseCase) CreateHostedCheckout(ctx context.Context, apikey strin
 		"currency":    req.Currency,
 	})
 
+	// Suspended and terminated merchants cannot open new checkouts
+	if _, err := uc.merchantUseCase.CheckEligibility(ctx, merchantID, merchantEntity.OperationCollect); err != nil {
+		return nil, err
+	}
+
 	// Validate reference ID
 	if err := uc.transactionUseCase.ValidateReferenceId(ctx, merchantID, req.Reference); err != nil {
 		return nil, err
@@ -415,6 +610,23 @@ func (uc *paymentUseCase) CreateHostedCheckout(ctx context.Context, apikey strin
 		expiresAt = req.ExpiresAt.UTC()
 	}
 
+	// Only offer mediums the merchant can actually be paid through
+	if err := uc.checkMediumsEnabled(ctx, merchantID, req.SupportedMediums); err != nil {
+		return nil, err
+	}
+
+	// Pre-fill the payer phone number from the saved customer
+	phoneNumber := req.PhoneNumber
+	if req.CustomerID != nil {
+		customer, err := uc.resolveSavedCustomer(ctx, merchantID, *req.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if phoneNumber == "" {
+			phoneNumber = customer.PhoneNumber
+		}
+	}
+
 	// Create hosted payment
 	hostedPayment := &txEntity.HostedPayment{
 		ID:               uuid.New(),
@@ -425,7 +637,7 @@ func (uc *paymentUseCase) CreateHostedCheckout(ctx context.Context, apikey strin
 		Description:      req.Description,
 		Reference:        req.Reference,
 		SupportedMediums: req.SupportedMediums,
-		PhoneNumber:      req.PhoneNumber,
+		PhoneNumber:      phoneNumber,
 		SuccessURL:       req.Redirects.Success,
 		FailedURL:        req.Redirects.Failed,
 		CallbackURL:      req.CallbackURL,
@@ -433,6 +645,7 @@ func (uc *paymentUseCase) CreateHostedCheckout(ctx context.Context, apikey strin
 		MerchantPaysFee:  req.MerchantPaysFee,
 		ExpiresAt:        expiresAt,
 		AcceptTip:        req.AcceptTip,
+		Test:             payment.IsTestMode(ctx),
 	}
 
 	// Store hosted payment
@@ -516,6 +729,9 @@ func (uc *paymentUseCase) UpdateHostedCheckout(ctx context.Context, apikey strin
 	}
 
 	if len(req.SupportedMediums) > 0 {
+		if err := uc.checkMediumsEnabled(payment.WithTestMode(ctx, existingPayment.Test), merchantID, req.SupportedMediums); err != nil {
+			return nil, err
+		}
 		existingPayment.SupportedMediums = req.SupportedMediums
 		updated = true
 	}
@@ -617,6 +833,18 @@ func (uc *paymentUseCase) GetHostedCheckout(ctx context.Context, id uuid.UUID) (
 		return nil, fmt.Errorf("hosted payment is no longer available")
 	}
 
+	// Checkouts of suspended and terminated merchants cannot be paid
+	if _, err := uc.merchantUseCase.CheckEligibility(ctx, hostedPayment.MerchantID, merchantEntity.OperationCollect); err != nil {
+		return nil, err
+	}
+
+	supportedMediums, err := uc.enabledCheckoutMediums(ctx, hostedPayment)
+	if err != nil {
+		return nil, err
+	}
+	// Hide mediums that are disabled platform wide or whose processor is down
+	supportedMediums = uc.paymentService.AvailableMediums(ctx, supportedMediums)
+
 	// Convert to response DTO
 	response := &socialPayEntity.HostedCheckoutResponseDTO{
 		ID:               hostedPayment.ID,
@@ -624,7 +852,7 @@ func (uc *paymentUseCase) GetHostedCheckout(ctx context.Context, id uuid.UUID) (
 		Currency:         hostedPayment.Currency,
 		Description:      hostedPayment.Description,
 		Reference:        hostedPayment.Reference,
-		SupportedMediums: hostedPayment.SupportedMediums,
+		SupportedMediums: supportedMediums,
 		MerchantPaysFee:  hostedPayment.MerchantPaysFee,
 		PhoneNumber:      hostedPayment.PhoneNumber,
 		SuccessURL:       hostedPayment.SuccessURL,
@@ -644,7 +872,7 @@ func (uc *paymentUseCase) GetHostedCheckout(ctx context.Context, id uuid.UUID) (
 }
 
 // GetHostedCheckoutWithMerchant retrieves hosted checkout details with merchant information
-func (uc *paymentUseCase) GetHostedCheckoutWithMerchant(ctx context.Context, id uuid.UUID) (*socialPayEntity.HostedCheckoutWithMerchantResponseDTO, error) {
+func (uc *paymentUseCase) GetHostedCheckoutWithMerchant(ctx context.Context, id uuid.UUID, locale string) (*socialPayEntity.HostedCheckoutWithMerchantResponseDTO, error) {
 	uc.log.Info("Getting hosted checkout details with merchant information", map[string]interface{}{
 		"hosted_checkout_id": id,
 	})
@@ -687,6 +915,34 @@ func (uc *paymentUseCase) GetHostedCheckoutWithMerchant(ctx context.Context, id
 		merchant = nil
 	}
 
+	// Checkouts of suspended and terminated merchants cannot be paid
+	if merchant != nil {
+		if _, err := merchant.Status.Allows(merchantEntity.OperationCollect); err != nil {
+			return nil, err
+		}
+	}
+
+	var merchantInfo *merchantEntity.Merchant
+	if merchant != nil {
+		merchantInfo = &merchantEntity.Merchant{
+			ID:           merchant.ID,
+			LegalName:    merchant.LegalName,
+			TradingName:  merchant.TradingName,
+			BusinessType: merchant.BusinessType,
+			WebsiteURL:   merchant.WebsiteURL,
+			Status:       merchant.Status,
+			CreatedAt:    merchant.CreatedAt,
+			UpdatedAt:    merchant.UpdatedAt,
+		}
+	}
+
+	supportedMediums, err := uc.enabledCheckoutMediums(ctx, hostedPayment)
+	if err != nil {
+		return nil, err
+	}
+	// Hide mediums that are disabled platform wide or whose processor is down
+	supportedMediums = uc.paymentService.AvailableMediums(ctx, supportedMediums)
+
 	// Convert to response DTO
 	response := &socialPayEntity.HostedCheckoutWithMerchantResponseDTO{
 		ID:               hostedPayment.ID,
@@ -694,7 +950,7 @@ func (uc *paymentUseCase) GetHostedCheckoutWithMerchant(ctx context.Context, id
 		Currency:         hostedPayment.Currency,
 		Description:      hostedPayment.Description,
 		Reference:        hostedPayment.Reference,
-		SupportedMediums: hostedPayment.SupportedMediums,
+		SupportedMediums: supportedMediums,
 		PhoneNumber:      hostedPayment.PhoneNumber,
 		SuccessURL:       hostedPayment.SuccessURL,
 		FailedURL:        hostedPayment.FailedURL,
@@ -703,16 +959,28 @@ func (uc *paymentUseCase) GetHostedCheckoutWithMerchant(ctx context.Context, id
 		ExpiresAt:        hostedPayment.ExpiresAt,
 		MerchantPaysFee:  hostedPayment.MerchantPaysFee,
 		AcceptTip:        hostedPayment.AcceptTip,
-		Merchant: &merchantEntity.Merchant{
-			ID:           merchant.ID,
-			LegalName:    merchant.LegalName,
-			TradingName:  merchant.TradingName,
-			BusinessType: merchant.BusinessType,
-			WebsiteURL:   merchant.WebsiteURL,
-			Status:       merchant.Status,
-			CreatedAt:    merchant.CreatedAt,
-			UpdatedAt:    merchant.UpdatedAt,
-		},
+		Merchant:         merchantInfo,
+	}
+
+	// Apply merchant branding, medium order and locale
+	if uc.brandingUseCase != nil {
+		resolved, err := uc.brandingUseCase.ResolveCheckout(ctx, hostedPayment.MerchantID, locale)
+		if err != nil {
+			uc.log.Warn("Failed to resolve checkout branding", map[string]interface{}{
+				"error":       err.Error(),
+				"merchant_id": hostedPayment.MerchantID,
+			})
+		} else {
+			response.Theme = &resolved.Theme
+			response.Locale = resolved.Locale
+			response.Messages = resolved.Messages
+			response.SupportedMediums = uc.brandingUseCase.OrderMediums(ctx, hostedPayment.MerchantID, supportedMediums)
+		}
+	}
+	if response.Locale == "" {
+		resolvedLocale := i18n.Resolve(locale)
+		response.Locale = string(resolvedLocale)
+		response.Messages = i18n.Messages(resolvedLocale, "checkout.")
 	}
 
 	uc.log.Info("Successfully retrieved hosted checkout details with merchant information", map[string]interface{}{
@@ -742,6 +1010,9 @@ func (uc *paymentUseCase) ProcessCheckoutPayment(ctx context.Context, req *socia
 		return nil, fmt.Errorf("hosted payment not found: %w", err)
 	}
 
+	// The payer has no API key, so the checkout carries the mode it was created in
+	ctx = payment.WithTestMode(ctx, hostedPayment.Test)
+
 	// Check if hosted payment is still valid
 	if hostedPayment.Status != txEntity.HostedPaymentPending {
 		uc.log.Error("Hosted payment is no longer pending", map[string]interface{}{
@@ -760,9 +1031,20 @@ func (uc *paymentUseCase) ProcessCheckoutPayment(ctx context.Context, req *socia
 		return nil, fmt.Errorf("hosted payment has expired")
 	}
 
-	// Validate that the selected medium is supported
+	if _, err := uc.merchantUseCase.CheckEligibility(ctx, hostedPayment.MerchantID, merchantEntity.OperationCollect); err != nil {
+		return nil, err
+	}
+
+	// Validate that the selected medium is supported and shown on the merchant's checkout
+	visibleMediums, err := uc.enabledCheckoutMediums(ctx, hostedPayment)
+	if err != nil {
+		return nil, err
+	}
+	if uc.brandingUseCase != nil {
+		visibleMediums = uc.brandingUseCase.OrderMediums(ctx, hostedPayment.MerchantID, visibleMediums)
+	}
 	mediumSupported := false
-	for _, supportedMedium := range hostedPayment.SupportedMediums {
+	for _, supportedMedium := range visibleMediums {
 		if supportedMedium == req.Medium {
 			mediumSupported = true
 			break
@@ -771,12 +1053,26 @@ func (uc *paymentUseCase) ProcessCheckoutPayment(ctx context.Context, req *socia
 	if !mediumSupported {
 		return nil, fmt.Errorf("selected payment medium is not supported")
 	}
+	if err := uc.paymentService.CheckMediumAvailable(ctx, req.Medium, visibleMediums); err != nil {
+		return nil, err
+	}
+
+	// A locked quote prices the checkout in the currency the payer pays in
+	baseAmount, currency := hostedPayment.Amount, hostedPayment.Currency
+	var quote *fxEntity.Quote
+	if req.FXQuoteID != nil {
+		quote, err = uc.checkoutQuote(ctx, hostedPayment, *req.FXQuoteID, req.Medium)
+		if err != nil {
+			return nil, err
+		}
+		baseAmount, currency = quote.ChargeAmount, quote.ChargeCurrency
+	}
 
 	// Use unified transaction creation service for checkout
 	txCreationReq := TransactionCreationRequest{
 		UserID:          hostedPayment.UserID,
 		MerchantID:      hostedPayment.MerchantID,
-		BaseAmount:      hostedPayment.Amount,
+		BaseAmount:      baseAmount,
 		Description:     hostedPayment.Description,
 		Medium:          req.Medium,
 		Type:            txEntity.DEPOSIT,
@@ -800,11 +1096,15 @@ func (uc *paymentUseCase) ProcessCheckoutPayment(ctx context.Context, req *socia
 
 	tx := txCreationResp.Transaction
 	tx.PhoneNumber = req.PhoneNumber
-	tx.Currency = hostedPayment.Currency
+	tx.Currency = currency
 	tx.Reference = hostedPayment.Reference
 	tx.Status = txEntity.INITIATED
 	tx.HasTip = req.TipAmount != nil && *req.TipAmount > 0
 
+	if quote != nil {
+		tx.Details = withDetail(tx.Details, txEntity.FXQuoteDetailKey, quote.ID.String())
+	}
+
 	uc.log.Info("Created checkout transaction using unified service", map[string]interface{}{
 		"transaction_id":    tx.Id,
 		"original_amount":   tx.BaseAmount,
@@ -821,9 +1121,43 @@ func (uc *paymentUseCase) ProcessCheckoutPayment(ctx context.Context, req *socia
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	if quote != nil {
+		// A quote prices one stored payment; settlement converts back at its rate
+		if err := uc.fx.ConsumeQuote(ctx, quote.ID, tx.Id); err != nil {
+			tx.Comment = err.Error()
+			_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
+			return nil, err
+		}
+	}
+
+	if err := uc.screenAccessLists(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	if err := uc.enforceLimits(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	held, err := uc.screenPayment(ctx, tx, riskEntity.FlowCheckout)
+	if err != nil {
+		return nil, err
+	}
+	if held != nil {
+		// The checkout is taken by the held payment, so it cannot be paid a second time
+		if err := uc.hostedPaymentRepo.UpdateWithTransaction(ctx, hostedPayment.ID,
+			tx.Id, string(req.Medium), req.PhoneNumber, txEntity.HostedPaymentCompleted); err != nil {
+			uc.log.Error("Failed to update hosted payment", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return nil, fmt.Errorf("failed to update hosted payment: %w", err)
+		}
+		return held, nil
+	}
+
 	// Process payment using payment service
 	paymentReq := &payment.PaymentRequest{
 		TransactionID: tx.Id,
+		MerchantID:    tx.MerchantId,
 		Medium:        req.Medium,
 		Amount:        tx.CustomerNet,
 		Currency:      tx.Currency,
@@ -835,20 +1169,22 @@ func (uc *paymentUseCase) ProcessCheckoutPayment(ctx context.Context, req *socia
 		FailedURL:     tx.FailedURL,
 	}
 
+	if req.SaveCustomer {
+		uc.recordCustomerConsent(ctx, tx, req.CustomerName)
+	}
+
 	paymentResp, err := uc.paymentService.ProcessPayment(ctx, hostedPayment.MerchantID.String(), paymentReq)
 	if err != nil {
 		uc.log.Error("Payment processing failed", map[string]interface{}{
 			"error": err.Error(),
 		})
-		tx.Status = txEntity.TransactionStatus(txEntity.FAILED)
 		tx.Comment = err.Error()
-		_ = uc.transactionRepo.Update(ctx, tx)
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
 		return nil, fmt.Errorf("failed to process payment: %w", err)
 	}
 
 	// Update transaction status
-	tx.Status = txEntity.TransactionStatus(paymentResp.Status)
-	if err := uc.transactionRepo.Update(ctx, tx); err != nil {
+	if err := uc.statusTransitions.ApplyProcessorStatus(ctx, tx, paymentResp.Status, paymentResp.Message); err != nil {
 		uc.log.Error("Failed to update transaction status", map[string]interface{}{
 			"error": err.Error(),
 		})
@@ -895,8 +1231,85 @@ func (uc *paymentUseCase) ProcessCheckoutPayment(ctx context.Context, req *socia
 	}, nil
 }
 
-func (uc *paymentUseCase) QueryTransactionStatus(ctx context.Context, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error) {
-	return uc.paymentService.QueryTransactionStatus(ctx, medium, transactionID)
+// ResolveLocale resolves the requested locale against the merchant default language
+func (uc *paymentUseCase) ResolveLocale(ctx context.Context, merchantID uuid.UUID, requested string) i18n.Locale {
+	if uc.brandingUseCase == nil {
+		return i18n.Resolve(requested)
+	}
+	return uc.brandingUseCase.ResolveLocale(ctx, merchantID, requested)
+}
+
+// checkMediumsEnabled rejects live checkouts offering mediums the merchant has not been enabled for
+func (uc *paymentUseCase) checkMediumsEnabled(ctx context.Context, merchantID uuid.UUID, mediums []txEntity.TransactionMedium) error {
+	if uc.processorAccounts == nil || payment.IsTestMode(ctx) {
+		return nil
+	}
+	return uc.processorAccounts.RequireEnabled(ctx, merchantID, mediums)
+}
+
+// enabledCheckoutMediums drops mediums disabled for the merchant since the checkout was created
+func (uc *paymentUseCase) enabledCheckoutMediums(ctx context.Context, hostedPayment *txEntity.HostedPayment) ([]txEntity.TransactionMedium, error) {
+	if uc.processorAccounts == nil || hostedPayment.Test {
+		return hostedPayment.SupportedMediums, nil
+	}
+
+	mediums, err := uc.processorAccounts.FilterEnabled(ctx, hostedPayment.MerchantID, hostedPayment.SupportedMediums)
+	if err != nil {
+		uc.log.Error("Failed to filter enabled checkout mediums", map[string]interface{}{
+			"hosted_checkout_id": hostedPayment.ID,
+			"error":              err.Error(),
+		})
+		return nil, fmt.Errorf("failed to load payment mediums: %w", err)
+	}
+	return mediums, nil
+}
+
+// resolveSavedCustomer loads a customer referenced by customer_id from the merchant's vault
+func (uc *paymentUseCase) resolveSavedCustomer(ctx context.Context, merchantID uuid.UUID, customerID uuid.UUID) (*customerVaultEntity.SavedCustomer, error) {
+	if uc.customerVault == nil {
+		return nil, fmt.Errorf("customer vault is not available")
+	}
+	return uc.customerVault.ResolveCustomer(ctx, customerID, merchantID)
+}
+
+// recordCustomerConsent stores the payer's consent; the customer is saved once the payment succeeds
+func (uc *paymentUseCase) recordCustomerConsent(ctx context.Context, tx *txEntity.Transaction, customerName string) {
+	if uc.customerVault == nil {
+		return
+	}
+
+	consent := &customerVaultEntity.CustomerConsent{
+		TransactionID: tx.Id,
+		MerchantID:    tx.MerchantId,
+		PhoneNumber:   tx.PhoneNumber,
+		Name:          customerName,
+		Medium:        tx.Medium,
+	}
+	if err := uc.customerVault.RecordConsent(ctx, consent); err != nil {
+		// Saving the customer is best effort and must not fail the payment
+		uc.log.Error("Failed to record customer consent", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+	}
+}
+
+func (uc *paymentUseCase) QueryTransactionStatus(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, transactionID string) (*payment.TransactionStatusQueryResponse, error) {
+	return uc.paymentService.QueryTransactionStatus(ctx, merchantID, medium, transactionID)
+}
+
+func (uc *paymentUseCase) CapturePayment(ctx context.Context, merchantID uuid.UUID, transactionID uuid.UUID, req *socialPayEntity.CapturePaymentRequest) (*socialPayEntity.PaymentResponse, error) {
+	if uc.cardAuthorizations == nil {
+		return nil, fmt.Errorf("authorize and capture is not available")
+	}
+	return uc.cardAuthorizations.Capture(ctx, merchantID, transactionID, req.Amount)
+}
+
+func (uc *paymentUseCase) VoidPayment(ctx context.Context, merchantID uuid.UUID, transactionID uuid.UUID, req *socialPayEntity.VoidPaymentRequest) (*socialPayEntity.PaymentResponse, error) {
+	if uc.cardAuthorizations == nil {
+		return nil, fmt.Errorf("authorize and capture is not available")
+	}
+	return uc.cardAuthorizations.Void(ctx, merchantID, transactionID, req.Reason)
 }
 
 type UseCaseConfig struct {
@@ -907,11 +1320,27 @@ type UseCaseConfig struct {
 	WalletUseCase      walletUsecase.MerchantWalletUsecase
 	MerchantUseCase    v2MerchantUsecase.MerchantUseCase
 	CommissionUseCase  commission_usecase.CommissionUseCase
+	BrandingUseCase    brandingUsecase.BrandingUseCase
+	CustomerVault      customerVaultUsecase.CustomerVaultUseCase
+	ProcessorAccounts  processorAccountUsecase.ProcessorAccountUseCase
+	CardAuthorizations *CardAuthorizationService
+	StatusTransitions  transaction_usecase.StatusTransitionUseCase
+	FX                 fxUsecase.FXUseCase
+	// WithdrawalApprovals holds withdrawals matching the merchant's payout policy
+	WithdrawalApprovals approvalUsecase.WithdrawalApprovalUseCase
+	// Beneficiaries resolves withdrawals to saved payout destinations
+	Beneficiaries beneficiaryUsecase.BeneficiaryUseCase
+	// Risk screens payments and withdrawals before they reach the processor
+	Risk riskUsecase.RiskUseCase
+	// Limits caps the payments and withdrawals of merchants
+	Limits limitsUsecase.LimitsUseCase
+	// AccessLists refuses payments and payouts involving blocked phones, cards, IPs and devices
+	AccessLists accessListUsecase.AccessListUseCase
 }
 
 func NewPaymentUseCase(config UseCaseConfig) PaymentUseCase {
 	logger := logging.NewStdLogger("[socialpay-api]")
-	transactionCreationService := NewTransactionCreationService(config.CommissionUseCase, logger)
+	transactionCreationService := NewTransactionCreationService(config.CommissionUseCase, config.ProcessorAccounts, logger)
 
 	return &paymentUseCase{
 		transactionRepo:            config.TransactionRepo,
@@ -920,6 +1349,17 @@ func NewPaymentUseCase(config UseCaseConfig) PaymentUseCase {
 		walletUseCase:              config.WalletUseCase,
 		paymentService:             config.PaymentService,
 		merchantUseCase:            config.MerchantUseCase,
+		brandingUseCase:            config.BrandingUseCase,
+		customerVault:              config.CustomerVault,
+		processorAccounts:          config.ProcessorAccounts,
+		cardAuthorizations:         config.CardAuthorizations,
+		statusTransitions:          config.StatusTransitions,
+		fx:                         config.FX,
+		withdrawalApprovals:        config.WithdrawalApprovals,
+		beneficiaries:              config.Beneficiaries,
+		risk:                       config.Risk,
+		limits:                     config.Limits,
+		accessLists:                config.AccessLists,
 		transactionCreationService: transactionCreationService,
 		log:                        logger,
 	}
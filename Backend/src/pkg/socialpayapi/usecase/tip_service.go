@@ -10,6 +10,7 @@ import (
 	"github.com/socialpay/socialpay/src/pkg/shared/payment"
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 	txRepository "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	transaction_usecase "github.com/socialpay/socialpay/src/pkg/transaction/usecase"
 	walletUseCase "github.com/socialpay/socialpay/src/pkg/wallet/usecase"
 )
 
@@ -28,8 +29,9 @@ type TipProcessingService interface {
 type tipProcessingService struct {
 	transactionRepo txRepository.TransactionRepository
 	walletUseCase   walletUseCase.MerchantWalletUsecase
-	paymentService  PaymentProcessor
-	log             logging.Logger
+	paymentService    PaymentProcessor
+	statusTransitions transaction_usecase.StatusTransitionUseCase
+	log               logging.Logger
 }
 
 // NewTipProcessingService creates a new tip processing service
@@ -37,12 +39,14 @@ func NewTipProcessingService(
 	transactionRepo txRepository.TransactionRepository,
 	walletUseCase walletUseCase.MerchantWalletUsecase,
 	paymentService PaymentProcessor,
+	statusTransitions transaction_usecase.StatusTransitionUseCase,
 ) TipProcessingService {
 	return &tipProcessingService{
-		transactionRepo: transactionRepo,
-		walletUseCase:   walletUseCase,
-		paymentService:  paymentService,
-		log:             logging.NewStdLogger("[TIP-PROCESSING]"),
+		transactionRepo:   transactionRepo,
+		walletUseCase:     walletUseCase,
+		paymentService:    paymentService,
+		statusTransitions: statusTransitions,
+		log:               logging.NewStdLogger("[TIP-PROCESSING]"),
 	}
 }
 
@@ -102,6 +106,14 @@ func (s *tipProcessingService) ProcessTipForTransaction(ctx context.Context, tra
 		return nil
 	}
 
+	// Sandbox tips are never paid out to a real tipee
+	if tx.Test {
+		s.log.Info("Skipping tip for test transaction", map[string]interface{}{
+			"transaction_id": transactionID,
+		})
+		return nil
+	}
+
 	// Validate tip data
 	if tx.TipAmount == nil || tx.TipeePhone == nil || tx.TipMedium == nil {
 		return fmt.Errorf("incomplete tip data for transaction %s", transactionID)
@@ -199,7 +211,8 @@ func (s *tipProcessingService) processTipWithdrawalAsync(ctx context.Context, ti
 		"tip_transaction_id": tipTx.Id,
 	})
 
-	// Create payment request for withdrawal
+	// Create payment request for withdrawal. Tips are paid out of SocialPay's account,
+	// so no merchant is set and the merchant's enabled mediums do not apply.
 	paymentReq := &payment.PaymentRequest{
 		TransactionID: tipTx.Id,
 		Medium:        tipTx.Medium,
@@ -215,22 +228,22 @@ func (s *tipProcessingService) processTipWithdrawalAsync(ctx context.Context, ti
 	_, err := s.paymentService.ProcessWithdrawal(ctx, tipTx.MerchantId.String(), paymentReq)
 
 	// Update transaction status based on result
+	status := txEntity.PENDING // Will be updated by webhook when completed
 	if err != nil {
-		tipTx.Status = txEntity.FAILED
+		status = txEntity.FAILED
 		tipTx.Comment = fmt.Sprintf("Tip withdrawal failed: %s", err.Error())
 		s.log.Error("Tip withdrawal failed", map[string]interface{}{
 			"tip_transaction_id": tipTx.Id,
 			"error":              err.Error(),
 		})
 	} else {
-		tipTx.Status = txEntity.PENDING // Will be updated by webhook when completed
 		s.log.Info("Tip withdrawal initiated successfully", map[string]interface{}{
 			"tip_transaction_id": tipTx.Id,
 		})
 	}
 
 	// Update transaction status
-	if updateErr := s.transactionRepo.Update(ctx, tipTx); updateErr != nil {
+	if updateErr := s.statusTransitions.ApplyProcessorStatus(ctx, tipTx, status, tipTx.Comment); updateErr != nil {
 		s.log.Error("Failed to update tip withdrawal status", map[string]interface{}{
 			"tip_transaction_id": tipTx.Id,
 			"error":              updateErr.Error(),
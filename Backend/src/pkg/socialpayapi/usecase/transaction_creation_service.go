@@ -6,22 +6,27 @@ import (
 
 	"github.com/google/uuid"
 	commission_usecase "github.com/socialpay/socialpay/src/pkg/commission/usecase"
+	processorAccountUsecase "github.com/socialpay/socialpay/src/pkg/processor_account/usecase"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 )
 
 // TransactionCreationService provides unified transaction creation logic
 type TransactionCreationService struct {
 	commissionUsecase commission_usecase.CommissionUseCase
+	processorAccounts processorAccountUsecase.ProcessorAccountUseCase
 	logger            logging.Logger
 }
 
 func NewTransactionCreationService(
 	commissionUsecase commission_usecase.CommissionUseCase,
+	processorAccounts processorAccountUsecase.ProcessorAccountUseCase,
 	logger logging.Logger,
 ) *TransactionCreationService {
 	return &TransactionCreationService{
 		commissionUsecase: commissionUsecase,
+		processorAccounts: processorAccounts,
 		logger:            logger,
 	}
 }
@@ -69,6 +74,20 @@ type TransactionCreationResponse struct {
 
 // CreateTransaction creates a transaction with proper amount calculations and commission
 func (s *TransactionCreationService) CreateTransaction(ctx context.Context, req TransactionCreationRequest) (*TransactionCreationResponse, error) {
+	// Live payments need the medium enabled for the merchant
+	merchantAccount := false
+	if !payment.IsTestMode(ctx) && s.processorAccounts != nil {
+		account, err := s.processorAccounts.ResolveAccount(ctx, req.MerchantID, req.Medium)
+		if err != nil {
+			return nil, err
+		}
+		// Deposits on the merchant's own credentials never reach SocialPay's account
+		merchantAccount = req.Type == txEntity.DEPOSIT && account.HasOwnCredentials
+	}
+	if merchantAccount && req.TipAmount != nil && *req.TipAmount > 0 {
+		return nil, fmt.Errorf("tips are not supported when paying into the merchant's own %s account", req.Medium)
+	}
+
 	// Get merchant-specific commission rate
 	commissionResult, err := s.commissionUsecase.CalculateCommission(ctx, req.BaseAmount, req.MerchantID)
 	if err != nil {
@@ -157,8 +176,9 @@ func (s *TransactionCreationService) CreateTransaction(ctx context.Context, req
 		CallbackURL:     req.CallbackURL,
 		SuccessURL:      req.SuccessURL,
 		FailedURL:       req.FailedURL,
-		Details:         req.Details,
+		Details:         transactionDetails(req.Details, merchantAccount),
 		MerchantPaysFee: req.MerchantPaysFee,
+		Test:            payment.IsTestMode(ctx),
 	}
 
 	// Add QR tag if provided
@@ -194,3 +214,17 @@ func (s *TransactionCreationService) CreateTransaction(ctx context.Context, req
 
 	return response, nil
 }
+
+// transactionDetails records where a deposit is collected next to the caller's details
+func transactionDetails(details map[string]interface{}, merchantAccount bool) map[string]interface{} {
+	if !merchantAccount {
+		return details
+	}
+
+	marked := make(map[string]interface{}, len(details)+1)
+	for k, v := range details {
+		marked[k] = v
+	}
+	marked[txEntity.SettlementDetailKey] = txEntity.SettlementMerchantAccount
+	return marked
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	limitsEntity "github.com/socialpay/socialpay/src/pkg/limits/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// enforceLimits checks a stored transaction against the limits of its merchant. A transaction
+// over a limit, or one the limits could not be checked for, is failed before it reaches the processor.
+func (uc *paymentUseCase) enforceLimits(ctx context.Context, tx *txEntity.Transaction) error {
+	if uc.limits == nil {
+		return nil
+	}
+
+	err := uc.limits.Enforce(ctx, tx)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, limitsEntity.ErrLimitExceeded) {
+		uc.log.Error("Failed to check transaction limits", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          err.Error(),
+		})
+		err = fmt.Errorf("failed to check transaction limits: %w", err)
+	}
+
+	tx.Comment = err.Error()
+	if _, transitionErr := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.FAILED,
+		Source:        txEntity.StatusSourceLimits,
+		Actor:         "transaction_limits",
+		Reason:        err.Error(),
+	}); transitionErr != nil {
+		uc.log.Error("Failed to fail transaction over its limits", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"error":          transitionErr.Error(),
+		})
+	} else {
+		tx.Status = txEntity.FAILED
+	}
+
+	return err
+}
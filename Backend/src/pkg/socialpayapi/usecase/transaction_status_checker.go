@@ -99,6 +99,10 @@ func (tsc *TransactionStatusChecker) CheckPendingCBETransactions(ctx context.Con
 
 	// Process each transaction
 	for _, tx := range transactions {
+		// Sandbox transactions are settled by the simulator, not CBE
+		if tx.Test {
+			continue
+		}
 		processedCount++
 
 		tsc.log.Info("Checking status for transaction", map[string]interface{}{
@@ -115,7 +119,7 @@ func (tsc *TransactionStatusChecker) CheckPendingCBETransactions(ctx context.Con
 		}
 
 		// Query transaction status from CBE
-		queryResp, err := tsc.paymentService.QueryTransactionStatus(ctx, txEntity.CBE, queryID)
+		queryResp, err := tsc.paymentService.QueryTransactionStatus(ctx, tx.MerchantId, txEntity.CBE, queryID)
 		if err != nil {
 			tsc.log.Error("Failed to query transaction status", map[string]interface{}{
 				"transaction_id": tx.Id,
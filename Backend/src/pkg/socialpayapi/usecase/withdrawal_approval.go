@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/payment"
+	socialPayEntity "github.com/socialpay/socialpay/src/pkg/socialpayapi/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	approvalEntity "github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/entity"
+)
+
+// withdrawalHoldReasons returns the payout policy rules a new withdrawal triggers
+func (uc *paymentUseCase) withdrawalHoldReasons(ctx context.Context, tx *txEntity.Transaction) ([]approvalEntity.HoldReason, error) {
+	if uc.withdrawalApprovals == nil {
+		return nil, nil
+	}
+
+	reasons, err := uc.withdrawalApprovals.Evaluate(ctx, tx)
+	if err != nil {
+		uc.log.Error("[Withdrawal] Failed to evaluate payout policy", map[string]interface{}{
+			"error":       err.Error(),
+			"merchant_id": tx.MerchantId,
+		})
+		return nil, fmt.Errorf("failed to evaluate payout policy: %w", err)
+	}
+
+	return reasons, nil
+}
+
+// holdWithdrawal parks a stored withdrawal until a second team member decides on it. The funds stay locked.
+func (uc *paymentUseCase) holdWithdrawal(ctx context.Context, tx *txEntity.Transaction, reasons []approvalEntity.HoldReason, requestedBy uuid.UUID) (*socialPayEntity.PaymentResponse, error) {
+	approval, err := uc.withdrawalApprovals.Hold(ctx, tx, reasons, requestedBy)
+	if err != nil {
+		uc.log.Error("[Withdrawal] Failed to hold withdrawal for approval", map[string]interface{}{
+			"error":          err.Error(),
+			"transaction_id": tx.Id,
+		})
+		tx.Comment = err.Error()
+		_ = uc.statusTransitions.ApplyProcessorStatus(ctx, tx, txEntity.FAILED, err.Error())
+		uc.unlockWithdrawal(ctx, tx)
+
+		return nil, fmt.Errorf("failed to hold withdrawal for approval: %w", err)
+	}
+
+	return &socialPayEntity.PaymentResponse{
+		Success:                true,
+		Status:                 string(txEntity.PENDING_APPROVAL),
+		Message:                "Withdrawal is awaiting approval by another team member",
+		Reference:              tx.Reference,
+		SocialPayTransactionID: tx.Id.String(),
+		Approval:               approval,
+	}, nil
+}
+
+func (uc *paymentUseCase) WithdrawalApproval(ctx context.Context, transactionID uuid.UUID) (*approvalEntity.Approval, error) {
+	if uc.withdrawalApprovals == nil {
+		return nil, nil
+	}
+	return uc.withdrawalApprovals.GetApprovalByTransactionID(ctx, transactionID)
+}
+
+func (uc *paymentUseCase) ExecuteApprovedWithdrawal(ctx context.Context, transactionID uuid.UUID) error {
+	tx, err := uc.heldWithdrawal(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	uc.log.Info("[Withdrawal] Sending approved withdrawal", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+	})
+
+	// Like other system-initiated withdrawals, the merchant ID stands in for the API key
+	_, err = uc.sendWithdrawal(payment.WithTestMode(ctx, tx.Test), tx.MerchantId.String(), tx)
+	return err
+}
+
+func (uc *paymentUseCase) ReleaseHeldWithdrawal(ctx context.Context, transactionID uuid.UUID, status txEntity.TransactionStatus, actor, reason string) error {
+	tx, err := uc.heldWithdrawal(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            status,
+		Source:        txEntity.StatusSourceWithdrawalApproval,
+		Actor:         actor,
+		Reason:        reason,
+	}); err != nil {
+		return fmt.Errorf("failed to release held withdrawal: %w", err)
+	}
+
+	uc.unlockWithdrawal(ctx, tx)
+
+	uc.log.Info("[Withdrawal] Released held withdrawal", map[string]interface{}{
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"status":         status,
+		"actor":          actor,
+	})
+
+	return nil
+}
+
+func (uc *paymentUseCase) heldWithdrawal(ctx context.Context, transactionID uuid.UUID) (*txEntity.Transaction, error) {
+	tx, err := uc.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal: %w", err)
+	}
+	if tx.Type != txEntity.WITHDRAWAL || tx.Status != txEntity.PENDING_APPROVAL {
+		return nil, fmt.Errorf("transaction %s is not a withdrawal awaiting approval", transactionID)
+	}
+	return tx, nil
+}
+
+// unlockWithdrawal returns the locked funds of a withdrawal that will not be sent
+func (uc *paymentUseCase) unlockWithdrawal(ctx context.Context, tx *txEntity.Transaction) {
+	if tx.Test {
+		return
+	}
+
+	if err := uc.walletUseCase.ProcessTransactionStatus(ctx, tx.MerchantId, tx.WalletCurrency(), tx.MerchantNet, 0, false, true); err != nil {
+		uc.log.Error("[Withdrawal] Failed to unlock withdrawal amount", map[string]interface{}{
+			"error":          err.Error(),
+			"transaction_id": tx.Id,
+			"merchant_id":    tx.MerchantId,
+			"amount":         tx.MerchantNet,
+		})
+	}
+}
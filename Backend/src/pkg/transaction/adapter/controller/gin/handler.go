@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -33,6 +34,7 @@ type WebhookDispatcher interface {
 // Handler handles HTTP requests for transactions
 type Handler struct {
 	useCase            usecase.TransactionUseCase
+	statusTransitions  usecase.StatusTransitionUseCase
 	webhookDispatcher  WebhookDispatcher
 	log                logging.Logger
 	middlewareProvider *middleware.MiddlewareProvider
@@ -40,6 +42,7 @@ type Handler struct {
 
 func NewTransactionHistoryHandler(
 	useCase usecase.TransactionUseCase,
+	statusTransitions usecase.StatusTransitionUseCase,
 	middlewareProvider *middleware.MiddlewareProvider,
 	webhookDispatcher WebhookDispatcher,
 ) Handler {
@@ -47,6 +50,7 @@ func NewTransactionHistoryHandler(
 	return Handler{
 		log:                logging.NewStdLogger("[TRANSACTION] [HANDLER]"),
 		useCase:            useCase,
+		statusTransitions:  statusTransitions,
 		middlewareProvider: middlewareProvider,
 		webhookDispatcher:  webhookDispatcher,
 	}
@@ -59,6 +63,7 @@ func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
 	g.POST("/history", func(c *gin.Context) { h.GetTransactionsByParameter(c, false) })
 	g.POST("/analytics", h.GetTransactionAnalytics)
 	g.POST("/chart", h.GetChartData)
+	g.GET("/analytics/checkout-abandonment", h.GetCheckoutAbandonment)
 	g.POST("/data/export", func(c *gin.Context) { h.GetTransactionData(c, false) })
 }
 
@@ -66,6 +71,7 @@ func (h *Handler) RegisterAdminRoutes(rg *gin.RouterGroup) {
 	g := rg.Group("/transactions/admin", ginn.ErrorMiddleWare(), h.middlewareProvider.JWTAuth, h.middlewareProvider.RBAC.RequirePermissionForAdmin(authv2Entity.RESOURCE_TRANSACTION, authv2Entity.OPERATION_ADMIN_READ))
 	g.POST("/history", func(c *gin.Context) { h.GetTransactionsByParameter(c, true) })
 	g.POST("/data/export", func(ctx *gin.Context) { h.GetTransactionData(ctx, true) })
+	g.GET("/:transactionID/timeline", h.GetTransactionTimeline)
 	g.POST("/override/:transactionID", h.middlewareProvider.RBAC.RequirePermissionForAdmin(authv2Entity.RESOURCE_TRANSACTION, authv2Entity.OPERATION_ADMIN_OVERRIDE), h.OverrideTransactionStatus)
 
 	// Admin analytics endpoints - using RequireAdmin for now
@@ -73,6 +79,7 @@ func (h *Handler) RegisterAdminRoutes(rg *gin.RouterGroup) {
 	adminAnalytics.GET("/transactions", h.GetAdminTransactionAnalytics)
 	adminAnalytics.GET("/chart", h.GetAdminChartData)
 	adminAnalytics.GET("/merchant-growth", h.GetMerchantGrowthAnalytics)
+	adminAnalytics.GET("/checkout-abandonment", h.GetAdminCheckoutAbandonment)
 }
 
 // get transactions
@@ -260,7 +267,7 @@ func (h *Handler) GetTransactionData(c *gin.Context, queryForAllUsers bool) {
 // OverrideTransactionStatus overrides the status of a transaction
 // @tags transactions
 // @Summary Override transaction status
-// @Description Override the status of a transaction with admin approval
+// @Description Override the status of a transaction with admin approval. Besides the normal status flow, an override may correct SUCCESS to FAILED, FAILED to SUCCESS and EXPIRED to SUCCESS or FAILED. A correction credits or reverses the wallets together with the status.
 // @Accept multipart/form-data
 // @Produce json
 // @Param transactionID path string true "Transaction ID" format(uuid)
@@ -354,6 +361,8 @@ func (h *Handler) dispatchWebhookForOverride(ctx context.Context, transactionID
 		ProviderTxID:  transactionStatusQueryResponse.ProviderTxId,
 		ProviderData:  string(providerData),
 		Timestamp:     time.Now(),
+		Source:        entity.StatusSourceAdminOverride,
+		Actor:         overrideReq.AdminID,
 	}
 
 	h.log.Info("Dispatching webhook for manual override", map[string]interface{}{
@@ -382,6 +391,40 @@ func (h *Handler) dispatchWebhookForOverride(ctx context.Context, transactionID
 	return nil
 }
 
+// GetTransactionTimeline returns the status history of a transaction
+// @Tags transactions
+// @Summary Get transaction status timeline
+// @Description Get every status change of a transaction with its source, actor and provider payload
+// @Produce json
+// @Param transactionID path string true "Transaction ID" format(uuid)
+// @Success 200 {object} response.SuccessResponse{data=entity.TransactionTimeline}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transactions/admin/{transactionID}/timeline [get]
+// @Security BearerAuth
+func (h *Handler) GetTransactionTimeline(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("transactionID"))
+	if err != nil {
+		err = errorxx.ErrAppBadInput.Wrap(err, "invalid transaction ID").
+			WithProperty(errorxx.ErrorCode, 400)
+		c.Error(err)
+		return
+	}
+
+	timeline, err := h.statusTransitions.GetTimeline(c.Request.Context(), transactionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Success: true,
+		Data:    timeline,
+	})
+}
+
 // GetTransactionAnalytics handles transaction analytics requests
 // @Tags transactions
 // @Summary Get transaction analytics
@@ -671,6 +714,100 @@ func (h *Handler) GetMerchantGrowthAnalytics(c *gin.Context) {
 	})
 }
 
+// GetCheckoutAbandonment godoc
+// @Summary Get hosted checkout abandonment
+// @Description Get hosted checkout abandonment rates per medium for the authenticated merchant
+// @Tags transactions
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param end_date query string false "End date inclusive (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} response.SuccessResponse{data=entity.CheckoutAbandonmentReport}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transactions/analytics/checkout-abandonment [get]
+func (h *Handler) GetCheckoutAbandonment(c *gin.Context) {
+	merchantID, exists := ginn.GetMerchantIDFromContext(c)
+	if !exists {
+		err := errorxx.ErrAuthUnauthorized.Wrap(nil, "merchant ID not found in context").
+			WithProperty(errorxx.ErrorCode, 401)
+		c.Error(err)
+		return
+	}
+
+	h.respondCheckoutAbandonment(c, &merchantID)
+}
+
+// GetAdminCheckoutAbandonment godoc
+// @Summary Get hosted checkout abandonment for all merchants
+// @Description Get hosted checkout abandonment rates per merchant and medium
+// @Tags Admin Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param end_date query string false "End date inclusive (YYYY-MM-DD), defaults to today"
+// @Param merchant_id query string false "Filter by merchant ID"
+// @Success 200 {object} response.SuccessResponse{data=entity.CheckoutAbandonmentReport}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /admin/analytics/checkout-abandonment [get]
+func (h *Handler) GetAdminCheckoutAbandonment(c *gin.Context) {
+	var merchantID *uuid.UUID
+	if merchantIDStr := c.Query("merchant_id"); merchantIDStr != "" {
+		parsed, err := uuid.Parse(merchantIDStr)
+		if err != nil {
+			err = errorxx.ErrAppBadInput.Wrap(err, "invalid merchant_id").
+				WithProperty(errorxx.ErrorCode, 400)
+			c.Error(err)
+			return
+		}
+		merchantID = &parsed
+	}
+
+	h.respondCheckoutAbandonment(c, merchantID)
+}
+
+func (h *Handler) respondCheckoutAbandonment(c *gin.Context, merchantID *uuid.UUID) {
+	var startDate, endDate time.Time
+	var err error
+
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		startDate, err = time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			err = errorxx.ErrAppBadInput.Wrap(err, "invalid start_date format").
+				WithProperty(errorxx.ErrorCode, 400)
+			c.Error(err)
+			return
+		}
+	}
+
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		endDate, err = time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			err = errorxx.ErrAppBadInput.Wrap(err, "invalid end_date format").
+				WithProperty(errorxx.ErrorCode, 400)
+			c.Error(err)
+			return
+		}
+		// Include the whole end day
+		endDate = endDate.AddDate(0, 0, 1)
+	}
+
+	report, err := h.useCase.GetCheckoutAbandonment(c.Request.Context(), merchantID, startDate, endDate)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
 // Helper methods for parsing filters
 
 func (h *Handler) parseAnalyticsFilter(c *gin.Context) (*entity.AnalyticsFilter, error) {
@@ -757,6 +894,10 @@ func (h *Handler) parseAnalyticsFilter(c *gin.Context) (*entity.AnalyticsFilter,
 		}
 	}
 
+	if currency := c.Query("currency"); currency != "" {
+		filter.Currency = strings.ToUpper(currency)
+	}
+
 	return filter, nil
 }
 
@@ -844,6 +985,10 @@ func (h *Handler) parseChartFilter(c *gin.Context) (*entity.ChartFilter, error)
 		}
 	}
 
+	if currency := c.Query("currency"); currency != "" {
+		filter.Currency = strings.ToUpper(currency)
+	}
+
 	if chartType := c.Query("chart_type"); chartType != "" {
 		filter.ChartType = chartType
 	}
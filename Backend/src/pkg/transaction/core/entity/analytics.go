@@ -5,6 +5,8 @@ import (
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
+
+	walletEntity "github.com/socialpay/socialpay/src/pkg/wallet/core/entity"
 )
 
 // DateUnit represents the time unit for chart data aggregation
@@ -49,6 +51,10 @@ type AnalyticsFilter struct {
 
 	// @Description Merchant ID filter (for admin analytics)
 	MerchantID []string `json:"merchant_id,omitempty"`
+
+	// @Description Base currency to report amounts in, converted at the rate in force when each transaction was made; amounts are summed as recorded when empty
+	// @Example USD
+	Currency string `json:"currency,omitempty"`
 }
 
 // ChartFilter represents filters for chart data
@@ -211,6 +217,10 @@ func (f *AnalyticsFilter) Validate() error {
 		return errors.New("amount_min cannot be greater than amount_max")
 	}
 
+	if f.Currency != "" && !walletEntity.Currency(f.Currency).IsValid() {
+		return errors.New("invalid currency value")
+	}
+
 	return nil
 }
 
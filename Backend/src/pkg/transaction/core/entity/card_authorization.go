@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardAuthorizationStatus represents the capture state of a card authorization
+type CardAuthorizationStatus string
+
+const (
+	CardAuthorizationAuthorized CardAuthorizationStatus = "AUTHORIZED"
+	CardAuthorizationCapturing  CardAuthorizationStatus = "CAPTURING"
+	CardAuthorizationCaptured   CardAuthorizationStatus = "CAPTURED"
+	CardAuthorizationVoiding    CardAuthorizationStatus = "VOIDING"
+	CardAuthorizationVoided     CardAuthorizationStatus = "VOIDED"
+)
+
+// CardAuthorization tracks the hold placed for an authorize-only card payment.
+// The transaction stays AUTHORIZED until the hold is captured or voided.
+type CardAuthorization struct {
+	TransactionID uuid.UUID               `json:"transaction_id"`
+	MerchantID    uuid.UUID               `json:"merchant_id"`
+	Medium        TransactionMedium       `json:"medium"`
+	Status        CardAuthorizationStatus `json:"status"`
+
+	// Amounts in the transaction currency
+	AuthorizedAmount float64  `json:"authorized_amount"`
+	CapturedAmount   *float64 `json:"captured_amount,omitempty"`
+	Currency         string   `json:"currency"`
+
+	// Provider reference of the capture or void
+	ProviderRef string `json:"provider_ref,omitempty"`
+	VoidReason  string `json:"void_reason,omitempty"`
+
+	// ExpiresAt is when the hold lapses; uncaptured holds are voided at this time
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CapturedAt *time.Time `json:"captured_at,omitempty"`
+	// SettledAt is when the captured transaction was credited to the wallets
+	SettledAt *time.Time `json:"settled_at,omitempty"`
+	VoidedAt  *time.Time `json:"voided_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// IsExpired reports whether the hold can no longer be captured
+func (a *CardAuthorization) IsExpired() bool {
+	return time.Now().After(a.ExpiresAt)
+}
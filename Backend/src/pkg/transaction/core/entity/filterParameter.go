@@ -86,6 +86,9 @@ func (f *FilterParameters) Validate() error {
 			REFUNDED,
 			EXPIRED,
 			CANCELED,
+			AUTHORIZED,
+			PENDING_APPROVAL,
+			PENDING_REVIEW,
 		)); err != nil {
 			return errors.New("invalid status value")
 		}
@@ -99,6 +102,7 @@ func (f *FilterParameters) Validate() error {
 			SALE,
 			WITHDRAWAL,
 			REFUND,
+			ADJUSTMENT,
 		)); err != nil {
 			return errors.New("invalid type value")
 		}
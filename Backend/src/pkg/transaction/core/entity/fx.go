@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCurrency is the currency of transactions recorded without one
+const DefaultCurrency = "ETB"
+
+// FXQuoteDetailKey is the transaction details key of the FX quote that priced a checkout payment
+const FXQuoteDetailKey = "fx_quote_id"
+
+// FXDetailKey is the transaction details key recording the currency conversion applied at settlement
+const FXDetailKey = "fx"
+
+// WalletCurrency is the currency the transaction's amounts are in
+func (t *Transaction) WalletCurrency() string {
+	if currency := strings.ToUpper(strings.TrimSpace(t.Currency)); currency != "" {
+		return currency
+	}
+	return DefaultCurrency
+}
+
+// FXQuoteID returns the FX quote that priced the transaction, if any
+func (t *Transaction) FXQuoteID() *uuid.UUID {
+	details, ok := t.Details.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	raw, _ := details[FXQuoteDetailKey].(string)
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
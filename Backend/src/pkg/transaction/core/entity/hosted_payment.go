@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -58,4 +59,50 @@ type HostedPayment struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	ExpiresAt time.Time `json:"expires_at"`
+
+	// Test is set when the checkout was created with a test API key
+	Test bool `json:"test"`
+}
+
+// CheckoutMediumUnselected groups hosted checkouts abandoned before a medium was chosen
+const CheckoutMediumUnselected = "UNSELECTED"
+
+// CheckoutAbandonmentStat represents hosted checkout outcomes for a merchant and medium
+// @Description Hosted checkout abandonment statistics for a merchant and medium
+type CheckoutAbandonmentStat struct {
+	MerchantID uuid.UUID `json:"merchant_id"`
+	Medium     string    `json:"medium" example:"TELEBIRR"`
+	Total      int64     `json:"total" example:"120"`
+	Completed  int64     `json:"completed" example:"90"`
+	Expired    int64     `json:"expired" example:"25"`
+	Canceled   int64     `json:"canceled" example:"3"`
+	Pending    int64     `json:"pending" example:"2"`
+
+	// Share of closed sessions that expired or were canceled (0-100)
+	AbandonmentRate float64 `json:"abandonment_rate" example:"23.73"`
+}
+
+// CheckoutAbandonmentReport represents abandonment statistics for a period
+// @Description Hosted checkout abandonment report
+type CheckoutAbandonmentReport struct {
+	StartDate time.Time                 `json:"start_date"`
+	EndDate   time.Time                 `json:"end_date"`
+	Stats     []CheckoutAbandonmentStat `json:"stats"`
+
+	// Totals across all merchants and mediums in the report
+	Total           int64   `json:"total"`
+	Completed       int64   `json:"completed"`
+	Expired         int64   `json:"expired"`
+	Canceled        int64   `json:"canceled"`
+	AbandonmentRate float64 `json:"abandonment_rate"`
+}
+
+// AbandonmentRate returns the percentage of closed sessions that were not paid
+func AbandonmentRate(completed, expired, canceled int64) float64 {
+	closed := completed + expired + canceled
+	if closed == 0 {
+		return 0
+	}
+	rate := float64(expired+canceled) / float64(closed) * 100
+	return math.Round(rate*100) / 100
 }
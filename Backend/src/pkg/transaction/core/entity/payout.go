@@ -0,0 +1,36 @@
+package entity
+
+import "github.com/google/uuid"
+
+// PayoutMediums are the mediums withdrawals can be paid out through
+var PayoutMediums = []TransactionMedium{CBE, TELEBIRR, CYBERSOURCE, KACHA, MPESA}
+
+// BeneficiaryDetailKey is the transaction details key of the saved beneficiary a withdrawal pays out to
+const BeneficiaryDetailKey = "beneficiary_id"
+
+// BankCodeDetailKey is the transaction details key of the bank a withdrawal to a bank account pays out to
+const BankCodeDetailKey = "bank_code"
+
+// BeneficiaryID returns the saved beneficiary the withdrawal pays out to, if any
+func (t *Transaction) BeneficiaryID() *uuid.UUID {
+	details, ok := t.Details.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	raw, _ := details[BeneficiaryDetailKey].(string)
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// BankCode returns the bank the withdrawal pays out to, empty for wallet payouts
+func (t *Transaction) BankCode() string {
+	details, ok := t.Details.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	code, _ := details[BankCodeDetailKey].(string)
+	return code
+}
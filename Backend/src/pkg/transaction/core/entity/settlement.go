@@ -0,0 +1,18 @@
+package entity
+
+// SettlementDetailKey is the transaction details key recording where a deposit's funds were collected
+const SettlementDetailKey = "settlement"
+
+// SettlementMerchantAccount marks a deposit collected straight into the merchant's own
+// processor account. SocialPay never holds these funds, so the wallet is not credited.
+const SettlementMerchantAccount = "MERCHANT_ACCOUNT"
+
+// SettlesToMerchantAccount reports whether the transaction was collected with the merchant's own credentials
+func (t *Transaction) SettlesToMerchantAccount() bool {
+	details, ok := t.Details.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	settlement, _ := details[SettlementDetailKey].(string)
+	return settlement == SettlementMerchantAccount
+}
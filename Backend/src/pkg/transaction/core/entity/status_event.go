@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusSource identifies which part of the system moved a transaction between statuses
+type StatusSource string
+
+const (
+	// StatusSourceProcessorResponse is the synchronous answer of the processor at initiation
+	StatusSourceProcessorResponse StatusSource = "PROCESSOR_RESPONSE"
+	// StatusSourceProviderCallback is an asynchronous callback from the provider
+	StatusSourceProviderCallback StatusSource = "PROVIDER_CALLBACK"
+	// StatusSourceAdminOverride is a manual correction by an admin
+	StatusSourceAdminOverride StatusSource = "ADMIN_OVERRIDE"
+	// StatusSourceCardAuthorization is a capture or void of a card hold
+	StatusSourceCardAuthorization StatusSource = "CARD_AUTHORIZATION"
+	// StatusSourceCheckoutExpiry is the hosted checkout sweeper
+	StatusSourceCheckoutExpiry StatusSource = "CHECKOUT_EXPIRY"
+	// StatusSourceWithdrawalApproval is a payout policy hold or an approver's decision on it
+	StatusSourceWithdrawalApproval StatusSource = "WITHDRAWAL_APPROVAL"
+	// StatusSourceRiskEngine is a risk rule blocking or holding a transaction, or an admin's review of it
+	StatusSourceRiskEngine StatusSource = "RISK_ENGINE"
+	// StatusSourceLimits is a transaction limit refusing a transaction before it reaches the processor
+	StatusSourceLimits StatusSource = "TRANSACTION_LIMITS"
+	// StatusSourceAccessList is a blocklist entry refusing a party to a transaction
+	StatusSourceAccessList StatusSource = "ACCESS_LIST"
+	// StatusSourceMerchantStatus is a suspended or terminated merchant being refused a transaction
+	StatusSourceMerchantStatus StatusSource = "MERCHANT_STATUS"
+)
+
+var (
+	// ErrIllegalStatusTransition is returned when the state machine does not allow the change
+	ErrIllegalStatusTransition = errors.New("illegal transaction status transition")
+	// ErrStatusConflict is returned when the status changed between reading and writing it
+	ErrStatusConflict = errors.New("transaction status changed concurrently")
+	// ErrStatusUnchanged is returned when the transaction is already in the requested status
+	ErrStatusUnchanged = errors.New("transaction already in requested status")
+)
+
+// statusTransitions is the transaction state machine. Terminal statuses have no entry.
+var statusTransitions = map[TransactionStatus][]TransactionStatus{
+	INITIATED:        {PENDING, AUTHORIZED, PENDING_APPROVAL, PENDING_REVIEW, SUCCESS, FAILED, EXPIRED, CANCELED},
+	PENDING_REVIEW:   {PENDING_APPROVAL, PENDING, AUTHORIZED, SUCCESS, FAILED, CANCELED},
+	PENDING_APPROVAL: {PENDING, SUCCESS, FAILED, EXPIRED, CANCELED},
+	PENDING:          {AUTHORIZED, SUCCESS, FAILED, EXPIRED, CANCELED},
+	AUTHORIZED:       {SUCCESS, CANCELED, EXPIRED},
+	SUCCESS:          {REFUNDED},
+}
+
+// adminOverrideTransitions are the corrections only an admin override may make on top of the
+// state machine, for a processor that reverses or confirms a payment after its final status
+// was recorded
+var adminOverrideTransitions = map[TransactionStatus][]TransactionStatus{
+	SUCCESS: {FAILED},
+	FAILED:  {SUCCESS},
+	EXPIRED: {SUCCESS, FAILED},
+}
+
+// CanTransition reports whether a transaction may move from one status to another
+func CanTransition(from, to TransactionStatus) bool {
+	return hasEdge(statusTransitions, from, to)
+}
+
+// CanTransitionAs reports whether source may move a transaction from one status to another.
+// Admin overrides may also take the correction edges; every other source follows the state machine.
+func CanTransitionAs(source StatusSource, from, to TransactionStatus) bool {
+	if CanTransition(from, to) {
+		return true
+	}
+	return source == StatusSourceAdminOverride && hasEdge(adminOverrideTransitions, from, to)
+}
+
+func hasEdge(edges map[TransactionStatus][]TransactionStatus, from, to TransactionStatus) bool {
+	for _, next := range edges[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusTransitionRequest asks for a transaction to be moved to a new status
+type StatusTransitionRequest struct {
+	TransactionID uuid.UUID
+	// From, when set, is the status the caller read; the transition fails with ErrStatusConflict
+	// when the transaction has left it since
+	From   TransactionStatus
+	To     TransactionStatus
+	Source StatusSource
+	// Actor is who caused the change: an admin ID, a processor name or a service
+	Actor  string
+	Reason string
+	// ProviderPayload is the raw provider data that triggered the change, if any
+	ProviderPayload string
+	// Movements are the wallet changes that settle the new status. They are applied in the
+	// same database transaction as the status, so a status never lands without its money.
+	Movements []WalletMovement
+}
+
+// WalletMovement is a change of one wallet's balances made by a status transition. A nil
+// MerchantID is the super admin wallet.
+type WalletMovement struct {
+	MerchantID *uuid.UUID
+	Currency   string
+	// Amount is added to the wallet's balance and LockedAmount to its locked amount; either may be negative
+	Amount       float64
+	LockedAmount float64
+}
+
+// TransactionStatusEvent is one entry of the append-only status history of a transaction
+type TransactionStatusEvent struct {
+	ID              uuid.UUID         `json:"id"`
+	TransactionID   uuid.UUID         `json:"transaction_id"`
+	FromStatus      TransactionStatus `json:"from_status"`
+	ToStatus        TransactionStatus `json:"to_status"`
+	Source          StatusSource      `json:"source"`
+	Actor           string            `json:"actor,omitempty"`
+	Reason          string            `json:"reason,omitempty"`
+	ProviderPayload string            `json:"provider_payload,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// TransactionTimeline is the status history of a transaction for support staff
+type TransactionTimeline struct {
+	TransactionID uuid.UUID                `json:"transaction_id"`
+	CurrentStatus TransactionStatus        `json:"current_status"`
+	Events        []TransactionStatusEvent `json:"events"`
+}
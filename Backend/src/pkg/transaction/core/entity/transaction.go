@@ -21,6 +21,10 @@ const (
 	DEPOSIT       TransactionType = "DEPOSIT"
 	WITHDRAWAL    TransactionType = "WITHDRAWAL"
 	REFUND        TransactionType = "REFUND"
+	// ADJUSTMENT is a manual correction of a wallet balance approved by two admins
+	ADJUSTMENT    TransactionType = "ADJUSTMENT"
+	// DISPUTE debits a disputed payment from the merchant wallet, or credits it back when the merchant wins
+	DISPUTE TransactionType = "DISPUTE"
 )
 
 // TransactionMedium represents the payment medium/provider
@@ -87,13 +91,18 @@ type TransactionRedirects struct {
 type TransactionStatus string
 
 const (
-	INITIATED TransactionStatus = "INITIATED"
-	PENDING   TransactionStatus = "PENDING"
-	SUCCESS   TransactionStatus = "SUCCESS"
-	FAILED    TransactionStatus = "FAILED"
-	REFUNDED  TransactionStatus = "REFUNDED"
-	EXPIRED   TransactionStatus = "EXPIRED"
-	CANCELED  TransactionStatus = "CANCELED"
+	INITIATED  TransactionStatus = "INITIATED"
+	PENDING    TransactionStatus = "PENDING"
+	SUCCESS    TransactionStatus = "SUCCESS"
+	FAILED     TransactionStatus = "FAILED"
+	REFUNDED   TransactionStatus = "REFUNDED"
+	EXPIRED    TransactionStatus = "EXPIRED"
+	CANCELED   TransactionStatus = "CANCELED"
+	AUTHORIZED TransactionStatus = "AUTHORIZED" // Card hold placed, awaiting capture or void
+	// Withdrawal held by the merchant's payout policy until a second team member decides on it
+	PENDING_APPROVAL TransactionStatus = "PENDING_APPROVAL"
+	// Withdrawal held by the risk engine until an admin reviews it
+	PENDING_REVIEW TransactionStatus = "PENDING_REVIEW"
 )
 
 // Transaction represents a payment transaction
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// CardAuthorizationRepository defines the interface for card authorization operations
+type CardAuthorizationRepository interface {
+	// Create records the hold placed for an authorize-only payment
+	Create(ctx context.Context, authorization *entity.CardAuthorization) error
+
+	// GetByTransactionID retrieves the authorization of a transaction
+	GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.CardAuthorization, error)
+
+	// Transition moves an authorization between states. It returns false when the
+	// authorization was not in the expected state, so only one capture or void can proceed.
+	Transition(ctx context.Context, transactionID uuid.UUID, from, to entity.CardAuthorizationStatus) (bool, error)
+
+	// MarkCaptured records a completed capture of an authorization being captured, and writes
+	// the amounts of tx, scaled down by a partial capture, in the same database transaction
+	MarkCaptured(ctx context.Context, tx *entity.Transaction, amount float64, providerRef string) error
+
+	// MarkVoided records a completed void
+	MarkVoided(ctx context.Context, transactionID uuid.UUID, reason string, providerRef string) error
+
+	// GetExpired retrieves uncaptured authorizations whose hold has lapsed
+	GetExpired(ctx context.Context, limit int) ([]entity.CardAuthorization, error)
+
+	// GetUnsettledCaptures retrieves authorizations captured more than olderThan ago whose
+	// transaction has not settled into the wallets yet
+	GetUnsettledCaptures(ctx context.Context, olderThan time.Duration, limit int) ([]entity.CardAuthorization, error)
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+const cardAuthorizationColumns = `
+	transaction_id, merchant_id, medium, status, authorized_amount, captured_amount, currency,
+	provider_ref, void_reason, expires_at, captured_at, settled_at, voided_at, created_at, updated_at`
+
+type CardAuthorizationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCardAuthorizationRepository(db *sql.DB) CardAuthorizationRepository {
+	return &CardAuthorizationRepositoryImpl{db: db}
+}
+
+func (r *CardAuthorizationRepositoryImpl) Create(ctx context.Context, authorization *entity.CardAuthorization) error {
+	if authorization.Status == "" {
+		authorization.Status = entity.CardAuthorizationAuthorized
+	}
+
+	query := `
+		INSERT INTO public.card_authorizations (
+			transaction_id, merchant_id, medium, status, authorized_amount, currency, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		authorization.TransactionID,
+		authorization.MerchantID,
+		string(authorization.Medium),
+		string(authorization.Status),
+		authorization.AuthorizedAmount,
+		authorization.Currency,
+		authorization.ExpiresAt,
+	).Scan(&authorization.CreatedAt, &authorization.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create card authorization: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CardAuthorizationRepositoryImpl) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.CardAuthorization, error) {
+	query := `SELECT ` + cardAuthorizationColumns + ` FROM public.card_authorizations WHERE transaction_id = $1`
+
+	authorization, err := scanCardAuthorization(r.db.QueryRowContext(ctx, query, transactionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("card authorization not found")
+		}
+		return nil, fmt.Errorf("failed to get card authorization: %w", err)
+	}
+
+	return authorization, nil
+}
+
+func (r *CardAuthorizationRepositoryImpl) Transition(ctx context.Context, transactionID uuid.UUID, from, to entity.CardAuthorizationStatus) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE public.card_authorizations
+		SET status = $3, updated_at = NOW()
+		WHERE transaction_id = $1 AND status = $2
+	`, transactionID, string(from), string(to))
+	if err != nil {
+		return false, fmt.Errorf("failed to update card authorization: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update card authorization: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (r *CardAuthorizationRepositoryImpl) MarkCaptured(ctx context.Context, tx *entity.Transaction, amount float64, providerRef string) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	result, err := dbTx.ExecContext(ctx, `
+		UPDATE public.card_authorizations
+		SET status = 'CAPTURED', captured_amount = $2, provider_ref = $3, captured_at = NOW(), updated_at = NOW()
+		WHERE transaction_id = $1 AND status = 'CAPTURING'
+	`, tx.Id, amount, nullableString(providerRef))
+	if err != nil {
+		return fmt.Errorf("failed to mark card authorization captured: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to mark card authorization captured: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("card authorization is not being captured")
+	}
+
+	// A partial capture scales the amounts, which the wallet credit on SUCCESS is based on
+	_, err = dbTx.ExecContext(ctx, `
+		UPDATE public.transactions
+		SET base_amount = $2, fee_amount = $3, admin_net = $4, vat_amount = $5,
+			merchant_net = $6, customer_net = $7, total_amount = $8, updated_at = NOW()
+		WHERE id = $1
+	`, tx.Id, tx.BaseAmount, tx.FeeAmount, tx.AdminNet, tx.VatAmount, tx.MerchantNet, tx.CustomerNet, tx.TotalAmount)
+	if err != nil {
+		return fmt.Errorf("failed to update captured transaction amounts: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CardAuthorizationRepositoryImpl) MarkVoided(ctx context.Context, transactionID uuid.UUID, reason string, providerRef string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE public.card_authorizations
+		SET status = 'VOIDED', void_reason = $2, provider_ref = $3, voided_at = NOW(), updated_at = NOW()
+		WHERE transaction_id = $1
+	`, transactionID, nullableString(reason), nullableString(providerRef))
+	if err != nil {
+		return fmt.Errorf("failed to mark card authorization voided: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CardAuthorizationRepositoryImpl) GetExpired(ctx context.Context, limit int) ([]entity.CardAuthorization, error) {
+	// Only holds the payer completed are voided; abandoned forms never reached AUTHORIZED
+	query := `
+		SELECT ` + cardAuthorizationColumns + `
+		FROM public.card_authorizations
+		WHERE status = 'AUTHORIZED'
+		  AND expires_at <= NOW()
+		  AND transaction_id IN (
+			SELECT id FROM public.transactions WHERE status = 'AUTHORIZED'
+		  )
+		ORDER BY expires_at
+		LIMIT $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired card authorizations: %w", err)
+	}
+	defer rows.Close()
+
+	authorizations := []entity.CardAuthorization{}
+	for rows.Next() {
+		authorization, err := scanCardAuthorization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card authorization: %w", err)
+		}
+		authorizations = append(authorizations, *authorization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate card authorizations: %w", err)
+	}
+
+	return authorizations, nil
+}
+
+func (r *CardAuthorizationRepositoryImpl) GetUnsettledCaptures(ctx context.Context, olderThan time.Duration, limit int) ([]entity.CardAuthorization, error) {
+	query := `
+		SELECT ` + cardAuthorizationColumns + `
+		FROM public.card_authorizations
+		WHERE status = 'CAPTURED'
+		  AND settled_at IS NULL
+		  AND captured_at <= $1
+		ORDER BY captured_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now().Add(-olderThan), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unsettled card captures: %w", err)
+	}
+	defer rows.Close()
+
+	authorizations := []entity.CardAuthorization{}
+	for rows.Next() {
+		authorization, err := scanCardAuthorization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card authorization: %w", err)
+		}
+		authorizations = append(authorizations, *authorization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate card authorizations: %w", err)
+	}
+
+	return authorizations, nil
+}
+
+type cardAuthorizationScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCardAuthorization(row cardAuthorizationScanner) (*entity.CardAuthorization, error) {
+	var (
+		a                       entity.CardAuthorization
+		medium, status          string
+		capturedAmount          sql.NullFloat64
+		providerRef, voidReason sql.NullString
+		capturedAt, settledAt   sql.NullTime
+		voidedAt                sql.NullTime
+	)
+
+	err := row.Scan(
+		&a.TransactionID,
+		&a.MerchantID,
+		&medium,
+		&status,
+		&a.AuthorizedAmount,
+		&capturedAmount,
+		&a.Currency,
+		&providerRef,
+		&voidReason,
+		&a.ExpiresAt,
+		&capturedAt,
+		&settledAt,
+		&voidedAt,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Medium = entity.TransactionMedium(medium)
+	a.Status = entity.CardAuthorizationStatus(status)
+	if capturedAmount.Valid {
+		a.CapturedAmount = &capturedAmount.Float64
+	}
+	a.ProviderRef = providerRef.String
+	a.VoidReason = voidReason.String
+	if capturedAt.Valid {
+		a.CapturedAt = &capturedAt.Time
+	}
+	if settledAt.Valid {
+		a.SettledAt = &settledAt.Time
+	}
+	if voidedAt.Valid {
+		a.VoidedAt = &voidedAt.Time
+	}
+
+	return &a, nil
+}
+
+func nullableString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
@@ -192,13 +192,16 @@ func (ns NullTransactionSource) Value() (driver.Value, error) {
 type TransactionStatus string
 
 const (
-	TransactionStatusINITIATED TransactionStatus = "INITIATED"
-	TransactionStatusPENDING   TransactionStatus = "PENDING"
-	TransactionStatusSUCCESS   TransactionStatus = "SUCCESS"
-	TransactionStatusFAILED    TransactionStatus = "FAILED"
-	TransactionStatusREFUNDED  TransactionStatus = "REFUNDED"
-	TransactionStatusEXPIRED   TransactionStatus = "EXPIRED"
-	TransactionStatusCANCELED  TransactionStatus = "CANCELED"
+	TransactionStatusINITIATED       TransactionStatus = "INITIATED"
+	TransactionStatusPENDING         TransactionStatus = "PENDING"
+	TransactionStatusSUCCESS         TransactionStatus = "SUCCESS"
+	TransactionStatusFAILED          TransactionStatus = "FAILED"
+	TransactionStatusREFUNDED        TransactionStatus = "REFUNDED"
+	TransactionStatusEXPIRED         TransactionStatus = "EXPIRED"
+	TransactionStatusCANCELED        TransactionStatus = "CANCELED"
+	TransactionStatusAUTHORIZED      TransactionStatus = "AUTHORIZED"
+	TransactionStatusPENDINGAPPROVAL TransactionStatus = "PENDING_APPROVAL"
+	TransactionStatusPENDINGREVIEW   TransactionStatus = "PENDING_REVIEW"
 )
 
 func (e *TransactionStatus) Scan(src interface{}) error {
@@ -258,6 +261,7 @@ type HostedPayment struct {
 	CreatedAt           time.Time           `json:"created_at"`
 	UpdatedAt           time.Time           `json:"updated_at"`
 	ExpiresAt           time.Time           `json:"expires_at"`
+	Test                bool                `json:"test"`
 }
 
 type MerchantsAddress struct {
@@ -32,10 +32,10 @@ const createHostedPayment = `-- name: CreateHostedPayment :one
 
 INSERT INTO public.hosted_payments (
     id, user_id, merchant_id, amount, currency, description, reference, 
-    supported_mediums, phone_number, success_url, failed_url, callback_url, merchant_pays_fee, accept_tip
+    supported_mediums, phone_number, success_url, failed_url, callback_url, merchant_pays_fee, accept_tip, test
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
-) RETURNING id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+) RETURNING id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at, test
 `
 
 type CreateHostedPaymentParams struct {
@@ -53,6 +53,7 @@ type CreateHostedPaymentParams struct {
 	CallbackUrl      sql.NullString  `json:"callback_url"`
 	MerchantPaysFee  bool            `json:"merchant_pays_fee"`
 	AcceptTip        bool            `json:"accept_tip"`
+	Test             bool            `json:"test"`
 }
 
 // Hosted Payments Queries
@@ -72,6 +73,7 @@ func (q *Queries) CreateHostedPayment(ctx context.Context, arg CreateHostedPayme
 		arg.CallbackUrl,
 		arg.MerchantPaysFee,
 		arg.AcceptTip,
+		arg.Test,
 	)
 	var i HostedPayment
 	err := row.Scan(
@@ -96,6 +98,7 @@ func (q *Queries) CreateHostedPayment(ctx context.Context, arg CreateHostedPayme
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ExpiresAt,
+		&i.Test,
 	)
 	return i, err
 }
@@ -493,7 +496,7 @@ func (q *Queries) GetByUserIdAndReferenceID(ctx context.Context, arg GetByUserId
 }
 
 const getExpiredHostedPayments = `-- name: GetExpiredHostedPayments :many
-SELECT id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at FROM public.hosted_payments 
+SELECT id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at, test FROM public.hosted_payments 
 WHERE status = 'PENDING' AND expires_at < CURRENT_TIMESTAMP
 `
 
@@ -528,6 +531,7 @@ func (q *Queries) GetExpiredHostedPayments(ctx context.Context) ([]HostedPayment
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ExpiresAt,
+			&i.Test,
 		); err != nil {
 			return nil, err
 		}
@@ -737,7 +741,7 @@ func (q *Queries) GetFilteredTransactions(ctx context.Context, arg GetFilteredTr
 }
 
 const getHostedPayment = `-- name: GetHostedPayment :one
-SELECT id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at FROM public.hosted_payments 
+SELECT id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at, test FROM public.hosted_payments 
 WHERE id = $1
 `
 
@@ -766,12 +770,13 @@ func (q *Queries) GetHostedPayment(ctx context.Context, id uuid.UUID) (HostedPay
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ExpiresAt,
+		&i.Test,
 	)
 	return i, err
 }
 
 const getHostedPaymentByReference = `-- name: GetHostedPaymentByReference :one
-SELECT id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at FROM public.hosted_payments 
+SELECT id, user_id, merchant_id, amount, currency, description, reference, supported_mediums, phone_number, success_url, failed_url, callback_url, status, transaction_id, selected_medium, selected_phone_number, merchant_pays_fee, accept_tip, created_at, updated_at, expires_at, test FROM public.hosted_payments 
 WHERE reference = $1 AND merchant_id = $2
 `
 
@@ -805,6 +810,7 @@ func (q *Queries) GetHostedPaymentByReference(ctx context.Context, arg GetHosted
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ExpiresAt,
+		&i.Test,
 	)
 	return i, err
 }
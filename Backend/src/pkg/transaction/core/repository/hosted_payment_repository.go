@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
@@ -32,4 +33,12 @@ type HostedPaymentRepository interface {
 
 	// GetExpiredPayments retrieves expired hosted payments
 	GetExpiredPayments(ctx context.Context) ([]entity.HostedPayment, error)
+
+	// Expire marks a pending hosted payment as EXPIRED together with its INITIATED or PENDING transaction.
+	// It returns false when the payment was no longer pending, and the ID of the transaction
+	// that was expired alongside it (nil when there was none to close).
+	Expire(ctx context.Context, id uuid.UUID) (bool, *uuid.UUID, error)
+
+	// GetAbandonmentStats aggregates hosted checkout outcomes per merchant and selected medium
+	GetAbandonmentStats(ctx context.Context, merchantID *uuid.UUID, startDate, endDate time.Time) ([]entity.CheckoutAbandonmentStat, error)
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -15,11 +16,13 @@ import (
 
 type HostedPaymentRepositoryImpl struct {
 	Queries *db.Queries
+	db      *sql.DB
 }
 
 func NewHostedPaymentRepository(dbConn *sql.DB) HostedPaymentRepository {
 	return &HostedPaymentRepositoryImpl{
 		Queries: db.New(dbConn),
+		db:      dbConn,
 	}
 }
 
@@ -48,6 +51,7 @@ func (r *HostedPaymentRepositoryImpl) Create(ctx context.Context, hostedPayment
 		SuccessUrl:       hostedPayment.SuccessURL,
 		FailedUrl:        hostedPayment.FailedURL,
 		CallbackUrl:      sql.NullString{String: hostedPayment.CallbackURL, Valid: hostedPayment.CallbackURL != ""},
+		Test:             hostedPayment.Test,
 	})
 
 	return err
@@ -183,6 +187,7 @@ func toEntityHostedPayment(dbHostedPayment *db.HostedPayment) *entity.HostedPaym
 		CreatedAt:        dbHostedPayment.CreatedAt,
 		UpdatedAt:        dbHostedPayment.UpdatedAt,
 		ExpiresAt:        dbHostedPayment.ExpiresAt,
+		Test:             dbHostedPayment.Test,
 	}
 
 	// Handle optional transaction ID
@@ -196,3 +201,111 @@ func toEntityHostedPayment(dbHostedPayment *db.HostedPayment) *entity.HostedPaym
 
 	return hostedPayment
 }
+
+func (r *HostedPaymentRepositoryImpl) Expire(ctx context.Context, id uuid.UUID) (bool, *uuid.UUID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Only pending sessions are expired so a payment completing concurrently wins
+	var transactionID uuid.NullUUID
+	err = tx.QueryRowContext(ctx, `
+		UPDATE public.hosted_payments
+		SET status = 'EXPIRED', updated_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+		RETURNING transaction_id
+	`, id).Scan(&transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil, nil
+		}
+		return false, nil, fmt.Errorf("failed to expire hosted payment: %w", err)
+	}
+
+	var expiredTxID *uuid.UUID
+	if transactionID.Valid {
+		// A PENDING transaction is one the payer started with a medium and then abandoned
+		var from entity.TransactionStatus
+		err := tx.QueryRowContext(ctx, `
+			UPDATE public.transactions t
+			SET status = 'EXPIRED', updated_at = NOW()
+			FROM (SELECT id, status FROM public.transactions WHERE id = $1 FOR UPDATE) old
+			WHERE t.id = old.id AND old.status IN ('INITIATED', 'PENDING')
+			RETURNING old.status
+		`, transactionID.UUID).Scan(&from)
+		if err != nil && err != sql.ErrNoRows {
+			return false, nil, fmt.Errorf("failed to expire hosted payment transaction: %w", err)
+		}
+		if err == nil {
+			err = insertStatusEvent(ctx, tx, &entity.TransactionStatusEvent{
+				TransactionID: transactionID.UUID,
+				FromStatus:    from,
+				ToStatus:      entity.EXPIRED,
+				Source:        entity.StatusSourceCheckoutExpiry,
+				Reason:        "Hosted checkout expired unpaid",
+			})
+			if err != nil {
+				return false, nil, err
+			}
+			expiredTxID = &transactionID.UUID
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, nil, fmt.Errorf("failed to commit hosted payment expiry: %w", err)
+	}
+
+	return true, expiredTxID, nil
+}
+
+func (r *HostedPaymentRepositoryImpl) GetAbandonmentStats(ctx context.Context, merchantID *uuid.UUID, startDate, endDate time.Time) ([]entity.CheckoutAbandonmentStat, error) {
+	query := `
+		SELECT
+			merchant_id,
+			COALESCE(NULLIF(selected_medium, ''), $3) AS medium,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = 'COMPLETED') AS completed,
+			COUNT(*) FILTER (WHERE status = 'EXPIRED') AS expired,
+			COUNT(*) FILTER (WHERE status = 'CANCELED') AS canceled,
+			COUNT(*) FILTER (WHERE status = 'PENDING') AS pending
+		FROM public.hosted_payments
+		WHERE created_at >= $1 AND created_at < $2
+	`
+	args := []interface{}{startDate, endDate, entity.CheckoutMediumUnselected}
+	if merchantID != nil {
+		query += ` AND merchant_id = $4`
+		args = append(args, *merchantID)
+	}
+	query += ` GROUP BY merchant_id, medium ORDER BY merchant_id, medium`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkout abandonment stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []entity.CheckoutAbandonmentStat
+	for rows.Next() {
+		var stat entity.CheckoutAbandonmentStat
+		if err := rows.Scan(
+			&stat.MerchantID,
+			&stat.Medium,
+			&stat.Total,
+			&stat.Completed,
+			&stat.Expired,
+			&stat.Canceled,
+			&stat.Pending,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan checkout abandonment stats: %w", err)
+		}
+		stat.AbandonmentRate = entity.AbandonmentRate(stat.Completed, stat.Expired, stat.Canceled)
+		stats = append(stats, stat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read checkout abandonment stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -70,9 +70,9 @@ WHERE t.id = $1;
 -- name: CreateHostedPayment :one
 INSERT INTO public.hosted_payments (
     id, user_id, merchant_id, amount, currency, description, reference, 
-    supported_mediums, phone_number, success_url, failed_url, callback_url, merchant_pays_fee, accept_tip
+    supported_mediums, phone_number, success_url, failed_url, callback_url, merchant_pays_fee, accept_tip, test
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 ) RETURNING *;
 
 -- name: GetHostedPayment :one
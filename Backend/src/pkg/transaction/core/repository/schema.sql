@@ -9,6 +9,14 @@ CREATE TYPE transaction_status AS ENUM (
     'CANCELED'
 );
 
+-- Statuses added after the type was created; CREATE TYPE does not run again on existing databases
+-- Card hold placed, awaiting capture or void
+ALTER TYPE transaction_status ADD VALUE IF NOT EXISTS 'AUTHORIZED';
+-- Withdrawal held for a second team member's approval
+ALTER TYPE transaction_status ADD VALUE IF NOT EXISTS 'PENDING_APPROVAL';
+-- Payment held by the risk engine for manual review
+ALTER TYPE transaction_status ADD VALUE IF NOT EXISTS 'PENDING_REVIEW';
+
 -- Create transaction source enum type
 CREATE TYPE transaction_source AS ENUM (
     'DIRECT',
@@ -155,6 +163,9 @@ CREATE TABLE IF NOT EXISTS public.hosted_payments (
     CONSTRAINT fk_hosted_payments_transaction FOREIGN KEY (transaction_id) REFERENCES public.transactions(id)
 );
 
+-- Created with a test API key; paid through the simulator
+ALTER TABLE public.hosted_payments ADD COLUMN IF NOT EXISTS test BOOLEAN NOT NULL DEFAULT false;
+
 -- Create QR links table
 -- QR link type enum
 CREATE TYPE qr_link_type AS ENUM (
@@ -269,4 +280,57 @@ CREATE TABLE IF NOT EXISTS public.transaction_status_overrides (
     admin_id UUID NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (transaction_id) REFERENCES public.transactions(id) ON DELETE CASCADE
-); 
\ No newline at end of file
+); 
+
+-- Card authorization status enum type
+CREATE TYPE card_authorization_status AS ENUM (
+    'AUTHORIZED',
+    'CAPTURING',
+    'CAPTURED',
+    'VOIDING',
+    'VOIDED'
+);
+
+-- Card holds placed for authorize-only payments, captured or voided later
+CREATE TABLE IF NOT EXISTS public.card_authorizations (
+    transaction_id UUID PRIMARY KEY REFERENCES public.transactions(id) ON DELETE CASCADE,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    medium VARCHAR(50) NOT NULL,
+    status card_authorization_status NOT NULL DEFAULT 'AUTHORIZED',
+
+    -- Amounts in the transaction currency
+    authorized_amount DECIMAL(20,2) NOT NULL,
+    captured_amount DECIMAL(20,2),
+    currency VARCHAR(3) NOT NULL DEFAULT 'ETB',
+
+    -- Provider reference of the capture or void
+    provider_ref VARCHAR(100),
+    void_reason TEXT,
+
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    captured_at TIMESTAMP WITH TIME ZONE,
+    -- When the captured transaction settled into the wallets
+    settled_at TIMESTAMP WITH TIME ZONE,
+    voided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_authorizations_merchant_id ON public.card_authorizations(merchant_id);
+CREATE INDEX IF NOT EXISTS idx_card_authorizations_expiry ON public.card_authorizations(expires_at) WHERE status = 'AUTHORIZED';
+CREATE INDEX IF NOT EXISTS idx_card_authorizations_unsettled ON public.card_authorizations(captured_at) WHERE status = 'CAPTURED' AND settled_at IS NULL;
+
+-- Append-only history of transaction status changes
+CREATE TABLE IF NOT EXISTS public.transaction_status_events (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES public.transactions(id) ON DELETE CASCADE,
+    from_status transaction_status NOT NULL,
+    to_status transaction_status NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    actor VARCHAR(255),
+    reason TEXT,
+    provider_payload TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_status_events_transaction_id ON public.transaction_status_events(transaction_id, created_at);
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// StatusEventRepository persists transaction status changes together with their history
+type StatusEventRepository interface {
+	// Transition moves the transaction from event.FromStatus to event.ToStatus, appends the
+	// event and applies the wallet movements in one database transaction. It returns
+	// entity.ErrStatusConflict when the transaction is no longer in event.FromStatus. A captured
+	// card authorization is marked settled when its transaction succeeds.
+	Transition(ctx context.Context, event *entity.TransactionStatusEvent, movements []entity.WalletMovement) error
+
+	// ListByTransactionID retrieves the status history of a transaction, oldest first
+	ListByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]entity.TransactionStatusEvent, error)
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+type StatusEventRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewStatusEventRepository(db *sql.DB) StatusEventRepository {
+	return &StatusEventRepositoryImpl{db: db}
+}
+
+func (r *StatusEventRepositoryImpl) Transition(ctx context.Context, event *entity.TransactionStatusEvent, movements []entity.WalletMovement) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The expected status guards against a concurrent writer moving the transaction first
+	result, err := tx.ExecContext(ctx, `
+		UPDATE public.transactions
+		SET status = $3, updated_at = NOW()
+		WHERE id = $1 AND status = $2
+	`, event.TransactionID, string(event.FromStatus), string(event.ToStatus))
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrStatusConflict
+	}
+
+	if err := insertStatusEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	for _, movement := range movements {
+		if err := moveWallet(ctx, tx, movement); err != nil {
+			return err
+		}
+	}
+
+	// A captured card hold is settled once its transaction succeeds with the wallet credit
+	if event.ToStatus == entity.SUCCESS {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE public.card_authorizations
+			SET settled_at = NOW(), updated_at = NOW()
+			WHERE transaction_id = $1 AND status = 'CAPTURED' AND settled_at IS NULL
+		`, event.TransactionID); err != nil {
+			return fmt.Errorf("failed to mark card capture settled: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status transition: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatusEventRepositoryImpl) ListByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]entity.TransactionStatusEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, transaction_id, from_status, to_status, source, actor, reason, provider_payload, created_at
+		FROM public.transaction_status_events
+		WHERE transaction_id = $1
+		ORDER BY created_at, id
+	`, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction status events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []entity.TransactionStatusEvent{}
+	for rows.Next() {
+		var (
+			e                      entity.TransactionStatusEvent
+			from, to, source       string
+			actor, reason, payload sql.NullString
+		)
+		if err := rows.Scan(&e.ID, &e.TransactionID, &from, &to, &source, &actor, &reason, &payload, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction status event: %w", err)
+		}
+		e.FromStatus = entity.TransactionStatus(from)
+		e.ToStatus = entity.TransactionStatus(to)
+		e.Source = entity.StatusSource(source)
+		e.Actor = actor.String
+		e.Reason = reason.String
+		e.ProviderPayload = payload.String
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transaction status events: %w", err)
+	}
+
+	return events, nil
+}
+
+// insertStatusEvent appends a status event inside the caller's database transaction
+func insertStatusEvent(ctx context.Context, tx *sql.Tx, event *entity.TransactionStatusEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO public.transaction_status_events (
+			id, transaction_id, from_status, to_status, source, actor, reason, provider_payload
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`,
+		event.ID,
+		event.TransactionID,
+		string(event.FromStatus),
+		string(event.ToStatus),
+		string(event.Source),
+		nullableString(event.Actor),
+		nullableString(event.Reason),
+		nullableString(event.ProviderPayload),
+	).Scan(&event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record transaction status event: %w", err)
+	}
+
+	return nil
+}
+
+// moveWallet applies a wallet movement inside the caller's database transaction. The admin
+// commission goes to the super admin wallet, as the wallet repository credits it.
+func moveWallet(ctx context.Context, tx *sql.Tx, movement entity.WalletMovement) error {
+	query := `
+		UPDATE merchant.wallet
+		SET amount = amount + $1,
+			locked_amount = locked_amount + $2,
+			updated_at = NOW()
+		WHERE wallet_type = 'super_admin'`
+	args := []interface{}{movement.Amount, movement.LockedAmount}
+	if movement.MerchantID != nil {
+		query = `
+			UPDATE merchant.wallet
+			SET amount = amount + $1,
+				locked_amount = locked_amount + $2,
+				updated_at = NOW()
+			WHERE merchant_id = $3 AND wallet_type = 'merchant' AND currency = $4`
+		args = append(args, *movement.MerchantID, movement.Currency)
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+	if affected == 0 {
+		if movement.MerchantID == nil {
+			return fmt.Errorf("admin wallet not found")
+		}
+		return fmt.Errorf("%s merchant wallet not found for merchantID: %s", movement.Currency, *movement.MerchantID)
+	}
+
+	return nil
+}
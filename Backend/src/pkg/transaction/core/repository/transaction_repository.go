@@ -17,17 +17,16 @@ type TransactionRepository interface {
 	GetByReferenceID(ctx context.Context, referenceID string) (*entity.Transaction, error)
 	GetByUserIdAndReferenceID(ctx context.Context, userID uuid.UUID, referenceID string) (*entity.Transaction, error)
 	GetByMerchantIdAndReferenceID(ctx context.Context, merchantID uuid.UUID, referenceID string) (*entity.Transaction, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TransactionStatus) error
 
 	// UpdateTransactionWithProviderData updates transaction with provider information
 	UpdateTransactionWithProviderData(ctx context.Context, id uuid.UUID, updateParams map[string]interface{}) error
 
+	// MergeTransactionDetails adds keys to the transaction details, replacing the keys it already has
+	MergeTransactionDetails(ctx context.Context, id uuid.UUID, details map[string]interface{}) error
+
 	// Create creates a new transaction
 	Create(ctx context.Context, tx *entity.Transaction) error
 
-	// Update updates an existing transaction
-	Update(ctx context.Context, tx *entity.Transaction) error
-
 	// GetTransactionsByParameters retrieves transactions filtered by parameters
 	// GetTransactionsByParameters(ctx context.Context, params entity.FilterParameters, userID uuid.UUID, limit, offset int32) ([]entity.Transaction, error)
 	GetTransactionsByParameters(ctx context.Context, filterParam filter.Filter, userID uuid.UUID) ([]entity.Transaction, error)
@@ -41,7 +40,6 @@ type TransactionRepository interface {
 	GetMerchantTransactions(ctx context.Context, merchantID uuid.UUID, limit, offset int32) ([]entity.Transaction, error)
 
 	GetFilteredMerchantTransactions(ctx context.Context, params *entity.FilterParameters, merchantID uuid.UUID, limit, offset int32) ([]entity.Transaction, error)
-	OverrideTransactionStatus(ctx context.Context, txnID uuid.UUID, newStatus entity.TransactionStatus, reason string, adminID string) error
 
 	//
 
@@ -22,29 +22,16 @@ import (
 
 type TransactionRepositoryImpl struct {
 	Queries *db.Queries
-	q       sql.DB
+	q       *sql.DB
 }
 
 func NewTransactionRepository(dbConn *sql.DB) TransactionRepository {
 	return &TransactionRepositoryImpl{
 		Queries: db.New(dbConn),
-		q:       *dbConn,
+		q:       dbConn,
 	}
 }
 
-func (r *TransactionRepositoryImpl) OverrideTransactionStatus(ctx context.Context, txnID uuid.UUID, newStatus entity.TransactionStatus, reason string, adminID string) error {
-	adminUUID, err := uuid.Parse(adminID)
-	if err != nil {
-		return err
-	}
-	return r.Queries.OverrideTransactionStatus(ctx, db.OverrideTransactionStatusParams{
-		TransactionID: txnID,
-		Status:        db.TransactionStatus(newStatus),
-		Reason:        reason,
-		AdminID:       adminUUID,
-	})
-}
-
 func (r *TransactionRepositoryImpl) GetTransactions(ctx context.Context, user_id uuid.UUID, limit, offset int32) ([]entity.Transaction, int, error) {
 	dbTxns, err := r.Queries.GetTransactions(ctx, db.GetTransactionsParams{
 		UserID: user_id,
@@ -144,13 +131,6 @@ func (r *TransactionRepositoryImpl) GetByMerchantIdAndReferenceID(ctx context.Co
 	return &entityTxn, nil
 }
 
-func (r *TransactionRepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TransactionStatus) error {
-	return r.Queries.UpdateStatus(ctx, db.UpdateStatusParams{
-		ID:     id,
-		Status: db.TransactionStatus(status),
-	})
-}
-
 // UpdateTransactionWithProviderData updates transaction with provider information
 func (r *TransactionRepositoryImpl) UpdateTransactionWithProviderData(ctx context.Context, id uuid.UUID, updateParams map[string]interface{}) error {
 	setParts := []string{}
@@ -175,6 +155,22 @@ func (r *TransactionRepositoryImpl) UpdateTransactionWithProviderData(ctx contex
 	return err
 }
 
+func (r *TransactionRepositoryImpl) MergeTransactionDetails(ctx context.Context, id uuid.UUID, details map[string]interface{}) error {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction details: %w", err)
+	}
+
+	query := `
+		UPDATE public.transactions
+		SET details = COALESCE(details, '{}'::jsonb) || $1::jsonb, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+
+	_, err = r.q.ExecContext(ctx, query, string(detailsJSON), id)
+	return err
+}
+
 func toEntityTransaction(dbTxn *db.Transaction) entity.Transaction {
 	tx := entity.Transaction{
 		Id:        dbTxn.ID,
@@ -818,13 +814,6 @@ func (r *TransactionRepositoryImpl) toCreateWithContextParams(tx *entity.Transac
 	return params
 }
 
-func (r *TransactionRepositoryImpl) Update(ctx context.Context, tx *entity.Transaction) error {
-	return r.Queries.UpdateTransaction(ctx, db.UpdateTransactionParams{
-		ID:     tx.Id,
-		Status: db.TransactionStatus(tx.Status),
-	})
-}
-
 func (r *TransactionRepositoryImpl) GetTransactionsByParameters(ctx context.Context, filterParam filter.Filter, userID uuid.UUID) ([]entity.Transaction, error) {
 	// status := string(params.Status)
 
@@ -997,6 +986,7 @@ func (r *TransactionRepositoryImpl) GetTransactionAnalytics(ctx context.Context,
 	whereClause, args := r.buildAnalyticsWhereClause(filter, merchantID)
 
 	fmt.Println("whereClause", whereClause)
+	amount := analyticsAmount(filter, &args)
 
 	// Optimized analytics query using parallel aggregation
 	// Split into separate queries for better performance with proper indexes
@@ -1005,8 +995,8 @@ func (r *TransactionRepositoryImpl) GetTransactionAnalytics(ctx context.Context,
 	mainQuery := `
 		SELECT 
 			COUNT(*) as total_transactions,
-			COALESCE(SUM(base_amount), 0) as total_amount,
-			COALESCE(SUM(merchant_net), 0) as total_merchant_net
+			COALESCE(SUM(` + amount("base_amount") + `), 0) as total_amount,
+			COALESCE(SUM(` + amount("merchant_net") + `), 0) as total_merchant_net
 		FROM transactions 
 		WHERE ` + whereClause
 
@@ -1015,7 +1005,7 @@ func (r *TransactionRepositoryImpl) GetTransactionAnalytics(ctx context.Context,
 		SELECT 
 			type,
 			COUNT(*) as count,
-			COALESCE(SUM(base_amount), 0) as base_amount
+			COALESCE(SUM(` + amount("base_amount") + `), 0) as base_amount
 		FROM transactions 
 		WHERE ` + whereClause + `
 		GROUP BY type`
@@ -1024,7 +1014,7 @@ func (r *TransactionRepositoryImpl) GetTransactionAnalytics(ctx context.Context,
 	tipQuery := `
 		SELECT 
 			COUNT(*) as tip_count,
-			COALESCE(SUM(COALESCE(tip_amount::numeric, 0)), 0) as tip_amount
+			COALESCE(SUM(` + amount("COALESCE(tip_amount::numeric, 0)") + `), 0) as tip_amount
 		FROM transactions 
 		WHERE ` + whereClause + ` AND has_tip = true`
 
@@ -1098,18 +1088,20 @@ func (r *TransactionRepositoryImpl) GetChartData(ctx context.Context, filter *en
 	// Determine the date truncation based on date unit
 	dateTrunc := r.getDateTruncation(filter.DateUnit)
 
+	amount := analyticsAmount(&filter.AnalyticsFilter, &args)
+
 	// Optimized chart query - single query structure for both count and base_amount
 	query := fmt.Sprintf(`
 		SELECT 
 			DATE_TRUNC('%s', created_at) as period,
 			COUNT(*) as count,
-			COALESCE(SUM(base_amount), 0) as total_amount
+			COALESCE(SUM(%s), 0) as total_amount
 		FROM transactions 
 		WHERE %s
 		GROUP BY DATE_TRUNC('%s', created_at)
 		ORDER BY period ASC
 		LIMIT 1000
-	`, dateTrunc, whereClause, dateTrunc) // Add LIMIT to prevent excessive data
+	`, dateTrunc, amount("base_amount"), whereClause, dateTrunc) // Add LIMIT to prevent excessive data
 
 	rows, err := r.q.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -1181,6 +1173,24 @@ func (r *TransactionRepositoryImpl) GetChartData(ctx context.Context, filter *en
 	return chartData, nil
 }
 
+// analyticsAmount returns a function restating an amount column in the filter's base currency at
+// the rate in force when each transaction was made. The currency is added to args on first use
+// so queries that sum no amounts keep their argument list.
+func analyticsAmount(filter *entity.AnalyticsFilter, args *[]interface{}) func(column string) string {
+	if filter.Currency == "" {
+		return func(column string) string { return column }
+	}
+
+	argIndex := 0
+	return func(column string) string {
+		if argIndex == 0 {
+			*args = append(*args, filter.Currency)
+			argIndex = len(*args)
+		}
+		return fmt.Sprintf("(%s * public.fx_factor(currency, $%d, created_at))", column, argIndex)
+	}
+}
+
 // Helper function to build WHERE clause for analytics
 func (r *TransactionRepositoryImpl) buildAnalyticsWhereClause(filter *entity.AnalyticsFilter, merchantID uuid.UUID) (string, []interface{}) {
 	var conditions []string
@@ -1201,6 +1211,9 @@ func (r *TransactionRepositoryImpl) buildAnalyticsWhereClause(filter *entity.Ana
 	args = append(args, filter.EndDate)
 	argIndex++
 
+	// Sandbox transactions never count towards analytics
+	conditions = append(conditions, "test IS NOT TRUE")
+
 	// Status filter - use ANY for better performance with arrays
 	if len(filter.Status) > 0 {
 		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argIndex))
@@ -1331,6 +1344,7 @@ func (r *TransactionRepositoryImpl) calculatePeriodComparison(ctx context.Contex
 		AmountMin:  filter.AmountMin,
 		AmountMax:  filter.AmountMax,
 		MerchantID: filter.MerchantID,
+		Currency:   filter.Currency,
 	}
 
 	// Get previous period analytics
@@ -1369,34 +1383,35 @@ func (r *TransactionRepositoryImpl) GetAdminTransactionAnalytics(ctx context.Con
 	// Build WHERE clause for admin analytics (no merchant filter)
 	whereClause, args := r.buildAdminAnalyticsWhereClause(filter)
 	fmt.Println("[admin]whereClause", whereClause)
+	amount := analyticsAmount(filter, &args)
 	// Optimized query using partial indexes
 	query := fmt.Sprintf(`
 		WITH base_metrics AS (
 			SELECT 
 				COUNT(*) as total_transactions,
-				COALESCE(SUM(total_amount), 0) as total_amount,
-				COALESCE(SUM(merchant_net), 0) as total_merchant_net,
-				COALESCE(SUM(admin_net), 0) as total_admin_net
+				COALESCE(SUM(%s), 0) as total_amount,
+				COALESCE(SUM(%s), 0) as total_merchant_net,
+				COALESCE(SUM(%s), 0) as total_admin_net
 			FROM transactions 
 			WHERE %s
 		),
 		deposit_metrics AS (
 			SELECT 
-				COALESCE(SUM(base_amount), 0) as deposit_amount,
+				COALESCE(SUM(%s), 0) as deposit_amount,
 				COUNT(*) as deposit_count
 			FROM transactions 
 			WHERE %s AND type = 'DEPOSIT'
 		),
 		withdrawal_metrics AS (
 			SELECT 
-				COALESCE(SUM(base_amount), 0) as withdrawal_amount,
+				COALESCE(SUM(%s), 0) as withdrawal_amount,
 				COUNT(*) as withdrawal_count
 			FROM transactions 
 			WHERE %s AND type = 'WITHDRAWAL'
 		),
 		tips_metrics AS (
 			SELECT 
-				COALESCE(SUM(tip_amount), 0) as tips_amount,
+				COALESCE(SUM(%s), 0) as tips_amount,
 				COUNT(*) as tips_count
 			FROM transactions 
 			WHERE %s AND has_tip = true
@@ -1416,7 +1431,10 @@ func (r *TransactionRepositoryImpl) GetAdminTransactionAnalytics(ctx context.Con
 		CROSS JOIN deposit_metrics d
 		CROSS JOIN withdrawal_metrics w
 		CROSS JOIN tips_metrics t
-	`, whereClause, whereClause, whereClause, whereClause)
+	`, amount("total_amount"), amount("merchant_net"), amount("admin_net"), whereClause,
+		amount("base_amount"), whereClause,
+		amount("base_amount"), whereClause,
+		amount("tip_amount"), whereClause)
 
 	var analytics entity.AdminTransactionAnalytics
 	err := r.q.QueryRowContext(ctx, query, args...).Scan(
@@ -1443,23 +1461,25 @@ func (r *TransactionRepositoryImpl) GetAdminChartData(ctx context.Context, filte
 	// Build WHERE clause for admin analytics
 	whereClause, args := r.buildAdminAnalyticsWhereClause(&filter.AnalyticsFilter)
 
+	amount := analyticsAmount(&filter.AnalyticsFilter, &args)
+
 	// Determine what to select based on chart type
 	var selectField string
 	switch filter.ChartType {
 	case "amount":
-		selectField = "COALESCE(SUM(base_amount), 0)"
+		selectField = "COALESCE(SUM(" + amount("base_amount") + "), 0)"
 	case "count":
 		selectField = "COUNT(*)"
 	case "admin_net":
-		selectField = "COALESCE(SUM(admin_net), 0)"
+		selectField = "COALESCE(SUM(" + amount("admin_net") + "), 0)"
 	case "merchant_net":
-		selectField = "COALESCE(SUM(merchant_net), 0)"
+		selectField = "COALESCE(SUM(" + amount("merchant_net") + "), 0)"
 	case "vat":
-		selectField = "COALESCE(SUM(vat_amount), 0)"
+		selectField = "COALESCE(SUM(" + amount("vat_amount") + "), 0)"
 	case "fee":
-		selectField = "COALESCE(SUM(fee_amount), 0)"
+		selectField = "COALESCE(SUM(" + amount("fee_amount") + "), 0)"
 	default:
-		selectField = "COALESCE(SUM(base_amount), 0)"
+		selectField = "COALESCE(SUM(" + amount("base_amount") + "), 0)"
 	}
 
 	// Get date truncation for grouping
@@ -1621,6 +1641,9 @@ func (r *TransactionRepositoryImpl) buildAdminAnalyticsWhereClause(filter *entit
 	args = append(args, filter.EndDate)
 	argIndex++
 
+	// Sandbox transactions never count towards analytics
+	conditions = append(conditions, "test IS NOT TRUE")
+
 	// Status filter
 	if len(filter.Status) > 0 {
 		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argIndex))
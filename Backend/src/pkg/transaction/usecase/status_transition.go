@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	transaction_repository "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+)
+
+// StatusTransitionUseCase is the single way to change the status of a transaction.
+// Every change is checked against the state machine and recorded in the status history.
+type StatusTransitionUseCase interface {
+	// Transition moves a transaction to req.To. It returns entity.ErrStatusUnchanged when the
+	// transaction is already there, entity.ErrIllegalStatusTransition when the state machine
+	// forbids the change (admin overrides may also make the correction edges) and entity.ErrStatusConflict when another writer got there first.
+	Transition(ctx context.Context, req entity.StatusTransitionRequest) (*entity.TransactionStatusEvent, error)
+
+	// ApplyProcessorStatus records the status a processor returned when the payment was
+	// initiated. A callback may already have moved the transaction on, in which case the
+	// callback wins and tx.Status is left as loaded.
+	ApplyProcessorStatus(ctx context.Context, tx *entity.Transaction, status entity.TransactionStatus, reason string) error
+
+	// GetTimeline retrieves the status history of a transaction
+	GetTimeline(ctx context.Context, transactionID uuid.UUID) (*entity.TransactionTimeline, error)
+}
+
+type statusTransitionUseCase struct {
+	repo   transaction_repository.TransactionRepository
+	events transaction_repository.StatusEventRepository
+	log    logging.Logger
+}
+
+func NewStatusTransitionUseCase(
+	repo transaction_repository.TransactionRepository,
+	events transaction_repository.StatusEventRepository) StatusTransitionUseCase {
+	return &statusTransitionUseCase{
+		repo:   repo,
+		events: events,
+		log:    logging.NewStdLogger("[TRANSACTION] [STATUS]"),
+	}
+}
+
+func (u *statusTransitionUseCase) Transition(ctx context.Context, req entity.StatusTransitionRequest) (*entity.TransactionStatusEvent, error) {
+	tx, err := u.repo.GetByID(ctx, req.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if tx.Status == req.To {
+		return nil, entity.ErrStatusUnchanged
+	}
+	if req.From != "" && tx.Status != req.From {
+		return nil, entity.ErrStatusConflict
+	}
+
+	if !entity.CanTransitionAs(req.Source, tx.Status, req.To) {
+		u.log.Warn("Rejected illegal status transition", map[string]interface{}{
+			"transaction_id": req.TransactionID,
+			"from":           tx.Status,
+			"to":             req.To,
+			"source":         req.Source,
+			"actor":          req.Actor,
+		})
+		return nil, fmt.Errorf("%w: %s to %s", entity.ErrIllegalStatusTransition, tx.Status, req.To)
+	}
+
+	event := &entity.TransactionStatusEvent{
+		ID:              uuid.New(),
+		TransactionID:   req.TransactionID,
+		FromStatus:      tx.Status,
+		ToStatus:        req.To,
+		Source:          req.Source,
+		Actor:           req.Actor,
+		Reason:          req.Reason,
+		ProviderPayload: req.ProviderPayload,
+	}
+
+	if err := u.events.Transition(ctx, event, req.Movements); err != nil {
+		if errors.Is(err, entity.ErrStatusConflict) {
+			u.log.Warn("Status changed concurrently", map[string]interface{}{
+				"transaction_id": req.TransactionID,
+				"expected":       tx.Status,
+				"to":             req.To,
+				"source":         req.Source,
+			})
+		}
+		return nil, err
+	}
+
+	u.log.Info("Transaction status changed", map[string]interface{}{
+		"transaction_id": req.TransactionID,
+		"from":           event.FromStatus,
+		"to":             event.ToStatus,
+		"source":         event.Source,
+		"actor":          event.Actor,
+	})
+
+	return event, nil
+}
+
+func (u *statusTransitionUseCase) ApplyProcessorStatus(ctx context.Context, tx *entity.Transaction, status entity.TransactionStatus, reason string) error {
+	_, err := u.Transition(ctx, entity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            status,
+		Source:        entity.StatusSourceProcessorResponse,
+		Actor:         string(tx.Medium),
+		Reason:        reason,
+	})
+	switch {
+	case err == nil, errors.Is(err, entity.ErrStatusUnchanged):
+		tx.Status = status
+		return nil
+	case errors.Is(err, entity.ErrIllegalStatusTransition), errors.Is(err, entity.ErrStatusConflict):
+		return nil
+	}
+	return err
+}
+
+func (u *statusTransitionUseCase) GetTimeline(ctx context.Context, transactionID uuid.UUID) (*entity.TransactionTimeline, error) {
+	tx, err := u.repo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	events, err := u.events.ListByTransactionID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TransactionTimeline{
+		TransactionID: tx.Id,
+		CurrentStatus: tx.Status,
+		Events:        events,
+	}, nil
+}
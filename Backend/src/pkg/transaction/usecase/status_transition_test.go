@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	transaction_repository "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+)
+
+// stubTransactionRepo serves a single transaction; every other method is left unimplemented
+type stubTransactionRepo struct {
+	transaction_repository.TransactionRepository
+	tx *entity.Transaction
+}
+
+func (r *stubTransactionRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	return r.tx, nil
+}
+
+// stubStatusEventRepo records the events it is asked to apply
+type stubStatusEventRepo struct {
+	transaction_repository.StatusEventRepository
+	applied []*entity.TransactionStatusEvent
+}
+
+func (r *stubStatusEventRepo) Transition(ctx context.Context, event *entity.TransactionStatusEvent, movements []entity.WalletMovement) error {
+	r.applied = append(r.applied, event)
+	return nil
+}
+
+func TestStatusTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    entity.TransactionStatus
+		to      entity.TransactionStatus
+		source  entity.StatusSource
+		allowed bool
+	}{
+		{"callback settles a pending payment", entity.PENDING, entity.SUCCESS, entity.StatusSourceProviderCallback, true},
+		{"capture settles a card hold", entity.AUTHORIZED, entity.SUCCESS, entity.StatusSourceCardAuthorization, true},
+		{"review releases a held payment", entity.PENDING_REVIEW, entity.PENDING, entity.StatusSourceRiskEngine, true},
+		{"sweeper expires an abandoned checkout", entity.PENDING, entity.EXPIRED, entity.StatusSourceCheckoutExpiry, true},
+		{"admin refunds a payment", entity.SUCCESS, entity.REFUNDED, entity.StatusSourceAdminOverride, true},
+		{"admin reverses a payment", entity.SUCCESS, entity.FAILED, entity.StatusSourceAdminOverride, true},
+		{"admin confirms a failed payment", entity.FAILED, entity.SUCCESS, entity.StatusSourceAdminOverride, true},
+		{"admin confirms an expired payment", entity.EXPIRED, entity.SUCCESS, entity.StatusSourceAdminOverride, true},
+		{"callback cannot reverse a payment", entity.SUCCESS, entity.FAILED, entity.StatusSourceProviderCallback, false},
+		{"callback cannot revive a failed payment", entity.FAILED, entity.SUCCESS, entity.StatusSourceProviderCallback, false},
+		{"processor cannot reopen a payment", entity.SUCCESS, entity.PENDING, entity.StatusSourceProcessorResponse, false},
+		{"admin cannot reopen a payment", entity.SUCCESS, entity.PENDING, entity.StatusSourceAdminOverride, false},
+		{"admin cannot undo a refund", entity.REFUNDED, entity.SUCCESS, entity.StatusSourceAdminOverride, false},
+		{"admin cannot revive a canceled payment", entity.CANCELED, entity.SUCCESS, entity.StatusSourceAdminOverride, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &entity.Transaction{Id: uuid.New(), Status: tt.from}
+			events := &stubStatusEventRepo{}
+			uc := NewStatusTransitionUseCase(&stubTransactionRepo{tx: tx}, events)
+
+			event, err := uc.Transition(context.Background(), entity.StatusTransitionRequest{
+				TransactionID: tx.Id,
+				To:            tt.to,
+				Source:        tt.source,
+				Actor:         "test",
+			})
+
+			if !tt.allowed {
+				if !errors.Is(err, entity.ErrIllegalStatusTransition) {
+					t.Fatalf("Transition(%s -> %s) = %v, want %v", tt.from, tt.to, err, entity.ErrIllegalStatusTransition)
+				}
+				if len(events.applied) != 0 {
+					t.Fatalf("Transition(%s -> %s) recorded %d events, want none", tt.from, tt.to, len(events.applied))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Transition(%s -> %s) = %v, want nil", tt.from, tt.to, err)
+			}
+			if len(events.applied) != 1 || events.applied[0] != event {
+				t.Fatalf("Transition(%s -> %s) recorded %d events, want the returned one", tt.from, tt.to, len(events.applied))
+			}
+			if event.FromStatus != tt.from || event.ToStatus != tt.to || event.Source != tt.source {
+				t.Fatalf("event = %s -> %s by %s, want %s -> %s by %s",
+					event.FromStatus, event.ToStatus, event.Source, tt.from, tt.to, tt.source)
+			}
+		})
+	}
+}
+
+func TestStatusTransitionUnchanged(t *testing.T) {
+	tx := &entity.Transaction{Id: uuid.New(), Status: entity.SUCCESS}
+	uc := NewStatusTransitionUseCase(&stubTransactionRepo{tx: tx}, &stubStatusEventRepo{})
+
+	_, err := uc.Transition(context.Background(), entity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            entity.SUCCESS,
+		Source:        entity.StatusSourceAdminOverride,
+	})
+	if !errors.Is(err, entity.ErrStatusUnchanged) {
+		t.Fatalf("Transition() = %v, want %v", err, entity.ErrStatusUnchanged)
+	}
+}
@@ -18,7 +18,6 @@ type TransactionUseCase interface {
 		parameter *entity.FilterParameters, pagination pagination.Pagination, queryForAllUsers bool) ([]entity.Transaction, int, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error)
 	GetByReferenceID(ctx context.Context, referenceID string) (*entity.Transaction, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TransactionStatus) error
 	ValidateReferenceId(ctx context.Context, merchantID uuid.UUID, referenceID string) error
 
 	// Analytics methods
@@ -29,4 +28,8 @@ type TransactionUseCase interface {
 	GetAdminTransactionAnalytics(ctx context.Context, filter *entity.AnalyticsFilter) (*entity.AdminTransactionAnalytics, error)
 	GetAdminChartData(ctx context.Context, filter *entity.ChartFilter) (*entity.ChartData, error)
 	GetMerchantGrowthAnalytics(ctx context.Context, startDate, endDate time.Time, dateUnit entity.DateUnit) (*entity.MerchantGrowthAnalytics, error)
+
+	// GetCheckoutAbandonment reports hosted checkout abandonment per merchant and medium.
+	// A nil merchantID reports across all merchants.
+	GetCheckoutAbandonment(ctx context.Context, merchantID *uuid.UUID, startDate, endDate time.Time) (*entity.CheckoutAbandonmentReport, error)
 }
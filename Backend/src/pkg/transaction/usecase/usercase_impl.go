@@ -15,8 +15,9 @@ import (
 )
 
 type transactionUseCase struct {
-	repo transaction_repository.TransactionRepository
-	log  logging.Logger
+	repo              transaction_repository.TransactionRepository
+	hostedPaymentRepo transaction_repository.HostedPaymentRepository
+	log               logging.Logger
 }
 
 func (t *transactionUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
@@ -27,15 +28,13 @@ func (t *transactionUseCase) GetByReferenceID(ctx context.Context, referenceID s
 	return t.repo.GetByReferenceID(ctx, referenceID)
 }
 
-func (t *transactionUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TransactionStatus) error {
-	return t.repo.UpdateStatus(ctx, id, status)
-}
-
 func NewTransactionUsecase(
-	repo transaction_repository.TransactionRepository) TransactionUseCase {
+	repo transaction_repository.TransactionRepository,
+	hostedPaymentRepo transaction_repository.HostedPaymentRepository) TransactionUseCase {
 	return &transactionUseCase{
-		repo: repo,
-		log:  logging.NewStdLogger("[TRANSACTION] [USECASE]"),
+		repo:              repo,
+		hostedPaymentRepo: hostedPaymentRepo,
+		log:               logging.NewStdLogger("[TRANSACTION] [USECASE]"),
 	}
 }
 
@@ -295,6 +294,50 @@ func (t *transactionUseCase) GetAdminTransactionAnalytics(ctx context.Context, f
 	return analytics, nil
 }
 
+// GetCheckoutAbandonment aggregates hosted checkout outcomes for the period
+func (t *transactionUseCase) GetCheckoutAbandonment(ctx context.Context, merchantID *uuid.UUID, startDate, endDate time.Time) (*entity.CheckoutAbandonmentReport, error) {
+	if endDate.IsZero() {
+		endDate = time.Now()
+	}
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, 0, -30)
+	}
+	if !endDate.After(startDate) {
+		return nil, errorxx.ErrAppBadInput.Wrap(fmt.Errorf("end_date must be after start_date"), "checkout abandonment filter validation error").
+			WithProperty(errorxx.ErrorCode, 400)
+	}
+
+	stats, err := t.hostedPaymentRepo.GetAbandonmentStats(ctx, merchantID, startDate, endDate)
+	if err != nil {
+		err = errorxx.ErrDBRead.Wrap(err, "failed to get checkout abandonment stats").
+			WithProperty(errorxx.ErrorCode, 500)
+
+		t.log.Error("failed to get checkout abandonment stats", map[string]interface{}{
+			"error":       err,
+			"merchant_id": merchantID,
+		})
+		return nil, err
+	}
+
+	report := &entity.CheckoutAbandonmentReport{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Stats:     stats,
+	}
+	if report.Stats == nil {
+		report.Stats = []entity.CheckoutAbandonmentStat{}
+	}
+	for _, stat := range stats {
+		report.Total += stat.Total
+		report.Completed += stat.Completed
+		report.Expired += stat.Expired
+		report.Canceled += stat.Canceled
+	}
+	report.AbandonmentRate = entity.AbandonmentRate(report.Completed, report.Expired, report.Canceled)
+
+	return report, nil
+}
+
 // GetAdminChartData retrieves admin-specific chart data
 func (t *transactionUseCase) GetAdminChartData(ctx context.Context, filter *entity.ChartFilter) (*entity.ChartData, error) {
 	// Validate the filter parameters
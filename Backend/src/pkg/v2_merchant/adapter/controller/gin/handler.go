@@ -1,6 +1,7 @@
 package gin
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -97,6 +98,7 @@ type UpdateAdminMerchantRequest struct {
 // UpdateMerchantStatusRequest contains update merchant status request body
 type UpdateMerchantStatusRequest struct {
 	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
 }
 
 // DeleteMerchantsRequest contains delete merchants request body
@@ -151,6 +153,21 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 			h.rbac.RequireMerchantOwner(),
 			h.UpdateMerchant)
 
+		merchants.GET("/tin/lookup/:tin",
+			ginn.JWTAuthMiddleware(jwtConfig),
+			h.rbac.RequireMerchantOwner(),
+			h.LookupTIN)
+
+		merchants.GET("/admin/tin-verifications/:id",
+			ginn.JWTAuthMiddleware(jwtConfig),
+			h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_MERCHANT, auth_entity.OPERATION_ADMIN_READ),
+			h.GetTINVerifications)
+
+		merchants.POST("/admin/tin-verifications/:id",
+			ginn.JWTAuthMiddleware(jwtConfig),
+			h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_MERCHANT, auth_entity.OPERATION_ADMIN_UPDATE),
+			h.VerifyMerchantTIN)
+
 		merchants.PUT("/admin/status/update/:id",
 			ginn.JWTAuthMiddleware(jwtConfig),
 			h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_MERCHANT, auth_entity.OPERATION_ADMIN_UPDATE),
@@ -647,7 +664,7 @@ func (h *Handler) ExportMerchants(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body UpdateMerchantRequest true "UpdateMerchant details"
-// @Success 201 {object} map[string]interface{} "Merchants updated successfully"
+// @Success 201 {object} map[string]interface{} "Merchants updated successfully, with the TIN registry check of a submitted TIN"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /merchants/update [put]
@@ -677,7 +694,7 @@ func (h *Handler) UpdateMerchant(c *gin.Context) {
 		return
 	}
 
-	err := h.useCase.UpdateMerchant(c, merchantId, &req)
+	verification, err := h.useCase.UpdateMerchant(c, merchantId, &req)
 
 	if err != nil {
 		if err.Error() == "merchant not found" {
@@ -706,14 +723,15 @@ func (h *Handler) UpdateMerchant(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Merchant updated successfully",
+		"success":          true,
+		"message":          "Merchant updated successfully",
+		"tin_verification": verification,
 	})
 }
 
 // UpdateMerchantStatus handles update merchant status
 // @Summary Update merchant status
-// @Description Update merchant status
+// @Description Update merchant status. A change is published to the merchant as a merchant.status_changed webhook event.
 // @Tags v2-merchants
 // @Accept json
 // @Produce json
@@ -755,6 +773,17 @@ func (h *Handler) UpdateMerchantStatus(c *gin.Context) {
 	err = h.useCase.UpdateMerchantStatus(c, id, &req)
 
 	if err != nil {
+		if errors.Is(err, entity.ErrInvalidMerchantStatus) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error: ApiError{
+					Type:    "INVALID_REQUEST",
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
 		if err.Error() == "merchant not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Success: false,
@@ -973,9 +1002,20 @@ func (h *Handler) UpdateAdminMerchant(c *gin.Context) {
 		Documents:    req.Documents,
 	}
 
-	err = h.useCase.UpdateAdminMerchant(c, id, entityReq)
+	verification, err := h.useCase.UpdateAdminMerchant(c, id, entityReq)
 
 	if err != nil {
+		if errors.Is(err, entity.ErrInvalidMerchantStatus) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error: ApiError{
+					Type:    "INVALID_REQUEST",
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
 		if err.Error() == "merchant not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Success: false,
@@ -1002,8 +1042,156 @@ func (h *Handler) UpdateAdminMerchant(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Merchant updated successfully",
+		"success":          true,
+		"message":          "Merchant updated successfully",
+		"tin_verification": verification,
+	})
+}
+
+// LookupTIN handles trade registry lookup
+// @Summary Look up TIN
+// @Description Look a business up in the trade registry by TIN, to fill in business information
+// @Tags v2-merchants
+// @Produce json
+// @Param tin path string true "Taxpayer identification number"
+// @Success 200 {object} SuccessResponse{data=entity.TINRegistryRecord}
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /merchants/tin/lookup/{tin} [get]
+func (h *Handler) LookupTIN(c *gin.Context) {
+	record, err := h.useCase.LookupTIN(c.Request.Context(), c.Param("tin"))
+	if err != nil {
+		if errors.Is(err, entity.ErrTINNotRegistered) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error: ApiError{
+					Type:    "TIN_NOT_REGISTERED",
+					Message: "No business is registered with this TIN",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Success: false,
+			Error: ApiError{
+				Type:    "REGISTRY_UNAVAILABLE",
+				Message: "The trade registry could not be reached",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    record,
+	})
+}
+
+// GetTINVerifications handles list merchant TIN verifications
+// @Summary Get merchant TIN verifications
+// @Description Get the trade registry checks of a merchant's TIN, newest first, with the registry responses
+// @Tags v2-merchants
+// @Produce json
+// @Param id path string true "Merchant ID"
+// @Success 200 {object} SuccessResponse{data=[]entity.TINVerification}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /merchants/admin/tin-verifications/{id} [get]
+func (h *Handler) GetTINVerifications(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error: ApiError{
+				Type:    "INVALID_REQUEST",
+				Message: "Invalid merchant ID",
+			},
+		})
+		return
+	}
+
+	verifications, err := h.useCase.GetTINVerifications(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error: ApiError{
+				Type:    "INTERNAL_SERVER_ERROR",
+				Message: "Failed to get TIN verifications",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    verifications,
+	})
+}
+
+// VerifyMerchantTIN handles re-checking a merchant TIN
+// @Summary Verify merchant TIN
+// @Description Check the stored TIN and business details of a merchant against the trade registry
+// @Tags v2-merchants
+// @Produce json
+// @Param id path string true "Merchant ID"
+// @Success 200 {object} SuccessResponse{data=entity.TINVerification}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /merchants/admin/tin-verifications/{id} [post]
+func (h *Handler) VerifyMerchantTIN(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error: ApiError{
+				Type:    "INVALID_REQUEST",
+				Message: "Invalid merchant ID",
+			},
+		})
+		return
+	}
+
+	verification, err := h.useCase.VerifyMerchantTIN(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case err.Error() == "merchant not found":
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error: ApiError{
+					Type:    "NOT_FOUND",
+					Message: "Merchant not found",
+				},
+			})
+		case errors.Is(err, entity.ErrTINMissing):
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Success: false,
+				Error: ApiError{
+					Type:    "TIN_MISSING",
+					Message: "Merchant has not submitted a TIN",
+				},
+			})
+		default:
+			h.log.Error("Failed to verify merchant TIN", map[string]interface{}{
+				"error": err.Error(),
+				"id":    id,
+			})
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Success: false,
+				Error: ApiError{
+					Type:    "INTERNAL_SERVER_ERROR",
+					Message: "Failed to verify merchant TIN",
+				},
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    verification,
 	})
 }
 
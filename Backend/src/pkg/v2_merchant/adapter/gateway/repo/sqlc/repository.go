@@ -3,6 +3,7 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -267,6 +268,175 @@ func (r *merchantRepository) GetMerchantSettings(ctx context.Context, merchantID
 	return r.convertSettingsToEntity(settings), nil
 }
 
+// UpdateMerchantCheckoutSettings upserts the checkout theme and default language of a merchant
+func (r *merchantRepository) UpdateMerchantCheckoutSettings(ctx context.Context, merchantID uuid.UUID, checkoutTheme *string, defaultLanguage string) error {
+	query := `
+		INSERT INTO merchants.settings (merchant_id, default_language, checkout_theme)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (merchant_id) DO UPDATE
+		SET default_language = EXCLUDED.default_language,
+			checkout_theme = EXCLUDED.checkout_theme,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, merchantID, defaultLanguage, utils.ToNullString(checkoutTheme))
+	if err != nil {
+		return fmt.Errorf("failed to update merchant checkout settings: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateMerchantRiskSettings upserts the risk rule overrides of a merchant
+func (r *merchantRepository) UpdateMerchantRiskSettings(ctx context.Context, merchantID uuid.UUID, riskSettings string) error {
+	query := `
+		INSERT INTO merchants.settings (merchant_id, risk_settings)
+		VALUES ($1, $2::jsonb)
+		ON CONFLICT (merchant_id) DO UPDATE
+		SET risk_settings = EXCLUDED.risk_settings,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, merchantID, riskSettings)
+	if err != nil {
+		return fmt.Errorf("failed to update merchant risk settings: %w", err)
+	}
+
+	return nil
+}
+
+// CreateTINVerification stores the result of a trade registry lookup for a merchant
+func (r *merchantRepository) CreateTINVerification(ctx context.Context, verification *entity.TINVerification) error {
+	autoFilled, err := json.Marshal(nonNil(verification.AutoFilled))
+	if err != nil {
+		return fmt.Errorf("failed to encode auto filled fields: %w", err)
+	}
+	discrepancies, err := json.Marshal(nonNil(verification.Discrepancies))
+	if err != nil {
+		return fmt.Errorf("failed to encode discrepancies: %w", err)
+	}
+	var rawResponse sql.NullString
+	if len(verification.RawResponse) > 0 {
+		rawResponse = sql.NullString{String: string(verification.RawResponse), Valid: true}
+	}
+
+	query := `
+		INSERT INTO merchants.tin_verifications (
+			id, merchant_id, tin, status, source, legal_name, registration_number,
+			business_category, legal_type, auto_filled, discrepancies, raw_response, error, checked_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::jsonb, $11::jsonb, $12::jsonb, $13, $14)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		verification.ID,
+		verification.MerchantID,
+		verification.TIN,
+		string(verification.Status),
+		verification.Source,
+		utils.ToNullString(verification.LegalName),
+		utils.ToNullString(verification.RegistrationNumber),
+		utils.ToNullString(verification.BusinessCategory),
+		utils.ToNullString(verification.LegalType),
+		string(autoFilled),
+		string(discrepancies),
+		rawResponse,
+		utils.ToNullString(verification.Error),
+		verification.CheckedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create TIN verification: %w", err)
+	}
+
+	return nil
+}
+
+// GetTINVerifications retrieves the trade registry lookups of a merchant, newest first
+func (r *merchantRepository) GetTINVerifications(ctx context.Context, merchantID uuid.UUID) ([]entity.TINVerification, error) {
+	query := `
+		SELECT id, merchant_id, tin, status, source, legal_name, registration_number,
+			business_category, legal_type, auto_filled, discrepancies, raw_response, error, checked_at
+		FROM merchants.tin_verifications
+		WHERE merchant_id = $1
+		ORDER BY checked_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TIN verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var verifications []entity.TINVerification
+	for rows.Next() {
+		var (
+			verification                                    entity.TINVerification
+			status                                          string
+			legalName, registrationNumber, businessCategory sql.NullString
+			legalType, rawResponse, verificationError       sql.NullString
+			autoFilled, discrepancies                       []byte
+		)
+		err := rows.Scan(
+			&verification.ID,
+			&verification.MerchantID,
+			&verification.TIN,
+			&status,
+			&verification.Source,
+			&legalName,
+			&registrationNumber,
+			&businessCategory,
+			&legalType,
+			&autoFilled,
+			&discrepancies,
+			&rawResponse,
+			&verificationError,
+			&verification.CheckedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan TIN verification: %w", err)
+		}
+
+		verification.Status = entity.TINVerificationStatus(status)
+		verification.LegalName = nullStringPtr(legalName)
+		verification.RegistrationNumber = nullStringPtr(registrationNumber)
+		verification.BusinessCategory = nullStringPtr(businessCategory)
+		verification.LegalType = nullStringPtr(legalType)
+		verification.Error = nullStringPtr(verificationError)
+		if rawResponse.Valid {
+			verification.RawResponse = json.RawMessage(rawResponse.String)
+		}
+		if err := json.Unmarshal(autoFilled, &verification.AutoFilled); err != nil {
+			return nil, fmt.Errorf("failed to decode auto filled fields: %w", err)
+		}
+		if err := json.Unmarshal(discrepancies, &verification.Discrepancies); err != nil {
+			return nil, fmt.Errorf("failed to decode discrepancies: %w", err)
+		}
+
+		verifications = append(verifications, verification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate TIN verifications: %w", err)
+	}
+
+	return verifications, nil
+}
+
+// nullStringPtr converts a nullable column to an optional string
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// nonNil encodes a nil slice as an empty JSON array rather than null
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
+
 // UpdateMerchant updates merchant
 func (r *merchantRepository) UpdateMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) error {
 	businessInfo := req.BusinessInfo
@@ -98,10 +98,29 @@ CREATE TABLE IF NOT EXISTS merchants.settings (
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
+-- Trade registry lookups of a merchant's TIN, kept as onboarding evidence
+CREATE TABLE IF NOT EXISTS merchants.tin_verifications (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    merchant_id uuid NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    tin VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    legal_name VARCHAR(255),
+    registration_number VARCHAR(100),
+    business_category VARCHAR(255),
+    legal_type VARCHAR(100),
+    auto_filled JSONB NOT NULL DEFAULT '[]',
+    discrepancies JSONB NOT NULL DEFAULT '[]',
+    raw_response JSONB,
+    error TEXT,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
 -- Indexes for better query performance
 CREATE INDEX IF NOT EXISTS idx_merchants_user_id ON merchants.merchants(user_id);
 CREATE INDEX IF NOT EXISTS idx_merchants_status ON merchants.merchants(status);
 CREATE INDEX IF NOT EXISTS idx_addresses_merchant_id ON merchants.addresses(merchant_id);
 CREATE INDEX IF NOT EXISTS idx_contacts_merchant_id ON merchants.contacts(merchant_id);
 CREATE INDEX IF NOT EXISTS idx_documents_merchant_id ON merchants.documents(merchant_id);
-CREATE INDEX IF NOT EXISTS idx_bank_accounts_merchant_id ON merchants.bank_accounts(merchant_id); 
+CREATE INDEX IF NOT EXISTS idx_bank_accounts_merchant_id ON merchants.bank_accounts(merchant_id);
+CREATE INDEX IF NOT EXISTS idx_tin_verifications_merchant_id ON merchants.tin_verifications(merchant_id, checked_at DESC); 
@@ -0,0 +1,98 @@
+package etrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	orgUsecase "github.com/socialpay/socialpay/src/pkg/org/usecase"
+	"github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/v2_merchant/usecase"
+)
+
+// lookupTimeout bounds a registry call, which the v1 client makes without a deadline
+const lookupTimeout = 15 * time.Second
+
+type registry struct {
+	checker orgUsecase.TINChecker
+}
+
+// New creates a TIN registry backed by the Ministry of Trade e-trade registration lookup
+func New(checker orgUsecase.TINChecker) usecase.TINRegistry {
+	return registry{checker: checker}
+}
+
+func (registry) Name() string {
+	return "etrade"
+}
+
+func (r registry) LookupTIN(ctx context.Context, tin string) (*entity.TINRegistryRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	type result struct {
+		raw map[string]interface{}
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		raw, err := r.checker.CheckTINRaw(tin)
+		done <- result{raw: raw, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("e-trade lookup: %w", ctx.Err())
+	case res := <-done:
+		if res.err != nil {
+			return nil, fmt.Errorf("e-trade lookup: %w", res.err)
+		}
+		return parseRegistration(tin, res.raw)
+	}
+}
+
+// parseRegistration maps an e-trade registration to a registry record. The registry answers
+// unknown TINs with an empty registration rather than an error.
+func parseRegistration(tin string, raw map[string]interface{}) (*entity.TINRegistryRecord, error) {
+	legalName := stringField(raw, "BusinessName")
+	if legalName == "" {
+		return nil, entity.ErrTINNotRegistered
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode e-trade response: %w", err)
+	}
+
+	return &entity.TINRegistryRecord{
+		TIN:                tin,
+		LegalName:          legalName,
+		RegistrationNumber: stringField(raw, "RegNo"),
+		BusinessCategory:   businessCategory(raw),
+		LegalType:          stringField(raw, "LegalCondtion"),
+		Raw:                encoded,
+	}, nil
+}
+
+// businessCategory is the first licensed business line, e.g. "Retail Trade of Electronics"
+func businessCategory(raw map[string]interface{}) string {
+	businesses, _ := raw["Businesses"].([]interface{})
+	for _, business := range businesses {
+		business, _ := business.(map[string]interface{})
+		subGroups, _ := business["SubGroups"].([]interface{})
+		for _, subGroup := range subGroups {
+			subGroup, _ := subGroup.(map[string]interface{})
+			if description := stringField(subGroup, "Description"); description != "" {
+				return description
+			}
+		}
+	}
+	return ""
+}
+
+func stringField(raw map[string]interface{}, key string) string {
+	value, _ := raw[key].(string)
+	return strings.TrimSpace(value)
+}
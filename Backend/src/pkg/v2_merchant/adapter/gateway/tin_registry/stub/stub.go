@@ -0,0 +1,64 @@
+package stub
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sync"
+
+	"github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+// tinPattern is the shape of an Ethiopian TIN
+var tinPattern = regexp.MustCompile(`^[0-9]{10}$`)
+
+// Registry is an in-memory TIN registry for running onboarding offline and in tests. Added
+// records are returned as is; any other well-formed TIN is treated as registered with no
+// details on file, so nothing is auto-filled or flagged, and malformed TINs are not registered.
+type Registry struct {
+	mu      sync.RWMutex
+	records map[string]entity.TINRegistryRecord
+}
+
+// New creates a stub registry holding records
+func New(records ...entity.TINRegistryRecord) *Registry {
+	r := &Registry{records: make(map[string]entity.TINRegistryRecord)}
+	for _, record := range records {
+		r.Add(record)
+	}
+	return r
+}
+
+// Add registers a business, replacing any record with the same TIN
+func (r *Registry) Add(record entity.TINRegistryRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[record.TIN] = record
+}
+
+func (r *Registry) Name() string {
+	return "stub"
+}
+
+func (r *Registry) LookupTIN(ctx context.Context, tin string) (*entity.TINRegistryRecord, error) {
+	r.mu.RLock()
+	record, ok := r.records[tin]
+	r.mu.RUnlock()
+
+	if !ok {
+		if !tinPattern.MatchString(tin) {
+			return nil, entity.ErrTINNotRegistered
+		}
+		record = entity.TINRegistryRecord{TIN: tin}
+	}
+
+	if record.Raw == nil {
+		raw, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		record.Raw = raw
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMerchantIneligible is wrapped by every IneligibleError
+var ErrMerchantIneligible = errors.New("merchant cannot carry out this operation")
+
+// ErrInvalidMerchantStatus is returned when setting a status that does not exist
+var ErrInvalidMerchantStatus = errors.New("invalid merchant status")
+
+// Error codes returned to API clients when a merchant's status refuses an operation
+const (
+	CodeMerchantSuspended  = "MERCHANT_SUSPENDED"
+	CodeMerchantTerminated = "MERCHANT_TERMINATED"
+)
+
+// MerchantOperation is a kind of money movement gated by merchant status
+type MerchantOperation string
+
+const (
+	// OperationCollect takes money from payers: direct, QR and hosted checkout payments
+	OperationCollect MerchantOperation = "COLLECT"
+	// OperationWithdraw pays money out of the merchant's wallet
+	OperationWithdraw MerchantOperation = "WITHDRAW"
+)
+
+// IneligibleError is returned when a merchant's status refuses an operation
+type IneligibleError struct {
+	Code      string            `json:"code"`
+	Status    MerchantStatus    `json:"status"`
+	Operation MerchantOperation `json:"operation"`
+}
+
+func (e *IneligibleError) Error() string {
+	if e.Operation == OperationWithdraw {
+		return fmt.Sprintf("merchant is %s and cannot withdraw", e.Status)
+	}
+	return fmt.Sprintf("merchant is %s and cannot accept payments", e.Status)
+}
+
+func (e *IneligibleError) Unwrap() error {
+	return ErrMerchantIneligible
+}
+
+// IsValid reports whether the status is one a merchant can be in
+func (s MerchantStatus) IsValid() bool {
+	switch s {
+	case StatusPendingVerification, StatusActive, StatusInactive, StatusSuspended, StatusTerminated:
+		return true
+	}
+	return false
+}
+
+// Allows decides whether a merchant in this status may carry out an operation. Terminated
+// merchants are refused everything. Suspended merchants cannot collect, and may only withdraw
+// once an admin has reviewed the withdrawal, which reviewRequired reports.
+func (s MerchantStatus) Allows(operation MerchantOperation) (reviewRequired bool, err error) {
+	switch s {
+	case StatusTerminated:
+		return false, &IneligibleError{Code: CodeMerchantTerminated, Status: s, Operation: operation}
+	case StatusSuspended:
+		if operation == OperationWithdraw {
+			return true, nil
+		}
+		return false, &IneligibleError{Code: CodeMerchantSuspended, Status: s, Operation: operation}
+	}
+	return false, nil
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMerchantStatusAllows(t *testing.T) {
+	tests := []struct {
+		status         MerchantStatus
+		operation      MerchantOperation
+		reviewRequired bool
+		code           string
+	}{
+		{StatusActive, OperationCollect, false, ""},
+		{StatusActive, OperationWithdraw, false, ""},
+		{StatusPendingVerification, OperationCollect, false, ""},
+		{StatusSuspended, OperationCollect, false, CodeMerchantSuspended},
+		{StatusSuspended, OperationWithdraw, true, ""},
+		{StatusTerminated, OperationCollect, false, CodeMerchantTerminated},
+		{StatusTerminated, OperationWithdraw, false, CodeMerchantTerminated},
+	}
+
+	for _, tt := range tests {
+		reviewRequired, err := tt.status.Allows(tt.operation)
+		if reviewRequired != tt.reviewRequired {
+			t.Errorf("%s %s: reviewRequired = %v, want %v", tt.status, tt.operation, reviewRequired, tt.reviewRequired)
+		}
+
+		if tt.code == "" {
+			if err != nil {
+				t.Errorf("%s %s: unexpected error %v", tt.status, tt.operation, err)
+			}
+			continue
+		}
+		var ineligible *IneligibleError
+		if !errors.As(err, &ineligible) || ineligible.Code != tt.code {
+			t.Errorf("%s %s: error = %v, want code %s", tt.status, tt.operation, err, tt.code)
+		}
+		if !errors.Is(err, ErrMerchantIneligible) {
+			t.Errorf("%s %s: error does not wrap ErrMerchantIneligible", tt.status, tt.operation)
+		}
+	}
+}
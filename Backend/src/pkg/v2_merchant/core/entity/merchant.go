@@ -244,6 +244,7 @@ type UpdateMerchantDocumentRequest struct {
 // UpdateMerchantStatusRequest contains update merchant status request body
 type UpdateMerchantStatusRequest struct {
 	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
 }
 
 // DeleteMerchantsRequest contains delete merchants request body
@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrTINNotRegistered is returned by a TIN registry that has no business with the TIN
+	ErrTINNotRegistered = errors.New("TIN is not registered")
+	// ErrTINMissing is returned when verifying a merchant that has not submitted a TIN
+	ErrTINMissing = errors.New("merchant has no TIN")
+)
+
+// TINVerificationStatus is the outcome of looking a merchant's TIN up in the trade registry
+type TINVerificationStatus string
+
+const (
+	// TINVerified means the registry knows the TIN and agrees with what the merchant submitted
+	TINVerified TINVerificationStatus = "VERIFIED"
+	// TINMismatch means the registry knows the TIN but some submitted details differ from it
+	TINMismatch TINVerificationStatus = "MISMATCH"
+	// TINNotFound means the registry has no business with the TIN
+	TINNotFound TINVerificationStatus = "NOT_FOUND"
+	// TINUnavailable means the registry could not be reached
+	TINUnavailable TINVerificationStatus = "UNAVAILABLE"
+)
+
+// Fields compared against the registry
+const (
+	TINFieldLegalName          = "legal_name"
+	TINFieldRegistrationNumber = "business_registration_number"
+	TINFieldBusinessCategory   = "industry_category"
+)
+
+// TINRegistryRecord is a business as the trade registry knows it
+type TINRegistryRecord struct {
+	TIN                string          `json:"tin"`
+	LegalName          string          `json:"legal_name"`
+	RegistrationNumber string          `json:"registration_number,omitempty"`
+	BusinessCategory   string          `json:"business_category,omitempty"`
+	LegalType          string          `json:"legal_type,omitempty"`
+	Raw                json.RawMessage `json:"-"`
+}
+
+// TINDiscrepancy is a submitted detail that differs from the registry
+type TINDiscrepancy struct {
+	Field     string `json:"field"`
+	Submitted string `json:"submitted"`
+	Registry  string `json:"registry"`
+}
+
+// TINVerification is the stored evidence of one registry lookup for a merchant
+type TINVerification struct {
+	ID                 uuid.UUID             `json:"id"`
+	MerchantID         uuid.UUID             `json:"merchant_id"`
+	TIN                string                `json:"tin"`
+	Status             TINVerificationStatus `json:"status"`
+	Source             string                `json:"source"`
+	LegalName          *string               `json:"legal_name,omitempty"`
+	RegistrationNumber *string               `json:"registration_number,omitempty"`
+	BusinessCategory   *string               `json:"business_category,omitempty"`
+	LegalType          *string               `json:"legal_type,omitempty"`
+	AutoFilled         []string              `json:"auto_filled,omitempty"`
+	Discrepancies      []TINDiscrepancy      `json:"discrepancies,omitempty"`
+	RawResponse        json.RawMessage       `json:"raw_response,omitempty" swaggertype:"object"`
+	Error              *string               `json:"error,omitempty"`
+	CheckedAt          time.Time             `json:"checked_at"`
+}
+
+// NewTINVerification starts a verification of tin for a merchant against the named registry
+func NewTINVerification(merchantID uuid.UUID, tin, source string, now time.Time) *TINVerification {
+	return &TINVerification{
+		ID:         uuid.New(),
+		MerchantID: merchantID,
+		TIN:        tin,
+		Source:     source,
+		CheckedAt:  now,
+	}
+}
+
+// ApplyRecord records what the registry returned and decides the status from the discrepancies
+func (v *TINVerification) ApplyRecord(record *TINRegistryRecord, discrepancies []TINDiscrepancy) {
+	v.LegalName = optional(record.LegalName)
+	v.RegistrationNumber = optional(record.RegistrationNumber)
+	v.BusinessCategory = optional(record.BusinessCategory)
+	v.LegalType = optional(record.LegalType)
+	v.RawResponse = record.Raw
+	v.Discrepancies = discrepancies
+
+	v.Status = TINVerified
+	if len(discrepancies) > 0 {
+		v.Status = TINMismatch
+	}
+}
+
+// ApplyError records a lookup that did not return a business
+func (v *TINVerification) ApplyError(err error) {
+	v.Status = TINUnavailable
+	if errors.Is(err, ErrTINNotRegistered) {
+		v.Status = TINNotFound
+	}
+	message := err.Error()
+	v.Error = &message
+}
+
+// FillFromRegistry fills the business details the merchant left blank from the registry and
+// returns the ones that were filled, followed by the submitted details that disagree with it.
+// Submitted values are never overwritten; a reviewer decides which side is right.
+func FillFromRegistry(info *UpdateMerchantBusinessInformationRequest, record *TINRegistryRecord) (filled []string, discrepancies []TINDiscrepancy) {
+	fields := []struct {
+		name      string
+		submitted **string
+		registry  string
+	}{
+		{TINFieldLegalName, &info.LegalName, record.LegalName},
+		{TINFieldRegistrationNumber, &info.BusinessRegistrationNumber, record.RegistrationNumber},
+		{TINFieldBusinessCategory, &info.IndustryCategory, record.BusinessCategory},
+	}
+
+	for _, field := range fields {
+		if field.registry == "" {
+			continue
+		}
+		if *field.submitted == nil || strings.TrimSpace(**field.submitted) == "" {
+			value := field.registry
+			*field.submitted = &value
+			filled = append(filled, field.name)
+			continue
+		}
+		if !sameRegistryValue(**field.submitted, field.registry) {
+			discrepancies = append(discrepancies, TINDiscrepancy{
+				Field:     field.name,
+				Submitted: **field.submitted,
+				Registry:  field.registry,
+			})
+		}
+	}
+
+	return filled, discrepancies
+}
+
+// CompareWithRegistry returns the stored merchant details that disagree with the registry
+func CompareWithRegistry(merchant *Merchant, record *TINRegistryRecord) []TINDiscrepancy {
+	info := UpdateMerchantBusinessInformationRequest{
+		LegalName:                  &merchant.LegalName,
+		BusinessRegistrationNumber: &merchant.BusinessRegistrationNumber,
+		IndustryCategory:           merchant.IndustryCategory,
+	}
+	_, discrepancies := FillFromRegistry(&info, record)
+	return discrepancies
+}
+
+// sameRegistryValue compares two values ignoring case, spacing and punctuation, so
+// "Abebe Trading P.L.C." matches "ABEBE TRADING PLC"
+func sameRegistryValue(a, b string) bool {
+	return registryKey(a) == registryKey(b)
+}
+
+func registryKey(value string) string {
+	var key strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			key.WriteRune(r)
+		}
+	}
+	return key.String()
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
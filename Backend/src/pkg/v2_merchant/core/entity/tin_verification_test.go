@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFillFromRegistry(t *testing.T) {
+	record := &TINRegistryRecord{
+		TIN:                "0012345678",
+		LegalName:          "Abebe Trading PLC",
+		RegistrationNumber: "MT/AA/14/0012345",
+		BusinessCategory:   "Retail Trade of Electronics",
+	}
+	legalName := "ABEBE TRADING P.L.C."
+	registrationNumber := "MT/AA/14/0099999"
+	info := &UpdateMerchantBusinessInformationRequest{
+		LegalName:                  &legalName,
+		BusinessRegistrationNumber: &registrationNumber,
+	}
+
+	filled, discrepancies := FillFromRegistry(info, record)
+
+	if !reflect.DeepEqual(filled, []string{TINFieldBusinessCategory}) {
+		t.Fatalf("filled = %v, want [%s]", filled, TINFieldBusinessCategory)
+	}
+	if info.IndustryCategory == nil || *info.IndustryCategory != record.BusinessCategory {
+		t.Fatalf("industry category = %v, want it filled from the registry", info.IndustryCategory)
+	}
+	want := []TINDiscrepancy{{Field: TINFieldRegistrationNumber, Submitted: registrationNumber, Registry: record.RegistrationNumber}}
+	if !reflect.DeepEqual(discrepancies, want) {
+		t.Fatalf("discrepancies = %+v, want %+v", discrepancies, want)
+	}
+	if *info.BusinessRegistrationNumber != registrationNumber {
+		t.Fatalf("submitted registration number was overwritten with %q", *info.BusinessRegistrationNumber)
+	}
+}
+
+func TestTINVerificationStatus(t *testing.T) {
+	verification := NewTINVerification(uuid.New(), "0012345678", "stub", time.Now())
+	verification.ApplyRecord(&TINRegistryRecord{LegalName: "Abebe Trading PLC"}, nil)
+	if verification.Status != TINVerified {
+		t.Fatalf("status = %s, want %s", verification.Status, TINVerified)
+	}
+
+	verification.ApplyRecord(&TINRegistryRecord{}, []TINDiscrepancy{{Field: TINFieldLegalName}})
+	if verification.Status != TINMismatch {
+		t.Fatalf("status = %s, want %s", verification.Status, TINMismatch)
+	}
+
+	verification.ApplyError(fmt.Errorf("e-trade lookup: %w", ErrTINNotRegistered))
+	if verification.Status != TINNotFound {
+		t.Fatalf("status = %s, want %s", verification.Status, TINNotFound)
+	}
+
+	verification.ApplyError(errors.New("failed to check TIN"))
+	if verification.Status != TINUnavailable {
+		t.Fatalf("status = %s, want %s", verification.Status, TINUnavailable)
+	}
+}
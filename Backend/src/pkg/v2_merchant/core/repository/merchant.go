@@ -39,6 +39,18 @@ type Repository interface {
 	// GetMerchantSettings retrieves settings for a merchant
 	GetMerchantSettings(ctx context.Context, merchantID uuid.UUID) (*entity.MerchantSettings, error)
 
+	// UpdateMerchantCheckoutSettings upserts the checkout theme and default language of a merchant
+	UpdateMerchantCheckoutSettings(ctx context.Context, merchantID uuid.UUID, checkoutTheme *string, defaultLanguage string) error
+
+	// UpdateMerchantRiskSettings upserts the risk rule overrides of a merchant, a JSON document
+	UpdateMerchantRiskSettings(ctx context.Context, merchantID uuid.UUID, riskSettings string) error
+
+	// CreateTINVerification stores the result of a trade registry lookup for a merchant
+	CreateTINVerification(ctx context.Context, verification *entity.TINVerification) error
+
+	// GetTINVerifications retrieves the trade registry lookups of a merchant, newest first
+	GetTINVerifications(ctx context.Context, merchantID uuid.UUID) ([]entity.TINVerification, error)
+
 	// UpdateMerchant updates merchant info
 	UpdateMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) error
 
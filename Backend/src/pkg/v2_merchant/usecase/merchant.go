@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
@@ -26,29 +27,38 @@ type MerchantUseCase interface {
 	GetMerchantDetails(ctx context.Context, id uuid.UUID) (*entity.MerchantDetails, error)
 	GetMerchantByUserID(ctx context.Context, userID uuid.UUID) (*entity.MerchantResponse, error)
 	ExportMerchants(ctx context.Context, req *entity.ExportMerchantsRequest) (*string, error)
-	UpdateMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) error
+	UpdateMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) (*entity.TINVerification, error)
 	UpdateMerchantStatus(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantStatusRequest) error
 	UpdateMerchantContact(ctx context.Context, id uuid.UUID, req *entity.UpdateMerchantContactRequest) error
 	UpdateMerchantDocument(ctx context.Context, id uuid.UUID, req *entity.UpdateMerchantDocumentRequest) error
-	UpdateAdminMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) error
+	UpdateAdminMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) (*entity.TINVerification, error)
 	DeleteMerchant(ctx context.Context, merchantID uuid.UUID) error
 	DeleteMerchants(ctx context.Context, req *entity.DeleteMerchantsRequest) error
 	GetMerchantStats(ctx context.Context) (*entity.MerchantStats, error)
 	ImpersonateMerchant(ctx context.Context, merchantID uuid.UUID) (*auth_entity.AuthResponse, error)
+	LookupTIN(ctx context.Context, tin string) (*entity.TINRegistryRecord, error)
+	VerifyMerchantTIN(ctx context.Context, merchantID uuid.UUID) (*entity.TINVerification, error)
+	GetTINVerifications(ctx context.Context, merchantID uuid.UUID) ([]entity.TINVerification, error)
+	CheckEligibility(ctx context.Context, merchantID uuid.UUID, operation entity.MerchantOperation) (reviewRequired bool, err error)
+	PublishStatusChanged(ctx context.Context, merchant *entity.Merchant, to entity.MerchantStatus, reason string)
+	SetEventPublisher(publisher MerchantEventPublisher)
 }
 
 type merchantUseCase struct {
 	authService auth_service.AuthService
 	log         logging.Logger
 	repo        repository.Repository
+	tinRegistry TINRegistry
+	events      MerchantEventPublisher
 }
 
 // NewMerchantUseCase creates a new merchant management use case
-func NewMerchantUseCase(authService auth_service.AuthService, repo repository.Repository) MerchantUseCase {
+func NewMerchantUseCase(authService auth_service.AuthService, repo repository.Repository, tinRegistry TINRegistry) MerchantUseCase {
 	return &merchantUseCase{
 		authService: authService,
 		log:         logging.NewStdLogger("[V2_MERCHANT]"),
 		repo:        repo,
+		tinRegistry: tinRegistry,
 	}
 }
 
@@ -237,25 +247,55 @@ func (u *merchantUseCase) ExportMerchants(ctx context.Context, req *entity.Expor
 	return &filePath, nil
 }
 
-// UpdateMerchant updates merchant
-func (u *merchantUseCase) UpdateMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) error {
-	err := u.repo.UpdateMerchant(ctx, merchantID, req)
+// UpdateMerchant updates merchant, checking a submitted TIN against the trade registry first
+func (u *merchantUseCase) UpdateMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) (*entity.TINVerification, error) {
+	merchant, err := u.repo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Merchants cannot change their own status; it moves through KYC and admin decisions
+	req.BusinessInfo.Status = &merchant.Status
+
+	verification := u.checkSubmittedTIN(ctx, merchantID, &req.BusinessInfo)
+
+	err = u.repo.UpdateMerchant(ctx, merchantID, req)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	u.saveTINVerification(ctx, verification)
+
+	return verification, nil
 }
 
-// UpdateMerchantStatus updates merchant status
+// UpdateMerchantStatus updates merchant status and publishes the change
 func (u *merchantUseCase) UpdateMerchantStatus(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantStatusRequest) error {
-	err := u.repo.UpdateMerchantStatus(ctx, merchantID, req)
+	status := entity.MerchantStatus(req.Status)
+	if !status.IsValid() {
+		return fmt.Errorf("%w: %s", entity.ErrInvalidMerchantStatus, req.Status)
+	}
+
+	merchant, err := u.repo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return err
+	}
+
+	err = u.repo.UpdateMerchantStatus(ctx, merchantID, req)
 
 	if err != nil {
 		return err
 	}
 
+	if merchant.Status != status {
+		reason := req.Reason
+		if reason == "" {
+			reason = "status changed by admin"
+		}
+		u.PublishStatusChanged(ctx, merchant, status, reason)
+	}
+
 	return nil
 }
 
@@ -281,15 +321,37 @@ func (u *merchantUseCase) UpdateMerchantDocument(ctx context.Context, id uuid.UU
 	return nil
 }
 
-// UpdateAdminMerchant updates merchant by admin
-func (u *merchantUseCase) UpdateAdminMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) error {
-	err := u.repo.UpdateAdminMerchant(ctx, merchantID, req)
+// UpdateAdminMerchant updates merchant by admin, checking a submitted TIN against the trade registry first
+func (u *merchantUseCase) UpdateAdminMerchant(ctx context.Context, merchantID uuid.UUID, req *entity.UpdateMerchantRequest) (*entity.TINVerification, error) {
+	merchant, err := u.repo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A missing status keeps the current one
+	if req.BusinessInfo.Status == nil {
+		req.BusinessInfo.Status = &merchant.Status
+	}
+	status := *req.BusinessInfo.Status
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: %s", entity.ErrInvalidMerchantStatus, status)
+	}
+
+	verification := u.checkSubmittedTIN(ctx, merchantID, &req.BusinessInfo)
+
+	err = u.repo.UpdateAdminMerchant(ctx, merchantID, req)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	u.saveTINVerification(ctx, verification)
+
+	if merchant.Status != status {
+		u.PublishStatusChanged(ctx, merchant, status, "status changed by admin")
+	}
+
+	return verification, nil
 }
 
 // DeleteMerchant deletes merchant
@@ -417,3 +479,116 @@ func (u *merchantUseCase) ImpersonateMerchant(ctx context.Context, merchantID uu
 		ExpiresAt:    expiresAt,
 	}, nil
 }
+
+// LookupTIN looks a business up in the trade registry, for filling in onboarding forms
+func (u *merchantUseCase) LookupTIN(ctx context.Context, tin string) (*entity.TINRegistryRecord, error) {
+	record, err := u.tinRegistry.LookupTIN(ctx, strings.TrimSpace(tin))
+	if err != nil {
+		if !errors.Is(err, entity.ErrTINNotRegistered) {
+			u.log.Warn("TIN registry lookup failed", map[string]interface{}{
+				"error":    err.Error(),
+				"registry": u.tinRegistry.Name(),
+			})
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// VerifyMerchantTIN checks the stored TIN and business details of a merchant against the
+// trade registry and stores the result
+func (u *merchantUseCase) VerifyMerchantTIN(ctx context.Context, merchantID uuid.UUID) (*entity.TINVerification, error) {
+	merchant, err := u.repo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if merchant == nil {
+		return nil, errors.New("merchant not found")
+	}
+
+	tin := strings.TrimSpace(merchant.TaxIdentificationNumber)
+	if tin == "" {
+		return nil, entity.ErrTINMissing
+	}
+
+	verification := entity.NewTINVerification(merchantID, tin, u.tinRegistry.Name(), time.Now())
+	record, err := u.tinRegistry.LookupTIN(ctx, tin)
+	if err != nil {
+		verification.ApplyError(err)
+	} else {
+		verification.ApplyRecord(record, entity.CompareWithRegistry(merchant, record))
+	}
+
+	if err := u.repo.CreateTINVerification(ctx, verification); err != nil {
+		u.log.Error("Failed to store TIN verification", map[string]interface{}{
+			"error":      err.Error(),
+			"merchantID": merchantID,
+		})
+		return nil, fmt.Errorf("failed to store TIN verification: %w", err)
+	}
+
+	return verification, nil
+}
+
+// GetTINVerifications gets the trade registry lookups of a merchant, newest first
+func (u *merchantUseCase) GetTINVerifications(ctx context.Context, merchantID uuid.UUID) ([]entity.TINVerification, error) {
+	verifications, err := u.repo.GetTINVerifications(ctx, merchantID)
+	if err != nil {
+		u.log.Error("Failed to get TIN verifications", map[string]interface{}{
+			"error":      err.Error(),
+			"merchantID": merchantID,
+		})
+		return nil, fmt.Errorf("failed to get TIN verifications: %w", err)
+	}
+
+	return verifications, nil
+}
+
+// checkSubmittedTIN looks a submitted TIN up in the trade registry and fills the business
+// details left blank from it. It returns nil when no TIN was submitted. A lookup that fails
+// does not hold up the update; the failure is recorded for the KYC reviewer instead.
+func (u *merchantUseCase) checkSubmittedTIN(ctx context.Context, merchantID uuid.UUID, info *entity.UpdateMerchantBusinessInformationRequest) *entity.TINVerification {
+	if info.TaxIdentificationNumber == nil {
+		return nil
+	}
+	tin := strings.TrimSpace(*info.TaxIdentificationNumber)
+	if tin == "" {
+		return nil
+	}
+
+	verification := entity.NewTINVerification(merchantID, tin, u.tinRegistry.Name(), time.Now())
+	record, err := u.tinRegistry.LookupTIN(ctx, tin)
+	if err != nil {
+		if !errors.Is(err, entity.ErrTINNotRegistered) {
+			u.log.Warn("TIN registry lookup failed", map[string]interface{}{
+				"error":      err.Error(),
+				"merchantID": merchantID,
+				"registry":   u.tinRegistry.Name(),
+			})
+		}
+		verification.ApplyError(err)
+		return verification
+	}
+
+	filled, discrepancies := entity.FillFromRegistry(info, record)
+	verification.ApplyRecord(record, discrepancies)
+	verification.AutoFilled = filled
+
+	return verification
+}
+
+// saveTINVerification stores the evidence of a registry lookup made during an update. The
+// update has already been saved, so a failure here is only logged.
+func (u *merchantUseCase) saveTINVerification(ctx context.Context, verification *entity.TINVerification) {
+	if verification == nil {
+		return
+	}
+
+	if err := u.repo.CreateTINVerification(ctx, verification); err != nil {
+		u.log.Error("Failed to store TIN verification", map[string]interface{}{
+			"error":      err.Error(),
+			"merchantID": verification.MerchantID,
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+	webhookDto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
+)
+
+// MerchantEventPublisher queues events for delivery to the merchant callback URL
+type MerchantEventPublisher interface {
+	PublishMerchantEvent(ctx context.Context, event webhookDto.WebhookEventMerchant) error
+}
+
+// SetEventPublisher sets where merchant status events are published. The webhook usecase is
+// created after the merchant usecase, so it cannot be passed to the constructor.
+func (u *merchantUseCase) SetEventPublisher(publisher MerchantEventPublisher) {
+	u.events = publisher
+}
+
+// CheckEligibility reports whether a merchant's status lets it carry out an operation. It
+// returns an *entity.IneligibleError when the status refuses it, and reviewRequired when the
+// operation may only go ahead after an admin reviews it.
+func (u *merchantUseCase) CheckEligibility(ctx context.Context, merchantID uuid.UUID, operation entity.MerchantOperation) (bool, error) {
+	merchant, err := u.repo.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check merchant status: %w", err)
+	}
+
+	return merchant.Status.Allows(operation)
+}
+
+// PublishStatusChanged emits a merchant.status_changed event for a merchant, still holding its
+// previous status, that moved to a new one. Publishing is best effort and only logged on failure.
+func (u *merchantUseCase) PublishStatusChanged(ctx context.Context, merchant *entity.Merchant, to entity.MerchantStatus, reason string) {
+	u.log.Info("Merchant status changed", map[string]interface{}{
+		"merchantID": merchant.ID,
+		"from":       merchant.Status,
+		"to":         to,
+		"reason":     reason,
+	})
+
+	if u.events == nil {
+		return
+	}
+
+	event := webhookDto.WebhookEventMerchant{
+		Event:          webhookDto.EventMerchantStatusChanged,
+		Status:         string(to),
+		PreviousStatus: string(merchant.Status),
+		Message:        reason,
+		Timestamp:      time.Now(),
+		MerchantID:     merchant.ID.String(),
+		UserID:         merchant.UserID.String(),
+	}
+
+	settings, err := u.repo.GetMerchantSettings(ctx, merchant.ID)
+	if err != nil {
+		u.log.Error("Failed to get merchant webhook settings", map[string]interface{}{
+			"error":      err.Error(),
+			"merchantID": merchant.ID,
+		})
+	} else if settings != nil && settings.EnableWebhooks && settings.WebhookURL != nil {
+		event.CallbackURL = *settings.WebhookURL
+	}
+
+	if err := u.events.PublishMerchantEvent(ctx, event); err != nil {
+		u.log.Error("Failed to publish merchant status event", map[string]interface{}{
+			"error":      err.Error(),
+			"merchantID": merchant.ID,
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/socialpay/socialpay/src/pkg/v2_merchant/core/entity"
+)
+
+// TINRegistry looks businesses up by TIN in a trade registry
+type TINRegistry interface {
+	// Name identifies the registry on stored verifications
+	Name() string
+
+	// LookupTIN returns the business registered under tin. It returns entity.ErrTINNotRegistered
+	// when the registry has no such business, and any other error when it could not be asked.
+	LookupTIN(ctx context.Context, tin string) (*entity.TINRegistryRecord, error)
+}
@@ -3,11 +3,13 @@ package controller
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
 	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	"github.com/socialpay/socialpay/src/pkg/wallet/core/entity"
 	walletUseCase "github.com/socialpay/socialpay/src/pkg/wallet/usecase"
 )
 
@@ -41,6 +43,13 @@ type WalletResponse struct {
 	Description string `json:"description,omitempty" example:"Main merchant wallet"`
 }
 
+// OpenWalletRequest represents a request to open a wallet in another currency
+// @Description Open currency wallet request
+type OpenWalletRequest struct {
+	// @Description Currency code of the new wallet
+	Currency string `json:"currency" binding:"required" example:"USD"`
+}
+
 // WalletController handles wallet-related HTTP requests
 type WalletController struct {
 	logger        logging.Logger
@@ -65,12 +74,14 @@ func (c *WalletController) RegisterRoutes(router *gin.RouterGroup) {
 	wallet := router.Group("/wallet", ginMiddleware.ErrorMiddleWare(), *c.middleware, ginMiddleware.MerchantIDMiddleware())
 	{
 		wallet.GET("", c.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WALLET, auth_entity.OPERATION_READ), c.GetMerchantWallet)
+		wallet.GET("/currencies", c.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WALLET, auth_entity.OPERATION_READ), c.ListMerchantWallets)
+		wallet.POST("/currencies", c.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WALLET, auth_entity.OPERATION_CREATE), c.OpenCurrencyWallet)
 	}
 }
 
 // GetMerchantWallet godoc
 // @Summary Get merchant wallet
-// @Description Get the wallet information for the authenticated merchant
+// @Description Get the primary wallet information for the authenticated merchant
 // @Tags wallet
 // @Accept json
 // @Produce json
@@ -102,3 +113,74 @@ func (c *WalletController) GetMerchantWallet(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, wallet)
 }
+
+// ListMerchantWallets godoc
+// @Summary List merchant wallets
+// @Description List the wallets of the authenticated merchant, one per currency, the primary wallet first
+// @Tags wallet
+// @Produce json
+// @Security BearerAuth
+// @Security MerchantID
+// @Success 200 {array} entity.MerchantWallet "Wallets"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Router /wallet/currencies [get]
+func (c *WalletController) ListMerchantWallets(ctx *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{Error: "merchant ID not found in context"})
+		return
+	}
+
+	wallets, err := c.walletUseCase.ListMerchantWallets(ctx, merchantID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if wallets == nil {
+		wallets = []entity.MerchantWallet{}
+	}
+
+	ctx.JSON(http.StatusOK, wallets)
+}
+
+// OpenCurrencyWallet godoc
+// @Summary Open a currency wallet
+// @Description Open a wallet in another currency. Payments in that currency settle into it without conversion.
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security MerchantID
+// @Param request body OpenWalletRequest true "Currency"
+// @Success 201 {object} entity.MerchantWallet "Wallet"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Router /wallet/currencies [post]
+func (c *WalletController) OpenCurrencyWallet(ctx *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, ErrorResponse{Error: "merchant ID not found in context"})
+		return
+	}
+
+	var req OpenWalletRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	currency := entity.Currency(strings.ToUpper(strings.TrimSpace(req.Currency)))
+	if !currency.IsValid() {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("unsupported currency: %s", req.Currency)})
+		return
+	}
+
+	wallet, err := c.walletUseCase.OpenCurrencyWallet(ctx, merchantID, currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, wallet)
+}
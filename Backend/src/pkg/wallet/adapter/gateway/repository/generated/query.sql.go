@@ -90,6 +90,8 @@ func (q *Queries) GetAdminWalletForUpdate(ctx context.Context) (MerchantWallet,
 const getMerchantWalletByMerchantID = `-- name: GetMerchantWalletByMerchantID :one
 SELECT id, user_id, merchant_id, amount, locked_amount, currency, wallet_type, created_at, updated_at FROM merchant.wallet
 WHERE merchant_id = $1 AND wallet_type = 'merchant'
+ORDER BY created_at
+LIMIT 1
 `
 
 func (q *Queries) GetMerchantWalletByMerchantID(ctx context.Context, merchantID uuid.UUID) (MerchantWallet, error) {
@@ -112,6 +114,8 @@ func (q *Queries) GetMerchantWalletByMerchantID(ctx context.Context, merchantID
 const getMerchantWalletByMerchantIDForUpdate = `-- name: GetMerchantWalletByMerchantIDForUpdate :one
 SELECT id, user_id, merchant_id, amount, locked_amount, currency, wallet_type, created_at, updated_at FROM merchant.wallet
 WHERE merchant_id = $1 AND wallet_type = 'merchant'
+ORDER BY created_at
+LIMIT 1
 FOR UPDATE
 `
 
@@ -135,6 +139,8 @@ func (q *Queries) GetMerchantWalletByMerchantIDForUpdate(ctx context.Context, me
 const getMerchantWalletByUserID = `-- name: GetMerchantWalletByUserID :one
 SELECT id, user_id, merchant_id, amount, locked_amount, currency, wallet_type, created_at, updated_at FROM merchant.wallet
 WHERE user_id = $1 AND wallet_type = 'merchant'
+ORDER BY created_at
+LIMIT 1
 `
 
 func (q *Queries) GetMerchantWalletByUserID(ctx context.Context, userID uuid.UUID) (MerchantWallet, error) {
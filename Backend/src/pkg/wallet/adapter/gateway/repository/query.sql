@@ -7,7 +7,9 @@ INSERT INTO merchant.wallet (
 
 -- name: GetMerchantWalletByUserID :one
 SELECT * FROM merchant.wallet
-WHERE user_id = $1 AND wallet_type = 'merchant';
+WHERE user_id = $1 AND wallet_type = 'merchant'
+ORDER BY created_at
+LIMIT 1;
 
 -- name: UpdateMerchantWallet :exec
 UPDATE merchant.wallet
@@ -18,11 +20,15 @@ WHERE id = $1;
 
 -- name: GetMerchantWalletByMerchantID :one
 SELECT * FROM merchant.wallet
-WHERE merchant_id = $1 AND wallet_type = 'merchant';
+WHERE merchant_id = $1 AND wallet_type = 'merchant'
+ORDER BY created_at
+LIMIT 1;
 
 -- name: GetMerchantWalletByMerchantIDForUpdate :one
 SELECT * FROM merchant.wallet
 WHERE merchant_id = $1 AND wallet_type = 'merchant'
+ORDER BY created_at
+LIMIT 1
 FOR UPDATE;
 
 -- name: UpdateMerchantWalletAmountByMerchantID :exec
@@ -15,4 +15,12 @@ CREATE TABLE IF NOT EXISTS merchant.wallet (
 
 -- Add indexes for better query performance
 CREATE INDEX IF NOT EXISTS idx_wallet_merchant_id ON merchant.wallet(merchant_id);
-CREATE INDEX IF NOT EXISTS idx_wallet_user_id ON merchant.wallet(user_id); 
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_wallet_user_id ON merchant.wallet(user_id);
+
+-- A merchant holds at most one wallet per currency; the oldest is the primary wallet
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_merchant_currency ON merchant.wallet(merchant_id, currency) WHERE wallet_type = 'merchant';
+
+-- Funds earmarked within amount by the rolling reserve of recent deposits and by compliance holds.
+-- Locked funds already left amount, so the available balance is amount - reserved_amount - held_amount.
+ALTER TABLE merchant.wallet ADD COLUMN IF NOT EXISTS reserved_amount FLOAT NOT NULL DEFAULT 0;
+ALTER TABLE merchant.wallet ADD COLUMN IF NOT EXISTS held_amount FLOAT NOT NULL DEFAULT 0;
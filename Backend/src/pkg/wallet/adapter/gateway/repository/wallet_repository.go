@@ -18,6 +18,8 @@ type WalletRepository interface {
 	CreateMerchantWallet(ctx context.Context, userID uuid.UUID, merchantID uuid.UUID, amount float64, lockedAmount float64, currency string) error
 	GetMerchantWalletByUserID(ctx context.Context, userID uuid.UUID) (*entity.MerchantWallet, error)
 	GetMerchantWalletByMerchantID(ctx context.Context, merchantID uuid.UUID) (*entity.MerchantWallet, error)
+	GetMerchantWalletByCurrency(ctx context.Context, merchantID uuid.UUID, currency string) (*entity.MerchantWallet, error)
+	ListMerchantWallets(ctx context.Context, merchantID uuid.UUID) ([]entity.MerchantWallet, error)
 	GetMerchantWalletByMerchantIDForUpdate(ctx context.Context, tx *sql.Tx, merchantID uuid.UUID) (*entity.MerchantWallet, error)
 	UpdateMerchantWallet(ctx context.Context, walletID uuid.UUID, amount float64, lockedAmount float64) error
 	UpdateMerchantWalletAmountByMerchantID(ctx context.Context, merchantID uuid.UUID, amount float64) error
@@ -30,10 +32,11 @@ type WalletRepository interface {
 	GetTotalAdminWalletAmount(ctx context.Context) (map[string]float64, error)
 
 	// Atomic transaction processing methods (high-performance)
-	ProcessDepositSuccess(ctx context.Context, merchantID uuid.UUID, merchantAmount float64, adminAmount float64) error
-	ProcessWithdrawalSuccess(ctx context.Context, merchantID uuid.UUID, merchantAmount float64, adminAmount float64) error
-	ProcessWithdrawalFailure(ctx context.Context, merchantID uuid.UUID, merchantAmount float64) error
-	LockWithdrawalAmountAtomic(ctx context.Context, merchantID uuid.UUID, amount float64) error
+	// The merchant amount moves in the merchant's wallet of the given currency
+	ProcessDepositSuccess(ctx context.Context, merchantID uuid.UUID, currency string, merchantAmount float64, adminAmount float64) error
+	ProcessWithdrawalSuccess(ctx context.Context, merchantID uuid.UUID, currency string, merchantAmount float64, adminAmount float64) error
+	ProcessWithdrawalFailure(ctx context.Context, merchantID uuid.UUID, currency string, merchantAmount float64) error
+	LockWithdrawalAmountAtomic(ctx context.Context, merchantID uuid.UUID, currency string, amount float64) error
 
 	// Health check operations
 	CheckWalletBalanceHealth(ctx context.Context) (*entity.WalletHealthCheck, error)
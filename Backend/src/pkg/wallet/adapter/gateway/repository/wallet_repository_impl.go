@@ -77,25 +77,21 @@ func (r *merchantWalletRepository) GetMerchantWalletByUserID(ctx context.Context
 }
 
 func (r *merchantWalletRepository) GetMerchantWalletByMerchantID(ctx context.Context, merchantID uuid.UUID) (*entity.MerchantWallet, error) {
-	wallet, err := r.queries.GetMerchantWalletByMerchantID(ctx, merchantID)
+	query := `
+		SELECT ` + merchantWalletColumns + `
+		FROM merchant.wallet
+		WHERE merchant_id = $1 AND wallet_type = 'merchant'
+		ORDER BY created_at
+		LIMIT 1
+	`
+	wallet, err := scanMerchantWallet(r.db.QueryRowContext(ctx, query, merchantID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("merchant wallet not found")
 		}
 		return nil, err
 	}
-
-	return &entity.MerchantWallet{
-		ID:           wallet.ID,
-		UserID:       wallet.UserID,
-		MerchantID:   wallet.MerchantID,
-		Amount:       wallet.Amount,
-		LockedAmount: wallet.LockedAmount,
-		Currency:     entity.Currency(wallet.Currency),
-		WalletType:   entity.WalletType(wallet.WalletType),
-		CreatedAt:    wallet.CreatedAt,
-		UpdatedAt:    wallet.UpdatedAt,
-	}, nil
+	return wallet, nil
 }
 
 func (r *merchantWalletRepository) GetMerchantWalletByMerchantIDForUpdate(ctx context.Context, tx *sql.Tx, merchantID uuid.UUID) (*entity.MerchantWallet, error) {
@@ -121,6 +117,79 @@ func (r *merchantWalletRepository) GetMerchantWalletByMerchantIDForUpdate(ctx co
 	}, nil
 }
 
+const merchantWalletColumns = `id, user_id, merchant_id, amount, locked_amount, reserved_amount, held_amount, currency, wallet_type, created_at, updated_at`
+
+// GetMerchantWalletByCurrency gets the merchant's wallet held in a currency
+func (r *merchantWalletRepository) GetMerchantWalletByCurrency(ctx context.Context, merchantID uuid.UUID, currency string) (*entity.MerchantWallet, error) {
+	query := `
+		SELECT ` + merchantWalletColumns + `
+		FROM merchant.wallet
+		WHERE merchant_id = $1 AND wallet_type = 'merchant' AND currency = $2
+	`
+	wallet, err := scanMerchantWallet(r.db.QueryRowContext(ctx, query, merchantID, currency))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.ErrWalletNotFound
+		}
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// ListMerchantWallets lists the merchant's wallets, the primary wallet first
+func (r *merchantWalletRepository) ListMerchantWallets(ctx context.Context, merchantID uuid.UUID) ([]entity.MerchantWallet, error) {
+	query := `
+		SELECT ` + merchantWalletColumns + `
+		FROM merchant.wallet
+		WHERE merchant_id = $1 AND wallet_type = 'merchant'
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets []entity.MerchantWallet
+	for rows.Next() {
+		wallet, err := scanMerchantWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, *wallet)
+	}
+	return wallets, rows.Err()
+}
+
+type walletScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMerchantWallet(row walletScanner) (*entity.MerchantWallet, error) {
+	var wallet entity.MerchantWallet
+	var currency, walletType string
+	err := row.Scan(
+		&wallet.ID,
+		&wallet.UserID,
+		&wallet.MerchantID,
+		&wallet.Amount,
+		&wallet.LockedAmount,
+		&wallet.ReservedAmount,
+		&wallet.HeldAmount,
+		&currency,
+		&walletType,
+		&wallet.CreatedAt,
+		&wallet.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	wallet.Currency = entity.Currency(currency)
+	wallet.WalletType = entity.WalletType(walletType)
+	wallet.AvailableAmount = wallet.Available()
+	return &wallet, nil
+}
+
 func (r *merchantWalletRepository) UpdateMerchantWallet(ctx context.Context, walletID uuid.UUID, amount float64, lockedAmount float64) error {
 	err := r.queries.UpdateMerchantWallet(ctx, db.UpdateMerchantWalletParams{
 		ID:           walletID,
@@ -429,7 +498,7 @@ func (r *merchantWalletRepository) checkMerchantWalletHealth(ctx context.Context
 			COALESCE(SUM(CASE WHEN type = 'withdrawal' AND status = 'SUCCESS' THEN ABS(merchant_net) ELSE 0 END), 0) as total_withdrawals,
 			COUNT(*) as transaction_count
 		FROM public.transactions 
-		WHERE merchant_id = $1 AND status = 'SUCCESS' AND merchant_net IS NOT NULL
+		WHERE merchant_id = $1 AND status = 'SUCCESS' AND merchant_net IS NOT NULL AND test IS NOT TRUE
 	`
 
 	var totalDeposits, totalWithdrawals float64
@@ -465,7 +534,7 @@ func (r *merchantWalletRepository) checkAdminWalletHealth(ctx context.Context, w
 			COALESCE(SUM(admin_net), 0) as total_commissions,
 			COUNT(*) as transaction_count
 		FROM public.transactions 
-		WHERE status = 'SUCCESS' AND admin_net IS NOT NULL AND admin_net > 0
+		WHERE status = 'SUCCESS' AND admin_net IS NOT NULL AND admin_net > 0 AND test IS NOT TRUE
 	`
 
 	var totalCommissions float64
@@ -496,7 +565,7 @@ func (r *merchantWalletRepository) checkAdminWalletHealth(ctx context.Context, w
 // Atomic transaction processing methods (high-performance)
 // These methods use single SQL statements to update both merchant and admin wallets atomically
 
-func (r *merchantWalletRepository) ProcessDepositSuccess(ctx context.Context, merchantID uuid.UUID, merchantAmount float64, adminAmount float64) error {
+func (r *merchantWalletRepository) ProcessDepositSuccess(ctx context.Context, merchantID uuid.UUID, currency string, merchantAmount float64, adminAmount float64) error {
 	// Single transaction with CTE for atomic updates of both wallets
 	query := `
 	WITH merchant_update AS (
@@ -504,6 +573,8 @@ func (r *merchantWalletRepository) ProcessDepositSuccess(ctx context.Context, me
 		SET amount = amount + $2,
 			updated_at = NOW()
 		WHERE merchant_id = $1
+			AND wallet_type = 'merchant'
+			AND currency = $4
 		RETURNING id
 	),
 	admin_update AS (
@@ -519,13 +590,13 @@ func (r *merchantWalletRepository) ProcessDepositSuccess(ctx context.Context, me
 	`
 
 	var merchantUpdated, adminUpdated int
-	err := r.db.QueryRowContext(ctx, query, merchantID, merchantAmount, adminAmount).Scan(&merchantUpdated, &adminUpdated)
+	err := r.db.QueryRowContext(ctx, query, merchantID, merchantAmount, adminAmount, currency).Scan(&merchantUpdated, &adminUpdated)
 	if err != nil {
 		return fmt.Errorf("failed to process deposit success: %w", err)
 	}
 
 	if merchantUpdated == 0 {
-		return fmt.Errorf("merchant wallet not found for merchantID: %s", merchantID)
+		return fmt.Errorf("%s merchant wallet not found for merchantID: %s", currency, merchantID)
 	}
 	if adminUpdated == 0 {
 		return fmt.Errorf("admin wallet not found")
@@ -534,7 +605,7 @@ func (r *merchantWalletRepository) ProcessDepositSuccess(ctx context.Context, me
 	return nil
 }
 
-func (r *merchantWalletRepository) ProcessWithdrawalSuccess(ctx context.Context, merchantID uuid.UUID, merchantAmount float64, adminAmount float64) error {
+func (r *merchantWalletRepository) ProcessWithdrawalSuccess(ctx context.Context, merchantID uuid.UUID, currency string, merchantAmount float64, adminAmount float64) error {
 	// Single transaction: unlock amount from merchant wallet and add commission to admin
 	query := `
 	WITH merchant_update AS (
@@ -542,6 +613,8 @@ func (r *merchantWalletRepository) ProcessWithdrawalSuccess(ctx context.Context,
 		SET locked_amount = locked_amount - $2,
 			updated_at = NOW()
 		WHERE merchant_id = $1
+			AND wallet_type = 'merchant'
+			AND currency = $4
 		RETURNING id
 	),
 	admin_update AS (
@@ -557,13 +630,13 @@ func (r *merchantWalletRepository) ProcessWithdrawalSuccess(ctx context.Context,
 	`
 
 	var merchantUpdated, adminUpdated int
-	err := r.db.QueryRowContext(ctx, query, merchantID, merchantAmount, adminAmount).Scan(&merchantUpdated, &adminUpdated)
+	err := r.db.QueryRowContext(ctx, query, merchantID, merchantAmount, adminAmount, currency).Scan(&merchantUpdated, &adminUpdated)
 	if err != nil {
 		return fmt.Errorf("failed to process withdrawal success: %w", err)
 	}
 
 	if merchantUpdated == 0 {
-		return fmt.Errorf("merchant wallet not found for merchantID: %s", merchantID)
+		return fmt.Errorf("%s merchant wallet not found for merchantID: %s", currency, merchantID)
 	}
 	if adminUpdated == 0 {
 		return fmt.Errorf("admin wallet not found")
@@ -572,7 +645,7 @@ func (r *merchantWalletRepository) ProcessWithdrawalSuccess(ctx context.Context,
 	return nil
 }
 
-func (r *merchantWalletRepository) ProcessWithdrawalFailure(ctx context.Context, merchantID uuid.UUID, merchantAmount float64) error {
+func (r *merchantWalletRepository) ProcessWithdrawalFailure(ctx context.Context, merchantID uuid.UUID, currency string, merchantAmount float64) error {
 	// Single statement: return locked amount to available balance and unlock it
 	query := `
 	UPDATE merchant.wallet 
@@ -580,9 +653,11 @@ func (r *merchantWalletRepository) ProcessWithdrawalFailure(ctx context.Context,
 		locked_amount = locked_amount - $2,
 		updated_at = NOW()
 	WHERE merchant_id = $1
+		AND wallet_type = 'merchant'
+		AND currency = $3
 	`
 
-	result, err := r.db.ExecContext(ctx, query, merchantID, merchantAmount)
+	result, err := r.db.ExecContext(ctx, query, merchantID, merchantAmount, currency)
 	if err != nil {
 		return fmt.Errorf("failed to process withdrawal failure: %w", err)
 	}
@@ -593,14 +668,15 @@ func (r *merchantWalletRepository) ProcessWithdrawalFailure(ctx context.Context,
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("merchant wallet not found for merchantID: %s", merchantID)
+		return fmt.Errorf("%s merchant wallet not found for merchantID: %s", currency, merchantID)
 	}
 
 	return nil
 }
 
-func (r *merchantWalletRepository) LockWithdrawalAmountAtomic(ctx context.Context, merchantID uuid.UUID, amount float64) error {
-	// Single atomic SQL operation to check balance and lock amount
+func (r *merchantWalletRepository) LockWithdrawalAmountAtomic(ctx context.Context, merchantID uuid.UUID, currency string, amount float64) error {
+	// Single atomic SQL operation to check balance and lock amount. Reserved and held funds
+	// stay in the wallet and cannot be withdrawn.
 	query := `
 	WITH wallet_update AS (
 		UPDATE merchant.wallet 
@@ -609,25 +685,26 @@ func (r *merchantWalletRepository) LockWithdrawalAmountAtomic(ctx context.Contex
 			updated_at = NOW()
 		WHERE merchant_id = $1
 			AND wallet_type = 'merchant'
-			AND amount >= $2
+			AND currency = $3
+			AND amount - reserved_amount - held_amount >= $2
 		RETURNING id
 	)
 	SELECT COUNT(*) FROM wallet_update
 	`
 
 	var rowsAffected int64
-	err := r.db.QueryRowContext(ctx, query, merchantID, amount).Scan(&rowsAffected)
+	err := r.db.QueryRowContext(ctx, query, merchantID, amount, currency).Scan(&rowsAffected)
 	if err != nil {
 		return fmt.Errorf("failed to lock withdrawal amount: %w", err)
 	}
 
 	if rowsAffected == 0 {
 		// Get current balance to provide a helpful error message
-		wallet, err := r.GetMerchantWalletByMerchantID(ctx, merchantID)
+		wallet, err := r.GetMerchantWalletByCurrency(ctx, merchantID, currency)
 		if err != nil {
-			return fmt.Errorf("insufficient funds or wallet not found")
+			return fmt.Errorf("insufficient funds or no %s wallet", currency)
 		}
-		return fmt.Errorf("insufficient funds: available balance is %.2f %s", wallet.Amount, wallet.Currency)
+		return fmt.Errorf("insufficient funds: available balance is %.2f %s", wallet.AvailableAmount, wallet.Currency)
 	}
 
 	return nil
@@ -1,11 +1,14 @@
 package entity
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var ErrWalletNotFound = errors.New("merchant wallet not found")
+
 type WalletType string
 
 const (
@@ -20,20 +23,44 @@ const (
 	CurrencyUSD Currency = "USD"
 )
 
+// Currencies are the currencies a merchant can hold a wallet in
+var Currencies = []Currency{CurrencyETB, CurrencyUSD}
+
+// IsValid reports whether wallets can be held in the currency
+func (c Currency) IsValid() bool {
+	for _, currency := range Currencies {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// MerchantWallet is a merchant's balance in one currency. Funds locked for a withdrawal leave
+// Amount until it completes; ReservedAmount and HeldAmount stay within Amount, earmarked by the
+// rolling reserve and compliance holds. AvailableAmount is what can be withdrawn.
 type MerchantWallet struct {
-	ID           uuid.UUID  `json:"id"`
-	UserID       uuid.UUID  `json:"user_id"`
-	MerchantID   uuid.UUID  `json:"merchant_id"`
-	Balance      float64    `json:"balance"`
-	Amount       float64    `json:"amount"`
-	LockedAmount float64    `json:"locked_amount"`
-	Description  string     `json:"description,omitempty"`
-	Currency     Currency   `json:"currency"`
-	WalletType   WalletType `json:"wallet_type"`
-	IsActive     bool       `json:"is_active"`
-	LastSyncAt   time.Time  `json:"last_sync_at"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	MerchantID      uuid.UUID  `json:"merchant_id"`
+	Balance         float64    `json:"balance"`
+	Amount          float64    `json:"amount"`
+	LockedAmount    float64    `json:"locked_amount"`
+	ReservedAmount  float64    `json:"reserved_amount"`
+	HeldAmount      float64    `json:"held_amount"`
+	AvailableAmount float64    `json:"available_amount"`
+	Description     string     `json:"description,omitempty"`
+	Currency        Currency   `json:"currency"`
+	WalletType      WalletType `json:"wallet_type"`
+	IsActive        bool       `json:"is_active"`
+	LastSyncAt      time.Time  `json:"last_sync_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Available is the part of the balance that is neither reserved nor held
+func (w *MerchantWallet) Available() float64 {
+	return w.Amount - w.ReservedAmount - w.HeldAmount
 }
 
 // WalletHealthCheck represents the health status of wallet balances vs transaction history
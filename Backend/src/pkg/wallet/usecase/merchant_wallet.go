@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,25 +33,85 @@ func (u *MerchantWalletUsecase) CreateMerchantWallet(ctx context.Context, userID
 	return u.walletRepository.CreateMerchantWallet(ctx, userID, merchantID, amount, lockedAmount, currency)
 }
 
+// GetMerchantWallet gets the merchant's primary wallet, the one opened with the merchant
 func (u *MerchantWalletUsecase) GetMerchantWallet(ctx context.Context, merchantID uuid.UUID) (*entity.MerchantWallet, error) {
 	return u.walletRepository.GetMerchantWalletByMerchantID(ctx, merchantID)
 }
 
-// LockWithdrawalAmount locks the specified amount in the merchant wallet for withdrawal
+// ListMerchantWallets lists the merchant's wallets, one per currency, the primary wallet first
+func (u *MerchantWalletUsecase) ListMerchantWallets(ctx context.Context, merchantID uuid.UUID) ([]entity.MerchantWallet, error) {
+	return u.walletRepository.ListMerchantWallets(ctx, merchantID)
+}
+
+// OpenCurrencyWallet opens a wallet in another currency for a merchant, or returns the one it already holds
+func (u *MerchantWalletUsecase) OpenCurrencyWallet(ctx context.Context, merchantID uuid.UUID, currency entity.Currency) (*entity.MerchantWallet, error) {
+	currency = entity.Currency(strings.ToUpper(string(currency)))
+	if !currency.IsValid() {
+		return nil, fmt.Errorf("unsupported currency: %s", currency)
+	}
+
+	wallet, err := u.walletRepository.GetMerchantWalletByCurrency(ctx, merchantID, string(currency))
+	if err == nil {
+		return wallet, nil
+	}
+	if !errors.Is(err, entity.ErrWalletNotFound) {
+		return nil, err
+	}
+
+	// The new wallet belongs to the owner of the primary wallet
+	primary, err := u.walletRepository.GetMerchantWalletByMerchantID(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.walletRepository.CreateMerchantWallet(ctx, primary.UserID, merchantID, 0, 0, string(currency)); err != nil {
+		return nil, fmt.Errorf("failed to open %s wallet: %w", currency, err)
+	}
+
+	u.logger.Info("Currency wallet opened", map[string]interface{}{
+		"merchantID": merchantID,
+		"currency":   currency,
+	})
+
+	return u.walletRepository.GetMerchantWalletByCurrency(ctx, merchantID, string(currency))
+}
+
+// SettlementWallet picks the wallet funds in a currency settle into: the merchant's wallet in
+// the first preferred currency it holds, else the primary wallet
+func (u *MerchantWalletUsecase) SettlementWallet(ctx context.Context, merchantID uuid.UUID, preferred ...string) (*entity.MerchantWallet, error) {
+	wallets, err := u.walletRepository.ListMerchantWallets(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(wallets) == 0 {
+		return nil, entity.ErrWalletNotFound
+	}
+
+	for _, currency := range preferred {
+		for i := range wallets {
+			if strings.EqualFold(string(wallets[i].Currency), currency) {
+				return &wallets[i], nil
+			}
+		}
+	}
+	return &wallets[0], nil
+}
+
+// LockWithdrawalAmount locks the specified amount in the merchant wallet of the currency for withdrawal
 // This prevents the amount from being used for other withdrawals while the transaction is processing
-func (u *MerchantWalletUsecase) LockWithdrawalAmount(ctx context.Context, merchantID uuid.UUID, amount float64) error {
+func (u *MerchantWalletUsecase) LockWithdrawalAmount(ctx context.Context, merchantID uuid.UUID, currency string, amount float64) error {
 	// Create a timeout context to prevent indefinite hangs
 	txCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Use the atomic locking operation
-	err := u.walletRepository.LockWithdrawalAmountAtomic(txCtx, merchantID, amount)
+	err := u.walletRepository.LockWithdrawalAmountAtomic(txCtx, merchantID, currency, amount)
 	if err != nil {
 		return fmt.Errorf("failed to lock withdrawal amount: %w", err)
 	}
 
 	u.logger.Debug("Withdrawal amount locked successfully", map[string]interface{}{
 		"merchantID": merchantID,
+		"currency":   currency,
 		"amount":     amount,
 	})
 
@@ -67,9 +129,11 @@ func (u *MerchantWalletUsecase) LockWithdrawalAmount(ctx context.Context, mercha
 //   - Failure: no action needed (no prior locking was done)
 //
 // Admin wallet always gets commission when transaction is successful
-func (u *MerchantWalletUsecase) ProcessTransactionStatus(ctx context.Context, merchantID uuid.UUID, merchantAmount float64, adminAmount float64, isSuccess bool, isWithdrawal bool) error {
+// The merchant amount is in the currency of the merchant wallet it moves in, the admin amount in the admin wallet's
+func (u *MerchantWalletUsecase) ProcessTransactionStatus(ctx context.Context, merchantID uuid.UUID, currency string, merchantAmount float64, adminAmount float64, isSuccess bool, isWithdrawal bool) error {
 	u.logger.Info("Processing transaction status", map[string]interface{}{
 		"merchantID":   merchantID,
+		"currency":     currency,
 		"amount":       merchantAmount,
 		"adminAmount":  adminAmount,
 		"isSuccess":    isSuccess,
@@ -80,7 +144,7 @@ func (u *MerchantWalletUsecase) ProcessTransactionStatus(ctx context.Context, me
 	if isWithdrawal {
 		if isSuccess {
 			// Withdrawal success: unlock amount (don't change available balance) + admin commission
-			err := u.walletRepository.ProcessWithdrawalSuccess(ctx, merchantID, merchantAmount, adminAmount)
+			err := u.walletRepository.ProcessWithdrawalSuccess(ctx, merchantID, currency, merchantAmount, adminAmount)
 			if err != nil {
 				u.logger.Error("Failed to process withdrawal success", map[string]interface{}{
 					"error":      err,
@@ -91,7 +155,7 @@ func (u *MerchantWalletUsecase) ProcessTransactionStatus(ctx context.Context, me
 			}
 		} else {
 			// Withdrawal failure: return locked amount to available balance
-			err := u.walletRepository.ProcessWithdrawalFailure(ctx, merchantID, merchantAmount)
+			err := u.walletRepository.ProcessWithdrawalFailure(ctx, merchantID, currency, merchantAmount)
 			if err != nil {
 				u.logger.Error("Failed to process withdrawal failure", map[string]interface{}{
 					"error":      err,
@@ -104,7 +168,7 @@ func (u *MerchantWalletUsecase) ProcessTransactionStatus(ctx context.Context, me
 	} else {
 		if isSuccess {
 			// Deposit success: add to available balance + admin commission
-			err := u.walletRepository.ProcessDepositSuccess(ctx, merchantID, merchantAmount, adminAmount)
+			err := u.walletRepository.ProcessDepositSuccess(ctx, merchantID, currency, merchantAmount, adminAmount)
 			if err != nil {
 				u.logger.Error("Failed to process deposit success", map[string]interface{}{
 					"error":      err,
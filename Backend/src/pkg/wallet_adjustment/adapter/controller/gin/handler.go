@@ -0,0 +1,321 @@
+package gin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	fileEntity "github.com/socialpay/socialpay/src/pkg/file/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	"github.com/socialpay/socialpay/src/pkg/wallet_adjustment/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/wallet_adjustment/usecase"
+)
+
+type Handler struct {
+	adjustmentUseCase usecase.WalletAdjustmentUseCase
+	log               logging.Logger
+	jwtMiddleware     gin.HandlerFunc
+	rbac              *ginMiddleware.RBACV2
+}
+
+func NewHandler(adjustmentUseCase usecase.WalletAdjustmentUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		adjustmentUseCase: adjustmentUseCase,
+		log:               logging.NewStdLogger("wallet_adjustment_handler"),
+		jwtMiddleware:     jwtMiddleware,
+		rbac:              rbac,
+	}
+}
+
+// RegisterRouter sets up the admin wallet adjustment routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	admin := router.Group("/admin/wallet-adjustments", h.jwtMiddleware)
+	admin.GET("",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ADMIN_WALLET, auth_entity.OPERATION_ADMIN_READ),
+		h.List)
+	admin.POST("",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ADMIN_WALLET, auth_entity.OPERATION_ADJUST),
+		h.Request)
+	admin.GET("/:id",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ADMIN_WALLET, auth_entity.OPERATION_ADMIN_READ),
+		h.Get)
+	admin.POST("/:id/attachments",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ADMIN_WALLET, auth_entity.OPERATION_ADJUST),
+		h.UploadAttachment)
+	admin.POST("/:id/approve",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ADMIN_WALLET, auth_entity.OPERATION_ADJUST),
+		h.Approve)
+	admin.POST("/:id/reject",
+		h.rbac.RequirePermissionForAdmin(auth_entity.RESOURCE_ADMIN_WALLET, auth_entity.OPERATION_ADJUST),
+		h.Reject)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// List godoc
+// @Summary      List wallet adjustments
+// @Description  List manual adjustments of merchant wallets and the admin wallet, latest first
+// @Tags         Admin Wallet Adjustments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        wallet_type  query  string  false  "MERCHANT or ADMIN"
+// @Param        merchant_id  query  string  false  "Merchant ID"
+// @Param        status       query  string  false  "PENDING, APPROVED or REJECTED"
+// @Param        limit        query  int     false  "Number of adjustments (max 200)"
+// @Param        offset       query  int     false  "Adjustments to skip"
+// @Success      200  {object}  entity.ListResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /admin/wallet-adjustments [get]
+func (h *Handler) List(c *gin.Context) {
+	filter := entity.Filter{
+		WalletType: entity.WalletType(strings.ToUpper(c.Query("wallet_type"))),
+		Status:     entity.Status(strings.ToUpper(c.Query("status"))),
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filter.Offset, _ = strconv.Atoi(c.Query("offset"))
+	if raw := c.Query("merchant_id"); raw != "" {
+		merchantID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid merchant ID")))
+			return
+		}
+		filter.MerchantID = &merchantID
+	}
+
+	response, err := h.adjustmentUseCase.List(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Request godoc
+// @Summary      Request a wallet adjustment
+// @Description  Request a credit or debit of a merchant wallet or the admin wallet, with a reason code and optionally the transaction it corrects. Nothing moves until a second admin approves it; debits cannot exceed the available balance.
+// @Tags         Admin Wallet Adjustments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.AdjustmentRequest  true  "Adjustment"
+// @Success      201  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /admin/wallet-adjustments [post]
+func (h *Handler) Request(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	var req entity.AdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	detail, err := h.adjustmentUseCase.Request(c.Request.Context(), &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, detail)
+}
+
+// Get godoc
+// @Summary      Get a wallet adjustment
+// @Description  Get a wallet adjustment with its attachments
+// @Tags         Admin Wallet Adjustments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Adjustment ID"
+// @Success      200  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /admin/wallet-adjustments/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	adjustmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid adjustment ID")))
+		return
+	}
+
+	detail, err := h.adjustmentUseCase.Get(c.Request.Context(), adjustmentID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// UploadAttachment godoc
+// @Summary      Attach a file to a wallet adjustment
+// @Description  Upload a PDF, JPEG or PNG file of up to 10MB supporting a pending adjustment, such as a processor settlement report
+// @Tags         Admin Wallet Adjustments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Adjustment ID"
+// @Param        file         formData  file    true   "Attachment"
+// @Param        description  formData  string  false  "What the file shows"
+// @Success      201  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/wallet-adjustments/{id}/attachments [post]
+func (h *Handler) UploadAttachment(c *gin.Context) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	adjustmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid adjustment ID")))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("no file is received")))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("failed to open file")))
+		return
+	}
+	defer file.Close()
+
+	upload := &entity.AttachmentUpload{
+		File:        file,
+		Header:      *fileHeader,
+		Description: c.PostForm("description"),
+	}
+	detail, err := h.adjustmentUseCase.UploadAttachment(c.Request.Context(), adjustmentID, adminID, upload)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, detail)
+}
+
+// Approve godoc
+// @Summary      Approve a wallet adjustment
+// @Description  Apply a pending adjustment to its wallet and post it as an ADJUSTMENT transaction. Must be a different admin than the one who requested it.
+// @Tags         Admin Wallet Adjustments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true   "Adjustment ID"
+// @Param        request  body  entity.DecisionRequest  false  "Decision note"
+// @Success      200  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      422  {object}  ErrorResponse
+// @Router       /admin/wallet-adjustments/{id}/approve [post]
+func (h *Handler) Approve(c *gin.Context) {
+	h.decide(c, h.adjustmentUseCase.Approve)
+}
+
+// Reject godoc
+// @Summary      Reject a wallet adjustment
+// @Description  Close a pending adjustment without touching the wallet. Must be a different admin than the one who requested it.
+// @Tags         Admin Wallet Adjustments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true   "Adjustment ID"
+// @Param        request  body  entity.DecisionRequest  false  "Decision note"
+// @Success      200  {object}  entity.Detail
+// @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/wallet-adjustments/{id}/reject [post]
+func (h *Handler) Reject(c *gin.Context) {
+	h.decide(c, h.adjustmentUseCase.Reject)
+}
+
+type decideFunc func(ctx context.Context, id uuid.UUID, req *entity.DecisionRequest, adminID uuid.UUID) (*entity.Detail, error)
+
+func (h *Handler) decide(c *gin.Context, decide decideFunc) {
+	adminID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return
+	}
+
+	adjustmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid adjustment ID")))
+		return
+	}
+
+	var req entity.DecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+
+	detail, err := decide(c.Request.Context(), adjustmentID, &req, adminID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	var fileErr *fileEntity.FileError
+	switch {
+	case errors.Is(err, entity.ErrAdjustmentNotFound),
+		errors.Is(err, entity.ErrTransactionNotFound),
+		errors.Is(err, entity.ErrWalletNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrSelfApproval):
+		c.JSON(http.StatusForbidden, newErrorResponse(err))
+	case errors.Is(err, entity.ErrAdjustmentDecided):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	case errors.Is(err, entity.ErrInsufficientBalance):
+		c.JSON(http.StatusUnprocessableEntity, newErrorResponse(err))
+	case errors.As(err, &fileErr),
+		strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+	default:
+		h.log.Error("Wallet adjustment request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
@@ -0,0 +1,256 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAdjustmentNotFound is returned when no adjustment has the given ID
+	ErrAdjustmentNotFound = errors.New("wallet adjustment not found")
+	// ErrAdjustmentDecided is returned when an adjustment was already approved or rejected
+	ErrAdjustmentDecided = errors.New("wallet adjustment has already been decided")
+	// ErrSelfApproval is returned when the admin who requested an adjustment tries to decide on it
+	ErrSelfApproval = errors.New("a wallet adjustment must be decided by a different admin than the one who requested it")
+	// ErrWalletNotFound is returned when the adjusted wallet does not exist in the adjustment currency
+	ErrWalletNotFound = errors.New("wallet not found")
+	// ErrInsufficientBalance is returned when a debit exceeds the available balance of the wallet
+	ErrInsufficientBalance = errors.New("insufficient available balance for the debit")
+	// ErrTransactionNotFound is returned when the related transaction does not exist
+	ErrTransactionNotFound = errors.New("related transaction not found")
+)
+
+// MaxAttachments bounds how many files an adjustment can carry
+const MaxAttachments = 10
+
+// WalletType is the kind of wallet an adjustment applies to
+type WalletType string
+
+const (
+	// WalletMerchant is a merchant's wallet in one currency
+	WalletMerchant WalletType = "MERCHANT"
+	// WalletAdmin is the single SocialPay admin wallet that collects fees
+	WalletAdmin WalletType = "ADMIN"
+)
+
+// Direction is whether an adjustment adds money to the wallet or takes it out
+type Direction string
+
+const (
+	Credit Direction = "CREDIT"
+	Debit  Direction = "DEBIT"
+)
+
+// ReasonCode is why a balance is corrected
+type ReasonCode string
+
+const (
+	// ReasonBalanceCorrection fixes a balance that drifted from its transactions
+	ReasonBalanceCorrection ReasonCode = "BALANCE_CORRECTION"
+	// ReasonMissedCredit credits a payment that succeeded but never reached the wallet
+	ReasonMissedCredit ReasonCode = "MISSED_CREDIT"
+	// ReasonDuplicateCredit takes back a payment credited more than once
+	ReasonDuplicateCredit ReasonCode = "DUPLICATE_CREDIT"
+	// ReasonFeeRefund returns fees charged in error
+	ReasonFeeRefund ReasonCode = "FEE_REFUND"
+	// ReasonChargebackRecovery recovers a chargeback settled outside the dispute flow
+	ReasonChargebackRecovery ReasonCode = "CHARGEBACK_RECOVERY"
+	// ReasonGoodwill is a commercial gesture
+	ReasonGoodwill ReasonCode = "GOODWILL"
+	ReasonOther    ReasonCode = "OTHER"
+)
+
+// IsValid reports whether r is a known reason code
+func (r ReasonCode) IsValid() bool {
+	switch r {
+	case ReasonBalanceCorrection, ReasonMissedCredit, ReasonDuplicateCredit, ReasonFeeRefund,
+		ReasonChargebackRecovery, ReasonGoodwill, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// Status is where an adjustment stands. Adjustments wait in PENDING until a second admin
+// approves them, which applies them to the wallet, or rejects them.
+type Status string
+
+const (
+	StatusPending  Status = "PENDING"
+	StatusApproved Status = "APPROVED"
+	StatusRejected Status = "REJECTED"
+)
+
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusRejected:
+		return true
+	}
+	return false
+}
+
+// Adjustment is a manual credit or debit of a wallet. Once approved it is posted as an
+// ADJUSTMENT transaction, TransactionID, that shows in the merchant's statement.
+// @Description Manual wallet adjustment
+type Adjustment struct {
+	ID         uuid.UUID  `json:"id"`
+	WalletType WalletType `json:"wallet_type" example:"MERCHANT"`
+	// MerchantID is the merchant whose wallet is adjusted, unset for the admin wallet
+	MerchantID           *uuid.UUID `json:"merchant_id,omitempty"`
+	Currency             string     `json:"currency" example:"ETB"`
+	Direction            Direction  `json:"direction" example:"CREDIT"`
+	Amount               float64    `json:"amount" example:"250"`
+	ReasonCode           ReasonCode `json:"reason_code" example:"MISSED_CREDIT"`
+	Note                 string     `json:"note"`
+	RelatedTransactionID *uuid.UUID `json:"related_transaction_id,omitempty"`
+	Status               Status     `json:"status" example:"PENDING"`
+	RequestedBy          uuid.UUID  `json:"requested_by"`
+	DecidedBy            *uuid.UUID `json:"decided_by,omitempty"`
+	DecisionNote         string     `json:"decision_note,omitempty"`
+	DecidedAt            *time.Time `json:"decided_at,omitempty"`
+	TransactionID        *uuid.UUID `json:"transaction_id,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// SignedAmount is the amount the adjustment adds to the wallet balance, negative for debits
+func (a *Adjustment) SignedAmount() float64 {
+	if a.Direction == Debit {
+		return -a.Amount
+	}
+	return a.Amount
+}
+
+// Decidable reports why an admin cannot decide on the adjustment, if they cannot
+func (a *Adjustment) Decidable(adminID uuid.UUID) error {
+	if a.Status != StatusPending {
+		return ErrAdjustmentDecided
+	}
+	if adminID == a.RequestedBy {
+		return ErrSelfApproval
+	}
+	return nil
+}
+
+// Attachment is a document supporting an adjustment, such as a bank statement or ticket export
+// @Description Wallet adjustment attachment
+type Attachment struct {
+	ID           uuid.UUID `json:"id"`
+	AdjustmentID uuid.UUID `json:"adjustment_id"`
+	FileURL      string    `json:"file_url"`
+	FileName     string    `json:"file_name"`
+	Description  string    `json:"description,omitempty" example:"Processor settlement report for 3 May"`
+	UploadedBy   uuid.UUID `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AttachmentUpload is a file uploaded to support an adjustment
+type AttachmentUpload struct {
+	File        multipart.File
+	Header      multipart.FileHeader
+	Description string
+}
+
+// Validate validates the upload
+func (u *AttachmentUpload) Validate() error {
+	if u.File == nil {
+		return errors.New("file: cannot be blank")
+	}
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Description, validation.Length(0, 500)),
+	)
+}
+
+// Detail is an adjustment with its attachments, oldest upload first
+// @Description Manual wallet adjustment with attachments
+type Detail struct {
+	Adjustment
+	Attachments []Attachment `json:"attachments"`
+}
+
+// AdjustmentRequest asks for a wallet to be credited or debited
+// @Description Wallet adjustment request
+type AdjustmentRequest struct {
+	WalletType WalletType `json:"wallet_type" example:"MERCHANT"`
+	// MerchantID is required for merchant wallets and must be left out for the admin wallet
+	MerchantID *uuid.UUID `json:"merchant_id,omitempty"`
+	Currency   string     `json:"currency" example:"ETB"`
+	Direction  Direction  `json:"direction" example:"CREDIT"`
+	Amount     float64    `json:"amount" example:"250"`
+	ReasonCode ReasonCode `json:"reason_code" example:"MISSED_CREDIT"`
+	Note       string     `json:"note" example:"Telebirr payment of 3 May confirmed by the processor but never credited"`
+	// RelatedTransactionID is the transaction the adjustment corrects, if any
+	RelatedTransactionID *uuid.UUID `json:"related_transaction_id,omitempty"`
+}
+
+// Normalize puts the codes and currency in upper case and rounds the amount to cents
+func (r *AdjustmentRequest) Normalize() {
+	r.WalletType = WalletType(strings.ToUpper(strings.TrimSpace(string(r.WalletType))))
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	r.Direction = Direction(strings.ToUpper(strings.TrimSpace(string(r.Direction))))
+	r.ReasonCode = ReasonCode(strings.ToUpper(strings.TrimSpace(string(r.ReasonCode))))
+	r.Note = strings.TrimSpace(r.Note)
+	r.Amount = math.Round(r.Amount*100) / 100
+}
+
+// Validate validates the adjustment request
+func (r *AdjustmentRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.WalletType, validation.Required, validation.In(WalletMerchant, WalletAdmin)),
+		validation.Field(&r.Currency, validation.Required, validation.Length(3, 3)),
+		validation.Field(&r.Direction, validation.Required, validation.In(Credit, Debit)),
+		validation.Field(&r.Amount, validation.Required, validation.Min(0.01)),
+		validation.Field(&r.ReasonCode, validation.Required),
+		validation.Field(&r.Note, validation.Required, validation.Length(1, 1000)),
+	); err != nil {
+		return err
+	}
+	if !r.ReasonCode.IsValid() {
+		return fmt.Errorf("unsupported reason_code: %s", r.ReasonCode)
+	}
+
+	hasMerchant := r.MerchantID != nil && *r.MerchantID != uuid.Nil
+	if r.WalletType == WalletMerchant && !hasMerchant {
+		return errors.New("merchant_id: required for merchant wallets")
+	}
+	if r.WalletType == WalletAdmin && r.MerchantID != nil {
+		return errors.New("merchant_id: must be empty for the admin wallet")
+	}
+	return nil
+}
+
+// DecisionRequest approves or rejects an adjustment
+// @Description Decision on a wallet adjustment
+type DecisionRequest struct {
+	Note string `json:"note,omitempty" example:"Matches the processor settlement report"`
+}
+
+// Validate validates the decision
+func (r *DecisionRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Note, validation.Length(0, 500)),
+	)
+}
+
+// Filter selects adjustments
+type Filter struct {
+	WalletType WalletType
+	MerchantID *uuid.UUID
+	Status     Status
+	Limit      int
+	Offset     int
+}
+
+// ListResponse is a page of adjustments, latest first
+// @Description Manual wallet adjustments
+type ListResponse struct {
+	Adjustments []Adjustment `json:"adjustments"`
+	Total       int          `json:"total"`
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAdjustmentRequestValidate(t *testing.T) {
+	merchantID := uuid.New()
+	valid := func() AdjustmentRequest {
+		return AdjustmentRequest{
+			WalletType: "merchant",
+			MerchantID: &merchantID,
+			Currency:   " etb",
+			Direction:  "credit",
+			Amount:     250.004,
+			ReasonCode: "missed_credit",
+			Note:       "Payment confirmed by the processor but never credited",
+		}
+	}
+
+	t.Run("normalized request passes", func(t *testing.T) {
+		req := valid()
+		req.Normalize()
+		if err := req.Validate(); err != nil {
+			t.Fatalf("Validate() = %v, want nil", err)
+		}
+		if req.WalletType != WalletMerchant || req.Currency != "ETB" || req.Direction != Credit ||
+			req.ReasonCode != ReasonMissedCredit || req.Amount != 250 {
+			t.Fatalf("Normalize() = %+v", req)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		modify func(*AdjustmentRequest)
+	}{
+		{"merchant wallet without merchant", func(r *AdjustmentRequest) { r.MerchantID = nil }},
+		{"admin wallet with merchant", func(r *AdjustmentRequest) { r.WalletType = WalletAdmin }},
+		{"unknown reason code", func(r *AdjustmentRequest) { r.ReasonCode = "TYPO" }},
+		{"unknown direction", func(r *AdjustmentRequest) { r.Direction = "REVERSE" }},
+		{"zero amount", func(r *AdjustmentRequest) { r.Amount = 0.001 }},
+		{"missing note", func(r *AdjustmentRequest) { r.Note = " " }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			req.Normalize()
+			if err := req.Validate(); err == nil {
+				t.Fatal("Validate() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestAdjustmentDecidable(t *testing.T) {
+	requester, approver := uuid.New(), uuid.New()
+	adjustment := &Adjustment{Status: StatusPending, RequestedBy: requester, Direction: Debit, Amount: 40}
+
+	if err := adjustment.Decidable(approver); err != nil {
+		t.Fatalf("Decidable(approver) = %v, want nil", err)
+	}
+	if err := adjustment.Decidable(requester); !errors.Is(err, ErrSelfApproval) {
+		t.Fatalf("Decidable(requester) = %v, want %v", err, ErrSelfApproval)
+	}
+	if got := adjustment.SignedAmount(); got != -40 {
+		t.Fatalf("SignedAmount() = %v, want -40", got)
+	}
+
+	adjustment.Status = StatusApproved
+	if err := adjustment.Decidable(approver); !errors.Is(err, ErrAdjustmentDecided) {
+		t.Fatalf("Decidable() after approval = %v, want %v", err, ErrAdjustmentDecided)
+	}
+}
@@ -0,0 +1,41 @@
+-- Wallet Adjustment Schema
+-- This should match the migration exactly
+
+-- Manual credits and debits of merchant and admin wallets, applied once a second admin approves
+CREATE TABLE IF NOT EXISTS public.wallet_adjustments (
+    id UUID PRIMARY KEY,
+    wallet_type VARCHAR(20) NOT NULL,
+    -- NULL for the admin wallet
+    merchant_id UUID REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    direction VARCHAR(10) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL CHECK (amount > 0),
+    reason_code VARCHAR(30) NOT NULL,
+    note TEXT NOT NULL,
+    related_transaction_id UUID REFERENCES public.transactions(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    requested_by UUID NOT NULL,
+    decided_by UUID,
+    decision_note TEXT,
+    decided_at TIMESTAMPTZ,
+    -- The ADJUSTMENT transaction posted on approval
+    transaction_id UUID REFERENCES public.transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (requested_by <> decided_by)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_adjustments_merchant ON public.wallet_adjustments(merchant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_wallet_adjustments_pending ON public.wallet_adjustments(created_at) WHERE status = 'PENDING';
+
+CREATE TABLE IF NOT EXISTS public.wallet_adjustment_attachments (
+    id UUID PRIMARY KEY,
+    adjustment_id UUID NOT NULL REFERENCES public.wallet_adjustments(id) ON DELETE CASCADE,
+    file_url TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    description TEXT,
+    uploaded_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_adjustment_attachments_adjustment ON public.wallet_adjustment_attachments(adjustment_id, created_at);
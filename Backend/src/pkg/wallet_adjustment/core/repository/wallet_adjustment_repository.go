@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/wallet_adjustment/core/entity"
+)
+
+// WalletAdjustmentRepository defines the interface for manual wallet adjustments and their attachments
+type WalletAdjustmentRepository interface {
+	Create(ctx context.Context, adjustment *entity.Adjustment) error
+	// Get retrieves an adjustment, nil when none exists
+	Get(ctx context.Context, id uuid.UUID) (*entity.Adjustment, error)
+	// AvailableBalance retrieves the available balance of the wallet an adjustment applies to,
+	// nil when the wallet does not exist
+	AvailableBalance(ctx context.Context, walletType entity.WalletType, merchantID *uuid.UUID, currency string) (*float64, error)
+	// List retrieves a page of adjustments, latest first, with the number of adjustments matching the filter
+	List(ctx context.Context, filter entity.Filter) ([]entity.Adjustment, int, error)
+	// Approve marks a pending adjustment approved, applies it to the wallet's amount and posts
+	// it as the ADJUSTMENT transaction adjustment.TransactionID, all at once. A debit may not
+	// exceed the wallet's available balance. It returns entity.ErrAdjustmentDecided when the
+	// adjustment was decided meanwhile, entity.ErrWalletNotFound and entity.ErrInsufficientBalance.
+	Approve(ctx context.Context, adjustment *entity.Adjustment) error
+	// Reject marks a pending adjustment rejected. It returns entity.ErrAdjustmentDecided when
+	// the adjustment was decided meanwhile.
+	Reject(ctx context.Context, adjustment *entity.Adjustment) error
+
+	AddAttachment(ctx context.Context, attachment *entity.Attachment) error
+	// ListAttachments retrieves the attachments of an adjustment, oldest first
+	ListAttachments(ctx context.Context, adjustmentID uuid.UUID) ([]entity.Attachment, error)
+}
@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/wallet_adjustment/core/entity"
+)
+
+const adjustmentColumns = `id, wallet_type, merchant_id, currency, direction, amount, reason_code, note,
+	related_transaction_id, status, requested_by, decided_by, decision_note, decided_at, transaction_id,
+	created_at, updated_at`
+
+const attachmentColumns = `id, adjustment_id, file_url, file_name, description, uploaded_by, created_at`
+
+type WalletAdjustmentRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewWalletAdjustmentRepository(db *sql.DB) WalletAdjustmentRepository {
+	return &WalletAdjustmentRepositoryImpl{db: db}
+}
+
+func (r *WalletAdjustmentRepositoryImpl) Create(ctx context.Context, adjustment *entity.Adjustment) error {
+	query := `
+		INSERT INTO public.wallet_adjustments (` + adjustmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		adjustment.ID, string(adjustment.WalletType), adjustment.MerchantID, adjustment.Currency,
+		string(adjustment.Direction), adjustment.Amount, string(adjustment.ReasonCode), adjustment.Note,
+		adjustment.RelatedTransactionID, string(adjustment.Status), adjustment.RequestedBy, adjustment.DecidedBy,
+		nullString(adjustment.DecisionNote), adjustment.DecidedAt, adjustment.TransactionID,
+		adjustment.CreatedAt, adjustment.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create wallet adjustment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WalletAdjustmentRepositoryImpl) Get(ctx context.Context, id uuid.UUID) (*entity.Adjustment, error) {
+	query := `SELECT ` + adjustmentColumns + ` FROM public.wallet_adjustments WHERE id = $1`
+
+	adjustment, err := scanAdjustment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get wallet adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+func (r *WalletAdjustmentRepositoryImpl) AvailableBalance(ctx context.Context, walletType entity.WalletType, merchantID *uuid.UUID, currency string) (*float64, error) {
+	query, args := walletQuery(walletType, merchantID, currency)
+
+	var walletID, userID uuid.UUID
+	var available float64
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&walletID, &userID, &available); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	return &available, nil
+}
+
+func (r *WalletAdjustmentRepositoryImpl) List(ctx context.Context, filter entity.Filter) ([]entity.Adjustment, int, error) {
+	where := `WHERE ($1 = '' OR wallet_type = $1)
+		AND ($2::uuid IS NULL OR merchant_id = $2)
+		AND ($3 = '' OR status = $3)`
+	args := []interface{}{string(filter.WalletType), filter.MerchantID, string(filter.Status)}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM public.wallet_adjustments ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count wallet adjustments: %w", err)
+	}
+
+	query := `
+		SELECT ` + adjustmentColumns + `
+		FROM public.wallet_adjustments
+		` + where + `
+		ORDER BY created_at DESC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list wallet adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	var adjustments []entity.Adjustment
+	for rows.Next() {
+		adjustment, err := scanAdjustment(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan wallet adjustment: %w", err)
+		}
+		adjustments = append(adjustments, *adjustment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list wallet adjustments: %w", err)
+	}
+
+	return adjustments, total, nil
+}
+
+func (r *WalletAdjustmentRepositoryImpl) Approve(ctx context.Context, adjustment *entity.Adjustment) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Deciding first locks the adjustment, so a concurrent approval waits and then finds it decided
+	if err := decide(ctx, dbTx, adjustment); err != nil {
+		return err
+	}
+
+	userID, err := adjustWallet(ctx, dbTx, adjustment)
+	if err != nil {
+		return err
+	}
+
+	if err := postTransaction(ctx, dbTx, adjustment, userID); err != nil {
+		return err
+	}
+
+	if _, err := dbTx.ExecContext(ctx,
+		`UPDATE public.wallet_adjustments SET transaction_id = $2 WHERE id = $1`,
+		adjustment.ID, adjustment.TransactionID,
+	); err != nil {
+		return fmt.Errorf("failed to link adjustment transaction: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wallet adjustment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WalletAdjustmentRepositoryImpl) Reject(ctx context.Context, adjustment *entity.Adjustment) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	if err := decide(ctx, dbTx, adjustment); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wallet adjustment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WalletAdjustmentRepositoryImpl) AddAttachment(ctx context.Context, attachment *entity.Attachment) error {
+	query := `
+		INSERT INTO public.wallet_adjustment_attachments (` + attachmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		attachment.ID, attachment.AdjustmentID, attachment.FileURL, attachment.FileName,
+		nullString(attachment.Description), attachment.UploadedBy, attachment.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to add wallet adjustment attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WalletAdjustmentRepositoryImpl) ListAttachments(ctx context.Context, adjustmentID uuid.UUID) ([]entity.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM public.wallet_adjustment_attachments
+		WHERE adjustment_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, adjustmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallet adjustment attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []entity.Attachment
+	for rows.Next() {
+		var item entity.Attachment
+		var description sql.NullString
+		if err := rows.Scan(
+			&item.ID, &item.AdjustmentID, &item.FileURL, &item.FileName, &description, &item.UploadedBy, &item.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan wallet adjustment attachment: %w", err)
+		}
+		item.Description = description.String
+		attachments = append(attachments, item)
+	}
+
+	return attachments, rows.Err()
+}
+
+// decide records the decision on a pending adjustment
+func decide(ctx context.Context, dbTx *sql.Tx, adjustment *entity.Adjustment) error {
+	query := `
+		UPDATE public.wallet_adjustments
+		SET status = $2, decided_by = $3, decision_note = $4, decided_at = $5, updated_at = $6
+		WHERE id = $1 AND status = 'PENDING'`
+
+	result, err := dbTx.ExecContext(ctx, query,
+		adjustment.ID, string(adjustment.Status), adjustment.DecidedBy, nullString(adjustment.DecisionNote),
+		adjustment.DecidedAt, adjustment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to decide wallet adjustment: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to decide wallet adjustment: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrAdjustmentDecided
+	}
+
+	return nil
+}
+
+// adjustWallet applies the adjustment to the amount of its wallet and returns the wallet's owner
+func adjustWallet(ctx context.Context, dbTx *sql.Tx, adjustment *entity.Adjustment) (uuid.UUID, error) {
+	query, args := walletQuery(adjustment.WalletType, adjustment.MerchantID, adjustment.Currency)
+
+	var walletID, userID uuid.UUID
+	var available float64
+	if err := dbTx.QueryRowContext(ctx, query+" FOR UPDATE", args...).Scan(&walletID, &userID, &available); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, entity.ErrWalletNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	if adjustment.Direction == entity.Debit && available < adjustment.Amount {
+		return uuid.Nil, fmt.Errorf("%w: %.2f %s available", entity.ErrInsufficientBalance, available, adjustment.Currency)
+	}
+
+	if _, err := dbTx.ExecContext(ctx, `
+		UPDATE merchant.wallet
+		SET amount = amount + $2,
+			updated_at = NOW()
+		WHERE id = $1`,
+		walletID, adjustment.SignedAmount(),
+	); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	return userID, nil
+}
+
+// walletQuery selects the ID, owner and available balance of the wallet an adjustment applies to
+func walletQuery(walletType entity.WalletType, merchantID *uuid.UUID, currency string) (string, []interface{}) {
+	if walletType == entity.WalletAdmin {
+		return `
+			SELECT id, user_id, amount - reserved_amount - held_amount
+			FROM merchant.wallet
+			WHERE wallet_type = 'super_admin' AND currency = $1`, []interface{}{currency}
+	}
+	return `
+		SELECT id, user_id, amount - reserved_amount - held_amount
+		FROM merchant.wallet
+		WHERE merchant_id = $1 AND wallet_type = 'merchant' AND currency = $2`, []interface{}{merchantID, currency}
+}
+
+// postTransaction records the adjustment as a successful ADJUSTMENT transaction of the wallet's
+// owner. The signed amount goes to merchant_net for merchant wallets, so the adjustment shows in
+// the merchant's statement and ledger, and to admin_net for the admin wallet.
+func postTransaction(ctx context.Context, dbTx *sql.Tx, adjustment *entity.Adjustment, userID uuid.UUID) error {
+	details := map[string]interface{}{
+		"adjustment_id": adjustment.ID,
+		"reason_code":   adjustment.ReasonCode,
+		"direction":     adjustment.Direction,
+		"requested_by":  adjustment.RequestedBy,
+		"approved_by":   adjustment.DecidedBy,
+	}
+	if adjustment.RelatedTransactionID != nil {
+		details["related_transaction_id"] = adjustment.RelatedTransactionID
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal adjustment details: %w", err)
+	}
+
+	var merchantNet, adminNet sql.NullFloat64
+	if adjustment.WalletType == entity.WalletAdmin {
+		adminNet = sql.NullFloat64{Float64: adjustment.SignedAmount(), Valid: true}
+	} else {
+		merchantNet = sql.NullFloat64{Float64: adjustment.SignedAmount(), Valid: true}
+	}
+
+	query := `
+		INSERT INTO public.transactions (
+			id, user_id, merchant_id, type, medium, reference, description, verified, status, test,
+			base_amount, fee_amount, vat_amount, admin_net, merchant_net, total_amount, currency, details,
+			confirm_timestamp, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, true, $8, false, $9, 0, 0, $10, $11, $9, $12, $13, $14, $14, $14)`
+
+	if _, err := dbTx.ExecContext(ctx, query,
+		adjustment.TransactionID, userID, adjustment.MerchantID, string(txEntity.ADJUSTMENT), string(txEntity.SOCIALPAY),
+		adjustment.ID.String(), fmt.Sprintf("%s %s: %s", adjustment.Direction, adjustment.ReasonCode, adjustment.Note),
+		string(txEntity.SUCCESS), adjustment.Amount, adminNet, merchantNet, adjustment.Currency, detailsJSON,
+		adjustment.DecidedAt,
+	); err != nil {
+		return fmt.Errorf("failed to post adjustment transaction: %w", err)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAdjustment(row rowScanner) (*entity.Adjustment, error) {
+	var adjustment entity.Adjustment
+	var merchantID, relatedTransactionID, decidedBy, transactionID uuid.NullUUID
+	var decisionNote sql.NullString
+	var decidedAt sql.NullTime
+	if err := row.Scan(
+		&adjustment.ID, &adjustment.WalletType, &merchantID, &adjustment.Currency, &adjustment.Direction,
+		&adjustment.Amount, &adjustment.ReasonCode, &adjustment.Note, &relatedTransactionID, &adjustment.Status,
+		&adjustment.RequestedBy, &decidedBy, &decisionNote, &decidedAt, &transactionID,
+		&adjustment.CreatedAt, &adjustment.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if merchantID.Valid {
+		adjustment.MerchantID = &merchantID.UUID
+	}
+	if relatedTransactionID.Valid {
+		adjustment.RelatedTransactionID = &relatedTransactionID.UUID
+	}
+	if decidedBy.Valid {
+		adjustment.DecidedBy = &decidedBy.UUID
+	}
+	adjustment.DecisionNote = decisionNote.String
+	if decidedAt.Valid {
+		adjustment.DecidedAt = &decidedAt.Time
+	}
+	if transactionID.Valid {
+		adjustment.TransactionID = &transactionID.UUID
+	}
+
+	return &adjustment, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	fileService "github.com/socialpay/socialpay/src/pkg/file/core/service"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txRepository "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	"github.com/socialpay/socialpay/src/pkg/wallet_adjustment/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/wallet_adjustment/core/repository"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// WalletAdjustmentUseCase defines the interface for manual wallet adjustments. An admin requests
+// a credit or debit with a reason code; it only reaches the wallet once a second admin approves it.
+type WalletAdjustmentUseCase interface {
+	// Request records a pending adjustment of a merchant wallet or the admin wallet
+	Request(ctx context.Context, req *entity.AdjustmentRequest, adminID uuid.UUID) (*entity.Detail, error)
+
+	// Get retrieves an adjustment with its attachments
+	Get(ctx context.Context, id uuid.UUID) (*entity.Detail, error)
+
+	// List retrieves a page of adjustments, latest first
+	List(ctx context.Context, filter entity.Filter) (*entity.ListResponse, error)
+
+	// Approve applies a pending adjustment to its wallet and posts it as an ADJUSTMENT
+	// transaction. The admin who requested it cannot approve it.
+	Approve(ctx context.Context, id uuid.UUID, req *entity.DecisionRequest, adminID uuid.UUID) (*entity.Detail, error)
+
+	// Reject closes a pending adjustment without touching the wallet. The admin who requested
+	// it cannot reject it.
+	Reject(ctx context.Context, id uuid.UUID, req *entity.DecisionRequest, adminID uuid.UUID) (*entity.Detail, error)
+
+	// UploadAttachment stores a file supporting a pending adjustment
+	UploadAttachment(ctx context.Context, id uuid.UUID, adminID uuid.UUID, upload *entity.AttachmentUpload) (*entity.Detail, error)
+}
+
+type walletAdjustmentUseCase struct {
+	repo            repository.WalletAdjustmentRepository
+	transactionRepo txRepository.TransactionRepository
+	files           fileService.FileService
+	log             logging.Logger
+}
+
+// NewWalletAdjustmentUseCase creates the wallet adjustment usecase
+func NewWalletAdjustmentUseCase(
+	repo repository.WalletAdjustmentRepository,
+	transactionRepo txRepository.TransactionRepository,
+	files fileService.FileService,
+) WalletAdjustmentUseCase {
+	return &walletAdjustmentUseCase{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		files:           files,
+		log:             logging.NewStdLogger("wallet_adjustment_usecase"),
+	}
+}
+
+func (uc *walletAdjustmentUseCase) Request(ctx context.Context, req *entity.AdjustmentRequest, adminID uuid.UUID) (*entity.Detail, error) {
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if req.RelatedTransactionID != nil {
+		txn, err := uc.transactionRepo.GetByID(ctx, *req.RelatedTransactionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, entity.ErrTransactionNotFound
+			}
+			return nil, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		if req.WalletType == entity.WalletMerchant && txn.MerchantId != *req.MerchantID {
+			return nil, fmt.Errorf("validation failed: related transaction belongs to another merchant")
+		}
+	}
+
+	// Checked again on approval, when the balance may have moved
+	available, err := uc.repo.AvailableBalance(ctx, req.WalletType, req.MerchantID, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if available == nil {
+		return nil, entity.ErrWalletNotFound
+	}
+	if req.Direction == entity.Debit && *available < req.Amount {
+		return nil, fmt.Errorf("%w: %.2f %s available", entity.ErrInsufficientBalance, *available, req.Currency)
+	}
+
+	now := time.Now()
+	adjustment := &entity.Adjustment{
+		ID:                   uuid.New(),
+		WalletType:           req.WalletType,
+		MerchantID:           req.MerchantID,
+		Currency:             req.Currency,
+		Direction:            req.Direction,
+		Amount:               req.Amount,
+		ReasonCode:           req.ReasonCode,
+		Note:                 req.Note,
+		RelatedTransactionID: req.RelatedTransactionID,
+		Status:               entity.StatusPending,
+		RequestedBy:          adminID,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	if err := uc.repo.Create(ctx, adjustment); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Wallet adjustment requested", map[string]interface{}{
+		"adjustment_id": adjustment.ID,
+		"wallet_type":   adjustment.WalletType,
+		"merchant_id":   adjustment.MerchantID,
+		"direction":     adjustment.Direction,
+		"amount":        adjustment.Amount,
+		"currency":      adjustment.Currency,
+		"reason_code":   adjustment.ReasonCode,
+		"admin_id":      adminID,
+	})
+
+	return uc.detail(ctx, adjustment)
+}
+
+func (uc *walletAdjustmentUseCase) Get(ctx context.Context, id uuid.UUID) (*entity.Detail, error) {
+	adjustment, err := uc.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.detail(ctx, adjustment)
+}
+
+func (uc *walletAdjustmentUseCase) List(ctx context.Context, filter entity.Filter) (*entity.ListResponse, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("validation failed: unsupported status: %s", filter.Status)
+	}
+	if filter.WalletType != "" && filter.WalletType != entity.WalletMerchant && filter.WalletType != entity.WalletAdmin {
+		return nil, fmt.Errorf("validation failed: unsupported wallet_type: %s", filter.WalletType)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	adjustments, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if adjustments == nil {
+		adjustments = []entity.Adjustment{}
+	}
+
+	return &entity.ListResponse{Adjustments: adjustments, Total: total}, nil
+}
+
+func (uc *walletAdjustmentUseCase) Approve(ctx context.Context, id uuid.UUID, req *entity.DecisionRequest, adminID uuid.UUID) (*entity.Detail, error) {
+	adjustment, err := uc.decide(ctx, id, req, adminID, entity.StatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionID := uuid.New()
+	adjustment.TransactionID = &transactionID
+	if err := uc.repo.Approve(ctx, adjustment); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Wallet adjustment approved", map[string]interface{}{
+		"adjustment_id":  adjustment.ID,
+		"transaction_id": transactionID,
+		"wallet_type":    adjustment.WalletType,
+		"merchant_id":    adjustment.MerchantID,
+		"amount":         adjustment.SignedAmount(),
+		"currency":       adjustment.Currency,
+		"requested_by":   adjustment.RequestedBy,
+		"approved_by":    adminID,
+	})
+
+	return uc.detail(ctx, adjustment)
+}
+
+func (uc *walletAdjustmentUseCase) Reject(ctx context.Context, id uuid.UUID, req *entity.DecisionRequest, adminID uuid.UUID) (*entity.Detail, error) {
+	adjustment, err := uc.decide(ctx, id, req, adminID, entity.StatusRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Reject(ctx, adjustment); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Wallet adjustment rejected", map[string]interface{}{
+		"adjustment_id": adjustment.ID,
+		"requested_by":  adjustment.RequestedBy,
+		"rejected_by":   adminID,
+	})
+
+	return uc.detail(ctx, adjustment)
+}
+
+func (uc *walletAdjustmentUseCase) UploadAttachment(ctx context.Context, id uuid.UUID, adminID uuid.UUID, upload *entity.AttachmentUpload) (*entity.Detail, error) {
+	if err := upload.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	adjustment, err := uc.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// The approver decides on what was attached, so nothing is added afterwards
+	if adjustment.Status != entity.StatusPending {
+		return nil, entity.ErrAdjustmentDecided
+	}
+
+	attachments, err := uc.repo.ListAttachments(ctx, adjustment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) >= entity.MaxAttachments {
+		return nil, fmt.Errorf("validation failed: an adjustment can have at most %d attachments", entity.MaxAttachments)
+	}
+
+	url, err := uc.files.UploadFile(ctx, upload.File, upload.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &entity.Attachment{
+		ID:           uuid.New(),
+		AdjustmentID: adjustment.ID,
+		FileURL:      *url,
+		FileName:     upload.Header.Filename,
+		Description:  upload.Description,
+		UploadedBy:   adminID,
+		CreatedAt:    time.Now(),
+	}
+	if err := uc.repo.AddAttachment(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Wallet adjustment attachment uploaded", map[string]interface{}{
+		"adjustment_id": adjustment.ID,
+		"attachment_id": attachment.ID,
+		"admin_id":      adminID,
+	})
+
+	return uc.detail(ctx, adjustment)
+}
+
+// decide loads a pending adjustment and records an admin's decision on it, to be saved
+func (uc *walletAdjustmentUseCase) decide(ctx context.Context, id uuid.UUID, req *entity.DecisionRequest, adminID uuid.UUID, status entity.Status) (*entity.Adjustment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	adjustment, err := uc.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := adjustment.Decidable(adminID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	adjustment.Status = status
+	adjustment.DecidedBy = &adminID
+	adjustment.DecisionNote = req.Note
+	adjustment.DecidedAt = &now
+	adjustment.UpdatedAt = now
+	return adjustment, nil
+}
+
+func (uc *walletAdjustmentUseCase) load(ctx context.Context, id uuid.UUID) (*entity.Adjustment, error) {
+	adjustment, err := uc.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if adjustment == nil {
+		return nil, entity.ErrAdjustmentNotFound
+	}
+	return adjustment, nil
+}
+
+func (uc *walletAdjustmentUseCase) detail(ctx context.Context, adjustment *entity.Adjustment) (*entity.Detail, error) {
+	attachments, err := uc.repo.ListAttachments(ctx, adjustment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if attachments == nil {
+		attachments = []entity.Attachment{}
+	}
+
+	return &entity.Detail{
+		Adjustment:  *adjustment,
+		Attachments: attachments,
+	}, nil
+}
@@ -18,8 +18,23 @@ type WebhookRequest struct {
 	MerchantID       string                   `json:"merchantId" binding:"required"`
 	UserID           string                   `json:"userId" binding:"required"`
 	IsHostedCheckout bool                     `json:"isHostedCheckout"`
+	// Source and Actor are set by internal dispatchers only and are never bound from a request
+	Source txEntity.StatusSource `json:"-"`
+	Actor  string                `json:"-"`
 }
 
+// EventCheckoutExpired is sent to the merchant callback when a hosted checkout expires unpaid
+const EventCheckoutExpired txEntity.TransactionType = "checkout.expired"
+
+// EventMerchantStatusChanged is sent to the merchant callback when its status changes
+const EventMerchantStatusChanged txEntity.TransactionType = "merchant.status_changed"
+
+// EventDisputeOpened is sent to the merchant callback when a payment is disputed
+const EventDisputeOpened txEntity.TransactionType = "dispute.opened"
+
+// EventDisputeStatusChanged is sent to the merchant callback when a dispute moves to a new status
+const EventDisputeStatusChanged txEntity.TransactionType = "dispute.status_changed"
+
 type WebhookEventMerchant struct {
 	Event        txEntity.TransactionType `json:"event"`
 	ReferenceId  string                   `json:"referenceId"`
@@ -32,6 +47,14 @@ type WebhookEventMerchant struct {
 	Timestamp    time.Time                `json:"timestamp"`
 	MerchantID   string                   `json:"merchantId"`
 	UserID       string                   `json:"userId"`
+	// HostedCheckoutID is set for hosted checkout lifecycle events
+	HostedCheckoutID string `json:"hostedCheckoutId,omitempty"`
+	// Test marks events for transactions made with a test API key
+	Test bool `json:"test,omitempty"`
+	// PreviousStatus is set for merchant and dispute status events
+	PreviousStatus string `json:"previousStatus,omitempty"`
+	// DisputeID is set for dispute events
+	DisputeID string `json:"disputeId,omitempty"`
 }
 
 type WebhookMessage struct {
@@ -46,4 +69,6 @@ type WebhookMessage struct {
 	Timestamp        time.Time                `json:"timestamp"`
 	UserID           string                   `json:"user_id"`
 	IsHostedCheckout bool                     `json:"isHostedCheckout"`
+	Source           txEntity.StatusSource    `json:"source,omitempty"`
+	Actor            string                   `json:"actor,omitempty"`
 }
@@ -162,6 +162,17 @@ func (w *WebhookSenderWorker) processMessage(ctx context.Context, msg dto.Webhoo
 			continue
 		}
 
+		// Events without a transaction (e.g. an unpaid checkout expiring) have no callback log
+		if msg.SocialPayTxnID == "" {
+			w.logger.Info("Webhook sent successfully", map[string]interface{}{
+				"event":              msg.Event,
+				"hosted_checkout_id": msg.HostedCheckoutID,
+				"attempts_needed":    retries + 1,
+				"response_status":    responseStatus,
+			})
+			return nil
+		}
+
 		// Log the webhook response
 		requestBody, _ := json.Marshal(msg)
 		txnID, err := uuid.Parse(msg.SocialPayTxnID)
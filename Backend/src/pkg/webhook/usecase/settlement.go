@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+
+	fxEntity "github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// walletPosition is what a transaction in a status has moved in the wallets, counted from
+// before it touched them. A withdrawal locks its amount while in flight, moves it out with
+// the commission to the admin when paid and returns it when it fails; a deposit credits the
+// merchant and the admin once it succeeds.
+type walletPosition struct {
+	merchantAmount float64
+	merchantLocked float64
+	adminAmount    float64
+}
+
+func positionOf(txn *txEntity.Transaction, status txEntity.TransactionStatus, settlement *fxEntity.Settlement) walletPosition {
+	settled := status == txEntity.SUCCESS || status == txEntity.REFUNDED
+	if txn.Type != txEntity.WITHDRAWAL {
+		if !settled {
+			return walletPosition{}
+		}
+		return walletPosition{merchantAmount: settlement.MerchantAmount, adminAmount: settlement.AdminAmount}
+	}
+
+	switch {
+	case settled:
+		return walletPosition{merchantAmount: -settlement.MerchantAmount, adminAmount: settlement.AdminAmount}
+	case status == txEntity.FAILED || status == txEntity.EXPIRED || status == txEntity.CANCELED:
+		return walletPosition{}
+	}
+	return walletPosition{merchantAmount: -settlement.MerchantAmount, merchantLocked: settlement.MerchantAmount}
+}
+
+// settlementMovements returns the wallet movements that take a transaction from what it had
+// moved in one status to what it moves in another. Sandbox transactions and deposits collected
+// into the merchant's own processor account never touch the wallets.
+func settlementMovements(txn *txEntity.Transaction, settlement *fxEntity.Settlement, from, to txEntity.TransactionStatus) []txEntity.WalletMovement {
+	if txn.Test || (txn.Type != txEntity.WITHDRAWAL && txn.SettlesToMerchantAccount()) {
+		return nil
+	}
+
+	before := positionOf(txn, from, settlement)
+	after := positionOf(txn, to, settlement)
+
+	var movements []txEntity.WalletMovement
+	if amount, locked := after.merchantAmount-before.merchantAmount, after.merchantLocked-before.merchantLocked; amount != 0 || locked != 0 {
+		merchantID := txn.MerchantId
+		movements = append(movements, txEntity.WalletMovement{
+			MerchantID:   &merchantID,
+			Currency:     settlement.WalletCurrency,
+			Amount:       amount,
+			LockedAmount: locked,
+		})
+	}
+	if amount := after.adminAmount - before.adminAmount; amount != 0 {
+		movements = append(movements, txEntity.WalletMovement{
+			Currency: settlement.AdminCurrency,
+			Amount:   amount,
+		})
+	}
+	return movements
+}
+
+// transitionSettlement returns the amounts a transaction moves when it goes to a status. A
+// transaction leaving a settled status is unwound with the amounts recorded when it settled;
+// any other is converted as it settles now.
+func (uc *WebhookUseCaseImpl) transitionSettlement(ctx context.Context, txn *txEntity.Transaction, to txEntity.TransactionStatus) (*fxEntity.Settlement, error) {
+	if txn.Status != txEntity.SUCCESS && txn.Status != txEntity.REFUNDED {
+		return uc.settleAmounts(ctx, txn, to == txEntity.SUCCESS)
+	}
+
+	txCurrency := txn.WalletCurrency()
+	settlement := &fxEntity.Settlement{
+		WalletCurrency: txCurrency,
+		MerchantAmount: txn.MerchantNet,
+		AdminCurrency:  txCurrency,
+		AdminAmount:    txn.AdminNet,
+	}
+	if details, ok := txn.Details.(map[string]interface{}); ok && details[txEntity.FXDetailKey] != nil {
+		if raw, err := json.Marshal(details[txEntity.FXDetailKey]); err == nil {
+			_ = json.Unmarshal(raw, settlement)
+		}
+	}
+	return settlement, nil
+}
+
+// transition moves a transaction to a status together with the wallet movements that settle it
+func (uc *WebhookUseCaseImpl) transition(ctx context.Context, txn *txEntity.Transaction, req txEntity.StatusTransitionRequest) (*fxEntity.Settlement, error) {
+	settlement, err := uc.transitionSettlement(ctx, txn, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	req.TransactionID = txn.Id
+	req.Movements = settlementMovements(txn, settlement, txn.Status, req.To)
+	if _, err := uc.statusTransitions.Transition(ctx, req); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	fxEntity "github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	walletEntity "github.com/socialpay/socialpay/src/pkg/wallet/core/entity"
+)
+
+// settleAmounts converts a transaction's net amounts into the currencies of the wallets they
+// move in. Deposits settle into the merchant's wallet in the checkout's priced currency or the
+// transaction currency when it holds one, else into the primary wallet at the locked quote or
+// the rate in force now. Withdrawals move in the wallet their amount was locked in. The admin
+// commission, with the FX spread SocialPay kept, is credited in the admin wallet's currency.
+func (uc *WebhookUseCaseImpl) settleAmounts(ctx context.Context, txn *txEntity.Transaction, isSuccess bool) (*fxEntity.Settlement, error) {
+	txCurrency := txn.WalletCurrency()
+	settlement := &fxEntity.Settlement{
+		WalletCurrency: txCurrency,
+		MerchantAmount: txn.MerchantNet,
+		AdminCurrency:  txCurrency,
+		AdminAmount:    txn.AdminNet,
+	}
+	if uc.fx == nil {
+		return settlement, nil
+	}
+	now := time.Now()
+
+	// Spread kept on the merchant conversion, in the transaction currency
+	var spread float64
+	if txn.Type != txEntity.WITHDRAWAL {
+		conversion, err := uc.merchantConversion(ctx, txn, now)
+		if err != nil {
+			return nil, err
+		}
+		if conversion != nil {
+			settlement.WalletCurrency = conversion.ToCurrency
+			settlement.MerchantAmount = conversion.ConvertedAmount
+			settlement.Merchant = conversion
+			spread = conversion.SpreadAmount / conversion.MidRate
+		}
+	}
+
+	if !isSuccess {
+		return settlement, nil
+	}
+
+	adminWallet, err := uc.adminWalletUsecase.GetAdminWallet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin wallet: %w", err)
+	}
+	adminAmount := txn.AdminNet + spread
+	settlement.AdminAmount = adminAmount
+	if adminWallet.Currency != "" && string(adminWallet.Currency) != txCurrency {
+		quoted, err := uc.fx.Convert(ctx, adminAmount, txCurrency, string(adminWallet.Currency), now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert admin commission: %w", err)
+		}
+		// SocialPay converts its own commission at the mid rate
+		conversion := fxEntity.NewConversion(txCurrency, quoted.ToCurrency, adminAmount, quoted.MidRate, 0, now)
+		conversion.RateID = quoted.RateID
+		settlement.AdminCurrency = conversion.ToCurrency
+		settlement.AdminAmount = conversion.ConvertedAmount
+		settlement.Admin = &conversion
+	}
+
+	return settlement, nil
+}
+
+// merchantConversion returns the conversion of a deposit's merchant amount into the wallet it
+// settles into, nil when the merchant holds a wallet in the transaction currency
+func (uc *WebhookUseCaseImpl) merchantConversion(ctx context.Context, txn *txEntity.Transaction, now time.Time) (*fxEntity.Conversion, error) {
+	txCurrency := txn.WalletCurrency()
+
+	var quote *fxEntity.Quote
+	preferred := []string{txCurrency}
+	if quoteID := txn.FXQuoteID(); quoteID != nil {
+		q, err := uc.fx.GetQuote(ctx, *quoteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fx quote: %w", err)
+		}
+		quote = q
+		preferred = []string{quote.Currency, txCurrency}
+	}
+
+	wallet, err := uc.walletUsecase.SettlementWallet(ctx, txn.MerchantId, preferred...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement wallet: %w", err)
+	}
+	walletCurrency := string(wallet.Currency)
+	if walletCurrency == txCurrency {
+		return nil, nil
+	}
+
+	// The quote's rate holds only for the payment it priced
+	if quote != nil && quote.TransactionID != nil && *quote.TransactionID == txn.Id &&
+		quote.ChargeCurrency == txCurrency && quote.Currency == walletCurrency {
+		conversion := quote.Conversion(txn.MerchantNet, now)
+		return &conversion, nil
+	}
+
+	conversion, err := uc.fx.Convert(ctx, txn.MerchantNet, txCurrency, walletCurrency, now)
+	if errors.Is(err, fxEntity.ErrRateNotFound) {
+		// Without a rate the funds stay in their currency rather than being held back
+		uc.log.Warn("no fx rate, settling into a wallet in the transaction currency", map[string]interface{}{
+			"transactionID":  txn.Id,
+			"currency":       txCurrency,
+			"walletCurrency": walletCurrency,
+		})
+		if _, err := uc.walletUsecase.OpenCurrencyWallet(ctx, txn.MerchantId, walletEntity.Currency(txCurrency)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert merchant amount: %w", err)
+	}
+	return conversion, nil
+}
+
+// recordSettlement keeps the applied conversion on the transaction for statements and reconciliation
+func (uc *WebhookUseCaseImpl) recordSettlement(ctx context.Context, txn *txEntity.Transaction, settlement *fxEntity.Settlement) {
+	if settlement.Merchant == nil && settlement.Admin == nil {
+		return
+	}
+
+	if err := uc.transactionRepo.MergeTransactionDetails(ctx, txn.Id, map[string]interface{}{
+		txEntity.FXDetailKey: settlement,
+	}); err != nil {
+		uc.log.Error("failed to record settlement conversion", map[string]interface{}{
+			"error":         err,
+			"transactionID": txn.Id,
+		})
+	}
+}
+
+// reserveDeposit holds back the merchant's rolling reserve from a credited deposit. The deposit
+// stays credited when this fails, so the failure is only logged for follow-up.
+func (uc *WebhookUseCaseImpl) reserveDeposit(ctx context.Context, txn *txEntity.Transaction, currency string, amount float64) {
+	if uc.reserves == nil {
+		return
+	}
+
+	if err := uc.reserves.ReserveDeposit(ctx, txn.MerchantId, txn.Id, currency, amount); err != nil {
+		uc.log.Error("failed to reserve deposit", map[string]interface{}{
+			"error":         err,
+			"transactionID": txn.Id,
+			"merchantID":    txn.MerchantId,
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	fxEntity "github.com/socialpay/socialpay/src/pkg/fx/core/entity"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+// wallets is an in-memory copy of the balances the movements apply to
+type wallets struct {
+	merchant, locked, admin float64
+}
+
+func (w *wallets) apply(movements []txEntity.WalletMovement) {
+	for _, m := range movements {
+		if m.MerchantID == nil {
+			w.admin += m.Amount
+			continue
+		}
+		w.merchant += m.Amount
+		w.locked += m.LockedAmount
+	}
+}
+
+func TestSettlementMovements(t *testing.T) {
+	settlement := &fxEntity.Settlement{WalletCurrency: "ETB", MerchantAmount: 95, AdminCurrency: "ETB", AdminAmount: 5}
+
+	tests := []struct {
+		name     string
+		txType   txEntity.TransactionType
+		opening  wallets
+		statuses []txEntity.TransactionStatus
+		want     wallets
+	}{
+		{
+			name:     "deposit credited on success",
+			txType:   txEntity.DEPOSIT,
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.SUCCESS},
+			want:     wallets{merchant: 95, admin: 5},
+		},
+		{
+			name:     "override reverses a credited deposit",
+			txType:   txEntity.DEPOSIT,
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.SUCCESS, txEntity.FAILED},
+			want:     wallets{},
+		},
+		{
+			name:     "override credits a failed deposit once",
+			txType:   txEntity.DEPOSIT,
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.FAILED, txEntity.SUCCESS},
+			want:     wallets{merchant: 95, admin: 5},
+		},
+		{
+			name:     "override credits an expired deposit",
+			txType:   txEntity.DEPOSIT,
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.EXPIRED, txEntity.FAILED, txEntity.SUCCESS},
+			want:     wallets{merchant: 95, admin: 5},
+		},
+		{
+			name:     "withdrawal paid out",
+			txType:   txEntity.WITHDRAWAL,
+			opening:  wallets{merchant: 105, locked: 95},
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.SUCCESS},
+			want:     wallets{merchant: 105, admin: 5},
+		},
+		{
+			name:     "withdrawal returned on failure",
+			txType:   txEntity.WITHDRAWAL,
+			opening:  wallets{merchant: 105, locked: 95},
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.FAILED},
+			want:     wallets{merchant: 200},
+		},
+		{
+			name:     "override returns a paid withdrawal",
+			txType:   txEntity.WITHDRAWAL,
+			opening:  wallets{merchant: 105, locked: 95},
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.SUCCESS, txEntity.FAILED},
+			want:     wallets{merchant: 200},
+		},
+		{
+			name:     "override pays out a failed withdrawal",
+			txType:   txEntity.WITHDRAWAL,
+			opening:  wallets{merchant: 105, locked: 95},
+			statuses: []txEntity.TransactionStatus{txEntity.PENDING, txEntity.FAILED, txEntity.SUCCESS},
+			want:     wallets{merchant: 105, admin: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := &txEntity.Transaction{Id: uuid.New(), MerchantId: uuid.New(), Type: tt.txType}
+			got := tt.opening
+			for i := 1; i < len(tt.statuses); i++ {
+				got.apply(settlementMovements(txn, settlement, tt.statuses[i-1], tt.statuses[i]))
+			}
+			if got != tt.want {
+				t.Fatalf("wallets after %v = %+v, want %+v", tt.statuses, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettlementMovementsSkipsTestTransactions(t *testing.T) {
+	settlement := &fxEntity.Settlement{WalletCurrency: "ETB", MerchantAmount: 95, AdminCurrency: "ETB", AdminAmount: 5}
+	txn := &txEntity.Transaction{Id: uuid.New(), MerchantId: uuid.New(), Type: txEntity.DEPOSIT, Test: true}
+
+	if movements := settlementMovements(txn, settlement, txEntity.PENDING, txEntity.SUCCESS); len(movements) != 0 {
+		t.Fatalf("settlementMovements() = %+v, want none for a test transaction", movements)
+	}
+}
@@ -17,6 +17,8 @@ type WebhookUseCase interface {
 	UpdateCallbackLog(ctx context.Context, id uuid.UUID, responseBody string, responseStatus int) error
 	HandlePaymentStatusUpdate(ctx context.Context, msg webhookDto.WebhookMessage) error
 	HandleWebhookDispatch(ctx context.Context, req dto.WebhookRequest) error
+	// PublishMerchantEvent queues an event for delivery to the merchant callback URL
+	PublishMerchantEvent(ctx context.Context, event dto.WebhookEventMerchant) error
 	GetProducer() *producer.GroupedProducer
 	GetSendProducer() *producer.GroupedProducer
 	GetCallbackLogByID(ctx context.Context, id uuid.UUID) (*entity.CallbackLog, error)
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,11 +11,15 @@ import (
 
 	"github.com/google/uuid"
 	commission_usecase "github.com/socialpay/socialpay/src/pkg/commission/usecase"
+	customerVaultUsecase "github.com/socialpay/socialpay/src/pkg/customer_vault/usecase"
+	fxUsecase "github.com/socialpay/socialpay/src/pkg/fx/usecase"
 	tipService "github.com/socialpay/socialpay/src/pkg/socialpayapi/usecase"
 	notificationUsecase "github.com/socialpay/socialpay/src/pkg/notifications/usecase"
+	reserveUsecase "github.com/socialpay/socialpay/src/pkg/reserve/usecase"
 	"github.com/socialpay/socialpay/src/pkg/shared/logging"
 	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
 	transactionRepo "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	transactionUsecase "github.com/socialpay/socialpay/src/pkg/transaction/usecase"
 	walletUsecase "github.com/socialpay/socialpay/src/pkg/wallet/usecase"
 	webhookDto "github.com/socialpay/socialpay/src/pkg/webhook/adapter/dto"
 	"github.com/socialpay/socialpay/src/pkg/webhook/adapter/gateway/kafka/producer"
@@ -32,6 +37,10 @@ type WebhookUseCaseImpl struct {
 	sendProducer        *producer.GroupedProducer
 	tipService          tipService.TipProcessingService
 	transactionNotifier *notificationUsecase.TransactionNotifier
+	customerVault       customerVaultUsecase.CustomerVaultUseCase
+	statusTransitions   transactionUsecase.StatusTransitionUseCase
+	fx                  fxUsecase.FXUseCase
+	reserves            reserveUsecase.ReserveUseCase
 }
 
 func NewWebhookUseCase(
@@ -43,6 +52,10 @@ func NewWebhookUseCase(
 	commissionUseCase commission_usecase.CommissionUseCase,
 	tipService tipService.TipProcessingService,
 	transactionNotifier *notificationUsecase.TransactionNotifier,
+	customerVault customerVaultUsecase.CustomerVaultUseCase,
+	statusTransitions transactionUsecase.StatusTransitionUseCase,
+	fx fxUsecase.FXUseCase,
+	reserves reserveUsecase.ReserveUseCase,
 ) WebhookUseCase {
 	log := logging.NewStdLogger("[webhook]")
 	log.Info("initializing webhook use case", map[string]interface{}{
@@ -68,6 +81,10 @@ func NewWebhookUseCase(
 		sendProducer:        sendProducer,
 		tipService:          tipService,
 		transactionNotifier: transactionNotifier,
+		customerVault:       customerVault,
+		statusTransitions:   statusTransitions,
+		fx:                  fx,
+		reserves:            reserves,
 	}
 }
 
@@ -100,19 +117,20 @@ func (uc *WebhookUseCaseImpl) ProcessTransactionStatus(ctx context.Context, txnI
 		"targetState":  status,
 	})
 
-	if !isValidStatusTransition(txn.Status, status) {
-		uc.log.Error("invalid status transition", map[string]interface{}{
-			"from":  txn.Status,
-			"to":    status,
-			"txnID": txnID,
-		})
-		return fmt.Errorf("invalid status transition from %s to %s", txn.Status, status)
+	_, err = uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: txnID,
+		To:            status,
+		Source:        txEntity.StatusSourceProviderCallback,
+		Actor:         string(txn.Medium),
+	})
+	if errors.Is(err, txEntity.ErrStatusUnchanged) {
+		return nil
 	}
-
-	if err := uc.transactionRepo.UpdateStatus(ctx, txnID, status); err != nil {
+	if err != nil {
 		uc.log.Error("failed to update transaction status", map[string]interface{}{
 			"error":  err,
 			"txnID":  txnID,
+			"from":   txn.Status,
 			"status": status,
 		})
 		return fmt.Errorf("failed to update transaction status: %w", err)
@@ -160,14 +178,48 @@ func (uc *WebhookUseCaseImpl) HandlePaymentStatusUpdate(ctx context.Context, msg
 		"transactionID": msg.TransactionID,
 	})
 
-	// Update transaction status
-	// Update transaction status, comment, provider data, and provider TX ID
 	txnStatus := txEntity.TransactionStatus(msg.Status)
 
-	// Prepare update parameters
+	// Late or duplicate updates are dropped before they touch the transaction or the wallets
+	source, actor := msg.Source, msg.Actor
+	if source == "" {
+		source, actor = txEntity.StatusSourceProviderCallback, string(txn.Medium)
+	}
+	if txn.Status == txnStatus || !txEntity.CanTransitionAs(source, txn.Status, txnStatus) {
+		uc.log.Warn("ignoring payment status update", map[string]interface{}{
+			"transactionID": msg.TransactionID,
+			"currentStatus": txn.Status,
+			"status":        txnStatus,
+			"source":        source,
+		})
+		return nil
+	}
+
+	// Parse merchant ID
+	merchantID, err := uuid.Parse(msg.MerchantID)
+	if err != nil {
+		uc.log.Error("invalid merchant ID", map[string]interface{}{
+			"error":      err,
+			"merchantID": msg.MerchantID,
+		})
+		return fmt.Errorf("invalid merchant ID: %w", err)
+	}
+
+	// Parse user ID for validation
+	_, err = uuid.Parse(msg.UserID)
+	if err != nil {
+		uc.log.Error("invalid user ID", map[string]interface{}{
+			"error":  err,
+			"userID": msg.UserID,
+		})
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	// Update comment, provider data, and provider TX ID
 	updateParams := map[string]interface{}{
-		"comment":        msg.Message, // Message from STDCallbackRequest
-		"provider_tx_id": msg.ProviderTxID,
+		"comment":          msg.Message, // Message from STDCallbackRequest
+		"provider_tx_id":   msg.ProviderTxID,
+		"webhook_received": true,
 	}
 
 	// Marshal provider data
@@ -195,12 +247,38 @@ func (uc *WebhookUseCaseImpl) HandlePaymentStatusUpdate(ctx context.Context, msg
 		"providerTxID":  msg.ProviderTxID,
 	})
 
-	err = uc.transactionRepo.UpdateStatus(ctx, txn.Id, txnStatus)
+	uc.log.Info("Processing Wallet", map[string]interface{}{
+		"merchantID": merchantID,
+		"type":       txn.Type,
+		"status":     txnStatus,
+	})
+
+	// The status and the wallet movements it settles are written together, so a redelivery
+	// after a failure finds the transaction where it was and settles it again
+	settlement, err := uc.transition(ctx, txn, txEntity.StatusTransitionRequest{
+		From:            txn.Status,
+		To:              txnStatus,
+		Source:          source,
+		Actor:           actor,
+		Reason:          msg.Message,
+		ProviderPayload: msg.ProviderData,
+	})
+	if errors.Is(err, txEntity.ErrStatusUnchanged) || errors.Is(err, txEntity.ErrIllegalStatusTransition) {
+		uc.log.Warn("ignoring payment status update", map[string]interface{}{
+			"transactionID": msg.TransactionID,
+			"currentStatus": txn.Status,
+			"status":        txnStatus,
+			"source":        source,
+			"reason":        err.Error(),
+		})
+		return nil
+	}
 	if err != nil {
 		uc.log.Error("failed to update transaction status", map[string]interface{}{
 			"error": err,
 			"txnID": txn.Id,
 		})
+		return fmt.Errorf("failed to update transaction status: %w", err)
 	}
 
 	// Update the transaction object with the new status for subsequent operations
@@ -212,67 +290,24 @@ func (uc *WebhookUseCaseImpl) HandlePaymentStatusUpdate(ctx context.Context, msg
 		"txnID":     msg.TransactionID,
 	})
 
-	// Send SMS notifications for transaction status updates
-	uc.log.Info("sending transaction status notifications", map[string]interface{}{
-		"transactionID": msg.TransactionID,
-		"status":        txnStatus,
-	})
-
-	// Parse merchant ID
-	merchantID, err := uuid.Parse(msg.MerchantID)
-	if err != nil {
-		uc.log.Error("invalid merchant ID", map[string]interface{}{
-			"error":      err,
-			"merchantID": msg.MerchantID,
-		})
-		return fmt.Errorf("invalid merchant ID: %w", err)
-	}
-
-	// Parse user ID for validation
-	_, err = uuid.Parse(msg.UserID)
-	if err != nil {
-		uc.log.Error("invalid user ID", map[string]interface{}{
-			"error":  err,
-			"userID": msg.UserID,
+	if txn.Test {
+		// Sandbox transactions never move live money
+		uc.log.Info("Skipping wallet for test transaction", map[string]interface{}{
+			"transactionID": txn.Id,
 		})
-		return fmt.Errorf("invalid user ID: %w", err)
-	}
-
-	uc.log.Info("Processing Wallet", map[string]interface{}{
-		"merchantID": merchantID,
-		"type":       txn.Type,
-		"status":     txnStatus,
-	})
-
-	if txn.Type == txEntity.WITHDRAWAL {
-		// Process withdrawal transaction using transaction-safe methods
-		isSuccess := txnStatus == txEntity.SUCCESS
-		// FIXED: Include admin amount and remove separate admin wallet call
-		if err := uc.walletUsecase.ProcessTransactionStatus(ctx, merchantID, txn.MerchantNet, txn.AdminNet, isSuccess, true); err != nil {
-			uc.log.Error("failed to process withdrawal status", map[string]interface{}{
-				"error":      err,
-				"merchantID": msg.MerchantID,
-				"amount":     msg.TotalAmount,
-				"status":     txnStatus,
-			})
-			return fmt.Errorf("failed to process withdrawal status: %w", err)
+	} else if txn.Type == txEntity.WITHDRAWAL {
+		if txnStatus == txEntity.SUCCESS {
+			uc.recordSettlement(ctx, txn, settlement)
 		}
 	} else if txnStatus == txEntity.SUCCESS {
-		// Process deposit transaction using transaction-safe methods
-		// FIXED: Include admin amount and remove separate admin wallet call
-		uc.log.Info("Processing deposit begin", map[string]interface{}{
-			"merchantID": merchantID,
-			"amount":     txn.MerchantNet,
-			"status":     txnStatus,
-		})
-		if err := uc.walletUsecase.ProcessTransactionStatus(ctx, merchantID, txn.MerchantNet, txn.AdminNet, true, false); err != nil {
-			uc.log.Error("failed to process deposit status", map[string]interface{}{
-				"error":      err,
-				"merchantID": msg.MerchantID,
-				"amount":     txn.MerchantNet,
-				"status":     txnStatus,
+		if txn.SettlesToMerchantAccount() {
+			// Collected into the merchant's own processor account; SocialPay holds none of the funds
+			uc.log.Info("Skipping wallet credit for merchant account settlement", map[string]interface{}{
+				"transactionID": txn.Id,
 			})
-			return fmt.Errorf("failed to process deposit status: %w", err)
+		} else {
+			uc.recordSettlement(ctx, txn, settlement)
+			uc.reserveDeposit(ctx, txn, settlement.WalletCurrency, settlement.MerchantAmount)
 		}
 		uc.log.Info("Processing deposit after", map[string]interface{}{
 			"merchantID": merchantID,
@@ -292,12 +327,14 @@ func (uc *WebhookUseCaseImpl) HandlePaymentStatusUpdate(ctx context.Context, msg
 			uc.tipService.ProcessTipForTransaction(ctx, txn.Id)
 		}
 
-		if err := uc.transactionRepo.Update(ctx, txn); err != nil {
-			uc.log.Error("failed to update transaction with commission details", map[string]interface{}{
-				"error": err,
-				"txnID": txn.Id,
-			})
-			return fmt.Errorf("failed to update transaction with commission details: %w", err)
+		// Save the payer to the merchant's customer vault when they consented
+		if uc.customerVault != nil {
+			if err := uc.customerVault.SaveFromTransaction(ctx, txn, msg.ProviderData); err != nil {
+				uc.log.Error("failed to save customer from transaction", map[string]interface{}{
+					"error": err,
+					"txnID": txn.Id,
+				})
+			}
 		}
 	}
 
@@ -308,7 +345,11 @@ func (uc *WebhookUseCaseImpl) HandlePaymentStatusUpdate(ctx context.Context, msg
 		"wallet":        txn.MerchantId,
 	})
 
-	if uc.transactionNotifier != nil {
+	if txn.Test {
+		uc.log.Info("test transaction, skipping SMS notifications", map[string]interface{}{
+			"transactionID": msg.TransactionID,
+		})
+	} else if uc.transactionNotifier != nil {
 		if err := uc.transactionNotifier.NotifyTransactionStatus(ctx, txn, string(txnStatus)); err != nil {
 			uc.log.Error("failed to send transaction notifications", map[string]interface{}{
 				"error":         err,
@@ -346,6 +387,7 @@ func (uc *WebhookUseCaseImpl) HandlePaymentStatusUpdate(ctx context.Context, msg
 		Message:      msg.Message,
 		MerchantID:   msg.MerchantID,
 		UserID:       msg.UserID,
+		Test:         txn.Test,
 	}
 
 	bytes, err := json.Marshal(event)
@@ -368,6 +410,31 @@ func (uc *WebhookUseCaseImpl) HandlePaymentStatusUpdate(ctx context.Context, msg
 	return nil
 }
 
+// PublishMerchantEvent queues an event for the webhook sender, keyed by merchant for ordering
+func (uc *WebhookUseCaseImpl) PublishMerchantEvent(ctx context.Context, event webhookDto.WebhookEventMerchant) error {
+	if event.CallbackURL == "" {
+		uc.log.Info("no callback URL, skipping merchant event", map[string]interface{}{
+			"event":      event.Event,
+			"merchantID": event.MerchantID,
+		})
+		return nil
+	}
+
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	uc.sendProducer.Produce(event.MerchantID, bytes)
+
+	uc.log.Info("merchant event produced to Kafka", map[string]interface{}{
+		"event":      event.Event,
+		"merchantID": event.MerchantID,
+	})
+
+	return nil
+}
+
 func (uc *WebhookUseCaseImpl) HandleWebhookDispatch(ctx context.Context, req webhookDto.WebhookRequest) error {
 	uc.log.Info("handling webhook dispatch", map[string]interface{}{
 		"type":          req.Type,
@@ -412,10 +479,14 @@ func (uc *WebhookUseCaseImpl) HandleWebhookDispatch(ctx context.Context, req web
 		"merchant_id":      txn.MerchantId,
 		"callback_url":     txn.CallbackURL,
 		"isHostedCheckout": req.IsHostedCheckout,
+		"source":           req.Source,
+		"actor":            req.Actor,
 	}
 
-	// check status
-	if txn.Status != txEntity.PENDING && txn.Status != txEntity.INITIATED {
+	// check status; authorized card payments stay open until captured or voided, and only an
+	// admin override may correct a final status
+	if txn.Status != txEntity.PENDING && txn.Status != txEntity.INITIATED && txn.Status != txEntity.AUTHORIZED &&
+		req.Source != txEntity.StatusSourceAdminOverride {
 		uc.log.Info("transaction is already finalized: CLOSED", map[string]interface{}{
 			"transactionID":   req.TransactionID,
 			"EXISTING_STATUS": txn.Status,
@@ -662,19 +733,19 @@ func (uc *WebhookUseCaseImpl) OverrideTransactionStatus(ctx context.Context, txn
 		return fmt.Errorf("failed to get transaction: %w", err)
 	}
 
-	if txn.Status == txEntity.SUCCESS || txn.Status == txEntity.FAILED {
-		uc.log.Error("transaction is already finalized", map[string]interface{}{
-			"txnID":  txnID,
-			"status": txn.Status,
-		})
-		return fmt.Errorf("transaction is already finalized")
-	}
-
 	oldStatus := txn.Status
-	if err := uc.transactionRepo.UpdateStatus(ctx, txnID, newStatus); err != nil {
+	_, err = uc.transition(ctx, txn, txEntity.StatusTransitionRequest{
+		From:   oldStatus,
+		To:     newStatus,
+		Source: txEntity.StatusSourceAdminOverride,
+		Actor:  adminID,
+		Reason: reason,
+	})
+	if err != nil {
 		uc.log.Error("failed to update transaction status", map[string]interface{}{
 			"error":  err,
 			"txnID":  txnID,
+			"from":   oldStatus,
 			"status": newStatus,
 		})
 		return fmt.Errorf("failed to update transaction status: %w", err)
@@ -713,34 +784,3 @@ func (uc *WebhookUseCaseImpl) OverrideTransactionStatus(ctx context.Context, txn
 
 	return nil
 }
-
-// Helper functions
-func isValidStatusTransition(from, to txEntity.TransactionStatus) bool {
-	validTransitions := map[txEntity.TransactionStatus][]txEntity.TransactionStatus{
-		txEntity.PENDING: {
-			txEntity.INITIATED,
-			txEntity.EXPIRED,
-			txEntity.FAILED,
-		},
-		txEntity.INITIATED: {
-			txEntity.SUCCESS,
-			txEntity.FAILED,
-		},
-		txEntity.SUCCESS: {},
-		txEntity.FAILED:  {},
-		txEntity.EXPIRED: {},
-	}
-
-	validNextStates, exists := validTransitions[from]
-	if !exists {
-		return false
-	}
-
-	for _, validState := range validNextStates {
-		if validState == to {
-			return true
-		}
-	}
-
-	return false
-}
@@ -0,0 +1,301 @@
+package gin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	auth_entity "github.com/socialpay/socialpay/src/pkg/authv2/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	ginMiddleware "github.com/socialpay/socialpay/src/pkg/shared/middleware/gin"
+	"github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/withdrawal_approval/usecase"
+)
+
+type Handler struct {
+	approvalUseCase usecase.WithdrawalApprovalUseCase
+	log             logging.Logger
+	jwtMiddleware   gin.HandlerFunc
+	rbac            *ginMiddleware.RBACV2
+}
+
+func NewHandler(approvalUseCase usecase.WithdrawalApprovalUseCase, jwtMiddleware gin.HandlerFunc, rbac *ginMiddleware.RBACV2) *Handler {
+	return &Handler{
+		approvalUseCase: approvalUseCase,
+		log:             logging.NewStdLogger("withdrawal_approval_handler"),
+		jwtMiddleware:   jwtMiddleware,
+		rbac:            rbac,
+	}
+}
+
+// RegisterRouter sets up the payout policy and withdrawal approval routes
+func (h *Handler) RegisterRouter(router *gin.RouterGroup) {
+	approvals := router.Group("/withdrawal_approvals", h.jwtMiddleware, ginMiddleware.MerchantIDMiddleware())
+	approvals.GET("/policy",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WITHDRAWAL_APPROVAL, auth_entity.OPERATION_READ),
+		h.GetPolicy)
+	approvals.PUT("/policy",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WITHDRAWAL_APPROVAL, auth_entity.OPERATION_UPDATE),
+		h.UpdatePolicy)
+	approvals.GET("",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WITHDRAWAL_APPROVAL, auth_entity.OPERATION_READ),
+		h.ListApprovals)
+	approvals.GET("/:id",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WITHDRAWAL_APPROVAL, auth_entity.OPERATION_READ),
+		h.GetApproval)
+	approvals.POST("/:id/approve",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WITHDRAWAL_APPROVAL, auth_entity.OPERATION_APPROVE),
+		h.Approve)
+	approvals.POST("/:id/reject",
+		h.rbac.RequirePermissionForMerchant(auth_entity.RESOURCE_WITHDRAWAL_APPROVAL, auth_entity.OPERATION_APPROVE),
+		h.Reject)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Error:   "request_failed",
+		Message: err.Error(),
+	}
+}
+
+// GetPolicy godoc
+// @Summary      Get payout policy
+// @Description  Get the rules that hold withdrawals of the authenticated merchant for a second team member's approval
+// @Tags         Withdrawal Approvals
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  entity.Policy
+// @Failure      401  {object}  ErrorResponse
+// @Router       /withdrawal_approvals/policy [get]
+func (h *Handler) GetPolicy(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	policy, err := h.approvalUseCase.GetPolicy(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicy godoc
+// @Summary      Update payout policy
+// @Description  Replace the payout policy. When enabled, withdrawals above the amount threshold, to a phone number never paid out to, or requested outside business hours (EAT) are held in PENDING_APPROVAL with their funds locked until another team member approves or rejects them, or the approval window closes.
+// @Tags         Withdrawal Approvals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  entity.UpdatePolicyRequest  true  "Payout policy"
+// @Success      200  {object}  entity.Policy
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /withdrawal_approvals/policy [put]
+func (h *Handler) UpdatePolicy(c *gin.Context) {
+	merchantID, userID, ok := h.merchantAndUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdatePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	policy, err := h.approvalUseCase.UpdatePolicy(c.Request.Context(), merchantID, &req, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// ListApprovals godoc
+// @Summary      List withdrawal approvals
+// @Description  List the held withdrawals of the authenticated merchant, latest first
+// @Tags         Withdrawal Approvals
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query  string  false  "PENDING, APPROVED, REJECTED or EXPIRED"
+// @Param        limit   query  int     false  "Number of approvals (max 200)"
+// @Success      200  {object}  entity.ApprovalListResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /withdrawal_approvals [get]
+func (h *Handler) ListApprovals(c *gin.Context) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	response, err := h.approvalUseCase.ListApprovals(c.Request.Context(), merchantID, entity.ApprovalFilter{
+		Status: entity.ApprovalStatus(c.Query("status")),
+		Limit:  limit,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetApproval godoc
+// @Summary      Get withdrawal approval
+// @Description  Get a held withdrawal with the rules it triggered and the decision taken on it
+// @Tags         Withdrawal Approvals
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Approval ID"
+// @Success      200  {object}  entity.Approval
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /withdrawal_approvals/{id} [get]
+func (h *Handler) GetApproval(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid approval ID")))
+		return
+	}
+
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return
+	}
+
+	approval, err := h.approvalUseCase.GetApproval(c.Request.Context(), merchantID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approval)
+}
+
+// Approve godoc
+// @Summary      Approve a held withdrawal
+// @Description  Approve a withdrawal held by the payout policy and send it to its processor. The team member who requested the withdrawal cannot approve it.
+// @Tags         Withdrawal Approvals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true   "Approval ID"
+// @Param        request  body  entity.DecisionRequest  false  "Decision note"
+// @Success      200  {object}  entity.Approval
+// @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /withdrawal_approvals/{id}/approve [post]
+func (h *Handler) Approve(c *gin.Context) {
+	h.decide(c, h.approvalUseCase.Approve)
+}
+
+// Reject godoc
+// @Summary      Reject a held withdrawal
+// @Description  Reject a withdrawal held by the payout policy. The withdrawal is canceled and its funds unlocked. The team member who requested the withdrawal cannot reject it.
+// @Tags         Withdrawal Approvals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true   "Approval ID"
+// @Param        request  body  entity.DecisionRequest  false  "Decision note"
+// @Success      200  {object}  entity.Approval
+// @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /withdrawal_approvals/{id}/reject [post]
+func (h *Handler) Reject(c *gin.Context) {
+	h.decide(c, h.approvalUseCase.Reject)
+}
+
+type decideFunc func(ctx context.Context, merchantID, id, approverID uuid.UUID, req *entity.DecisionRequest) (*entity.Approval, error)
+
+func (h *Handler) decide(c *gin.Context, decide decideFunc) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(fmt.Errorf("invalid approval ID")))
+		return
+	}
+
+	merchantID, userID, ok := h.merchantAndUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.DecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, newErrorResponse(err))
+			return
+		}
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, newErrorResponse(err))
+		return
+	}
+
+	approval, err := decide(c.Request.Context(), merchantID, id, userID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approval)
+}
+
+func (h *Handler) merchantAndUser(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	merchantID, exists := ginMiddleware.GetMerchantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("merchant not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, exists := ginMiddleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(fmt.Errorf("user not authenticated")))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return merchantID, userID, true
+}
+
+func (h *Handler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrApprovalNotFound):
+		c.JSON(http.StatusNotFound, newErrorResponse(err))
+	case errors.Is(err, entity.ErrSelfApproval):
+		c.JSON(http.StatusForbidden, newErrorResponse(err))
+	case errors.Is(err, entity.ErrApprovalDecided), errors.Is(err, entity.ErrApprovalExpired):
+		c.JSON(http.StatusConflict, newErrorResponse(err))
+	default:
+		h.log.Error("Withdrawal approval request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, newErrorResponse(err))
+	}
+}
@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+)
+
+var (
+	// ErrApprovalNotFound is returned when a merchant has no approval with the given ID
+	ErrApprovalNotFound = errors.New("withdrawal approval not found")
+	// ErrApprovalDecided is returned when an approval was already approved, rejected or expired
+	ErrApprovalDecided = errors.New("withdrawal approval has already been decided")
+	// ErrApprovalExpired is returned when an approver decides after the approval window closed
+	ErrApprovalExpired = errors.New("withdrawal approval has expired")
+	// ErrSelfApproval is returned when the team member who requested a withdrawal tries to decide on it
+	ErrSelfApproval = errors.New("a withdrawal must be decided by a different team member than the one who requested it")
+)
+
+const (
+	// DefaultApprovalTTLHours is how long a held withdrawal waits for a decision unless the policy says otherwise
+	DefaultApprovalTTLHours = 24
+	// MaxApprovalTTLHours bounds how long funds can stay locked awaiting a decision
+	MaxApprovalTTLHours = 7 * 24
+)
+
+// BusinessZone is the time zone business hours are set in
+var BusinessZone = time.FixedZone("EAT", 3*60*60)
+
+// ApprovalStatus is the state of a held withdrawal
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "PENDING"
+	ApprovalApproved ApprovalStatus = "APPROVED"
+	ApprovalRejected ApprovalStatus = "REJECTED"
+	ApprovalExpired  ApprovalStatus = "EXPIRED"
+)
+
+// HoldReason is a payout policy rule a withdrawal triggered
+type HoldReason string
+
+const (
+	ReasonAmountThreshold HoldReason = "AMOUNT_THRESHOLD"
+	ReasonNewBeneficiary  HoldReason = "NEW_BENEFICIARY"
+	ReasonOffHours        HoldReason = "OFF_HOURS"
+)
+
+// Policy is a merchant's payout policy. When enabled, withdrawals that trigger any of its rules
+// are held in PENDING_APPROVAL until a second team member approves or rejects them.
+type Policy struct {
+	MerchantID uuid.UUID `json:"merchant_id"`
+	Enabled    bool      `json:"enabled"`
+
+	// AmountThreshold holds withdrawals above the amount, in the withdrawal currency
+	AmountThreshold *float64 `json:"amount_threshold,omitempty"`
+	// HoldNewBeneficiaries holds withdrawals to a phone number the merchant has never paid out to
+	HoldNewBeneficiaries bool `json:"hold_new_beneficiaries"`
+	// BusinessHoursStart and BusinessHoursEnd (hours of the day in EAT, end exclusive) hold
+	// withdrawals requested outside them; both unset means no off-hours rule
+	BusinessHoursStart *int `json:"business_hours_start,omitempty"`
+	BusinessHoursEnd   *int `json:"business_hours_end,omitempty"`
+	// HoldWeekends treats Saturday and Sunday as off-hours
+	HoldWeekends bool `json:"hold_weekends"`
+
+	ApprovalTTLHours int `json:"approval_ttl_hours"`
+
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// DefaultPolicy is the policy of a merchant that has not set one: every withdrawal goes through
+func DefaultPolicy(merchantID uuid.UUID) *Policy {
+	return &Policy{
+		MerchantID:       merchantID,
+		ApprovalTTLHours: DefaultApprovalTTLHours,
+	}
+}
+
+// Evaluate returns the rules a withdrawal triggers, none when it can go straight to the processor
+func (p *Policy) Evaluate(amount float64, newBeneficiary bool, at time.Time) []HoldReason {
+	if !p.Enabled {
+		return nil
+	}
+
+	var reasons []HoldReason
+	if p.AmountThreshold != nil && amount > *p.AmountThreshold {
+		reasons = append(reasons, ReasonAmountThreshold)
+	}
+	if p.HoldNewBeneficiaries && newBeneficiary {
+		reasons = append(reasons, ReasonNewBeneficiary)
+	}
+	if p.offHours(at) {
+		reasons = append(reasons, ReasonOffHours)
+	}
+	return reasons
+}
+
+// offHours reports whether a time falls outside the policy's business hours
+func (p *Policy) offHours(at time.Time) bool {
+	local := at.In(BusinessZone)
+	if p.HoldWeekends && (local.Weekday() == time.Saturday || local.Weekday() == time.Sunday) {
+		return true
+	}
+	if p.BusinessHoursStart == nil || p.BusinessHoursEnd == nil {
+		return false
+	}
+
+	start, end, hour := *p.BusinessHoursStart, *p.BusinessHoursEnd, local.Hour()
+	if start <= end {
+		return hour < start || hour >= end
+	}
+	// Business hours past midnight, e.g. 20 to 4
+	return hour < start && hour >= end
+}
+
+// Approval is a withdrawal held by the payout policy and the decision taken on it
+type Approval struct {
+	ID            uuid.UUID                  `json:"id"`
+	TransactionID uuid.UUID                  `json:"transaction_id"`
+	MerchantID    uuid.UUID                  `json:"merchant_id"`
+	Amount        float64                    `json:"amount"`
+	Currency      string                     `json:"currency"`
+	Medium        txEntity.TransactionMedium `json:"medium"`
+	PhoneNumber   string                     `json:"phone_number"`
+	Reasons       []HoldReason               `json:"reasons"`
+	Status        ApprovalStatus             `json:"status"`
+	RequestedBy   uuid.UUID                  `json:"requested_by"`
+	DecidedBy     *uuid.UUID                 `json:"decided_by,omitempty"`
+	DecisionNote  string                     `json:"decision_note,omitempty"`
+	DecidedAt     *time.Time                 `json:"decided_at,omitempty"`
+	ExpiresAt     time.Time                  `json:"expires_at"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+// Decidable reports why an approver cannot decide on the approval, if they cannot
+func (a *Approval) Decidable(approverID uuid.UUID, now time.Time) error {
+	if a.Status != ApprovalPending {
+		return ErrApprovalDecided
+	}
+	if !now.Before(a.ExpiresAt) {
+		return ErrApprovalExpired
+	}
+	if approverID == a.RequestedBy {
+		return ErrSelfApproval
+	}
+	return nil
+}
+
+// UpdatePolicyRequest replaces a merchant's payout policy
+// @Description Payout policy of a merchant
+type UpdatePolicyRequest struct {
+	Enabled              bool     `json:"enabled" example:"true"`
+	AmountThreshold      *float64 `json:"amount_threshold,omitempty" example:"50000"`
+	HoldNewBeneficiaries bool     `json:"hold_new_beneficiaries" example:"true"`
+	BusinessHoursStart   *int     `json:"business_hours_start,omitempty" example:"8"`
+	BusinessHoursEnd     *int     `json:"business_hours_end,omitempty" example:"18"`
+	HoldWeekends         bool     `json:"hold_weekends" example:"false"`
+	// Hours a held withdrawal waits for a decision, 24 by default
+	ApprovalTTLHours int `json:"approval_ttl_hours,omitempty" example:"24"`
+}
+
+// Validate validates the payout policy
+func (r *UpdatePolicyRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.AmountThreshold, validation.Min(0.0)),
+		validation.Field(&r.BusinessHoursStart, validation.Min(0), validation.Max(23)),
+		validation.Field(&r.BusinessHoursEnd, validation.Min(0), validation.Max(24)),
+		validation.Field(&r.ApprovalTTLHours, validation.Min(0), validation.Max(MaxApprovalTTLHours)),
+	); err != nil {
+		return err
+	}
+
+	if (r.BusinessHoursStart == nil) != (r.BusinessHoursEnd == nil) {
+		return errors.New("business_hours_start and business_hours_end must be set together")
+	}
+	if r.BusinessHoursStart != nil && *r.BusinessHoursStart == *r.BusinessHoursEnd {
+		return errors.New("business hours must not be empty")
+	}
+	return nil
+}
+
+// DecisionRequest approves or rejects a held withdrawal
+// @Description Decision on a held withdrawal
+type DecisionRequest struct {
+	Note string `json:"note,omitempty" example:"Confirmed with the finance lead"`
+}
+
+// Validate validates the decision
+func (r *DecisionRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Note, validation.Length(0, 500)),
+	)
+}
+
+// ApprovalFilter narrows the approvals of a merchant
+type ApprovalFilter struct {
+	Status ApprovalStatus
+	Limit  int
+}
+
+// ApprovalListResponse lists the held withdrawals of a merchant
+// @Description Held withdrawals of a merchant, latest first
+type ApprovalListResponse struct {
+	Approvals []Approval `json:"approvals"`
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestPolicyEvaluate(t *testing.T) {
+	threshold := 50000.0
+	policy := &Policy{
+		Enabled:              true,
+		AmountThreshold:      &threshold,
+		HoldNewBeneficiaries: true,
+		BusinessHoursStart:   intPtr(8),
+		BusinessHoursEnd:     intPtr(18),
+		HoldWeekends:         true,
+	}
+
+	// Monday 10:00 EAT
+	workday := time.Date(2026, 5, 4, 7, 0, 0, 0, time.UTC)
+
+	t.Run("ordinary withdrawal goes through", func(t *testing.T) {
+		if reasons := policy.Evaluate(1000, false, workday); len(reasons) != 0 {
+			t.Fatalf("reasons = %v, want none", reasons)
+		}
+	})
+
+	t.Run("every triggered rule is reported", func(t *testing.T) {
+		// Monday 20:00 EAT
+		evening := time.Date(2026, 5, 4, 17, 0, 0, 0, time.UTC)
+		reasons := policy.Evaluate(75000, true, evening)
+		want := []HoldReason{ReasonAmountThreshold, ReasonNewBeneficiary, ReasonOffHours}
+		if len(reasons) != len(want) {
+			t.Fatalf("reasons = %v, want %v", reasons, want)
+		}
+		for i := range want {
+			if reasons[i] != want[i] {
+				t.Fatalf("reasons = %v, want %v", reasons, want)
+			}
+		}
+	})
+
+	t.Run("weekends are off-hours", func(t *testing.T) {
+		// Saturday 10:00 EAT
+		saturday := time.Date(2026, 5, 9, 7, 0, 0, 0, time.UTC)
+		reasons := policy.Evaluate(1000, false, saturday)
+		if len(reasons) != 1 || reasons[0] != ReasonOffHours {
+			t.Fatalf("reasons = %v, want [OFF_HOURS]", reasons)
+		}
+	})
+
+	t.Run("business hours past midnight", func(t *testing.T) {
+		night := &Policy{Enabled: true, BusinessHoursStart: intPtr(20), BusinessHoursEnd: intPtr(4)}
+		for hour, off := range map[int]bool{19: true, 20: false, 23: false, 3: false, 4: true, 12: true} {
+			at := time.Date(2026, 5, 4, hour, 0, 0, 0, BusinessZone)
+			if got := night.offHours(at); got != off {
+				t.Fatalf("offHours at %02d:00 = %v, want %v", hour, got, off)
+			}
+		}
+	})
+
+	t.Run("disabled policy holds nothing", func(t *testing.T) {
+		disabled := *policy
+		disabled.Enabled = false
+		if reasons := disabled.Evaluate(75000, true, workday); len(reasons) != 0 {
+			t.Fatalf("reasons = %v, want none", reasons)
+		}
+	})
+}
+
+func TestApprovalDecidable(t *testing.T) {
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	requester, approver := uuid.New(), uuid.New()
+	approval := &Approval{Status: ApprovalPending, RequestedBy: requester, ExpiresAt: now.Add(time.Hour)}
+
+	if err := approval.Decidable(approver, now); err != nil {
+		t.Fatalf("Decidable() = %v, want nil", err)
+	}
+	if err := approval.Decidable(requester, now); !errors.Is(err, ErrSelfApproval) {
+		t.Fatalf("Decidable() by requester = %v, want ErrSelfApproval", err)
+	}
+	if err := approval.Decidable(approver, now.Add(time.Hour)); !errors.Is(err, ErrApprovalExpired) {
+		t.Fatalf("Decidable() after expiry = %v, want ErrApprovalExpired", err)
+	}
+
+	decided := *approval
+	decided.Status = ApprovalRejected
+	if err := decided.Decidable(approver, now); !errors.Is(err, ErrApprovalDecided) {
+		t.Fatalf("Decidable() on decided approval = %v, want ErrApprovalDecided", err)
+	}
+}
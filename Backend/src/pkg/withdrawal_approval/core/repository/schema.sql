@@ -0,0 +1,37 @@
+-- Withdrawal Approval Schema
+-- This should match the migration exactly
+
+CREATE TABLE IF NOT EXISTS public.withdrawal_policies (
+    merchant_id UUID PRIMARY KEY REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    amount_threshold DECIMAL(20,2),
+    hold_new_beneficiaries BOOLEAN NOT NULL DEFAULT false,
+    -- Hours of the day in EAT, end exclusive; both NULL when there is no off-hours rule
+    business_hours_start SMALLINT CHECK (business_hours_start BETWEEN 0 AND 23),
+    business_hours_end SMALLINT CHECK (business_hours_end BETWEEN 0 AND 24),
+    hold_weekends BOOLEAN NOT NULL DEFAULT false,
+    approval_ttl_hours INTEGER NOT NULL DEFAULT 24,
+    updated_by UUID,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS public.withdrawal_approvals (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL UNIQUE REFERENCES public.transactions(id) ON DELETE CASCADE,
+    merchant_id UUID NOT NULL REFERENCES merchants.merchants(id) ON DELETE CASCADE,
+    amount DECIMAL(20,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'ETB',
+    medium VARCHAR(50) NOT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    reasons TEXT[] NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    requested_by UUID NOT NULL,
+    decided_by UUID,
+    decision_note TEXT,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_withdrawal_approvals_merchant ON public.withdrawal_approvals(merchant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_withdrawal_approvals_expiry ON public.withdrawal_approvals(expires_at) WHERE status = 'PENDING';
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/entity"
+)
+
+// WithdrawalApprovalRepository defines the interface for payout policies and held withdrawals
+type WithdrawalApprovalRepository interface {
+	// GetPolicy retrieves the payout policy of a merchant, nil when the merchant has not set one
+	GetPolicy(ctx context.Context, merchantID uuid.UUID) (*entity.Policy, error)
+
+	// SavePolicy creates or replaces the payout policy of a merchant
+	SavePolicy(ctx context.Context, policy *entity.Policy) (*entity.Policy, error)
+
+	// HasPaidBeneficiary reports whether the merchant has made a successful withdrawal to the phone number through the medium
+	HasPaidBeneficiary(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, phoneNumber string, test bool) (bool, error)
+
+	// CreateApproval stores a held withdrawal
+	CreateApproval(ctx context.Context, approval *entity.Approval) error
+
+	// GetApproval retrieves an approval of a merchant, nil when none exists
+	GetApproval(ctx context.Context, merchantID, id uuid.UUID) (*entity.Approval, error)
+
+	// GetApprovalByTransactionID retrieves the approval of a withdrawal, nil when it was never held
+	GetApprovalByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Approval, error)
+
+	// ListApprovals retrieves the approvals of a merchant, latest first
+	ListApprovals(ctx context.Context, merchantID uuid.UUID, filter entity.ApprovalFilter) ([]entity.Approval, error)
+
+	// ListExpired retrieves pending approvals whose window closed before the given time
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]entity.Approval, error)
+
+	// Decide records the decision on a pending approval. It returns entity.ErrApprovalDecided
+	// when the approval is no longer pending, so only one decision is ever taken.
+	Decide(ctx context.Context, approval *entity.Approval) error
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/entity"
+)
+
+const policyColumns = `merchant_id, enabled, amount_threshold, hold_new_beneficiaries, business_hours_start, business_hours_end, hold_weekends, approval_ttl_hours, updated_by, updated_at`
+
+const approvalColumns = `id, transaction_id, merchant_id, amount, currency, medium, phone_number, reasons, status, requested_by, decided_by, decision_note, decided_at, expires_at, created_at`
+
+type WithdrawalApprovalRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewWithdrawalApprovalRepository(db *sql.DB) WithdrawalApprovalRepository {
+	return &WithdrawalApprovalRepositoryImpl{db: db}
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) GetPolicy(ctx context.Context, merchantID uuid.UUID) (*entity.Policy, error) {
+	query := `SELECT ` + policyColumns + ` FROM public.withdrawal_policies WHERE merchant_id = $1`
+
+	policy, err := scanPolicy(r.db.QueryRowContext(ctx, query, merchantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get withdrawal policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) SavePolicy(ctx context.Context, policy *entity.Policy) (*entity.Policy, error) {
+	query := `
+		INSERT INTO public.withdrawal_policies (
+			merchant_id, enabled, amount_threshold, hold_new_beneficiaries, business_hours_start,
+			business_hours_end, hold_weekends, approval_ttl_hours, updated_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (merchant_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled,
+			amount_threshold = EXCLUDED.amount_threshold,
+			hold_new_beneficiaries = EXCLUDED.hold_new_beneficiaries,
+			business_hours_start = EXCLUDED.business_hours_start,
+			business_hours_end = EXCLUDED.business_hours_end,
+			hold_weekends = EXCLUDED.hold_weekends,
+			approval_ttl_hours = EXCLUDED.approval_ttl_hours,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING ` + policyColumns
+
+	saved, err := scanPolicy(r.db.QueryRowContext(ctx, query,
+		policy.MerchantID, policy.Enabled, policy.AmountThreshold, policy.HoldNewBeneficiaries,
+		policy.BusinessHoursStart, policy.BusinessHoursEnd, policy.HoldWeekends, policy.ApprovalTTLHours,
+		policy.UpdatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save withdrawal policy: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) HasPaidBeneficiary(ctx context.Context, merchantID uuid.UUID, medium txEntity.TransactionMedium, phoneNumber string, test bool) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM public.transactions
+			WHERE merchant_id = $1 AND type = 'WITHDRAWAL' AND status = 'SUCCESS'
+				AND medium = $2 AND phone_number = $3 AND COALESCE(test, false) = $4
+		)`
+
+	var paid bool
+	if err := r.db.QueryRowContext(ctx, query, merchantID, string(medium), phoneNumber, test).Scan(&paid); err != nil {
+		return false, fmt.Errorf("failed to check withdrawal beneficiary: %w", err)
+	}
+
+	return paid, nil
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) CreateApproval(ctx context.Context, approval *entity.Approval) error {
+	query := `
+		INSERT INTO public.withdrawal_approvals (` + approvalColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		approval.ID, approval.TransactionID, approval.MerchantID, approval.Amount, approval.Currency,
+		string(approval.Medium), approval.PhoneNumber, pq.Array(reasonStrings(approval.Reasons)),
+		string(approval.Status), approval.RequestedBy, approval.DecidedBy, nullString(approval.DecisionNote),
+		approval.DecidedAt, approval.ExpiresAt, approval.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create withdrawal approval: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) GetApproval(ctx context.Context, merchantID, id uuid.UUID) (*entity.Approval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM public.withdrawal_approvals WHERE merchant_id = $1 AND id = $2`
+
+	approval, err := scanApproval(r.db.QueryRowContext(ctx, query, merchantID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get withdrawal approval: %w", err)
+	}
+
+	return approval, nil
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) GetApprovalByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Approval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM public.withdrawal_approvals WHERE transaction_id = $1`
+
+	approval, err := scanApproval(r.db.QueryRowContext(ctx, query, transactionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get withdrawal approval: %w", err)
+	}
+
+	return approval, nil
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) ListApprovals(ctx context.Context, merchantID uuid.UUID, filter entity.ApprovalFilter) ([]entity.Approval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM public.withdrawal_approvals
+		WHERE merchant_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID, string(filter.Status), filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list withdrawal approvals: %w", err)
+	}
+	defer rows.Close()
+
+	return scanApprovals(rows)
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) ListExpired(ctx context.Context, before time.Time, limit int) ([]entity.Approval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM public.withdrawal_approvals
+		WHERE status = 'PENDING' AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired withdrawal approvals: %w", err)
+	}
+	defer rows.Close()
+
+	return scanApprovals(rows)
+}
+
+func (r *WithdrawalApprovalRepositoryImpl) Decide(ctx context.Context, approval *entity.Approval) error {
+	query := `
+		UPDATE public.withdrawal_approvals
+		SET status = $2,
+			decided_by = $3,
+			decision_note = $4,
+			decided_at = $5
+		WHERE id = $1 AND status = 'PENDING'`
+
+	result, err := r.db.ExecContext(ctx, query,
+		approval.ID, string(approval.Status), approval.DecidedBy, nullString(approval.DecisionNote), approval.DecidedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to decide withdrawal approval: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to decide withdrawal approval: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrApprovalDecided
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPolicy(row rowScanner) (*entity.Policy, error) {
+	var policy entity.Policy
+	var threshold sql.NullFloat64
+	var hoursStart, hoursEnd sql.NullInt32
+	var updatedBy uuid.NullUUID
+	var updatedAt time.Time
+
+	err := row.Scan(
+		&policy.MerchantID,
+		&policy.Enabled,
+		&threshold,
+		&policy.HoldNewBeneficiaries,
+		&hoursStart,
+		&hoursEnd,
+		&policy.HoldWeekends,
+		&policy.ApprovalTTLHours,
+		&updatedBy,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if threshold.Valid {
+		policy.AmountThreshold = &threshold.Float64
+	}
+	if hoursStart.Valid && hoursEnd.Valid {
+		start, end := int(hoursStart.Int32), int(hoursEnd.Int32)
+		policy.BusinessHoursStart = &start
+		policy.BusinessHoursEnd = &end
+	}
+	if updatedBy.Valid {
+		policy.UpdatedBy = &updatedBy.UUID
+	}
+	policy.UpdatedAt = &updatedAt
+
+	return &policy, nil
+}
+
+func scanApprovals(rows *sql.Rows) ([]entity.Approval, error) {
+	var approvals []entity.Approval
+	for rows.Next() {
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan withdrawal approval: %w", err)
+		}
+		approvals = append(approvals, *approval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list withdrawal approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+func scanApproval(row rowScanner) (*entity.Approval, error) {
+	var approval entity.Approval
+	var medium, status string
+	var reasons []string
+	var decidedBy uuid.NullUUID
+	var note sql.NullString
+	var decidedAt sql.NullTime
+
+	err := row.Scan(
+		&approval.ID,
+		&approval.TransactionID,
+		&approval.MerchantID,
+		&approval.Amount,
+		&approval.Currency,
+		&medium,
+		&approval.PhoneNumber,
+		pq.Array(&reasons),
+		&status,
+		&approval.RequestedBy,
+		&decidedBy,
+		&note,
+		&decidedAt,
+		&approval.ExpiresAt,
+		&approval.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	approval.Medium = txEntity.TransactionMedium(medium)
+	approval.Status = entity.ApprovalStatus(status)
+	for _, reason := range reasons {
+		approval.Reasons = append(approval.Reasons, entity.HoldReason(reason))
+	}
+	if decidedBy.Valid {
+		approval.DecidedBy = &decidedBy.UUID
+	}
+	approval.DecisionNote = note.String
+	if decidedAt.Valid {
+		approval.DecidedAt = &decidedAt.Time
+	}
+
+	return &approval, nil
+}
+
+func reasonStrings(reasons []entity.HoldReason) []string {
+	values := make([]string, len(reasons))
+	for i, reason := range reasons {
+		values[i] = string(reason)
+	}
+	return values
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
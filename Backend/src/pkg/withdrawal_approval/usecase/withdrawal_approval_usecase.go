@@ -0,0 +1,435 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/socialpay/socialpay/src/pkg/shared/logging"
+	txEntity "github.com/socialpay/socialpay/src/pkg/transaction/core/entity"
+	txRepository "github.com/socialpay/socialpay/src/pkg/transaction/core/repository"
+	txUsecase "github.com/socialpay/socialpay/src/pkg/transaction/usecase"
+	"github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/entity"
+	"github.com/socialpay/socialpay/src/pkg/withdrawal_approval/core/repository"
+)
+
+const (
+	defaultApprovalListLimit = 50
+	maxApprovalListLimit     = 200
+	expirySweepBatch         = 100
+)
+
+// WithdrawalExecutor carries out decisions on held withdrawals. The payment usecase implements it,
+// and is set after construction because it depends on this usecase.
+type WithdrawalExecutor interface {
+	// ExecuteApprovedWithdrawal sends an approved withdrawal to its processor
+	ExecuteApprovedWithdrawal(ctx context.Context, transactionID uuid.UUID) error
+
+	// ReleaseHeldWithdrawal closes a rejected or expired withdrawal and unlocks its funds
+	ReleaseHeldWithdrawal(ctx context.Context, transactionID uuid.UUID, status txEntity.TransactionStatus, actor, reason string) error
+}
+
+// Notifier tells merchants about held withdrawals and the decisions taken on them
+type Notifier interface {
+	NotifyWithdrawalApproval(ctx context.Context, transaction *txEntity.Transaction, status string) error
+}
+
+// WithdrawalApprovalUseCase defines the interface for payout policies and the maker-checker flow of withdrawals
+type WithdrawalApprovalUseCase interface {
+	// GetPolicy retrieves the payout policy of a merchant, the default policy when none was set
+	GetPolicy(ctx context.Context, merchantID uuid.UUID) (*entity.Policy, error)
+
+	// UpdatePolicy replaces the payout policy of a merchant
+	UpdatePolicy(ctx context.Context, merchantID uuid.UUID, req *entity.UpdatePolicyRequest, userID uuid.UUID) (*entity.Policy, error)
+
+	// Evaluate returns the payout policy rules a withdrawal triggers, none when it can be sent straight away
+	Evaluate(ctx context.Context, tx *txEntity.Transaction) ([]entity.HoldReason, error)
+
+	// Hold moves a stored withdrawal to PENDING_APPROVAL and opens its approval
+	Hold(ctx context.Context, tx *txEntity.Transaction, reasons []entity.HoldReason, requestedBy uuid.UUID) (*entity.Approval, error)
+
+	// GetApproval retrieves an approval of a merchant
+	GetApproval(ctx context.Context, merchantID, id uuid.UUID) (*entity.Approval, error)
+
+	// GetApprovalByTransactionID retrieves the approval of a withdrawal, nil when it was never held
+	GetApprovalByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Approval, error)
+
+	// ListApprovals retrieves the approvals of a merchant, latest first
+	ListApprovals(ctx context.Context, merchantID uuid.UUID, filter entity.ApprovalFilter) (*entity.ApprovalListResponse, error)
+
+	// Approve releases a held withdrawal to its processor
+	Approve(ctx context.Context, merchantID, id, approverID uuid.UUID, req *entity.DecisionRequest) (*entity.Approval, error)
+
+	// Reject cancels a held withdrawal and unlocks its funds
+	Reject(ctx context.Context, merchantID, id, approverID uuid.UUID, req *entity.DecisionRequest) (*entity.Approval, error)
+
+	// ExpireOverdue expires approvals nobody decided on in time and unlocks their funds
+	ExpireOverdue(ctx context.Context) error
+
+	// SetExecutor sets who carries out the decisions
+	SetExecutor(executor WithdrawalExecutor)
+}
+
+type withdrawalApprovalUseCase struct {
+	repo              repository.WithdrawalApprovalRepository
+	transactionRepo   txRepository.TransactionRepository
+	statusTransitions txUsecase.StatusTransitionUseCase
+	notifier          Notifier
+	executor          WithdrawalExecutor
+	log               logging.Logger
+}
+
+// NewWithdrawalApprovalUseCase creates the withdrawal approval usecase
+func NewWithdrawalApprovalUseCase(
+	repo repository.WithdrawalApprovalRepository,
+	transactionRepo txRepository.TransactionRepository,
+	statusTransitions txUsecase.StatusTransitionUseCase,
+	notifier Notifier,
+) WithdrawalApprovalUseCase {
+	return &withdrawalApprovalUseCase{
+		repo:              repo,
+		transactionRepo:   transactionRepo,
+		statusTransitions: statusTransitions,
+		notifier:          notifier,
+		log:               logging.NewStdLogger("withdrawal_approval_usecase"),
+	}
+}
+
+func (uc *withdrawalApprovalUseCase) SetExecutor(executor WithdrawalExecutor) {
+	uc.executor = executor
+}
+
+func (uc *withdrawalApprovalUseCase) GetPolicy(ctx context.Context, merchantID uuid.UUID) (*entity.Policy, error) {
+	policy, err := uc.repo.GetPolicy(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return entity.DefaultPolicy(merchantID), nil
+	}
+	return policy, nil
+}
+
+func (uc *withdrawalApprovalUseCase) UpdatePolicy(ctx context.Context, merchantID uuid.UUID, req *entity.UpdatePolicyRequest, userID uuid.UUID) (*entity.Policy, error) {
+	ttl := req.ApprovalTTLHours
+	if ttl == 0 {
+		ttl = entity.DefaultApprovalTTLHours
+	}
+
+	policy, err := uc.repo.SavePolicy(ctx, &entity.Policy{
+		MerchantID:           merchantID,
+		Enabled:              req.Enabled,
+		AmountThreshold:      req.AmountThreshold,
+		HoldNewBeneficiaries: req.HoldNewBeneficiaries,
+		BusinessHoursStart:   req.BusinessHoursStart,
+		BusinessHoursEnd:     req.BusinessHoursEnd,
+		HoldWeekends:         req.HoldWeekends,
+		ApprovalTTLHours:     ttl,
+		UpdatedBy:            &userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Withdrawal policy updated", map[string]interface{}{
+		"merchant_id":            merchantID,
+		"enabled":                policy.Enabled,
+		"amount_threshold":       policy.AmountThreshold,
+		"hold_new_beneficiaries": policy.HoldNewBeneficiaries,
+		"business_hours_start":   policy.BusinessHoursStart,
+		"business_hours_end":     policy.BusinessHoursEnd,
+		"hold_weekends":          policy.HoldWeekends,
+		"approval_ttl_hours":     policy.ApprovalTTLHours,
+		"updated_by":             userID,
+	})
+
+	return policy, nil
+}
+
+func (uc *withdrawalApprovalUseCase) Evaluate(ctx context.Context, tx *txEntity.Transaction) ([]entity.HoldReason, error) {
+	policy, err := uc.GetPolicy(ctx, tx.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Enabled {
+		return nil, nil
+	}
+
+	newBeneficiary := false
+	if policy.HoldNewBeneficiaries {
+		paid, err := uc.repo.HasPaidBeneficiary(ctx, tx.MerchantId, tx.Medium, tx.PhoneNumber, tx.Test)
+		if err != nil {
+			return nil, err
+		}
+		newBeneficiary = !paid
+	}
+
+	return policy.Evaluate(tx.BaseAmount, newBeneficiary, time.Now()), nil
+}
+
+func (uc *withdrawalApprovalUseCase) Hold(ctx context.Context, tx *txEntity.Transaction, reasons []entity.HoldReason, requestedBy uuid.UUID) (*entity.Approval, error) {
+	policy, err := uc.GetPolicy(ctx, tx.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.statusTransitions.Transition(ctx, txEntity.StatusTransitionRequest{
+		TransactionID: tx.Id,
+		To:            txEntity.PENDING_APPROVAL,
+		Source:        txEntity.StatusSourceWithdrawalApproval,
+		Actor:         requestedBy.String(),
+		Reason:        "held by payout policy: " + joinReasons(reasons),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to hold withdrawal: %w", err)
+	}
+	tx.Status = txEntity.PENDING_APPROVAL
+
+	now := time.Now()
+	approval := &entity.Approval{
+		ID:            uuid.New(),
+		TransactionID: tx.Id,
+		MerchantID:    tx.MerchantId,
+		Amount:        tx.BaseAmount,
+		Currency:      tx.WalletCurrency(),
+		Medium:        tx.Medium,
+		PhoneNumber:   tx.PhoneNumber,
+		Reasons:       reasons,
+		Status:        entity.ApprovalPending,
+		RequestedBy:   requestedBy,
+		ExpiresAt:     now.Add(time.Duration(policy.ApprovalTTLHours) * time.Hour),
+		CreatedAt:     now,
+	}
+	if err := uc.repo.CreateApproval(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Withdrawal held for approval", map[string]interface{}{
+		"approval_id":    approval.ID,
+		"transaction_id": tx.Id,
+		"merchant_id":    tx.MerchantId,
+		"amount":         approval.Amount,
+		"reasons":        approval.Reasons,
+		"requested_by":   requestedBy,
+		"expires_at":     approval.ExpiresAt,
+	})
+	uc.notify(ctx, tx, entity.ApprovalPending)
+
+	return approval, nil
+}
+
+func (uc *withdrawalApprovalUseCase) GetApproval(ctx context.Context, merchantID, id uuid.UUID) (*entity.Approval, error) {
+	approval, err := uc.repo.GetApproval(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, entity.ErrApprovalNotFound
+	}
+	return approval, nil
+}
+
+func (uc *withdrawalApprovalUseCase) GetApprovalByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.Approval, error) {
+	return uc.repo.GetApprovalByTransactionID(ctx, transactionID)
+}
+
+func (uc *withdrawalApprovalUseCase) ListApprovals(ctx context.Context, merchantID uuid.UUID, filter entity.ApprovalFilter) (*entity.ApprovalListResponse, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultApprovalListLimit
+	}
+	if filter.Limit > maxApprovalListLimit {
+		filter.Limit = maxApprovalListLimit
+	}
+
+	approvals, err := uc.repo.ListApprovals(ctx, merchantID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if approvals == nil {
+		approvals = []entity.Approval{}
+	}
+
+	return &entity.ApprovalListResponse{Approvals: approvals}, nil
+}
+
+func (uc *withdrawalApprovalUseCase) Approve(ctx context.Context, merchantID, id, approverID uuid.UUID, req *entity.DecisionRequest) (*entity.Approval, error) {
+	approval, err := uc.decide(ctx, merchantID, id, approverID, entity.ApprovalApproved, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.executor.ExecuteApprovedWithdrawal(ctx, approval.TransactionID); err != nil {
+		uc.log.Error("Approved withdrawal could not be sent", map[string]interface{}{
+			"approval_id":    approval.ID,
+			"transaction_id": approval.TransactionID,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("withdrawal approved but could not be sent: %w", err)
+	}
+
+	return approval, nil
+}
+
+func (uc *withdrawalApprovalUseCase) Reject(ctx context.Context, merchantID, id, approverID uuid.UUID, req *entity.DecisionRequest) (*entity.Approval, error) {
+	approval, err := uc.decide(ctx, merchantID, id, approverID, entity.ApprovalRejected, req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := "withdrawal rejected by approver"
+	if req.Note != "" {
+		reason += ": " + req.Note
+	}
+	if err := uc.executor.ReleaseHeldWithdrawal(ctx, approval.TransactionID, txEntity.CANCELED, approverID.String(), reason); err != nil {
+		uc.log.Error("Failed to release rejected withdrawal", map[string]interface{}{
+			"approval_id":    approval.ID,
+			"transaction_id": approval.TransactionID,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("withdrawal rejected but its funds could not be released: %w", err)
+	}
+
+	return approval, nil
+}
+
+// decide records an approver's decision on a pending approval
+func (uc *withdrawalApprovalUseCase) decide(ctx context.Context, merchantID, id, approverID uuid.UUID, status entity.ApprovalStatus, note string) (*entity.Approval, error) {
+	if uc.executor == nil {
+		return nil, fmt.Errorf("withdrawal approvals are not available")
+	}
+
+	approval, err := uc.GetApproval(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := approval.Decidable(approverID, now); err != nil {
+		uc.log.Warn("Withdrawal decision refused", map[string]interface{}{
+			"approval_id": approval.ID,
+			"approver_id": approverID,
+			"decision":    status,
+			"reason":      err.Error(),
+		})
+		return nil, err
+	}
+
+	approval.Status = status
+	approval.DecidedBy = &approverID
+	approval.DecisionNote = note
+	approval.DecidedAt = &now
+	if err := uc.repo.Decide(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("Withdrawal decided", map[string]interface{}{
+		"approval_id":    approval.ID,
+		"transaction_id": approval.TransactionID,
+		"merchant_id":    merchantID,
+		"decision":       status,
+		"approver_id":    approverID,
+		"requested_by":   approval.RequestedBy,
+		"note":           note,
+	})
+	uc.notifyTransaction(ctx, approval.TransactionID, status)
+
+	return approval, nil
+}
+
+func (uc *withdrawalApprovalUseCase) ExpireOverdue(ctx context.Context) error {
+	if uc.executor == nil {
+		return nil
+	}
+
+	now := time.Now()
+	approvals, err := uc.repo.ListExpired(ctx, now, expirySweepBatch)
+	if err != nil {
+		return err
+	}
+
+	expired := 0
+	for i := range approvals {
+		approval := &approvals[i]
+		approval.Status = entity.ApprovalExpired
+		approval.DecidedAt = &now
+		if err := uc.repo.Decide(ctx, approval); err != nil {
+			if !errors.Is(err, entity.ErrApprovalDecided) {
+				uc.log.Error("Failed to expire withdrawal approval", map[string]interface{}{
+					"approval_id": approval.ID,
+					"error":       err.Error(),
+				})
+			}
+			// Decided concurrently, nothing to release
+			continue
+		}
+
+		if err := uc.executor.ReleaseHeldWithdrawal(ctx, approval.TransactionID, txEntity.EXPIRED, "withdrawal_approval_expiry", "approval window closed without a decision"); err != nil {
+			uc.log.Error("Failed to release expired withdrawal", map[string]interface{}{
+				"approval_id":    approval.ID,
+				"transaction_id": approval.TransactionID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+
+		uc.log.Info("Withdrawal approval expired", map[string]interface{}{
+			"approval_id":    approval.ID,
+			"transaction_id": approval.TransactionID,
+			"merchant_id":    approval.MerchantID,
+			"expires_at":     approval.ExpiresAt,
+		})
+		uc.notifyTransaction(ctx, approval.TransactionID, entity.ApprovalExpired)
+		expired++
+	}
+
+	if len(approvals) > 0 {
+		uc.log.Info("Completed expiring withdrawal approvals", map[string]interface{}{
+			"total_found":   len(approvals),
+			"total_expired": expired,
+		})
+	}
+
+	return nil
+}
+
+func (uc *withdrawalApprovalUseCase) notifyTransaction(ctx context.Context, transactionID uuid.UUID, status entity.ApprovalStatus) {
+	if uc.notifier == nil {
+		return
+	}
+
+	tx, err := uc.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		uc.log.Error("Failed to load withdrawal for notification", map[string]interface{}{
+			"transaction_id": transactionID,
+			"error":          err.Error(),
+		})
+		return
+	}
+	uc.notify(ctx, tx, status)
+}
+
+// notify never fails the flow; the decision is already recorded
+func (uc *withdrawalApprovalUseCase) notify(ctx context.Context, tx *txEntity.Transaction, status entity.ApprovalStatus) {
+	if uc.notifier == nil {
+		return
+	}
+
+	if err := uc.notifier.NotifyWithdrawalApproval(ctx, tx, string(status)); err != nil {
+		uc.log.Error("Failed to send withdrawal approval notification", map[string]interface{}{
+			"transaction_id": tx.Id,
+			"status":         status,
+			"error":          err.Error(),
+		})
+	}
+}
+
+func joinReasons(reasons []entity.HoldReason) string {
+	values := make([]string, len(reasons))
+	for i, reason := range reasons {
+		values[i] = string(reason)
+	}
+	return strings.Join(values, ", ")
+}